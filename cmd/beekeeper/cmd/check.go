@@ -2,18 +2,45 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/ethersphere/beekeeper/pkg/bee"
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/chaos"
+	"github.com/ethersphere/beekeeper/pkg/check/restarts"
 	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/ethersphere/beekeeper/pkg/cost"
+	"github.com/ethersphere/beekeeper/pkg/github"
 	"github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/report"
 	"github.com/ethersphere/beekeeper/pkg/tracing"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/push"
 	"github.com/spf13/cobra"
 )
 
+// report flags are shared by the check command and the report writers
+const (
+	optionNameBaseline           = "baseline"
+	optionNameBaselineThreshold  = "baseline-threshold"
+	optionNameBaselineThresholds = "baseline-thresholds"
+	optionNameGitHubAPIURL       = "github-api-url"
+	optionNameGitHubRepo         = "github-repo"
+	optionNameGitHubSHA          = "github-sha"
+	optionNameGitHubToken        = "github-token"
+	optionNameHTMLReport         = "html-report"
+	optionNameInfluxDBToken      = "influxdb-token"
+	optionNameInfluxDBURL        = "influxdb-url"
+	optionNameJSONReport         = "json-report"
+	optionNameJUnitReport        = "junit-report"
+	optionNameResultsDB          = "results-db"
+	optionNameResultsDBDriver    = "results-db-driver"
+)
+
 func (c *command) initCheckCmd() (err error) {
 	const (
 		optionNameClusterName          = "cluster-name"
@@ -23,6 +50,18 @@ func (c *command) initCheckCmd() (err error) {
 		optionNameSeed                 = "seed"
 		optionNameTimeout              = "timeout"
 		optionNameMetricsPusherAddress = "metrics-pusher-address"
+		optionNameDetectRestarts       = "detect-restarts"
+		optionNameFailOnRestarts       = "fail-on-restarts"
+		optionNameFailFast             = "fail-fast"
+		optionNameRunManifest          = "run-manifest"
+		optionNameDeleteCluster        = "delete-cluster"
+		optionNamePreserveOnFailure    = "preserve-on-failure"
+		optionNameArtifactsDir         = "artifacts-dir"
+		optionNameNodeLogs             = "node-logs"
+		optionNameNodeLogsTail         = "node-logs-tail"
+		optionNameDebugState           = "debug-state"
+		optionNameCostAccounting       = "cost-accounting"
+		optionNameRebroadcastAfter     = "rebroadcast-after"
 		// TODO: optionNameStages         = "stages"
 	)
 
@@ -31,6 +70,10 @@ func (c *command) initCheckCmd() (err error) {
 		Short: "runs integration tests on a Bee cluster",
 		Long:  `runs integration tests on a Bee cluster.`,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if (c.globalConfig.GetBool(optionNameNodeLogs) || c.globalConfig.GetBool(optionNameDebugState)) && c.globalConfig.GetString(optionNameArtifactsDir) == "" {
+				return fmt.Errorf("%s and %s require %s", optionNameNodeLogs, optionNameDebugState, optionNameArtifactsDir)
+			}
+
 			ctx, cancel := context.WithTimeout(cmd.Context(), c.globalConfig.GetDuration(optionNameTimeout))
 			defer cancel()
 
@@ -47,20 +90,41 @@ func (c *command) initCheckCmd() (err error) {
 			}
 
 			var (
-				metricsPusher  *push.Pusher
-				metricsEnabled = c.globalConfig.GetBool(optionNameMetricsEnabled)
-				cleanup        func()
+				metricsPusher   *push.Pusher
+				metricsRegistry *prometheus.Registry
+				metricsEnabled  = c.globalConfig.GetBool(optionNameMetricsEnabled)
+				cleanup         func()
 			)
 
-			if metricsEnabled {
+			// pushgateway is not needed when metrics are exported otherwise
+			if metricsEnabled && c.globalConfig.GetString(optionNameMetricsPusherAddress) != "" {
 				metricsPusher, cleanup = newMetricsPusher(c.globalConfig.GetString(optionNameMetricsPusherAddress), cfgCluster.GetNamespace(), c.logger)
 				// cleanup executes when the calling context terminates
 				defer cleanup()
 			}
 
+			// metrics are scraped from the server and exported over OTLP
+			// besides being pushed
+			if metricsEnabled {
+				metricsRegistry, cleanup, err = c.newMetricsExporters(cfgCluster.GetNamespace())
+				if err != nil {
+					return err
+				}
+				defer cleanup()
+			}
+
+			// teardown executes before metrics are flushed by the cleanup,
+			// the command context is limited by the run deadline
+			defer c.teardownOnDeadline(cmd.Context(), cluster)
+
 			// logger metrics
 			if l, ok := c.logger.(metrics.Reporter); ok && metricsEnabled {
-				metrics.RegisterCollectors(metricsPusher, l.Report()...)
+				registerMetrics(metricsPusher, metricsRegistry, l.Report()...)
+			}
+
+			// bee client request timing metrics
+			if metricsEnabled {
+				registerMetrics(metricsPusher, metricsRegistry, bee.Report()...)
 			}
 
 			// tracing
@@ -83,13 +147,119 @@ func (c *command) initCheckCmd() (err error) {
 				Seed: c.globalConfig.GetInt64(optionNameSeed),
 			}
 
-			// run checks
+			// restarts watcher, it watches failures of nodes too, so that
+			// checks fail fast
+			var (
+				watcher        *restarts.Watcher
+				detectRestarts = c.globalConfig.GetBool(optionNameDetectRestarts)
+				failFast       = c.globalConfig.GetBool(optionNameFailFast)
+			)
+			if detectRestarts || failFast {
+				watcher = restarts.NewWatcher(cluster, c.logger)
+				if metricsEnabled {
+					registerMetrics(metricsPusher, metricsRegistry, watcher.Report()...)
+				}
+			}
+			if failFast {
+				if err := watcher.WatchFailures(ctx); err != nil {
+					if !errors.Is(err, orchestration.ErrNotSupported) {
+						c.logger.Warningf("watching node failures: %v", err)
+					}
+					failFast = false
+				}
+			}
+
+			// cost tracker
+			var costs *cost.Tracker
+			if c.globalConfig.GetBool(optionNameCostAccounting) {
+				costs = cost.NewTracker(cluster, c.swapClient, c.logger)
+				if metricsEnabled {
+					registerMetrics(metricsPusher, metricsRegistry, costs.Report()...)
+				}
+			}
+
+			// structured results of the checks are summarized when the run
+			// ends and recorded in the run manifest together with the options
+			// the checks ran with
+			var (
+				results       []beekeeper.Result
+				regressions   []report.Regression
+				start         = time.Now()
+				checkOptions  = make(map[string]interface{})
+				checkAttempts = make(map[string][]beekeeper.Result) // failed attempts, replayed from the manifest
+				manifest      *runManifest
+			)
+			if c.globalConfig.GetString(optionNameRunManifest) != "" {
+				manifest = c.newRunManifest(ctx, cmd.Flags(), c.globalConfig.GetString(optionNameClusterName), cluster, checkGlobalConfig.Seed)
+			}
+
+			// cluster is deleted or preserved after the results are summarized
+			if c.globalConfig.GetBool(optionNameDeleteCluster) || c.globalConfig.GetBool(optionNamePreserveOnFailure) {
+				defer func() {
+					// the run context may be done already
+					ctx, cancel := context.WithTimeout(context.Background(), c.globalConfig.GetDuration(optionNameTeardownTimeout))
+					defer cancel()
+					c.finishCluster(ctx, cluster, c.globalConfig.GetString(optionNameClusterName), cfgCluster.GetNamespace(), results, err,
+						c.globalConfig.GetBool(optionNameDeleteCluster), c.globalConfig.GetBool(optionNamePreserveOnFailure))
+				}()
+			}
+			defer func() {
+				c.logCheckResults(results)
+				run := c.newReportRun(cluster, start, results, checkOptions)
+				run.Regressions = regressions
+				c.writeReports(run)
+				c.storeResults(run)
+				c.writeInfluxDB(run)
+				c.notify(run)
+				if manifest == nil {
+					return
+				}
+				for _, r := range results {
+					checkConfig := c.config.Checks[r.Name]
+					manifest.addCheck(checkConfig.Type, checkConfig.Timeout, checkOptions[r.Name], r, checkAttempts[r.Name])
+				}
+				if err := manifest.write(c.globalConfig.GetString(optionNameRunManifest)); err != nil {
+					c.logger.Errorf("run manifest: %v", err)
+					return
+				}
+				c.logger.Infof("run manifest written to %s", c.globalConfig.GetString(optionNameRunManifest))
+			}()
+
+			// checks are reported as check runs of a GitHub commit
+			githubRuns, err := c.newCheckRuns()
+			if err != nil {
+				return err
+			}
+
+			// order checks by their dependencies
+			var checkNames []string
 			for _, checkName := range c.globalConfig.GetStringSlice(optionNameChecks) {
-				checkName = strings.TrimSpace(checkName)
+				checkNames = append(checkNames, strings.TrimSpace(checkName))
+			}
+			checkOrder, err := c.config.CheckOrder(checkNames)
+			if err != nil {
+				return err
+			}
+
+			// run checks, a failed check fails the run but only the checks
+			// that depend on it are skipped
+			failed := make(map[string]bool)
+			var failures []string
+			for _, checkName := range checkOrder {
 				// get configuration
-				checkConfig, ok := c.config.Checks[checkName]
-				if !ok {
-					return fmt.Errorf("check '%s' doesn't exist", checkName)
+				checkConfig := c.config.Checks[checkName]
+
+				if dep, ok := failedDependency(checkConfig.DependsOn, failed); ok {
+					failed[checkName] = true
+					r := beekeeper.Result{
+						Name:   checkName,
+						Status: beekeeper.StatusSkipped,
+						Error:  fmt.Sprintf("skipped due to dependency failure: %s", dep),
+					}
+					results = append(results, r)
+					githubRuns.complete(r)
+					c.logger.Infof("check %s skipped due to dependency failure: %s", checkName, dep)
+					continue
 				}
 
 				// choose check type
@@ -103,40 +273,108 @@ func (c *command) initCheckCmd() (err error) {
 				if err != nil {
 					return fmt.Errorf("creating check %s options: %w", checkName, err)
 				}
+				checkOptions[checkName] = o
 
 				// create check
-				chk := check.NewAction(c.logger)
+				logger, err := c.checkLogger(checkName, checkConfig)
+				if err != nil {
+					return err
+				}
+				chk := check.NewAction(logger)
 				if r, ok := chk.(metrics.Reporter); ok && metricsEnabled {
-					metrics.RegisterCollectors(metricsPusher, r.Report()...)
+					registerMetrics(metricsPusher, metricsRegistry, r.Report()...)
 				}
 				chk = beekeeper.NewActionMiddleware(tracer, chk, checkName)
 
-				if checkConfig.Timeout != nil {
-					ctx, cancel = context.WithTimeout(ctx, *checkConfig.Timeout)
-					defer cancel()
+				if detectRestarts {
+					if err := watcher.Begin(ctx, checkName, fmt.Sprintf("%s %+v", checkConfig.Type, o)); err != nil {
+						return fmt.Errorf("check %s: restarts watcher: %w", checkName, err)
+					}
 				}
 
-				c.logger.Infof("running check: %s", checkName)
+				c.rebroadcastStuckTransactions(ctx, cluster, c.globalConfig.GetDuration(optionNameRebroadcastAfter), checkName)
 
-				ch := make(chan error, 1)
-				go func() {
-					ch <- chk.Run(ctx, cluster, o)
-					close(ch)
-				}()
+				c.logger.Infof("running check: %s", checkName)
+				githubRuns.start(ctx, checkName)
+				c.beginCosts(ctx, costs, checkName)
 
-				select {
-				case <-ctx.Done():
-					deadline, ok := ctx.Deadline()
-					if ok {
-						return fmt.Errorf("running check %s: %w: deadline %v", checkName, ctx.Err(), deadline)
+				// checks are canceled by failures of nodes, with the failure
+				// as the cause
+				checkCtx, cancelCheck := context.WithCancelCause(ctx)
+				stopFailFast := func() {}
+				if failFast {
+					stopFailFast = watcher.FailFast(checkName, cancelCheck)
+				}
+				// chaos actions disrupt nodes while the check runs, their
+				// failures fail checks that pass
+				stopChaos := chaos.Start(checkCtx, cluster, checkConfig.ChaosActions(logger)...)
+				r, attempts, err := c.runCheck(checkCtx, cluster, chk, checkName, checkConfig, o)
+				checkAttempts[checkName] = attempts
+				if chaosErr := stopChaos(); chaosErr != nil && err == nil {
+					err = chaosErr
+					r.Status = beekeeper.StatusFailed
+					r.Error = err.Error()
+				}
+				var failure orchestration.NodeFailure
+				if errors.As(context.Cause(checkCtx), &failure) {
+					err = fmt.Errorf("node failure: %w", failure)
+					r.Status = beekeeper.StatusFailed
+					r.Error = err.Error()
+				}
+				stopFailFast()
+				cancelCheck(nil)
+				r.Name = checkName
+				// the phase of the check ends whether it passed or not, so
+				// that restarts during failed checks are reported too
+				var restartEvents []restarts.Event
+				if detectRestarts {
+					var watchErr error
+					restartEvents, watchErr = watcher.End(ctx)
+					if watchErr != nil {
+						if err != nil {
+							c.logger.Errorf("check %s: restarts watcher: %v", checkName, watchErr)
+						} else {
+							err = fmt.Errorf("restarts watcher: %w", watchErr)
+							r.Status = beekeeper.StatusFailed
+							r.Error = err.Error()
+						}
 					}
-					return fmt.Errorf("running check %s: %w", checkName, ctx.Err())
-				case err = <-ch:
-					if err != nil {
+				}
+				c.endCosts(ctx, costs, &r)
+				if err != nil && c.globalConfig.GetBool(optionNameNodeLogs) {
+					c.captureNodeLogs(cluster, c.globalConfig.GetString(optionNameArtifactsDir), c.globalConfig.GetInt64(optionNameNodeLogsTail), &r)
+				}
+				if err != nil && c.globalConfig.GetBool(optionNameDebugState) {
+					c.captureDebugState(cluster, c.globalConfig.GetString(optionNameArtifactsDir), &r)
+				}
+				results = append(results, r)
+				githubRuns.complete(r)
+				if len(restartEvents) > 0 && c.globalConfig.GetBool(optionNameFailOnRestarts) {
+					return fmt.Errorf("running check %s: %d nodes restarted during the check", checkName, len(restartEvents))
+				}
+				if err != nil {
+					if ctx.Err() != nil {
 						return fmt.Errorf("running check %s: %w", checkName, err)
 					}
-					c.logger.Infof("%s check completed successfully", checkName)
+					c.logger.Errorf("running check %s: %v", checkName, err)
+					failed[checkName] = true
+					failures = append(failures, checkName)
+					continue
 				}
+				c.logger.Infof("%s check completed successfully", checkName)
+			}
+
+			// measurements of failed checks are compared too, regressions
+			// may explain the failures
+			if baseline := c.globalConfig.GetString(optionNameBaseline); baseline != "" {
+				regressions, err = c.compareBaseline(baseline, results)
+				if err != nil {
+					failures = append(failures, err.Error())
+				}
+			}
+
+			if len(failures) > 0 {
+				return fmt.Errorf("checks failed: %s", strings.Join(failures, ", "))
 			}
 			return nil
 		},
@@ -144,14 +382,162 @@ func (c *command) initCheckCmd() (err error) {
 	}
 
 	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
-	cmd.Flags().String(optionNameMetricsPusherAddress, "pushgateway.staging.internal", "prometheus metrics pusher address")
+	cmd.Flags().String(optionNameBaseline, "", "JSON results file of a previous run to compare measurements with, the run fails on regressions exceeding the thresholds")
+	cmd.Flags().Float64(optionNameBaselineThreshold, 0.2, "relative change of measurements against the baseline that is tolerated, durations and costs regress when they grow, other measurements when they shrink")
+	cmd.Flags().StringToString(optionNameBaselineThresholds, nil, "thresholds of single measurements overriding the default one, as check/measurement=threshold")
+	cmd.Flags().String(optionNameMetricsPusherAddress, "pushgateway.staging.internal", "prometheus metrics pusher address, empty to disable")
 	cmd.Flags().Bool(optionNameCreateCluster, false, "creates cluster before executing checks")
+	cmd.Flags().Bool(optionNameDeleteCluster, false, "deletes cluster after executing checks")
+	cmd.Flags().Bool(optionNamePreserveOnFailure, false, "if any check fails, skip cluster deletion, label the namespace and print pods and references for inspection")
 	cmd.Flags().StringSlice(optionNameChecks, []string{"pingpong"}, "list of checks to execute")
 	cmd.Flags().Bool(optionNameMetricsEnabled, true, "enable metrics")
+	addMetricsExportFlags(cmd, "checks")
 	cmd.Flags().Int64(optionNameSeed, -1, "seed, -1 for random")
 	cmd.Flags().Duration(optionNameTimeout, 30*time.Minute, "timeout")
+	cmd.Flags().Bool(optionNameCostAccounting, false, "account gas and BZZ spent and on-chain transactions sent by the nodes during every check, transactions are counted only with geth-url set")
+	cmd.Flags().Bool(optionNameDetectRestarts, false, "watch Bee node restarts and OOM kills during each check")
+	cmd.Flags().String(optionNameGitHubAPIURL, github.DefaultAPIURL, "GitHub API URL, for GitHub Enterprise")
+	cmd.Flags().String(optionNameGitHubRepo, "", "GitHub repository, as owner/name, of the commit to report checks to as check runs")
+	cmd.Flags().String(optionNameGitHubSHA, "", "GitHub commit to report checks to as check runs, like the commit of the tested Bee image")
+	cmd.Flags().String(optionNameGitHubToken, "", "GitHub token with checks write permission, can be set with BEEKEEPER_GITHUB_TOKEN environment variable")
+	cmd.Flags().String(optionNameHTMLReport, "", "directory to write the HTML report of the run to, or a pre-signed object storage URL to upload it to")
+	cmd.Flags().String(optionNameInfluxDBToken, "", "InfluxDB API token, can be set with BEEKEEPER_INFLUXDB_TOKEN environment variable")
+	cmd.Flags().String(optionNameInfluxDBURL, "", "InfluxDB write URL to write check results and measurements to in line protocol, like http://influxdb:8086/api/v2/write?org=bee&bucket=beekeeper, empty to disable")
+	cmd.Flags().String(optionNameJSONReport, "", "file to write the JSON results of the checks to, or a pre-signed object storage URL to upload it to")
+	cmd.Flags().String(optionNameJUnitReport, "", "file to write the JUnit XML report of the checks to, every check is a test suite with its steps as test cases, or a pre-signed object storage URL to upload it to")
+	cmd.Flags().Bool(optionNameFailOnRestarts, false, "fail the run if any node restarted during a check, requires detect-restarts")
+	cmd.Flags().Bool(optionNameFailFast, false, "watch pods of the nodes during each check and fail the check as soon as a node is OOM killed or crashloops, with events of its pod, kubernetes clusters only")
+	cmd.Flags().String(optionNameArtifactsDir, "", "directory to write run artifacts, like node logs, to, required by node-logs and debug-state")
+	cmd.Flags().Bool(optionNameDebugState, false, "capture profiles, topology, reserve state and pending transactions of the nodes involved in a failed check to the artifacts-dir")
+	cmd.Flags().Bool(optionNameNodeLogs, false, "capture logs of the nodes involved in a failed check to the artifacts-dir")
+	cmd.Flags().Int64(optionNameNodeLogsTail, 1000, "number of the last log lines captured from every node, 0 for all")
+	cmd.Flags().Duration(optionNameRebroadcastAfter, 0, "before every check, rebroadcast on-chain transactions of the nodes pending for longer than the duration, 0 to disable")
+	cmd.Flags().String(optionNameResultsDB, "", "data source name of the SQL database to store check results and measurements in, empty to disable")
+	cmd.Flags().String(optionNameResultsDBDriver, "postgres", "database/sql driver of the results database, postgres or sqlite")
+	cmd.Flags().String(optionNameRunManifest, "", "file to write the run manifest with seeds, cluster configuration without secrets, bee versions, check options and results to, empty to disable")
+
+	cmd.AddCommand(c.initCheckListCmd())
+	cmd.AddCommand(c.initCheckReplayCmd())
 
 	c.root.AddCommand(cmd)
 
 	return nil
 }
+
+// failedDependency returns the first dependency that failed or was skipped
+func failedDependency(dependsOn []string, failed map[string]bool) (string, bool) {
+	for _, dep := range dependsOn {
+		if failed[dep] {
+			return dep, true
+		}
+	}
+	return "", false
+}
+
+// runCheck runs the check, running it again after a failure as many times as
+// the check retries are configured. Every attempt is limited by the check
+// timeout and the delay before a retry doubles with every attempt. Results
+// of failed attempts are returned together with the result of the last one.
+func (c *command) runCheck(ctx context.Context, cluster orchestration.Cluster, chk beekeeper.Action, checkName string, checkConfig config.Check, o interface{}) (r beekeeper.Result, failed []beekeeper.Result, err error) {
+	retries, backoff := checkConfig.GetRetries(), checkConfig.GetRetryBackoff()
+
+	for attempt := 0; ; attempt++ {
+		r, err = runCheckAttempt(ctx, cluster, chk, checkConfig.Timeout, o)
+		if err != nil {
+			failed = append(failed, r)
+		}
+		// a check that did not stop would run together with its retry
+		if err == nil || attempt >= retries || ctx.Err() != nil || errors.Is(err, errCheckNotStopped) {
+			return r, failed, err
+		}
+
+		delay := backoff << attempt
+		c.logger.Infof("check %s: attempt %d of %d failed: %v, retrying in %s", checkName, attempt+1, retries+1, err, delay)
+
+		select {
+		case <-ctx.Done():
+			return r, failed, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// checkStopTimeout is how long a check is waited for to return after its
+// attempt is canceled
+var checkStopTimeout = time.Minute
+
+// errCheckNotStopped is returned with the error of a canceled attempt of a
+// check that did not return in the check stop timeout
+var errCheckNotStopped = errors.New("check did not stop")
+
+// runCheckAttempt runs the check once, limited by the timeout if it is set.
+// A canceled attempt returns after the check returns, so that it does not run
+// together with the next attempt, or with errCheckNotStopped if it does not
+// return in the check stop timeout.
+func runCheckAttempt(ctx context.Context, cluster orchestration.Cluster, chk beekeeper.Action, timeout *time.Duration, o interface{}) (beekeeper.Result, error) {
+	var cancel context.CancelFunc
+	if timeout != nil {
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	type runResult struct {
+		result beekeeper.Result
+		err    error
+	}
+	start := time.Now()
+	ch := make(chan runResult, 1)
+	go func() {
+		r, err := beekeeper.RunResult(ctx, cluster, chk, o)
+		ch <- runResult{result: r, err: err}
+		close(ch)
+	}()
+
+	select {
+	case <-ctx.Done():
+		// the cause is the failure of a node if it canceled the check
+		err := context.Cause(ctx)
+		if deadline, ok := ctx.Deadline(); ok && errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%w: deadline %v", ctx.Err(), deadline)
+		}
+		cancel()
+		select {
+		case <-ch:
+		case <-time.After(checkStopTimeout):
+			err = fmt.Errorf("%w: %w in %s", err, errCheckNotStopped, checkStopTimeout)
+		}
+		return beekeeper.Result{
+			Status:   beekeeper.StatusFailed,
+			Error:    err.Error(),
+			Start:    start,
+			Duration: time.Since(start),
+		}, err
+	case rr := <-ch:
+		return rr.result, rr.err
+	}
+}
+
+// logCheckResults logs a summary of structured check results
+func (c *command) logCheckResults(results []beekeeper.Result) {
+	for _, r := range results {
+		c.logger.Infof("check %s %s in %s", r.Name, r.Status, r.Duration.Round(time.Millisecond))
+		for _, s := range r.Steps {
+			if s.Error != "" {
+				c.logger.Infof("  step %s %s in %s: %s", s.Name, s.Status, s.Duration.Round(time.Millisecond), s.Error)
+				continue
+			}
+			c.logger.Infof("  step %s %s in %s", s.Name, s.Status, s.Duration.Round(time.Millisecond))
+		}
+		for _, m := range r.Measurements {
+			c.logger.Infof("  measurement %s: %g %s", m.Name, m.Value, m.Unit)
+		}
+		for _, a := range r.Artifacts {
+			c.logger.Infof("  artifact %s: %s", a.Name, a.Path)
+		}
+		if r.Error != "" {
+			c.logger.Infof("  error: %s", r.Error)
+		}
+	}
+}
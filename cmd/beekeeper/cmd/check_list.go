@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+func (c *command) initCheckListCmd() *cobra.Command {
+	const (
+		optionNameDescribe = "describe"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list [check type]...",
+		Short: "lists available check types",
+		Long: `Lists available check types, or only the given ones.
+With --describe, options of every check type are printed with their types and default values.`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			checkTypes := args
+			if len(checkTypes) == 0 {
+				for name := range config.Checks {
+					checkTypes = append(checkTypes, name)
+				}
+				sort.Strings(checkTypes)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			for _, checkType := range checkTypes {
+				if _, ok := config.Checks[checkType]; !ok {
+					return fmt.Errorf("check %s not implemented", checkType)
+				}
+				fmt.Fprintln(w, checkType)
+
+				if !c.globalConfig.GetBool(optionNameDescribe) {
+					continue
+				}
+				options, err := config.DescribeCheck(checkType)
+				if err != nil {
+					return err
+				}
+				for _, o := range options {
+					fmt.Fprintf(w, "  %s\t%s\t%s\n", o.Name, o.Type, o.Default)
+				}
+			}
+
+			return w.Flush()
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return c.globalConfig.BindPFlags(cmd.Flags())
+		},
+	}
+
+	cmd.Flags().Bool(optionNameDescribe, false, "print options of check types with their types and default values")
+
+	return cmd
+}
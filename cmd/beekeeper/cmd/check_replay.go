@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+func (c *command) initCheckReplayCmd() *cobra.Command {
+	const (
+		optionNameClusterName = "cluster-name"
+		optionNameChecks      = "checks"
+		optionNameTimeout     = "timeout"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "replay <manifest>",
+		Short: "replays failed attempts of checks of a recorded run",
+		Long: `Replays failed attempts of checks of a run recorded in a run manifest against the current cluster.
+The last failed attempt of every check is run once, without retries, with the options, seed and timeout
+it ran with, so it selects the same nodes and generates the same data as long as the cluster has the
+same nodes. By default checks with failed attempts are replayed, even if a later attempt passed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx, cancel := context.WithTimeout(cmd.Context(), c.globalConfig.GetDuration(optionNameTimeout))
+			defer cancel()
+
+			manifest, err := readRunManifest(args[0])
+			if err != nil {
+				return err
+			}
+			c.logger.Infof("replaying run of beekeeper %s started at %s", manifest.Beekeeper, manifest.Start)
+
+			// choose checks
+			var replay []manifestCheck
+			names := c.globalConfig.GetStringSlice(optionNameChecks)
+			for _, mc := range manifest.Checks {
+				_, _, failed := mc.failedAttempt()
+				if (len(names) == 0 && failed) || contains(names, mc.Name) {
+					replay = append(replay, mc)
+				}
+			}
+			if len(replay) == 0 {
+				return fmt.Errorf("no checks to replay in run manifest %s", args[0])
+			}
+
+			clusterName := c.globalConfig.GetString(optionNameClusterName)
+			if clusterName == "" {
+				clusterName = manifest.ClusterName
+			}
+			cluster, err := c.setupCluster(ctx, clusterName, c.config, false)
+			if err != nil {
+				return fmt.Errorf("cluster setup: %w", err)
+			}
+
+			// nodes are selected by the seed from sorted node names and
+			// overlays, differences make the replay select other nodes
+			overlays, err := cluster.FlattenOverlays(ctx)
+			if err != nil {
+				return fmt.Errorf("overlays: %w", err)
+			}
+			for name, n := range manifest.Nodes {
+				overlay, ok := overlays[name]
+				switch {
+				case !ok:
+					c.logger.Warningf("node %s of the recorded run is not in the cluster", name)
+				case n.Overlay != "" && overlay.String() != n.Overlay:
+					c.logger.Warningf("node %s has overlay %s, recorded run had %s", name, overlay, n.Overlay)
+				}
+			}
+			for name := range overlays {
+				if _, ok := manifest.Nodes[name]; !ok {
+					c.logger.Warningf("node %s is not in the recorded run", name)
+				}
+			}
+
+			var results []beekeeper.Result
+			defer func() {
+				c.logCheckResults(results)
+			}()
+
+			var failures []string
+			for _, mc := range replay {
+				data, err := json.Marshal(mc.Options)
+				if err != nil {
+					return fmt.Errorf("check %s: marshal options: %w", mc.Name, err)
+				}
+				o, err := config.RestoreCheckOptions(mc.Type, data)
+				if err != nil {
+					return fmt.Errorf("check %s: %w", mc.Name, err)
+				}
+
+				if attempt, recorded, ok := mc.failedAttempt(); ok {
+					c.logger.Infof("replaying attempt %d of check %s, recorded result %s: %s", attempt, mc.Name, recorded.Status, recorded.Error)
+				} else {
+					c.logger.Infof("replaying check %s, recorded result %s", mc.Name, mc.Result.Status)
+				}
+
+				// the check runs at the log verbosity configured for it, if
+				// it is still configured
+				logger, err := c.checkLogger(mc.Name, c.config.Checks[mc.Name])
+				if err != nil {
+					return err
+				}
+				chk := config.Checks[mc.Type].NewAction(logger)
+				r, err := runCheckAttempt(ctx, cluster, chk, mc.Timeout, o)
+				r.Name = mc.Name
+				results = append(results, r)
+				if err != nil {
+					if ctx.Err() != nil {
+						return fmt.Errorf("replaying check %s: %w", mc.Name, err)
+					}
+					c.logger.Errorf("replaying check %s: %v", mc.Name, err)
+					failures = append(failures, mc.Name)
+					continue
+				}
+				c.logger.Infof("%s check replayed successfully", mc.Name)
+			}
+
+			if len(failures) > 0 {
+				return fmt.Errorf("replayed checks failed: %v", failures)
+			}
+			return nil
+		},
+		PreRunE: c.preRunE,
+	}
+
+	cmd.Flags().String(optionNameClusterName, "", "cluster name, defaults to the cluster of the recorded run")
+	cmd.Flags().StringSlice(optionNameChecks, nil, "list of checks to replay, defaults to checks with failed attempts")
+	cmd.Flags().Duration(optionNameTimeout, 30*time.Minute, "timeout")
+
+	return cmd
+}
+
+// contains reports whether the name is in the list
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
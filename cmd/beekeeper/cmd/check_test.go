@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// testCheck fails its runs until they are done, unless they are set to pass,
+// and records whether runs overlap
+type testCheck struct {
+	mu       sync.Mutex
+	pass     []bool        // results of runs, the last one repeats
+	stopping time.Duration // time runs take to return after they are canceled
+	ignore   bool          // runs do not return when they are canceled
+	runs     int
+	running  int
+	overlaps int
+}
+
+func (c *testCheck) Run(ctx context.Context, cluster orchestration.Cluster, o interface{}) error {
+	c.mu.Lock()
+	pass := c.pass[len(c.pass)-1]
+	if c.runs < len(c.pass) {
+		pass = c.pass[c.runs]
+	}
+	c.runs++
+	c.running++
+	if c.running > 1 {
+		c.overlaps++
+	}
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.running--
+		c.mu.Unlock()
+	}()
+
+	if pass {
+		return nil
+	}
+	if c.ignore {
+		time.Sleep(time.Second)
+		return errors.New("failed")
+	}
+	<-ctx.Done()
+	time.Sleep(c.stopping)
+	return ctx.Err()
+}
+
+func TestRunCheckAttempt(t *testing.T) {
+	timeout := 10 * time.Millisecond
+	chk := &testCheck{pass: []bool{false}, stopping: 50 * time.Millisecond}
+
+	r, err := runCheckAttempt(context.Background(), nil, chk, &timeout, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if r.Status != beekeeper.StatusFailed {
+		t.Errorf("got status %s, want %s", r.Status, beekeeper.StatusFailed)
+	}
+
+	// the attempt returns after the check returns
+	chk.mu.Lock()
+	defer chk.mu.Unlock()
+	if chk.running != 0 {
+		t.Error("check runs after its attempt returned")
+	}
+}
+
+func TestRunCheckAttemptNotStopped(t *testing.T) {
+	defer func(d time.Duration) { checkStopTimeout = d }(checkStopTimeout)
+	checkStopTimeout = 10 * time.Millisecond
+
+	timeout := 10 * time.Millisecond
+	chk := &testCheck{pass: []bool{false}, ignore: true}
+
+	_, err := runCheckAttempt(context.Background(), nil, chk, &timeout, nil)
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errCheckNotStopped) {
+		t.Errorf("got error %v, want %v and %v", err, context.DeadlineExceeded, errCheckNotStopped)
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	defer func(d time.Duration) { checkStopTimeout = d }(checkStopTimeout)
+	checkStopTimeout = 100 * time.Millisecond
+
+	c := &command{logger: logging.New(io.Discard, 0, "")}
+	timeout := 20 * time.Millisecond
+	retries := 2
+
+	for _, tc := range []struct {
+		name       string
+		check      *testCheck
+		wantRuns   int
+		wantFailed int
+		wantErr    error
+	}{
+		{
+			name:     "pass",
+			check:    &testCheck{pass: []bool{true}},
+			wantRuns: 1,
+		},
+		{
+			name:       "pass after timeouts",
+			check:      &testCheck{pass: []bool{false, false, true}, stopping: 30 * time.Millisecond},
+			wantRuns:   3,
+			wantFailed: 2,
+		},
+		{
+			name:       "timeouts",
+			check:      &testCheck{pass: []bool{false}, stopping: 30 * time.Millisecond},
+			wantRuns:   3,
+			wantFailed: 3,
+			wantErr:    context.DeadlineExceeded,
+		},
+		{
+			// the check is not retried while it runs
+			name:       "not stopped",
+			check:      &testCheck{pass: []bool{false}, ignore: true},
+			wantRuns:   1,
+			wantFailed: 1,
+			wantErr:    errCheckNotStopped,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			checkConfig := config.Check{Timeout: &timeout, Retries: &retries}
+
+			r, failed, err := c.runCheck(context.Background(), nil, tc.check, "test", checkConfig, nil)
+			if tc.wantErr == nil && err != nil || !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr == nil && r.Status != beekeeper.StatusPassed {
+				t.Errorf("got status %s, want %s", r.Status, beekeeper.StatusPassed)
+			}
+			if len(failed) != tc.wantFailed {
+				t.Errorf("got %d failed attempts, want %d", len(failed), tc.wantFailed)
+			}
+
+			tc.check.mu.Lock()
+			defer tc.check.mu.Unlock()
+			if tc.check.runs != tc.wantRuns {
+				t.Errorf("got %d runs, want %d", tc.check.runs, tc.wantRuns)
+			}
+			if tc.check.overlaps != 0 {
+				t.Errorf("got %d runs overlapping with the previous attempt", tc.check.overlaps)
+			}
+		})
+	}
+}
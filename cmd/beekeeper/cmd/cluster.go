@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ethersphere/beekeeper/pkg/config"
 	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	orchestrationDocker "github.com/ethersphere/beekeeper/pkg/orchestration/docker"
 	orchestrationK8S "github.com/ethersphere/beekeeper/pkg/orchestration/k8s"
+	orchestrationStatic "github.com/ethersphere/beekeeper/pkg/orchestration/static"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -17,10 +20,17 @@ func (c *command) deleteCluster(ctx context.Context, clusterName string, cfg *co
 	}
 
 	clusterOptions := clusterConfig.Export()
-	clusterOptions.K8SClient = c.k8sClient
 	clusterOptions.SwapClient = c.swapClient
 
-	cluster := orchestrationK8S.NewCluster(clusterConfig.GetName(), clusterOptions, c.logger)
+	if clusterOptions.Orchestrator == "static" {
+		c.logger.Warningf("nodes of static cluster %s are not managed by beekeeper, they are not deleted", clusterName)
+		return
+	}
+
+	cluster, err := c.newCluster(clusterConfig.GetName(), clusterOptions)
+	if err != nil {
+		return err
+	}
 
 	// delete node groups
 	for ng, v := range clusterConfig.GetNodeGroups() {
@@ -32,7 +42,11 @@ func (c *command) deleteCluster(ctx context.Context, clusterName string, cfg *co
 
 		if v.Mode == "bootnode" { // TODO: implement standalone mode
 			// register node group
-			cluster.AddNodeGroup(ng, ngConfig.Export())
+			ngOptions, err := c.nodeGroupOptions(v, ngConfig, clusterOptions.Orchestrator)
+			if err != nil {
+				return fmt.Errorf("node group %s: %w", ng, err)
+			}
+			cluster.AddNodeGroup(ng, ngOptions)
 
 			// delete nodes from the node group
 			g, err := cluster.NodeGroup(ng)
@@ -49,16 +63,19 @@ func (c *command) deleteCluster(ctx context.Context, clusterName string, cfg *co
 					return fmt.Errorf("deleting node %s from the node group %s", nName, ng)
 				}
 
-				if deleteStorage && *ngConfig.PersistenceEnabled {
-					pvcName := fmt.Sprintf("data-%s-0", nName)
-					if err := c.k8sClient.PVC.Delete(ctx, pvcName, clusterOptions.Namespace); err != nil {
-						return fmt.Errorf("deleting pvc %s: %w", pvcName, err)
+				if deleteStorage && ngOptions.PersistenceEnabled {
+					if err := c.deleteNodeStorage(ctx, g, nName); err != nil {
+						return err
 					}
 				}
 			}
 		} else {
 			// register node group
-			cluster.AddNodeGroup(ng, ngConfig.Export())
+			ngOptions, err := c.nodeGroupOptions(v, ngConfig, clusterOptions.Orchestrator)
+			if err != nil {
+				return fmt.Errorf("node group %s: %w", ng, err)
+			}
+			cluster.AddNodeGroup(ng, ngOptions)
 
 			// delete nodes from the node group
 			g, err := cluster.NodeGroup(ng)
@@ -75,10 +92,9 @@ func (c *command) deleteCluster(ctx context.Context, clusterName string, cfg *co
 						return fmt.Errorf("deleting node %s from the node group %s", nName, ng)
 					}
 
-					if deleteStorage && *ngConfig.PersistenceEnabled {
-						pvcName := fmt.Sprintf("data-%s-0", nName)
-						if err := c.k8sClient.PVC.Delete(ctx, pvcName, clusterOptions.Namespace); err != nil {
-							return fmt.Errorf("deleting pvc %s: %w", pvcName, err)
+					if deleteStorage && ngOptions.PersistenceEnabled {
+						if err := c.deleteNodeStorage(ctx, g, nName); err != nil {
+							return err
 						}
 					}
 				}
@@ -89,18 +105,34 @@ func (c *command) deleteCluster(ctx context.Context, clusterName string, cfg *co
 						return fmt.Errorf("deleting node %s from the node group %s", nName, ng)
 					}
 
-					if deleteStorage && *ngConfig.PersistenceEnabled {
-						pvcName := fmt.Sprintf("data-%s-0", nName)
-						if err := c.k8sClient.PVC.Delete(ctx, pvcName, clusterOptions.Namespace); err != nil {
-							return fmt.Errorf("deleting pvc %s: %w", pvcName, err)
+					if deleteStorage && ngOptions.PersistenceEnabled {
+						if err := c.deleteNodeStorage(ctx, g, nName); err != nil {
+							return err
 						}
 					}
 				}
+
+				// mined keys are kept as long as the data of the nodes
+				k8sClient, err := c.kubeconfigK8S(v)
+				if err != nil {
+					return err
+				}
+				if deleteStorage && k8sClient != nil && clusterOptions.Orchestrator != "docker" {
+					if err := k8sClient.Secret.Delete(ctx, swarmKeysSecret(ng), clusterConfig.GetNodeGroupNamespace(v)); err != nil {
+						return fmt.Errorf("deleting Swarm keys of the node group %s: %w", ng, err)
+					}
+				}
 			}
 
 		}
 	}
 
+	if dc, ok := cluster.(*orchestrationDocker.Cluster); ok {
+		if err := dc.DeleteNetwork(ctx); err != nil {
+			return fmt.Errorf("deleting network: %w", err)
+		}
+	}
+
 	return
 }
 
@@ -111,12 +143,47 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 	}
 
 	clusterOptions := clusterConfig.Export()
-	clusterOptions.K8SClient = c.k8sClient
 	clusterOptions.SwapClient = c.swapClient
+	if clusterConfig.Funding != nil {
+		clusterOptions.Funding = clusterConfig.Funding.Export()
+	}
 
-	cluster = orchestrationK8S.NewCluster(clusterConfig.GetName(), clusterOptions, c.logger)
+	cluster, err = c.newCluster(clusterConfig.GetName(), clusterOptions)
+	if err != nil {
+		return nil, err
+	}
 	bootnodes := ""
 
+	// nodes of the mesh topology are bootnodes of each other
+	var mesh meshTopology
+	switch clusterConfig.GetTopology() {
+	case "bootnode":
+	case "mesh":
+		if clusterOptions.Orchestrator == "static" {
+			return nil, fmt.Errorf("mesh topology is not supported by the static orchestrator")
+		}
+		// addresses of the mesh resolve within one kubernetes cluster only
+		for ng, v := range clusterConfig.GetNodeGroups() {
+			if c.otherKubernetesCluster(v) {
+				return nil, fmt.Errorf("node group %s: mesh topology is not supported across kubernetes clusters", ng)
+			}
+		}
+		if mesh, err = newMeshTopology(clusterConfig, cfg); err != nil {
+			return nil, fmt.Errorf("mesh topology: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("topology %s is not supported", clusterConfig.GetTopology())
+	}
+
+	// docker networks of namespaces are isolated from each other
+	if clusterOptions.Orchestrator == "docker" {
+		for ng, v := range clusterConfig.GetNodeGroups() {
+			if v.Namespace != "" && v.Namespace != clusterOptions.Namespace {
+				return nil, fmt.Errorf("node group %s: namespaces of node groups are not supported by the docker orchestrator", ng)
+			}
+		}
+	}
+
 	errGroup := new(errgroup.Group)
 
 	for ng, v := range clusterConfig.GetNodeGroups() {
@@ -132,7 +199,11 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 			}
 
 			// add node group to the cluster
-			cluster.AddNodeGroup(ng, ngConfig.Export())
+			ngOptions, err := c.nodeGroupOptions(v, ngConfig, clusterOptions.Orchestrator)
+			if err != nil {
+				return nil, fmt.Errorf("node group %s: %w", ng, err)
+			}
+			cluster.AddNodeGroup(ng, ngOptions)
 
 			// start nodes in the node group
 			g, err := cluster.NodeGroup(ng)
@@ -148,8 +219,9 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 				}
 
 				// set bootnodes
-				bConfig := beeConfig.Export()
-				bConfig.Bootnodes = fmt.Sprintf(node.Bootnodes, clusterConfig.GetNamespace()) // TODO: improve bootnode management, support more than 2 bootnodes
+				nbConfig := beeConfig.Override(node.Overrides)
+				bConfig := nbConfig.Export()
+				bConfig.Bootnodes = fmt.Sprintf(node.Bootnodes, clusterConfig.GetNodeGroupNamespace(v)) // TODO: improve bootnode management, support more than 2 bootnodes
 				bootnodes += bConfig.Bootnodes + " "
 
 				// set NodeOptions
@@ -168,6 +240,9 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 				if len(node.SwarmKey) > 0 {
 					nOptions.SwarmKey = orchestration.EncryptedKey(node.SwarmKey)
 				}
+				nOptions.APIURL = node.APIURL
+				nOptions.DebugAPIURL = node.DebugAPIURL
+				nOptions.Image = node.Image
 
 				errGroup.Go(func() error {
 					if start {
@@ -200,7 +275,10 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 			bConfig := beeConfig.Export()
 			bConfig.Bootnodes = bootnodes
 			// add node group to the cluster
-			ngOptions := ngConfig.Export()
+			ngOptions, err := c.nodeGroupOptions(v, ngConfig, clusterOptions.Orchestrator)
+			if err != nil {
+				return nil, fmt.Errorf("node group %s: %w", ng, err)
+			}
 			ngOptions.BeeConfig = &bConfig
 			cluster.AddNodeGroup(ng, ngOptions)
 
@@ -210,6 +288,16 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 				return nil, err
 			}
 
+			if v.Mode == "discover" {
+				if clusterOptions.Orchestrator != "static" {
+					return nil, fmt.Errorf("node group %s: nodes are discovered by the static orchestrator only", ng)
+				}
+				if err := c.discoverNodes(ctx, g, v, clusterConfig.GetNodeGroupNamespace(v)); err != nil {
+					return nil, fmt.Errorf("discovering nodes of node group %s: %w", ng, err)
+				}
+				continue
+			}
+
 			for i, node := range v.Nodes {
 				// set node name
 				nName := fmt.Sprintf("%s-%d", ng, i)
@@ -230,6 +318,20 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 				if len(node.SwarmKey) > 0 {
 					nOptions.SwarmKey = orchestration.EncryptedKey(node.SwarmKey)
 				}
+				nOptions.APIURL = node.APIURL
+				nOptions.DebugAPIURL = node.DebugAPIURL
+				nOptions.Image = node.Image
+				if node.Overrides != nil {
+					nbConfig := beeConfig.Override(node.Overrides)
+					nConfig := nbConfig.Export()
+					nConfig.Bootnodes = bootnodes
+					nOptions.Config = &nConfig
+				}
+				if mesh != nil {
+					if err := mesh.apply(nName, node.Peers, &nOptions, bConfig); err != nil {
+						return nil, err
+					}
+				}
 
 				errGroup.Go(func() error {
 					if start {
@@ -241,15 +343,31 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 			}
 
 			if len(v.Nodes) == 0 {
+				// mine Swarm keys for nodes to control neighborhood density
+				swarmKeys, err := c.mineSwarmKeys(ctx, ng, v, bConfig.NetworkID, bConfig.Password, clusterOptions.Orchestrator, clusterConfig.GetNodeGroupNamespace(v), start)
+				if err != nil {
+					return nil, fmt.Errorf("mining Swarm keys for node group %s: %w", ng, err)
+				}
+
 				for i := 0; i < v.Count; i++ {
 					// set node name
 					nName := fmt.Sprintf("%s-%d", ng, i)
+					// set NodeOptions
+					nOptions := orchestration.NodeOptions{}
+					if i < len(swarmKeys) {
+						nOptions.SwarmKey = orchestration.EncryptedKey(swarmKeys[i])
+					}
+					if mesh != nil {
+						if err := mesh.apply(nName, nil, &nOptions, bConfig); err != nil {
+							return nil, err
+						}
+					}
 
 					errGroup.Go(func() error {
 						if start {
-							return g.SetupNode(ctx, nName, orchestration.NodeOptions{}, clusterConfig.Funding.Export())
+							return g.SetupNode(ctx, nName, nOptions, clusterConfig.Funding.Export())
 						} else {
-							return g.AddNode(ctx, nName, orchestration.NodeOptions{})
+							return g.AddNode(ctx, nName, nOptions)
 						}
 					})
 				}
@@ -263,3 +381,98 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 
 	return
 }
+
+// newCluster returns the cluster managed by the orchestrator of the cluster
+// options, kubernetes by default, with nodes installed from the Helm chart
+// by the helm orchestrator, or the static cluster of existing nodes
+func (c *command) newCluster(name string, o orchestration.ClusterOptions) (orchestration.Cluster, error) {
+	switch o.Orchestrator {
+	case "", "k8s":
+		o.K8SClient = c.k8sClient
+		return orchestrationK8S.NewCluster(name, o, c.logger), nil
+	case "helm":
+		o.K8SClient = c.k8sClient
+		o.HelmClient = c.helmClient("", "")
+		return orchestrationK8S.NewCluster(name, o, c.logger), nil
+	case "docker":
+		return orchestrationDocker.NewCluster(name, o, c.logger), nil
+	case "static":
+		return orchestrationStatic.NewCluster(name, o, c.logger), nil
+	default:
+		return nil, fmt.Errorf("unknown orchestrator %s", o.Orchestrator)
+	}
+}
+
+// nodeGroupOptions returns checked options of the node group, with clients of
+// its kubernetes cluster if the node group sets a kubeconfig or a context
+func (c *command) nodeGroupOptions(v config.ClusterNodeGroup, profile config.NodeGroup, orchestrator string) (o orchestration.NodeGroupOptions, err error) {
+	o = v.Export(profile)
+	switch o.PersistenceRetentionPolicy {
+	case "", "Retain", "Delete":
+	default:
+		return o, fmt.Errorf("unknown persistence retention policy %s", o.PersistenceRetentionPolicy)
+	}
+	if !c.otherKubernetesCluster(v) {
+		return o, nil
+	}
+
+	if orchestrator == "docker" {
+		return o, fmt.Errorf("kubeconfigs of node groups are not supported by the docker orchestrator")
+	}
+
+	if o.K8SClient, err = c.kubeconfigK8S(v); err != nil {
+		return o, err
+	}
+	if orchestrator == "helm" {
+		o.HelmClient = c.helmClient(v.Kubeconfig, v.KubeContext)
+	}
+
+	return o, nil
+}
+
+// discoverNodes adds nodes of pods in the namespace selected by labels to the
+// node group, in the kubernetes cluster of the node group if it sets a
+// kubeconfig or a context
+func (c *command) discoverNodes(ctx context.Context, g orchestration.NodeGroup, v config.ClusterNodeGroup, namespace string) error {
+	k8sClient, err := c.kubeconfigK8S(v)
+	if err != nil {
+		return err
+	}
+	if k8sClient == nil {
+		return errors.New("kubernetes client is not set")
+	}
+
+	nodes, err := orchestrationStatic.Discover(ctx, k8sClient, namespace, orchestrationStatic.DiscoverOptions{Selector: v.Selector})
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		if err := g.AddNode(ctx, n.Name, orchestration.NodeOptions{
+			APIURL:      n.APIURL,
+			DebugAPIURL: n.DebugAPIURL,
+		}); err != nil {
+			return fmt.Errorf("add node %s: %w", n.Name, err)
+		}
+	}
+	c.logger.Infof("%d nodes of node group %s are discovered in namespace %s", len(nodes), g.Name(), namespace)
+
+	return nil
+}
+
+// deleteNodeStorage deletes the persistent storage of the node, its volume
+// with docker and its persistent volume claim with kubernetes
+func (c *command) deleteNodeStorage(ctx context.Context, g orchestration.NodeGroup, name string) error {
+	switch ng := g.(type) {
+	case *orchestrationDocker.NodeGroup:
+		if err := ng.DeleteNodeVolume(ctx, name); err != nil {
+			return fmt.Errorf("deleting volume of node %s: %w", name, err)
+		}
+	case *orchestrationK8S.NodeGroup:
+		if err := ng.DeleteNodeVolumeClaim(ctx, name); err != nil {
+			return fmt.Errorf("deleting volume claim of node %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -9,16 +10,22 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/ethersphere/beekeeper/pkg/helm"
 	"github.com/ethersphere/beekeeper/pkg/k8s"
 	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/logging/elasticsearch"
+	"github.com/ethersphere/beekeeper/pkg/logging/loki"
+	"github.com/ethersphere/beekeeper/pkg/logging/rotate"
 	"github.com/ethersphere/beekeeper/pkg/swap"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -28,18 +35,43 @@ import (
 )
 
 const (
-	optionNameConfigDir          = "config-dir"
-	optionNameConfigGitRepo      = "config-git-repo"
-	optionNameConfigGitBranch    = "config-git-branch"
-	optionNameConfigGitUsername  = "config-git-username"
-	optionNameConfigGitPassword  = "config-git-password"
-	optionNameLogVerbosity       = "log-verbosity"
-	optionNameLokiEndpoint       = "loki-endpoint"
-	optionNameTracingEnabled     = "tracing-enable"
-	optionNameTracingEndpoint    = "tracing-endpoint"
-	optionNameTracingHost        = "tracing-host"
-	optionNameTracingPort        = "tracing-port"
-	optionNameTracingServiceName = "tracing-service-name"
+	optionNameConfigDir             = "config-dir"
+	optionNameDeadline              = "deadline"
+	optionNameTeardownTimeout       = "teardown-timeout"
+	optionNameConfigGitRepo         = "config-git-repo"
+	optionNameConfigGitBranch       = "config-git-branch"
+	optionNameConfigGitUsername     = "config-git-username"
+	optionNameConfigGitPassword     = "config-git-password"
+	optionNameElasticsearchAPIKey   = "elasticsearch-api-key"
+	optionNameElasticsearchIndex    = "elasticsearch-index"
+	optionNameElasticsearchPassword = "elasticsearch-password"
+	optionNameElasticsearchURL      = "elasticsearch-url"
+	optionNameElasticsearchUsername = "elasticsearch-username"
+	optionNameLogFile               = "log-file"
+	optionNameLogFileMaxAge         = "log-file-max-age"
+	optionNameLogFileMaxBackups     = "log-file-max-backups"
+	optionNameLogFileMaxSize        = "log-file-max-size"
+	optionNameLogFormat             = "log-format"
+	optionNameLogVerbosity          = "log-verbosity"
+	optionNameLokiBearerToken       = "loki-bearer-token"
+	optionNameLokiBufferDir         = "loki-buffer-dir"
+	optionNameLokiBufferMaxBytes    = "loki-buffer-max-bytes"
+	optionNameLokiCAFile            = "loki-ca-file"
+	optionNameLokiCertFile          = "loki-cert-file"
+	optionNameLokiEndpoint          = "loki-endpoint"
+	optionNameLokiFieldLabels       = "loki-field-labels"
+	optionNameLokiInsecureTLS       = "loki-insecure-tls"
+	optionNameLokiKeyFile           = "loki-key-file"
+	optionNameLokiLabels            = "loki-labels"
+	optionNameLokiPassword          = "loki-password"
+	optionNameLokiTenantID          = "loki-tenant-id"
+	optionNameLokiUsername          = "loki-username"
+	optionNameRunID                 = "run-id"
+	optionNameTracingEnabled        = "tracing-enable"
+	optionNameTracingEndpoint       = "tracing-endpoint"
+	optionNameTracingHost           = "tracing-host"
+	optionNameTracingPort           = "tracing-port"
+	optionNameTracingServiceName    = "tracing-service-name"
 )
 
 func init() {
@@ -56,10 +88,16 @@ type command struct {
 	config *config.Config
 	// kubernetes client
 	k8sClient *k8s.Client
+	// kubernetes clients of node groups in other kubernetes clusters, by kubeconfig and context
+	k8sClients map[string]*k8s.Client
 	// swap client
 	swapClient swap.Client
 	// logger
 	logger logging.Logger
+	// ID of the run, labels logs pushed to Loki
+	runID string
+	// cancels the run deadline context
+	cancelDeadline context.CancelFunc
 }
 
 type option func(*command)
@@ -72,7 +110,11 @@ func newCommand(opts ...option) (c *command, err error) {
 			SilenceErrors: true,
 			SilenceUsage:  true,
 			PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-				return c.initConfig()
+				if err := c.initConfig(); err != nil {
+					return err
+				}
+				c.setDeadline(cmd)
+				return nil
 			},
 		},
 	}
@@ -100,6 +142,10 @@ func newCommand(opts ...option) (c *command, err error) {
 		return nil, err
 	}
 
+	if err := c.initDoctorCmd(); err != nil {
+		return nil, err
+	}
+
 	if err := c.initFundCmd(); err != nil {
 		return nil, err
 	}
@@ -112,12 +158,25 @@ func newCommand(opts ...option) (c *command, err error) {
 		return nil, err
 	}
 
+	if err := c.initUpgradeCmd(); err != nil {
+		return nil, err
+	}
+
 	c.initVersionCmd()
 
 	return c, nil
 }
 
 func (c *command) Execute() (err error) {
+	defer func() {
+		if c.cancelDeadline != nil {
+			c.cancelDeadline()
+		}
+		// logs waiting to be pushed to Loki are sent
+		if l, ok := c.logger.(io.Closer); ok {
+			_ = l.Close()
+		}
+	}()
 	return c.root.Execute()
 }
 
@@ -138,9 +197,34 @@ func (c *command) initGlobalFlags() {
 	globalFlags.String(optionNameConfigGitBranch, "main", "Git branch")
 	globalFlags.String(optionNameConfigGitUsername, "", "Git username (needed for private repos)")
 	globalFlags.String(optionNameConfigGitPassword, "", "Git password or personal access tokens (needed for private repos)")
+	globalFlags.Duration(optionNameDeadline, 0, "deadline of the whole run, after which created resources are torn down, 0 for no deadline")
+	globalFlags.Duration(optionNameTeardownTimeout, 2*time.Minute, "timeout of the teardown after the run deadline expired")
+	globalFlags.String(optionNameElasticsearchAPIKey, "", "base64 encoded API key authenticating requests to Elasticsearch, can be set with BEEKEEPER_ELASTICSEARCH_API_KEY environment variable")
+	globalFlags.String(optionNameElasticsearchIndex, "beekeeper", "prefix of daily Elasticsearch indices logs are indexed in, like beekeeper-2023.10.16")
+	globalFlags.String(optionNameElasticsearchPassword, "", "password of basic authentication to Elasticsearch, can be set with BEEKEEPER_ELASTICSEARCH_PASSWORD environment variable")
+	globalFlags.String(optionNameElasticsearchURL, "", "Elasticsearch URL for indexing logs with their fields as documents, like http://elasticsearch.testnet.internal:9200")
+	globalFlags.String(optionNameElasticsearchUsername, "", "username of basic authentication to Elasticsearch")
+	globalFlags.String(optionNameLogFile, "", "file to write logs to in addition to the output, in the log format")
+	globalFlags.Duration(optionNameLogFileMaxAge, 0, "maximum age of the log file before it is rotated, 0 for no limit")
+	globalFlags.Int(optionNameLogFileMaxBackups, 0, "maximum number of rotated log files kept, 0 for no limit")
+	globalFlags.Int64(optionNameLogFileMaxSize, 100<<20, "maximum size in bytes of the log file before it is rotated, 0 for no limit")
+	globalFlags.String(optionNameLogFormat, "text", "log format, text or json with fields of log lines, like check, node and iteration, as JSON object fields")
 	globalFlags.String(optionNameLogVerbosity, "info", "log verbosity level 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=trace")
+	globalFlags.String(optionNameLokiBearerToken, "", "bearer token authenticating requests to Loki, can be set with BEEKEEPER_LOKI_BEARER_TOKEN environment variable")
+	globalFlags.String(optionNameLokiBufferDir, "", "directory to buffer logs in while Loki is unavailable, they are pushed once it is back, empty to drop them")
+	globalFlags.Int64(optionNameLokiBufferMaxBytes, 1<<30, "maximum size of the Loki buffer directory, 0 for no limit")
+	globalFlags.String(optionNameLokiCAFile, "", "PEM encoded CA certificates verifying Loki, in addition to system CAs")
+	globalFlags.String(optionNameLokiCertFile, "", "PEM encoded client certificate authenticating requests to Loki")
 	globalFlags.String(optionNameLokiEndpoint, "", "loki http endpoint for pushing local logs (use http://loki.testnet.internal/loki/api/v1/push)")
-	globalFlags.Bool(optionNameTracingEnabled, false, "enable tracing")
+	globalFlags.StringSlice(optionNameLokiFieldLabels, []string{logging.FieldCheck}, "fields of log lines whose values label the lines pushed to Loki, like check or node")
+	globalFlags.Bool(optionNameLokiInsecureTLS, false, "skip verification of the Loki certificate")
+	globalFlags.String(optionNameLokiKeyFile, "", "PEM encoded key of the client certificate authenticating requests to Loki")
+	globalFlags.StringToString(optionNameLokiLabels, nil, "static labels of log lines pushed to Loki, like cluster=default,environment=testnet")
+	globalFlags.String(optionNameLokiPassword, "", "password of basic authentication to Loki, like the Grafana Cloud API key, can be set with BEEKEEPER_LOKI_PASSWORD environment variable")
+	globalFlags.String(optionNameLokiTenantID, "", "tenant ID of multi-tenant Loki sent in the X-Scope-OrgID header")
+	globalFlags.String(optionNameLokiUsername, "", "username of basic authentication to Loki, like the Grafana Cloud Loki user ID")
+	globalFlags.String(optionNameRunID, "", "ID of the run labeling log lines pushed to Loki as run, like the ID of the CI job, generated if not set")
+	globalFlags.Bool(optionNameTracingEnabled, false, "enable tracing, trace context is propagated to Bee nodes so spans of nodes with tracing enabled join the traces of checks")
 	globalFlags.String(optionNameTracingEndpoint, "tempo-tempo-distributed-distributor.observability:6831", "endpoint to send tracing data")
 	globalFlags.String(optionNameTracingHost, "", "host to send tracing data")
 	globalFlags.String(optionNameTracingPort, "", "port to send tracing data")
@@ -148,7 +232,7 @@ func (c *command) initGlobalFlags() {
 }
 
 func (c *command) bindGlobalFlags() (err error) {
-	for _, flag := range []string{optionNameConfigDir, optionNameConfigGitRepo, optionNameConfigGitBranch, optionNameConfigGitUsername, optionNameConfigGitPassword, optionNameLogVerbosity, optionNameLokiEndpoint} {
+	for _, flag := range []string{optionNameConfigDir, optionNameDeadline, optionNameTeardownTimeout, optionNameConfigGitRepo, optionNameConfigGitBranch, optionNameConfigGitUsername, optionNameConfigGitPassword, optionNameElasticsearchAPIKey, optionNameElasticsearchIndex, optionNameElasticsearchPassword, optionNameElasticsearchURL, optionNameElasticsearchUsername, optionNameLogFile, optionNameLogFileMaxAge, optionNameLogFileMaxBackups, optionNameLogFileMaxSize, optionNameLogFormat, optionNameLogVerbosity, optionNameLokiBearerToken, optionNameLokiBufferDir, optionNameLokiBufferMaxBytes, optionNameLokiCAFile, optionNameLokiCertFile, optionNameLokiEndpoint, optionNameLokiFieldLabels, optionNameLokiInsecureTLS, optionNameLokiKeyFile, optionNameLokiLabels, optionNameLokiPassword, optionNameLokiTenantID, optionNameLokiUsername, optionNameRunID} {
 		if err := c.globalConfig.BindPFlag(flag, c.root.PersistentFlags().Lookup(flag)); err != nil {
 			return err
 		}
@@ -194,11 +278,41 @@ func (c *command) initConfig() (err error) {
 	// init logger
 	verbosity := c.globalConfig.GetString(optionNameLogVerbosity)
 	lokiEndpoint := c.globalConfig.GetString(optionNameLokiEndpoint)
-	c.logger, err = newLogger(c.root, verbosity, lokiEndpoint)
+	c.runID = c.globalConfig.GetString(optionNameRunID)
+	if c.runID == "" {
+		c.runID = uuid.NewString()
+	}
+	lokiLabels := map[string]string{"run": c.runID}
+	for k, v := range c.globalConfig.GetStringMapString(optionNameLokiLabels) {
+		lokiLabels[k] = v
+	}
+	lokiTLS, err := loki.NewTLSConfig(c.globalConfig.GetString(optionNameLokiCAFile), c.globalConfig.GetString(optionNameLokiCertFile),
+		c.globalConfig.GetString(optionNameLokiKeyFile), c.globalConfig.GetBool(optionNameLokiInsecureTLS))
+	if err != nil {
+		return fmt.Errorf("loki tls: %w", err)
+	}
+	c.logger, err = newLogger(c.root, verbosity, lokiEndpoint, c.globalConfig.GetString(optionNameLogFormat),
+		logging.WithLokiAuth(c.globalConfig.GetString(optionNameLokiUsername), c.globalConfig.GetString(optionNameLokiPassword),
+			c.globalConfig.GetString(optionNameLokiBearerToken), c.globalConfig.GetString(optionNameLokiTenantID)),
+		logging.WithLokiBuffer(c.globalConfig.GetString(optionNameLokiBufferDir), c.globalConfig.GetInt64(optionNameLokiBufferMaxBytes)),
+		logging.WithLokiLabels(lokiLabels, c.globalConfig.GetStringSlice(optionNameLokiFieldLabels)),
+		logging.WithLokiTLS(lokiTLS),
+		logging.WithElasticsearch(c.globalConfig.GetString(optionNameElasticsearchURL), elasticsearch.ClientOptions{
+			Index:    c.globalConfig.GetString(optionNameElasticsearchIndex),
+			Username: c.globalConfig.GetString(optionNameElasticsearchUsername),
+			Password: c.globalConfig.GetString(optionNameElasticsearchPassword),
+			APIKey:   c.globalConfig.GetString(optionNameElasticsearchAPIKey),
+		}),
+		logging.WithFile(c.globalConfig.GetString(optionNameLogFile), rotate.Options{
+			MaxBytes:   c.globalConfig.GetInt64(optionNameLogFileMaxSize),
+			MaxAge:     c.globalConfig.GetDuration(optionNameLogFileMaxAge),
+			MaxBackups: c.globalConfig.GetInt(optionNameLogFileMaxBackups),
+		}))
 	if err != nil {
 		return fmt.Errorf("new logger: %w", err)
 	}
 	c.logger.Infof("verbosity log level: %v", c.logger.GetLevel())
+	c.logger.Infof("run id: %s", c.runID)
 
 	if c.globalConfig.GetString(optionNameConfigGitRepo) != "" {
 		// read configuration from git repo
@@ -308,27 +422,126 @@ func (c *command) setK8S() (err error) {
 	if c.globalConfig.GetBool("enable-k8s") {
 
 		s := &k8s.ClientSetup{
-			NewForConfig:         kubernetes.NewForConfig,
-			InClusterConfig:      rest.InClusterConfig,
-			BuildConfigFromFlags: clientcmd.BuildConfigFromFlags,
-			FlagString:           flag.String,
-			FlagParse:            flag.Parse,
-			OsUserHomeDir:        os.UserHomeDir,
+			NewForConfig:           kubernetes.NewForConfig,
+			InClusterConfig:        rest.InClusterConfig,
+			BuildConfigFromFlags:   clientcmd.BuildConfigFromFlags,
+			BuildConfigFromContext: k8s.BuildConfigFromContext,
+			FlagString:             flag.String,
+			FlagParse:              flag.Parse,
+			OsUserHomeDir:          os.UserHomeDir,
 		}
 
 		o := &k8s.ClientOptions{
-			InCluster:      c.globalConfig.GetBool("in-cluster"),
-			KubeconfigPath: c.globalConfig.GetString("kubeconfig"),
+			InCluster:         c.globalConfig.GetBool("in-cluster"),
+			KubeconfigPath:    c.globalConfig.GetString("kubeconfig"),
+			Context:           c.globalConfig.GetString("kube-context"),
+			Namespace:         c.globalConfig.GetString("kube-namespace"),
+			Impersonate:       c.globalConfig.GetString("kube-as"),
+			ImpersonateGroups: c.globalConfig.GetStringSlice("kube-as-groups"),
 		}
 
 		if c.k8sClient, err = k8s.NewClient(s, o, c.logger); err != nil && err != k8s.ErrKubeconfigNotSet {
 			return fmt.Errorf("creating Kubernetes client: %w", err)
 		}
+
+		c.setDefaultNamespaces()
 	}
 
 	return
 }
 
+// setDefaultNamespaces sets namespaces of clusters without one to the
+// namespace of the kubeconfig context, or its override
+func (c *command) setDefaultNamespaces() {
+	if c.config == nil || c.k8sClient == nil || c.k8sClient.DefaultNamespace() == "" {
+		return
+	}
+
+	for name, cluster := range c.config.Clusters {
+		if cluster.Namespace != nil && *cluster.Namespace != "" {
+			continue
+		}
+		namespace := c.k8sClient.DefaultNamespace()
+		cluster.Namespace = &namespace
+		c.config.Clusters[name] = cluster
+	}
+}
+
+// otherKubernetesCluster returns whether the node group runs in another
+// kubernetes cluster than the global one, with its own kubeconfig or context
+func (c *command) otherKubernetesCluster(v config.ClusterNodeGroup) bool {
+	return (v.Kubeconfig != "" && v.Kubeconfig != c.globalConfig.GetString("kubeconfig")) ||
+		(v.KubeContext != "" && v.KubeContext != c.globalConfig.GetString("kube-context"))
+}
+
+// helmClient returns the helm client of the kubernetes cluster of the
+// kubeconfig and its context, the global ones if they are not set
+func (c *command) helmClient(kubeconfig, kubeContext string) *helm.Client {
+	if kubeconfig == "" {
+		kubeconfig = c.globalConfig.GetString("kubeconfig")
+	}
+	if kubeContext == "" {
+		kubeContext = c.globalConfig.GetString("kube-context")
+	}
+
+	return helm.NewClient(&helm.ClientOptions{
+		KubeconfigPath:    kubeconfig,
+		KubeContext:       kubeContext,
+		Impersonate:       c.globalConfig.GetString("kube-as"),
+		ImpersonateGroups: c.globalConfig.GetStringSlice("kube-as-groups"),
+	})
+}
+
+// kubeconfigK8S returns the client of the kubernetes cluster of the node
+// group kubeconfig and its context, or the global client, nil if kubernetes
+// is not enabled, when neither is set. Clients are created once and shared by
+// all node groups of the kubeconfig and context.
+func (c *command) kubeconfigK8S(v config.ClusterNodeGroup) (*k8s.Client, error) {
+	if !c.otherKubernetesCluster(v) {
+		return c.k8sClient, nil
+	}
+
+	kubeconfig := v.Kubeconfig
+	if kubeconfig == "" {
+		kubeconfig = c.globalConfig.GetString("kubeconfig")
+	}
+	kubeContext := v.KubeContext
+	if kubeContext == "" {
+		kubeContext = c.globalConfig.GetString("kube-context")
+	}
+
+	key := kubeconfig + "/" + kubeContext
+	if client, ok := c.k8sClients[key]; ok {
+		return client, nil
+	}
+
+	client, err := k8s.NewClient(&k8s.ClientSetup{
+		NewForConfig:           kubernetes.NewForConfig,
+		InClusterConfig:        rest.InClusterConfig,
+		BuildConfigFromFlags:   clientcmd.BuildConfigFromFlags,
+		BuildConfigFromContext: k8s.BuildConfigFromContext,
+		// the kubeconfig flag is registered by the global client only
+		FlagString:    func(_, value, _ string) *string { return &value },
+		FlagParse:     func() {},
+		OsUserHomeDir: os.UserHomeDir,
+	}, &k8s.ClientOptions{
+		KubeconfigPath:    kubeconfig,
+		Context:           kubeContext,
+		Impersonate:       c.globalConfig.GetString("kube-as"),
+		ImpersonateGroups: c.globalConfig.GetStringSlice("kube-as-groups"),
+	}, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kubernetes client of kubeconfig %s: %w", kubeconfig, err)
+	}
+
+	if c.k8sClients == nil {
+		c.k8sClients = make(map[string]*k8s.Client)
+	}
+	c.k8sClients[key] = client
+
+	return client, nil
+}
+
 func (c *command) setSwapClient() (err error) {
 	if len(c.globalConfig.GetString("geth-url")) > 0 {
 		gethUrl, err := url.Parse(c.globalConfig.GetString("geth-url"))
@@ -347,23 +560,57 @@ func (c *command) setSwapClient() (err error) {
 	return
 }
 
-func newLogger(cmd *cobra.Command, verbosity, lokiEndpoint string) (logging.Logger, error) {
-	var logger logging.Logger
+func newLogger(cmd *cobra.Command, verbosity, lokiEndpoint, format string, opts ...logging.Option) (logging.Logger, error) {
+	switch strings.ToLower(format) {
+	case "text":
+	case "json":
+		opts = append(opts, logging.WithJSONFormatter())
+	default:
+		return nil, fmt.Errorf("unknown %s %q, use help to check flag usage options", optionNameLogFormat, format)
+	}
+
+	level, err := logLevel(verbosity)
+	if err != nil {
+		return nil, err
+	}
+	if level == logrus.PanicLevel {
+		return logging.New(io.Discard, 0, ""), nil
+	}
+	return logging.New(cmd.OutOrStdout(), level, lokiEndpoint, opts...), nil
+}
+
+// logLevel returns the level of the log verbosity, silent verbosity is the
+// panic level
+func logLevel(verbosity string) (logrus.Level, error) {
 	switch strings.ToLower(verbosity) {
 	case "0", "silent":
-		logger = logging.New(io.Discard, 0, "")
+		return logrus.PanicLevel, nil
 	case "1", "error":
-		logger = logging.New(cmd.OutOrStdout(), logrus.ErrorLevel, lokiEndpoint)
+		return logrus.ErrorLevel, nil
 	case "2", "warn":
-		logger = logging.New(cmd.OutOrStdout(), logrus.WarnLevel, lokiEndpoint)
+		return logrus.WarnLevel, nil
 	case "3", "info":
-		logger = logging.New(cmd.OutOrStdout(), logrus.InfoLevel, lokiEndpoint)
+		return logrus.InfoLevel, nil
 	case "4", "debug":
-		logger = logging.New(cmd.OutOrStdout(), logrus.DebugLevel, lokiEndpoint)
+		return logrus.DebugLevel, nil
 	case "5", "trace":
-		logger = logging.New(cmd.OutOrStdout(), logrus.TraceLevel, lokiEndpoint)
+		return logrus.TraceLevel, nil
 	default:
-		return nil, fmt.Errorf("unknown %s level %q, use help to check flag usage options", optionNameLogVerbosity, verbosity)
+		return 0, fmt.Errorf("unknown %s level %q, use help to check flag usage options", optionNameLogVerbosity, verbosity)
+	}
+}
+
+// checkLogger returns the logger of the check that logs lines with the check
+// field, at the log verbosity of the check if it overrides the global one
+func (c *command) checkLogger(name string, cfg config.Check) (logging.Logger, error) {
+	logger := logging.WithFields(c.logger, logrus.Fields{logging.FieldCheck: name})
+	if cfg.LogVerbosity == nil || *cfg.LogVerbosity == "" {
+		return logger, nil
+	}
+
+	level, err := logLevel(*cfg.LogVerbosity)
+	if err != nil {
+		return nil, fmt.Errorf("check %s: %w", name, err)
 	}
-	return logger, nil
+	return logging.WithLevel(logger, level), nil
 }
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/cost"
+)
+
+// beginCosts starts accounting costs of the check, failures to account costs
+// do not fail the check
+func (c *command) beginCosts(ctx context.Context, tracker *cost.Tracker, checkName string) {
+	if tracker == nil {
+		return
+	}
+	if err := tracker.Begin(ctx, checkName); err != nil {
+		c.logger.Warningf("check %s: %v", checkName, err)
+	}
+}
+
+// endCosts records costs of all nodes spent during the check as measurements
+// of its result
+func (c *command) endCosts(ctx context.Context, tracker *cost.Tracker, r *beekeeper.Result) {
+	if tracker == nil {
+		return
+	}
+	costs, err := tracker.End(ctx)
+	if err != nil {
+		c.logger.Warningf("check %s: %v", r.Name, err)
+		return
+	}
+
+	total := cost.Total(costs)
+	r.Measure("cost_gas", total.GasTokens(), "native")
+	r.Measure("cost_postage", total.PostageBZZ(), "BZZ")
+	r.Measure("cost_transactions", float64(total.Transactions), "tx")
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// debugStateEndpoints are debug API endpoints snapshotted from the nodes
+// involved in a failed check, by the file name they are written to
+var debugStateEndpoints = map[string]string{
+	"pprof-goroutine.txt": "/debug/pprof/goroutine?debug=2",
+	"pprof-heap.pb.gz":    "/debug/pprof/heap",
+	"pprof-allocs.pb.gz":  "/debug/pprof/allocs",
+	"pprof-block.pb.gz":   "/debug/pprof/block",
+	"pprof-mutex.pb.gz":   "/debug/pprof/mutex",
+	"topology.json":       "/topology",
+	"reservestate.json":   "/reservestate",
+	"transactions.json":   "/transactions",
+}
+
+// captureDebugState writes profiles, topology, reserve state and pending
+// transactions of the nodes involved in the failed check to the artifacts
+// directory and attaches them to the check result. Nodes are involved if
+// their names are mentioned in the failure, otherwise state of all nodes is
+// captured.
+func (c *command) captureDebugState(cluster orchestration.Cluster, dir string, r *beekeeper.Result) {
+	// the run context may be done already
+	ctx, cancel := context.WithTimeout(context.Background(), c.globalConfig.GetDuration(optionNameTeardownTimeout))
+	defer cancel()
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		c.logger.Errorf("debug state: node clients: %v", err)
+		return
+	}
+	involved := involvedNodes(cluster, r)
+
+	names := make([]string, 0, len(debugStateEndpoints))
+	for name := range debugStateEndpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	nodes := make([]string, 0, len(clients))
+	for node := range clients {
+		if len(involved) == 0 || involved[node] {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Strings(nodes)
+
+	for _, node := range nodes {
+		nodeDir := filepath.Join(dir, r.Name, node)
+		if err := os.MkdirAll(nodeDir, 0o755); err != nil {
+			c.logger.Errorf("debug state: %v", err)
+			return
+		}
+
+		for _, name := range names {
+			path := filepath.Join(nodeDir, name)
+			if err := writeDebugState(ctx, clients[node], debugStateEndpoints[name], path); err != nil {
+				c.logger.Errorf("debug state: node %s: %v", node, err)
+				continue
+			}
+			r.Attach(fmt.Sprintf("%s of node %s", name, node), path)
+		}
+	}
+
+	c.logger.Infof("check %s: debug state of %d nodes written to %s", r.Name, len(nodes), filepath.Join(dir, r.Name))
+}
+
+// writeDebugState writes the response of the debug API endpoint to the file
+func writeDebugState(ctx context.Context, client *bee.Client, endpoint, path string) error {
+	resp, err := client.DebugAPIRawResponse(ctx, endpoint)
+	if err != nil {
+		return fmt.Errorf("%s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", endpoint, resp.Status)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return fmt.Errorf("%s: %w", endpoint, err)
+	}
+
+	return f.Close()
+}
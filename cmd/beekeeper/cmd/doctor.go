@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/doctor"
+	"github.com/spf13/cobra"
+)
+
+func (c *command) initDoctorCmd() (err error) {
+	const (
+		optionNameClusterName      = "cluster-name"
+		optionNameTimeout          = "timeout"
+		optionNameProbeTimeout     = "probe-timeout"
+		optionNameMaxChainLag      = "max-chain-lag"
+		optionNameMaxClockSkew     = "max-clock-skew"
+		optionNameMaxDepthSpread   = "max-depth-spread"
+		optionNameMinBatchTTL      = "min-batch-ttl"
+		optionNameMinNativeBalance = "min-native-balance"
+		optionNameMinBZZBalance    = "min-bzz-balance"
+		optionNameFailOnWarnings   = "fail-on-warnings"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "diagnoses problems of a Bee cluster",
+		Long: `Diagnoses problems of a Bee cluster.
+Runs fast, read-only probes against all nodes of the cluster: readiness gate, topology invariants,
+batch visibility, wallet balances, chain sync and clock skew, and prints a prioritized list of
+problems with remediation hints.`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx, cancel := context.WithTimeout(cmd.Context(), c.globalConfig.GetDuration(optionNameTimeout))
+			defer cancel()
+
+			cluster, err := c.setupCluster(ctx, c.globalConfig.GetString(optionNameClusterName), c.config, false)
+			if err != nil {
+				return fmt.Errorf("cluster setup: %w", err)
+			}
+
+			o := doctor.NewDefaultOptions()
+			o.ProbeTimeout = c.globalConfig.GetDuration(optionNameProbeTimeout)
+			o.MaxChainLag = c.globalConfig.GetUint64(optionNameMaxChainLag)
+			o.MaxClockSkew = c.globalConfig.GetDuration(optionNameMaxClockSkew)
+			o.MaxDepthSpread = c.globalConfig.GetInt(optionNameMaxDepthSpread)
+			o.MinBatchTTL = c.globalConfig.GetDuration(optionNameMinBatchTTL)
+
+			minNative, ok := new(big.Int).SetString(c.globalConfig.GetString(optionNameMinNativeBalance), 10)
+			if !ok {
+				return fmt.Errorf("invalid %s value", optionNameMinNativeBalance)
+			}
+			o.MinNativeBalance = minNative
+
+			minBZZ, ok := new(big.Int).SetString(c.globalConfig.GetString(optionNameMinBZZBalance), 10)
+			if !ok {
+				return fmt.Errorf("invalid %s value", optionNameMinBZZBalance)
+			}
+			o.MinBZZBalance = minBZZ
+
+			problems, err := doctor.New(cluster, o, c.logger).Run(ctx)
+			if err != nil {
+				return fmt.Errorf("doctor: %w", err)
+			}
+
+			if err := doctor.Print(os.Stdout, problems); err != nil {
+				return fmt.Errorf("print problems: %w", err)
+			}
+
+			for _, p := range problems {
+				if p.Severity == doctor.SeverityCritical || (p.Severity == doctor.SeverityWarning && c.globalConfig.GetBool(optionNameFailOnWarnings)) {
+					return fmt.Errorf("doctor found %d problems", len(problems))
+				}
+			}
+
+			return nil
+		},
+		PreRunE: c.preRunE,
+	}
+
+	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
+	cmd.Flags().Duration(optionNameTimeout, 2*time.Minute, "timeout")
+	cmd.Flags().Duration(optionNameProbeTimeout, 10*time.Second, "timeout of a single request to a node")
+	cmd.Flags().Uint64(optionNameMaxChainLag, 10, "maximum number of blocks a node may be behind the chain tip")
+	cmd.Flags().Duration(optionNameMaxClockSkew, 5*time.Second, "maximum clock skew between a node and this machine")
+	cmd.Flags().Int(optionNameMaxDepthSpread, 1, "maximum difference of kademlia depths between full nodes")
+	cmd.Flags().Duration(optionNameMinBatchTTL, time.Hour, "minimum TTL of owned postage batches")
+	cmd.Flags().String(optionNameMinNativeBalance, "1000000000000000", "minimum native token balance of a node wallet in wei")
+	cmd.Flags().String(optionNameMinBZZBalance, "0", "minimum BZZ balance of a node wallet in PLUR")
+	cmd.Flags().Bool(optionNameFailOnWarnings, false, "exit with an error on warnings, not only on critical problems")
+
+	c.root.AddCommand(cmd)
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/github"
+)
+
+// checkRuns reports checks as GitHub check runs of a commit, a nil
+// checkRuns reports nothing
+type checkRuns struct {
+	client  *github.Client
+	sha     string
+	ids     map[string]int64
+	timeout time.Duration
+	command *command
+}
+
+// newCheckRuns returns the check runs reporter if the GitHub repository and
+// commit are set
+func (c *command) newCheckRuns() (*checkRuns, error) {
+	repo, sha := c.globalConfig.GetString(optionNameGitHubRepo), c.globalConfig.GetString(optionNameGitHubSHA)
+	if repo == "" && sha == "" {
+		return nil, nil
+	}
+	if repo == "" || sha == "" {
+		return nil, errors.New("both github repository and commit have to be set")
+	}
+
+	client, err := github.NewClient(github.Options{
+		APIURL: c.globalConfig.GetString(optionNameGitHubAPIURL),
+		Repo:   repo,
+		Token:  c.globalConfig.GetString(optionNameGitHubToken),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("github: %w", err)
+	}
+
+	return &checkRuns{
+		client:  client,
+		sha:     sha,
+		ids:     make(map[string]int64),
+		timeout: c.globalConfig.GetDuration(optionNameTeardownTimeout),
+		command: c,
+	}, nil
+}
+
+// start reports the check as in progress
+func (g *checkRuns) start(ctx context.Context, checkName string) {
+	if g == nil {
+		return
+	}
+
+	now := time.Now()
+	id, err := g.client.CreateCheckRun(ctx, github.CheckRun{
+		Name:      checkRunName(checkName),
+		HeadSHA:   g.sha,
+		Status:    github.StatusInProgress,
+		StartedAt: &now,
+	})
+	if err != nil {
+		g.command.logger.Errorf("github: %v", err)
+		return
+	}
+	g.ids[checkName] = id
+}
+
+// complete reports the result of the check, creating the check run if the
+// check has not been started, as skipped checks are not
+func (g *checkRuns) complete(r beekeeper.Result) {
+	if g == nil {
+		return
+	}
+
+	// the run context may be done already
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+
+	now := time.Now()
+	run := github.CheckRun{
+		Status:      github.StatusCompleted,
+		Conclusion:  checkRunConclusion(r),
+		CompletedAt: &now,
+		Output:      checkRunOutput(r),
+	}
+
+	id, ok := g.ids[r.Name]
+	if !ok {
+		run.Name = checkRunName(r.Name)
+		run.HeadSHA = g.sha
+		if _, err := g.client.CreateCheckRun(ctx, run); err != nil {
+			g.command.logger.Errorf("github: %v", err)
+		}
+		return
+	}
+	if err := g.client.UpdateCheckRun(ctx, id, run); err != nil {
+		g.command.logger.Errorf("github: %v", err)
+	}
+}
+
+// checkRunName returns the name of the check run of the check
+func checkRunName(checkName string) string {
+	return "beekeeper/" + checkName
+}
+
+// checkRunConclusion returns the check run conclusion of the check result
+func checkRunConclusion(r beekeeper.Result) string {
+	switch {
+	case r.Status == beekeeper.StatusPassed:
+		return github.ConclusionSuccess
+	case r.Status == beekeeper.StatusSkipped:
+		return github.ConclusionSkipped
+	case strings.Contains(r.Error, context.DeadlineExceeded.Error()):
+		return github.ConclusionTimedOut
+	default:
+		return github.ConclusionFailure
+	}
+}
+
+// checkRunOutput returns the check run output with steps, measurements and
+// the error of the check
+func checkRunOutput(r beekeeper.Result) *github.Output {
+	o := &github.Output{
+		Title:   fmt.Sprintf("%s %s in %s", r.Name, r.Status, r.Duration.Round(time.Millisecond)),
+		Summary: r.Error,
+	}
+	if o.Summary == "" {
+		o.Summary = o.Title
+	}
+
+	var b strings.Builder
+	if len(r.Steps) > 0 {
+		b.WriteString("| Step | Status | Duration | Error |\n|---|---|---|---|\n")
+		for _, s := range r.Steps {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", s.Name, s.Status, s.Duration.Round(time.Millisecond), strings.ReplaceAll(s.Error, "|", "\\|"))
+		}
+	}
+	if len(r.Measurements) > 0 {
+		b.WriteString("\n| Measurement | Value |\n|---|---|\n")
+		for _, m := range r.Measurements {
+			fmt.Fprintf(&b, "| %s | %g %s |\n", m.Name, m.Value, m.Unit)
+		}
+	}
+	o.Text = b.String()
+
+	return o
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/ethersphere/beekeeper/pkg/k8s"
+	"github.com/ethersphere/beekeeper/pkg/k8s/secret"
+	"github.com/ethersphere/beekeeper/pkg/orchestration/utils"
+)
+
+// swarmKeysSecret returns name of the secret the mined Swarm keys of the node
+// group are persisted in
+func swarmKeysSecret(nodeGroup string) string {
+	return fmt.Sprintf("%s-swarm-keys", nodeGroup)
+}
+
+// mineSwarmKeys returns Swarm keys of the node group nodes whose overlays are
+// in the configured neighborhoods, or nil if no neighborhoods are configured.
+// With kubernetes clusters the keys are persisted in a secret of the
+// namespace, in the kubernetes cluster of the node group, when the cluster is created, and read from it afterwards, so
+// nodes keep their overlays even if the mining options change.
+func (c *command) mineSwarmKeys(ctx context.Context, ng string, v config.ClusterNodeGroup, networkID uint64, password, orchestrator, namespace string, persist bool) (keys []string, err error) {
+	neighborhoods := utils.Neighborhoods{}
+	if v.NeighborhoodDepth > 0 {
+		if neighborhoods, err = utils.DepthNeighborhoods(v.NeighborhoodDepth, v.NeighborhoodSize); err != nil {
+			return nil, err
+		}
+	}
+	for prefix, count := range v.Neighborhoods {
+		neighborhoods[prefix] = count
+	}
+	if len(neighborhoods) == 0 {
+		return nil, nil
+	}
+
+	// docker clusters have no secrets to persist keys in
+	var k8sClient *k8s.Client
+	if orchestrator != "docker" {
+		if k8sClient, err = c.kubeconfigK8S(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if k8sClient != nil {
+		data, err := k8sClient.Secret.Get(ctx, swarmKeysSecret(ng), namespace)
+		if err != nil {
+			return nil, fmt.Errorf("reading Swarm keys: %w", err)
+		}
+		if len(data) > 0 {
+			for i := 0; i < v.Count; i++ {
+				key, ok := data[fmt.Sprintf("%s-%d", ng, i)]
+				if !ok {
+					break
+				}
+				keys = append(keys, string(key))
+			}
+			if len(keys) == v.Count {
+				c.logger.Infof("node group %s: Swarm keys are read from secret %s", ng, swarmKeysSecret(ng))
+				return keys, nil
+			}
+			c.logger.Infof("node group %s: secret %s has %d of %d Swarm keys, mining them again", ng, swarmKeysSecret(ng), len(keys), v.Count)
+			keys = nil
+		}
+	}
+
+	keys, overlays, err := utils.MineSwarmKeys(v.NeighborhoodSeed, v.Count, networkID, password, neighborhoods)
+	if err != nil {
+		return nil, err
+	}
+	for i, o := range overlays {
+		c.logger.Infof("node %s-%d: mined overlay %s", ng, i, o)
+	}
+
+	if k8sClient != nil && persist {
+		data := make(map[string]string, len(keys))
+		for i, key := range keys {
+			data[fmt.Sprintf("%s-%d", ng, i)] = key
+		}
+		if _, err := k8sClient.Secret.Set(ctx, swarmKeysSecret(ng), namespace, secret.Options{
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "beekeeper",
+				"app.kubernetes.io/part-of":    ng,
+			},
+			StringData: data,
+			Type:       "Opaque",
+		}); err != nil {
+			return nil, fmt.Errorf("persisting Swarm keys: %w", err)
+		}
+		c.logger.Infof("node group %s: Swarm keys are persisted in secret %s", ng, swarmKeysSecret(ng))
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	beekeeperversion "github.com/ethersphere/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/spf13/pflag"
+)
+
+// runManifest records everything needed to reproduce a check run
+type runManifest struct {
+	Beekeeper   string                      `json:"beekeeper"` // version with git commit
+	Args        []string                    `json:"args"`
+	Seed        int64                       `json:"seed"` // global seed, -1 for random
+	Start       time.Time                   `json:"start"`
+	End         time.Time                   `json:"end"`
+	ClusterName string                      `json:"clusterName"`
+	Cluster     config.Cluster              `json:"cluster"`
+	NodeGroups  map[string]config.NodeGroup `json:"nodeGroups"`
+	BeeConfigs  map[string]config.BeeConfig `json:"beeConfigs"`
+	Nodes       map[string]manifestNode     `json:"nodes"`
+	Checks      []manifestCheck             `json:"checks"`
+}
+
+// manifestNode records a cluster node
+type manifestNode struct {
+	Overlay string `json:"overlay,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// manifestCheck records a check with options it ran with, including its
+// seed, and its failed attempts, so that they can be replayed
+type manifestCheck struct {
+	Name           string             `json:"name"`
+	Type           string             `json:"type"`
+	Options        interface{}        `json:"options,omitempty"`
+	Timeout        *time.Duration     `json:"timeout,omitempty"`        // timeout of every attempt
+	Result         beekeeper.Result   `json:"result"`                   // result of the last attempt
+	FailedAttempts []beekeeper.Result `json:"failedAttempts,omitempty"` // results of failed attempts, the first attempt first
+}
+
+// failedAttempt returns the number, counted from 1, and the result of the
+// last failed attempt of the check, if any attempt failed
+func (mc manifestCheck) failedAttempt() (attempt int, r beekeeper.Result, ok bool) {
+	if n := len(mc.FailedAttempts); n > 0 {
+		return n, mc.FailedAttempts[n-1], true
+	}
+	// manifests without attempts record only the last one
+	if mc.Result.Status == beekeeper.StatusFailed {
+		return 1, mc.Result, true
+	}
+	return 0, beekeeper.Result{}, false
+}
+
+// newRunManifest returns the manifest of a run on the cluster. Only
+// allowlisted fields of the cluster configuration and values of allowlisted
+// flags are recorded, so secrets are left out.
+func (c *command) newRunManifest(ctx context.Context, flags *pflag.FlagSet, clusterName string, cluster orchestration.Cluster, seed int64) *runManifest {
+	m := &runManifest{
+		Beekeeper:   beekeeperversion.Version,
+		Args:        manifestArgs(os.Args, flags),
+		Seed:        seed,
+		Start:       time.Now(),
+		ClusterName: clusterName,
+		Nodes:       make(map[string]manifestNode),
+	}
+	m.Cluster, m.NodeGroups, m.BeeConfigs = manifestConfig(c.config, clusterName)
+
+	overlays, err := cluster.FlattenOverlays(ctx)
+	if err != nil {
+		c.logger.Warningf("run manifest: overlays: %v", err)
+	}
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		c.logger.Warningf("run manifest: node clients: %v", err)
+	}
+	for name, client := range clients {
+		n := manifestNode{}
+		if overlay, ok := overlays[name]; ok {
+			n.Overlay = overlay.String()
+		}
+		health, err := client.Health(ctx)
+		if err != nil {
+			c.logger.Warningf("run manifest: node %s: health: %v", name, err)
+		}
+		n.Version = health.Version
+		m.Nodes[name] = n
+	}
+
+	return m
+}
+
+// manifestFlags are flags whose values are recorded in run manifests, values
+// of other flags may be secrets, like tokens and passwords, or URLs with
+// credentials
+var manifestFlags = map[string]bool{
+	"baseline-threshold":  true,
+	"baseline-thresholds": true,
+	"checks":              true,
+	"cluster-name":        true,
+	"cost-accounting":     true,
+	"create-cluster":      true,
+	"deadline":            true,
+	"debug-state":         true,
+	"delete-cluster":      true,
+	"detect-restarts":     true,
+	"fail-fast":           true,
+	"fail-on-restarts":    true,
+	"log-format":          true,
+	"log-verbosity":       true,
+	"metrics-enabled":     true,
+	"node-logs":           true,
+	"node-logs-tail":      true,
+	"preserve-on-failure": true,
+	"rebroadcast-after":   true,
+	"run-id":              true,
+	"seed":                true,
+	"teardown-timeout":    true,
+	"timeout":             true,
+	"tracing-enable":      true,
+}
+
+// manifestRedacted replaces values left out of run manifests
+const manifestRedacted = "<redacted>"
+
+// manifestArgs returns the command line arguments with values of flags that
+// are not allowlisted redacted
+func manifestArgs(args []string, flags *pflag.FlagSet) []string {
+	l := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if i == 0 || len(arg) < 2 || arg[0] != '-' {
+			l = append(l, arg)
+			continue
+		}
+		if arg == "--" {
+			l = append(l, args[i:]...)
+			break
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		var f *pflag.Flag
+		if flags != nil {
+			if strings.HasPrefix(arg, "--") {
+				f = flags.Lookup(name)
+			} else if len(name) == 1 {
+				f = flags.ShorthandLookup(name)
+			}
+		}
+		allowed := f != nil && manifestFlags[f.Name]
+
+		switch {
+		case hasValue:
+			if !allowed {
+				value = manifestRedacted
+			}
+			l = append(l, strings.SplitN(arg, "=", 2)[0]+"="+value)
+		case f != nil && f.NoOptDefVal == "" && i+1 < len(args):
+			// the flag takes the next argument as its value
+			i++
+			if allowed {
+				l = append(l, arg, args[i])
+			} else {
+				l = append(l, arg, manifestRedacted)
+			}
+		default:
+			l = append(l, arg)
+		}
+	}
+	return l
+}
+
+// manifest allowlists of fields of the configuration recorded in run
+// manifests, other fields are zeroed
+var (
+	manifestClusterFields = fieldSet("Inherit", "Name", "Orchestrator", "DockerHostIP", "Namespace", "DisableNamespace",
+		"APIDomain", "APIInsecureTLS", "APIScheme", "DebugAPIDomain", "DebugAPIInsecureTLS", "DebugAPIScheme", "Funding", "NodeGroups",
+		"Topology", "RateLimit", "RateLimitBurst", "NodeRateLimit", "NodeRateLimitBurst", "ReadRetries", "WriteRetries",
+		"RetryMinBackoff", "RetryMaxBackoff", "RetryBudget", "MaxIdleConnsPerHost", "MaxConnsPerHost", "IdleConnTimeout",
+		"DisableKeepAlives", "DisableHTTP2", "AccessMode")
+	manifestClusterNodeGroupFields = fieldSet("Mode", "BeeConfig", "Config", "Count", "Nodes", "Neighborhoods", "NeighborhoodSeed",
+		"NeighborhoodDepth", "NeighborhoodSize", "Selector", "Image", "Namespace", "APIDomain", "DebugAPIDomain", "Resources",
+		"Persistence", "Scheduling", "SecurityContext", "Netem")
+	manifestClusterNodeFields = fieldSet("Name", "Bootnodes", "Image", "Overrides", "Peers")
+	manifestNodeGroupFields   = fieldSet("Inherit", "Annotations", "APIInsecureTLS", "ClefImage", "ClefImagePullPolicy",
+		"DeploymentMode", "Gateway", "HelmChart", "HelmChartVersion", "Image", "ImagePullPolicy", "IngressClass",
+		"IngressDebugClass", "Labels", "NodeSelector", "EmptyDirMedium", "EmptyDirSizeLimit", "Netem", "PersistenceEnabled",
+		"PersistenceStorageClass", "PersistenceStorageRequest", "PersistenceRetentionPolicy", "PodManagementPolicy",
+		"ResourcesLimitCPU", "ResourcesLimitEphemeralStorage", "ResourcesLimitMemory", "ResourcesRequestCPU",
+		"ResourcesRequestEphemeralStorage", "ResourcesRequestMemory", "RestartPolicy", "Scheduling", "SecurityContext",
+		"UpdateStrategy")
+	manifestBeeConfigFields = fieldSet("Inherit", "AllowPrivateCIDRs", "APIAddr", "BlockTime", "Bootnodes", "BootnodeMode",
+		"CacheCapacity", "ClefSignerEnable", "CORSAllowedOrigins", "DataDir", "DbOpenFilesLimit", "DbBlockCacheCapacity",
+		"DbWriteBufferSize", "DbDisableSeeksCompaction", "DebugAPIAddr", "DebugAPIEnable", "FullNode", "NATAddr", "Mainnet",
+		"NetworkID", "P2PAddr", "P2PWSEnable", "PaymentEarly", "PaymentThreshold", "PaymentTolerance", "PostageStampAddress",
+		"PostageContractStartBlock", "PriceOracleAddress", "RedistributionAddress", "StakingAddress",
+		"StorageIncentivesEnable", "Restricted", "ChequebookEnable", "SwapEnable", "SwapDeploymentGasPrice",
+		"SwapFactoryAddress", "SwapLegacyFactoryAddresses", "SwapInitialDeposit", "TracingEnabled", "TracingServiceName",
+		"Verbosity", "WelcomeMessage", "WithdrawalAddresses", "WarmupTime")
+)
+
+// manifestConfig returns the configuration of the cluster, its node groups
+// and bee configs, with only allowlisted fields set
+func manifestConfig(cfg *config.Config, clusterName string) (cluster config.Cluster, nodeGroups map[string]config.NodeGroup, beeConfigs map[string]config.BeeConfig) {
+	cluster = cfg.Clusters[clusterName]
+	keepFields(&cluster, manifestClusterFields)
+	nodeGroups = make(map[string]config.NodeGroup)
+	beeConfigs = make(map[string]config.BeeConfig)
+
+	clusterNodeGroups := make(map[string]config.ClusterNodeGroup)
+	for name, ng := range cluster.GetNodeGroups() {
+		keepFields(&ng, manifestClusterNodeGroupFields)
+		nodes := make([]config.ClusterNode, 0, len(ng.Nodes))
+		for _, n := range ng.Nodes {
+			keepFields(&n, manifestClusterNodeFields)
+			if n.Overrides != nil {
+				overrides := *n.Overrides
+				keepFields(&overrides, manifestBeeConfigFields)
+				n.Overrides = &overrides
+			}
+			nodes = append(nodes, n)
+		}
+		ng.Nodes = nodes
+		clusterNodeGroups[name] = ng
+
+		if c, ok := cfg.NodeGroups[ng.Config]; ok {
+			keepFields(&c, manifestNodeGroupFields)
+			nodeGroups[ng.Config] = c
+		}
+		if c, ok := cfg.BeeConfigs[ng.BeeConfig]; ok {
+			keepFields(&c, manifestBeeConfigFields)
+			beeConfigs[ng.BeeConfig] = c
+		}
+	}
+	if cluster.NodeGroups != nil {
+		cluster.NodeGroups = &clusterNodeGroups
+	}
+
+	return cluster, nodeGroups, beeConfigs
+}
+
+// fieldSet returns the set of names of fields
+func fieldSet(names ...string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// keepFields zeroes exported fields of the struct the pointer points to that
+// are not in the allowlist
+func keepFields(ptr interface{}, allowed map[string]bool) {
+	v := reflect.ValueOf(ptr).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Type().Field(i); f.IsExported() && !allowed[f.Name] {
+			v.Field(i).Set(reflect.Zero(f.Type))
+		}
+	}
+}
+
+// manifestOptionSecret matches names of fields of check options whose values
+// are secrets
+var manifestOptionSecret = regexp.MustCompile(`(?i)(password|token|secret|privatekey|apikey|credential)`)
+
+// manifestOptions returns the check options as a JSON object without fields
+// whose values are secrets, they are left at their defaults when checks are
+// replayed
+func manifestOptions(o interface{}) interface{} {
+	v := reflect.ValueOf(o)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return o
+	}
+
+	fields := make(map[string]interface{})
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if !f.IsExported() || manifestOptionSecret.MatchString(f.Name) {
+			continue
+		}
+		name := f.Name
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		fields[name] = v.Field(i).Interface()
+	}
+	return fields
+}
+
+// readRunManifest reads the manifest from the file
+func readRunManifest(path string) (m runManifest, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return runManifest{}, fmt.Errorf("read run manifest %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(b, &m); err != nil {
+		return runManifest{}, fmt.Errorf("unmarshal run manifest %s: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// addCheck records the check with its failed attempts
+func (m *runManifest) addCheck(checkType string, timeout *time.Duration, o interface{}, r beekeeper.Result, failedAttempts []beekeeper.Result) {
+	m.Checks = append(m.Checks, manifestCheck{
+		Name:           r.Name,
+		Type:           checkType,
+		Options:        manifestOptions(o),
+		Timeout:        timeout,
+		Result:         r,
+		FailedAttempts: failedAttempts,
+	})
+}
+
+// write writes the manifest to the file as indented JSON
+func (m *runManifest) write(path string) error {
+	m.End = time.Now()
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal run manifest: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write run manifest %s: %w", path, err)
+	}
+
+	return nil
+}
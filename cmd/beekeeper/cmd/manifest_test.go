@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/check/authenticated"
+	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/spf13/pflag"
+)
+
+const secretValue = "s3cr3t"
+
+func TestManifestArgs(t *testing.T) {
+	flags := pflag.NewFlagSet("check", pflag.ContinueOnError)
+	flags.String("cluster-name", "", "")
+	flags.Int64("seed", -1, "")
+	flags.Bool("create-cluster", false, "")
+	flags.String("github-token", "", "")
+	flags.String("loki-password", "", "")
+	flags.String("elasticsearch-password", "", "")
+	flags.String("config-git-password", "", "")
+	flags.StringP("html-report", "r", "", "")
+
+	args := []string{"beekeeper", "check",
+		"--cluster-name", "default",
+		"--seed=42",
+		"--create-cluster",
+		"--github-token", secretValue,
+		"--loki-password=" + secretValue,
+		"--elasticsearch-password", secretValue,
+		"--config-git-password=" + secretValue,
+		"-r", secretValue,
+		"--unknown=" + secretValue,
+	}
+
+	got := manifestArgs(args, flags)
+	want := []string{"beekeeper", "check",
+		"--cluster-name", "default",
+		"--seed=42",
+		"--create-cluster",
+		"--github-token", manifestRedacted,
+		"--loki-password=" + manifestRedacted,
+		"--elasticsearch-password", manifestRedacted,
+		"--config-git-password=" + manifestRedacted,
+		"-r", manifestRedacted,
+		"--unknown=" + manifestRedacted,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got args %q, want %q", got, want)
+	}
+}
+
+func TestManifestConfig(t *testing.T) {
+	s := secretValue
+	cfg := &config.Config{
+		Clusters: map[string]config.Cluster{
+			"default": {
+				Name:          &s,
+				AdminPassword: &s,
+				NodeGroups: &map[string]config.ClusterNodeGroup{
+					"bee": {
+						BeeConfig:  "bee-config",
+						Config:     "node-group",
+						Kubeconfig: secretValue,
+						Nodes: []config.ClusterNode{{
+							Name:      "bee-0",
+							Clef:      config.Clef{Key: secretValue, Password: secretValue},
+							LibP2PKey: secretValue,
+							SwarmKey:  secretValue,
+							APIURL:    secretValue,
+							Overrides: &config.BeeConfig{Password: &s, SwapEndpoint: &s, WelcomeMessage: &s},
+						}},
+					},
+				},
+			},
+		},
+		NodeGroups: map[string]config.NodeGroup{
+			"node-group": {APIBearerToken: &s, HelmChartRepo: &s, Image: &s},
+		},
+		BeeConfigs: map[string]config.BeeConfig{
+			"bee-config": {
+				Password:           &s,
+				TokenEncryptionKey: &s,
+				SwapEndpoint:       &s,
+				AdminPassword:      &s,
+				ClefSignerEndpoint: &s,
+				ResolverOptions:    &s,
+				TracingEndpoint:    &s,
+				WelcomeMessage:     &s,
+			},
+		},
+	}
+
+	cluster, nodeGroups, beeConfigs := manifestConfig(cfg, "default")
+
+	// allowlisted fields are kept, the secret value is in four of them:
+	// the cluster name, the node group image and both welcome messages
+	b, err := json.Marshal(runManifest{Cluster: cluster, NodeGroups: nodeGroups, BeeConfigs: beeConfigs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(b), secretValue); n != 4 {
+		t.Errorf("got %d values of secret fields, want 4 values of allowlisted ones: %s", n, b)
+	}
+
+	if cluster.AdminPassword != nil {
+		t.Error("cluster admin password is recorded")
+	}
+	bc := beeConfigs["bee-config"]
+	for name, v := range map[string]*string{
+		"password":             bc.Password,
+		"token encryption key": bc.TokenEncryptionKey,
+		"swap endpoint":        bc.SwapEndpoint,
+		"admin password":       bc.AdminPassword,
+		"clef signer endpoint": bc.ClefSignerEndpoint,
+		"resolver options":     bc.ResolverOptions,
+		"tracing endpoint":     bc.TracingEndpoint,
+	} {
+		if v != nil {
+			t.Errorf("bee config %s is recorded", name)
+		}
+	}
+	if bc.WelcomeMessage == nil {
+		t.Error("bee config welcome message is not recorded")
+	}
+	if ng := nodeGroups["node-group"]; ng.APIBearerToken != nil || ng.HelmChartRepo != nil {
+		t.Error("node group api bearer token or helm chart repo is recorded")
+	}
+	n := cluster.GetNodeGroups()["bee"].Nodes[0]
+	if n.Name != "bee-0" || n.Clef != (config.Clef{}) || n.LibP2PKey != "" || n.SwarmKey != "" || n.APIURL != "" {
+		t.Errorf("node keys are recorded: %+v", n)
+	}
+	if n.Overrides.Password != nil || n.Overrides.SwapEndpoint != nil || n.Overrides.WelcomeMessage == nil {
+		t.Errorf("node overrides are not redacted: %+v", n.Overrides)
+	}
+
+	// the configuration is not modified
+	if cfg.BeeConfigs["bee-config"].Password == nil || cfg.Clusters["default"].AdminPassword == nil {
+		t.Error("configuration is modified")
+	}
+}
+
+func TestManifestOptions(t *testing.T) {
+	o := authenticated.Options{AdminPassword: secretValue, DryRun: true}
+
+	b, err := json.Marshal(manifestOptions(o))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), secretValue) {
+		t.Errorf("secret is recorded: %s", b)
+	}
+
+	restored, err := config.RestoreCheckOptions("authenticate", b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restored.(authenticated.Options).DryRun {
+		t.Error("options are not restored")
+	}
+}
+
+func TestManifestCheckFailedAttempt(t *testing.T) {
+	failed := beekeeper.Result{Status: beekeeper.StatusFailed, Error: "timeout"}
+	passed := beekeeper.Result{Status: beekeeper.StatusPassed}
+
+	for _, tc := range []struct {
+		name        string
+		check       manifestCheck
+		wantAttempt int
+		wantError   string
+	}{
+		{name: "passed", check: manifestCheck{Result: passed}},
+		{name: "failed", check: manifestCheck{Result: failed, FailedAttempts: []beekeeper.Result{{Error: "first"}, failed}}, wantAttempt: 2, wantError: "timeout"},
+		{name: "passed after failed attempt", check: manifestCheck{Result: passed, FailedAttempts: []beekeeper.Result{{Status: beekeeper.StatusFailed, Error: "first"}}}, wantAttempt: 1, wantError: "first"},
+		{name: "failed without attempts", check: manifestCheck{Result: failed}, wantAttempt: 1, wantError: "timeout"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			attempt, r, ok := tc.check.failedAttempt()
+			if ok != (tc.wantAttempt > 0) || attempt != tc.wantAttempt || r.Error != tc.wantError {
+				t.Errorf("got attempt %d with error %q, %t, want attempt %d with error %q", attempt, r.Error, ok, tc.wantAttempt, tc.wantError)
+			}
+		})
+	}
+}
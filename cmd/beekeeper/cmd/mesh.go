@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/orchestration/utils"
+)
+
+// meshNode is a node of the mesh topology with its libp2p key and underlay
+// address
+type meshNode struct {
+	libP2PKey string
+	address   string
+}
+
+// meshTopology maps names of nodes of the mesh topology to their libp2p keys
+// and addresses
+type meshTopology map[string]meshNode
+
+// newMeshTopology returns the mesh of all nodes of the cluster that are not
+// bootnodes. Nodes get libp2p keys generated, unless they are configured, so
+// their underlay addresses are known before any of them starts.
+func newMeshTopology(clusterConfig config.Cluster, cfg *config.Config) (meshTopology, error) {
+	m := make(meshTopology)
+	for ng, v := range clusterConfig.GetNodeGroups() {
+		if v.Mode == "bootnode" || v.Mode == "discover" {
+			continue
+		}
+
+		beeConfig, ok := cfg.BeeConfigs[v.BeeConfig]
+		if !ok {
+			return nil, fmt.Errorf("bee profile %s not defined", v.BeeConfig)
+		}
+		bConfig := beeConfig.Export()
+
+		nodes := make(map[string]string) // libp2p keys by node names
+		for i, node := range v.Nodes {
+			nName := fmt.Sprintf("%s-%d", ng, i)
+			if len(node.Name) > 0 {
+				nName = node.Name
+			}
+			nodes[nName] = node.LibP2PKey
+		}
+		if len(v.Nodes) == 0 {
+			for i := 0; i < v.Count; i++ {
+				nodes[fmt.Sprintf("%s-%d", ng, i)] = ""
+			}
+		}
+
+		port := bConfig.P2PAddr[strings.LastIndex(bConfig.P2PAddr, ":")+1:]
+		for name, key := range nodes {
+			var (
+				peerID string
+				err    error
+			)
+			if key != "" {
+				peerID, err = utils.LibP2PPeerID(key, bConfig.Password)
+			} else {
+				key, peerID, err = utils.CreateLibP2PKey(bConfig.Password)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("libp2p key of node %s: %w", name, err)
+			}
+
+			m[name] = meshNode{
+				libP2PKey: key,
+				// the headless service of the node, aliased by the docker network
+				address: fmt.Sprintf("/dns4/%s-headless.%s.svc.cluster.local/tcp/%s/p2p/%s", name, clusterConfig.GetNodeGroupNamespace(v), port, peerID),
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// apply sets the libp2p key of the node and its bootnodes to the addresses
+// of its peers, all other nodes of the mesh if no peers are given. Bootnodes
+// of bootnode node groups, if there are any, are kept.
+func (m meshTopology) apply(name string, peers []string, o *orchestration.NodeOptions, c orchestration.Config) error {
+	n, ok := m[name]
+	if !ok {
+		return fmt.Errorf("node %s is not in the mesh", name)
+	}
+
+	if len(peers) == 0 {
+		for p := range m {
+			if p != name {
+				peers = append(peers, p)
+			}
+		}
+		sort.Strings(peers)
+	}
+
+	if o.Config != nil {
+		c = *o.Config
+	}
+
+	addrs := strings.Fields(c.Bootnodes)
+	for _, p := range peers {
+		peer, ok := m[p]
+		if !ok {
+			return fmt.Errorf("peer %s of node %s is not in the mesh", p, name)
+		}
+		addrs = append(addrs, peer.address)
+	}
+
+	c.Bootnodes = strings.Join(addrs, " ")
+	o.Config = &c
+	o.LibP2PKey = n.libP2PKey
+
+	return nil
+}
@@ -1,14 +1,39 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/push"
 	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/cobra"
 )
 
+// metrics export flags are shared by the check and simulate commands
+const (
+	optionNameMetricsServerAddress   = "metrics-server-address"
+	optionNameOTLPEndpoint           = "otlp-endpoint"
+	optionNameOTLPHeaders            = "otlp-headers"
+	optionNameOTLPInterval           = "otlp-interval"
+	optionNameOTLPResourceAttributes = "otlp-resource-attributes"
+)
+
+// addMetricsExportFlags adds flags of metrics exporters used besides the
+// metrics pusher
+func addMetricsExportFlags(cmd *cobra.Command, actions string) {
+	cmd.Flags().String(optionNameMetricsServerAddress, "", fmt.Sprintf("address to serve metrics on /metrics and liveness on /healthz for scraping while %s run, like :9090, empty to disable", actions))
+	cmd.Flags().String(optionNameOTLPEndpoint, "", "OTLP/HTTP endpoint to export metrics to, like http://otel-collector:4318, empty to disable")
+	cmd.Flags().StringToString(optionNameOTLPHeaders, nil, "headers of OTLP export requests, like authentication headers of vendors")
+	cmd.Flags().Duration(optionNameOTLPInterval, 15*time.Second, "interval of OTLP metrics exports")
+	cmd.Flags().StringToString(optionNameOTLPResourceAttributes, nil, "resource attributes of exported OTLP metrics, service.name defaults to beekeeper")
+}
+
 // newMetricsPusher returns a new metrics pusher and a cleanup function.
 func newMetricsPusher(pusherAddress, job string, logger logging.Logger) (*push.Pusher, func()) {
 	metricsPusher := push.New(pusherAddress, job)
@@ -43,3 +68,109 @@ func newMetricsPusher(pusherAddress, job string, logger logging.Logger) (*push.P
 	}
 	return metricsPusher, cleanupFn
 }
+
+// newMetricsExporters starts the metrics server and the OTLP exporter if they
+// are set and returns the registry of metrics they export and a cleanup
+// function. The registry is nil if none of them is set.
+func (c *command) newMetricsExporters(namespace string) (*prometheus.Registry, func(), error) {
+	serverAddr := c.globalConfig.GetString(optionNameMetricsServerAddress)
+	otlpEndpoint := c.globalConfig.GetString(optionNameOTLPEndpoint)
+	if serverAddr == "" && otlpEndpoint == "" {
+		return nil, func() {}, nil
+	}
+
+	registry := prometheus.NewRegistry()
+	var cleanups []func()
+	cleanupFn := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+
+	if serverAddr != "" {
+		s := metrics.NewServer(serverAddr, registry)
+		listenAddr, err := s.Start()
+		if err != nil {
+			return nil, nil, fmt.Errorf("metrics server: %w", err)
+		}
+		c.logger.Infof("metrics server listening on %s", listenAddr)
+
+		cleanups = append(cleanups, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := s.Close(ctx); err != nil {
+				c.logger.Debugf("metrics server close: %v", err)
+			}
+		})
+	}
+
+	if otlpEndpoint != "" {
+		attrs := map[string]string{"service.namespace": namespace}
+		for k, v := range c.globalConfig.GetStringMapString(optionNameOTLPResourceAttributes) {
+			attrs[k] = v
+		}
+		interval := c.globalConfig.GetDuration(optionNameOTLPInterval)
+		if interval <= 0 {
+			cleanupFn()
+			return nil, nil, errors.New("otlp interval has to be positive")
+		}
+
+		cleanups = append(cleanups, newOTLPExporter(metrics.NewOTLPExporter(registry, metrics.OTLPOptions{
+			Endpoint:           otlpEndpoint,
+			Headers:            c.globalConfig.GetStringMapString(optionNameOTLPHeaders),
+			ResourceAttributes: attrs,
+		}), interval, c.logger))
+	}
+
+	return registry, cleanupFn, nil
+}
+
+// newOTLPExporter exports metrics periodically and returns a cleanup
+// function.
+func newOTLPExporter(e *metrics.OTLPExporter, interval time.Duration, logger logging.Logger) func() {
+	killC := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	// start periodic exporter
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-killC:
+				return
+			case <-time.After(interval):
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				if err := e.Export(ctx); err != nil {
+					logger.Debugf("otlp exporter periodic export: %v", err)
+				}
+				cancel()
+			}
+		}
+	}()
+	return func() {
+		close(killC)
+		wg.Wait()
+		// export metrics before returning
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := e.Export(ctx); err != nil {
+			logger.Infof("otlp exporter export: %v", err)
+		}
+	}
+}
+
+// registerMetrics registers collectors with the metrics pusher and the
+// registry of metrics exporters, if they are running.
+func registerMetrics(p *push.Pusher, r *prometheus.Registry, c ...prometheus.Collector) {
+	if p != nil {
+		metrics.RegisterCollectors(p, c...)
+	}
+	if r == nil {
+		return
+	}
+	// collectors of the same check type registered twice collide, as they
+	// do in the pusher, only the first ones are exported
+	_ = metrics.RegisterAll(r, c...)
+}
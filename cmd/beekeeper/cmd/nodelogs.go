@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// nodeLogsMargin is how long before the start of a check node logs are
+// captured from
+const nodeLogsMargin = time.Minute
+
+// captureNodeLogs writes recent logs of the nodes involved in the failed
+// check to the artifacts directory and attaches them to the check result.
+// Nodes are involved if their names are mentioned in the failure, otherwise
+// logs of all nodes are captured.
+func (c *command) captureNodeLogs(cluster orchestration.Cluster, dir string, tail int64, r *beekeeper.Result) {
+	// the run context may be done already
+	ctx, cancel := context.WithTimeout(context.Background(), c.globalConfig.GetDuration(optionNameTeardownTimeout))
+	defer cancel()
+
+	involved := involvedNodes(cluster, r)
+
+	var since time.Time
+	if !r.Start.IsZero() {
+		since = r.Start.Add(-nodeLogsMargin)
+	}
+
+	checkDir := filepath.Join(dir, r.Name)
+	if err := os.MkdirAll(checkDir, 0o755); err != nil {
+		c.logger.Errorf("node logs: %v", err)
+		return
+	}
+
+	groups := cluster.NodeGroups()
+	for _, gName := range cluster.NodeGroupsSorted() {
+		g := groups[gName]
+		for _, name := range g.NodesSorted() {
+			if len(involved) > 0 && !involved[name] {
+				continue
+			}
+
+			logs, err := g.NodeLogs(ctx, name, since, tail)
+			if err != nil {
+				c.logger.Errorf("node logs: node %s: %v", name, err)
+				continue
+			}
+
+			path := filepath.Join(checkDir, name+".log")
+			if err := os.WriteFile(path, logs, 0o644); err != nil {
+				c.logger.Errorf("node logs: node %s: %v", name, err)
+				continue
+			}
+			r.Attach("logs of node "+name, path)
+		}
+	}
+
+	c.logger.Infof("check %s: node logs written to %s", r.Name, checkDir)
+}
+
+// involvedNodes returns the nodes of the cluster mentioned in the failure of
+// the check
+func involvedNodes(cluster orchestration.Cluster, r *beekeeper.Result) map[string]bool {
+	failure := []string{r.Error}
+	for _, s := range r.Failed() {
+		failure = append(failure, s.Error)
+	}
+	text := strings.Join(failure, "\n")
+
+	names := cluster.NodeNames()
+	if len(names) == 0 {
+		return nil
+	}
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	re := regexp.MustCompile(`\b(?:` + strings.Join(quoted, "|") + `)\b`)
+
+	involved := make(map[string]bool)
+	for _, name := range re.FindAllString(text, -1) {
+		involved[name] = true
+	}
+	return involved
+}
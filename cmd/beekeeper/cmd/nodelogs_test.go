@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// testCluster implements the parts of the cluster used by the command
+type testCluster struct {
+	orchestration.Cluster
+	nodes []string
+}
+
+func (c testCluster) NodeNames() []string {
+	return c.nodes
+}
+
+func TestInvolvedNodes(t *testing.T) {
+	cluster := testCluster{nodes: []string{"bee-1", "bee-10", "bee-2", "light-0"}}
+
+	for _, tc := range []struct {
+		name   string
+		result beekeeper.Result
+		want   map[string]bool
+	}{
+		{
+			name:   "error",
+			result: beekeeper.Result{Error: "node bee-10: upload: 500, node bee-2: timeout"},
+			want:   map[string]bool{"bee-10": true, "bee-2": true},
+		},
+		{
+			name: "failed steps",
+			result: beekeeper.Result{Error: "1 step failed", Steps: []beekeeper.Step{
+				{Name: "upload", Status: beekeeper.StatusPassed, Error: "bee-2"},
+				{Name: "download", Status: beekeeper.StatusFailed, Error: "light-0 not synced"},
+			}},
+			want: map[string]bool{"light-0": true},
+		},
+		{
+			name:   "no nodes",
+			result: beekeeper.Result{Error: "bee-3 and bee-1x failed"},
+			want:   map[string]bool{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := involvedNodes(cluster, &tc.result); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got nodes %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
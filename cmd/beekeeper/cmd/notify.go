@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/notify"
+	"github.com/ethersphere/beekeeper/pkg/report"
+)
+
+// notify sends the summary of the run to all configured notification
+// targets. A target is notified about failed checks of its severities, and
+// about successful runs only if it asks for them.
+func (c *command) notify(run report.Run) {
+	if len(c.config.Notifications) == 0 {
+		return
+	}
+
+	// the run context may be done already
+	ctx, cancel := context.WithTimeout(context.Background(), c.globalConfig.GetDuration(optionNameTeardownTimeout))
+	defer cancel()
+
+	var reportURL string
+	if dest := c.globalConfig.GetString(optionNameHTMLReport); isURL(dest) {
+		reportURL = dest
+	}
+
+	names := make([]string, 0, len(c.config.Notifications))
+	for name := range c.config.Notifications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		cfg := c.config.Notifications[name]
+
+		s := notify.Summary{
+			Cluster:     run.Cluster,
+			BeeVersions: run.BeeVersions,
+			Status:      run.Status(),
+			Start:       run.Start,
+			End:         run.End,
+			ReportURL:   reportURL,
+		}
+		for _, r := range run.Results {
+			switch r.Status {
+			case beekeeper.StatusPassed:
+				s.Passed++
+			case beekeeper.StatusSkipped:
+				s.Skipped++
+			case beekeeper.StatusFailed:
+				s.Failed++
+				checkConfig := c.config.Checks[r.Name]
+				severity := checkConfig.GetSeverity()
+				if len(cfg.Severities) > 0 && !contains(cfg.Severities, severity) {
+					continue
+				}
+				f := notify.Failure{
+					Check:     r.Name,
+					Severity:  severity,
+					Error:     r.Error,
+					Artifacts: r.Artifacts,
+				}
+				if failed := r.Failed(); len(failed) > 0 {
+					f.FailedStep = failed[0].Name
+				}
+				s.Failures = append(s.Failures, f)
+			}
+		}
+
+		for _, r := range run.Regressions {
+			checkConfig := c.config.Checks[r.Check]
+			severity := checkConfig.GetSeverity()
+			if len(cfg.Severities) > 0 && !contains(cfg.Severities, severity) {
+				continue
+			}
+			s.Status = beekeeper.StatusFailed
+			s.Regressions = append(s.Regressions, notify.Regression{
+				Check:       r.Check,
+				Severity:    severity,
+				Measurement: r.Measurement,
+				Message:     r.String(),
+			})
+		}
+
+		if len(s.Failures) == 0 && len(s.Regressions) == 0 && (s.Status == beekeeper.StatusFailed || !cfg.OnSuccess) {
+			continue
+		}
+
+		n, err := notify.New(cfg.Type, cfg.GetURL(), nil)
+		if err != nil {
+			c.logger.Errorf("notification %s: %v", name, err)
+			continue
+		}
+		if err := n.Notify(ctx, s); err != nil {
+			c.logger.Errorf("notification %s: %v", name, err)
+			continue
+		}
+		c.logger.Infof("notification %s sent", name)
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/k8s/namespace"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// preservedLabel marks namespaces preserved for inspection after a failed run
+const preservedLabel = "beekeeper.ethswarm.org/preserved"
+
+// referenceRegexp matches Swarm references mentioned in check failures
+var referenceRegexp = regexp.MustCompile(`\b[0-9a-f]{64}\b`)
+
+// preserveCluster labels the namespace of the cluster and prints the pods
+// and the references mentioned in the failed results, so the environment can
+// be inspected before a manual cleanup
+func (c *command) preserveCluster(ctx context.Context, cluster orchestration.Cluster, ns string, results []beekeeper.Result, runErr error) {
+	var (
+		failed     []string
+		references = make(map[string]struct{})
+	)
+	addReferences := func(s string) {
+		for _, ref := range referenceRegexp.FindAllString(s, -1) {
+			references[ref] = struct{}{}
+		}
+	}
+	for _, r := range results {
+		if r.Status != beekeeper.StatusFailed {
+			continue
+		}
+		failed = append(failed, r.Name)
+		addReferences(r.Error)
+		for _, s := range r.Steps {
+			addReferences(s.Error)
+		}
+	}
+	if runErr != nil {
+		addReferences(runErr.Error())
+	}
+
+	c.logger.Warningf("run failed, cluster %s in namespace %s is preserved for inspection", cluster.Name(), ns)
+
+	if c.k8sClient != nil {
+		if _, err := c.k8sClient.Namespace.Label(ctx, ns, namespace.Options{
+			Labels:      map[string]string{preservedLabel: "true"},
+			Annotations: map[string]string{preservedLabel + "-checks": strings.Join(failed, ",")},
+		}); err != nil {
+			c.logger.Errorf("preserve cluster: %v", err)
+		} else {
+			c.logger.Infof("namespace %s labeled %s=true", ns, preservedLabel)
+		}
+	}
+
+	groups := cluster.NodeGroups()
+	for _, gName := range cluster.NodeGroupsSorted() {
+		for _, nName := range groups[gName].NodesSorted() {
+			c.logger.Infof("node group %s: node %s: pod %s-0", gName, nName, nName)
+		}
+	}
+
+	refs := make([]string, 0, len(references))
+	for ref := range references {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		c.logger.Infof("reference mentioned in failures: %s", ref)
+	}
+}
+
+// finishCluster deletes the cluster after the run, unless the run failed and
+// the cluster is preserved for inspection
+func (c *command) finishCluster(ctx context.Context, cluster orchestration.Cluster, clusterName, ns string, results []beekeeper.Result, runErr error, deleteCluster, preserveOnFailure bool) {
+	failed := runErr != nil
+	for _, r := range results {
+		if r.Status == beekeeper.StatusFailed {
+			failed = true
+		}
+	}
+
+	if failed && preserveOnFailure {
+		c.preserveCluster(ctx, cluster, ns, results, runErr)
+		return
+	}
+
+	if !deleteCluster {
+		return
+	}
+
+	c.logger.Infof("deleting cluster %s", clusterName)
+	if err := c.deleteCluster(ctx, clusterName, c.config, false); err != nil {
+		c.logger.Errorf("delete cluster %s: %v", clusterName, err)
+	}
+}
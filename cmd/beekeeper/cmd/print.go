@@ -19,15 +19,15 @@ func (c *command) initPrintCmd() (err error) {
 	cmd := &cobra.Command{
 		Use:   "print",
 		Short: "prints information about a Bee cluster",
-		Long: `Prints information about a Bee cluster: addresses, depths, nodes, overlays, peers, topologies
-Requires exactly one argument from the following list: addresses, depths, nodes, overlays, peers, topologies`,
+		Long: `Prints information about a Bee cluster: accounting, addresses, depths, nodes, overlays, peers, status, topologies
+Requires exactly one argument from the following list: accounting, addresses, depths, nodes, overlays, peers, status, topologies`,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
-				return fmt.Errorf("requires exactly one argument from the following list: addresses, depths, nodes, overlays, peers, topologies, config")
+				return fmt.Errorf("requires exactly one argument from the following list: accounting, addresses, depths, nodes, overlays, peers, status, topologies, config")
 			}
 
 			if _, ok := printFuncs[args[0]]; !ok {
-				return fmt.Errorf("argument '%s' is not from the following list: addresses, depths, nodes, overlays, peers, topologies, config", args[0])
+				return fmt.Errorf("argument '%s' is not from the following list: accounting, addresses, depths, nodes, overlays, peers, status, topologies, config", args[0])
 			}
 
 			return nil
@@ -74,6 +74,24 @@ Requires exactly one argument from the following list: addresses, depths, nodes,
 }
 
 var printFuncs = map[string]func(ctx context.Context, cluster orchestration.Cluster) (err error){
+	"accounting": func(ctx context.Context, cluster orchestration.Cluster) (err error) {
+		accounting, err := cluster.Accounting(ctx)
+		if err != nil {
+			return err
+		}
+
+		for ng, na := range accounting {
+			fmt.Printf("Printing %s node group's accounting\n", ng)
+			for n, accounts := range na {
+				for p, a := range accounts {
+					fmt.Printf("Node %s. peer: %s balance: %d surplus: %d reserved: %d shadow reserved: %d ghost: %d threshold given: %d received: %d\n",
+						n, p, a.Balance, a.SurplusBalance, a.ReservedBalance, a.ShadowReservedBalance, a.GhostBalance, a.CurrentThresholdGiven, a.CurrentThresholdReceived)
+				}
+			}
+		}
+
+		return
+	},
 	"addresses": func(ctx context.Context, cluster orchestration.Cluster) (err error) {
 		addresses, err := cluster.Addresses(ctx)
 		if err != nil {
@@ -110,9 +128,13 @@ var printFuncs = map[string]func(ctx context.Context, cluster orchestration.Clus
 		return
 	},
 	"nodes": func(ctx context.Context, cluster orchestration.Cluster) (err error) {
-		nodes := cluster.NodeNames()
+		nodes := cluster.Nodes()
 
-		for _, n := range nodes {
+		for _, n := range cluster.NodeNames() {
+			if image := nodes[n].Image(); image != "" {
+				fmt.Printf("%s %s\n", n, image)
+				continue
+			}
 			fmt.Printf("%s\n", n)
 		}
 
@@ -149,6 +171,24 @@ var printFuncs = map[string]func(ctx context.Context, cluster orchestration.Clus
 		}
 		return
 	},
+	"status": func(ctx context.Context, cluster orchestration.Cluster) (err error) {
+		status, err := orchestration.GetClusterStatus(ctx, cluster)
+		if err != nil {
+			return err
+		}
+
+		for _, n := range status.NodeNames() {
+			s := status.Nodes[n]
+			fmt.Printf("Node %s. mode: %s reserve size: %d storage radius: %d connected peers: %d reachable: %t\n", n, s.BeeMode, s.ReserveSize, s.StorageRadius, s.ConnectedPeers, s.IsReachable)
+		}
+		for n, err := range status.Errors {
+			fmt.Printf("Node %s. error: %v\n", n, err)
+		}
+		fmt.Printf("Cluster. reserve size: %d storage radius: %d-%d connected peers: %d-%d modes: %v\n",
+			status.ReserveSize, status.MinStorageRadius, status.MaxStorageRadius, status.MinPeers, status.MaxPeers, status.BeeModes)
+
+		return
+	},
 	"topologies": func(ctx context.Context, cluster orchestration.Cluster) (err error) {
 		topologies, err := cluster.Topologies(ctx)
 		if err != nil {
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/report"
+)
+
+// writeReports writes reports of the run to destinations set by the report
+// flags
+func (c *command) writeReports(run report.Run) {
+	// the run context may be done already
+	ctx, cancel := context.WithTimeout(context.Background(), c.globalConfig.GetDuration(optionNameTeardownTimeout))
+	defer cancel()
+
+	reports := []struct {
+		flag        string
+		contentType string
+		file        string // file name used when destination is a directory
+		write       func(io.Writer, report.Run) error
+	}{
+		{flag: optionNameJSONReport, contentType: "application/json", write: report.WriteJSON},
+		{flag: optionNameJUnitReport, contentType: "application/xml", write: report.WriteJUnit},
+		{flag: optionNameHTMLReport, contentType: "text/html; charset=utf-8", file: "index.html", write: report.WriteHTML},
+	}
+
+	for _, r := range reports {
+		dest := c.globalConfig.GetString(r.flag)
+		if dest == "" {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := r.write(&buf, run); err != nil {
+			c.logger.Errorf("%s: %v", r.flag, err)
+			continue
+		}
+
+		if isURL(dest) {
+			if err := uploadReport(ctx, dest, r.contentType, buf.Bytes()); err != nil {
+				c.logger.Errorf("%s: %v", r.flag, err)
+				continue
+			}
+			c.logger.Infof("%s uploaded to %s", r.flag, dest)
+			continue
+		}
+
+		if r.file != "" {
+			if err := os.MkdirAll(dest, 0o755); err != nil {
+				c.logger.Errorf("%s: %v", r.flag, err)
+				continue
+			}
+			dest = filepath.Join(dest, r.file)
+		}
+		if err := os.WriteFile(dest, buf.Bytes(), 0o644); err != nil {
+			c.logger.Errorf("%s: write %s: %v", r.flag, dest, err)
+			continue
+		}
+		c.logger.Infof("%s written to %s", r.flag, dest)
+	}
+}
+
+// newReportRun returns the run of the checks on the cluster for the reports
+func (c *command) newReportRun(cluster orchestration.Cluster, start time.Time, results []beekeeper.Result, checkOptions map[string]interface{}) report.Run {
+	run := report.Run{
+		Cluster: cluster.Name(),
+		Start:   start,
+		End:     time.Now(),
+		Results: results,
+		Types:   make(map[string]string),
+		Options: checkOptions,
+		Nodes:   make(map[string][]string),
+	}
+
+	// the run context may be done already
+	ctx, cancel := context.WithTimeout(context.Background(), c.globalConfig.GetDuration(optionNameTeardownTimeout))
+	defer cancel()
+	run.BeeVersions = beeVersions(ctx, cluster)
+
+	for _, r := range results {
+		run.Types[r.Name] = c.config.Checks[r.Name].Type
+		if r.Status != beekeeper.StatusFailed {
+			continue
+		}
+		for name := range involvedNodes(cluster, &r) {
+			run.Nodes[r.Name] = append(run.Nodes[r.Name], name)
+		}
+		sort.Strings(run.Nodes[r.Name])
+	}
+
+	return run
+}
+
+// storeResults saves the run to the results database if it is set
+func (c *command) storeResults(run report.Run) {
+	dsn := c.globalConfig.GetString(optionNameResultsDB)
+	if dsn == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.globalConfig.GetDuration(optionNameTeardownTimeout))
+	defer cancel()
+
+	store, err := report.NewSQLStore(ctx, c.globalConfig.GetString(optionNameResultsDBDriver), dsn)
+	if err != nil {
+		c.logger.Errorf("results database: %v", err)
+		return
+	}
+	defer store.Close()
+
+	id, err := store.Save(ctx, run)
+	if err != nil {
+		c.logger.Errorf("results database: %v", err)
+		return
+	}
+	c.logger.Infof("results stored in the results database as run %s", id)
+}
+
+// writeInfluxDB writes the run to InfluxDB in line protocol if its write URL
+// is set
+func (c *command) writeInfluxDB(run report.Run) {
+	url := c.globalConfig.GetString(optionNameInfluxDBURL)
+	if url == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.globalConfig.GetDuration(optionNameTeardownTimeout))
+	defer cancel()
+
+	var buf bytes.Buffer
+	if err := report.WriteInflux(&buf, run); err != nil {
+		c.logger.Errorf("influxdb: %v", err)
+		return
+	}
+
+	// precision of written points is nanoseconds, the default of both write
+	// APIs
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &buf)
+	if err != nil {
+		c.logger.Errorf("influxdb: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if token := c.globalConfig.GetString(optionNameInfluxDBToken); token != "" {
+		req.Header.Set("Authorization", "Token "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.logger.Errorf("influxdb: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		c.logger.Errorf("influxdb: write: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+		return
+	}
+	c.logger.Infof("results written to influxdb")
+}
+
+// beeVersions returns distinct versions of the cluster nodes, nodes that do
+// not respond are left out
+func beeVersions(ctx context.Context, cluster orchestration.Cluster) (versions []string) {
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for _, client := range clients {
+		health, err := client.Health(ctx)
+		if err != nil || seen[health.Version] {
+			continue
+		}
+		seen[health.Version] = true
+		versions = append(versions, health.Version)
+	}
+	sort.Strings(versions)
+
+	return versions
+}
+
+// isURL reports whether the report destination is an HTTP URL instead of a
+// local path
+func isURL(dest string) bool {
+	return strings.HasPrefix(dest, "http://") || strings.HasPrefix(dest, "https://")
+}
+
+// uploadReport uploads the report with an HTTP PUT request, as object storage
+// services accept it on pre-signed URLs
+func uploadReport(ctx context.Context, url, contentType string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("upload %s: %s", url, resp.Status)
+	}
+
+	return nil
+}
+
+// compareBaseline compares measurements of the results with the baseline
+// results file and returns the regressions with an error listing them
+func (c *command) compareBaseline(path string, results []beekeeper.Result) ([]report.Regression, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("baseline: %w", err)
+	}
+	defer f.Close()
+
+	baseline, err := report.ReadJSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("baseline %s: %w", path, err)
+	}
+
+	thresholds := report.Thresholds{
+		Default:      c.globalConfig.GetFloat64(optionNameBaselineThreshold),
+		Measurements: make(map[string]float64),
+	}
+	for name, v := range c.globalConfig.GetStringMapString(optionNameBaselineThresholds) {
+		t, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("baseline threshold of %s: %w", name, err)
+		}
+		thresholds.Measurements[name] = t
+	}
+
+	regressions := report.Compare(baseline, results, thresholds)
+	if len(regressions) == 0 {
+		c.logger.Infof("no regressions against baseline of %s run at %s, bee versions %s", baseline.Cluster, baseline.Start, strings.Join(baseline.BeeVersions, ", "))
+		return nil, nil
+	}
+
+	msgs := make([]string, 0, len(regressions))
+	for _, r := range regressions {
+		c.logger.Errorf("regression: %s", r)
+		msgs = append(msgs, r.String())
+	}
+	return regressions, fmt.Errorf("%d regressions against baseline %s: %s", len(regressions), path, strings.Join(msgs, "; "))
+}
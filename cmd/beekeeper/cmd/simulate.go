@@ -6,10 +6,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethersphere/beekeeper/pkg/bee"
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
 	"github.com/ethersphere/beekeeper/pkg/config"
 	"github.com/ethersphere/beekeeper/pkg/metrics"
 	"github.com/ethersphere/beekeeper/pkg/tracing"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/push"
 	"github.com/spf13/cobra"
 )
@@ -47,20 +49,41 @@ func (c *command) initSimulateCmd() (err error) {
 			}
 
 			var (
-				metricsPusher  *push.Pusher
-				metricsEnabled = c.globalConfig.GetBool(optionNameMetricsEnabled)
-				cleanup        func()
+				metricsPusher   *push.Pusher
+				metricsRegistry *prometheus.Registry
+				metricsEnabled  = c.globalConfig.GetBool(optionNameMetricsEnabled)
+				cleanup         func()
 			)
 
-			if metricsEnabled {
+			// pushgateway is not needed when metrics are exported otherwise
+			if metricsEnabled && c.globalConfig.GetString(optionNameMetricsPusherAddress) != "" {
 				metricsPusher, cleanup = newMetricsPusher(c.globalConfig.GetString(optionNameMetricsPusherAddress), cfgCluster.GetNamespace(), c.logger)
 				// cleanup executes when the calling context terminates
 				defer cleanup()
 			}
 
+			// metrics are scraped from the server and exported over OTLP
+			// besides being pushed
+			if metricsEnabled {
+				metricsRegistry, cleanup, err = c.newMetricsExporters(cfgCluster.GetNamespace())
+				if err != nil {
+					return err
+				}
+				defer cleanup()
+			}
+
+			// teardown executes before metrics are flushed by the cleanup,
+			// the command context is limited by the run deadline
+			defer c.teardownOnDeadline(cmd.Context(), cluster)
+
 			// logger metrics
 			if l, ok := c.logger.(metrics.Reporter); ok && metricsEnabled {
-				metrics.RegisterCollectors(metricsPusher, l.Report()...)
+				registerMetrics(metricsPusher, metricsRegistry, l.Report()...)
+			}
+
+			// bee client request timing metrics
+			if metricsEnabled {
+				registerMetrics(metricsPusher, metricsRegistry, bee.Report()...)
 			}
 
 			// tracing
@@ -106,7 +129,7 @@ func (c *command) initSimulateCmd() (err error) {
 				// create simulation
 				sim := simulation.NewAction(c.logger)
 				if s, ok := sim.(metrics.Reporter); ok && metricsEnabled {
-					metrics.RegisterCollectors(metricsPusher, s.Report()...)
+					registerMetrics(metricsPusher, metricsRegistry, s.Report()...)
 				}
 				sim = beekeeper.NewActionMiddleware(tracer, sim, simulationName)
 
@@ -122,10 +145,11 @@ func (c *command) initSimulateCmd() (err error) {
 	}
 
 	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
-	cmd.Flags().String(optionNameMetricsPusherAddress, "pushgateway.staging.internal", "prometheus metrics pusher address")
+	cmd.Flags().String(optionNameMetricsPusherAddress, "pushgateway.staging.internal", "prometheus metrics pusher address, empty to disable")
 	cmd.Flags().Bool(optionNameCreateCluster, false, "creates cluster before executing simulations")
 	cmd.Flags().StringSlice(optionNameSimulations, []string{"upload"}, "list of simulations to execute")
 	cmd.Flags().Bool(optionNameMetricsEnabled, true, "enable metrics")
+	addMetricsExportFlags(cmd, "simulations")
 	cmd.Flags().Int64(optionNameSeed, -1, "seed, -1 for random")
 	cmd.Flags().Duration(optionNameTimeout, 30*time.Minute, "timeout")
 
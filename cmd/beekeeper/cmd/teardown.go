@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/spf13/cobra"
+)
+
+// setDeadline limits the context of the command by the run deadline
+func (c *command) setDeadline(cmd *cobra.Command) {
+	deadline := c.globalConfig.GetDuration(optionNameDeadline)
+	if deadline <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), deadline)
+	cmd.SetContext(ctx)
+	c.cancelDeadline = cancel
+}
+
+// teardownOnDeadline tears down the run if the run deadline expired. The
+// context has to be the one limited by the deadline, not by the timeout of the
+// command, which expires without a teardown.
+func (c *command) teardownOnDeadline(deadlineCtx context.Context, cluster orchestration.Cluster) {
+	if c.cancelDeadline == nil || !errors.Is(deadlineCtx.Err(), context.DeadlineExceeded) {
+		return
+	}
+
+	// the run context is done, so the teardown has its own
+	ctx, cancel := context.WithTimeout(context.Background(), c.globalConfig.GetDuration(optionNameTeardownTimeout))
+	defer cancel()
+
+	c.logger.Warning("run deadline expired, tearing down")
+	c.teardown(ctx, cluster)
+}
+
+// teardown releases resources created on the cluster nodes during the run
+func (c *command) teardown(ctx context.Context, cluster orchestration.Cluster) {
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		c.logger.Errorf("teardown: node clients: %v", err)
+		return
+	}
+
+	for name, client := range clients {
+		if err := client.Teardown(ctx); err != nil {
+			c.logger.Errorf("teardown: node %s: %v", name, err)
+		}
+	}
+
+	c.logger.Info("teardown completed")
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/spf13/viper"
+)
+
+// teardownCluster records whether the cluster is torn down
+type teardownCluster struct {
+	orchestration.Cluster
+	tornDown bool
+}
+
+func (c *teardownCluster) NodesClients(ctx context.Context) (map[string]*bee.Client, error) {
+	c.tornDown = true
+	return nil, errors.New("no nodes")
+}
+
+func TestTeardownOnDeadline(t *testing.T) {
+	expired := func(t *testing.T) context.Context {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		t.Cleanup(cancel)
+		<-ctx.Done()
+		return ctx
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, tc := range []struct {
+		name         string
+		deadline     bool
+		ctx          context.Context
+		wantTeardown bool
+	}{
+		{name: "deadline expired", deadline: true, ctx: expired(t), wantTeardown: true},
+		{name: "deadline not expired", deadline: true, ctx: context.Background()},
+		{name: "canceled", deadline: true, ctx: canceled},
+		// the context expired on the timeout of the command
+		{name: "no deadline", ctx: expired(t)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &command{globalConfig: viper.New(), logger: logging.New(io.Discard, 0, "")}
+			c.globalConfig.Set(optionNameTeardownTimeout, time.Second)
+			if tc.deadline {
+				c.cancelDeadline = func() {}
+			}
+			cluster := &teardownCluster{}
+
+			c.teardownOnDeadline(tc.ctx, cluster)
+			if cluster.tornDown != tc.wantTeardown {
+				t.Errorf("got teardown %t, want %t", cluster.tornDown, tc.wantTeardown)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// rebroadcastStuckTransactions rebroadcasts on-chain transactions of all
+// nodes pending for longer than the age, so that checks do not wait for
+// transactions dropped by the chain. Failures do not fail the check.
+func (c *command) rebroadcastStuckTransactions(ctx context.Context, cluster orchestration.Cluster, age time.Duration, checkName string) {
+	if age <= 0 {
+		return
+	}
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		c.logger.Warningf("check %s: stuck transactions: %v", checkName, err)
+		return
+	}
+
+	for name, client := range clients {
+		txs, err := client.RebroadcastStuckTransactions(ctx, age)
+		for _, tx := range txs {
+			c.logger.Infof("check %s: node %s: rebroadcast transaction %s pending for more than %s", checkName, name, tx, age)
+		}
+		if err != nil {
+			c.logger.Warningf("check %s: node %s: stuck transactions: %v", checkName, name, err)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+func (c *command) initUpgradeCmd() (err error) {
+	const (
+		optionNameClusterName    = "cluster-name"
+		optionNameNodeGroup      = "node-group"
+		optionNameImage          = "image"
+		optionNameMaxUnavailable = "max-unavailable"
+		optionNameReadyTimeout   = "ready-timeout"
+		optionNameBatchInterval  = "batch-interval"
+		optionNameChecks         = "checks"
+		optionNameSeed           = "seed"
+		optionNameTimeout        = "timeout"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "upgrades image of a node group",
+		Long: `Upgrades image of a node group.
+Nodes of the node group are upgraded in batches of at most max-unavailable nodes. Every node of the
+group has to be ready before a batch starts and the upgraded nodes have to become ready within the
+ready timeout, otherwise the upgrade stops. Verification checks run after all nodes are upgraded.`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx, cancel := context.WithTimeout(cmd.Context(), c.globalConfig.GetDuration(optionNameTimeout))
+			defer cancel()
+
+			if c.globalConfig.GetString(optionNameNodeGroup) == "" {
+				return fmt.Errorf("%s is not set", optionNameNodeGroup)
+			}
+			if c.globalConfig.GetString(optionNameImage) == "" {
+				return fmt.Errorf("%s is not set", optionNameImage)
+			}
+			if c.globalConfig.GetInt(optionNameMaxUnavailable) < 1 {
+				return fmt.Errorf("%s must be at least 1", optionNameMaxUnavailable)
+			}
+
+			// verification checks are looked up before nodes are upgraded
+			var checkNames []string
+			for _, checkName := range c.globalConfig.GetStringSlice(optionNameChecks) {
+				checkNames = append(checkNames, strings.TrimSpace(checkName))
+			}
+			checkOrder, err := c.config.CheckOrder(checkNames)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := c.setupCluster(ctx, c.globalConfig.GetString(optionNameClusterName), c.config, false)
+			if err != nil {
+				return fmt.Errorf("cluster setup: %w", err)
+			}
+
+			g, err := cluster.NodeGroup(c.globalConfig.GetString(optionNameNodeGroup))
+			if err != nil {
+				return err
+			}
+
+			if err := c.rollingUpgrade(ctx, g, c.globalConfig.GetString(optionNameImage), rollingUpgradeOptions{
+				MaxUnavailable: c.globalConfig.GetInt(optionNameMaxUnavailable),
+				ReadyTimeout:   c.globalConfig.GetDuration(optionNameReadyTimeout),
+				BatchInterval:  c.globalConfig.GetDuration(optionNameBatchInterval),
+			}); err != nil {
+				return fmt.Errorf("upgrade node group %s: %w", g.Name(), err)
+			}
+
+			checkGlobalConfig := config.CheckGlobalConfig{
+				Seed: c.globalConfig.GetInt64(optionNameSeed),
+			}
+
+			for _, checkName := range checkOrder {
+				checkConfig := c.config.Checks[checkName]
+
+				check, ok := config.Checks[checkConfig.Type]
+				if !ok {
+					return fmt.Errorf("check %s not implemented", checkConfig.Type)
+				}
+
+				o, err := check.NewOptions(checkGlobalConfig, checkConfig)
+				if err != nil {
+					return fmt.Errorf("creating check %s options: %w", checkName, err)
+				}
+
+				logger, err := c.checkLogger(checkName, checkConfig)
+				if err != nil {
+					return err
+				}
+
+				c.logger.Infof("running verification check: %s", checkName)
+				if _, _, err := c.runCheck(ctx, cluster, check.NewAction(logger), checkName, checkConfig, o); err != nil {
+					return fmt.Errorf("verification check %s: %w", checkName, err)
+				}
+				c.logger.Infof("%s check completed successfully", checkName)
+			}
+
+			return nil
+		},
+		PreRunE: c.preRunE,
+	}
+
+	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
+	cmd.Flags().String(optionNameNodeGroup, "", "name of the node group to upgrade")
+	cmd.Flags().String(optionNameImage, "", "Bee image to upgrade nodes to, like ethersphere/bee:2.0.0")
+	cmd.Flags().Int(optionNameMaxUnavailable, 1, "maximum number of nodes upgraded at the same time")
+	cmd.Flags().Duration(optionNameReadyTimeout, 5*time.Minute, "time a node has to become ready in, before and after its upgrade")
+	cmd.Flags().Duration(optionNameBatchInterval, 0, "time to wait after a batch of nodes is upgraded, like for the topology to settle")
+	cmd.Flags().StringSlice(optionNameChecks, nil, "list of checks to execute after the upgrade, empty to skip verification")
+	cmd.Flags().Int64(optionNameSeed, -1, "seed of verification checks, -1 for random")
+	cmd.Flags().Duration(optionNameTimeout, 60*time.Minute, "timeout")
+
+	c.root.AddCommand(cmd)
+
+	return nil
+}
+
+// rollingUpgradeOptions are limits of the rolling upgrade
+type rollingUpgradeOptions struct {
+	MaxUnavailable int
+	ReadyTimeout   time.Duration
+	BatchInterval  time.Duration
+}
+
+// rollingUpgrade sets the image of the node group nodes in batches, in order
+// of their names. The whole group is gated on readiness before every batch,
+// so a batch never starts while other nodes are unavailable.
+func (c *command) rollingUpgrade(ctx context.Context, g orchestration.NodeGroup, image string, o rollingUpgradeOptions) (err error) {
+	nodes := g.NodesSorted()
+	for start := 0; start < len(nodes); start += o.MaxUnavailable {
+		end := start + o.MaxUnavailable
+		if end > len(nodes) {
+			end = len(nodes)
+		}
+		batch := nodes[start:end]
+
+		if err := waitNodesReady(ctx, g, nodes, o.ReadyTimeout); err != nil {
+			return err
+		}
+
+		c.logger.Infof("upgrading nodes %s to %s", strings.Join(batch, ", "), image)
+
+		eg, ectx := errgroup.WithContext(ctx)
+		for _, name := range batch {
+			name := name
+			eg.Go(func() error {
+				// the node group waits for the node to become ready again
+				ctx, cancel := context.WithTimeout(ectx, o.ReadyTimeout)
+				defer cancel()
+
+				if err := g.SetNodeImage(ctx, name, image); err != nil {
+					return fmt.Errorf("node %s: %w", name, err)
+				}
+				return nil
+			})
+		}
+		if err := eg.Wait(); err != nil {
+			return fmt.Errorf("upgraded %d of %d nodes: %w", start, len(nodes), err)
+		}
+
+		c.logger.Infof("upgraded %d of %d nodes", end, len(nodes))
+
+		if o.BatchInterval > 0 && end < len(nodes) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(o.BatchInterval):
+			}
+		}
+	}
+
+	return waitNodesReady(ctx, g, nodes, o.ReadyTimeout)
+}
+
+// waitNodesReady waits for all nodes to be ready, it fails with the first
+// node that is not ready within the timeout
+func waitNodesReady(ctx context.Context, g orchestration.NodeGroup, nodes []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, name := range nodes {
+		for {
+			ok, err := g.NodeReady(ctx, name)
+			if err != nil {
+				return fmt.Errorf("node %s readiness: %w", name, err)
+			}
+			if ok {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("node %s is not ready: %w", name, ctx.Err())
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}
+
+	return nil
+}
@@ -39,6 +39,13 @@ func (c *Client) BuildConfigFromFlags(masterUrl string, kubeconfigPath string) (
 	return &rest.Config{}, nil
 }
 
+func (c *Client) BuildConfigFromContext(kubeconfigPath string, context string) (*rest.Config, string, error) {
+	if c.expectError {
+		return nil, "", fmt.Errorf("mock error")
+	}
+	return &rest.Config{}, "context-namespace", nil
+}
+
 func (c *Client) OsUserHomeDir() (string, error) {
 	if c.expectError {
 		return "", fmt.Errorf("mock error")
@@ -73,12 +73,19 @@ func (*Pod) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, lis
 
 // Get implements v1.PodInterface
 func (*Pod) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Pod, error) {
-	panic("unimplemented")
+	if name == "get_bad" {
+		return nil, fmt.Errorf("mock error: cannot get pod")
+	} else {
+		return nil, errors.NewNotFound(schema.GroupResource{}, name)
+	}
 }
 
 // List implements v1.PodInterface
 func (*Pod) List(ctx context.Context, opts metav1.ListOptions) (*v1.PodList, error) {
-	panic("unimplemented")
+	if opts.LabelSelector == "list=bad" {
+		return nil, fmt.Errorf("mock error: cannot list pods")
+	}
+	return &v1.PodList{}, nil
 }
 
 // Patch implements v1.PodInterface
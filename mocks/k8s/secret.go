@@ -53,7 +53,10 @@ func (*Secret) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions,
 
 // Get implements v1.SecretInterface
 func (*Secret) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Secret, error) {
-	panic("unimplemented")
+	if name == "get_bad" {
+		return nil, fmt.Errorf("mock error: cannot get secret")
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{}, name)
 }
 
 // List implements v1.SecretInterface
@@ -0,0 +1,93 @@
+// Package assert provides soft assertions for checks. Failed assertions are
+// recorded and the check continues, so a single run reports every finding
+// instead of stopping at the first one.
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Assertions records outcomes of soft assertions. It is safe for concurrent
+// use.
+type Assertions struct {
+	mu       sync.Mutex
+	total    int
+	failures []string
+}
+
+// New returns new Assertions
+func New() *Assertions {
+	return &Assertions{}
+}
+
+// True records an assertion that cond holds and returns cond
+func (a *Assertions) True(cond bool, format string, args ...interface{}) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.total++
+	if !cond {
+		a.failures = append(a.failures, fmt.Sprintf(format, args...))
+	}
+
+	return cond
+}
+
+// NoError records an assertion that err is nil and returns whether it is.
+// The message of a failed assertion is formatted and suffixed with the error.
+func (a *Assertions) NoError(err error, format string, args ...interface{}) bool {
+	if err != nil {
+		return a.True(false, "%s: %v", fmt.Sprintf(format, args...), err)
+	}
+	return a.True(true, "")
+}
+
+// Errorf records a failed assertion
+func (a *Assertions) Errorf(format string, args ...interface{}) {
+	a.True(false, format, args...)
+}
+
+// Total returns the number of recorded assertions
+func (a *Assertions) Total() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.total
+}
+
+// Failures returns messages of failed assertions in the order they were
+// recorded
+func (a *Assertions) Failures() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]string(nil), a.failures...)
+}
+
+// Err returns an *Error aggregating all failed assertions, or nil if none
+// failed
+func (a *Assertions) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.failures) == 0 {
+		return nil
+	}
+
+	return &Error{
+		Total:    a.total,
+		Failures: append([]string(nil), a.failures...),
+	}
+}
+
+// Error is the aggregated error of failed assertions
+type Error struct {
+	Total    int
+	Failures []string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d of %d assertions failed: %s", len(e.Failures), e.Total, strings.Join(e.Failures, "; "))
+}
@@ -0,0 +1,74 @@
+package assert_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/assert"
+)
+
+func TestAssertions(t *testing.T) {
+	a := assert.New()
+
+	if err := a.Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !a.True(true, "chunk %d", 1) {
+		t.Error("expected true")
+	}
+	if a.True(false, "chunk %d not replicated", 2) {
+		t.Error("expected false")
+	}
+	if !a.NoError(nil, "chunk %d", 3) {
+		t.Error("expected true")
+	}
+	if a.NoError(errors.New("not found"), "chunk %d", 4) {
+		t.Error("expected false")
+	}
+	a.Errorf("chunk %d invalid", 5)
+
+	if a.Total() != 5 {
+		t.Errorf("expected 5 assertions, got %d", a.Total())
+	}
+
+	expected := []string{"chunk 2 not replicated", "chunk 4: not found", "chunk 5 invalid"}
+	if !reflect.DeepEqual(a.Failures(), expected) {
+		t.Errorf("expected failures %v, got %v", expected, a.Failures())
+	}
+
+	err := a.Err()
+	var e *assert.Error
+	if !errors.As(err, &e) {
+		t.Fatalf("expected *assert.Error, got %T", err)
+	}
+	if e.Total != 5 || !reflect.DeepEqual(e.Failures, expected) {
+		t.Errorf("unexpected error %+v", e)
+	}
+	if msg := "3 of 5 assertions failed: chunk 2 not replicated; chunk 4: not found; chunk 5 invalid"; err.Error() != msg {
+		t.Errorf("expected message %q, got %q", msg, err.Error())
+	}
+}
+
+func TestAssertionsConcurrent(t *testing.T) {
+	a := assert.New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			a.True(i%10 != 0, "item %d", i)
+		}(i)
+	}
+	wg.Wait()
+
+	if a.Total() != 100 {
+		t.Errorf("expected 100 assertions, got %d", a.Total())
+	}
+	if len(a.Failures()) != 10 {
+		t.Errorf("expected 10 failures, got %d", len(a.Failures()))
+	}
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -20,6 +21,7 @@ const (
 	deferredUploadHeader    = "Swarm-Deferred-Upload"
 	swarmPinHeader          = "Swarm-Pin"
 	swarmTagHeader          = "Swarm-Tag"
+	postageStampHeader      = "Swarm-Postage-Stamp"
 )
 
 var userAgent = "beekeeper/" + beekeeper.Version
@@ -27,8 +29,13 @@ var userAgent = "beekeeper/" + beekeeper.Version
 // Client manages communication with the Bee API.
 type Client struct {
 	httpClient *http.Client // HTTP client must handle authentication implicitly.
+	baseURL    *url.URL     // Websockets are dialed outside of the HTTP client.
 	service    service      // Reuse a single struct instead of allocating one for each service on the heap.
 	restricted bool
+	// TLS configuration and bearer token of websockets, requests of the HTTP
+	// client are authenticated by its transport
+	tlsConfig   *tls.Config
+	bearerToken string
 
 	// Services that API provides.
 	Bytes       *BytesService
@@ -41,12 +48,17 @@ type Client struct {
 	SOC         *SOCService
 	Stewardship *StewardshipService
 	Auth        *AuthService
+	Envelope    *EnvelopeService
 }
 
 // ClientOptions holds optional parameters for the Client.
 type ClientOptions struct {
 	HTTPClient *http.Client
 	Restricted bool
+	// TLSConfig and BearerToken are used to dial websockets, they have to be
+	// set on the transport of the HTTP client for other requests
+	TLSConfig   *tls.Config
+	BearerToken string
 }
 
 // NewClient constructs a new Client.
@@ -59,7 +71,10 @@ func NewClient(baseURL *url.URL, o *ClientOptions) (c *Client) {
 	}
 
 	c = newClient(httpClientWithTransport(baseURL, o.HTTPClient))
+	c.baseURL = baseURL
 	c.restricted = o.Restricted
+	c.tlsConfig = o.TLSConfig
+	c.bearerToken = o.BearerToken
 
 	return
 }
@@ -79,6 +94,7 @@ func newClient(httpClient *http.Client) (c *Client) {
 	c.SOC = (*SOCService)(&c.service)
 	c.Stewardship = (*StewardshipService)(&c.service)
 	c.Auth = (*AuthService)(&c.service)
+	c.Envelope = (*EnvelopeService)(&c.service)
 	return c
 }
 
@@ -108,6 +124,39 @@ func httpClientWithTransport(baseURL *url.URL, c *http.Client) *http.Client {
 	return c
 }
 
+// Get sends a GET request to the API path and decodes the JSON response
+// into v. It is meant for inspecting responses of endpoints that do not have
+// a dedicated service method.
+func (c *Client) Get(ctx context.Context, path string, v interface{}) (err error) {
+	return c.requestJSON(ctx, http.MethodGet, path, nil, v)
+}
+
+// Do sends a request with the provided header and body to the path and
+// returns the response regardless of its status code. The header is sent as
+// is, without the defaults of other requests. The caller must close the
+// response body.
+func (c *Client) Do(ctx context.Context, method, path string, header http.Header, body io.Reader) (resp *http.Response, err error) {
+	req, err := http.NewRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if header != nil {
+		req.Header = header.Clone()
+	}
+
+	if c.restricted && req.Header.Get("Authorization") == "" {
+		key, err := GetToken(path, method)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	return c.httpClient.Do(req)
+}
+
 // requestJSON handles the HTTP request response cycle. It JSON encodes the request
 // body, creates an HTTP request with provided method on a path with required
 // headers and decodes request body if the v argument is not nil and content type is
@@ -172,12 +221,19 @@ func encodeJSON(w io.Writer, v interface{}) (err error) {
 
 // requestData handles the HTTP request response cycle.
 func (c *Client) requestData(ctx context.Context, method, path string, body io.Reader, v interface{}) (resp io.ReadCloser, err error) {
+	return c.requestDataWithHeader(ctx, method, path, http.Header{}, body)
+}
+
+// requestDataWithHeader handles the HTTP request response cycle with
+// additional request headers.
+func (c *Client) requestDataWithHeader(ctx context.Context, method, path string, header http.Header, body io.Reader) (resp io.ReadCloser, err error) {
 	req, err := http.NewRequest(method, path, body)
 	if err != nil {
 		return nil, err
 	}
 	req = req.WithContext(ctx)
 
+	req.Header = header
 	if body != nil {
 		req.Header.Set("Content-Type", contentType)
 	}
@@ -308,4 +364,8 @@ type UploadOptions struct {
 	Tag     uint32
 	BatchID string
 	Direct  bool
+	// Stamp is the hex encoded pre-signed postage stamp of an uploaded chunk,
+	// sent instead of the batch so that nodes that do not own the batch
+	// upload it
+	Stamp string
 }
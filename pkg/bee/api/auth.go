@@ -82,6 +82,15 @@ var roles = map[string]string{
 	"accountant": TokenAccountant,
 }
 
+// RoleTokens returns tokens of restricted nodes by their roles
+func RoleTokens() map[string]string {
+	tokens := make(map[string]string, len(roles))
+	for role, token := range roles {
+		tokens[role] = token
+	}
+	return tokens
+}
+
 func GetToken(path, method string) (string, error) {
 	roleName := getRole(path, method)
 
@@ -112,7 +121,9 @@ var policies = [][]string{
 	{"consumer", "/bytes/*", "GET"},
 	{"creator", "/bytes", "POST"},
 	{"consumer", "/chunks/*", "GET"},
+	{"consumer", "/chunks/*", "HEAD"},
 	{"creator", "/chunks", "POST"},
+	{"creator", "/envelope/*", "POST"},
 	{"consumer", "/bzz/*", "GET"},
 	{"creator", "/bzz/*", "PATCH"},
 	{"creator", "/bzz", "POST"},
@@ -126,6 +137,7 @@ var policies = [][]string{
 	{"maintainer", "/pins", "GET"},
 	{"creator", "/pss/send/*", "POST"},
 	{"consumer", "/pss/subscribe/*", "GET"},
+	{"consumer", "/gsoc/subscribe/*", "GET"},
 	{"creator", "/soc/*/*", "POST"},
 	{"creator", "/feeds/*/*", "POST"},
 	{"consumer", "/feeds/*/*", "GET"},
@@ -141,6 +153,9 @@ var policies = [][]string{
 	{"maintainer", "/peers/*", "DELETE"},
 	{"maintainer", "/pingpong/*", "POST"},
 	{"maintainer", "/topology", "GET"},
+	{"maintainer", "/status", "GET"},
+	{"maintainer", "/status/peers", "GET"},
+	{"maintainer", "/redistributionstate", "GET"},
 	{"maintainer", "/welcome-message", "(GET)|(POST)"},
 	{"maintainer", "/balances", "GET"},
 	{"maintainer", "/balances/*", "GET"},
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/ethersphere/bee/pkg/swarm"
 )
@@ -21,6 +22,20 @@ func (c *ChunksService) Download(ctx context.Context, a swarm.Address, targets s
 	return c.client.requestData(ctx, http.MethodGet, "/"+apiVersion+"/chunks/"+a.String()+"?targets="+targets, nil, nil)
 }
 
+// Has reports whether the chunk is in the local store of the node, with a
+// HEAD request, so the chunk is not downloaded and not retrieved from the
+// network if the node does not have it
+func (c *ChunksService) Has(ctx context.Context, a swarm.Address) (bool, error) {
+	err := c.client.request(ctx, http.MethodHead, "/"+apiVersion+"/chunks/"+a.String(), nil, nil)
+	if IsHTTPStatusErrorCode(err, http.StatusNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // ChunksUploadResponse represents Upload's response
 type ChunksUploadResponse struct {
 	Reference swarm.Address `json:"reference"`
@@ -36,7 +51,14 @@ func (c *ChunksService) Upload(ctx context.Context, data []byte, o UploadOptions
 	if o.Direct {
 		h.Add(deferredUploadHeader, "false")
 	}
-	h.Add(postageStampBatchHeader, o.BatchID)
+	if o.Tag != 0 {
+		h.Add(swarmTagHeader, strconv.FormatUint(uint64(o.Tag), 10))
+	}
+	if o.Stamp != "" {
+		h.Add(postageStampHeader, o.Stamp)
+	} else {
+		h.Add(postageStampBatchHeader, o.BatchID)
+	}
 	err := c.client.requestWithHeader(ctx, http.MethodPost, "/"+apiVersion+"/chunks", h, bytes.NewReader(data), &resp)
 	return resp, err
 }
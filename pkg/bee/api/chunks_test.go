@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+func TestChunksHas(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/chunks/" + strings.Repeat("aa", 32):
+			w.Header().Set("Content-Length", "4104")
+		case "/v1/chunks/" + strings.Repeat("bb", 32):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer s.Close()
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(u, nil)
+
+	for _, tc := range []struct {
+		address string
+		want    bool
+		wantErr bool
+	}{
+		{address: strings.Repeat("aa", 32), want: true},
+		{address: strings.Repeat("bb", 32), want: false},
+		{address: strings.Repeat("cc", 32), wantErr: true},
+	} {
+		has, err := c.Chunks.Has(context.Background(), swarm.MustParseHexAddress(tc.address))
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("chunk %s: got error %v", tc.address, err)
+		}
+		if has != tc.want {
+			t.Fatalf("chunk %s: got %v, want %v", tc.address, has, tc.want)
+		}
+	}
+}
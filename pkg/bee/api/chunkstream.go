@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultChunkStreamWindow is the number of chunks sent on a chunk stream
+// before their receipts, if the window is not set
+const DefaultChunkStreamWindow = 64
+
+// ErrChunkStreamClosed is returned by sends on a closed chunk stream
+var ErrChunkStreamClosed = errors.New("chunk stream closed")
+
+// ChunkStream uploads chunks on a websocket, without a request for every
+// chunk. The node acknowledges every chunk with an empty message, in the
+// order they were sent, and closes the stream on the first chunk it fails
+// to store. Sends block while the window of chunks waiting for
+// acknowledgements is full, so that callers are slowed down to the pace of
+// the node instead of queueing chunks without a limit.
+type ChunkStream struct {
+	ws      *websocket.Conn
+	window  chan struct{} // holds a token for every chunk waiting for acknowledgement
+	done    chan struct{} // closed when the stream ends
+	writeMu sync.Mutex
+
+	mu    sync.Mutex
+	err   error
+	acked int
+}
+
+// ChunkStreamOptions holds optional parameters of a chunk stream
+type ChunkStreamOptions struct {
+	UploadOptions
+	// Window is the number of chunks sent before their acknowledgements,
+	// defaults to DefaultChunkStreamWindow
+	Window int
+}
+
+// Stream opens the stream uploading chunks stamped by the batch of the
+// options, with the tag, if it is set. Pinning and direct uploads are not
+// supported by streams.
+func (c *ChunksService) Stream(ctx context.Context, o ChunkStreamOptions) (*ChunkStream, error) {
+	h := http.Header{}
+	h.Add(postageStampBatchHeader, o.BatchID)
+	if o.Tag != 0 {
+		h.Add(swarmTagHeader, strconv.FormatUint(uint64(o.Tag), 10))
+	}
+
+	ws, err := c.client.dialWebsocket(ctx, "/chunks/stream", h)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Window <= 0 {
+		o.Window = DefaultChunkStreamWindow
+	}
+	s := &ChunkStream{
+		ws:     ws,
+		window: make(chan struct{}, o.Window),
+		done:   make(chan struct{}),
+	}
+	go s.receive()
+
+	return s, nil
+}
+
+// receive counts acknowledgements of chunks until the stream ends
+func (s *ChunkStream) receive() {
+	defer close(s.done)
+
+	for {
+		_, data, err := s.ws.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			switch {
+			case websocket.IsCloseError(err, websocket.CloseNormalClosure):
+				s.setErr(ErrChunkStreamClosed)
+			case errors.As(err, &closeErr) && closeErr.Text != "":
+				// the node closes the stream with the reason of the failure
+				s.setErr(fmt.Errorf("chunk stream: %s", closeErr.Text))
+			default:
+				s.setErr(fmt.Errorf("chunk stream: %w", err))
+			}
+			return
+		}
+		if len(data) > 0 {
+			s.setErr(fmt.Errorf("chunk stream: unexpected message %q", data))
+			return
+		}
+
+		select {
+		case <-s.window:
+		default:
+			s.setErr(errors.New("chunk stream: acknowledgement of a chunk that was not sent"))
+			return
+		}
+		s.mu.Lock()
+		s.acked++
+		s.mu.Unlock()
+	}
+}
+
+// Send sends the chunk, span and payload, on the stream. It blocks while the
+// window is full, until a chunk is acknowledged, the stream ends or the
+// context is done. A nil error means that the chunk was sent, it is stored
+// once it is acknowledged, which Close and Acknowledged report.
+func (s *ChunkStream) Send(ctx context.Context, data []byte) error {
+	select {
+	case s.window <- struct{}{}:
+	case <-s.done:
+		return s.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := s.ws.WriteMessage(websocket.BinaryMessage, data); err != nil {
+		s.setErr(fmt.Errorf("chunk stream: %w", err))
+		return s.Err()
+	}
+	return nil
+}
+
+// Pending returns the number of chunks sent and not acknowledged yet, it is
+// the window size while sends are blocked by the node
+func (s *ChunkStream) Pending() int {
+	return len(s.window)
+}
+
+// Acknowledged returns the number of chunks the node stored
+func (s *ChunkStream) Acknowledged() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.acked
+}
+
+// Close waits for acknowledgements of sent chunks and closes the stream. It
+// returns the error that ended the stream before all chunks were
+// acknowledged, or the error of the context.
+func (s *ChunkStream) Close(ctx context.Context) error {
+	defer s.ws.Close()
+
+	// wait for acknowledgements
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for s.Pending() > 0 {
+		select {
+		case <-s.done:
+			return s.Err()
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	s.writeMu.Lock()
+	err := s.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(5*time.Second))
+	s.writeMu.Unlock()
+	if err != nil {
+		// all chunks are stored, the stream just did not close cleanly
+		return nil
+	}
+
+	// the node closes its end of the stream
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+	}
+
+	return nil
+}
+
+// Err returns the error that ended the stream, nil while it is open
+func (s *ChunkStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// setErr sets the error ending the stream, the first one is kept
+func (s *ChunkStream) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
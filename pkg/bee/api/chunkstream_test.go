@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// newChunkStreamServer returns the chunk stream endpoint acknowledging
+// chunks until the failing one, if it is set
+func newChunkStreamServer(t *testing.T, failing string) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var received []string
+	upgrader := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chunks/stream" || r.Header.Get(postageStampBatchHeader) != "batch" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		for {
+			_, data, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(data) == failing {
+				_ = ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "chunk write error"))
+				return
+			}
+			received = append(received, string(data))
+			if err := ws.WriteMessage(websocket.BinaryMessage, nil); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s, &received
+}
+
+func TestChunkStream(t *testing.T) {
+	s, received := newChunkStreamServer(t, "")
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	stream, err := NewClient(u, nil).Chunks.Stream(ctx, ChunkStreamOptions{UploadOptions: UploadOptions{BatchID: "batch"}, Window: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := []string{"first", "second", "third", "fourth", "fifth"}
+	for _, c := range chunks {
+		if err := stream.Send(ctx, []byte(c)); err != nil {
+			t.Fatal(err)
+		}
+		if p := stream.Pending(); p > 2 {
+			t.Fatalf("got %d pending chunks over the window", p)
+		}
+	}
+	if err := stream.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := stream.Acknowledged(); got != len(chunks) {
+		t.Fatalf("got %d acknowledged chunks, want %d", got, len(chunks))
+	}
+	if got := strings.Join(*received, ","); got != strings.Join(chunks, ",") {
+		t.Fatalf("got chunks %s, want %s", got, strings.Join(chunks, ","))
+	}
+}
+
+func TestChunkStreamError(t *testing.T) {
+	s, _ := newChunkStreamServer(t, "second")
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	stream, err := NewClient(u, nil).Chunks.Stream(ctx, ChunkStreamOptions{UploadOptions: UploadOptions{BatchID: "batch"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []string{"first", "second"} {
+		if err := stream.Send(ctx, []byte(c)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = stream.Close(ctx)
+	if err == nil || !strings.Contains(err.Error(), "chunk write error") {
+		t.Fatalf("got error %v, want chunk write error", err)
+	}
+	if got := stream.Acknowledged(); got != 1 {
+		t.Fatalf("got %d acknowledged chunks, want 1", got)
+	}
+
+	if _, err := NewClient(u, nil).Chunks.Stream(ctx, ChunkStreamOptions{}); !IsHTTPStatusErrorCode(err, http.StatusBadRequest) {
+		t.Fatalf("got error %v, want bad request", err)
+	}
+}
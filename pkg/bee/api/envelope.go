@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+// EnvelopeService represents Bee's postage stamp envelope service
+type EnvelopeService service
+
+// Envelope represents a postage stamp signed by the node that owns the batch,
+// with hex encoded fields
+type Envelope struct {
+	Issuer    string `json:"issuer"`
+	Index     string `json:"index"`
+	Timestamp string `json:"timestamp"`
+	Signature string `json:"signature"`
+}
+
+// Stamp returns the hex encoded postage stamp of the batch in the envelope,
+// as it is sent with uploads of pre-signed chunks
+func (e Envelope) Stamp(batchID string) (string, error) {
+	var stamp []byte
+	for _, f := range []struct {
+		name, value string
+		size        int
+	}{
+		{"batch id", batchID, 32},
+		{"index", e.Index, 8},
+		{"timestamp", e.Timestamp, 8},
+		{"signature", e.Signature, 65},
+	} {
+		b, err := hex.DecodeString(f.value)
+		if err != nil {
+			return "", fmt.Errorf("stamp %s: %w", f.name, err)
+		}
+		if len(b) != f.size {
+			return "", fmt.Errorf("stamp %s has %d bytes, want %d", f.name, len(b), f.size)
+		}
+		stamp = append(stamp, b...)
+	}
+	return hex.EncodeToString(stamp), nil
+}
+
+// Create signs a postage stamp of the batch for the chunk address. The node
+// has to own the batch.
+func (e *EnvelopeService) Create(ctx context.Context, batchID string, address swarm.Address) (resp Envelope, err error) {
+	h := http.Header{}
+	h.Add(postageStampBatchHeader, batchID)
+	err = e.client.requestWithHeader(ctx, http.MethodPost, "/envelope/"+address.String(), h, nil, &resp)
+	return
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestEnvelopeStamp(t *testing.T) {
+	batchID := strings.Repeat("ab", 32)
+	e := Envelope{
+		Index:     strings.Repeat("01", 8),
+		Timestamp: strings.Repeat("02", 8),
+		Signature: strings.Repeat("03", 65),
+	}
+
+	stamp, err := e.Stamp(batchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := hex.DecodeString(stamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 113 {
+		t.Fatalf("got stamp of %d bytes, want 113", len(b))
+	}
+	if want := batchID + e.Index + e.Timestamp + e.Signature; stamp != want {
+		t.Fatalf("got stamp %s, want %s", stamp, want)
+	}
+
+	e.Signature = e.Signature[2:]
+	if _, err := e.Stamp(batchID); err == nil {
+		t.Fatal("got no error for short signature")
+	}
+}
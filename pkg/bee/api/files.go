@@ -18,6 +18,11 @@ func (f *FilesService) Download(ctx context.Context, a swarm.Address) (resp io.R
 	return f.client.requestData(ctx, http.MethodGet, "/"+apiVersion+"/bzz/"+a.String(), nil, nil)
 }
 
+// DownloadCID downloads data from the node addressed by a Swarm CID
+func (f *FilesService) DownloadCID(ctx context.Context, cid string) (resp io.ReadCloser, err error) {
+	return f.client.requestData(ctx, http.MethodGet, "/"+apiVersion+"/bzz/"+url.PathEscape(cid), nil, nil)
+}
+
 // FilesUploadResponse represents Upload's response
 type FilesUploadResponse struct {
 	Reference swarm.Address `json:"reference"`
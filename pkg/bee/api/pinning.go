@@ -34,16 +34,20 @@ func (ps *PinningService) UnpinRootHash(ctx context.Context, ref swarm.Address)
 }
 
 // GetPinnedRootHash determines if the root hash of
-// given reference is pinned by returning its reference.
+// given reference is pinned by returning its reference. Zero address is
+// returned if the root hash is not pinned.
 func (ps *PinningService) GetPinnedRootHash(ctx context.Context, ref swarm.Address) (swarm.Address, error) {
 	res := struct {
 		Reference swarm.Address `json:"reference"`
 	}{}
 	err := ps.client.requestJSON(ctx, http.MethodGet, pinsPath(ref.String()), nil, &res)
-	if err != nil {
+	if IsHTTPStatusErrorCode(err, http.StatusNotFound) {
 		return swarm.ZeroAddress, nil
 	}
-	return res.Reference, err
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+	return res.Reference, nil
 }
 
 // GetPins returns all references of pinned root hashes.
@@ -51,9 +55,8 @@ func (ps *PinningService) GetPins(ctx context.Context) ([]swarm.Address, error)
 	res := struct {
 		References []swarm.Address `json:"references"`
 	}{}
-	err := ps.client.requestJSON(ctx, http.MethodGet, pinsBasePath, nil, &res)
-	if err != nil {
-		return nil, nil
+	if err := ps.client.requestJSON(ctx, http.MethodGet, pinsBasePath, nil, &res); err != nil {
+		return nil, err
 	}
-	return res.References, err
+	return res.References, nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/ethersphere/bee/pkg/swarm"
 )
@@ -22,3 +23,21 @@ func (p *PSSService) SendMessage(ctx context.Context, nodeAddress swarm.Address,
 
 	return p.client.requestWithHeader(ctx, http.MethodPost, url, h, data, nil)
 }
+
+// SendMessageToTargets sends a PSS message to a recipient with a specific
+// topic, mining the trojan chunk so that its address starts with one of the
+// given hex encoded targets
+func (p *PSSService) SendMessageToTargets(ctx context.Context, targets []string, nodePublicKey string, topic string, data io.Reader, batchID string) error {
+	h := http.Header{}
+	h.Add(postageStampBatchHeader, batchID)
+
+	url := fmt.Sprintf("/%s/pss/send/%s/%s?recipient=%s", apiVersion, topic, strings.Join(targets, ","), nodePublicKey)
+
+	return p.client.requestWithHeader(ctx, http.MethodPost, url, h, data, nil)
+}
+
+// Subscribe subscribes to messages sent to the node with the topic over a
+// websocket, until the context is done
+func (p *PSSService) Subscribe(ctx context.Context, topic string) (*Subscription, error) {
+	return p.client.subscribe(ctx, "/pss/subscribe/"+topic)
+}
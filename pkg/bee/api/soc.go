@@ -26,3 +26,9 @@ func (p *SOCService) UploadSOC(ctx context.Context, owner, ID, signature string,
 	resp := SocResponse{}
 	return &resp, p.client.requestWithHeader(ctx, http.MethodPost, url, h, data, &resp)
 }
+
+// SubscribeGSOC subscribes to updates of the single owner chunk at the
+// address, like GSOC messages, over a websocket, until the context is done
+func (p *SOCService) SubscribeGSOC(ctx context.Context, address swarm.Address) (*Subscription, error) {
+	return p.client.subscribe(ctx, "/gsoc/subscribe/"+address.String())
+}
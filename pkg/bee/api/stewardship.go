@@ -28,11 +28,12 @@ func (ss *StewardshipService) IsRetrievable(ctx context.Context, ref swarm.Addre
 }
 
 // Reupload re-uploads root hash and all of its underlying associated chunks to
-// the network.
-func (ss *StewardshipService) Reupload(ctx context.Context, ref swarm.Address) error {
-	res := struct {
-		Message string `json:"message,omitempty"`
-		Code    int    `json:"code,omitempty"`
-	}{}
-	return ss.client.requestJSON(ctx, http.MethodPut, stewardshipPath(ref.String()), nil, &res)
+// the network. Chunks are stamped with the postage batch, nodes that do not
+// require one for re-uploads ignore it.
+func (ss *StewardshipService) Reupload(ctx context.Context, ref swarm.Address, batchID string) error {
+	h := http.Header{}
+	if batchID != "" {
+		h.Add(postageStampBatchHeader, batchID)
+	}
+	return ss.client.requestWithHeader(ctx, http.MethodPut, stewardshipPath(ref.String()), h, nil, nil)
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -42,6 +43,30 @@ func (p *TagsService) GetTag(ctx context.Context, tagUID uint32) (resp TagRespon
 	return resp, err
 }
 
+// TagsResponse represents ListTags's response
+type TagsResponse struct {
+	Tags []TagResponse `json:"tags"`
+}
+
+// ListTags lists tags of the node, starting at the offset. Limit of 0 lists
+// the node's default number of tags.
+func (p *TagsService) ListTags(ctx context.Context, offset, limit int) (resp TagsResponse, err error) {
+	v := url.Values{}
+	if offset > 0 {
+		v.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		v.Set("limit", strconv.Itoa(limit))
+	}
+	path := "/tags"
+	if len(v) > 0 {
+		path += "?" + v.Encode()
+	}
+
+	err = p.client.requestJSON(ctx, http.MethodGet, path, nil, &resp)
+	return resp, err
+}
+
 func (p *TagsService) WaitSync(ctx context.Context, tagUID uint32) (err error) {
 
 	c := make(chan bool)
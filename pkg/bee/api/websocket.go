@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebsocketMessage is a message received on a websocket subscription
+type WebsocketMessage struct {
+	Data       []byte
+	ReceivedAt time.Time
+}
+
+// Subscription streams messages received on a websocket until its context
+// is done or the connection is closed
+type Subscription struct {
+	// C receives messages, it is closed when the subscription ends
+	C <-chan WebsocketMessage
+
+	mu  sync.Mutex
+	err error
+}
+
+// Err returns the error that ended the subscription, nil while it is active
+// or if it ended with its context
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Subscription) setErr(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// subscribe opens a websocket on the path and streams received messages on
+// the subscription
+func (c *Client) subscribe(ctx context.Context, path string) (*Subscription, error) {
+	ws, err := c.dialWebsocket(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan WebsocketMessage)
+	s := &Subscription{C: ch}
+	done := make(chan struct{})
+
+	// closing the connection stops reading when the context is done
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		ws.Close()
+	}()
+
+	go func() {
+		defer close(ch)
+		defer close(done)
+		for {
+			_, data, err := ws.ReadMessage()
+			if err != nil {
+				if ctx.Err() == nil && !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+					s.setErr(fmt.Errorf("websocket %s: %w", path, err))
+				}
+				return
+			}
+
+			select {
+			case ch <- WebsocketMessage{Data: data, ReceivedAt: time.Now()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// dialWebsocket opens a websocket on the path, with the header in addition
+// to authentication headers
+func (c *Client) dialWebsocket(ctx context.Context, path string, h http.Header) (*websocket.Conn, error) {
+	u, err := c.baseURL.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+
+	header := h.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("User-Agent", userAgent)
+	if c.restricted {
+		key, err := GetToken(path, http.MethodGet)
+		if err != nil {
+			return nil, err
+		}
+		header.Set("Authorization", "Bearer "+key)
+	} else if c.bearerToken != "" {
+		header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 45 * time.Second,
+		TLSClientConfig:  c.tlsConfig,
+	}
+	ws, resp, err := dialer.DialContext(ctx, u.String(), header)
+	if err != nil {
+		if resp != nil {
+			defer drain(resp.Body)
+			if herr := responseErrorHandler(resp); herr != nil {
+				return nil, fmt.Errorf("websocket %s: %w", path, herr)
+			}
+		}
+		return nil, fmt.Errorf("websocket %s: %w", path, err)
+	}
+
+	return ws, nil
+}
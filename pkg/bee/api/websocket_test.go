@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestPSSSubscribe(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pss/subscribe/test" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		for _, m := range []string{"first", "second"} {
+			if err := ws.WriteMessage(websocket.BinaryMessage, []byte(m)); err != nil {
+				return
+			}
+		}
+		_ = ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+	defer s.Close()
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(u, nil)
+
+	sub, err := c.PSS.Subscribe(context.Background(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for msg := range sub.C {
+		got = append(got, string(msg.Data))
+	}
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Fatalf("got messages %v, want [first second]", got)
+	}
+	if err := sub.Err(); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if _, err := c.PSS.Subscribe(context.Background(), "other"); !IsHTTPStatusErrorCode(err, http.StatusNotFound) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+}
+
+func TestPSSSubscribeContext(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		// block until the client closes the connection
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer s.Close()
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := NewClient(u, nil).PSS.Subscribe(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-sub.C:
+		if ok {
+			t.Fatal("got message")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not closed with the context")
+	}
+	if err := sub.Err(); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+}
+
+func TestPSSSubscribeTLS(t *testing.T) {
+	var authorization string
+	upgrader := websocket.Upgrader{}
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		_ = ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+	defer s.Close()
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(u, &ClientOptions{
+		TLSConfig:   s.Client().Transport.(*http.Transport).TLSClientConfig,
+		BearerToken: "secret",
+	})
+
+	sub, err := c.PSS.Subscribe(context.Background(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range sub.C {
+	}
+	if got, want := authorization, "Bearer secret"; got != want {
+		t.Fatalf("got authorization %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package bee
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// NewTLSConfig returns the TLS configuration verifying nodes with CAs of the
+// PEM encoded CA file, in addition to system CAs, and authenticating with
+// the client certificate, if the files are set, like for nodes behind
+// ingresses with mutual TLS
+func NewTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in CA file %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// tlsConfig returns a copy of the TLS configuration, or a new one, skipping
+// verification of the node if insecure is set
+func tlsConfig(cfg *tls.Config, insecure bool) *tls.Config {
+	if cfg == nil {
+		cfg = &tls.Config{}
+	} else {
+		cfg = cfg.Clone()
+	}
+	if insecure {
+		cfg.InsecureSkipVerify = true
+	}
+	return cfg
+}
+
+// bearerTransport authenticates requests to a Bee API with the bearer token,
+// like to an authenticating ingress in front of the node
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+// newBearerTransport returns the transport authenticating requests with the
+// token, or the next transport if the token is not set
+func newBearerTransport(token string, next http.RoundTripper) http.RoundTripper {
+	if token == "" {
+		return next
+	}
+
+	return &bearerTransport{
+		token: token,
+		next:  next,
+	}
+}
+
+// RoundTrip sets the token of the request, unless it is already
+// authenticated, like with the security token of a restricted node
+func (t *bearerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Header.Get("Authorization") != "" {
+		return t.next.RoundTrip(r)
+	}
+
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+t.token)
+
+	return t.next.RoundTrip(r)
+}
@@ -1,6 +1,7 @@
 package bee
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"crypto/tls"
@@ -10,25 +11,29 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethersphere/bee/pkg/swarm"
 	"github.com/ethersphere/beekeeper/pkg/bee/api"
 	"github.com/ethersphere/beekeeper/pkg/bee/debugapi"
+	"github.com/ethersphere/beekeeper/pkg/bigint"
 	"github.com/ethersphere/beekeeper/pkg/logging"
 )
 
-const retryCount int = 5
-
 // Client manages communication with the Bee node
 type Client struct {
 	api    *api.Client
 	debug  *debugapi.Client
 	opts   ClientOptions
 	logger logging.Logger
-	// number of times to retry call
-	retry int
+	// resources created through the client, released on teardown
+	createdMu      sync.Mutex
+	createdPins    []swarm.Address
+	createdBatches []string
 }
 
 // ClientOptions holds optional parameters for the Client.
@@ -37,32 +42,47 @@ type ClientOptions struct {
 	APIInsecureTLS      bool
 	DebugAPIURL         *url.URL
 	DebugAPIInsecureTLS bool
-	Retry               int
+	Retry               RetryOptions
 	Restricted          bool
+	// TLSConfig of requests to both APIs, like custom CAs or client
+	// certificates, insecure TLS options skip verification on top of it
+	TLSConfig *tls.Config
+	// CAFile, CertFile and KeyFile are the files TLSConfig is loaded from,
+	// for clients of the node other than Beekeeper, like check plugins
+	CAFile   string
+	CertFile string
+	KeyFile  string
+	// BearerToken authenticates requests that are not authenticated with the
+	// security token of a restricted node
+	BearerToken string
+	// RateLimiter limits requests of clients of all nodes it is shared by,
+	// like all nodes of the cluster, nil for no limit
+	RateLimiter *RateLimiter
+	// NodeRateLimit limits requests per second to the node, both APIs
+	// together, 0 for no limit
+	NodeRateLimit      float64
+	NodeRateLimitBurst int
+	// Transport tunes connections to the node
+	Transport TransportOptions
 }
 
 // NewClient returns Bee client
 func NewClient(opts ClientOptions, logger logging.Logger) (c *Client) {
 	c = &Client{
-		retry:  retryCount,
 		opts:   opts,
 		logger: logger,
 	}
 
+	nodeLimiter := NewRateLimiter(opts.NodeRateLimit, opts.NodeRateLimitBurst)
+	retryBudget := newRetryBudget(opts.Retry.Budget)
+
 	if opts.APIURL != nil {
-		c.api = api.NewClient(opts.APIURL, &api.ClientOptions{HTTPClient: &http.Client{Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: opts.APIInsecureTLS},
-		}}, Restricted: opts.Restricted})
+		apiTLSConfig := tlsConfig(opts.TLSConfig, opts.APIInsecureTLS)
+		c.api = api.NewClient(opts.APIURL, &api.ClientOptions{HTTPClient: &http.Client{Transport: newRetryTransport("api", opts.Retry, retryBudget, newRateLimitTransport("api", opts.RateLimiter, nodeLimiter, newBearerTransport(opts.BearerToken, newTraceTransport("api", newTracingTransport("api", newHTTPTransport(opts.Transport, apiTLSConfig))))))}, Restricted: opts.Restricted, TLSConfig: apiTLSConfig, BearerToken: opts.BearerToken})
 	}
 	if opts.DebugAPIURL != nil {
-		c.debug = debugapi.NewClient(opts.DebugAPIURL, &debugapi.ClientOptions{HTTPClient: &http.Client{Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: opts.DebugAPIInsecureTLS},
-		}}, Restricted: opts.Restricted})
+		c.debug = debugapi.NewClient(opts.DebugAPIURL, &debugapi.ClientOptions{HTTPClient: &http.Client{Transport: newRetryTransport("debug", opts.Retry, retryBudget, newRateLimitTransport("debug", opts.RateLimiter, nodeLimiter, newBearerTransport(opts.BearerToken, newTraceTransport("debug", newTracingTransport("debug", newHTTPTransport(opts.Transport, tlsConfig(opts.TLSConfig, opts.DebugAPIInsecureTLS)))))))}, Restricted: opts.Restricted})
 	}
-	if opts.Retry > 0 {
-		c.retry = opts.Retry
-	}
-
 	return
 }
 
@@ -124,23 +144,35 @@ func (c *Client) Accounting(ctx context.Context) (resp Accounting, err error) {
 
 	for peer, b := range r.Accounting {
 		resp.Accounting = append(resp.Accounting, Account{
-			Balance:                  b.Balance.Int64(),
-			ConsumedBalance:          b.ConsumedBalance.Int64(),
-			ThresholdReceived:        b.ThresholdReceived.Int64(),
-			ThresholdGiven:           b.ThresholdGiven.Int64(),
-			SurplusBalance:           b.SurplusBalance.Int64(),
-			CurrentThresholdReceived: b.CurrentThresholdReceived.Int64(),
-			CurrentThresholdGiven:    b.CurrentThresholdGiven.Int64(),
-			ReservedBalance:          b.ReservedBalance.Int64(),
-			ShadowReservedBalance:    b.ShadowReservedBalance.Int64(),
-			GhostBalance:             b.GhostBalance.Int64(),
+			Balance:                  int64Value(b.Balance),
+			ConsumedBalance:          int64Value(b.ConsumedBalance),
+			ThresholdReceived:        int64Value(b.ThresholdReceived),
+			ThresholdGiven:           int64Value(b.ThresholdGiven),
+			SurplusBalance:           int64Value(b.SurplusBalance),
+			CurrentThresholdReceived: int64Value(b.CurrentThresholdReceived),
+			CurrentThresholdGiven:    int64Value(b.CurrentThresholdGiven),
+			ReservedBalance:          int64Value(b.ReservedBalance),
+			ShadowReservedBalance:    int64Value(b.ShadowReservedBalance),
+			GhostBalance:             int64Value(b.GhostBalance),
 			Peer:                     peer,
 		})
 	}
+	sort.Slice(resp.Accounting, func(i, j int) bool {
+		return resp.Accounting[i].Peer < resp.Accounting[j].Peer
+	})
 
 	return
 }
 
+// int64Value returns the value of the accounting field, 0 for fields older
+// nodes do not report
+func int64Value(i *bigint.BigInt) int64 {
+	if i == nil || i.Int == nil {
+		return 0
+	}
+	return i.Int64()
+}
+
 // Balance represents node's balance with peer
 type Balance struct {
 	Balance int64
@@ -221,11 +253,39 @@ func (c *Client) DownloadFile(ctx context.Context, a swarm.Address) (size int64,
 	return size, h.Sum(nil), nil
 }
 
+// DownloadFileCID downloads a file addressed by a Swarm CID from the node and
+// returns its size and hash
+func (c *Client) DownloadFileCID(ctx context.Context, cid string) (size int64, hash []byte, err error) {
+	r, err := c.api.Files.DownloadCID(ctx, cid)
+	if err != nil {
+		return 0, nil, fmt.Errorf("download file %s: %w", cid, err)
+	}
+	defer r.Close()
+
+	h := fileHasher()
+	size, err = io.Copy(h, r)
+	if err != nil {
+		return 0, nil, fmt.Errorf("download file %s, hashing copy: %w", cid, err)
+	}
+
+	return size, h.Sum(nil), nil
+}
+
 // HasChunk returns true/false if node has a chunk
 func (c *Client) HasChunk(ctx context.Context, a swarm.Address) (bool, error) {
 	return c.debug.Node.HasChunk(ctx, a)
 }
 
+// ProbeChunk reports whether the chunk is in the local store of the node
+// with a HEAD request, without downloading it
+func (c *Client) ProbeChunk(ctx context.Context, a swarm.Address) (bool, error) {
+	has, err := c.api.Chunks.Has(ctx, a)
+	if err != nil {
+		return false, fmt.Errorf("probe chunk %s: %w", a, err)
+	}
+	return has, nil
+}
+
 func (c *Client) HasChunks(ctx context.Context, a []swarm.Address) (has []bool, count int, err error) {
 	has = make([]bool, len(a))
 	for i, addr := range a {
@@ -243,16 +303,7 @@ func (c *Client) HasChunks(ctx context.Context, a []swarm.Address) (has []bool,
 
 // Overlay returns node's overlay address
 func (c *Client) Overlay(ctx context.Context) (o swarm.Address, err error) {
-	var a debugapi.Addresses
-	for r := 0; r < c.retry; r++ {
-		time.Sleep(2 * time.Duration(r) * time.Second)
-
-		a, err = c.debug.Node.Addresses(ctx)
-		if err != nil {
-			continue
-		}
-		break
-	}
+	a, err := c.debug.Node.Addresses(ctx)
 	if err != nil {
 		return swarm.Address{}, fmt.Errorf("get addresses: %w", err)
 	}
@@ -277,23 +328,59 @@ func (c *Client) Peers(ctx context.Context) (peers []swarm.Address, err error) {
 
 // PinRootHash pins root hash of given reference.
 func (c *Client) PinRootHash(ctx context.Context, ref swarm.Address) error {
-	return c.api.Pinning.PinRootHash(ctx, ref)
+	if err := c.api.Pinning.PinRootHash(ctx, ref); err != nil {
+		return fmt.Errorf("pin %s: %w", ref, err)
+	}
+	c.recordPin(true, ref)
+	return nil
 }
 
 // UnpinRootHash unpins root hash of given reference.
 func (c *Client) UnpinRootHash(ctx context.Context, ref swarm.Address) error {
-	return c.api.Pinning.UnpinRootHash(ctx, ref)
+	if err := c.api.Pinning.UnpinRootHash(ctx, ref); err != nil {
+		return fmt.Errorf("unpin %s: %w", ref, err)
+	}
+	c.forgetPin(ref)
+	return nil
 }
 
 // GetPinnedRootHash determines if the root hash of
-// given reference is pinned by returning its reference.
+// given reference is pinned by returning its reference, zero address if it is
+// not pinned.
 func (c *Client) GetPinnedRootHash(ctx context.Context, ref swarm.Address) (swarm.Address, error) {
-	return c.api.Pinning.GetPinnedRootHash(ctx, ref)
+	pinned, err := c.api.Pinning.GetPinnedRootHash(ctx, ref)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("get pin %s: %w", ref, err)
+	}
+	return pinned, nil
+}
+
+// RepairPin re-uploads chunks of the pinned reference to the network,
+// stamped with the postage batch, and verifies the reference is still pinned
+// after the repair. Chunks missing from the pin are not repaired, they have
+// to be pinned again.
+func (c *Client) RepairPin(ctx context.Context, ref swarm.Address, batchID string) error {
+	if err := c.api.Stewardship.Reupload(ctx, ref, batchID); err != nil {
+		return fmt.Errorf("repair pin %s: %w", ref, err)
+	}
+
+	pinned, err := c.api.Pinning.GetPinnedRootHash(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("repair pin %s: get pin: %w", ref, err)
+	}
+	if !pinned.Equal(ref) {
+		return fmt.Errorf("repair pin %s: reference is not pinned", ref)
+	}
+	return nil
 }
 
 // GetPins returns all references of pinned root hashes.
 func (c *Client) GetPins(ctx context.Context) ([]swarm.Address, error) {
-	return c.api.Pinning.GetPins(ctx)
+	pins, err := c.api.Pinning.GetPins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get pins: %w", err)
+	}
+	return pins, nil
 }
 
 // Ping pings other node
@@ -383,6 +470,7 @@ func (c *Client) CreatePostageBatch(ctx context.Context, amount int64, depth uin
 	if err != nil {
 		return "", fmt.Errorf("create postage stamp: %w", err)
 	}
+	c.recordBatch(id)
 
 	usable := false
 	// wait for the stamp to become usable
@@ -507,11 +595,243 @@ func (c *Client) ReserveState(ctx context.Context) (debugapi.ReserveState, error
 	return c.debug.Postage.ReserveState(ctx)
 }
 
+// Batches returns all postage batches known to the node, including batches
+// owned by other nodes
+func (c *Client) Batches(ctx context.Context) ([]debugapi.Batch, error) {
+	return c.debug.Postage.Batches(ctx)
+}
+
+// ChainState returns chain state of the node
+func (c *Client) ChainState(ctx context.Context) (debugapi.ChainState, error) {
+	return c.debug.Postage.ChainState(ctx)
+}
+
+// Health returns node's health status and versions
+func (c *Client) Health(ctx context.Context) (debugapi.Health, error) {
+	return c.debug.Node.Health(ctx)
+}
+
+// Transactions returns pending on-chain transactions of the node
+func (c *Client) Transactions(ctx context.Context) ([]debugapi.Transaction, error) {
+	txs, err := c.debug.Node.Transactions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("transactions: %w", err)
+	}
+	return txs, nil
+}
+
+// Transaction returns the pending on-chain transaction of the node
+func (c *Client) Transaction(ctx context.Context, txHash string) (debugapi.Transaction, error) {
+	tx, err := c.debug.Node.Transaction(ctx, txHash)
+	if err != nil {
+		return debugapi.Transaction{}, fmt.Errorf("transaction %s: %w", txHash, err)
+	}
+	return tx, nil
+}
+
+// RebroadcastTransaction sends the pending transaction to the chain again
+func (c *Client) RebroadcastTransaction(ctx context.Context, txHash string) (string, error) {
+	r, err := c.debug.Node.RebroadcastTransaction(ctx, txHash)
+	if err != nil {
+		return "", fmt.Errorf("rebroadcast transaction %s: %w", txHash, err)
+	}
+	return r.TransactionHash, nil
+}
+
+// CancelTransaction cancels the pending transaction with a replacing one
+// with the gas price, node's suggested gas price if it is empty, and returns
+// hash of the replacing transaction
+func (c *Client) CancelTransaction(ctx context.Context, txHash, gasPrice string) (string, error) {
+	r, err := c.debug.Node.CancelTransaction(ctx, txHash, gasPrice)
+	if err != nil {
+		return "", fmt.Errorf("cancel transaction %s: %w", txHash, err)
+	}
+	return r.TransactionHash, nil
+}
+
+// RebroadcastStuckTransactions rebroadcasts pending transactions of the node
+// created longer than the age ago and returns their hashes. Transactions
+// are rebroadcast while the context is not done, errors of single
+// transactions are returned together.
+func (c *Client) RebroadcastStuckTransactions(ctx context.Context, age time.Duration) ([]string, error) {
+	txs, err := c.Transactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		rebroadcast []string
+		errs        []error
+	)
+	for _, tx := range txs {
+		if time.Since(tx.Created) < age {
+			continue
+		}
+		if _, err := c.RebroadcastTransaction(ctx, tx.TransactionHash); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rebroadcast = append(rebroadcast, tx.TransactionHash)
+	}
+
+	return rebroadcast, errors.Join(errs...)
+}
+
+// Status returns status snapshot of the node, like its reserve size, storage
+// radius and connected peers
+func (c *Client) Status(ctx context.Context) (debugapi.Status, error) {
+	s, err := c.debug.Node.Status(ctx)
+	if err != nil {
+		return debugapi.Status{}, fmt.Errorf("status: %w", err)
+	}
+	return s, nil
+}
+
+// StatusPeers returns status snapshots of the node's peers
+func (c *Client) StatusPeers(ctx context.Context) ([]debugapi.Status, error) {
+	s, err := c.debug.Node.StatusPeers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("status peers: %w", err)
+	}
+	return s, nil
+}
+
+// APIResponse returns the decoded JSON response of a GET request to the API
+// path, without mapping it to a type
+func (c *Client) APIResponse(ctx context.Context, path string) (resp interface{}, err error) {
+	if err := c.api.Get(ctx, path, &resp); err != nil {
+		return nil, fmt.Errorf("api %s: %w", path, err)
+	}
+
+	return resp, nil
+}
+
+// DebugAPIResponse returns the decoded JSON response of a GET request to the
+// Debug API path, without mapping it to a type
+func (c *Client) DebugAPIResponse(ctx context.Context, path string) (resp interface{}, err error) {
+	if err := c.debug.Get(ctx, path, &resp); err != nil {
+		return nil, fmt.Errorf("debug api %s: %w", path, err)
+	}
+
+	return resp, nil
+}
+
+// APIRawResponse returns the raw response of a GET request to the API path
+// regardless of its status code. The caller must close the response body.
+func (c *Client) APIRawResponse(ctx context.Context, path string) (*http.Response, error) {
+	return c.api.Do(ctx, http.MethodGet, path, nil, nil)
+}
+
+// APIRequest sends a request with the header and body to the API path as is
+// and returns the raw response regardless of its status code. It is meant for
+// sending malformed requests. The caller must close the response body.
+func (c *Client) APIRequest(ctx context.Context, method, path string, header http.Header, body io.Reader) (*http.Response, error) {
+	return c.api.Do(ctx, method, path, header, body)
+}
+
+// DebugAPIRawResponse returns the raw response of a GET request to the Debug
+// API path regardless of its status code. The caller must close the response
+// body.
+func (c *Client) DebugAPIRawResponse(ctx context.Context, path string) (*http.Response, error) {
+	return c.debug.Do(ctx, http.MethodGet, path)
+}
+
+// Goroutines returns the number of goroutines of the node reported by the
+// go_goroutines metric of the Debug API
+func (c *Client) Goroutines(ctx context.Context) (int, error) {
+	v, err := c.Metric(ctx, "go_goroutines")
+	if err != nil {
+		return 0, err
+	}
+
+	return int(v), nil
+}
+
+// Metric returns the value of the unlabeled metric exposed on the Debug API
+// metrics endpoint
+func (c *Client) Metric(ctx context.Context, name string) (float64, error) {
+	resp, err := c.debug.Do(ctx, http.MethodGet, "/metrics")
+	if err != nil {
+		return 0, fmt.Errorf("metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("metrics: status %s", resp.Status)
+	}
+
+	prefix := name + " "
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(line, prefix)), 64)
+		if err != nil {
+			return 0, fmt.Errorf("metrics: parse %s: %w", name, err)
+		}
+		return v, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("metrics: %w", err)
+	}
+
+	return 0, fmt.Errorf("metrics: %s not found", name)
+}
+
+// Readiness returns true if the node is ready to serve requests
+func (c *Client) Readiness(ctx context.Context) (bool, error) {
+	r, err := c.debug.Node.Readiness(ctx)
+	if err != nil {
+		return false, fmt.Errorf("readiness: %w", err)
+	}
+
+	return r.Status == "ok", nil
+}
+
+// Time returns node's current time
+func (c *Client) Time(ctx context.Context) (time.Time, error) {
+	t, err := c.debug.Node.Time(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("time: %w", err)
+	}
+
+	return t, nil
+}
+
 // SendPSSMessage triggers a PSS message with a topic and recipient address
 func (c *Client) SendPSSMessage(ctx context.Context, nodeAddress swarm.Address, publicKey string, topic string, prefix int, data []byte, batchID string) error {
 	return c.api.PSS.SendMessage(ctx, nodeAddress, publicKey, topic, prefix, bytes.NewReader(data), batchID)
 }
 
+// SubscribePSS subscribes to PSS messages with the topic sent to the node,
+// until the context is done
+func (c *Client) SubscribePSS(ctx context.Context, topic string) (*api.Subscription, error) {
+	s, err := c.api.PSS.Subscribe(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe pss topic %s: %w", topic, err)
+	}
+	return s, nil
+}
+
+// SubscribeGSOC subscribes to GSOC messages of the single owner chunk at the
+// address, until the context is done
+func (c *Client) SubscribeGSOC(ctx context.Context, address swarm.Address) (*api.Subscription, error) {
+	s, err := c.api.SOC.SubscribeGSOC(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe gsoc %s: %w", address, err)
+	}
+	return s, nil
+}
+
+// SendPSSMessageToTargets sends a PSS message to a recipient whose neighborhood
+// is described by hex encoded targets
+func (c *Client) SendPSSMessageToTargets(ctx context.Context, targets []string, publicKey string, topic string, data []byte, batchID string) error {
+	return c.api.PSS.SendMessageToTargets(ctx, targets, publicKey, topic, bytes.NewReader(data), batchID)
+}
+
 // UploadSOC uploads a single owner chunk to a node with a E
 func (c *Client) UploadSOC(ctx context.Context, owner, ID, signature string, data []byte, batchID string) (swarm.Address, error) {
 	resp, err := c.api.SOC.UploadSOC(ctx, owner, ID, signature, bytes.NewReader(data), batchID)
@@ -549,6 +869,26 @@ func (c *Client) Settlements(ctx context.Context) (resp Settlements, err error)
 	return
 }
 
+// TimeSettlements returns node's pseudosettle (time based) settlements
+func (c *Client) TimeSettlements(ctx context.Context) (resp Settlements, err error) {
+	r, err := c.debug.Node.TimeSettlements(ctx)
+	if err != nil {
+		return Settlements{}, fmt.Errorf("get time settlements: %w", err)
+	}
+
+	for _, b := range r.Settlements {
+		resp.Settlements = append(resp.Settlements, Settlement{
+			Peer:     b.Peer,
+			Received: b.Received.Int64(),
+			Sent:     b.Sent.Int64(),
+		})
+	}
+	resp.TotalReceived = r.TotalReceived.Int64()
+	resp.TotalSent = r.TotalSent.Int64()
+
+	return
+}
+
 type Cheque struct {
 	Beneficiary string
 	Chequebook  string
@@ -585,18 +925,27 @@ func (c *Client) CashoutStatus(ctx context.Context, a swarm.Address) (resp Casho
 	}
 
 	return CashoutStatusResponse{
-		Peer: r.Peer,
-		Cheque: &Cheque{
-			Beneficiary: r.Cheque.Beneficiary,
-			Chequebook:  r.Cheque.Chequebook,
-			Payout:      r.Cheque.Payout.Int,
-		},
+		Peer:            r.Peer,
+		Cheque:          newCheque(r.Cheque),
 		TransactionHash: r.TransactionHash,
 		Result:          cashoutStatusResult,
 		UncashedAmount:  r.UncashedAmount.Int,
 	}, nil
 }
 
+// newCheque converts debug API cheque, returns nil if there is no cheque
+func newCheque(c *debugapi.Cheque) *Cheque {
+	if c == nil {
+		return nil
+	}
+
+	return &Cheque{
+		Beneficiary: c.Beneficiary,
+		Chequebook:  c.Chequebook,
+		Payout:      c.Payout.Int,
+	}
+}
+
 func (c *Client) Cashout(ctx context.Context, a swarm.Address) (resp string, err error) {
 	r, err := c.debug.Node.Cashout(ctx, a)
 	if err != nil {
@@ -623,6 +972,125 @@ func (c *Client) ChequebookBalance(ctx context.Context) (resp ChequebookBalanceR
 	}, nil
 }
 
+// ChequebookAddress returns address of the node's chequebook contract
+func (c *Client) ChequebookAddress(ctx context.Context) (string, error) {
+	r, err := c.debug.Node.ChequebookAddress(ctx)
+	if err != nil {
+		return "", fmt.Errorf("chequebook address: %w", err)
+	}
+
+	return r.ChequebookAddress, nil
+}
+
+// LastCheques represents last cheques sent to and received from a peer
+type LastCheques struct {
+	Peer         swarm.Address
+	LastReceived *Cheque
+	LastSent     *Cheque
+}
+
+// LastCheques returns last cheques sent to and received from all peers
+func (c *Client) LastCheques(ctx context.Context) (resp []LastCheques, err error) {
+	r, err := c.debug.Node.LastCheques(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("last cheques: %w", err)
+	}
+
+	for _, lc := range r {
+		peer, err := swarm.ParseHexAddress(lc.Peer)
+		if err != nil {
+			return nil, fmt.Errorf("last cheques: peer %s: %w", lc.Peer, err)
+		}
+		resp = append(resp, LastCheques{
+			Peer:         peer,
+			LastReceived: newCheque(lc.LastReceived),
+			LastSent:     newCheque(lc.LastSent),
+		})
+	}
+
+	return
+}
+
+// LastChequesPeer returns last cheques sent to and received from a given peer
+func (c *Client) LastChequesPeer(ctx context.Context, a swarm.Address) (resp LastCheques, err error) {
+	r, err := c.debug.Node.LastChequesPeer(ctx, a)
+	if err != nil {
+		return LastCheques{}, fmt.Errorf("last cheques peer %s: %w", a, err)
+	}
+
+	return LastCheques{
+		Peer:         a,
+		LastReceived: newCheque(r.LastReceived),
+		LastSent:     newCheque(r.LastSent),
+	}, nil
+}
+
+// CashoutWithGasPrice cashes out the last cheque received from a given peer using a given gas price
+func (c *Client) CashoutWithGasPrice(ctx context.Context, a swarm.Address, gasPrice string) (resp string, err error) {
+	r, err := c.debug.Node.CashoutWithGasPrice(ctx, a, gasPrice)
+	if err != nil {
+		return "", fmt.Errorf("cashout: %w", err)
+	}
+
+	return r.TransactionHash, nil
+}
+
+// ChequebookDeposit deposits a given amount of BZZ to the node's chequebook
+func (c *Client) ChequebookDeposit(ctx context.Context, amount int64) (string, error) {
+	r, err := c.debug.Node.ChequebookDeposit(ctx, amount)
+	if err != nil {
+		return "", fmt.Errorf("chequebook deposit: %w", err)
+	}
+
+	return r.TransactionHash, nil
+}
+
+// ChequebookWithdraw withdraws a given amount of BZZ from the node's chequebook
+func (c *Client) ChequebookWithdraw(ctx context.Context, amount int64) (string, error) {
+	r, err := c.debug.Node.ChequebookWithdraw(ctx, amount)
+	if err != nil {
+		return "", fmt.Errorf("chequebook withdraw: %w", err)
+	}
+
+	return r.TransactionHash, nil
+}
+
+// WalletResponse represents node's wallet balances
+type WalletResponse struct {
+	BZZ                       *big.Int
+	NativeToken               *big.Int
+	ChainID                   int64
+	ChequebookContractAddress string
+	WalletAddress             string
+}
+
+// Wallet returns node's wallet balances
+func (c *Client) Wallet(ctx context.Context) (resp WalletResponse, err error) {
+	r, err := c.debug.Node.Wallet(ctx)
+	if err != nil {
+		return WalletResponse{}, fmt.Errorf("wallet: %w", err)
+	}
+
+	return WalletResponse{
+		BZZ:                       r.BZZ.Int,
+		NativeToken:               r.NativeToken.Int,
+		ChainID:                   r.ChainID,
+		ChequebookContractAddress: r.ChequebookContractAddress,
+		WalletAddress:             r.WalletAddress,
+	}, nil
+}
+
+// WalletWithdraw withdraws a given amount of a given coin (bzz or nativetoken)
+// from the node's wallet to a whitelisted address
+func (c *Client) WalletWithdraw(ctx context.Context, coin, address string, amount *big.Int) (string, error) {
+	r, err := c.debug.Node.WalletWithdraw(ctx, coin, address, amount)
+	if err != nil {
+		return "", fmt.Errorf("wallet withdraw %s: %w", coin, err)
+	}
+
+	return r.TransactionHash, nil
+}
+
 // Topology represents Kademlia topology
 type Topology struct {
 	Overlay             swarm.Address
@@ -646,16 +1114,7 @@ type Bin struct {
 
 // Topology returns Kademlia topology
 func (c *Client) Topology(ctx context.Context) (topology Topology, err error) {
-	var t debugapi.Topology
-	for r := 0; r < c.retry; r++ {
-		time.Sleep(2 * time.Duration(r) * time.Second)
-
-		t, err = c.debug.Node.Topology(ctx)
-		if err != nil {
-			continue
-		}
-		break
-	}
+	t, err := c.debug.Node.Topology(ctx)
 	if err != nil {
 		return Topology{}, fmt.Errorf("get topology: %w", err)
 	}
@@ -718,6 +1177,7 @@ func (c *Client) UploadBytes(ctx context.Context, b []byte, o api.UploadOptions)
 	if err != nil {
 		return swarm.ZeroAddress, fmt.Errorf("upload bytes: %w", err)
 	}
+	c.recordPin(o.Pin, r.Reference)
 
 	return r.Reference, nil
 }
@@ -728,10 +1188,36 @@ func (c *Client) UploadChunk(ctx context.Context, data []byte, o api.UploadOptio
 	if err != nil {
 		return swarm.ZeroAddress, fmt.Errorf("upload chunk: %w", err)
 	}
+	c.recordPin(o.Pin, resp.Reference)
 
 	return resp.Reference, nil
 }
 
+// StreamChunks opens the stream uploading chunks to the node on a websocket,
+// without a request for every chunk
+func (c *Client) StreamChunks(ctx context.Context, o api.ChunkStreamOptions) (*api.ChunkStream, error) {
+	s, err := c.api.Chunks.Stream(ctx, o)
+	if err != nil {
+		return nil, fmt.Errorf("stream chunks: %w", err)
+	}
+	return s, nil
+}
+
+// StampChunk returns the hex encoded postage stamp of the batch for the chunk
+// address, signed by the node that owns the batch. Chunks uploaded with the
+// stamp in upload options are accepted by nodes that do not own the batch.
+func (c *Client) StampChunk(ctx context.Context, batchID string, address swarm.Address) (string, error) {
+	e, err := c.api.Envelope.Create(ctx, batchID, address)
+	if err != nil {
+		return "", fmt.Errorf("stamp chunk %s: %w", address, err)
+	}
+	stamp, err := e.Stamp(batchID)
+	if err != nil {
+		return "", fmt.Errorf("stamp chunk %s: %w", address, err)
+	}
+	return stamp, nil
+}
+
 // UploadFile uploads file to the node
 func (c *Client) UploadFile(ctx context.Context, f *File, o api.UploadOptions) (err error) {
 	h := fileHasher()
@@ -739,6 +1225,7 @@ func (c *Client) UploadFile(ctx context.Context, f *File, o api.UploadOptions) (
 	if err != nil {
 		return fmt.Errorf("upload file: %w", err)
 	}
+	c.recordPin(o.Pin, r.Reference)
 
 	f.SetAddress(r.Reference)
 	f.SetHash(h.Sum(nil))
@@ -753,6 +1240,7 @@ func (c *Client) UploadCollection(ctx context.Context, f *File, o api.UploadOpti
 	if err != nil {
 		return fmt.Errorf("upload collection: %w", err)
 	}
+	c.recordPin(o.Pin, r.Reference)
 
 	f.SetAddress(r.Reference)
 	f.SetHash(h.Sum(nil))
@@ -797,15 +1285,58 @@ func (c *Client) GetTag(ctx context.Context, tagUID uint32) (resp api.TagRespons
 	return
 }
 
+// WaitTag polls the tag every interval until its counters satisfy the
+// condition, like all chunks being stored or synced, and returns the tag
+func (c *Client) WaitTag(ctx context.Context, tagUID uint32, interval time.Duration, cond func(api.TagResponse) bool) (api.TagResponse, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		tag, err := c.api.Tags.GetTag(ctx, tagUID)
+		if err != nil {
+			return tag, fmt.Errorf("wait tag %d: %w", tagUID, err)
+		}
+		if cond(tag) {
+			return tag, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return tag, fmt.Errorf("wait tag %d: split %d, stored %d, synced %d of %d: %w", tagUID, tag.Split, tag.Stored, tag.Synced, tag.Total, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListTags lists tags of the node, starting at the offset, limit of 0 lists
+// the node's default number of tags
+func (c *Client) ListTags(ctx context.Context, offset, limit int) ([]api.TagResponse, error) {
+	resp, err := c.api.Tags.ListTags(ctx, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+
+	return resp.Tags, nil
+}
+
 // IsRetrievable checks whether the content on the given address is retrievable.
 func (c *Client) IsRetrievable(ctx context.Context, ref swarm.Address) (bool, error) {
-	return c.api.Stewardship.IsRetrievable(ctx, ref)
+	ok, err := c.api.Stewardship.IsRetrievable(ctx, ref)
+	if err != nil {
+		return false, fmt.Errorf("is retrievable %s: %w", ref, err)
+	}
+
+	return ok, nil
 }
 
 // Reupload re-uploads root hash and all of its underlying associated chunks to
-// the network.
-func (c *Client) Reupload(ctx context.Context, ref swarm.Address) error {
-	return c.api.Stewardship.Reupload(ctx, ref)
+// the network, stamped with the postage batch.
+func (c *Client) Reupload(ctx context.Context, ref swarm.Address, batchID string) error {
+	if err := c.api.Stewardship.Reupload(ctx, ref, batchID); err != nil {
+		return fmt.Errorf("reupload %s: %w", ref, err)
+	}
+
+	return nil
 }
 
 // Authenticate
@@ -830,6 +1361,16 @@ func (c *Client) GetStake(ctx context.Context) (*big.Int, error) {
 	return c.debug.Stake.GetStakedAmount(ctx)
 }
 
+// GetRedistributionState returns state of the node's storage incentives
+// agent, like the phase and round it is in and the last round it won
+func (c *Client) GetRedistributionState(ctx context.Context) (debugapi.RedistributionState, error) {
+	s, err := c.debug.Stake.GetRedistributionState(ctx)
+	if err != nil {
+		return debugapi.RedistributionState{}, fmt.Errorf("get redistribution state: %w", err)
+	}
+	return s, nil
+}
+
 // WithdrawStake withdraws stake
 func (c *Client) WithdrawStake(ctx context.Context) (string, error) {
 	return c.debug.Stake.WithdrawStake(ctx)
@@ -90,6 +90,33 @@ func httpClientWithTransport(baseURL *url.URL, c *http.Client) *http.Client {
 	return c
 }
 
+// Get sends a GET request to the Debug API path and decodes the JSON response
+// into v. It is meant for inspecting responses of endpoints that do not have
+// a dedicated service method.
+func (c *Client) Get(ctx context.Context, path string, v interface{}) (err error) {
+	return c.requestJSON(ctx, http.MethodGet, path, nil, v)
+}
+
+// Do sends a request without a body to the path and returns the response
+// regardless of its status code. The caller must close the response body.
+func (c *Client) Do(ctx context.Context, method, path string) (resp *http.Response, err error) {
+	req, err := http.NewRequest(method, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if c.restricted {
+		key, err := api.GetToken(path, method)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	return c.httpClient.Do(req)
+}
+
 // requestJSON handles the HTTP request response cycle. It JSON encodes the request
 // body, creates an HTTP request with provided method on a path with required
 // headers and decodes request body if the v argument is not nil and content type is
@@ -2,7 +2,10 @@ package debugapi
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/ethersphere/beekeeper/pkg/bigint"
@@ -105,7 +108,10 @@ func (n *NodeService) RemoveChunk(ctx context.Context, a swarm.Address) error {
 
 // Health represents node's health
 type Health struct {
-	Status string `json:"status"`
+	Status          string `json:"status"`
+	Version         string `json:"version"`
+	APIVersion      string `json:"apiVersion"`
+	DebugAPIVersion string `json:"debugApiVersion"`
 }
 
 // Health returns node's health
@@ -114,6 +120,28 @@ func (n *NodeService) Health(ctx context.Context) (resp Health, err error) {
 	return
 }
 
+// Time returns node's current time taken from the Date header of the health
+// endpoint response
+func (n *NodeService) Time(ctx context.Context) (t time.Time, err error) {
+	req, err := http.NewRequest(http.MethodGet, "/health", nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	req = req.WithContext(ctx)
+
+	r, err := n.client.httpClient.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer drain(r.Body)
+
+	if err = responseErrorHandler(r); err != nil {
+		return time.Time{}, err
+	}
+
+	return http.ParseTime(r.Header.Get("Date"))
+}
+
 // Peers represents node's peers
 type Peers struct {
 	Peers []Peer `json:"peers"`
@@ -167,6 +195,13 @@ func (n *NodeService) Settlements(ctx context.Context) (resp Settlements, err er
 	return
 }
 
+// TimeSettlements returns node's pseudosettle (time based) settlements with
+// all peers
+func (n *NodeService) TimeSettlements(ctx context.Context) (resp Settlements, err error) {
+	err = n.client.request(ctx, http.MethodGet, "/timesettlements", nil, &resp)
+	return
+}
+
 type Cheque struct {
 	Beneficiary string         `json:"beneficiary"`
 	Chequebook  string         `json:"chequebook"`
@@ -211,6 +246,89 @@ func (n *NodeService) ChequebookBalance(ctx context.Context) (resp ChequebookBal
 	return
 }
 
+type ChequebookAddressResponse struct {
+	ChequebookAddress string `json:"chequebookAddress"`
+}
+
+// ChequebookAddress returns address of the node's chequebook contract
+func (n *NodeService) ChequebookAddress(ctx context.Context) (resp ChequebookAddressResponse, err error) {
+	err = n.client.request(ctx, http.MethodGet, "/chequebook/address", nil, &resp)
+	return
+}
+
+// ChequebookLastCheques represents last cheques sent to and received from a peer
+type ChequebookLastCheques struct {
+	Peer         string  `json:"peer"`
+	LastReceived *Cheque `json:"lastreceived"`
+	LastSent     *Cheque `json:"lastsent"`
+}
+
+type chequebookLastChequesResponse struct {
+	LastCheques []ChequebookLastCheques `json:"lastcheques"`
+}
+
+// LastCheques returns last cheques sent to and received from all peers
+func (n *NodeService) LastCheques(ctx context.Context) (resp []ChequebookLastCheques, err error) {
+	var r chequebookLastChequesResponse
+	err = n.client.request(ctx, http.MethodGet, "/chequebook/cheque", nil, &r)
+	return r.LastCheques, err
+}
+
+// LastChequesPeer returns last cheques sent to and received from a given peer
+func (n *NodeService) LastChequesPeer(ctx context.Context, a swarm.Address) (resp ChequebookLastCheques, err error) {
+	err = n.client.request(ctx, http.MethodGet, "/chequebook/cheque/"+a.String(), nil, &resp)
+	return
+}
+
+// CashoutWithGasPrice cashes out the last cheque received from a given peer using a given gas price
+func (n *NodeService) CashoutWithGasPrice(ctx context.Context, a swarm.Address, gasPrice string) (resp TransactionHashResponse, err error) {
+	if gasPrice == "" {
+		return n.Cashout(ctx, a)
+	}
+
+	h := http.Header{}
+	h.Add("Gas-Price", gasPrice)
+	err = n.client.requestWithHeader(ctx, http.MethodPost, "/chequebook/cashout/"+a.String(), h, nil, &resp)
+	return
+}
+
+// ChequebookDeposit deposits a given amount of BZZ to the node's chequebook
+func (n *NodeService) ChequebookDeposit(ctx context.Context, amount int64) (resp TransactionHashResponse, err error) {
+	err = n.client.request(ctx, http.MethodPost, fmt.Sprintf("/chequebook/deposit?amount=%d", amount), nil, &resp)
+	return
+}
+
+// ChequebookWithdraw withdraws a given amount of BZZ from the node's chequebook
+func (n *NodeService) ChequebookWithdraw(ctx context.Context, amount int64) (resp TransactionHashResponse, err error) {
+	err = n.client.request(ctx, http.MethodPost, fmt.Sprintf("/chequebook/withdraw?amount=%d", amount), nil, &resp)
+	return
+}
+
+// Wallet represents node's wallet balances
+type Wallet struct {
+	BZZ                       *bigint.BigInt `json:"bzzBalance"`
+	NativeToken               *bigint.BigInt `json:"nativeTokenBalance"`
+	ChainID                   int64          `json:"chainID"`
+	ChequebookContractAddress string         `json:"chequebookContractAddress"`
+	WalletAddress             string         `json:"walletAddress"`
+}
+
+// Wallet returns node's wallet balances
+func (n *NodeService) Wallet(ctx context.Context) (resp Wallet, err error) {
+	err = n.client.request(ctx, http.MethodGet, "/wallet", nil, &resp)
+	return
+}
+
+// WalletWithdraw withdraws a given amount of a given coin (bzz or nativetoken)
+// from the node's wallet to a whitelisted address
+func (n *NodeService) WalletWithdraw(ctx context.Context, coin, address string, amount *big.Int) (resp TransactionHashResponse, err error) {
+	q := url.Values{}
+	q.Set("address", address)
+	q.Set("amount", amount.String())
+	err = n.client.request(ctx, http.MethodPost, "/wallet/withdraw/"+url.PathEscape(coin)+"?"+q.Encode(), nil, &resp)
+	return
+}
+
 // Topology represents Kademlia topology
 type Topology struct {
 	BaseAddr            swarm.Address  `json:"baseAddr"`
@@ -108,3 +108,44 @@ func (p *PostageService) ReserveState(ctx context.Context) (ReserveState, error)
 	err := p.client.request(ctx, http.MethodGet, "/reservestate", nil, &resp)
 	return resp, err
 }
+
+// ChainState represents the chain state of the node
+type ChainState struct {
+	ChainTip     uint64         `json:"chainTip"`
+	Block        uint64         `json:"block"`
+	TotalAmount  *bigint.BigInt `json:"totalAmount"`
+	CurrentPrice *bigint.BigInt `json:"currentPrice"`
+}
+
+// Returns the chain state of the node
+func (p *PostageService) ChainState(ctx context.Context) (ChainState, error) {
+	var resp ChainState
+	err := p.client.request(ctx, http.MethodGet, "/chainstate", nil, &resp)
+	return resp, err
+}
+
+// Batch represents a postage batch known to the node's batch store
+type Batch struct {
+	BatchID     string         `json:"batchID"`
+	Value       *bigint.BigInt `json:"value"`
+	Start       uint64         `json:"start"`
+	Owner       string         `json:"owner"`
+	Depth       uint8          `json:"depth"`
+	BucketDepth uint8          `json:"bucketDepth"`
+	Immutable   bool           `json:"immutable"`
+	BatchTTL    int64          `json:"batchTTL"`
+}
+
+type batchesResponse struct {
+	Batches []Batch `json:"batches"`
+}
+
+// Fetches all postage batches known to the node's batch store
+func (p *PostageService) Batches(ctx context.Context) ([]Batch, error) {
+	var resp batchesResponse
+	err := p.client.request(ctx, http.MethodGet, "/batches", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Batches, nil
+}
@@ -51,3 +51,29 @@ func (s *StakingService) WithdrawStake(ctx context.Context) (txHash string, err
 	}
 	return r.TxHash, nil
 }
+
+// RedistributionState represents state of the node's storage incentives
+// agent
+type RedistributionState struct {
+	MinimumGasFunds           *bigint.BigInt `json:"minimumGasFunds"`
+	HasSufficientFunds        bool           `json:"hasSufficientFunds"`
+	IsFrozen                  bool           `json:"isFrozen"`
+	IsFullySynced             bool           `json:"isFullySynced"`
+	IsHealthy                 bool           `json:"isHealthy"`
+	Phase                     string         `json:"phase"`
+	Round                     uint64         `json:"round"`
+	LastWonRound              uint64         `json:"lastWonRound"`
+	LastPlayedRound           uint64         `json:"lastPlayedRound"`
+	LastFrozenRound           uint64         `json:"lastFrozenRound"`
+	LastSelectedRound         uint64         `json:"lastSelectedRound"`
+	LastSampleDurationSeconds float64        `json:"lastSampleDurationSeconds"`
+	Block                     uint64         `json:"block"`
+	Reward                    *bigint.BigInt `json:"reward"`
+	Fees                      *bigint.BigInt `json:"fees"`
+}
+
+// GetRedistributionState gets state of the storage incentives agent
+func (s *StakingService) GetRedistributionState(ctx context.Context) (resp RedistributionState, err error) {
+	err = s.client.requestJSON(ctx, http.MethodGet, "/redistributionstate", nil, &resp)
+	return
+}
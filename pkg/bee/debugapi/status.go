@@ -0,0 +1,43 @@
+package debugapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// Status represents status snapshot of a node
+type Status struct {
+	Overlay                 string  `json:"overlay"`
+	Peer                    string  `json:"peer,omitempty"` // overlay reported by older nodes
+	Proximity               uint8   `json:"proximity"`
+	BeeMode                 string  `json:"beeMode"`
+	ReserveSize             uint64  `json:"reserveSize"`
+	ReserveSizeWithinRadius uint64  `json:"reserveSizeWithinRadius"`
+	PullsyncRate            float64 `json:"pullsyncRate"`
+	StorageRadius           uint8   `json:"storageRadius"`
+	ConnectedPeers          uint64  `json:"connectedPeers"`
+	NeighborhoodSize        uint64  `json:"neighborhoodSize"`
+	RequestFailed           bool    `json:"requestFailed,omitempty"` // only in snapshots of peers
+	BatchCommitment         uint64  `json:"batchCommitment"`
+	IsReachable             bool    `json:"isReachable"`
+	LastSyncedBlock         uint64  `json:"lastSyncedBlock"`
+	CommittedDepth          uint8   `json:"committedDepth"`
+}
+
+// Status returns status snapshot of the node
+func (n *NodeService) Status(ctx context.Context) (resp Status, err error) {
+	err = n.client.requestJSON(ctx, http.MethodGet, "/status", nil, &resp)
+	return
+}
+
+// StatusPeers returns status snapshots of the node's peers, as the peers
+// reported them to the node
+func (n *NodeService) StatusPeers(ctx context.Context) ([]Status, error) {
+	var resp struct {
+		Snapshots []Status `json:"snapshots"`
+	}
+	if err := n.client.requestJSON(ctx, http.MethodGet, "/status/peers", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
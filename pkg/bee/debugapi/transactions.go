@@ -0,0 +1,61 @@
+package debugapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bigint"
+)
+
+// Transaction represents an on-chain transaction sent by the node that is
+// not confirmed yet
+type Transaction struct {
+	TransactionHash string         `json:"transactionHash"`
+	To              string         `json:"to"`
+	Nonce           uint64         `json:"nonce"`
+	GasPrice        *bigint.BigInt `json:"gasPrice"`
+	GasLimit        uint64         `json:"gasLimit"`
+	GasTipBoost     int            `json:"gasTipBoost"`
+	GasTipCap       *bigint.BigInt `json:"gasTipCap"`
+	GasFeeCap       *bigint.BigInt `json:"gasFeeCap"`
+	Data            string         `json:"data"`
+	Created         time.Time      `json:"created"`
+	Description     string         `json:"description"`
+	Value           *bigint.BigInt `json:"value"`
+}
+
+// Transactions returns pending transactions of the node
+func (n *NodeService) Transactions(ctx context.Context) ([]Transaction, error) {
+	var resp struct {
+		PendingTransactions []Transaction `json:"pendingTransactions"`
+	}
+	if err := n.client.requestJSON(ctx, http.MethodGet, "/transactions", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PendingTransactions, nil
+}
+
+// Transaction returns the pending transaction of the node
+func (n *NodeService) Transaction(ctx context.Context, txHash string) (resp Transaction, err error) {
+	err = n.client.requestJSON(ctx, http.MethodGet, "/transactions/"+txHash, nil, &resp)
+	return
+}
+
+// RebroadcastTransaction sends the pending transaction to the chain again
+func (n *NodeService) RebroadcastTransaction(ctx context.Context, txHash string) (resp TransactionHashResponse, err error) {
+	err = n.client.requestJSON(ctx, http.MethodPost, "/transactions/"+txHash, nil, &resp)
+	return
+}
+
+// CancelTransaction cancels the pending transaction by replacing it with an
+// empty transaction with the same nonce and the gas price, node's suggested
+// gas price if it is empty. It returns hash of the replacing transaction.
+func (n *NodeService) CancelTransaction(ctx context.Context, txHash, gasPrice string) (resp TransactionHashResponse, err error) {
+	h := http.Header{}
+	if gasPrice != "" {
+		h.Add("Gas-Price", gasPrice)
+	}
+	err = n.client.requestWithHeader(ctx, http.MethodDelete, "/transactions/"+txHash, h, nil, &resp)
+	return
+}
@@ -0,0 +1,86 @@
+package bee
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// transportMetrics are shared by clients of all nodes
+var transportMetrics = newMetrics()
+
+type metrics struct {
+	RequestPhaseDuration   *prometheus.HistogramVec
+	RateLimitWaitDuration  *prometheus.HistogramVec
+	Retries                *prometheus.CounterVec
+	RetryBudgetExhausted   *prometheus.CounterVec
+	Connections            *prometheus.CounterVec
+	ConnectionIdleDuration *prometheus.HistogramVec
+}
+
+func newMetrics() metrics {
+	subsystem := "bee_client"
+	return metrics{
+		RequestPhaseDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "request_phase_seconds",
+				Help:      "Duration of DNS lookups, connections, TLS handshakes, server processing and time to first byte of requests to Bee APIs.",
+				Buckets:   []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+			},
+			[]string{"api", "method", "endpoint", "phase"},
+		),
+		RateLimitWaitDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "rate_limit_wait_seconds",
+				Help:      "Time requests to Bee APIs waited for the global and the node rate limiter.",
+				Buckets:   []float64{0.001, 0.01, 0.1, 0.5, 1, 2.5, 5, 10, 30},
+			},
+			[]string{"api", "limiter"},
+		),
+		Retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "request_retries_total",
+				Help:      "Number of retries of failed requests to Bee APIs.",
+			},
+			[]string{"api", "method", "endpoint"},
+		),
+		RetryBudgetExhausted: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "retry_budget_exhausted_total",
+				Help:      "Number of failed requests to Bee APIs not retried as the retry budget of the node was exhausted.",
+			},
+			[]string{"api"},
+		),
+		Connections: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "connections_total",
+				Help:      "Number of connections requests to Bee APIs were sent on, by whether the connection was reused from the pool.",
+			},
+			[]string{"api", "reused"},
+		),
+		ConnectionIdleDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "connection_idle_seconds",
+				Help:      "Time reused connections to Bee APIs were idle in the pool.",
+				Buckets:   []float64{0.001, 0.01, 0.1, 1, 5, 15, 30, 60, 90},
+			},
+			[]string{"api"},
+		),
+	}
+}
+
+// Report returns metrics of requests of all Bee clients
+func Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(transportMetrics)
+}
@@ -0,0 +1,131 @@
+package bee
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Limiters of requests, the global one is shared by clients of all nodes of
+// the cluster
+const (
+	limiterGlobal = "global"
+	limiterNode   = "node"
+)
+
+// RateLimiter is a token bucket limiting the rate of requests. Requests
+// above the rate wait for tokens instead of failing, so checks are slowed
+// down rather than broken by the limit. A nil RateLimiter allows all
+// requests.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // maximum number of tokens
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns the limiter allowing rate requests per second with
+// bursts of burst requests, burst defaults to the rate rounded up. It
+// returns nil, no limit, if the rate is not positive.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(rate))
+	}
+
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until the request is allowed or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	// the token is reserved right away, so waiting requests are allowed in
+	// the order they came in
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		// the request is not sent, the token is given back
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// rateLimitTransport delays requests to a Bee API until the global and the
+// node limiter allow them
+type rateLimitTransport struct {
+	api    string // api or debug
+	global *RateLimiter
+	node   *RateLimiter
+	next   http.RoundTripper
+}
+
+// newRateLimitTransport returns the transport limiting requests to the API
+func newRateLimitTransport(api string, global, node *RateLimiter, next http.RoundTripper) *rateLimitTransport {
+	return &rateLimitTransport{
+		api:    api,
+		global: global,
+		node:   node,
+		next:   next,
+	}
+}
+
+// RoundTrip waits for the node limiter first, so that requests to a node
+// that is at its limit do not hold tokens of the global limiter
+func (t *rateLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := t.wait(r.Context(), limiterNode, t.node); err != nil {
+		return nil, err
+	}
+	if err := t.wait(r.Context(), limiterGlobal, t.global); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(r)
+}
+
+func (t *rateLimitTransport) wait(ctx context.Context, limiter string, l *RateLimiter) error {
+	if l == nil {
+		return nil
+	}
+
+	start := time.Now()
+	if err := l.Wait(ctx); err != nil {
+		return err
+	}
+	transportMetrics.RateLimitWaitDuration.WithLabelValues(t.api, limiter).Observe(time.Since(start).Seconds())
+
+	return nil
+}
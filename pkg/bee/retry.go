@@ -0,0 +1,252 @@
+package bee
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultReadRetries     = 5
+	defaultWriteRetries    = 0
+	defaultRetryMinBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 10 * time.Second
+	// retryBudgetMax is the maximum number of retries a budget holds, it is
+	// full when the client starts, so that a node that just started is retried
+	retryBudgetMax = 10
+)
+
+// Classes of requests, they are retried by their own policies
+const (
+	classRead  = "read"
+	classWrite = "write"
+)
+
+// RetryOptions holds policies of retries of failed requests to Bee APIs.
+// Reads, GET and HEAD requests, are retried on all connection errors and on
+// 429, 502, 503 and 504 responses. Writes, like uploads, are not idempotent,
+// the node may have handled them even if the ingress in front of it failed,
+// so they are retried only if the connection to the node failed before they
+// were sent, and if their body can be sent again. Writes are not retried by
+// default.
+type RetryOptions struct {
+	ReadRetries  int           // retries of a failed read, 0 for the default, negative for none
+	WriteRetries int           // retries of a write whose connection failed, none if 0 or negative
+	MinBackoff   time.Duration // delay before the first retry, doubled on every retry
+	MaxBackoff   time.Duration // maximum delay between retries
+	// Budget is the ratio of retries to requests to the node, the node is not
+	// retried above it, so that retries do not multiply the load of a node
+	// that is failing. 0 for no budget.
+	Budget float64
+}
+
+// withDefaults returns options with defaults of unset fields
+func (o RetryOptions) withDefaults() RetryOptions {
+	if o.ReadRetries < 0 {
+		o.ReadRetries = 0
+	} else if o.ReadRetries == 0 {
+		o.ReadRetries = defaultReadRetries
+	}
+	if o.WriteRetries < 0 {
+		o.WriteRetries = 0
+	} else if o.WriteRetries == 0 {
+		o.WriteRetries = defaultWriteRetries
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = defaultRetryMinBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaultRetryMaxBackoff
+	}
+	if o.MaxBackoff < o.MinBackoff {
+		o.MaxBackoff = o.MinBackoff
+	}
+	return o
+}
+
+// retryBudget is a token bucket of retries, every request adds the ratio of
+// a token and every retry takes one. A nil budget allows all retries.
+type retryBudget struct {
+	mu     sync.Mutex
+	ratio  float64
+	tokens float64
+}
+
+func newRetryBudget(ratio float64) *retryBudget {
+	if ratio <= 0 {
+		return nil
+	}
+
+	return &retryBudget{
+		ratio:  ratio,
+		tokens: retryBudgetMax,
+	}
+}
+
+// request adds the request to the budget
+func (b *retryBudget) request() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.tokens+b.ratio, retryBudgetMax)
+}
+
+// retry reports whether the budget allows a retry and takes it
+func (b *retryBudget) retry() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// retryTransport retries failed requests to a Bee API with exponential
+// backoff and jitter
+type retryTransport struct {
+	api    string // api or debug
+	opts   RetryOptions
+	budget *retryBudget
+	next   http.RoundTripper
+}
+
+// newRetryTransport returns the transport retrying requests to the API, the
+// budget is shared by transports of both APIs of the node
+func newRetryTransport(api string, o RetryOptions, budget *retryBudget, next http.RoundTripper) *retryTransport {
+	return &retryTransport{
+		api:    api,
+		opts:   o.withDefaults(),
+		budget: budget,
+		next:   next,
+	}
+}
+
+// RoundTrip sends the request and retries it while it fails, retries are
+// left and the budget allows them. The response or error of the last
+// attempt is returned.
+func (t *retryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	class, retries := classRead, t.opts.ReadRetries
+	if !isRead(r.Method) {
+		class, retries = classWrite, t.opts.WriteRetries
+		// a body that was sent can not be sent again without GetBody
+		if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+			retries = 0
+		}
+	}
+
+	t.budget.request()
+
+	backoff := t.opts.MinBackoff
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if r.GetBody != nil {
+				body, err := r.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r = r.Clone(r.Context())
+				r.Body = body
+			}
+		}
+
+		resp, err := t.next.RoundTrip(r)
+		if attempt >= retries || !retryable(class, resp, err) || r.Context().Err() != nil {
+			return resp, err
+		}
+		if !t.budget.retry() {
+			transportMetrics.RetryBudgetExhausted.WithLabelValues(t.api).Inc()
+			return resp, err
+		}
+
+		delay := jitter(backoff)
+		if d, ok := retryAfter(resp); ok && d > delay {
+			delay = d
+			if delay > t.opts.MaxBackoff {
+				delay = t.opts.MaxBackoff
+			}
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+			resp.Body.Close()
+		}
+
+		transportMetrics.Retries.WithLabelValues(t.api, r.Method, endpoint(r.URL.Path)).Inc()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, r.Context().Err()
+		case <-timer.C:
+		}
+
+		if backoff *= 2; backoff > t.opts.MaxBackoff {
+			backoff = t.opts.MaxBackoff
+		}
+	}
+}
+
+// isRead reports whether requests of the method only read
+func isRead(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	return false
+}
+
+// retryable reports whether the failed request of the class is retried
+func retryable(class string, resp *http.Response, err error) bool {
+	if class == classWrite {
+		// writes are retried only if the connection failed, the node has
+		// not received them
+		var opErr *net.OpError
+		return err != nil && errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// jitter returns a random delay between half the backoff and the backoff,
+// so that requests failed together are not retried together
+func jitter(backoff time.Duration) time.Duration {
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryAfter returns the delay of the Retry-After header of the response in
+// seconds, if it is set
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	s, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || s < 0 {
+		return 0, false
+	}
+	return time.Duration(s) * time.Second, true
+}
@@ -0,0 +1,172 @@
+package bee
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// roundTripFunc is the transport answering requests with the function
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+var (
+	errDial  = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	errRead  = &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	errOther = errors.New("unexpected EOF")
+)
+
+func TestRetryTransport(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		method   string
+		body     string
+		opts     RetryOptions
+		status   int
+		err      error
+		attempts int
+	}{
+		{name: "get on 503", method: http.MethodGet, status: http.StatusServiceUnavailable, attempts: 3},
+		{name: "get on 429", method: http.MethodGet, status: http.StatusTooManyRequests, attempts: 3},
+		{name: "get on connection reset", method: http.MethodGet, err: errRead, attempts: 3},
+		{name: "get on other error", method: http.MethodGet, err: errOther, attempts: 3},
+		{name: "head on 504", method: http.MethodHead, status: http.StatusGatewayTimeout, attempts: 3},
+		{name: "get on 500", method: http.MethodGet, status: http.StatusInternalServerError, attempts: 1},
+		{name: "get on 404", method: http.MethodGet, status: http.StatusNotFound, attempts: 1},
+		{name: "get without retries", method: http.MethodGet, opts: RetryOptions{ReadRetries: -1}, status: http.StatusServiceUnavailable, attempts: 1},
+		{name: "options on 503", method: http.MethodOptions, opts: RetryOptions{WriteRetries: 2}, status: http.StatusServiceUnavailable, attempts: 1},
+		{name: "post on 502", method: http.MethodPost, body: "data", opts: RetryOptions{WriteRetries: 2}, status: http.StatusBadGateway, attempts: 1},
+		{name: "post on 503", method: http.MethodPost, body: "data", opts: RetryOptions{WriteRetries: 2}, status: http.StatusServiceUnavailable, attempts: 1},
+		{name: "post on 504", method: http.MethodPost, body: "data", opts: RetryOptions{WriteRetries: 2}, status: http.StatusGatewayTimeout, attempts: 1},
+		{name: "post on 429", method: http.MethodPost, body: "data", opts: RetryOptions{WriteRetries: 2}, status: http.StatusTooManyRequests, attempts: 1},
+		{name: "post on connection reset", method: http.MethodPost, body: "data", opts: RetryOptions{WriteRetries: 2}, err: errRead, attempts: 1},
+		{name: "post on dial error", method: http.MethodPost, body: "data", opts: RetryOptions{WriteRetries: 2}, err: errDial, attempts: 3},
+		{name: "post on dial error by default", method: http.MethodPost, body: "data", err: errDial, attempts: 1},
+		{name: "delete on dial error", method: http.MethodDelete, opts: RetryOptions{WriteRetries: 2}, err: errDial, attempts: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// retried reads are attempted 3 times
+			if tc.opts.ReadRetries == 0 {
+				tc.opts.ReadRetries = 2
+			}
+			tc.opts.MinBackoff = time.Millisecond
+			tc.opts.MaxBackoff = time.Millisecond
+
+			var (
+				mu       sync.Mutex
+				attempts int
+				bodies   []string
+			)
+			transport := newRetryTransport("api", tc.opts, nil, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				attempts++
+				if r.Body != nil {
+					b, err := io.ReadAll(r.Body)
+					if err != nil {
+						return nil, err
+					}
+					bodies = append(bodies, string(b))
+				}
+				if tc.err != nil {
+					return nil, tc.err
+				}
+				return &http.Response{StatusCode: tc.status, Header: make(http.Header), Body: http.NoBody}, nil
+			}))
+
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			r, err := http.NewRequest(tc.method, "http://bee-0/bytes", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := transport.RoundTrip(r)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("got error %v, want %v", err, tc.err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			} else if resp.StatusCode != tc.status {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tc.status)
+			}
+
+			if attempts != tc.attempts {
+				t.Errorf("got %d attempts, want %d", attempts, tc.attempts)
+			}
+			for i, b := range bodies {
+				if b != tc.body {
+					t.Errorf("attempt %d: got body %q, want %q", i+1, b, tc.body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportBodyNotRewindable(t *testing.T) {
+	var attempts int
+	transport := newRetryTransport("api", RetryOptions{WriteRetries: 2, MinBackoff: time.Millisecond}, nil, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, errDial
+	}))
+
+	r, err := http.NewRequest(http.MethodPost, "http://bee-0/bytes", io.NopCloser(strings.NewReader("data")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transport.RoundTrip(r); !errors.Is(err, errDial) {
+		t.Errorf("got error %v, want %v", err, errDial)
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestRetryTransportBudget(t *testing.T) {
+	budget := newRetryBudget(0.1)
+
+	var attempts int
+	transport := newRetryTransport("api", RetryOptions{ReadRetries: 100, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}, budget, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: make(http.Header), Body: http.NoBody}, nil
+	}))
+
+	r, err := http.NewRequest(http.MethodGet, "http://bee-0/health", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transport.RoundTrip(r); err != nil {
+		t.Fatal(err)
+	}
+
+	// the full budget holds 10 retries, the request adds less than one
+	if want := 1 + retryBudgetMax; attempts != want {
+		t.Errorf("got %d attempts, want %d", attempts, want)
+	}
+}
+
+func TestRetryOptionsDefaults(t *testing.T) {
+	o := RetryOptions{}.withDefaults()
+	if o.ReadRetries != defaultReadRetries {
+		t.Errorf("got %d read retries, want %d", o.ReadRetries, defaultReadRetries)
+	}
+	if o.WriteRetries != 0 {
+		t.Errorf("got %d write retries, want 0", o.WriteRetries)
+	}
+
+	o = RetryOptions{ReadRetries: -1, WriteRetries: -1}.withDefaults()
+	if o.ReadRetries != 0 || o.WriteRetries != 0 {
+		t.Errorf("got %d read and %d write retries, want none", o.ReadRetries, o.WriteRetries)
+	}
+}
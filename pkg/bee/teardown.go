@@ -0,0 +1,99 @@
+package bee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+// recordPin records the reference pinned through the client
+func (c *Client) recordPin(pinned bool, ref swarm.Address) {
+	if !pinned {
+		return
+	}
+
+	c.createdMu.Lock()
+	defer c.createdMu.Unlock()
+
+	c.createdPins = append(c.createdPins, ref)
+}
+
+// forgetPin forgets the reference unpinned through the client
+func (c *Client) forgetPin(ref swarm.Address) {
+	c.createdMu.Lock()
+	defer c.createdMu.Unlock()
+
+	pins := c.createdPins[:0]
+	for _, p := range c.createdPins {
+		if !p.Equal(ref) {
+			pins = append(pins, p)
+		}
+	}
+	c.createdPins = pins
+}
+
+// recordBatch records the postage batch bought through the client
+func (c *Client) recordBatch(id string) {
+	c.createdMu.Lock()
+	defer c.createdMu.Unlock()
+
+	c.createdBatches = append(c.createdBatches, id)
+}
+
+// Teardown releases resources created through the client. Pinned references
+// are unpinned. Postage batches can not be deleted, so the bought ones are
+// diluted until they expire.
+func (c *Client) Teardown(ctx context.Context) error {
+	c.createdMu.Lock()
+	pins, batches := c.createdPins, c.createdBatches
+	c.createdPins, c.createdBatches = nil, nil
+	c.createdMu.Unlock()
+
+	var errs []error
+	unpinned := make(map[string]bool)
+	for _, ref := range pins {
+		if unpinned[ref.String()] {
+			continue
+		}
+		unpinned[ref.String()] = true
+		if err := c.api.Pinning.UnpinRootHash(ctx, ref); err != nil {
+			errs = append(errs, fmt.Errorf("unpin %s: %w", ref, err))
+		}
+	}
+
+	for _, id := range batches {
+		if err := c.expireBatch(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("expire batch %s: %w", id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// expireBatch dilutes the batch by as many depths as its TTL has bits, so its
+// remaining balance per chunk is halved below the price of a block and the
+// batch expires in the next one. Batches already expired are skipped.
+func (c *Client) expireBatch(ctx context.Context, id string) error {
+	b, err := c.debug.Postage.PostageStamp(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !b.Exists || b.BatchTTL <= 0 {
+		return nil
+	}
+
+	depth := uint64(b.Depth) + uint64(bits.Len64(uint64(b.BatchTTL)))
+	if depth > math.MaxUint8 {
+		depth = math.MaxUint8
+	}
+	if err := c.debug.Postage.DilutePostageBatch(ctx, id, depth, ""); err != nil {
+		return err
+	}
+
+	c.logger.Infof("batch %s bought during the run is diluted to depth %d to expire", id, depth)
+	return nil
+}
@@ -0,0 +1,107 @@
+package bee
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee/debugapi"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+)
+
+func TestTeardown(t *testing.T) {
+	stamps := map[string]debugapi.PostageStampResponse{
+		"active":  {Exists: true, Depth: 20, BatchTTL: 86400},
+		"expired": {Exists: true, Depth: 20, BatchTTL: 0},
+		"deep":    {Exists: true, Depth: 250, BatchTTL: 86400},
+	}
+
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if id, ok := strings.CutPrefix(r.URL.Path, "/stamps/"); ok && r.Method == http.MethodGet {
+			s, ok := stamps[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(s)
+			return
+		}
+		_, _ = io.WriteString(w, "{}")
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(ClientOptions{APIURL: u, DebugAPIURL: u}, logging.New(io.Discard, 0, ""))
+
+	ref := swarm.MustParseHexAddress("ca6357a08e317d15ec560fef34e4c45f8f19f01c372aa70f1da72bfa7f1a4338")
+	c.recordPin(true, ref)
+	c.recordPin(true, ref)
+	for _, id := range []string{"active", "expired", "deep"} {
+		c.recordBatch(id)
+	}
+
+	if err := c.Teardown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(requests)
+	want := []string{
+		"DELETE /pins/" + ref.String(),
+		"GET /stamps/active",
+		"GET /stamps/deep",
+		"GET /stamps/expired",
+		// the TTL of a day has 17 bits
+		"PATCH /stamps/dilute/active/37",
+		"PATCH /stamps/dilute/deep/255",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got requests\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+
+	// created resources are released once
+	requests = nil
+	if err := c.Teardown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("got requests %v after the teardown", requests)
+	}
+}
+
+func TestTeardownError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(ClientOptions{APIURL: u, DebugAPIURL: u}, logging.New(io.Discard, 0, ""))
+	c.recordBatch("missing")
+
+	if err := c.Teardown(context.Background()); err == nil || !strings.Contains(err.Error(), "expire batch missing") {
+		t.Errorf("got error %v, want error expiring the batch", err)
+	}
+}
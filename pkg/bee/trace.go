@@ -0,0 +1,166 @@
+package bee
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phases of a request timed by the trace transport. Server is the time
+// from the request being written to the first response byte, the time the
+// node spent on the request, while ttfb includes the time spent on DNS
+// lookups and connections, which is the time spent in Kubernetes networking
+// and ingresses.
+const (
+	phaseDNS     = "dns"
+	phaseConnect = "connect"
+	phaseTLS     = "tls"
+	phaseServer  = "server"
+	phaseTTFB    = "ttfb"
+)
+
+// traceTransport times phases of requests to a Bee API with httptrace
+type traceTransport struct {
+	api  string // api or debug
+	next http.RoundTripper
+}
+
+// newTraceTransport returns the transport timing requests to the API
+func newTraceTransport(api string, next http.RoundTripper) *traceTransport {
+	return &traceTransport{
+		api:  api,
+		next: next,
+	}
+}
+
+// RoundTrip times phases of the request. Hooks of a trace that is already
+// in the request context are called as well.
+func (t *traceTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt := &requestTrace{
+		api:      t.api,
+		method:   r.Method,
+		endpoint: endpoint(r.URL.Path),
+		start:    time.Now(),
+	}
+
+	return t.next.RoundTrip(r.WithContext(httptrace.WithClientTrace(r.Context(), rt.clientTrace())))
+}
+
+// requestTrace holds start times of request phases, hooks may be called
+// concurrently when connections are raced
+type requestTrace struct {
+	api      string
+	method   string
+	endpoint string
+	start    time.Time
+
+	mu      sync.Mutex
+	dns     time.Time
+	connect time.Time
+	tls     time.Time
+	wrote   time.Time
+}
+
+func (rt *requestTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(i httptrace.GotConnInfo) {
+			transportMetrics.Connections.WithLabelValues(rt.api, strconv.FormatBool(i.Reused)).Inc()
+			if i.WasIdle {
+				transportMetrics.ConnectionIdleDuration.WithLabelValues(rt.api).Observe(i.IdleTime.Seconds())
+			}
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			rt.begin(&rt.dns)
+		},
+		DNSDone: func(i httptrace.DNSDoneInfo) {
+			rt.end(phaseDNS, &rt.dns, i.Err)
+		},
+		ConnectStart: func(_, _ string) {
+			rt.begin(&rt.connect)
+		},
+		ConnectDone: func(_, _ string, err error) {
+			rt.end(phaseConnect, &rt.connect, err)
+		},
+		TLSHandshakeStart: func() {
+			rt.begin(&rt.tls)
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			rt.end(phaseTLS, &rt.tls, err)
+		},
+		WroteRequest: func(i httptrace.WroteRequestInfo) {
+			if i.Err == nil {
+				rt.begin(&rt.wrote)
+			}
+		},
+		GotFirstResponseByte: func() {
+			rt.end(phaseServer, &rt.wrote, nil)
+			rt.observe(phaseTTFB, time.Since(rt.start))
+		},
+	}
+}
+
+// begin records the start of a phase
+func (rt *requestTrace) begin(start *time.Time) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	*start = time.Now()
+}
+
+// end observes the duration of a successful phase
+func (rt *requestTrace) end(phase string, start *time.Time, err error) {
+	rt.mu.Lock()
+	s := *start
+	rt.mu.Unlock()
+
+	if err != nil || s.IsZero() {
+		return
+	}
+	rt.observe(phase, time.Since(s))
+}
+
+func (rt *requestTrace) observe(phase string, d time.Duration) {
+	transportMetrics.RequestPhaseDuration.WithLabelValues(rt.api, rt.method, rt.endpoint, phase).Observe(d.Seconds())
+}
+
+// endpoint returns the path with references, addresses, batch IDs and
+// numbers replaced by placeholders, and the path of a file in a collection
+// left out, so that metrics of an endpoint are not split by its arguments
+func endpoint(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if !isArgument(s) {
+			continue
+		}
+		segments[i] = "{}"
+		// bzz/{reference}/path/of/file
+		if i > 0 && segments[i-1] == "bzz" && i+1 < len(segments) {
+			segments = append(segments[:i+1], "*")
+			break
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// isArgument reports whether the path segment is a hex encoded reference,
+// address or ID, or a number
+func isArgument(s string) bool {
+	if s == "" {
+		return false
+	}
+	digits, hex := true, len(s) >= 16
+	for _, r := range strings.TrimPrefix(s, "0x") {
+		switch {
+		case r >= '0' && r <= '9':
+		case r >= 'a' && r <= 'f', r >= 'A' && r <= 'F':
+			digits = false
+		default:
+			return false
+		}
+	}
+	return digits || hex
+}
@@ -0,0 +1,81 @@
+package bee
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/uber/jaeger-client-go"
+)
+
+// Headers propagating the trace context to Bee nodes. Bee reads its own
+// swarm-trace-id header, the Jaeger and W3C headers are for proxies and
+// ingresses that trace requests on the way.
+const (
+	swarmTraceIDHeader = "swarm-trace-id"
+	uberTraceIDHeader  = "uber-trace-id"
+	traceparentHeader  = "traceparent"
+)
+
+// tracingTransport traces requests to a Bee API as child spans of the span
+// in the request context and propagates them to the node, so that spans of
+// beekeeper and the node join into one trace
+type tracingTransport struct {
+	api  string // api or debug
+	next http.RoundTripper
+}
+
+// newTracingTransport returns the transport tracing requests to the API
+func newTracingTransport(api string, next http.RoundTripper) *tracingTransport {
+	return &tracingTransport{
+		api:  api,
+		next: next,
+	}
+}
+
+// RoundTrip traces the request if its context has a span of a tracer that
+// is enabled
+func (t *tracingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	parent := opentracing.SpanFromContext(r.Context())
+	if parent == nil {
+		return t.next.RoundTrip(r)
+	}
+
+	span := parent.Tracer().StartSpan(fmt.Sprintf("%s %s %s", t.api, r.Method, endpoint(r.URL.Path)), opentracing.ChildOf(parent.Context()))
+	defer span.Finish()
+	ext.SpanKindRPCClient.Set(span)
+	ext.HTTPMethod.Set(span, r.Method)
+	ext.HTTPUrl.Set(span, r.URL.String())
+
+	sc, ok := span.Context().(jaeger.SpanContext)
+	if !ok { // tracing is disabled
+		return t.next.RoundTrip(r)
+	}
+
+	r = r.Clone(r.Context())
+	r.Header.Set(swarmTraceIDHeader, sc.String())
+	r.Header.Set(uberTraceIDHeader, sc.String())
+	r.Header.Set(traceparentHeader, traceparent(sc))
+
+	resp, err := t.next.RoundTrip(r)
+	if err != nil {
+		ext.LogError(span, err)
+		return nil, err
+	}
+	ext.HTTPStatusCode.Set(span, uint16(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		ext.Error.Set(span, true)
+	}
+
+	return resp, nil
+}
+
+// traceparent returns the W3C trace context header of the span
+func traceparent(sc jaeger.SpanContext) string {
+	flags := 0
+	if sc.IsSampled() {
+		flags = 1
+	}
+	return fmt.Sprintf("00-%016x%016x-%016x-%02x", sc.TraceID().High, sc.TraceID().Low, uint64(sc.SpanID()), flags)
+}
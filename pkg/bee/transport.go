@@ -0,0 +1,69 @@
+package bee
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxIdleConnsPerHost = 100
+	defaultIdleConnTimeout     = 90 * time.Second
+)
+
+// TransportOptions tunes connections to a node. Connections are pooled and
+// reused by default, so that concurrent requests of load checks do not open
+// a connection each, exhausting ephemeral ports, and latencies do not include
+// handshakes of new connections.
+type TransportOptions struct {
+	MaxIdleConnsPerHost int           // idle connections kept for reuse, 0 for the default
+	MaxConnsPerHost     int           // connections, requests above wait for one, 0 for no limit
+	IdleConnTimeout     time.Duration // time idle connections are kept for reuse, 0 for the default
+	DisableKeepAlives   bool          // opens a connection for every request
+	DisableHTTP2        bool          // does not negotiate HTTP/2 with nodes behind TLS
+	// DialContext dials connections to nodes instead of the network, like
+	// through port-forwards of the kubernetes API
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// newHTTPTransport returns the transport of connections to an API of the
+// node
+func newHTTPTransport(o TransportOptions, tlsConfig *tls.Config) *http.Transport {
+	if o.MaxIdleConnsPerHost <= 0 {
+		o.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	}
+	if o.IdleConnTimeout <= 0 {
+		o.IdleConnTimeout = defaultIdleConnTimeout
+	}
+
+	t := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+		MaxIdleConns:          o.MaxIdleConnsPerHost,
+		MaxIdleConnsPerHost:   o.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       o.MaxConnsPerHost,
+		IdleConnTimeout:       o.IdleConnTimeout,
+		DisableKeepAlives:     o.DisableKeepAlives,
+		ForceAttemptHTTP2:     !o.DisableHTTP2,
+	}
+	if o.DialContext != nil {
+		// connections do not go through the network, so neither through
+		// proxies
+		t.Proxy = nil
+		t.DialContext = o.DialContext
+	}
+	if o.DisableHTTP2 {
+		// a non-nil empty map turns HTTP/2 off
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	return t
+}
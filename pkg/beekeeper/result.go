@@ -0,0 +1,114 @@
+package beekeeper
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// Status represents outcome of an action or of its step
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// Result represents structured outcome of an action run
+type Result struct {
+	Name         string        `json:"name"`
+	Status       Status        `json:"status"`
+	Error        string        `json:"error,omitempty"`
+	Start        time.Time     `json:"start"`
+	Duration     time.Duration `json:"duration"`
+	Steps        []Step        `json:"steps,omitempty"`
+	Measurements []Measurement `json:"measurements,omitempty"`
+	Artifacts    []Artifact    `json:"artifacts,omitempty"`
+}
+
+// Step represents outcome of a single step of an action run
+type Step struct {
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Measurement represents a value measured during an action run
+type Measurement struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit,omitempty"`
+}
+
+// Artifact represents a file produced during an action run
+type Artifact struct {
+	Name string `json:"name"`
+	Path string `json:"path"` // file path or URL
+}
+
+// ResultReporter is implemented by actions that return structured results
+// instead of a bare error.
+type ResultReporter interface {
+	RunResult(ctx context.Context, cluster orchestration.Cluster, o interface{}) (Result, error)
+}
+
+// Step runs f as a named step and records its outcome
+func (r *Result) Step(name string, f func() error) error {
+	start := time.Now()
+	err := f()
+
+	s := Step{Name: name, Status: StatusPassed, Duration: time.Since(start)}
+	if err != nil {
+		s.Status = StatusFailed
+		s.Error = err.Error()
+	}
+	r.Steps = append(r.Steps, s)
+
+	return err
+}
+
+// Measure records a measured value
+func (r *Result) Measure(name string, value float64, unit string) {
+	r.Measurements = append(r.Measurements, Measurement{Name: name, Value: value, Unit: unit})
+}
+
+// Attach records a produced artifact
+func (r *Result) Attach(name, path string) {
+	r.Artifacts = append(r.Artifacts, Artifact{Name: name, Path: path})
+}
+
+// Failed returns failed steps
+func (r *Result) Failed() (steps []Step) {
+	for _, s := range r.Steps {
+		if s.Status == StatusFailed {
+			steps = append(steps, s)
+		}
+	}
+	return
+}
+
+// RunResult runs the action and returns its structured result. Actions that
+// do not implement ResultReporter get a result without steps.
+func RunResult(ctx context.Context, cluster orchestration.Cluster, action Action, o interface{}) (r Result, err error) {
+	start := time.Now()
+
+	if rr, ok := action.(ResultReporter); ok {
+		r, err = rr.RunResult(ctx, cluster, o)
+	} else {
+		err = action.Run(ctx, cluster, o)
+	}
+
+	r.Start = start
+	r.Duration = time.Since(start)
+	r.Status = StatusPassed
+	r.Error = ""
+	if err != nil {
+		r.Status = StatusFailed
+		r.Error = err.Error()
+	}
+
+	return r, err
+}
@@ -0,0 +1,107 @@
+package beekeeper_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/opentracing/opentracing-go"
+)
+
+var errTest = errors.New("test")
+
+// testAction returns the error
+type testAction struct {
+	err error
+}
+
+func (a testAction) Run(ctx context.Context, cluster orchestration.Cluster, o interface{}) error {
+	return a.err
+}
+
+// testReporter runs a passed and a failed step, and returns the error
+type testReporter struct {
+	testAction
+}
+
+func (a testReporter) RunResult(ctx context.Context, cluster orchestration.Cluster, o interface{}) (r beekeeper.Result, err error) {
+	_ = r.Step("pass", func() error { return nil })
+	_ = r.Step("fail", func() error { return errTest })
+	r.Measure("latency", 1.5, "s")
+	r.Attach("log", "/tmp/log")
+	// the status and error are set by the caller
+	r.Status = beekeeper.StatusSkipped
+	r.Error = "overridden"
+	return r, a.err
+}
+
+func TestResult(t *testing.T) {
+	var r beekeeper.Result
+
+	if err := r.Step("pass", func() error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Step("fail", func() error { return errTest }); !errors.Is(err, errTest) {
+		t.Fatalf("got error %v, want %v", err, errTest)
+	}
+	r.Measure("latency", 1.5, "s")
+	r.Attach("log", "/tmp/log")
+
+	if len(r.Steps) != 2 {
+		t.Fatalf("got %d steps, want 2", len(r.Steps))
+	}
+	if s := r.Steps[0]; s.Name != "pass" || s.Status != beekeeper.StatusPassed || s.Error != "" {
+		t.Errorf("got step %+v, want passed step", s)
+	}
+	if s := r.Steps[1]; s.Name != "fail" || s.Status != beekeeper.StatusFailed || s.Error != errTest.Error() {
+		t.Errorf("got step %+v, want failed step", s)
+	}
+	if failed := r.Failed(); len(failed) != 1 || failed[0].Name != "fail" {
+		t.Errorf("got failed steps %+v, want step fail", failed)
+	}
+	if want := []beekeeper.Measurement{{Name: "latency", Value: 1.5, Unit: "s"}}; !reflect.DeepEqual(r.Measurements, want) {
+		t.Errorf("got measurements %+v, want %+v", r.Measurements, want)
+	}
+	if want := []beekeeper.Artifact{{Name: "log", Path: "/tmp/log"}}; !reflect.DeepEqual(r.Artifacts, want) {
+		t.Errorf("got artifacts %+v, want %+v", r.Artifacts, want)
+	}
+}
+
+func TestRunResult(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		action     beekeeper.Action
+		wantStatus beekeeper.Status
+		wantError  string
+		wantSteps  int
+	}{
+		{name: "action passed", action: testAction{}, wantStatus: beekeeper.StatusPassed},
+		{name: "action failed", action: testAction{err: errTest}, wantStatus: beekeeper.StatusFailed, wantError: errTest.Error()},
+		{name: "reporter passed", action: testReporter{}, wantStatus: beekeeper.StatusPassed, wantSteps: 2},
+		{name: "reporter failed", action: testReporter{testAction{err: errTest}}, wantStatus: beekeeper.StatusFailed, wantError: errTest.Error(), wantSteps: 2},
+		// the result is reported through the tracing middleware
+		{name: "middleware", action: beekeeper.NewActionMiddleware(opentracing.NoopTracer{}, testReporter{}, "test"), wantStatus: beekeeper.StatusPassed, wantSteps: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+
+			r, err := beekeeper.RunResult(context.Background(), nil, tc.action, nil)
+			if tc.wantError == "" && err != nil || tc.wantError != "" && (err == nil || err.Error() != tc.wantError) {
+				t.Fatalf("got error %v, want %q", err, tc.wantError)
+			}
+			if r.Status != tc.wantStatus || r.Error != tc.wantError {
+				t.Errorf("got status %s with error %q, want %s with %q", r.Status, r.Error, tc.wantStatus, tc.wantError)
+			}
+			if len(r.Steps) != tc.wantSteps {
+				t.Errorf("got %d steps, want %d", len(r.Steps), tc.wantSteps)
+			}
+			if r.Start.Before(start) || r.Duration < 0 || r.Duration > time.Since(start) {
+				t.Errorf("got start %s and duration %s of the run started at %s", r.Start, r.Duration, start)
+			}
+		})
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/opentracing/opentracing-go"
 )
 
-var _ Action = (*actionMiddleware)(nil)
+var (
+	_ Action         = (*actionMiddleware)(nil)
+	_ ResultReporter = (*actionMiddleware)(nil)
+)
 
 type actionMiddleware struct {
 	tracer     opentracing.Tracer
@@ -33,6 +36,14 @@ func (am *actionMiddleware) Run(ctx context.Context, cluster orchestration.Clust
 	return am.action.Run(ctx, cluster, o)
 }
 
+// RunResult implements beekeeper.ResultReporter
+func (am *actionMiddleware) RunResult(ctx context.Context, cluster orchestration.Cluster, o interface{}) (Result, error) {
+	span := createSpan(ctx, am.tracer, am.actionName)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+	return RunResult(ctx, cluster, am.action, o)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
@@ -0,0 +1,189 @@
+// Package chaos provides actions that disrupt nodes of the cluster while
+// checks run, so resilience checks share the manipulation of nodes instead of
+// reimplementing it.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// Action represents chaos action run against the cluster
+type Action interface {
+	// Name returns name of the action, used in logs and errors
+	Name() string
+	// Run disrupts nodes of the cluster until the context is done or the
+	// action is completed
+	Run(ctx context.Context, cluster orchestration.Cluster) error
+}
+
+// Start runs the actions in the background until the returned stop function
+// is called, stop waits for the actions to return and returns the first error
+// of them, actions stopped by it do not fail
+func Start(ctx context.Context, cluster orchestration.Cluster, actions ...Action) (stop func() error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for _, a := range actions {
+		wg.Add(1)
+		go func(a Action) {
+			defer wg.Done()
+			err := a.Run(ctx, cluster)
+			if err == nil || errors.Is(err, context.Canceled) {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("chaos action %s: %w", a.Name(), err)
+			}
+		}(a)
+	}
+
+	return func() error {
+		cancel()
+		wg.Wait()
+		return firstErr
+	}
+}
+
+// WaitReady waits until the node is ready or the timeout expires
+func WaitReady(ctx context.Context, g orchestration.NodeGroup, name string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		ok, err := g.NodeReady(ctx, name)
+		if err == nil && ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("node %s not ready after %s: %w", name, timeout, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// readyPollTimeout is the time a poll of node readiness has to answer in
+const readyPollTimeout = 2 * time.Second
+
+// WaitRecovery waits until the killed node goes down and becomes ready again,
+// or the timeout expires. The node is down while its readiness is false or not
+// answered within the poll timeout, so readiness that blocks until the node is
+// ready does not hide the restart.
+func WaitRecovery(ctx context.Context, g orchestration.NodeGroup, name string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	down := false
+	for {
+		pollCtx, cancelPoll := context.WithTimeout(ctx, readyPollTimeout)
+		ok, err := g.NodeReady(pollCtx, name)
+		cancelPoll()
+		switch {
+		case err == nil && ok:
+			if down {
+				return nil
+			}
+		case err == nil, errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil:
+			// readiness not answered in time is readiness waiting for the
+			// node to come up
+			down = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("node %s not ready after %s: %w", name, timeout, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// inject runs the injection of faults after the delay and their removal
+// after the duration, or when the context is done if the duration is 0.
+// Faults are removed after the injection fails or the action is stopped too.
+func inject(ctx context.Context, logger logging.Logger, name string, delay, duration time.Duration, injectFn, removeFn func(ctx context.Context) error) (err error) {
+	if err := sleep(ctx, delay); err != nil {
+		return err
+	}
+
+	defer func() {
+		removeCtx, cancel := context.WithTimeout(context.Background(), healTimeout)
+		defer cancel()
+		if removeErr := removeFn(removeCtx); removeErr != nil && !errors.Is(removeErr, orchestration.ErrNotSupported) {
+			if err == nil || errors.Is(err, context.Canceled) {
+				err = removeErr
+				return
+			}
+			logger.Errorf("chaos %s: %v", name, removeErr)
+		}
+	}()
+
+	if err := injectFn(ctx); err != nil {
+		return err
+	}
+
+	if duration == 0 {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if err := sleep(ctx, duration); err != nil {
+		return err
+	}
+
+	logger.Infof("chaos %s: removing faults after %s", name, duration)
+	return nil
+}
+
+// nodeNames returns the names with names of node groups replaced by names of
+// their nodes
+func nodeNames(cluster orchestration.Cluster, names []string) (l []string, err error) {
+	nodeGroups := cluster.NodeGroups()
+	nodes := cluster.Nodes()
+
+	for _, name := range names {
+		if g, ok := nodeGroups[name]; ok {
+			l = append(l, g.NodesSorted()...)
+			continue
+		}
+		if _, ok := nodes[name]; !ok {
+			return nil, fmt.Errorf("node or node group %s not found", name)
+		}
+		l = append(l, name)
+	}
+
+	return l, nil
+}
+
+// nodeGroup returns node group of the node
+func nodeGroup(cluster orchestration.Cluster, name string) (orchestration.NodeGroup, error) {
+	for _, g := range cluster.NodeGroups() {
+		if _, ok := g.Nodes()[name]; ok {
+			return g, nil
+		}
+	}
+	return nil, fmt.Errorf("node %s not found", name)
+}
+
+// sleep waits for the duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -0,0 +1,234 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+var logger = logging.New(io.Discard, 0, "")
+
+// testCluster implements the parts of the cluster used by actions
+type testCluster struct {
+	orchestration.Cluster
+	groups map[string]*testNodeGroup
+}
+
+func newTestCluster(groups map[string][]string, downFor time.Duration) *testCluster {
+	c := &testCluster{groups: make(map[string]*testNodeGroup)}
+	for name, nodes := range groups {
+		g := &testNodeGroup{
+			name:    name,
+			nodes:   nodes,
+			downFor: downFor,
+			killed:  make(map[string]time.Time),
+			stopped: make(map[string]bool),
+		}
+		c.groups[name] = g
+	}
+	return c
+}
+
+func (c *testCluster) NodeGroups() map[string]orchestration.NodeGroup {
+	l := make(map[string]orchestration.NodeGroup, len(c.groups))
+	for name, g := range c.groups {
+		l[name] = g
+	}
+	return l
+}
+
+func (c *testCluster) Nodes() map[string]orchestration.Node {
+	l := make(map[string]orchestration.Node)
+	for _, g := range c.groups {
+		for name, n := range g.Nodes() {
+			l[name] = n
+		}
+	}
+	return l
+}
+
+// testNodeGroup implements the parts of the node group used by actions,
+// killed nodes are not ready for the down duration
+type testNodeGroup struct {
+	orchestration.NodeGroup
+	name     string
+	nodes    []string
+	downFor  time.Duration
+	blocking bool        // readiness of killed nodes waits until they are ready
+	client   *bee.Client // client of every node
+
+	mu      sync.Mutex
+	killed  map[string]time.Time
+	stopped map[string]bool
+	kills   []string
+}
+
+func (g *testNodeGroup) Name() string {
+	return g.name
+}
+
+func (g *testNodeGroup) Nodes() map[string]orchestration.Node {
+	l := make(map[string]orchestration.Node, len(g.nodes))
+	for _, name := range g.nodes {
+		l[name] = nil
+	}
+	return l
+}
+
+func (g *testNodeGroup) NodesSorted() []string {
+	l := append([]string(nil), g.nodes...)
+	sort.Strings(l)
+	return l
+}
+
+func (g *testNodeGroup) NodeClient(name string) (*bee.Client, error) {
+	if g.client == nil {
+		return nil, fmt.Errorf("node %s has no client", name)
+	}
+	return g.client, nil
+}
+
+func (g *testNodeGroup) KillNode(ctx context.Context, name string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.killed[name] = time.Now()
+	g.kills = append(g.kills, name)
+	return nil
+}
+
+func (g *testNodeGroup) StopNode(ctx context.Context, name string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.stopped[name] = true
+	return nil
+}
+
+func (g *testNodeGroup) StartNode(ctx context.Context, name string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.stopped[name] {
+		return fmt.Errorf("node %s is not stopped", name)
+	}
+	g.stopped[name] = false
+	return nil
+}
+
+func (g *testNodeGroup) NodeReady(ctx context.Context, name string) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stopped[name] {
+		return false, nil
+	}
+	killed, ok := g.killed[name]
+	if !ok || time.Since(killed) >= g.downFor {
+		return true, nil
+	}
+	if !g.blocking {
+		return false, nil
+	}
+
+	g.mu.Unlock()
+	defer g.mu.Lock()
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-time.After(g.downFor - time.Since(killed)):
+		return true, nil
+	}
+}
+
+// testAction runs until the context is done or returns the error
+type testAction struct {
+	err error
+}
+
+func (a testAction) Name() string {
+	return "test"
+}
+
+func (a testAction) Run(ctx context.Context, cluster orchestration.Cluster) error {
+	if a.err != nil {
+		return a.err
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestStart(t *testing.T) {
+	cluster := newTestCluster(nil, 0)
+
+	stop := Start(context.Background(), cluster, testAction{}, testAction{})
+	if err := stop(); err != nil {
+		t.Errorf("stopped actions failed: %v", err)
+	}
+
+	errTest := errors.New("test")
+	stop = Start(context.Background(), cluster, testAction{}, testAction{err: errTest})
+	if err := stop(); !errors.Is(err, errTest) {
+		t.Errorf("got error %v, want %v", err, errTest)
+	}
+}
+
+func TestNodeNames(t *testing.T) {
+	cluster := newTestCluster(map[string][]string{
+		"bee":   {"bee-1", "bee-0"},
+		"light": {"light-0"},
+	}, 0)
+
+	got, err := nodeNames(cluster, []string{"bee", "light-0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"bee-0", "bee-1", "light-0"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got nodes %v, want %v", got, want)
+	}
+
+	if _, err := nodeNames(cluster, []string{"unknown"}); err == nil {
+		t.Error("unknown node is expanded")
+	}
+}
+
+func TestWaitRecovery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test waiting for recovery of killed nodes")
+	}
+
+	for _, tc := range []struct {
+		name     string
+		downFor  time.Duration
+		blocking bool
+		wantErr  bool
+	}{
+		{name: "ready", downFor: 1500 * time.Millisecond},
+		// readiness watches the node until it is ready, as readiness of
+		// statefulset replicas does
+		{name: "blocking ready", downFor: 3 * time.Second, blocking: true},
+		{name: "never down", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := newTestCluster(map[string][]string{"bee": {"bee-0"}}, tc.downFor)
+			g := cluster.groups["bee"]
+			g.blocking = tc.blocking
+
+			if err := g.KillNode(context.Background(), "bee-0"); err != nil {
+				t.Fatal(err)
+			}
+			err := WaitRecovery(context.Background(), g, "bee-0", 6*time.Second)
+			if tc.wantErr && !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
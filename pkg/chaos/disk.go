@@ -0,0 +1,127 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// DiskPressureOptions represents options of the disk pressure action
+type DiskPressureOptions struct {
+	Nodes    []string      // names of nodes or node groups whose data volumes are filled
+	Percent  int           // percentage of capacity of data volumes filled
+	Delay    time.Duration // delay before volumes are filled
+	Duration time.Duration // time volumes are kept filled, until the action is stopped if 0
+}
+
+// compile check whether DiskPressure implements interface
+var _ Action = (*DiskPressure)(nil)
+
+// DiskPressure represents the action filling data volumes of nodes to a
+// percentage of their capacity, so nodes approach their disk capacity
+type DiskPressure struct {
+	opts   DiskPressureOptions
+	logger logging.Logger
+}
+
+// NewDiskPressure returns new disk pressure action
+func NewDiskPressure(o DiskPressureOptions, logger logging.Logger) *DiskPressure {
+	return &DiskPressure{
+		opts:   o,
+		logger: logger,
+	}
+}
+
+// Name returns name of the action
+func (d *DiskPressure) Name() string {
+	return "disk-pressure"
+}
+
+// Run fills data volumes of the nodes after the delay and frees them after
+// the duration, or when the context is done
+func (d *DiskPressure) Run(ctx context.Context, cluster orchestration.Cluster) error {
+	nodes, err := nodeNames(cluster, d.opts.Nodes)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return errors.New("no nodes")
+	}
+
+	return inject(ctx, d.logger, d.Name(), d.opts.Delay, d.opts.Duration, func(ctx context.Context) error {
+		for _, name := range nodes {
+			g, err := nodeGroup(cluster, name)
+			if err != nil {
+				return err
+			}
+			size, err := orchestration.FillDisk(ctx, g, name, d.opts.Percent)
+			if err != nil {
+				return err
+			}
+			d.logger.Infof("chaos %s: node %s: data volume is filled to %d%% with %d bytes", d.Name(), name, d.opts.Percent, size)
+		}
+		return nil
+	}, func(ctx context.Context) error {
+		for _, name := range nodes {
+			g, err := nodeGroup(cluster, name)
+			if err != nil {
+				return err
+			}
+			if err := orchestration.FreeDisk(ctx, g, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// IOFaultOptions represents options of the IO fault action
+type IOFaultOptions struct {
+	Faults   []orchestration.IOFault // faults injected together
+	Delay    time.Duration           // delay before faults are injected
+	Duration time.Duration           // time faults are injected for, until the action is stopped if 0
+}
+
+// compile check whether IOFault implements interface
+var _ Action = (*IOFault)(nil)
+
+// IOFault represents the action throttling IO of data volumes of nodes by
+// chaos-mesh and removing the faults when it is done
+type IOFault struct {
+	opts   IOFaultOptions
+	logger logging.Logger
+}
+
+// NewIOFault returns new IO fault action
+func NewIOFault(o IOFaultOptions, logger logging.Logger) *IOFault {
+	return &IOFault{
+		opts:   o,
+		logger: logger,
+	}
+}
+
+// Name returns name of the action
+func (i *IOFault) Name() string {
+	return "io-fault"
+}
+
+// Run injects the faults after the delay and removes them after the
+// duration, or when the context is done
+func (i *IOFault) Run(ctx context.Context, cluster orchestration.Cluster) error {
+	if len(i.opts.Faults) == 0 {
+		return errors.New("no faults")
+	}
+
+	return inject(ctx, i.logger, i.Name(), i.opts.Delay, i.opts.Duration, func(ctx context.Context) error {
+		for _, f := range i.opts.Faults {
+			if err := cluster.InjectIOFault(ctx, f); err != nil {
+				return err
+			}
+		}
+		i.logger.Infof("chaos %s: %d faults are injected", i.Name(), len(i.opts.Faults))
+		return nil
+	}, cluster.RemoveIOFaults)
+}
@@ -0,0 +1,59 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// NetworkFaultOptions represents options of the network fault action
+type NetworkFaultOptions struct {
+	Faults   []orchestration.NetworkFault // faults injected together
+	Delay    time.Duration                // delay before faults are injected
+	Duration time.Duration                // time faults are injected for, until the action is stopped if 0
+}
+
+// compile check whether NetworkFault implements interface
+var _ Action = (*NetworkFault)(nil)
+
+// NetworkFault represents the action injecting faults of networks of pods of
+// nodes, like latency, packet loss or bandwidth limits, by chaos-mesh and
+// removing them when it is done
+type NetworkFault struct {
+	opts   NetworkFaultOptions
+	logger logging.Logger
+}
+
+// NewNetworkFault returns new network fault action
+func NewNetworkFault(o NetworkFaultOptions, logger logging.Logger) *NetworkFault {
+	return &NetworkFault{
+		opts:   o,
+		logger: logger,
+	}
+}
+
+// Name returns name of the action
+func (n *NetworkFault) Name() string {
+	return "network-fault"
+}
+
+// Run injects the faults after the delay and removes them after the
+// duration, or when the context is done
+func (n *NetworkFault) Run(ctx context.Context, cluster orchestration.Cluster) error {
+	if len(n.opts.Faults) == 0 {
+		return errors.New("no faults")
+	}
+
+	return inject(ctx, n.logger, n.Name(), n.opts.Delay, n.opts.Duration, func(ctx context.Context) error {
+		for _, f := range n.opts.Faults {
+			if err := cluster.InjectNetworkFault(ctx, f); err != nil {
+				return err
+			}
+		}
+		n.logger.Infof("chaos %s: %d faults are injected", n.Name(), len(n.opts.Faults))
+		return nil
+	}, cluster.RemoveNetworkFaults)
+}
@@ -0,0 +1,133 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// healTimeout is the time the partition has to be healed in, after the
+// action is stopped too
+const healTimeout = time.Minute
+
+// PartitionOptions represents options of the partition action, unset ones
+// have defaults
+type PartitionOptions struct {
+	Groups   [][]string    // names of nodes or node groups of every group, nodes not in any group form another one
+	Delay    time.Duration // delay before the cluster is partitioned
+	Duration time.Duration // time the cluster is partitioned, 1m by default
+	// During is called when the cluster is partitioned, and the partition is
+	// healed after it returns and the duration passes, so checks assert
+	// behavior of the partitioned cluster
+	During func(ctx context.Context) error
+	// After is called when the partition is healed, so checks assert
+	// recovery of the cluster, like convergence of synchronization
+	After func(ctx context.Context) error
+}
+
+// compile check whether Partition implements interface
+var _ Action = (*Partition)(nil)
+
+// Partition represents the action splitting nodes of the cluster into groups
+// not able to connect to each other for a duration, then healing the cluster
+type Partition struct {
+	opts   PartitionOptions
+	logger logging.Logger
+}
+
+// NewPartition returns new partition action
+func NewPartition(o PartitionOptions, logger logging.Logger) *Partition {
+	if o.Duration <= 0 {
+		o.Duration = time.Minute
+	}
+
+	return &Partition{
+		opts:   o,
+		logger: logger,
+	}
+}
+
+// Name returns name of the action
+func (p *Partition) Name() string {
+	return "partition"
+}
+
+// Run partitions the cluster after the delay and heals it after the
+// duration, or when the context is done
+func (p *Partition) Run(ctx context.Context, cluster orchestration.Cluster) (err error) {
+	groups, err := partitionGroups(cluster, p.opts.Groups)
+	if err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		return errors.New("no partition groups")
+	}
+
+	if err := sleep(ctx, p.opts.Delay); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if err := cluster.Partition(ctx, groups); err != nil {
+		if errors.Is(err, orchestration.ErrNotSupported) {
+			return err
+		}
+		return p.heal(cluster, err)
+	}
+	p.logger.Infof("chaos %s: cluster is partitioned into groups %v", p.Name(), groups)
+
+	if p.opts.During != nil {
+		if err := p.opts.During(ctx); err != nil {
+			return p.heal(cluster, fmt.Errorf("during partition: %w", err))
+		}
+	}
+	if err := sleep(ctx, p.opts.Duration-time.Since(start)); err != nil {
+		return p.heal(cluster, err)
+	}
+
+	if err := p.heal(cluster, nil); err != nil {
+		return err
+	}
+	p.logger.Infof("chaos %s: cluster is healed after %s", p.Name(), time.Since(start).Round(time.Second))
+
+	if p.opts.After != nil {
+		if err := p.opts.After(ctx); err != nil {
+			return fmt.Errorf("after partition: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// heal heals the cluster, even after the action is stopped, and returns the
+// error, or the error of healing if there is none
+func (p *Partition) heal(cluster orchestration.Cluster, err error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), healTimeout)
+	defer cancel()
+
+	if healErr := cluster.Heal(ctx); healErr != nil {
+		if err == nil {
+			return healErr
+		}
+		p.logger.Errorf("chaos %s: %v", p.Name(), healErr)
+	}
+	return err
+}
+
+// partitionGroups returns the groups with names of node groups replaced by
+// names of their nodes
+func partitionGroups(cluster orchestration.Cluster, groups [][]string) (l [][]string, err error) {
+	for _, group := range groups {
+		names, err := nodeNames(cluster, group)
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, names)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,229 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+const (
+	// PodKillModeKill kills pods of nodes, simulating unclean shutdowns
+	PodKillModeKill = "kill"
+	// PodKillModeRestart stops nodes and starts them after the stop delay
+	PodKillModeRestart = "restart"
+)
+
+// PodKillOptions represents options of the pod kill action, unset ones have
+// defaults
+type PodKillOptions struct {
+	Mode         string        // kill, the default, or restart
+	NodeGroups   []string      // node groups nodes are selected from, all by default
+	Nodes        []string      // nodes selected from, overrides node groups
+	Exclude      []string      // nodes never selected, like the ones checks upload to
+	Count        int           // number of nodes affected every round, 1 by default
+	Interval     time.Duration // delay before every round, 1m by default
+	Rounds       int           // number of rounds, until the action is stopped if 0
+	StopDelay    time.Duration // time nodes are kept stopped in restart mode, 10s by default
+	ReadyTimeout time.Duration // time affected nodes have to become ready, 5m by default
+	Seed         int64         // seed of selection of nodes, random by default
+}
+
+// compile check whether PodKill implements interface
+var _ Action = (*PodKill)(nil)
+
+// PodKill represents the action killing or restarting randomly selected
+// nodes on a schedule
+type PodKill struct {
+	opts   PodKillOptions
+	logger logging.Logger
+}
+
+// NewPodKill returns new pod kill action
+func NewPodKill(o PodKillOptions, logger logging.Logger) *PodKill {
+	if o.Mode == "" {
+		o.Mode = PodKillModeKill
+	}
+	if o.Count <= 0 {
+		o.Count = 1
+	}
+	if o.Interval <= 0 {
+		o.Interval = time.Minute
+	}
+	if o.StopDelay <= 0 {
+		o.StopDelay = 10 * time.Second
+	}
+	if o.ReadyTimeout <= 0 {
+		o.ReadyTimeout = 5 * time.Minute
+	}
+	if o.Seed == 0 {
+		o.Seed = random.Int64()
+	}
+
+	return &PodKill{
+		opts:   o,
+		logger: logger,
+	}
+}
+
+// Name returns name of the action
+func (p *PodKill) Name() string {
+	return "pod-kill"
+}
+
+// Run kills or restarts the configured number of randomly selected nodes
+// every interval and waits for them to become ready, until the context is
+// done or all rounds are completed
+func (p *PodKill) Run(ctx context.Context, cluster orchestration.Cluster) error {
+	if p.opts.Mode != PodKillModeKill && p.opts.Mode != PodKillModeRestart {
+		return fmt.Errorf("mode %s is not supported", p.opts.Mode)
+	}
+
+	nodes := p.candidates(cluster)
+	if len(nodes) == 0 {
+		return errors.New("no nodes to select")
+	}
+
+	p.logger.Infof("chaos %s: seed: %d", p.Name(), p.opts.Seed)
+	rnd := random.PseudoGenerator(p.opts.Seed)
+
+	for round := 1; p.opts.Rounds == 0 || round <= p.opts.Rounds; round++ {
+		if err := sleep(ctx, p.opts.Interval); err != nil {
+			return err
+		}
+
+		count := p.opts.Count
+		if count > len(nodes) {
+			count = len(nodes)
+		}
+		var selected []string
+		for _, i := range rnd.Perm(len(nodes))[:count] {
+			selected = append(selected, nodes[i])
+		}
+		sort.Strings(selected)
+
+		p.logger.Infof("chaos %s: round %d: %s nodes %v", p.Name(), round, p.opts.Mode, selected)
+		if err := p.disrupt(ctx, cluster, selected); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// disrupt kills or restarts the nodes and waits for all of them to become
+// ready
+func (p *PodKill) disrupt(ctx context.Context, cluster orchestration.Cluster, nodes []string) error {
+	groups := make(map[string]orchestration.NodeGroup, len(nodes))
+	for _, name := range nodes {
+		g, err := nodeGroup(cluster, name)
+		if err != nil {
+			return err
+		}
+		groups[name] = g
+	}
+
+	start := time.Now()
+	switch p.opts.Mode {
+	case PodKillModeKill:
+		// every node is killed and watched concurrently, so its recovery is
+		// observed even when other nodes recover slowly
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			firstErr error
+		)
+		for _, name := range nodes {
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				err := groups[name].KillNode(ctx, name)
+				if err != nil {
+					err = fmt.Errorf("kill node %s: %w", name, err)
+				} else {
+					err = WaitRecovery(ctx, groups[name], name, p.opts.ReadyTimeout)
+				}
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}(name)
+		}
+		wg.Wait()
+		if firstErr != nil {
+			return firstErr
+		}
+	case PodKillModeRestart:
+		for _, name := range nodes {
+			if err := groups[name].StopNode(ctx, name); err != nil {
+				return fmt.Errorf("stop node %s: %w", name, err)
+			}
+		}
+		if err := sleep(ctx, p.opts.StopDelay); err != nil {
+			return err
+		}
+		for _, name := range nodes {
+			if err := groups[name].StartNode(ctx, name); err != nil {
+				return fmt.Errorf("start node %s: %w", name, err)
+			}
+		}
+		for _, name := range nodes {
+			if err := WaitReady(ctx, groups[name], name, p.opts.ReadyTimeout); err != nil {
+				return err
+			}
+		}
+	}
+
+	p.logger.Infof("chaos %s: nodes %v are ready after %s", p.Name(), nodes, time.Since(start).Round(time.Second))
+	return nil
+}
+
+// candidates returns sorted names of nodes the action selects from
+func (p *PodKill) candidates(cluster orchestration.Cluster) (nodes []string) {
+	exclude := make(map[string]bool, len(p.opts.Exclude))
+	for _, name := range p.opts.Exclude {
+		exclude[name] = true
+	}
+
+	if len(p.opts.Nodes) > 0 {
+		for _, name := range p.opts.Nodes {
+			if !exclude[name] {
+				nodes = append(nodes, name)
+			}
+		}
+		sort.Strings(nodes)
+		return nodes
+	}
+
+	for name, g := range cluster.NodeGroups() {
+		if len(p.opts.NodeGroups) > 0 && !contains(p.opts.NodeGroups, name) {
+			continue
+		}
+		for _, n := range g.NodesSorted() {
+			if !exclude[n] {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// contains returns whether the list contains the string
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
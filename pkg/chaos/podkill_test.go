@@ -0,0 +1,129 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestPodKillCandidates(t *testing.T) {
+	cluster := newTestCluster(map[string][]string{
+		"bee":   {"bee-2", "bee-0", "bee-1"},
+		"light": {"light-0", "light-1"},
+	}, 0)
+
+	for _, tc := range []struct {
+		name string
+		opts PodKillOptions
+		want []string
+	}{
+		{
+			name: "all",
+			want: []string{"bee-0", "bee-1", "bee-2", "light-0", "light-1"},
+		},
+		{
+			name: "node groups",
+			opts: PodKillOptions{NodeGroups: []string{"bee"}},
+			want: []string{"bee-0", "bee-1", "bee-2"},
+		},
+		{
+			name: "exclude",
+			opts: PodKillOptions{NodeGroups: []string{"bee"}, Exclude: []string{"bee-1"}},
+			want: []string{"bee-0", "bee-2"},
+		},
+		{
+			name: "nodes override node groups",
+			opts: PodKillOptions{NodeGroups: []string{"bee"}, Nodes: []string{"light-1", "bee-0"}, Exclude: []string{"bee-0"}},
+			want: []string{"light-1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewPodKill(tc.opts, logger).candidates(cluster)
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("got candidates %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPodKillRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test waiting for recovery of killed nodes")
+	}
+
+	// nodes recover between polls of their readiness, every node has to be
+	// watched while others are watched too
+	cluster := newTestCluster(map[string][]string{
+		"bee": {"bee-0", "bee-1", "bee-2", "bee-3"},
+	}, 1500*time.Millisecond)
+
+	p := NewPodKill(PodKillOptions{
+		Count:        3,
+		Interval:     time.Millisecond,
+		Rounds:       1,
+		ReadyTimeout: 5 * time.Second,
+		Seed:         1,
+	}, logger)
+	if err := p.Run(context.Background(), cluster); err != nil {
+		t.Fatal(err)
+	}
+
+	kills := cluster.groups["bee"].kills
+	if len(kills) != 3 {
+		t.Fatalf("got %d killed nodes, want 3", len(kills))
+	}
+
+	// the selection is deterministic for the seed
+	cluster2 := newTestCluster(map[string][]string{
+		"bee": {"bee-0", "bee-1", "bee-2", "bee-3"},
+	}, 0)
+	if err := NewPodKill(PodKillOptions{Count: 3, Interval: time.Millisecond, Rounds: 1, ReadyTimeout: time.Second, Seed: 1}, logger).Run(context.Background(), cluster2); err == nil {
+		t.Fatal("recovery of nodes that never go down is observed")
+	}
+	kills2 := cluster2.groups["bee"].kills
+	sort.Strings(kills)
+	sort.Strings(kills2)
+	if fmt.Sprint(kills) != fmt.Sprint(kills2) {
+		t.Errorf("got killed nodes %v and %v with the same seed", kills, kills2)
+	}
+}
+
+func TestPodKillRestart(t *testing.T) {
+	cluster := newTestCluster(map[string][]string{
+		"bee": {"bee-0", "bee-1"},
+	}, 0)
+
+	p := NewPodKill(PodKillOptions{
+		Mode:      PodKillModeRestart,
+		Count:     2,
+		Interval:  time.Millisecond,
+		Rounds:    2,
+		StopDelay: time.Millisecond,
+	}, logger)
+	if err := p.Run(context.Background(), cluster); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"bee-0", "bee-1"} {
+		if cluster.groups["bee"].stopped[name] {
+			t.Errorf("node %s is not started", name)
+		}
+	}
+}
+
+func TestPodKillStop(t *testing.T) {
+	cluster := newTestCluster(map[string][]string{
+		"bee": {"bee-0"},
+	}, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// rounds are run until the action is stopped
+	err := NewPodKill(PodKillOptions{Interval: time.Hour}, logger).Run(ctx, cluster)
+	if err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,250 @@
+package chaos
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// StressOptions represents options of the stress action, unset ones have
+// defaults
+type StressOptions struct {
+	Stresses      []orchestration.Stress // stress injected together
+	Delay         time.Duration          // delay before stress is injected
+	Duration      time.Duration          // time stress is injected for, until the action is stopped if 0
+	ProbeInterval time.Duration          // interval of probes of API latency of nodes of stressed node groups, 10s by default
+	MaxLatency    time.Duration          // latency of probes failing the action, probes are only logged if 0
+}
+
+// compile check whether Stress implements interface
+var _ Action = (*Stress)(nil)
+
+// Stress represents the action loading CPU and memory of nodes by chaos-mesh
+// and probing latency of their APIs while they are stressed, so checks show
+// whether nodes degrade gracefully under resource pressure
+type Stress struct {
+	opts   StressOptions
+	logger logging.Logger
+}
+
+// NewStress returns new stress action
+func NewStress(o StressOptions, logger logging.Logger) *Stress {
+	if o.ProbeInterval <= 0 {
+		o.ProbeInterval = 10 * time.Second
+	}
+
+	return &Stress{
+		opts:   o,
+		logger: logger,
+	}
+}
+
+// Name returns name of the action
+func (s *Stress) Name() string {
+	return "stress"
+}
+
+// Run injects the stress after the delay and removes it after the duration,
+// or when the context is done. APIs of nodes are probed while they are
+// stressed, and the action fails if a probe fails or exceeds the maximal
+// latency, even if it is stopped.
+func (s *Stress) Run(ctx context.Context, cluster orchestration.Cluster) error {
+	if len(s.opts.Stresses) == 0 {
+		return errors.New("no stress")
+	}
+
+	nodes, err := nodeNames(cluster, s.nodeGroups(cluster))
+	if err != nil {
+		return err
+	}
+
+	targets, err := probeTargets(cluster, nodes)
+	if err != nil {
+		return err
+	}
+
+	probeCtx, cancelProbe := context.WithCancel(ctx)
+	defer cancelProbe()
+
+	var (
+		wg       sync.WaitGroup
+		probeErr error
+	)
+	err = inject(ctx, s.logger, s.Name(), s.opts.Delay, s.opts.Duration, func(ctx context.Context) error {
+		for _, st := range s.opts.Stresses {
+			if err := cluster.InjectStress(ctx, st); err != nil {
+				return err
+			}
+		}
+		s.logger.Infof("chaos %s: %d stresses are injected", s.Name(), len(s.opts.Stresses))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			probeErr = s.probe(probeCtx, targets)
+		}()
+		return nil
+	}, func(ctx context.Context) error {
+		cancelProbe()
+		wg.Wait()
+		return cluster.RemoveStress(ctx)
+	})
+	if probeErr != nil && (err == nil || errors.Is(err, context.Canceled)) {
+		return probeErr
+	}
+
+	return err
+}
+
+// probe requests health of the nodes every probe interval and logs the
+// maximal latency of every round, until the context is done. It returns the
+// first failed request or the first one exceeding the maximal latency, if it
+// is set.
+func (s *Stress) probe(ctx context.Context, targets []probeTarget) (err error) {
+	timeout := s.opts.MaxLatency
+	if timeout <= 0 {
+		timeout = s.opts.ProbeInterval
+	}
+
+	for round := 1; ; round++ {
+		if sleep(ctx, s.opts.ProbeInterval) != nil {
+			return err
+		}
+
+		var (
+			maxLatency time.Duration
+			maxNode    string
+			failed     int
+		)
+		for _, target := range targets {
+			name := target.name
+			latency, probeErr := target.probe(ctx, timeout)
+			if ctx.Err() != nil {
+				return err
+			}
+			if probeErr != nil {
+				failed++
+				s.logger.Warningf("chaos %s: round %d: node %s: %v", s.Name(), round, name, probeErr)
+				if err == nil && s.opts.MaxLatency > 0 {
+					err = fmt.Errorf("node %s: %w", name, probeErr)
+				}
+				continue
+			}
+			if latency > maxLatency {
+				maxLatency, maxNode = latency, name
+			}
+			if err == nil && s.opts.MaxLatency > 0 && latency > s.opts.MaxLatency {
+				err = fmt.Errorf("node %s: api latency %s exceeds %s", name, latency, s.opts.MaxLatency)
+			}
+		}
+
+		s.logger.Infof("chaos %s: round %d: maximal api latency %s of node %s, %d of %d probes failed", s.Name(), round, maxLatency, maxNode, failed, len(targets))
+	}
+}
+
+// probeTarget is the health endpoint of a node probed with its own client.
+// Bee clients retry and rate limit requests, which would hide the latency
+// and failures of a single request.
+type probeTarget struct {
+	name   string
+	url    string
+	token  string
+	client *http.Client
+}
+
+// probeTargets returns health endpoints of debug APIs of the nodes
+func probeTargets(cluster orchestration.Cluster, nodes []string) (targets []probeTarget, err error) {
+	for _, name := range nodes {
+		g, err := nodeGroup(cluster, name)
+		if err != nil {
+			return nil, err
+		}
+		c, err := g.NodeClient(name)
+		if err != nil {
+			return nil, err
+		}
+		o := c.Config()
+		if o.DebugAPIURL == nil {
+			return nil, fmt.Errorf("node %s: no debug api", name)
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if o.TLSConfig != nil {
+			transport.TLSClientConfig = o.TLSConfig.Clone()
+		}
+		if o.DebugAPIInsecureTLS {
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{}
+			}
+			transport.TLSClientConfig.InsecureSkipVerify = true
+		}
+
+		targets = append(targets, probeTarget{
+			name:   name,
+			url:    o.DebugAPIURL.JoinPath("health").String(),
+			token:  o.BearerToken,
+			client: &http.Client{Transport: transport},
+		})
+	}
+
+	return targets, nil
+}
+
+// probe returns latency of a single health request of the node
+func (t probeTarget) probe(ctx context.Context, timeout time.Duration) (latency time.Duration, err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.url, nil)
+	if err != nil {
+		return 0, err
+	}
+	if t.token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+
+	start := time.Now()
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("health: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("health: %s", resp.Status)
+	}
+
+	return time.Since(start), nil
+}
+
+// nodeGroups returns sorted names of node groups of the stress, all if any
+// of it is injected into all of them
+func (s *Stress) nodeGroups(cluster orchestration.Cluster) (names []string) {
+	set := make(map[string]bool)
+	for _, st := range s.opts.Stresses {
+		if len(st.NodeGroups) == 0 {
+			for name := range cluster.NodeGroups() {
+				set[name] = true
+			}
+			continue
+		}
+		for _, name := range st.NodeGroups {
+			set[name] = true
+		}
+	}
+
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
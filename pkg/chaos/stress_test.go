@@ -0,0 +1,129 @@
+package chaos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+)
+
+// testHealthServer answers health requests with the status after the delay
+// and counts them
+type testHealthServer struct {
+	*httptest.Server
+	status int
+	delay  time.Duration
+
+	mu       sync.Mutex
+	requests int
+	token    string
+}
+
+func newTestHealthServer(t *testing.T, status int, delay time.Duration) *testHealthServer {
+	s := &testHealthServer{status: status, delay: delay}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		s.token = r.Header.Get("Authorization")
+		s.mu.Unlock()
+
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		time.Sleep(s.delay)
+		w.WriteHeader(s.status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testHealthServer) client(t *testing.T, token string) *bee.Client {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bee.NewClient(bee.ClientOptions{
+		DebugAPIURL: u,
+		BearerToken: token,
+		Retry:       bee.RetryOptions{ReadRetries: 5, MinBackoff: time.Millisecond},
+	}, logger)
+}
+
+func TestProbeTarget(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		status  int
+		wantErr string
+	}{
+		{name: "healthy", status: http.StatusOK},
+		// failures are not retried, unlike with the client of the node
+		{name: "unavailable", status: http.StatusServiceUnavailable, wantErr: "503"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestHealthServer(t, tc.status, 0)
+			cluster := newTestCluster(map[string][]string{"bee": {"bee-0"}}, 0)
+			cluster.groups["bee"].client = server.client(t, "secret")
+
+			targets, err := probeTargets(cluster, []string{"bee-0"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			latency, err := targets[0].probe(context.Background(), time.Second)
+			if tc.wantErr == "" && (err != nil || latency <= 0) || tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Errorf("got latency %s with error %v, want error %q", latency, err, tc.wantErr)
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if server.requests != 1 {
+				t.Errorf("got %d requests, want 1", server.requests)
+			}
+			if server.token != "Bearer secret" {
+				t.Errorf("got authorization %q, want the bearer token", server.token)
+			}
+		})
+	}
+}
+
+func TestStressProbe(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		status     int
+		delay      time.Duration
+		maxLatency time.Duration
+		wantErr    string
+	}{
+		{name: "within latency", status: http.StatusOK, maxLatency: time.Second},
+		{name: "latency exceeded", status: http.StatusOK, delay: 20 * time.Millisecond, maxLatency: 10 * time.Millisecond, wantErr: "node bee-0"},
+		{name: "failed", status: http.StatusInternalServerError, maxLatency: time.Second, wantErr: "node bee-0: health: 500"},
+		// probes are only logged without the maximal latency
+		{name: "failed without max latency", status: http.StatusInternalServerError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestHealthServer(t, tc.status, tc.delay)
+			cluster := newTestCluster(map[string][]string{"bee": {"bee-0"}}, 0)
+			cluster.groups["bee"].client = server.client(t, "")
+
+			targets, err := probeTargets(cluster, []string{"bee-0"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			s := NewStress(StressOptions{ProbeInterval: 10 * time.Millisecond, MaxLatency: tc.maxLatency}, logger)
+			err = s.probe(ctx, targets)
+			if tc.wantErr == "" && err != nil || tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Errorf("got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
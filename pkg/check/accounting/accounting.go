@@ -0,0 +1,332 @@
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	DisconnectTolerance int64 // percentage of the threshold a debt may exceed it before peers disconnect
+	Downloaders         int
+	ExpectRefreshment   bool
+	FileSize            int64
+	GasPrice            string
+	LightRefreshRate    int64 // refreshment per second of debts of light nodes
+	MirrorRetries       int
+	MirrorRetryDelay    time.Duration
+	PostageAmount       int64
+	PostageDepth        uint64
+	PostageLabel        string
+	RefreshRate         int64 // refreshment per second of debts of full nodes
+	RefreshSlack        time.Duration
+	Rounds              int
+	Seed                int64
+	Uploaders           int
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		DisconnectTolerance: 25,
+		Downloaders:         2,
+		ExpectRefreshment:   true,
+		FileSize:            1024 * 1024, // 1mb
+		GasPrice:            "",
+		LightRefreshRate:    450000,
+		MirrorRetries:       5,
+		MirrorRetryDelay:    2 * time.Second,
+		PostageAmount:       1000,
+		PostageDepth:        20,
+		PostageLabel:        "test-label",
+		RefreshRate:         4500000,
+		RefreshSlack:        5 * time.Second,
+		Rounds:              5,
+		Seed:                random.Int64(),
+		Uploaders:           1,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run drives asymmetric traffic, with a few nodes uploading and other nodes
+// downloading, and asserts accounting invariants: balances of node pairs
+// mirror each other, debts respect payment thresholds, and pseudosettle
+// refreshments mirror each other and do not exceed the refresh rate.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	overlays, err := cluster.FlattenOverlays(ctx)
+	if err != nil {
+		return err
+	}
+
+	fullNodes := cluster.FullNodeNames()
+	if len(fullNodes) < o.Uploaders+o.Downloaders {
+		return fmt.Errorf("accounting check requires at least %d full nodes", o.Uploaders+o.Downloaders)
+	}
+	sort.Strings(fullNodes)
+	rnd.Shuffle(len(fullNodes), func(i, j int) { fullNodes[i], fullNodes[j] = fullNodes[j], fullNodes[i] })
+	uploaders := fullNodes[:o.Uploaders]
+	downloaders := fullNodes[o.Uploaders : o.Uploaders+o.Downloaders]
+
+	light := make(map[string]bool)
+	for _, name := range cluster.LightNodeNames() {
+		light[overlays[name].String()] = true
+	}
+
+	before, err := c.timeSettlements(ctx, clients)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+
+	if err := c.traffic(ctx, clients, uploaders, downloaders, rnd, o); err != nil {
+		return err
+	}
+
+	var violations []string
+
+	mirror, err := c.checkBalances(ctx, clients, overlays, o)
+	if err != nil {
+		return err
+	}
+	violations = append(violations, mirror...)
+
+	after, err := c.timeSettlements(ctx, clients)
+	if err != nil {
+		return err
+	}
+	elapsed := time.Since(start)
+
+	refresh, total := c.checkRefreshments(before, after, overlays, light, elapsed, o)
+	violations = append(violations, refresh...)
+	c.logger.Infof("pseudosettle refreshed %d in %s", total, elapsed.Round(time.Second))
+
+	if o.ExpectRefreshment && total == 0 {
+		violations = append(violations, "no pseudosettle refreshment happened during traffic")
+	}
+
+	c.metrics.Violations.Add(float64(len(violations)))
+	for _, v := range violations {
+		c.logger.Infof("violation: %s", v)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%d accounting invariant violations, first: %s", len(violations), violations[0])
+	}
+
+	c.logger.Info("accounting invariants hold")
+
+	return nil
+}
+
+// traffic uploads files from uploaders and downloads them from downloaders
+func (c *Check) traffic(ctx context.Context, clients map[string]*bee.Client, uploaders, downloaders []string, rnd *rand.Rand, o Options) error {
+	batchIDs := make(map[string]string)
+	for _, name := range uploaders {
+		batchID, err := clients[name].GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+		if err != nil {
+			return fmt.Errorf("node %s: batch id %w", name, err)
+		}
+		batchIDs[name] = batchID
+		c.logger.Infof("node %s: batch id %s", name, batchID)
+	}
+
+	for i := 0; i < o.Rounds; i++ {
+		for _, u := range uploaders {
+			data := make([]byte, o.FileSize)
+			if _, err := rnd.Read(data); err != nil {
+				return fmt.Errorf("random data: %w", err)
+			}
+
+			ref, err := clients[u].UploadBytes(ctx, data, api.UploadOptions{BatchID: batchIDs[u]})
+			if err != nil {
+				return fmt.Errorf("node %s: upload: %w", u, err)
+			}
+			c.metrics.TrafficBytes.WithLabelValues("upload").Add(float64(len(data)))
+
+			for _, d := range downloaders {
+				if _, err := clients[d].DownloadBytes(ctx, ref); err != nil {
+					return fmt.Errorf("node %s: download %s: %w", d, ref, err)
+				}
+				c.metrics.TrafficBytes.WithLabelValues("download").Add(float64(len(data)))
+			}
+		}
+		c.logger.Infof("traffic round %d of %d done", i+1, o.Rounds)
+	}
+
+	return nil
+}
+
+// checkBalances asserts that balances of every node pair mirror each other and
+// that debts respect thresholds. Mirroring is retried as balances of a pair
+// can not be read atomically.
+func (c *Check) checkBalances(ctx context.Context, clients map[string]*bee.Client, overlays map[string]swarm.Address, o Options) (violations []string, err error) {
+	names := make(map[string]string, len(overlays)) // overlay -> name
+	for name, overlay := range overlays {
+		names[overlay.String()] = name
+	}
+
+	for i := 0; i <= o.MirrorRetries; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(o.MirrorRetryDelay):
+			}
+		}
+
+		accounts := make(map[string]map[string]bee.Account) // node -> peer overlay -> account
+		for name, client := range clients {
+			a, err := client.Accounting(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("node %s: %w", name, err)
+			}
+			accounts[name] = make(map[string]bee.Account, len(a.Accounting))
+			for _, acc := range a.Accounting {
+				accounts[name][acc.Peer] = acc
+			}
+		}
+
+		violations = nil
+		for name, peers := range accounts {
+			for peer, acc := range peers {
+				violations = append(violations, thresholdViolations(name, peer, acc, o.DisconnectTolerance)...)
+
+				peerName, ok := names[peer]
+				if !ok {
+					continue // peer outside of the cluster
+				}
+				if name > peerName {
+					continue // check every pair once
+				}
+
+				other, ok := accounts[peerName][overlays[name].String()]
+				if !ok {
+					if acc.Balance != 0 {
+						violations = append(violations, fmt.Sprintf("node %s has balance %d with %s that has no account with it", name, acc.Balance, peerName))
+					}
+					continue
+				}
+				if acc.Balance+other.Balance != 0 {
+					violations = append(violations, fmt.Sprintf("balances do not mirror: %s has %d with %s, %s has %d with %s", name, acc.Balance, peerName, peerName, other.Balance, name))
+				}
+			}
+		}
+
+		if len(violations) == 0 {
+			return nil, nil
+		}
+		sort.Strings(violations)
+	}
+
+	return violations, nil
+}
+
+// thresholdViolations returns violations of payment thresholds of the account
+func thresholdViolations(name, peer string, acc bee.Account, tolerance int64) (violations []string) {
+	if acc.Balance > 0 && acc.ThresholdReceived > 0 {
+		if limit := acc.ThresholdReceived * (100 + tolerance) / 100; acc.Balance > limit {
+			violations = append(violations, fmt.Sprintf("node %s: peer %s owes %d, more than the disconnect limit %d", name, peer, acc.Balance, limit))
+		}
+	}
+	if acc.Balance < 0 && acc.ThresholdGiven > 0 {
+		if limit := acc.ThresholdGiven * (100 + tolerance) / 100; -acc.Balance > limit {
+			violations = append(violations, fmt.Sprintf("node %s: owes %d to peer %s, more than the disconnect limit %d", name, -acc.Balance, peer, limit))
+		}
+	}
+	return violations
+}
+
+// timeSettlements returns pseudosettle settlements of all nodes by node name
+// and peer overlay
+func (c *Check) timeSettlements(ctx context.Context, clients map[string]*bee.Client) (map[string]map[string]bee.Settlement, error) {
+	all := make(map[string]map[string]bee.Settlement, len(clients))
+	for name, client := range clients {
+		s, err := client.TimeSettlements(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("node %s: %w", name, err)
+		}
+		all[name] = make(map[string]bee.Settlement, len(s.Settlements))
+		for _, p := range s.Settlements {
+			all[name][p.Peer] = p
+		}
+	}
+	return all, nil
+}
+
+// checkRefreshments asserts that refreshments received by a node from a peer
+// equal refreshments sent by the peer to the node and do not exceed the
+// refresh rate, and returns the total refreshment
+func (c *Check) checkRefreshments(before, after map[string]map[string]bee.Settlement, overlays map[string]swarm.Address, light map[string]bool, elapsed time.Duration, o Options) (violations []string, total int64) {
+	names := make(map[string]string, len(overlays))
+	for name, overlay := range overlays {
+		names[overlay.String()] = name
+	}
+
+	for name, peers := range after {
+		for peer, s := range peers {
+			received := s.Received - before[name][peer].Received
+			total += received
+
+			rate := o.RefreshRate
+			if light[peer] {
+				rate = o.LightRefreshRate
+			}
+			if limit := rate * int64((elapsed + o.RefreshSlack).Seconds()); received > limit {
+				violations = append(violations, fmt.Sprintf("node %s received refreshment %d from %s in %s, more than %d allowed by rate %d", name, received, peer, elapsed.Round(time.Second), limit, rate))
+			}
+
+			peerName, ok := names[peer]
+			if !ok {
+				continue
+			}
+			self := overlays[name].String()
+			sent := after[peerName][self].Sent - before[peerName][self].Sent
+			if received != sent {
+				violations = append(violations, fmt.Sprintf("refreshments do not mirror: %s received %d from %s, %s sent %d", name, received, peerName, peerName, sent))
+			}
+			c.metrics.Refreshment.WithLabelValues(name, peerName).Set(float64(received))
+		}
+	}
+
+	sort.Strings(violations)
+	return violations, total
+}
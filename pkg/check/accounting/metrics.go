@@ -0,0 +1,48 @@
+package accounting
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	TrafficBytes *prometheus.CounterVec
+	Refreshment  *prometheus.GaugeVec
+	Violations   prometheus.Counter
+}
+
+func newMetrics() metrics {
+	subsystem := "check_accounting"
+	return metrics{
+		TrafficBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "traffic_bytes",
+				Help:      "Number of bytes uploaded and downloaded to drive accounting.",
+			},
+			[]string{"direction"},
+		),
+		Refreshment: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "refreshment",
+				Help:      "Pseudosettle refreshment received by a node from a peer during the check.",
+			},
+			[]string{"node", "peer"},
+		),
+		Violations: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "violations_count",
+				Help:      "Number of accounting invariant violations.",
+			},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
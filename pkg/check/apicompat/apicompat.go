@@ -0,0 +1,310 @@
+package apicompat
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	ContentSize   int64
+	GasPrice      string
+	NodeGroups    []string // node groups to compare, all groups if empty
+	PostageAmount int64
+	PostageDepth  uint64
+	PostageLabel  string
+	Seed          int64
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		ContentSize:   1024 * 10, // 10kb
+		GasPrice:      "",
+		PostageAmount: 1000,
+		PostageDepth:  20,
+		PostageLabel:  "test-label",
+		Seed:          random.Int64(),
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// endpoints whose response shapes are compared between versions
+var (
+	apiEndpoints      = []string{"/pins"}
+	debugAPIEndpoints = []string{"/addresses", "/chainstate", "/health", "/stamps", "/topology", "/wallet"}
+)
+
+// scenario steps
+const (
+	stepStamps   = "stamps"
+	stepUpload   = "upload"
+	stepDownload = "download"
+	stepPin      = "pin"
+	stepPSS      = "pss"
+)
+
+var steps = []string{stepStamps, stepUpload, stepDownload, stepPin, stepPSS}
+
+// result of the scenario run against a node of a group
+type result struct {
+	group   string
+	node    string
+	version string
+	errs    map[string]error // step -> error
+	ref     swarm.Address
+	shapes  map[string]shape // endpoint -> response shape
+}
+
+// Run runs the same scenario against a node of every node group and compares
+// the results between bee versions. The oldest version is the baseline and
+// every newer version must behave the same and keep all fields of its
+// responses.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	groups := cluster.NodeGroups()
+	groupNames := o.NodeGroups
+	if len(groupNames) == 0 {
+		for name := range groups {
+			groupNames = append(groupNames, name)
+		}
+	}
+	sort.Strings(groupNames)
+
+	data := make([]byte, o.ContentSize)
+	if _, err := rnd.Read(data); err != nil {
+		return fmt.Errorf("random data: %w", err)
+	}
+
+	var recipient *bee.Client
+	var results []result
+	for _, gName := range groupNames {
+		g, ok := groups[gName]
+		if !ok {
+			return fmt.Errorf("node group %s not found", gName)
+		}
+		nodes := g.NodesSorted()
+		if len(nodes) == 0 {
+			c.logger.Infof("node group %s has no nodes, skipping", gName)
+			continue
+		}
+		node := nodes[0]
+
+		// all nodes send pss messages to the first one
+		if recipient == nil {
+			recipient = clients[node]
+		}
+
+		r, err := c.scenario(ctx, gName, node, clients[node], recipient, data, o)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", node, err)
+		}
+		results = append(results, r)
+	}
+
+	if len(results) < 2 {
+		return errors.New("api compatibility check requires at least 2 node groups with nodes")
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return compareVersions(results[i].version, results[j].version) < 0
+	})
+
+	base := results[0]
+	var violations []string
+	for _, r := range results[1:] {
+		v := compare(base, r)
+		for _, s := range v {
+			c.logger.Infof("%s (%s) -> %s (%s): %s", base.version, base.group, r.version, r.group, s)
+		}
+		c.metrics.Violations.WithLabelValues(base.version, r.version).Add(float64(len(v)))
+		violations = append(violations, v...)
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("%d api contract violations compared to version %s", len(violations), base.version)
+	}
+
+	c.logger.Infof("api of %d node groups is compatible with version %s", len(results), base.version)
+
+	return nil
+}
+
+// scenario uploads, downloads and pins content, sends a pss message and
+// records response shapes of the compared endpoints
+func (c *Check) scenario(ctx context.Context, group, node string, client, recipient *bee.Client, data []byte, o Options) (r result, err error) {
+	health, err := client.Health(ctx)
+	if err != nil {
+		return result{}, fmt.Errorf("health: %w", err)
+	}
+
+	r = result{
+		group:   group,
+		node:    node,
+		version: health.Version,
+		errs:    make(map[string]error),
+		shapes:  make(map[string]shape),
+	}
+	c.logger.Infof("node %s: group %s, version %s", node, group, r.version)
+
+	batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	r.errs[stepStamps] = err
+	if err != nil {
+		// no other step can be done without a batch
+		return r, nil
+	}
+
+	r.ref, r.errs[stepUpload] = client.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID})
+	if r.errs[stepUpload] == nil {
+		got, err := client.DownloadBytes(ctx, r.ref)
+		if err == nil && !bytes.Equal(got, data) {
+			err = errors.New("downloaded content differs")
+		}
+		r.errs[stepDownload] = err
+
+		r.errs[stepPin] = pin(ctx, client, r.ref)
+	}
+
+	addr, err := recipient.Addresses(ctx)
+	if err != nil {
+		return result{}, fmt.Errorf("recipient addresses: %w", err)
+	}
+	topic := fmt.Sprintf("apicompat-%d", time.Now().UnixNano())
+	r.errs[stepPSS] = client.SendPSSMessage(ctx, addr.Overlay, addr.PSSPublicKey, topic, 1, []byte(node), batchID)
+
+	for _, e := range apiEndpoints {
+		resp, err := client.APIResponse(ctx, e)
+		if err != nil {
+			r.errs["GET "+e] = err
+			continue
+		}
+		r.shapes["api "+e] = shapeOf(resp)
+	}
+	for _, e := range debugAPIEndpoints {
+		resp, err := client.DebugAPIResponse(ctx, e)
+		if err != nil {
+			r.errs["GET debug "+e] = err
+			continue
+		}
+		r.shapes["debug api "+e] = shapeOf(resp)
+	}
+
+	for _, s := range steps {
+		if err := r.errs[s]; err != nil {
+			c.logger.Infof("node %s: %s: %v", node, s, err)
+		}
+	}
+
+	return r, nil
+}
+
+// pin pins the reference, verifies that it is listed and unpins it
+func pin(ctx context.Context, client *bee.Client, ref swarm.Address) error {
+	if err := client.PinRootHash(ctx, ref); err != nil {
+		return err
+	}
+
+	pinned, err := client.GetPinnedRootHash(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if !pinned.Equal(ref) {
+		return errors.New("pinned reference not found")
+	}
+
+	return client.UnpinRootHash(ctx, ref)
+}
+
+// compare returns contract violations of the newer result against the base
+func compare(base, newer result) (violations []string) {
+	for step, err := range base.errs {
+		if err == nil && newer.errs[step] != nil {
+			violations = append(violations, fmt.Sprintf("%s works in %s, fails in %s: %v", step, base.version, newer.version, newer.errs[step]))
+		}
+	}
+
+	if !base.ref.IsZero() && !newer.ref.IsZero() && !base.ref.Equal(newer.ref) {
+		violations = append(violations, fmt.Sprintf("same content has reference %s in %s and %s in %s", base.ref, base.version, newer.ref, newer.version))
+	}
+
+	for endpoint, s := range base.shapes {
+		ns, ok := newer.shapes[endpoint]
+		if !ok {
+			continue // request failure is reported above
+		}
+		for _, b := range breaks(s, ns) {
+			violations = append(violations, endpoint+": "+b)
+		}
+	}
+
+	sort.Strings(violations)
+	return violations
+}
+
+// compareVersions compares bee versions like 1.13.0-8d5e5ffd by their
+// numeric major, minor and patch parts
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) (p [3]int) {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	for i, s := range strings.SplitN(v, ".", 3) {
+		p[i], _ = strconv.Atoi(s)
+	}
+	return p
+}
@@ -0,0 +1,29 @@
+package apicompat
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Violations *prometheus.CounterVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_apicompat"
+	return metrics{
+		Violations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "violations_count",
+				Help:      "Number of API contract violations of a newer bee version compared to the baseline version.",
+			},
+			[]string{"base_version", "version"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
@@ -0,0 +1,108 @@
+package apicompat
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// shape maps JSON paths of a response to the kinds of their values. Array
+// elements are merged under the "[]" path segment.
+type shape map[string]string
+
+const (
+	kindArray  = "array"
+	kindBool   = "bool"
+	kindNull   = "null"
+	kindNumber = "number"
+	kindObject = "object"
+	kindString = "string"
+)
+
+// shapeOf returns the shape of a decoded JSON value
+func shapeOf(v interface{}) shape {
+	s := make(shape)
+	s.add("", v)
+	return s
+}
+
+func (s shape) add(path string, v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		s[path] = kindObject
+		for k, e := range v {
+			s.add(path+"."+k, e)
+		}
+	case []interface{}:
+		s[path] = kindArray
+		for _, e := range v {
+			s.add(path+"[]", e)
+		}
+	case string:
+		s[path] = kindString
+	case float64:
+		s[path] = kindNumber
+	case bool:
+		s[path] = kindBool
+	case nil:
+		s[path] = kindNull
+	}
+}
+
+// breaks returns descriptions of paths of the old shape that are missing or
+// have a different kind in the new shape. Additions are not breaking. Paths
+// under arrays that are empty in the new shape and null values can not be
+// compared and are skipped.
+func breaks(old, new shape) (b []string) {
+	for path, kind := range old {
+		if kind == kindNull || underEmptyArray(path, new) {
+			continue
+		}
+
+		newKind, ok := new[path]
+		switch {
+		case !ok:
+			b = append(b, displayPath(path)+" removed")
+		case newKind != kind && newKind != kindNull:
+			b = append(b, displayPath(path)+" changed from "+kind+" to "+newKind)
+		}
+	}
+
+	sort.Strings(b)
+	return b
+}
+
+// underEmptyArray returns true if the path is an element of an array that has
+// no elements in the shape
+func underEmptyArray(path string, s shape) bool {
+	for i := strings.Index(path, "[]"); i >= 0; {
+		array := path[:i]
+		if s[array] == kindArray && !hasPrefix(s, array+"[]") {
+			return true
+		}
+
+		next := strings.Index(path[i+2:], "[]")
+		if next < 0 {
+			break
+		}
+		i += 2 + next
+	}
+
+	return false
+}
+
+func hasPrefix(s shape, prefix string) bool {
+	for path := range s {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "response"
+	}
+	return strconv.Quote(strings.TrimPrefix(path, "."))
+}
@@ -0,0 +1,208 @@
+package apifuzz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/check/restarts"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	GasPrice           string
+	Iterations         int // number of times the static cases are sent
+	MaxGoroutineGrowth int // allowed growth of node's goroutines after the fuzzing
+	NodeName           string
+	PostageAmount      int64
+	PostageDepth       uint64
+	PostageLabel       string
+	RandomCases        int // number of random cases sent in every iteration
+	RequestTimeout     time.Duration
+	Seed               int64
+	SettleTimeout      time.Duration // time the goroutines have to return to the baseline
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		GasPrice:           "",
+		Iterations:         5,
+		MaxGoroutineGrowth: 50,
+		PostageAmount:      1000,
+		PostageDepth:       20,
+		PostageLabel:       "test-label",
+		RandomCases:        50,
+		RequestTimeout:     30 * time.Second,
+		Seed:               random.Int64(),
+		SettleTimeout:      time.Minute,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run sends malformed requests to the upload endpoints of a node. Malformed
+// requests must be rejected with a 4xx status code, random requests must not
+// cause a 5xx status code, and the node must not restart or leak goroutines.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	name := o.NodeName
+	if name == "" {
+		fullNodes := cluster.FullNodeNames()
+		if len(fullNodes) == 0 {
+			return errors.New("api fuzz check requires at least one full node")
+		}
+		sort.Strings(fullNodes)
+		name = fullNodes[rnd.Intn(len(fullNodes))]
+	}
+	client, ok := clients[name]
+	if !ok {
+		return fmt.Errorf("node %s not found", name)
+	}
+
+	batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: batch id %w", name, err)
+	}
+	c.logger.Infof("node %s: batch id %s", name, batchID)
+
+	watcher := restarts.NewWatcher(cluster, c.logger)
+	if err := watcher.Begin(ctx, "fuzz", "malformed uploads"); err != nil {
+		return err
+	}
+
+	baseline, err := client.Goroutines(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", name, err)
+	}
+	c.logger.Infof("node %s: %d goroutines before fuzzing", name, baseline)
+
+	var violations []string
+	for i := 0; i < o.Iterations; i++ {
+		for _, fc := range staticCases(batchID) {
+			if v := c.send(ctx, client, name, fc, o, true); v != "" {
+				violations = append(violations, v)
+			}
+		}
+		for _, fc := range randomCases(rnd, batchID, o.RandomCases) {
+			if v := c.send(ctx, client, name, fc, o, false); v != "" {
+				violations = append(violations, v)
+			}
+		}
+		c.logger.Infof("node %s: iteration %d of %d done", name, i+1, o.Iterations)
+	}
+
+	events, err := watcher.End(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		violations = append(violations, e.String())
+	}
+
+	if err := c.waitGoroutines(ctx, client, name, baseline, o); err != nil {
+		violations = append(violations, err.Error())
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("node %s: %d violations, first: %s", name, len(violations), violations[0])
+	}
+
+	c.logger.Infof("node %s: all malformed requests were rejected cleanly", name)
+
+	return nil
+}
+
+// send sends the request and returns a description of the violation, if any
+func (c *Check) send(ctx context.Context, client *bee.Client, name string, fc fuzzCase, o Options, malformed bool) string {
+	ctx, cancel := context.WithTimeout(ctx, o.RequestTimeout)
+	defer cancel()
+
+	resp, err := client.APIRequest(ctx, fc.method, fc.path, fc.header, fc.reader())
+	if err != nil {
+		if fc.truncated {
+			c.metrics.Requests.WithLabelValues(fc.path, "aborted").Inc()
+			return ""
+		}
+		c.metrics.Requests.WithLabelValues(fc.path, "error").Inc()
+		return fmt.Sprintf("%s %s (%s): request failed: %v", fc.method, fc.path, fc.name, err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	c.metrics.Requests.WithLabelValues(fc.path, fmt.Sprintf("%dxx", resp.StatusCode/100)).Inc()
+
+	switch {
+	case resp.StatusCode >= http.StatusInternalServerError:
+	case malformed && resp.StatusCode/100 != 4:
+	default:
+		return ""
+	}
+
+	v := fmt.Sprintf("%s %s (%s): status %d", fc.method, fc.path, fc.name, resp.StatusCode)
+	c.logger.Infof("node %s: %s", name, v)
+	return v
+}
+
+// waitGoroutines waits until the number of node's goroutines is within the
+// allowed growth from the baseline
+func (c *Check) waitGoroutines(ctx context.Context, client *bee.Client, name string, baseline int, o Options) error {
+	ctx, cancel := context.WithTimeout(ctx, o.SettleTimeout)
+	defer cancel()
+
+	for {
+		n, err := client.Goroutines(ctx)
+		if err == nil {
+			c.metrics.GoroutineGrowth.WithLabelValues(name).Set(float64(n - baseline))
+			if n-baseline <= o.MaxGoroutineGrowth {
+				c.logger.Infof("node %s: %d goroutines after fuzzing", name, n)
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("goroutines: %w", err)
+			}
+			return fmt.Errorf("goroutines grew from %d to %d, allowed growth %d", baseline, n, o.MaxGoroutineGrowth)
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
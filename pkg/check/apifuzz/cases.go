@@ -0,0 +1,157 @@
+package apifuzz
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+)
+
+// header names of the upload endpoints
+const (
+	batchHeader      = "Swarm-Postage-Batch-Id"
+	collectionHeader = "Swarm-Collection"
+	deferredHeader   = "Swarm-Deferred-Upload"
+	encryptHeader    = "Swarm-Encrypt"
+	pinHeader        = "Swarm-Pin"
+	tagHeader        = "Swarm-Tag"
+)
+
+// fuzzCase is a malformed upload request
+type fuzzCase struct {
+	name   string
+	method string
+	path   string
+	header http.Header
+	body   []byte
+	// truncated cases abort the request body midway, the node may not
+	// respond at all
+	truncated bool
+}
+
+// reader returns the request body
+func (fc fuzzCase) reader() io.Reader {
+	if fc.truncated {
+		return io.MultiReader(bytes.NewReader(fc.body), errReader{})
+	}
+	return bytes.NewReader(fc.body)
+}
+
+// errReader fails reading, aborting a chunked request body
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("truncated body")
+}
+
+var endpoints = []string{"/chunks", "/bytes", "/bzz"}
+
+// staticCases returns malformed requests to every upload endpoint. Cases that
+// target validation other than the batch id use the valid batch id. Values of
+// the pin and encrypt headers that are not booleans and long file names are
+// accepted by nodes, so they are only sent by random cases.
+func staticCases(batchID string) (cases []fuzzCase) {
+	unknownBatch := strings.Repeat("0", 64)
+	chunk := make([]byte, 8+4096)
+	chunk[0] = 0x00
+	chunk[1] = 0x10 // span of 4096 bytes
+
+	for _, e := range endpoints {
+		cases = append(cases,
+			fuzzCase{name: "missing batch id", path: e, header: header(""), body: chunk},
+			fuzzCase{name: "non hex batch id", path: e, header: header("zz" + unknownBatch[2:]), body: chunk},
+			fuzzCase{name: "short batch id", path: e, header: header("00ff"), body: chunk},
+			fuzzCase{name: "unknown batch id", path: e, header: header(unknownBatch), body: chunk},
+			fuzzCase{name: "absurdly long batch id", path: e, header: header(strings.Repeat("ab", 32*1024)), body: chunk},
+			fuzzCase{name: "empty body", path: e, header: header(batchID)},
+			fuzzCase{name: "invalid tag", path: e, header: with(header(batchID), tagHeader, "not-a-number"), body: chunk},
+			fuzzCase{name: "negative tag", path: e, header: with(header(batchID), tagHeader, "-1"), body: chunk},
+			fuzzCase{name: "unknown tag", path: e, header: with(header(batchID), tagHeader, "4294967295"), body: chunk},
+			fuzzCase{name: "invalid deferred upload", path: e, header: with(header(batchID), deferredHeader, "sometimes"), body: chunk},
+			fuzzCase{name: "truncated body", path: e, header: header(batchID), body: chunk[:100], truncated: true},
+		)
+	}
+
+	cases = append(cases,
+		fuzzCase{name: "chunk shorter than span", path: "/chunks", header: header(batchID), body: []byte{1, 2, 3}},
+		fuzzCase{name: "chunk larger than max size", path: "/chunks", header: header(batchID), body: make([]byte, 8+4096+1)},
+		fuzzCase{name: "invalid content type", path: "/bzz", header: with(header(batchID), "Content-Type", "text/;;="), body: chunk},
+		fuzzCase{name: "multipart without boundary", path: "/bzz", header: with(header(batchID), "Content-Type", "multipart/form-data"), body: chunk},
+		fuzzCase{name: "garbage tar collection", path: "/bzz", header: with(with(header(batchID), "Content-Type", "application/x-tar"), collectionHeader, "true"), body: chunk},
+		fuzzCase{name: "garbage multipart collection", path: "/bzz", header: with(with(header(batchID), "Content-Type", "multipart/form-data; boundary=xyz"), collectionHeader, "true"), body: chunk},
+	)
+
+	for i := range cases {
+		cases[i].method = http.MethodPost
+	}
+
+	return cases
+}
+
+// randomCases returns n requests with random batch ids, header values, content
+// types and bodies
+func randomCases(rnd *rand.Rand, batchID string, n int) []fuzzCase {
+	headers := []string{tagHeader, pinHeader, deferredHeader, encryptHeader, collectionHeader, "Content-Type"}
+	cases := make([]fuzzCase, 0, n)
+
+	for i := 0; i < n; i++ {
+		e := endpoints[rnd.Intn(len(endpoints))]
+
+		id := batchID
+		switch rnd.Intn(3) {
+		case 0:
+			id = hex.EncodeToString(randomBytes(rnd, rnd.Intn(64)))
+		case 1:
+			id = printable(randomBytes(rnd, rnd.Intn(64)))
+		}
+		h := header(id)
+
+		for j := rnd.Intn(3); j > 0; j-- {
+			h = with(h, headers[rnd.Intn(len(headers))], printable(randomBytes(rnd, rnd.Intn(32))))
+		}
+
+		cases = append(cases, fuzzCase{
+			name:      "random",
+			method:    http.MethodPost,
+			path:      e,
+			header:    h,
+			body:      randomBytes(rnd, rnd.Intn(2*4096)),
+			truncated: rnd.Intn(4) == 0,
+		})
+	}
+
+	return cases
+}
+
+func header(batchID string) http.Header {
+	h := make(http.Header)
+	if batchID != "" {
+		h.Set(batchHeader, batchID)
+	}
+	h.Set("Content-Type", "application/octet-stream")
+	return h
+}
+
+func with(h http.Header, key, value string) http.Header {
+	h = h.Clone()
+	h.Set(key, value)
+	return h
+}
+
+func randomBytes(rnd *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	_, _ = rnd.Read(b)
+	return b
+}
+
+// printable maps bytes to printable ASCII as Go HTTP client rejects invalid
+// header values
+func printable(b []byte) string {
+	for i := range b {
+		b[i] = ' ' + b[i]%('~'-' ')
+	}
+	return string(b)
+}
@@ -0,0 +1,39 @@
+package apifuzz
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Requests        *prometheus.CounterVec
+	GoroutineGrowth *prometheus.GaugeVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_apifuzz"
+	return metrics{
+		Requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "requests_count",
+				Help:      "Number of fuzzed requests by endpoint and status class.",
+			},
+			[]string{"endpoint", "status"},
+		),
+		GoroutineGrowth: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "goroutine_growth",
+				Help:      "Growth of node goroutines after fuzzing.",
+			},
+			[]string{"node"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
@@ -0,0 +1,124 @@
+package autotune
+
+import (
+	"sync"
+	"time"
+)
+
+// aimd is an additive-increase/multiplicative-decrease controller for the
+// number of concurrent upload streams against a single node. Feedback is
+// collected in windows; at the end of each window the limit is increased by
+// a constant step if the error rate and mean latency are within targets, or
+// multiplied by the backoff factor otherwise.
+type aimd struct {
+	mu sync.Mutex
+
+	limit    float64
+	min      float64
+	max      float64
+	increase float64
+	backoff  float64
+
+	maxErrorRate  float64
+	latencyTarget time.Duration
+	windowSize    int
+
+	samples   int
+	errors    int
+	latencies time.Duration
+
+	history []int
+}
+
+func newAIMD(o Options) *aimd {
+	return &aimd{
+		limit:         float64(o.InitialConcurrency),
+		min:           float64(o.MinConcurrency),
+		max:           float64(o.MaxConcurrency),
+		increase:      o.IncreaseStep,
+		backoff:       o.BackoffFactor,
+		maxErrorRate:  o.MaxErrorRate,
+		latencyTarget: o.LatencyTarget,
+		windowSize:    o.WindowSize,
+	}
+}
+
+// Limit returns the current concurrency limit.
+func (a *aimd) Limit() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return int(a.limit)
+}
+
+// Feedback records the outcome of a single upload and adjusts the limit
+// when the current window is complete.
+func (a *aimd) Feedback(d time.Duration, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.samples++
+	if err != nil {
+		a.errors++
+	} else {
+		a.latencies += d
+	}
+
+	if a.samples < a.windowSize {
+		return
+	}
+
+	errorRate := float64(a.errors) / float64(a.samples)
+	var meanLatency time.Duration
+	if ok := a.samples - a.errors; ok > 0 {
+		meanLatency = a.latencies / time.Duration(ok)
+	}
+
+	if errorRate > a.maxErrorRate || (a.latencyTarget > 0 && meanLatency > a.latencyTarget) {
+		a.limit *= a.backoff
+	} else {
+		a.limit += a.increase
+	}
+
+	if a.limit < a.min {
+		a.limit = a.min
+	}
+	if a.limit > a.max {
+		a.limit = a.max
+	}
+
+	a.history = append(a.history, int(a.limit))
+	a.samples, a.errors, a.latencies = 0, 0, 0
+}
+
+// Converged returns the mean limit over the last n windows, which is
+// reported as the optimal concurrency for the node. AIMD oscillates around
+// the capacity of the node, so the mean of the sawtooth is a better estimate
+// than the last observed value.
+func (a *aimd) Converged(n int) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.history) == 0 {
+		return a.limit
+	}
+
+	if n <= 0 || n > len(a.history) {
+		n = len(a.history)
+	}
+
+	var sum int
+	for _, l := range a.history[len(a.history)-n:] {
+		sum += l
+	}
+
+	return float64(sum) / float64(n)
+}
+
+// Windows returns the number of completed feedback windows.
+func (a *aimd) Windows() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return len(a.history)
+}
@@ -0,0 +1,158 @@
+package autotune
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAIMD(t *testing.T) {
+	errUpload := errors.New("upload")
+
+	o := NewDefaultOptions()
+	o.InitialConcurrency = 4
+	o.MinConcurrency = 2
+	o.MaxConcurrency = 8
+	o.IncreaseStep = 2
+	o.BackoffFactor = 0.5
+	o.MaxErrorRate = 0.25
+	o.LatencyTarget = time.Second
+	o.WindowSize = 4
+
+	// window feeds a full window of samples, failed ones of them with an
+	// error, the rest with the latency
+	window := func(a *aimd, failed int, latency time.Duration) {
+		for i := 0; i < o.WindowSize; i++ {
+			var err error
+			if i < failed {
+				err = errUpload
+			}
+			a.Feedback(latency, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		windows func(a *aimd)
+		want    []int
+	}{
+		{
+			name: "increase",
+			windows: func(a *aimd) {
+				window(a, 0, time.Millisecond)
+				window(a, 1, time.Millisecond) // error rate at the maximum
+			},
+			want: []int{6, 8},
+		},
+		{
+			name: "increase up to max",
+			windows: func(a *aimd) {
+				for i := 0; i < 4; i++ {
+					window(a, 0, time.Millisecond)
+				}
+			},
+			want: []int{6, 8, 8, 8},
+		},
+		{
+			name: "decrease on errors",
+			windows: func(a *aimd) {
+				window(a, 0, time.Millisecond)
+				window(a, 2, time.Millisecond)
+			},
+			want: []int{6, 3},
+		},
+		{
+			name: "decrease on latency",
+			windows: func(a *aimd) {
+				window(a, 0, 2*time.Second)
+			},
+			want: []int{2},
+		},
+		{
+			name: "decrease down to min",
+			windows: func(a *aimd) {
+				window(a, 4, 0)
+				window(a, 4, 0)
+			},
+			want: []int{2, 2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newAIMD(o)
+			if a.Limit() != o.InitialConcurrency {
+				t.Fatalf("got initial limit %d, want %d", a.Limit(), o.InitialConcurrency)
+			}
+
+			tc.windows(a)
+			if fmt.Sprint(a.history) != fmt.Sprint(tc.want) {
+				t.Errorf("got limits %v, want %v", a.history, tc.want)
+			}
+			if a.Windows() != len(tc.want) || a.Limit() != tc.want[len(tc.want)-1] {
+				t.Errorf("got limit %d after %d windows, want %d after %d", a.Limit(), a.Windows(), tc.want[len(tc.want)-1], len(tc.want))
+			}
+		})
+	}
+}
+
+func TestAIMDPartialWindow(t *testing.T) {
+	o := NewDefaultOptions()
+	o.WindowSize = 3
+	a := newAIMD(o)
+
+	a.Feedback(time.Millisecond, nil)
+	a.Feedback(time.Millisecond, nil)
+	if a.Windows() != 0 || a.Limit() != o.InitialConcurrency {
+		t.Errorf("limit %d is adjusted after %d windows before the window is complete", a.Limit(), a.Windows())
+	}
+}
+
+func TestAIMDConverged(t *testing.T) {
+	a := newAIMD(NewDefaultOptions())
+	if got := a.Converged(3); got != float64(a.Limit()) {
+		t.Errorf("got converged limit %v without windows, want the initial limit %d", got, a.Limit())
+	}
+
+	a.history = []int{2, 4, 6, 8}
+	for _, tc := range []struct {
+		n    int
+		want float64
+	}{
+		{n: 2, want: 7},
+		{n: 4, want: 5},
+		{n: 0, want: 5},
+		{n: 10, want: 5},
+	} {
+		if got := a.Converged(tc.n); got != tc.want {
+			t.Errorf("got converged limit %v over %d windows, want %v", got, tc.n, tc.want)
+		}
+	}
+}
+
+func TestValidateOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		modify  func(o *Options)
+		wantErr string
+	}{
+		{name: "defaults", modify: func(o *Options) {}},
+		{name: "min below one", modify: func(o *Options) { o.MinConcurrency = 0 }, wantErr: "invalid concurrency bounds"},
+		{name: "max below min", modify: func(o *Options) { o.MinConcurrency, o.MaxConcurrency = 4, 2 }, wantErr: "invalid concurrency bounds"},
+		{name: "initial below min", modify: func(o *Options) { o.MinConcurrency, o.InitialConcurrency = 2, 1 }, wantErr: "initial concurrency"},
+		{name: "initial above max", modify: func(o *Options) { o.InitialConcurrency = o.MaxConcurrency + 1 }, wantErr: "initial concurrency"},
+		{name: "initial at max", modify: func(o *Options) { o.InitialConcurrency = o.MaxConcurrency }},
+		{name: "backoff factor of one", modify: func(o *Options) { o.BackoffFactor = 1 }, wantErr: "backoff factor"},
+		{name: "no window", modify: func(o *Options) { o.WindowSize = 0 }, wantErr: "window size"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o := NewDefaultOptions()
+			tc.modify(&o)
+
+			err := validateOptions(o)
+			if tc.wantErr == "" && err != nil || tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Errorf("got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
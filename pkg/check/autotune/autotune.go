@@ -0,0 +1,246 @@
+package autotune
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	ContentSize        int64
+	Duration           time.Duration
+	GasPrice           string
+	PostageAmount      int64
+	PostageDepth       uint64
+	PostageLabel       string
+	Seed               int64
+	UploadGroups       []string
+	InitialConcurrency int
+	MinConcurrency     int
+	MaxConcurrency     int
+	IncreaseStep       float64
+	BackoffFactor      float64
+	MaxErrorRate       float64
+	LatencyTarget      time.Duration
+	WindowSize         int
+	ConvergeWindows    int
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		ContentSize:        1000000,
+		Duration:           10 * time.Minute,
+		GasPrice:           "",
+		PostageAmount:      1000000,
+		PostageDepth:       20,
+		PostageLabel:       "autotune",
+		Seed:               random.Int64(),
+		InitialConcurrency: 1,
+		MinConcurrency:     1,
+		MaxConcurrency:     64,
+		IncreaseStep:       1,
+		BackoffFactor:      0.5,
+		MaxErrorRate:       0.05,
+		LatencyTarget:      10 * time.Second,
+		WindowSize:         10,
+		ConvergeWindows:    5,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run adaptively tunes the number of concurrent upload streams per node and
+// reports the converged concurrency for each of them.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	if err := validateOptions(o); err != nil {
+		return err
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	nodes := cluster.FullNodeNames()
+	if len(o.UploadGroups) > 0 {
+		nodes = nodes[:0]
+		for _, g := range o.UploadGroups {
+			ng, err := cluster.NodeGroup(g)
+			if err != nil {
+				return err
+			}
+			nodes = append(nodes, ng.NodesSorted()...)
+		}
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("no upload nodes")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.Duration)
+	defer cancel()
+
+	rnds := random.PseudoGenerators(o.Seed, len(nodes))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string]float64, len(nodes))
+		errs    []error
+	)
+
+	for i, name := range nodes {
+		i, name := i, name
+		client, ok := clients[name]
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			converged, err := c.tune(ctx, name, client, rnds[i].Int63(), o)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("node %s: %w", name, err))
+				return
+			}
+			results[name] = converged
+		}()
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	for _, name := range nodes {
+		if v, ok := results[name]; ok {
+			c.logger.Infof("node %s: converged upload concurrency %.2f", name, v)
+		}
+	}
+
+	return nil
+}
+
+// validateOptions returns an error if the options of the controller are
+// invalid
+func validateOptions(o Options) error {
+	if o.MinConcurrency < 1 || o.MaxConcurrency < o.MinConcurrency {
+		return fmt.Errorf("invalid concurrency bounds: min %d, max %d", o.MinConcurrency, o.MaxConcurrency)
+	}
+	if o.InitialConcurrency < o.MinConcurrency || o.InitialConcurrency > o.MaxConcurrency {
+		return fmt.Errorf("initial concurrency %d must be between min %d and max %d", o.InitialConcurrency, o.MinConcurrency, o.MaxConcurrency)
+	}
+	if o.BackoffFactor <= 0 || o.BackoffFactor >= 1 {
+		return fmt.Errorf("backoff factor must be between 0 and 1, got %v", o.BackoffFactor)
+	}
+	if o.WindowSize < 1 {
+		return fmt.Errorf("window size must be positive, got %d", o.WindowSize)
+	}
+
+	return nil
+}
+
+// tune runs uploads against a single node until the context is done, keeping
+// the number of in-flight uploads at the limit given by the controller.
+func (c *Check) tune(ctx context.Context, name string, client *bee.Client, seed int64, o Options) (float64, error) {
+	batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return 0, fmt.Errorf("batch id: %w", err)
+	}
+	c.logger.Infof("node %s: batch id %s", name, batchID)
+
+	ctrl := newAIMD(o)
+	rnd := random.PseudoGenerator(seed)
+
+	var (
+		inflight int
+		done     = make(chan struct{})
+		wg       sync.WaitGroup
+	)
+
+	defer wg.Wait()
+
+	for {
+		limit := ctrl.Limit()
+		c.metrics.Concurrency.WithLabelValues(name).Set(float64(limit))
+
+		for inflight < limit {
+			data := make([]byte, o.ContentSize)
+			if _, err := rnd.Read(data); err != nil {
+				return 0, fmt.Errorf("random data: %w", err)
+			}
+
+			inflight++
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				start := time.Now()
+				_, err := client.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID})
+				d := time.Since(start)
+
+				// uploads cut short by the end of the run are not a signal
+				if ctx.Err() == nil {
+					if err != nil {
+						c.metrics.UploadErrors.WithLabelValues(name).Inc()
+						c.logger.Debugf("node %s: upload: %v", name, err)
+					} else {
+						c.metrics.UploadDuration.WithLabelValues(name).Observe(d.Seconds())
+					}
+					ctrl.Feedback(d, err)
+				}
+
+				select {
+				case done <- struct{}{}:
+				case <-ctx.Done():
+				}
+			}()
+		}
+
+		select {
+		case <-done:
+			inflight--
+		case <-ctx.Done():
+			converged := ctrl.Converged(o.ConvergeWindows)
+			c.metrics.ConvergedConcurrency.WithLabelValues(name).Set(converged)
+			c.logger.Infof("node %s: %d windows evaluated", name, ctrl.Windows())
+			return converged, nil
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package autotune
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Concurrency          *prometheus.GaugeVec
+	ConvergedConcurrency *prometheus.GaugeVec
+	UploadErrors         *prometheus.CounterVec
+	UploadDuration       *prometheus.HistogramVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_autotune"
+	return metrics{
+		Concurrency: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "upload_concurrency",
+				Help:      "Current number of concurrent upload streams.",
+			},
+			[]string{"node"},
+		),
+		ConvergedConcurrency: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "converged_upload_concurrency",
+				Help:      "Converged optimal number of concurrent upload streams.",
+			},
+			[]string{"node"},
+		),
+		UploadErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "upload_errors_count",
+				Help:      "Number of failed uploads.",
+			},
+			[]string{"node"},
+		),
+		UploadDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "upload_duration_seconds",
+				Help:      "Upload duration through the /bytes endpoint.",
+			},
+			[]string{"node"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
@@ -0,0 +1,241 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	ContentSize      int64
+	GasPrice         string
+	MaxCachedRatio   float64 // allowed ratio of the second download duration to the first one
+	PostageAmount    int64
+	PostageDepth     uint64
+	PostageLabel     string
+	RetrievalRetries int
+	RetryDelay       time.Duration
+	Rounds           int
+	Seed             int64
+	SyncWait         time.Duration
+	UploadNode       string
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		ContentSize:      4096, // single chunk
+		GasPrice:         "",
+		MaxCachedRatio:   0.5,
+		PostageAmount:    1000,
+		PostageDepth:     20,
+		PostageLabel:     "test-label",
+		RetrievalRetries: 5,
+		RetryDelay:       5 * time.Second,
+		Rounds:           3,
+		Seed:             random.Int64(),
+		SyncWait:         10 * time.Second,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run uploads content and downloads it twice from a node that does not store
+// it. Nodes cache retrieved chunks, so the content must be stored on the
+// downloader after the first download and the second download must be
+// significantly faster.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+	if o.Rounds <= 0 {
+		return fmt.Errorf("rounds must be positive, got %d", o.Rounds)
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	overlays, err := cluster.FlattenOverlays(ctx)
+	if err != nil {
+		return err
+	}
+
+	fullNodes := cluster.FullNodeNames()
+	if len(fullNodes) < 2 {
+		return errors.New("cache check requires at least two full nodes")
+	}
+	sort.Strings(fullNodes)
+
+	uploader := o.UploadNode
+	if uploader == "" {
+		uploader = fullNodes[rnd.Intn(len(fullNodes))]
+	}
+	client, ok := clients[uploader]
+	if !ok {
+		return fmt.Errorf("node %s not found", uploader)
+	}
+
+	batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: batch id %w", uploader, err)
+	}
+	c.logger.Infof("node %s: batch id %s", uploader, batchID)
+
+	var (
+		first, second time.Duration
+		violations    []string
+	)
+	for i := 0; i < o.Rounds; i++ {
+		data := make([]byte, o.ContentSize)
+		if _, err := rnd.Read(data); err != nil {
+			return fmt.Errorf("random data: %w", err)
+		}
+
+		ref, err := client.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID})
+		if err != nil {
+			return fmt.Errorf("node %s: upload: %w", uploader, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(o.SyncWait):
+		}
+
+		downloader, err := nonStorer(ctx, clients, overlays, fullNodes, uploader, ref)
+		if err != nil {
+			return err
+		}
+
+		d1, err := c.download(ctx, clients[downloader], ref, data, o)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", downloader, err)
+		}
+
+		has, err := clients[downloader].HasChunk(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("node %s: has chunk %s: %w", downloader, ref, err)
+		}
+		if !has {
+			violations = append(violations, fmt.Sprintf("node %s: content %s is not local after a download", downloader, ref))
+		}
+
+		d2, err := c.download(ctx, clients[downloader], ref, data, o)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", downloader, err)
+		}
+
+		first += d1
+		second += d2
+		c.logger.Infof("round %d: node %s: first download %s, second download %s", i+1, downloader, d1, d2)
+	}
+
+	d1, d2 := first/time.Duration(o.Rounds), second/time.Duration(o.Rounds)
+	c.metrics.DownloadDuration.WithLabelValues("first").Set(d1.Seconds())
+	c.metrics.DownloadDuration.WithLabelValues("second").Set(d2.Seconds())
+	c.metrics.DownloadDelta.Set((d1 - d2).Seconds())
+	c.logger.Infof("mean first download %s, mean second download %s", d1, d2)
+
+	if limit := time.Duration(float64(first) * o.MaxCachedRatio); second > limit {
+		violations = append(violations, fmt.Sprintf("second downloads took %s in total, more than %.2f of the first downloads %s", second, o.MaxCachedRatio, first))
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("%d cache violations, first: %s", len(violations), violations[0])
+	}
+
+	c.logger.Info("cached retrievals are local and faster")
+
+	return nil
+}
+
+// download downloads the content, retrying until it is retrieved, and returns
+// the duration of the successful download
+func (c *Check) download(ctx context.Context, client *bee.Client, ref swarm.Address, data []byte, o Options) (d time.Duration, err error) {
+	var got []byte
+	for i := 0; i < o.RetrievalRetries; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(o.RetryDelay):
+			}
+		}
+
+		start := time.Now()
+		got, err = client.DownloadBytes(ctx, ref)
+		d = time.Since(start)
+		if err == nil {
+			break
+		}
+		c.logger.Infof("download %s: %v", ref, err)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if !bytes.Equal(got, data) {
+		return 0, fmt.Errorf("download %s: downloaded content differs", ref)
+	}
+
+	return d, nil
+}
+
+// nonStorer returns the full node, other than the uploader, with the lowest
+// proximity to the reference that does not store it
+func nonStorer(ctx context.Context, clients map[string]*bee.Client, overlays map[string]swarm.Address, fullNodes []string, uploader string, ref swarm.Address) (string, error) {
+	candidates := make([]string, 0, len(fullNodes))
+	for _, name := range fullNodes {
+		if name != uploader {
+			candidates = append(candidates, name)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return swarm.Proximity(overlays[candidates[i]].Bytes(), ref.Bytes()) < swarm.Proximity(overlays[candidates[j]].Bytes(), ref.Bytes())
+	})
+
+	for _, name := range candidates {
+		has, err := clients[name].HasChunk(ctx, ref)
+		if err != nil {
+			return "", fmt.Errorf("node %s: has chunk %s: %w", name, ref, err)
+		}
+		if !has {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("every node stores content %s", ref)
+}
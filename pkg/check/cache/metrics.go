@@ -0,0 +1,38 @@
+package cache
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	DownloadDuration *prometheus.GaugeVec
+	DownloadDelta    prometheus.Gauge
+}
+
+func newMetrics() metrics {
+	subsystem := "check_cache"
+	return metrics{
+		DownloadDuration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "download_duration_seconds",
+				Help:      "Mean duration of the first and the second download.",
+			},
+			[]string{"download"},
+		),
+		DownloadDelta: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "download_delta_seconds",
+				Help:      "Mean difference between the first and the second download duration.",
+			},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
@@ -0,0 +1,251 @@
+package chequebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	FileSize      int64
+	GasPrice      string
+	MaxUploads    int
+	PostageAmount int64
+	PostageDepth  uint64
+	PostageLabel  string
+	Retries       int
+	RetryDelay    time.Duration
+	Seed          int64
+	UploadNode    string
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		FileSize:      1 * 1024 * 1024, // 1mb
+		GasPrice:      "",
+		MaxUploads:    50,
+		PostageAmount: 1000,
+		PostageDepth:  20,
+		PostageLabel:  "test-label",
+		Retries:       30,
+		RetryDelay:    5 * time.Second,
+		Seed:          random.Int64(),
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	logger logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		logger: logger,
+	}
+}
+
+// Run drives upload traffic from a node until it issues a cheque, cashes the
+// cheque out on the receiving node, waits for the cashout transaction to be
+// confirmed and verifies that chequebook balances of both nodes moved by the
+// cashed out amount.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	overlays, err := cluster.FlattenOverlays(ctx)
+	if err != nil {
+		return err
+	}
+
+	uploaderName := o.UploadNode
+	if uploaderName == "" {
+		fullNodes := cluster.FullNodeNames()
+		if len(fullNodes) < 2 {
+			return errors.New("chequebook check requires at least 2 full nodes")
+		}
+		uploaderName = fullNodes[rnd.Intn(len(fullNodes))]
+	}
+	uploader, ok := clients[uploaderName]
+	if !ok {
+		return fmt.Errorf("upload node %s not found", uploaderName)
+	}
+	uploaderOverlay := overlays[uploaderName]
+	c.logger.Infof("uploader: %s (%s)", uploaderName, uploaderOverlay)
+
+	sentBefore, err := sentCheques(ctx, uploader)
+	if err != nil {
+		return err
+	}
+
+	batchID, err := uploader.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: batch id %w", uploaderName, err)
+	}
+	c.logger.Infof("node %s: batch id %s", uploaderName, batchID)
+
+	// upload until the uploader sends a new cheque to one of its peers
+	var beneficiary swarm.Address
+	for i := 0; i < o.MaxUploads && beneficiary.IsZero(); i++ {
+		data := make([]byte, o.FileSize)
+		if _, err := rnd.Read(data); err != nil {
+			return fmt.Errorf("random data: %w", err)
+		}
+
+		addr, err := uploader.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID})
+		if err != nil {
+			return fmt.Errorf("node %s: %w", uploaderName, err)
+		}
+		c.logger.Infof("upload %d: uploaded %s to node %s", i, addr, uploaderName)
+
+		sent, err := sentCheques(ctx, uploader)
+		if err != nil {
+			return err
+		}
+
+		for peer, payout := range sent {
+			if before, ok := sentBefore[peer]; !ok || payout.Cmp(before) > 0 {
+				beneficiary, _ = swarm.ParseHexAddress(peer)
+				break
+			}
+		}
+	}
+	if beneficiary.IsZero() {
+		return fmt.Errorf("node %s did not send any cheque after %d uploads", uploaderName, o.MaxUploads)
+	}
+
+	receiverName, ok := nameByOverlay(overlays, beneficiary)
+	if !ok {
+		return fmt.Errorf("cheque beneficiary %s is not a cluster node", beneficiary)
+	}
+	receiver := clients[receiverName]
+	c.logger.Infof("node %s sent a cheque to node %s (%s)", uploaderName, receiverName, beneficiary)
+
+	status, err := receiver.CashoutStatus(ctx, uploaderOverlay)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", receiverName, err)
+	}
+	if status.UncashedAmount == nil || status.UncashedAmount.Sign() <= 0 {
+		return fmt.Errorf("node %s has no uncashed amount from node %s", receiverName, uploaderName)
+	}
+	c.logger.Infof("node %s: uncashed amount from node %s: %s", receiverName, uploaderName, status.UncashedAmount)
+
+	issuerBefore, err := uploader.ChequebookBalance(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", uploaderName, err)
+	}
+	receiverBefore, err := receiver.ChequebookBalance(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", receiverName, err)
+	}
+
+	txHash, err := receiver.CashoutWithGasPrice(ctx, uploaderOverlay, o.GasPrice)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", receiverName, err)
+	}
+	c.logger.Infof("node %s: cashing out cheque from node %s in transaction %s", receiverName, uploaderName, txHash)
+
+	var result *bee.CashoutStatusResult
+	for i := 0; i < o.Retries; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(o.RetryDelay):
+		}
+
+		status, err := receiver.CashoutStatus(ctx, uploaderOverlay)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", receiverName, err)
+		}
+		if status.TransactionHash == nil || *status.TransactionHash != txHash || status.Result == nil {
+			c.logger.Infof("transaction %s not yet confirmed", txHash)
+			continue
+		}
+
+		result = status.Result
+		break
+	}
+	if result == nil {
+		return fmt.Errorf("cashout transaction %s not confirmed", txHash)
+	}
+	if result.Bounced {
+		return fmt.Errorf("cheque from node %s bounced on node %s", uploaderName, receiverName)
+	}
+	c.logger.Infof("transaction %s confirmed, payout %s", txHash, result.LastPayout)
+
+	issuerAfter, err := uploader.ChequebookBalance(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", uploaderName, err)
+	}
+	receiverAfter, err := receiver.ChequebookBalance(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", receiverName, err)
+	}
+
+	// other cashouts may happen at the same time, so balances must move at
+	// least by the payout
+	issuerDiff := new(big.Int).Sub(issuerBefore.TotalBalance, issuerAfter.TotalBalance)
+	if issuerDiff.Cmp(result.LastPayout) < 0 {
+		return fmt.Errorf("node %s: chequebook balance decreased by %s, expected at least %s", uploaderName, issuerDiff, result.LastPayout)
+	}
+
+	receiverDiff := new(big.Int).Sub(receiverAfter.TotalBalance, receiverBefore.TotalBalance)
+	if receiverDiff.Cmp(result.LastPayout) < 0 {
+		return fmt.Errorf("node %s: chequebook balance increased by %s, expected at least %s", receiverName, receiverDiff, result.LastPayout)
+	}
+
+	c.logger.Infof("chequebook balances moved by %s", result.LastPayout)
+
+	return nil
+}
+
+// sentCheques returns payouts of the last cheques sent by the node keyed by peer
+func sentCheques(ctx context.Context, client *bee.Client) (map[string]*big.Int, error) {
+	cheques, err := client.LastCheques(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sent := make(map[string]*big.Int)
+	for _, c := range cheques {
+		if c.LastSent != nil && c.LastSent.Payout != nil {
+			sent[c.Peer.String()] = c.LastSent.Payout
+		}
+	}
+
+	return sent, nil
+}
+
+func nameByOverlay(overlays map[string]swarm.Address, a swarm.Address) (string, bool) {
+	for name, o := range overlays {
+		if o.Equal(a) {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -108,20 +108,16 @@ func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts int
 				return fmt.Errorf("could not get chunk even after several attempts")
 			}
 
-			// check if the node is there in the local store of node B
-			// this does a get chunk instead of Has chunk, so the following
-			// call just checks if the chunk is accessible from nodeB
-			present, err := nodeB.HasChunk(ctx, ref)
-			if err != nil {
-				// give time for the chunk to reach its destination
-				time.Sleep(100 * time.Millisecond)
-				count++
-				continue
-			}
-
-			if present {
+			// check if the chunk is in the local store of node B, probing
+			// does not download it, so node B does not cache it
+			present, err := nodeB.ProbeChunk(ctx, ref)
+			if err == nil && present {
 				break
 			}
+
+			// give time for the chunk to reach its destination
+			time.Sleep(100 * time.Millisecond)
+			count++
 		}
 
 		// download the chunk from nodeC
@@ -0,0 +1,187 @@
+package cid
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	FileCount        int
+	FileName         string
+	FileSize         int64
+	GasPrice         string
+	PostageAmount    int64
+	PostageDepth     uint64
+	PostageLabel     string
+	RetrievalRetries int
+	RetryDelay       time.Duration
+	Seed             int64
+	UploadNode       string
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		FileCount:        3,
+		FileName:         "cid",
+		FileSize:         1024 * 100, // 100kb
+		GasPrice:         "",
+		PostageAmount:    1000,
+		PostageDepth:     20,
+		PostageLabel:     "test-label",
+		RetrievalRetries: 5,
+		RetryDelay:       5 * time.Second,
+		Seed:             random.Int64(),
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run uploads files, converts their references to Swarm CIDs and back, and
+// retrieves the files by CID from a node of every node group, so a change of
+// the CID encoding in any bee release is detected.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	uploader := o.UploadNode
+	if uploader == "" {
+		fullNodes := cluster.FullNodeNames()
+		if len(fullNodes) == 0 {
+			return errors.New("cid check requires at least one full node")
+		}
+		sort.Strings(fullNodes)
+		uploader = fullNodes[rnd.Intn(len(fullNodes))]
+	}
+	client, ok := clients[uploader]
+	if !ok {
+		return fmt.Errorf("node %s not found", uploader)
+	}
+
+	batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: batch id %w", uploader, err)
+	}
+	c.logger.Infof("node %s: batch id %s", uploader, batchID)
+
+	// a node of every group retrieves the files, as groups may run
+	// different bee versions
+	groups := cluster.NodeGroups()
+	groupNames := make([]string, 0, len(groups))
+	for name := range groups {
+		groupNames = append(groupNames, name)
+	}
+	sort.Strings(groupNames)
+
+	for i := 0; i < o.FileCount; i++ {
+		file := bee.NewRandomFile(rnd, fmt.Sprintf("%s-%d", o.FileName, i), o.FileSize)
+		if err := client.UploadFile(ctx, &file, api.UploadOptions{BatchID: batchID}); err != nil {
+			return fmt.Errorf("node %s: upload file %s: %w", uploader, file.Name(), err)
+		}
+
+		cid, err := encodeCID(file.Address(), manifestCodec)
+		if err != nil {
+			return fmt.Errorf("encode cid of reference %s: %w", file.Address(), err)
+		}
+		ref, codec, err := decodeCID(cid)
+		if err != nil {
+			return fmt.Errorf("decode cid %s of reference %s: %w", cid, file.Address(), err)
+		}
+		if !ref.Equal(file.Address()) || codec != manifestCodec {
+			return fmt.Errorf("cid %s of reference %s decoded to reference %s with codec %#x", cid, file.Address(), ref, codec)
+		}
+		c.logger.Infof("file %s: reference %s, cid %s, bzz.link https://%s.bzz.link", file.Name(), file.Address(), cid, cid)
+
+		for _, gName := range groupNames {
+			nodes := groups[gName].NodesSorted()
+			if len(nodes) == 0 {
+				continue
+			}
+			name := nodes[0]
+			nodeClient, ok := clients[name]
+			if !ok {
+				continue
+			}
+
+			if err := c.retrieve(ctx, nodeClient, cid, file, o); err != nil {
+				c.metrics.Retrievals.WithLabelValues(gName, "failed").Inc()
+				return fmt.Errorf("node group %s: node %s: %w", gName, name, err)
+			}
+			c.metrics.Retrievals.WithLabelValues(gName, "success").Inc()
+			c.logger.Infof("node group %s: node %s: retrieved file %s by cid", gName, name, file.Name())
+		}
+	}
+
+	c.logger.Info("all files were retrieved by their swarm cids")
+
+	return nil
+}
+
+// retrieve downloads the file by its CID and compares it to the uploaded file
+func (c *Check) retrieve(ctx context.Context, client *bee.Client, cid string, file bee.File, o Options) (err error) {
+	var (
+		size int64
+		hash []byte
+	)
+	for i := 0; i < o.RetrievalRetries; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(o.RetryDelay):
+			}
+		}
+
+		size, hash, err = client.DownloadFileCID(ctx, cid)
+		if err == nil {
+			break
+		}
+		c.logger.Infof("download cid %s: %v", cid, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if size != file.Size() || !bytes.Equal(hash, file.Hash()) {
+		return fmt.Errorf("file %s downloaded by cid %s differs", file.Name(), cid)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package cid
+
+import (
+	"fmt"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+)
+
+// multicodec codes of Swarm CIDs
+const (
+	manifestCodec = 0xfa // swarm-manifest
+	feedCodec     = 0xfb // swarm-feed
+)
+
+// encodeCID returns the base32 CIDv1 of the reference with the codec
+func encodeCID(ref swarm.Address, codec uint64) (string, error) {
+	mh, err := multihash.Encode(ref.Bytes(), multihash.KECCAK_256)
+	if err != nil {
+		return "", fmt.Errorf("encode multihash: %w", err)
+	}
+
+	return cid.NewCidV1(codec, mh).String(), nil
+}
+
+// decodeCID returns the reference and the codec of the CIDv1
+func decodeCID(s string) (ref swarm.Address, codec uint64, err error) {
+	id, err := cid.Parse(s)
+	if err != nil {
+		return swarm.ZeroAddress, 0, fmt.Errorf("parse cid: %w", err)
+	}
+
+	if v := id.Version(); v != 1 {
+		return swarm.ZeroAddress, 0, fmt.Errorf("unsupported cid version %d", v)
+	}
+	codec = id.Type()
+	if codec != manifestCodec && codec != feedCodec {
+		return swarm.ZeroAddress, 0, fmt.Errorf("unsupported codec %#x", codec)
+	}
+
+	mh, err := multihash.Decode(id.Hash())
+	if err != nil {
+		return swarm.ZeroAddress, 0, fmt.Errorf("decode multihash: %w", err)
+	}
+	if mh.Code != multihash.KECCAK_256 {
+		return swarm.ZeroAddress, 0, fmt.Errorf("unsupported multihash %#x", mh.Code)
+	}
+	if len(mh.Digest) != swarm.HashSize {
+		return swarm.ZeroAddress, 0, fmt.Errorf("invalid digest size %d", len(mh.Digest))
+	}
+
+	return swarm.NewAddress(mh.Digest), codec, nil
+}
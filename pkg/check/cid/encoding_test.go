@@ -0,0 +1,71 @@
+package cid
+
+import (
+	"testing"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+// CIDs resolved by bee's cidv1 resolver
+const (
+	goldenRef         = "ca6357a08e317d15ec560fef34e4c45f8f19f01c372aa70f1da72bfa7f1a4338"
+	goldenManifestCID = "bah5acgzazjrvpieogf6rl3cwb7xtjzgel6hrt4a4g4vkody5u4v7u7y2im4a"
+	goldenFeedCID     = "bah5qcgzazjrvpieogf6rl3cwb7xtjzgel6hrt4a4g4vkody5u4v7u7y2im4a"
+)
+
+func TestEncodeCID(t *testing.T) {
+	ref := swarm.MustParseHexAddress(goldenRef)
+
+	for _, tc := range []struct {
+		name  string
+		codec uint64
+		want  string
+	}{
+		{name: "manifest", codec: manifestCodec, want: goldenManifestCID},
+		{name: "feed", codec: feedCodec, want: goldenFeedCID},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := encodeCID(ref, tc.codec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got cid %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCID(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		cid       string
+		wantCodec uint64
+		wantErr   bool
+	}{
+		{name: "manifest", cid: goldenManifestCID, wantCodec: manifestCodec},
+		{name: "feed", cid: goldenFeedCID, wantCodec: feedCodec},
+		{name: "other codec", cid: "bafybeiekkklkqtypmqav6ytqjbdqucxfwuk5cgige4245d2qhkccuyfnly", wantErr: true},
+		{name: "truncated", cid: "bafybeiekk", wantErr: true},
+		{name: "not a cid", cid: goldenRef, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, codec, err := decodeCID(tc.cid)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("got reference %s, want error", ref)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ref.String() != goldenRef {
+				t.Errorf("got reference %s, want %s", ref, goldenRef)
+			}
+			if codec != tc.wantCodec {
+				t.Errorf("got codec %#x, want %#x", codec, tc.wantCodec)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package cid
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Retrievals *prometheus.CounterVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_cid"
+	return metrics{
+		Retrievals: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "retrievals_count",
+				Help:      "Number of retrievals by Swarm CID per node group by result.",
+			},
+			[]string{"node_group", "result"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
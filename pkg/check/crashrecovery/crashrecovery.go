@@ -0,0 +1,291 @@
+package crashrecovery
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/chaos"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	ContentCount      int // number of uploads before the node is killed
+	ContentSize       int64
+	GasPrice          string
+	KillDelay         time.Duration // time the node uploads before it is killed
+	MinReserveRatio   float64       // allowed ratio of the reserve size after the recovery to the size before the kill
+	NodeName          string
+	PostageAmount     int64
+	PostageDepth      uint64
+	PostageLabel      string
+	ReadyTimeout      time.Duration // time the killed node has to become ready
+	ReserveSizeMetric string
+	RetrievalRetries  int
+	RetryDelay        time.Duration
+	Seed              int64
+	UploadInterval    time.Duration
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		ContentCount:      10,
+		ContentSize:       1024 * 100, // 100kb
+		GasPrice:          "",
+		KillDelay:         10 * time.Second,
+		MinReserveRatio:   0.9,
+		PostageAmount:     1000,
+		PostageDepth:      20,
+		PostageLabel:      "test-label",
+		ReadyTimeout:      5 * time.Minute,
+		ReserveSizeMetric: "bee_localstore_reserve_size",
+		RetrievalRetries:  5,
+		RetryDelay:        5 * time.Second,
+		Seed:              random.Int64(),
+		UploadInterval:    500 * time.Millisecond,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// upload is content acknowledged by the node
+type upload struct {
+	ref swarm.Address
+	sum [sha256.Size]byte
+}
+
+// Run kills a node while it is uploading pinned content and verifies that
+// the node recovers: integrity of pinned content holds, the reserve size is
+// sane and all acknowledged content is retrievable from the node.
+// Bee 1.13 has no endpoint validating its localstore, and its db commands
+// need the store of a stopped node, so integrity of the localstore is
+// verified by the pins of acknowledged uploads and by their retrieval, every
+// retrieved chunk is verified against its address and the content against
+// the uploaded data.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	name := o.NodeName
+	if name == "" {
+		fullNodes := cluster.FullNodeNames()
+		if len(fullNodes) == 0 {
+			return errors.New("crash recovery check requires at least one full node")
+		}
+		sort.Strings(fullNodes)
+		name = fullNodes[rnd.Intn(len(fullNodes))]
+	}
+
+	var g orchestration.NodeGroup
+	for _, ng := range cluster.NodeGroups() {
+		if _, ok := ng.Nodes()[name]; ok {
+			g = ng
+			break
+		}
+	}
+	if g == nil {
+		return fmt.Errorf("node %s not found", name)
+	}
+	client, err := g.NodeClient(name)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", name, err)
+	}
+
+	batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: batch id %w", name, err)
+	}
+	c.logger.Infof("node %s: batch id %s", name, batchID)
+
+	var acked []upload
+	for i := 0; i < o.ContentCount; i++ {
+		u, err := c.upload(ctx, client, rnd, batchID, o)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", name, err)
+		}
+		acked = append(acked, u)
+	}
+	c.logger.Infof("node %s: uploaded %d pinned contents", name, len(acked))
+
+	reserveBefore, err := client.Metric(ctx, o.ReserveSizeMetric)
+	if err != nil {
+		return fmt.Errorf("node %s: reserve size: %w", name, err)
+	}
+
+	// acknowledged uploads are appended only by the uploader until it stops
+	var (
+		wg   sync.WaitGroup
+		stop = make(chan struct{})
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(o.UploadInterval):
+			}
+
+			// uploads fail while the node is down, only acknowledged ones
+			// have to survive
+			u, err := c.upload(ctx, client, rnd, batchID, o)
+			if err != nil {
+				continue
+			}
+			acked = append(acked, u)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		close(stop)
+		wg.Wait()
+		return ctx.Err()
+	case <-time.After(o.KillDelay):
+	}
+
+	c.logger.Infof("node %s: killing mid-upload", name)
+	start := time.Now()
+	err = g.KillNode(ctx, name)
+	close(stop)
+	wg.Wait()
+	if err != nil {
+		return fmt.Errorf("kill node %s: %w", name, err)
+	}
+
+	// the restart is observed by the node going down and becoming ready
+	// again, not by readiness alone, which holds before the pod is killed
+	if err := chaos.WaitRecovery(ctx, g, name, o.ReadyTimeout); err != nil {
+		return err
+	}
+	c.metrics.RecoveryDuration.Set(time.Since(start).Seconds())
+	c.logger.Infof("node %s: recovered in %s, %d acknowledged uploads", name, time.Since(start).Round(time.Second), len(acked))
+
+	var violations []string
+
+	v, err := c.checkPins(ctx, client, acked)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", name, err)
+	}
+	violations = append(violations, v...)
+
+	reserveAfter, err := client.Metric(ctx, o.ReserveSizeMetric)
+	if err != nil {
+		return fmt.Errorf("node %s: reserve size: %w", name, err)
+	}
+	c.logger.Infof("node %s: reserve size %.0f before the kill, %.0f after the recovery", name, reserveBefore, reserveAfter)
+	if reserveAfter < 0 || reserveAfter < reserveBefore*o.MinReserveRatio {
+		violations = append(violations, fmt.Sprintf("reserve size dropped from %.0f to %.0f", reserveBefore, reserveAfter))
+	}
+
+	for _, u := range acked {
+		if err := c.retrieve(ctx, client, u, o); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+
+	c.metrics.Violations.Add(float64(len(violations)))
+	for _, v := range violations {
+		c.logger.Infof("node %s: violation: %s", name, v)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("node %s: %d violations after unclean shutdown, first: %s", name, len(violations), violations[0])
+	}
+
+	c.logger.Infof("node %s: localstore is consistent after unclean shutdown", name)
+
+	return nil
+}
+
+// upload uploads random pinned content and returns it once acknowledged
+func (c *Check) upload(ctx context.Context, client *bee.Client, rnd *rand.Rand, batchID string, o Options) (upload, error) {
+	data := make([]byte, o.ContentSize)
+	if _, err := rnd.Read(data); err != nil {
+		return upload{}, fmt.Errorf("random data: %w", err)
+	}
+
+	ref, err := client.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID, Pin: true})
+	if err != nil {
+		c.metrics.Uploads.WithLabelValues("failed").Inc()
+		return upload{}, fmt.Errorf("upload: %w", err)
+	}
+	c.metrics.Uploads.WithLabelValues("acknowledged").Inc()
+
+	return upload{ref: ref, sum: sha256.Sum256(data)}, nil
+}
+
+// checkPins verifies that all acknowledged uploads are still pinned, their
+// chunks are verified when they are retrieved
+func (c *Check) checkPins(ctx context.Context, client *bee.Client, acked []upload) (violations []string, err error) {
+	for _, u := range acked {
+		pinned, err := client.GetPinnedRootHash(ctx, u.ref)
+		if err != nil {
+			return nil, err
+		}
+		if !pinned.Equal(u.ref) {
+			violations = append(violations, fmt.Sprintf("acknowledged upload %s is not pinned", u.ref))
+		}
+	}
+
+	return violations, nil
+}
+
+// retrieve downloads acknowledged content and compares it to the uploaded data
+func (c *Check) retrieve(ctx context.Context, client *bee.Client, u upload, o Options) (err error) {
+	var data []byte
+	for i := 0; i < o.RetrievalRetries; i++ {
+		data, err = client.DownloadBytes(ctx, u.ref)
+		if err == nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(o.RetryDelay):
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("acknowledged upload %s is not retrievable: %w", u.ref, err)
+	}
+
+	if sha256.Sum256(data) != u.sum {
+		return fmt.Errorf("acknowledged upload %s: downloaded content differs", u.ref)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package crashrecovery
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Uploads          *prometheus.CounterVec
+	RecoveryDuration prometheus.Gauge
+	Violations       prometheus.Counter
+}
+
+func newMetrics() metrics {
+	subsystem := "check_crashrecovery"
+	return metrics{
+		Uploads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "uploads_count",
+				Help:      "Number of uploads to the killed node by result.",
+			},
+			[]string{"result"},
+		),
+		RecoveryDuration: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "recovery_seconds",
+				Help:      "Time from killing the node until it is ready again.",
+			},
+		),
+		Violations: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "violations_count",
+				Help:      "Number of consistency violations found after the recovery.",
+			},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
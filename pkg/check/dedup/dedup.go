@@ -0,0 +1,228 @@
+package dedup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	ContentSize       int64
+	GasPrice          string
+	MaxStorageGrowth  float64 // allowed ratio of reserve growth after all uploads to the growth after the first upload
+	PostageAmount     int64
+	PostageDepth      uint64
+	PostageLabel      string
+	ReserveSizeMetric string
+	Seed              int64
+	SyncWait          time.Duration
+	Uploaders         int
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		ContentSize:       5 * 1024 * 1024, // 5mb
+		GasPrice:          "",
+		MaxStorageGrowth:  1.2,
+		PostageAmount:     1000,
+		PostageDepth:      20,
+		PostageLabel:      "test-label",
+		ReserveSizeMetric: "bee_localstore_reserve_size",
+		Seed:              random.Int64(),
+		SyncWait:          30 * time.Second,
+		Uploaders:         3,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run uploads the same content from multiple nodes, each with its own batch.
+// References must be identical, the reserve of the cluster must not grow
+// significantly after the first upload and the content must be retrievable
+// from every uploader and from a node that did not upload it.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	_, err = c.RunResult(ctx, cluster, opts)
+	return err
+}
+
+// RunResult runs the check and returns uploads and retrievals as steps and
+// the growth of the reserve as measurements of its result
+func (c *Check) RunResult(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (r beekeeper.Result, err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return r, fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return r, err
+	}
+
+	fullNodes := cluster.FullNodeNames()
+	if len(fullNodes) < o.Uploaders+1 {
+		return r, fmt.Errorf("dedup check requires at least %d full nodes", o.Uploaders+1)
+	}
+	sort.Strings(fullNodes)
+	rnd.Shuffle(len(fullNodes), func(i, j int) { fullNodes[i], fullNodes[j] = fullNodes[j], fullNodes[i] })
+	// the node after the uploaders only downloads the content
+	uploaders := fullNodes[:o.Uploaders]
+
+	data := make([]byte, o.ContentSize)
+	if _, err := rnd.Read(data); err != nil {
+		return r, fmt.Errorf("random data: %w", err)
+	}
+
+	before, err := c.reserveSize(ctx, clients, fullNodes, o)
+	if err != nil {
+		return r, err
+	}
+
+	var (
+		ref      swarm.Address
+		afterOne int64
+		batchIDs = make(map[string]string)
+		refs     = make(map[string]swarm.Address)
+	)
+	for i, name := range uploaders {
+		client := clients[name]
+
+		if err := r.Step(fmt.Sprintf("node %s upload", name), func() error {
+			batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+			if err != nil {
+				return fmt.Errorf("node %s: batch id %w", name, err)
+			}
+			for other, id := range batchIDs {
+				if id == batchID {
+					return fmt.Errorf("nodes %s and %s use the same batch %s", name, other, batchID)
+				}
+			}
+			batchIDs[name] = batchID
+			c.logger.Infof("node %s: batch id %s", name, batchID)
+
+			uploaded, err := client.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID})
+			if err != nil {
+				return fmt.Errorf("node %s: upload: %w", name, err)
+			}
+			refs[name] = uploaded
+			c.logger.Infof("node %s: uploaded content with reference %s", name, uploaded)
+			return nil
+		}); err != nil {
+			return r, err
+		}
+
+		if i == 0 {
+			ref = refs[name]
+
+			select {
+			case <-ctx.Done():
+				return r, ctx.Err()
+			case <-time.After(o.SyncWait):
+			}
+			size, err := c.reserveSize(ctx, clients, fullNodes, o)
+			if err != nil {
+				return r, err
+			}
+			afterOne = size - before
+			c.metrics.ReserveGrowth.WithLabelValues("first").Set(float64(afterOne))
+			r.Measure("reserve growth after the first upload", float64(afterOne), "chunks")
+			c.logger.Infof("reserve grew by %d chunks after the first upload", afterOne)
+		}
+	}
+
+	for name, uploaded := range refs {
+		if !uploaded.Equal(ref) {
+			return r, fmt.Errorf("node %s: reference %s differs from %s", name, uploaded, ref)
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return r, ctx.Err()
+	case <-time.After(o.SyncWait):
+	}
+	size, err := c.reserveSize(ctx, clients, fullNodes, o)
+	if err != nil {
+		return r, err
+	}
+	afterAll := size - before
+	c.metrics.ReserveGrowth.WithLabelValues("all").Set(float64(afterAll))
+	r.Measure("reserve growth after all uploads", float64(afterAll), "chunks")
+	c.logger.Infof("reserve grew by %d chunks after %d uploads", afterAll, len(uploaders))
+
+	if afterOne <= 0 {
+		c.logger.Warningf("reserve did not grow after the first upload, skipping storage growth assertion")
+	} else if ratio := float64(afterAll) / float64(afterOne); ratio > o.MaxStorageGrowth {
+		return r, fmt.Errorf("reserve grew %.2f times more after %d uploads of the same content than after one, allowed %.2f", ratio, len(uploaders), o.MaxStorageGrowth)
+	}
+
+	for _, name := range fullNodes[:o.Uploaders+1] {
+		if err := r.Step(fmt.Sprintf("node %s retrieve", name), func() error {
+			if err := retrieve(ctx, clients[name], ref, data); err != nil {
+				return fmt.Errorf("node %s: %w", name, err)
+			}
+			return nil
+		}); err != nil {
+			return r, err
+		}
+		c.logger.Infof("node %s: content retrieved", name)
+	}
+
+	return r, nil
+}
+
+// reserveSize returns the sum of reserve sizes of the nodes
+func (c *Check) reserveSize(ctx context.Context, clients map[string]*bee.Client, nodes []string, o Options) (total int64, err error) {
+	for _, name := range nodes {
+		v, err := clients[name].Metric(ctx, o.ReserveSizeMetric)
+		if err != nil {
+			return 0, fmt.Errorf("node %s: reserve size: %w", name, err)
+		}
+		total += int64(v)
+	}
+
+	return total, nil
+}
+
+func retrieve(ctx context.Context, client *bee.Client, ref swarm.Address, data []byte) error {
+	got, err := client.DownloadBytes(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", ref, err)
+	}
+
+	if !bytes.Equal(got, data) {
+		return errors.New("downloaded content differs")
+	}
+
+	return nil
+}
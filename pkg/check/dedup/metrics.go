@@ -0,0 +1,29 @@
+package dedup
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	ReserveGrowth *prometheus.GaugeVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_dedup"
+	return metrics{
+		ReserveGrowth: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "reserve_growth_chunks",
+				Help:      "Growth of the cluster reserve size after the first and after all uploads of the same content.",
+			},
+			[]string{"uploads"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
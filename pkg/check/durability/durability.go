@@ -0,0 +1,288 @@
+package durability
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	AgeBuckets      []time.Duration // upper bounds of age buckets, older records fall into the last, open bucket
+	ContentSize     int64
+	CorpusSize      int // number of files uploaded on each run
+	GasPrice        string
+	MinSuccessRatio float64 // minimum ratio of retrieved samples in every age bucket
+	PostageAmount   int64
+	PostageDepth    uint64
+	PostageLabel    string
+	Retries         int
+	RetryDelay      time.Duration
+	SamplesPerAge   int // number of records re-retrieved from every age bucket
+	Seed            int64
+	StorePath       string
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		AgeBuckets:      []time.Duration{time.Hour, 24 * time.Hour, 3 * 24 * time.Hour, 7 * 24 * time.Hour, 14 * 24 * time.Hour, 30 * 24 * time.Hour},
+		ContentSize:     1024 * 1024, // 1mb
+		CorpusSize:      10,
+		GasPrice:        "",
+		MinSuccessRatio: 1,
+		PostageAmount:   100000000,
+		PostageDepth:    20,
+		PostageLabel:    "durability",
+		Retries:         3,
+		RetryDelay:      10 * time.Second,
+		SamplesPerAge:   10,
+		Seed:            random.Int64(),
+		StorePath:       "durability.json",
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run re-retrieves a sample of content uploaded on previous runs, grouped by
+// age, and uploads a new corpus for the following runs. The check is meant to
+// be run periodically against a long lived cluster.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	fullNodes := cluster.FullNodeNames()
+	if len(fullNodes) == 0 {
+		return errors.New("durability check requires at least one full node")
+	}
+	sort.Strings(fullNodes)
+
+	store := NewFileStore(o.StorePath)
+	records, err := store.Records()
+	if err != nil {
+		return fmt.Errorf("load records: %w", err)
+	}
+	c.logger.Infof("loaded %d records from %s", len(records), o.StorePath)
+
+	var failed []string
+
+	var clusterRecords []Record
+	for _, r := range records {
+		if r.Cluster == "" || r.Cluster == cluster.Name() {
+			clusterRecords = append(clusterRecords, r)
+		}
+	}
+
+	now := time.Now()
+	samples := sampleByAge(rnd, clusterRecords, now, o.AgeBuckets, o.SamplesPerAge)
+	for _, bucket := range sortedKeys(samples) {
+		sample := samples[bucket]
+		var ok int
+		for _, r := range sample {
+			name := fullNodes[rnd.Intn(len(fullNodes))]
+			if err := c.verify(ctx, clients[name].DownloadBytes, r, o); err != nil {
+				c.logger.Infof("node %s: record %s uploaded %s ago: %v", name, r.Reference, now.Sub(r.UploadedAt).Round(time.Minute), err)
+				c.metrics.Retrievals.WithLabelValues(bucket, "failed").Inc()
+				continue
+			}
+			ok++
+			c.metrics.Retrievals.WithLabelValues(bucket, "ok").Inc()
+		}
+
+		ratio := float64(ok) / float64(len(sample))
+		c.metrics.SuccessRatio.WithLabelValues(bucket).Set(ratio)
+		c.logger.Infof("age %s: retrieved %d of %d sampled records, success ratio %.2f", bucket, ok, len(sample), ratio)
+
+		if ratio < o.MinSuccessRatio {
+			failed = append(failed, fmt.Sprintf("%s (%.2f)", bucket, ratio))
+		}
+	}
+
+	if err := c.uploadCorpus(ctx, cluster, store, rnd, fullNodes, o); err != nil {
+		return fmt.Errorf("upload corpus: %w", err)
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("success ratio below %.2f for age buckets %v", o.MinSuccessRatio, failed)
+	}
+
+	return nil
+}
+
+// uploadCorpus uploads random content from a random full node and stores
+// records of the uploads
+func (c *Check) uploadCorpus(ctx context.Context, cluster orchestration.Cluster, store Store, rnd *rand.Rand, fullNodes []string, o Options) error {
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	name := fullNodes[rnd.Intn(len(fullNodes))]
+	client := clients[name]
+
+	batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: batch id %w", name, err)
+	}
+	c.logger.Infof("node %s: batch id %s", name, batchID)
+
+	records := make([]Record, 0, o.CorpusSize)
+	for i := 0; i < o.CorpusSize; i++ {
+		data := make([]byte, o.ContentSize)
+		if _, err := rnd.Read(data); err != nil {
+			return fmt.Errorf("random data: %w", err)
+		}
+
+		ref, err := client.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID})
+		if err != nil {
+			return fmt.Errorf("node %s: upload: %w", name, err)
+		}
+
+		sum := sha256.Sum256(data)
+		records = append(records, Record{
+			Reference:  ref.String(),
+			Size:       o.ContentSize,
+			SHA256:     hex.EncodeToString(sum[:]),
+			Uploader:   name,
+			BatchID:    batchID,
+			Cluster:    cluster.Name(),
+			UploadedAt: time.Now(),
+		})
+		c.metrics.Uploads.Inc()
+	}
+
+	if err := store.Add(records...); err != nil {
+		return fmt.Errorf("store records: %w", err)
+	}
+	c.logger.Infof("node %s: uploaded %d files, stored in %s", name, len(records), o.StorePath)
+
+	return nil
+}
+
+// verify downloads content of the record and compares its hash
+func (c *Check) verify(ctx context.Context, download func(context.Context, swarm.Address) ([]byte, error), r Record, o Options) (err error) {
+	ref, err := swarm.ParseHexAddress(r.Reference)
+	if err != nil {
+		return fmt.Errorf("parse reference: %w", err)
+	}
+
+	var data []byte
+	for i := 0; i <= o.Retries; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(o.RetryDelay):
+			}
+		}
+
+		data, err = download(ctx, ref)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("download: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != r.SHA256 {
+		return errors.New("content hash mismatch")
+	}
+
+	return nil
+}
+
+// sampleByAge groups records into age buckets and returns up to n randomly
+// chosen records from every non-empty bucket, keyed by bucket label
+func sampleByAge(rnd *rand.Rand, records []Record, now time.Time, buckets []time.Duration, n int) map[string][]Record {
+	bounds := append([]time.Duration(nil), buckets...)
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+
+	grouped := make(map[string][]Record)
+	for _, r := range records {
+		label := ageLabel(now.Sub(r.UploadedAt), bounds)
+		grouped[label] = append(grouped[label], r)
+	}
+
+	for _, label := range sortedKeys(grouped) {
+		rs := grouped[label]
+		rnd.Shuffle(len(rs), func(i, j int) { rs[i], rs[j] = rs[j], rs[i] })
+		if len(rs) > n {
+			grouped[label] = rs[:n]
+		}
+	}
+
+	return grouped
+}
+
+// ageLabel returns the label of the first bucket the age fits in
+func ageLabel(age time.Duration, bounds []time.Duration) string {
+	for _, b := range bounds {
+		if age <= b {
+			return "le_" + formatDuration(b)
+		}
+	}
+	if len(bounds) == 0 {
+		return "all"
+	}
+	return "gt_" + formatDuration(bounds[len(bounds)-1])
+}
+
+// formatDuration formats whole days as days and other durations as hours
+func formatDuration(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return d.String()
+}
+
+func sortedKeys(m map[string][]Record) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
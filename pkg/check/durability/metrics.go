@@ -0,0 +1,48 @@
+package durability
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Uploads      prometheus.Counter
+	Retrievals   *prometheus.CounterVec
+	SuccessRatio *prometheus.GaugeVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_durability"
+	return metrics{
+		Uploads: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "uploads_count",
+				Help:      "Number of files uploaded to the durability corpus.",
+			},
+		),
+		Retrievals: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "retrievals_count",
+				Help:      "Number of re-retrievals of sampled files by age bucket and result.",
+			},
+			[]string{"age", "result"},
+		),
+		SuccessRatio: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "success_ratio",
+				Help:      "Ratio of successfully re-retrieved sampled files by age bucket.",
+			},
+			[]string{"age"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
@@ -0,0 +1,84 @@
+package durability
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Record describes uploaded content that is re-retrieved on later runs
+type Record struct {
+	Reference  string    `json:"reference"`
+	Size       int64     `json:"size"`
+	SHA256     string    `json:"sha256"`
+	Uploader   string    `json:"uploader"`
+	BatchID    string    `json:"batchID"`
+	Cluster    string    `json:"cluster,omitempty"`
+	UploadedAt time.Time `json:"uploadedAt"`
+}
+
+// Store keeps records between check runs
+type Store interface {
+	Records() ([]Record, error)
+	Add(records ...Record) error
+}
+
+// fileStore keeps records in a JSON file. The file should be placed on
+// storage that outlives the machine running beekeeper.
+type fileStore struct {
+	path string
+}
+
+// NewFileStore returns a store backed by the JSON file at path
+func NewFileStore(path string) Store {
+	return &fileStore{path: path}
+}
+
+// Records returns all stored records, or none if the file does not exist yet
+func (s *fileStore) Records() (records []Record, err error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", s.path, err)
+	}
+
+	if err := json.Unmarshal(b, &records); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", s.path, err)
+	}
+
+	return records, nil
+}
+
+// Add appends records to the file. The file is replaced atomically so that an
+// interrupted run does not lose records of previous runs.
+func (s *fileStore) Add(records ...Record) error {
+	existing, err := s.Records()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(append(existing, records...), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode records: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", tmp, err)
+	}
+
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("rename %s: %w", tmp, err)
+	}
+
+	return nil
+}
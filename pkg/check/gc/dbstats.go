@@ -0,0 +1,41 @@
+package gc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// dbStats returns on-disk statistics of the data directory of the node, ok is
+// false if the orchestrator can not run commands in containers of nodes
+func (c *Check) dbStats(ctx context.Context, cluster orchestration.Cluster, name string) (stats orchestration.NodeDBStats, ok bool, err error) {
+	for _, g := range cluster.NodeGroups() {
+		if _, found := g.Nodes()[name]; !found {
+			continue
+		}
+
+		stats, err = orchestration.DBStats(ctx, g, name)
+		if errors.Is(err, orchestration.ErrNotSupported) {
+			return orchestration.NodeDBStats{}, false, nil
+		}
+		if err != nil {
+			return orchestration.NodeDBStats{}, false, err
+		}
+		c.logger.Infof("node %s: data directory size %d bytes, %d files, directories %v", name, stats.Size, stats.Files, stats.Dirs)
+		return stats, true, nil
+	}
+
+	return orchestration.NodeDBStats{}, false, fmt.Errorf("node %s not found", name)
+}
+
+// checkDBGrowth returns error if the data directory of the node grew by more
+// than the maximum growth, none for no limit
+func checkDBGrowth(before, after orchestration.NodeDBStats, maxGrowth int64) error {
+	growth := after.Size - before.Size
+	if maxGrowth > 0 && growth > maxGrowth {
+		return fmt.Errorf("data directory grew by %d bytes, from %d to %d, more than %d bytes", growth, before.Size, after.Size, maxGrowth)
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 type Options struct {
 	CacheSize    int // size of the node's localstore in chunks
 	GasPrice     string
+	MaxDBGrowth  int64 // bytes the data directory of the node may grow by during the check, 0 for no limit
 	PostageLabel string
 	ReserveSize  int
 	Seed         int64
@@ -29,6 +30,7 @@ func NewDefaultOptions() Options {
 	return Options{
 		CacheSize:    1000,
 		GasPrice:     "",
+		MaxDBGrowth:  0,
 		PostageLabel: "test-label",
 		ReserveSize:  1024,
 		Seed:         0,
@@ -186,6 +188,22 @@ func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts int
 		return fmt.Errorf("wrong initial storage radius, got %d want %d", origState.StorageRadius, 0)
 	}
 
+	// on-disk sizes of the localstore are compared after chunks are evicted,
+	// if their growth is limited
+	var (
+		dbBefore orchestration.NodeDBStats
+		dbStats  bool
+	)
+	if o.MaxDBGrowth > 0 {
+		dbBefore, dbStats, err = c.dbStats(ctx, cluster, node.Name())
+		if err != nil {
+			return fmt.Errorf("db stats: %w", err)
+		}
+		if !dbStats {
+			c.logger.Infof("db stats of node %s are not supported by the orchestrator, db growth is not checked", node.Name())
+		}
+	}
+
 	batchID, err := client.CreatePostageBatch(ctx, cheapBatchAmount, batchDepth, o.GasPrice, o.PostageLabel, true)
 	if err != nil {
 		return fmt.Errorf("create batch: %w", err)
@@ -276,6 +294,16 @@ func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts int
 		return fmt.Errorf("high value chunks were gc'd. Retrieved: %d, gc'd count: %d", hasCount, len(highValueChunks)-hasCount)
 	}
 
+	if dbStats {
+		dbAfter, _, err := c.dbStats(ctx, cluster, node.Name())
+		if err != nil {
+			return fmt.Errorf("db stats: %w", err)
+		}
+		if err := checkDBGrowth(dbBefore, dbAfter, o.MaxDBGrowth); err != nil {
+			return fmt.Errorf("node %s: %w", node.Name(), err)
+		}
+	}
+
 	// local pinning sanity checks
 
 	has, err := client.HasChunk(ctx, pinnedChunk.Address())
@@ -0,0 +1,360 @@
+package lightnode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	ChunksPerNode int   // number of chunks mined close to each light node
+	FileSize      int64 // size of the data uploaded and downloaded by light nodes
+	GasPrice      string
+	PostageAmount int64
+	PostageDepth  uint64
+	PostageLabel  string
+	Retries       int
+	RetryDelay    time.Duration
+	Seed          int64
+	SyncWait      time.Duration // time given to the cluster to push and pull sync chunks
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		ChunksPerNode: 5,
+		FileSize:      1 * 1024 * 1024, // 1mb
+		GasPrice:      "",
+		PostageAmount: 1000,
+		PostageDepth:  20,
+		PostageLabel:  "test-label",
+		Retries:       5,
+		RetryDelay:    5 * time.Second,
+		Seed:          random.Int64(),
+		SyncWait:      30 * time.Second,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	logger logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		logger: logger,
+	}
+}
+
+// Run runs the light node suite. It asserts that light nodes appear as light
+// nodes in the topology of their peers, that they can upload and download
+// content and that they never store chunks of others, neither through
+// pushsync nor through pullsync, even when the chunks are in their
+// neighborhood.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	lightNodes := cluster.LightNodeNames()
+	fullNodes := cluster.FullNodeNames()
+	if len(lightNodes) == 0 {
+		return errors.New("light node check requires at least 1 light node")
+	}
+	if len(fullNodes) == 0 {
+		return errors.New("light node check requires at least 1 full node")
+	}
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	overlays, err := cluster.FlattenOverlays(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkTopology(ctx, clients, overlays, lightNodes, fullNodes); err != nil {
+		return fmt.Errorf("topology: %w", err)
+	}
+	c.logger.Info("light nodes have correct mode in peers' topology")
+
+	if err := c.checkUploadDownload(ctx, rnd, clients, lightNodes, fullNodes, o); err != nil {
+		return fmt.Errorf("upload and download: %w", err)
+	}
+	c.logger.Info("light nodes can upload and download")
+
+	if err := c.checkNoStorage(ctx, rnd, clients, overlays, lightNodes, fullNodes, o); err != nil {
+		return fmt.Errorf("storage: %w", err)
+	}
+	c.logger.Info("light nodes do not store chunks of others")
+
+	return nil
+}
+
+// checkTopology verifies that full nodes list light nodes only among light
+// nodes and never in kademlia bins, and that full nodes are never listed as
+// light nodes
+func (c *Check) checkTopology(ctx context.Context, clients map[string]*bee.Client, overlays map[string]swarm.Address, lightNodes, fullNodes []string) error {
+	light := make(map[string]string, len(lightNodes))
+	for _, name := range lightNodes {
+		light[overlays[name].String()] = name
+	}
+	full := make(map[string]string, len(fullNodes))
+	for _, name := range fullNodes {
+		full[overlays[name].String()] = name
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range fullNodes {
+		t, err := clients[name].Topology(ctx)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", name, err)
+		}
+
+		for bin, b := range t.Bins {
+			for _, p := range append(b.ConnectedPeers, b.DisconnectedPeers...) {
+				if l, ok := light[p.Address.String()]; ok {
+					return fmt.Errorf("node %s: light node %s listed in %s", name, l, bin)
+				}
+			}
+		}
+
+		for _, p := range t.LightNodes.ConnectedPeers {
+			if f, ok := full[p.Address.String()]; ok {
+				return fmt.Errorf("node %s: full node %s listed as light node", name, f)
+			}
+			if l, ok := light[p.Address.String()]; ok {
+				seen[l] = true
+			}
+		}
+	}
+
+	for _, name := range lightNodes {
+		if !seen[name] {
+			return fmt.Errorf("light node %s not connected to any full node", name)
+		}
+	}
+
+	return nil
+}
+
+// checkUploadDownload uploads data from every light node and downloads it
+// from a full node, and downloads data uploaded by a full node from every
+// light node
+func (c *Check) checkUploadDownload(ctx context.Context, rnd *rand.Rand, clients map[string]*bee.Client, lightNodes, fullNodes []string, o Options) error {
+	fullName := fullNodes[0]
+	full := clients[fullName]
+
+	fullBatchID, err := full.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: batch id %w", fullName, err)
+	}
+
+	for _, name := range lightNodes {
+		light := clients[name]
+
+		batchID, err := light.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+		if err != nil {
+			return fmt.Errorf("node %s: batch id %w", name, err)
+		}
+		c.logger.Infof("node %s: batch id %s", name, batchID)
+
+		data, err := randomData(rnd, o.FileSize)
+		if err != nil {
+			return err
+		}
+		ref, err := light.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID})
+		if err != nil {
+			return fmt.Errorf("node %s: upload: %w", name, err)
+		}
+		c.logger.Infof("node %s: uploaded %s", name, ref)
+
+		if err := c.download(ctx, full, fullName, ref, data, o); err != nil {
+			return err
+		}
+
+		data, err = randomData(rnd, o.FileSize)
+		if err != nil {
+			return err
+		}
+		ref, err = full.UploadBytes(ctx, data, api.UploadOptions{BatchID: fullBatchID})
+		if err != nil {
+			return fmt.Errorf("node %s: upload: %w", fullName, err)
+		}
+		c.logger.Infof("node %s: uploaded %s", fullName, ref)
+
+		if err := c.download(ctx, light, name, ref, data, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkNoStorage uploads chunks mined in the neighborhood of each light node
+// from a full node and verifies that, once the cluster had time to sync, the
+// chunks are stored by full nodes but not by the light node
+func (c *Check) checkNoStorage(ctx context.Context, rnd *rand.Rand, clients map[string]*bee.Client, overlays map[string]swarm.Address, lightNodes, fullNodes []string, o Options) error {
+	uploaderName := fullNodes[0]
+	uploader := clients[uploaderName]
+
+	batchID, err := uploader.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: batch id %w", uploaderName, err)
+	}
+
+	fullOverlays := make(map[string]swarm.Address, len(fullNodes))
+	for _, name := range fullNodes {
+		fullOverlays[name] = overlays[name]
+	}
+
+	uploaded := make(map[string][]swarm.Chunk, len(lightNodes))
+	for _, name := range lightNodes {
+		// chunks sharing a long prefix with the light node would be stored by
+		// the light node if it took part in the reserve
+		chunks := bee.GenerateNRandomChunksAt(rnd, overlays[name], o.ChunksPerNode, 8)
+		for _, ch := range chunks {
+			if _, err := uploader.UploadChunk(ctx, ch.Data(), api.UploadOptions{BatchID: batchID}); err != nil {
+				return fmt.Errorf("node %s: upload chunk: %w", uploaderName, err)
+			}
+		}
+		uploaded[name] = chunks
+		c.logger.Infof("node %s: uploaded %d chunks close to light node %s", uploaderName, len(chunks), name)
+	}
+
+	c.logger.Infof("waiting %s for chunks to sync", o.SyncWait)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(o.SyncWait):
+	}
+
+	for name, chunks := range uploaded {
+		for _, ch := range chunks {
+			// the chunk must have reached its closest full node, so that it
+			// was available for syncing to the light node
+			closestName, err := closestNode(ch.Address(), fullOverlays)
+			if err != nil {
+				return err
+			}
+			if err := c.waitForChunk(ctx, clients[closestName], closestName, ch.Address(), o); err != nil {
+				return err
+			}
+
+			has, err := clients[name].HasChunk(ctx, ch.Address())
+			if err != nil {
+				return fmt.Errorf("node %s: %w", name, err)
+			}
+			if has {
+				return fmt.Errorf("light node %s stores chunk %s of another node", name, ch.Address())
+			}
+		}
+		c.logger.Infof("light node %s stores none of %d chunks in its neighborhood", name, len(chunks))
+	}
+
+	return nil
+}
+
+// download downloads data from a node, retrying while it is not yet available
+func (c *Check) download(ctx context.Context, client *bee.Client, name string, ref swarm.Address, want []byte, o Options) (err error) {
+	var data []byte
+	for i := 0; i < o.Retries; i++ {
+		data, err = client.DownloadBytes(ctx, ref)
+		if err == nil {
+			break
+		}
+		c.logger.Infof("node %s: download %s: %v", name, ref, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(o.RetryDelay):
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("node %s: download %s: %w", name, ref, err)
+	}
+
+	if !bytes.Equal(data, want) {
+		return fmt.Errorf("node %s: downloaded data of %s does not match uploaded data", name, ref)
+	}
+	c.logger.Infof("node %s: downloaded %s", name, ref)
+
+	return nil
+}
+
+// waitForChunk waits until the chunk is stored by the node
+func (c *Check) waitForChunk(ctx context.Context, client *bee.Client, name string, addr swarm.Address, o Options) error {
+	for i := 0; i < o.Retries; i++ {
+		has, err := client.HasChunk(ctx, addr)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", name, err)
+		}
+		if has {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(o.RetryDelay):
+		}
+	}
+
+	return fmt.Errorf("chunk %s not found on the closest full node %s", addr, name)
+}
+
+// closestNode returns the name of the node closest to the address
+func closestNode(addr swarm.Address, nodes map[string]swarm.Address) (closestName string, err error) {
+	var closest swarm.Address
+	for name, a := range nodes {
+		if closest.IsZero() {
+			closestName, closest = name, a
+			continue
+		}
+
+		dcmp, err := swarm.DistanceCmp(addr, a, closest)
+		if err != nil {
+			return "", fmt.Errorf("find closest node: %w", err)
+		}
+		if dcmp == 1 {
+			closestName, closest = name, a
+		}
+	}
+
+	if closest.IsZero() {
+		return "", errors.New("closest node not found")
+	}
+
+	return closestName, nil
+}
+
+func randomData(rnd *rand.Rand, size int64) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := rnd.Read(data); err != nil {
+		return nil, fmt.Errorf("random data: %w", err)
+	}
+	return data, nil
+}
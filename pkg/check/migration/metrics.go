@@ -0,0 +1,39 @@
+package migration
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	SwitchDuration *prometheus.GaugeVec
+	Violations     *prometheus.CounterVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_migration"
+	return metrics{
+		SwitchDuration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "switch_seconds",
+				Help:      "Time from setting the image of a node until it runs the new version.",
+			},
+			[]string{"node", "phase"},
+		),
+		Violations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "violations_count",
+				Help:      "Number of data and state violations found after a migration.",
+			},
+			[]string{"phase"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
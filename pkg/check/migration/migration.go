@@ -0,0 +1,340 @@
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	ContentCount  int // number of pinned uploads per node
+	ContentSize   int64
+	Downgrade     bool   // switch back to FromImage after the upgrade and verify again
+	FromImage     string // image the data is created with, current image if empty
+	GasPrice      string
+	NodeGroups    []string // node groups to migrate, all groups if empty
+	PostageAmount int64
+	PostageDepth  uint64
+	PostageLabel  string
+	ReadyTimeout  time.Duration // time a node has to run the new version
+	Seed          int64
+	ToImage       string
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		ContentCount:  5,
+		ContentSize:   1024 * 100, // 100kb
+		Downgrade:     false,
+		FromImage:     "",
+		GasPrice:      "",
+		PostageAmount: 1000,
+		PostageDepth:  20,
+		PostageLabel:  "test-label",
+		ReadyTimeout:  5 * time.Minute,
+		Seed:          random.Int64(),
+		ToImage:       "",
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// upload is pinned content acknowledged by the node
+type upload struct {
+	ref swarm.Address
+	sum [sha256.Size]byte
+}
+
+// phase switches all nodes to the image
+type phase struct {
+	name  string
+	image string
+}
+
+// state is the node state that has to survive the migration
+type state struct {
+	overlay swarm.Address
+	batches map[string]uint8 // batch id -> depth
+	stake   *big.Int         // nil if staking is not available
+	pins    map[string]bool
+	uploads []upload
+}
+
+// Run uploads and pins data on nodes running FromImage, switches them to
+// ToImage, and verifies that data, pins, stamps and stake survive the
+// localstore migration. With Downgrade set, nodes are switched back to
+// FromImage and verified again.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+	if o.ToImage == "" {
+		return errors.New("migration check requires the to-image option")
+	}
+	if o.Downgrade && o.FromImage == "" {
+		return errors.New("migration check requires the from-image option to downgrade")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	groups := cluster.NodeGroups()
+	groupNames := o.NodeGroups
+	if len(groupNames) == 0 {
+		for name := range groups {
+			groupNames = append(groupNames, name)
+		}
+	}
+	sort.Strings(groupNames)
+
+	type node struct {
+		name   string
+		group  orchestration.NodeGroup
+		client *bee.Client
+	}
+	var nodes []node
+	for _, gName := range groupNames {
+		g, ok := groups[gName]
+		if !ok {
+			return fmt.Errorf("node group %s not found", gName)
+		}
+		for _, name := range g.NodesSorted() {
+			client, err := g.NodeClient(name)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", name, err)
+			}
+			nodes = append(nodes, node{name: name, group: g, client: client})
+		}
+	}
+
+	if o.FromImage != "" {
+		for _, n := range nodes {
+			if err := c.switchImage(ctx, n.group, n.client, n.name, o.FromImage, "prepare", o); err != nil {
+				return err
+			}
+		}
+	}
+
+	states := make(map[string]state, len(nodes))
+	for _, n := range nodes {
+		batchID, err := n.client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+		if err != nil {
+			return fmt.Errorf("node %s: batch id %w", n.name, err)
+		}
+		c.logger.Infof("node %s: batch id %s", n.name, batchID)
+
+		var uploads []upload
+		for i := 0; i < o.ContentCount; i++ {
+			data := make([]byte, o.ContentSize)
+			if _, err := rnd.Read(data); err != nil {
+				return fmt.Errorf("random data: %w", err)
+			}
+			ref, err := n.client.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID, Pin: true})
+			if err != nil {
+				return fmt.Errorf("node %s: upload: %w", n.name, err)
+			}
+			uploads = append(uploads, upload{ref: ref, sum: sha256.Sum256(data)})
+		}
+
+		s, err := c.snapshot(ctx, n.client, n.name)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", n.name, err)
+		}
+		s.uploads = uploads
+		states[n.name] = s
+		c.logger.Infof("node %s: uploaded %d pinned contents, %d batches, %d pins", n.name, len(uploads), len(s.batches), len(s.pins))
+	}
+
+	phases := []phase{{name: "upgrade", image: o.ToImage}}
+	if o.Downgrade {
+		phases = append(phases, phase{name: "downgrade", image: o.FromImage})
+	}
+
+	for _, p := range phases {
+		var violations []string
+		for _, n := range nodes {
+			if err := c.switchImage(ctx, n.group, n.client, n.name, p.image, p.name, o); err != nil {
+				return err
+			}
+
+			v, err := c.verify(ctx, n.client, n.name, states[n.name])
+			if err != nil {
+				return fmt.Errorf("node %s: %w", n.name, err)
+			}
+			for _, msg := range v {
+				c.logger.Infof("node %s: %s violation: %s", n.name, p.name, msg)
+				violations = append(violations, fmt.Sprintf("node %s: %s", n.name, msg))
+			}
+		}
+
+		c.metrics.Violations.WithLabelValues(p.name).Add(float64(len(violations)))
+		if len(violations) > 0 {
+			return fmt.Errorf("%s to %s: %d violations, first: %s", p.name, p.image, len(violations), violations[0])
+		}
+		c.logger.Infof("%s to %s: data and state survived", p.name, p.image)
+	}
+
+	return nil
+}
+
+// switchImage sets the image of the node and waits until it runs a different
+// version than before
+func (c *Check) switchImage(ctx context.Context, g orchestration.NodeGroup, client *bee.Client, name, image, phaseName string, o Options) error {
+	h, err := client.Health(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: health: %w", name, err)
+	}
+
+	c.logger.Infof("node %s: switching from version %s to image %s", name, h.Version, image)
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, o.ReadyTimeout)
+	defer cancel()
+
+	if err := g.SetNodeImage(ctx, name, image); err != nil {
+		return fmt.Errorf("set node %s image: %w", name, err)
+	}
+
+	// the old pod may still respond during its termination, wait for the
+	// version to change unless the images are the same
+	same := o.FromImage == o.ToImage || phaseName == "prepare"
+	for {
+		v, err := client.Health(ctx)
+		if err == nil && (same || v.Version != h.Version) {
+			if ok, err := client.Readiness(ctx); err == nil && ok {
+				c.metrics.SwitchDuration.WithLabelValues(name, phaseName).Set(time.Since(start).Seconds())
+				c.logger.Infof("node %s: runs version %s after %s", name, v.Version, time.Since(start).Round(time.Second))
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("node %s: not running a new version after %s: %w", name, o.ReadyTimeout, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// snapshot returns the node state
+func (c *Check) snapshot(ctx context.Context, client *bee.Client, name string) (s state, err error) {
+	if s.overlay, err = client.Overlay(ctx); err != nil {
+		return state{}, err
+	}
+
+	batches, err := client.PostageBatches(ctx)
+	if err != nil {
+		return state{}, fmt.Errorf("postage batches: %w", err)
+	}
+	s.batches = make(map[string]uint8, len(batches))
+	for _, b := range batches {
+		if b.Exists && b.Usable {
+			s.batches[b.BatchID] = b.Depth
+		}
+	}
+
+	if s.stake, err = client.GetStake(ctx); err != nil {
+		c.logger.Infof("node %s: stake not available, skipping: %v", name, err)
+		s.stake = nil
+	}
+
+	pins, err := client.GetPins(ctx)
+	if err != nil {
+		return state{}, fmt.Errorf("pins: %w", err)
+	}
+	s.pins = make(map[string]bool, len(pins))
+	for _, p := range pins {
+		s.pins[p.String()] = true
+	}
+
+	return s, nil
+}
+
+// verify compares the node state to the state before the migration and
+// downloads the uploaded content
+func (c *Check) verify(ctx context.Context, client *bee.Client, name string, before state) (violations []string, err error) {
+	after, err := c.snapshot(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !after.overlay.Equal(before.overlay) {
+		violations = append(violations, fmt.Sprintf("overlay changed from %s to %s", before.overlay, after.overlay))
+	}
+
+	for _, id := range sortedKeys(before.batches) {
+		depth, ok := after.batches[id]
+		switch {
+		case !ok:
+			violations = append(violations, fmt.Sprintf("batch %s is missing or not usable", id))
+		case depth != before.batches[id]:
+			violations = append(violations, fmt.Sprintf("batch %s depth changed from %d to %d", id, before.batches[id], depth))
+		}
+	}
+
+	if before.stake != nil {
+		if after.stake == nil {
+			violations = append(violations, fmt.Sprintf("stake %s is not available", before.stake))
+		} else if after.stake.Cmp(before.stake) != 0 {
+			violations = append(violations, fmt.Sprintf("stake changed from %s to %s", before.stake, after.stake))
+		}
+	}
+
+	for _, ref := range sortedKeys(before.pins) {
+		if !after.pins[ref] {
+			violations = append(violations, fmt.Sprintf("pin %s is missing", ref))
+		}
+	}
+
+	for _, u := range before.uploads {
+		data, err := client.DownloadBytes(ctx, u.ref)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("content %s is not retrievable: %v", u.ref, err))
+			continue
+		}
+		if sha256.Sum256(data) != u.sum {
+			violations = append(violations, fmt.Sprintf("content %s differs", u.ref))
+		}
+	}
+
+	return violations, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
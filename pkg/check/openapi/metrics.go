@@ -0,0 +1,39 @@
+package openapi
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Responses *prometheus.CounterVec
+	Problems  *prometheus.CounterVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_openapi"
+	return metrics{
+		Responses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "responses_count",
+				Help:      "Number of validated responses by spec, path and result.",
+			},
+			[]string{"spec", "path", "result"},
+		),
+		Problems: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "problems_count",
+				Help:      "Number of spec conformance problems by spec and path.",
+			},
+			[]string{"spec", "path"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
@@ -0,0 +1,172 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// Options represents check options
+type Options struct {
+	APIEndpoints      []string
+	APISpec           string // file path or URL of the API spec
+	DebugAPIEndpoints []string
+	DebugAPISpec      string   // file path or URL of the Debug API spec
+	NodeGroups        []string // node groups to validate, all groups if empty
+	StrictFields      bool     // report response fields that are not documented
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		APIEndpoints:      []string{"/pins", "/tags"},
+		APISpec:           "https://raw.githubusercontent.com/ethersphere/bee/v1.13.0/openapi/Swarm.yaml",
+		DebugAPIEndpoints: []string{"/addresses", "/balances", "/chainstate", "/health", "/node", "/peers", "/readiness", "/reservestate", "/settlements", "/stamps", "/topology", "/wallet"},
+		DebugAPISpec:      "https://raw.githubusercontent.com/ethersphere/bee/v1.13.0/openapi/SwarmDebug.yaml",
+		StrictFields:      false,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run validates responses of the configured endpoints of every node against
+// the bee OpenAPI specs
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	l := newLoader()
+	apiSpec, err := l.load(ctx, o.APISpec)
+	if err != nil {
+		return fmt.Errorf("api spec: %w", err)
+	}
+	debugAPISpec, err := l.load(ctx, o.DebugAPISpec)
+	if err != nil {
+		return fmt.Errorf("debug api spec: %w", err)
+	}
+
+	v := &validator{loader: l, strict: o.StrictFields}
+
+	groups := cluster.NodeGroups()
+	groupNames := o.NodeGroups
+	if len(groupNames) == 0 {
+		for name := range groups {
+			groupNames = append(groupNames, name)
+		}
+	}
+	sort.Strings(groupNames)
+
+	var total int
+	for _, gName := range groupNames {
+		g, ok := groups[gName]
+		if !ok {
+			return fmt.Errorf("node group %s not found", gName)
+		}
+
+		clients, err := g.NodesClients(ctx)
+		if err != nil {
+			return fmt.Errorf("node group %s: %w", gName, err)
+		}
+
+		for _, name := range g.NodesSorted() {
+			client, ok := clients[name]
+			if !ok {
+				continue
+			}
+
+			for _, e := range o.APIEndpoints {
+				n, err := c.validate(ctx, v, apiSpec, o.APISpec, name, "api", e, client.APIRawResponse)
+				if err != nil {
+					return err
+				}
+				total += n
+			}
+			for _, e := range o.DebugAPIEndpoints {
+				n, err := c.validate(ctx, v, debugAPISpec, o.DebugAPISpec, name, "debug api", e, client.DebugAPIRawResponse)
+				if err != nil {
+					return err
+				}
+				total += n
+			}
+		}
+	}
+
+	if total > 0 {
+		return fmt.Errorf("found %d responses that do not conform to the openapi specs", total)
+	}
+
+	c.logger.Info("all responses conform to the openapi specs")
+
+	return nil
+}
+
+// validate requests the endpoint and validates the response against the spec,
+// logging found problems and returning their count
+func (c *Check) validate(ctx context.Context, v *validator, spec node, location, nodeName, specName, endpoint string, get func(context.Context, string) (*http.Response, error)) (int, error) {
+	op, template, ok := operation(spec, http.MethodGet, endpoint)
+	if !ok {
+		return 0, fmt.Errorf("%s spec: GET %s is not documented", specName, endpoint)
+	}
+
+	resp, err := get(ctx, endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("node %s: %s GET %s: %w", nodeName, specName, endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("node %s: %s GET %s: read body: %w", nodeName, specName, endpoint, err)
+	}
+
+	var body interface{}
+	decodeErr := json.Unmarshal(b, &body)
+
+	problems, err := v.response(ctx, op, location, resp.StatusCode, resp.Header.Get("Content-Type"), body, decodeErr)
+	if err != nil {
+		return 0, fmt.Errorf("%s spec: GET %s: %w", specName, template, err)
+	}
+
+	for _, p := range problems {
+		c.logger.Infof("node %s: %s GET %s (%d): %s", nodeName, specName, endpoint, resp.StatusCode, p)
+	}
+	c.metrics.Responses.WithLabelValues(specName, template, result(problems)).Inc()
+	c.metrics.Problems.WithLabelValues(specName, template).Add(float64(len(problems)))
+
+	if len(problems) > 0 {
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+func result(problems []string) string {
+	if len(problems) > 0 {
+		return "invalid"
+	}
+	return "valid"
+}
@@ -0,0 +1,213 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// node is a decoded YAML or JSON value of an OpenAPI document
+type node = map[string]interface{}
+
+// loader loads OpenAPI documents from files or URLs and resolves references
+// between them, caching every loaded document
+type loader struct {
+	client *http.Client
+	docs   map[string]node
+}
+
+func newLoader() *loader {
+	return &loader{
+		client: http.DefaultClient,
+		docs:   make(map[string]node),
+	}
+}
+
+// load returns the document at the location, a file path or an http(s) URL
+func (l *loader) load(ctx context.Context, location string) (node, error) {
+	if doc, ok := l.docs[location]; ok {
+		return doc, nil
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		b, err = l.fetch(ctx, location)
+	} else {
+		b, err = os.ReadFile(location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", location, err)
+	}
+
+	var v interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", location, err)
+	}
+
+	doc, ok := normalize(v).(node)
+	if !ok {
+		return nil, fmt.Errorf("decode %s: document is not an object", location)
+	}
+	l.docs[location] = doc
+
+	return doc, nil
+}
+
+func (l *loader) fetch(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// resolve follows $ref of the value until a value without a reference is
+// found and returns it together with the location of its document
+func (l *loader) resolve(ctx context.Context, v node, location string) (node, string, error) {
+	for i := 0; i < 32; i++ {
+		ref, ok := v["$ref"].(string)
+		if !ok {
+			return v, location, nil
+		}
+
+		file, pointer, _ := strings.Cut(ref, "#")
+		if file != "" {
+			location = relative(location, file)
+		}
+
+		doc, err := l.load(ctx, location)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var target interface{} = doc
+		for _, p := range strings.Split(strings.Trim(pointer, "/"), "/") {
+			if p == "" {
+				continue
+			}
+			p = strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~")
+			m, ok := target.(node)
+			if !ok {
+				return nil, "", fmt.Errorf("reference %s not found", ref)
+			}
+			if target, ok = m[p]; !ok {
+				return nil, "", fmt.Errorf("reference %s not found", ref)
+			}
+		}
+
+		if v, ok = target.(node); !ok {
+			return nil, "", fmt.Errorf("reference %s is not an object", ref)
+		}
+	}
+
+	return nil, "", fmt.Errorf("too many nested references in %s", location)
+}
+
+// relative returns the location of a file referenced from a document
+func relative(base, file string) string {
+	if u, err := url.Parse(base); err == nil && u.Scheme != "" {
+		r, err := u.Parse(file)
+		if err == nil {
+			return r.String()
+		}
+	}
+	return path.Join(path.Dir(base), file)
+}
+
+// operation returns the operation of the spec that matches the method and
+// the concrete request path. Paths without parameters are preferred over
+// templated ones.
+func operation(spec node, method, requestPath string) (op node, template string, ok bool) {
+	paths, _ := spec["paths"].(node)
+
+	templates := make([]string, 0, len(paths))
+	for t := range paths {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		ci, cj := strings.Count(templates[i], "{"), strings.Count(templates[j], "{")
+		if ci != cj {
+			return ci < cj
+		}
+		return templates[i] < templates[j]
+	})
+
+	for _, t := range templates {
+		if !matchPath(t, requestPath) {
+			continue
+		}
+		item, _ := paths[t].(node)
+		if op, ok := item[strings.ToLower(method)].(node); ok {
+			return op, t, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// matchPath reports whether the request path matches the path template
+func matchPath(template, requestPath string) bool {
+	ts := strings.Split(strings.Trim(template, "/"), "/")
+	ps := strings.Split(strings.Trim(requestPath, "/"), "/")
+	if len(ts) != len(ps) {
+		return false
+	}
+
+	for i := range ts {
+		if strings.HasPrefix(ts[i], "{") && strings.HasSuffix(ts[i], "}") {
+			continue
+		}
+		if ts[i] != ps[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalize converts maps with non-string keys, produced for example by
+// unquoted status codes, to maps with string keys
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalize(e)
+		}
+		return v
+	case map[interface{}]interface{}:
+		m := make(node, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = normalize(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalize(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,168 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSpecs writes the documents to the directory by their names
+func writeSpecs(t *testing.T, dir string, docs map[string]string) {
+	t.Helper()
+	for name, doc := range docs {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(doc), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecs(t, dir, map[string]string{
+		"api.yaml": `
+components:
+  schemas:
+    Address:
+      $ref: "#/components/schemas/Reference"
+    Reference:
+      type: string
+    "a/b~c":
+      type: integer
+    Loop:
+      $ref: "#/components/schemas/Loop"
+    Scalar:
+      $ref: "#/components/schemas/Reference/type"
+    Remote:
+      $ref: "common.yaml#/components/schemas/Balance"
+`,
+		"common.yaml": `
+components:
+  schemas:
+    Balance:
+      $ref: "#/components/schemas/BigInt"
+    BigInt:
+      type: string
+      pattern: "^[0-9]+$"
+`,
+	})
+	location := filepath.Join(dir, "api.yaml")
+
+	for _, tc := range []struct {
+		name         string
+		ref          string
+		wantType     string
+		wantLocation string
+		wantErr      string
+	}{
+		{name: "no reference", wantLocation: location},
+		{name: "nested references", ref: "#/components/schemas/Address", wantType: "string", wantLocation: location},
+		{name: "escaped pointer", ref: "#/components/schemas/a~1b~0c", wantType: "integer", wantLocation: location},
+		{name: "other document", ref: "common.yaml#/components/schemas/Balance", wantType: "string", wantLocation: filepath.Join(dir, "common.yaml")},
+		{name: "reference to other document", ref: "#/components/schemas/Remote", wantType: "string", wantLocation: filepath.Join(dir, "common.yaml")},
+		{name: "missing", ref: "#/components/schemas/Missing", wantErr: "not found"},
+		{name: "missing document", ref: "missing.yaml#/components", wantErr: "load"},
+		{name: "not an object", ref: "#/components/schemas/Scalar", wantErr: "not an object"},
+		{name: "cycle", ref: "#/components/schemas/Loop", wantErr: "too many nested references"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := node{"type": "object"}
+			if tc.ref != "" {
+				v = node{"$ref": tc.ref}
+			}
+
+			got, gotLocation, err := newLoader().resolve(context.Background(), v, location)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantType != "" && got["type"] != tc.wantType {
+				t.Errorf("got type %v, want %s", got["type"], tc.wantType)
+			}
+			if gotLocation != tc.wantLocation {
+				t.Errorf("got location %s, want %s", gotLocation, tc.wantLocation)
+			}
+		})
+	}
+}
+
+func TestResolveURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/specs/api.yaml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`
+components:
+  schemas:
+    Remote:
+      $ref: "common.yaml#/components/schemas/BigInt"
+`))
+	})
+	mux.HandleFunc("/specs/common.yaml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`
+components:
+  schemas:
+    BigInt:
+      type: string
+`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	got, location, err := newLoader().resolve(context.Background(), node{"$ref": "#/components/schemas/Remote"}, server.URL+"/specs/api.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["type"] != "string" {
+		t.Errorf("got type %v, want string", got["type"])
+	}
+	if want := server.URL + "/specs/common.yaml"; location != want {
+		t.Errorf("got location %s, want %s", location, want)
+	}
+}
+
+func TestOperation(t *testing.T) {
+	spec := node{
+		"paths": node{
+			"/bytes/{reference}": node{"get": node{"operationId": "downloadBytes"}},
+			"/bytes":             node{"post": node{"operationId": "uploadBytes"}},
+			"/pins/{reference}":  node{"get": node{"operationId": "getPin"}},
+			"/pins/check":        node{"get": node{"operationId": "checkPins"}},
+		},
+	}
+
+	for _, tc := range []struct {
+		method, path string
+		wantID       string
+		wantTemplate string
+	}{
+		{method: "GET", path: "/bytes/abcd", wantID: "downloadBytes", wantTemplate: "/bytes/{reference}"},
+		{method: "POST", path: "/bytes", wantID: "uploadBytes", wantTemplate: "/bytes"},
+		{method: "GET", path: "/pins/check", wantID: "checkPins", wantTemplate: "/pins/check"},
+		{method: "GET", path: "/pins/abcd/", wantID: "getPin", wantTemplate: "/pins/{reference}"},
+		{method: "DELETE", path: "/bytes/abcd"},
+		{method: "GET", path: "/bytes/abcd/efgh"},
+	} {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			op, template, ok := operation(spec, tc.method, tc.path)
+			if tc.wantID == "" {
+				if ok {
+					t.Fatalf("got operation %v of %s, want none", op, template)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("operation not found")
+			}
+			if op["operationId"] != tc.wantID || template != tc.wantTemplate {
+				t.Errorf("got operation %v of %s, want %s of %s", op["operationId"], template, tc.wantID, tc.wantTemplate)
+			}
+		})
+	}
+}
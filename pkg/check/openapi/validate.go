@@ -0,0 +1,252 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// validator validates decoded JSON values against OpenAPI schemas
+type validator struct {
+	loader *loader
+	strict bool // report properties that are not documented
+}
+
+// response validates status code, content type and body of a response
+// against the operation and returns found problems
+func (v *validator) response(ctx context.Context, op node, location string, status int, contentType string, body interface{}, decodeErr error) (problems []string, err error) {
+	responses, _ := op["responses"].(node)
+
+	r, ok := responses[strconv.Itoa(status)].(node)
+	if !ok {
+		r, ok = responses[fmt.Sprintf("%dXX", status/100)].(node)
+	}
+	if !ok {
+		r, ok = responses["default"].(node)
+	}
+	if !ok {
+		documented := make([]string, 0, len(responses))
+		for code := range responses {
+			documented = append(documented, code)
+		}
+		sort.Strings(documented)
+		return []string{fmt.Sprintf("status code %d is not documented, documented are %s", status, strings.Join(documented, ", "))}, nil
+	}
+
+	r, location, err = v.loader.resolve(ctx, r, location)
+	if err != nil {
+		return nil, err
+	}
+
+	content, _ := r["content"].(node)
+	if len(content) == 0 {
+		return nil, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	media, ok := content[mediaType].(node)
+	if !ok {
+		documented := make([]string, 0, len(content))
+		for t := range content {
+			documented = append(documented, t)
+		}
+		sort.Strings(documented)
+		return []string{fmt.Sprintf("content type %q is not documented, documented are %s", contentType, strings.Join(documented, ", "))}, nil
+	}
+
+	schema, ok := media["schema"].(node)
+	if !ok || mediaType != "application/json" {
+		return nil, nil
+	}
+
+	if decodeErr != nil {
+		return []string{fmt.Sprintf("invalid json body: %v", decodeErr)}, nil
+	}
+
+	return v.value(ctx, body, schema, location, "")
+}
+
+// value validates the value against the schema
+func (v *validator) value(ctx context.Context, val interface{}, schema node, location, path string) (problems []string, err error) {
+	schema, location, err = v.loader.resolve(ctx, schema, location)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable {
+			return nil, nil
+		}
+		if _, typed := schema["type"]; !typed {
+			return nil, nil
+		}
+		return []string{fmt.Sprintf("%s is null", displayPath(path))}, nil
+	}
+
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		for _, s := range all {
+			sn, _ := s.(node)
+			p, err := v.value(ctx, val, sn, location, path)
+			if err != nil {
+				return nil, err
+			}
+			problems = append(problems, p...)
+		}
+	}
+
+	for _, key := range []string{"oneOf", "anyOf"} {
+		alternatives, ok := schema[key].([]interface{})
+		if !ok {
+			continue
+		}
+		var first []string
+		matched := false
+		for i, s := range alternatives {
+			sn, _ := s.(node)
+			p, err := v.value(ctx, val, sn, location, path)
+			if err != nil {
+				return nil, err
+			}
+			if len(p) == 0 {
+				matched = true
+				break
+			}
+			if i == 0 {
+				first = p
+			}
+		}
+		if !matched {
+			problems = append(problems, first...)
+		}
+	}
+
+	typ, _ := schema["type"].(string)
+	if typ == "" {
+		if _, ok := schema["properties"]; ok {
+			typ = "object"
+		}
+	}
+
+	switch typ {
+	case "object":
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			return append(problems, fmt.Sprintf("%s is %s, expected object", displayPath(path), kind(val))), nil
+		}
+		p, err := v.object(ctx, obj, schema, location, path)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, p...)
+	case "array":
+		arr, ok := val.([]interface{})
+		if !ok {
+			return append(problems, fmt.Sprintf("%s is %s, expected array", displayPath(path), kind(val))), nil
+		}
+		items, _ := schema["items"].(node)
+		if items == nil {
+			break
+		}
+		for i, e := range arr {
+			p, err := v.value(ctx, e, items, location, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			problems = append(problems, p...)
+		}
+	case "string":
+		if _, ok := val.(string); !ok {
+			problems = append(problems, fmt.Sprintf("%s is %s, expected string", displayPath(path), kind(val)))
+		}
+	case "integer":
+		if f, ok := val.(float64); !ok || f != math.Trunc(f) {
+			problems = append(problems, fmt.Sprintf("%s is %s, expected integer", displayPath(path), kind(val)))
+		}
+	case "number":
+		if _, ok := val.(float64); !ok {
+			problems = append(problems, fmt.Sprintf("%s is %s, expected number", displayPath(path), kind(val)))
+		}
+	case "boolean":
+		if _, ok := val.(bool); !ok {
+			problems = append(problems, fmt.Sprintf("%s is %s, expected boolean", displayPath(path), kind(val)))
+		}
+	}
+
+	return problems, nil
+}
+
+// object validates required and documented properties of the object,
+// properties that are not documented are reported if the schema does not
+// allow additional properties or in the strict mode
+func (v *validator) object(ctx context.Context, obj map[string]interface{}, schema node, location, path string) (problems []string, err error) {
+	required, _ := schema["required"].([]interface{})
+	for _, r := range required {
+		name, _ := r.(string)
+		if _, ok := obj[name]; !ok {
+			problems = append(problems, fmt.Sprintf("required field %s is missing", displayPath(path+"."+name)))
+		}
+	}
+
+	properties, _ := schema["properties"].(node)
+	additional, _ := schema["additionalProperties"].(node)
+	closed := schema["additionalProperties"] == false
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := path + "." + name
+		s, ok := properties[name].(node)
+		switch {
+		case ok:
+		case additional != nil:
+			s = additional
+		case closed, v.strict && len(properties) > 0:
+			problems = append(problems, fmt.Sprintf("field %s is not documented", displayPath(p)))
+			continue
+		default:
+			continue
+		}
+
+		found, err := v.value(ctx, obj[name], s, location, p)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, found...)
+	}
+
+	return problems, nil
+}
+
+func kind(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "response"
+	}
+	return strconv.Quote(strings.TrimPrefix(path, "."))
+}
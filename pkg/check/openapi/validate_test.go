@@ -0,0 +1,251 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const validateSpec = `
+components:
+  schemas:
+    Address:
+      type: string
+    Peer:
+      type: object
+      required: [address]
+      properties:
+        address:
+          $ref: "#/components/schemas/Address"
+        depth:
+          type: integer
+    Peers:
+      type: object
+      properties:
+        peers:
+          type: array
+          items:
+            $ref: "#/components/schemas/Peer"
+`
+
+// decodeSchema returns the schema decoded from YAML
+func decodeSchema(t *testing.T, s string) node {
+	t.Helper()
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return normalize(v).(node)
+}
+
+// decodeJSON returns the value decoded from JSON
+func decodeJSON(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestValidateValue(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecs(t, dir, map[string]string{"api.yaml": validateSpec})
+	location := filepath.Join(dir, "api.yaml")
+
+	for _, tc := range []struct {
+		name   string
+		schema string
+		value  string
+		strict bool
+		want   []string
+	}{
+		{
+			name:   "valid reference",
+			schema: `$ref: "#/components/schemas/Peers"`,
+			value:  `{"peers": [{"address": "abcd", "depth": 2}]}`,
+		},
+		{
+			name:   "required field of reference",
+			schema: `$ref: "#/components/schemas/Peers"`,
+			value:  `{"peers": [{"depth": 2}, {"address": "abcd"}]}`,
+			want:   []string{`required field "peers[0].address" is missing`},
+		},
+		{
+			name:   "required fields",
+			schema: "{type: object, required: [a, b], properties: {a: {type: string}}}",
+			value:  `{}`,
+			want:   []string{`required field "a" is missing`, `required field "b" is missing`},
+		},
+		{
+			name:   "type mismatches",
+			schema: "{type: object, properties: {s: {type: string}, i: {type: integer}, n: {type: number}, b: {type: boolean}, a: {type: array}, o: {type: object}}}",
+			value:  `{"s": 1, "i": 1.5, "n": "1", "b": "true", "a": {}, "o": []}`,
+			want: []string{
+				`"a" is object, expected array`,
+				`"b" is string, expected boolean`,
+				`"i" is number, expected integer`,
+				`"n" is string, expected number`,
+				`"o" is array, expected object`,
+				`"s" is number, expected string`,
+			},
+		},
+		{
+			name:   "type mismatch of reference",
+			schema: `$ref: "#/components/schemas/Peer"`,
+			value:  `{"address": 42, "depth": "2"}`,
+			want:   []string{`"address" is number, expected string`, `"depth" is string, expected integer`},
+		},
+		{
+			name:   "type mismatch of response",
+			schema: "{type: array}",
+			value:  `{}`,
+			want:   []string{"response is object, expected array"},
+		},
+		{
+			name:   "integer",
+			schema: "{type: integer}",
+			value:  `42`,
+		},
+		{
+			name:   "null",
+			schema: "{type: object, properties: {a: {type: string}, b: {type: string, nullable: true}, c: {}}}",
+			value:  `{"a": null, "b": null, "c": null}`,
+			want:   []string{`"a" is null`},
+		},
+		{
+			name:   "additional properties schema",
+			schema: "{type: object, additionalProperties: {type: integer}}",
+			value:  `{"a": 1, "b": "2"}`,
+			want:   []string{`"b" is string, expected integer`},
+		},
+		{
+			name:   "additional properties schema with properties",
+			schema: "{type: object, properties: {name: {type: string}}, additionalProperties: {type: integer}}",
+			value:  `{"name": "bee", "count": 1}`,
+			strict: true,
+		},
+		{
+			name:   "additional properties not allowed",
+			schema: "{type: object, properties: {name: {type: string}}, additionalProperties: false}",
+			value:  `{"name": "bee", "count": 1}`,
+			want:   []string{`field "count" is not documented`},
+		},
+		{
+			name:   "additional properties allowed",
+			schema: "{type: object, properties: {name: {type: string}}, additionalProperties: true}",
+			value:  `{"name": "bee", "count": 1}`,
+		},
+		{
+			name:   "undocumented properties",
+			schema: "{type: object, properties: {name: {type: string}}}",
+			value:  `{"name": "bee", "count": 1}`,
+		},
+		{
+			name:   "undocumented properties in strict mode",
+			schema: "{type: object, properties: {name: {type: string}}}",
+			value:  `{"name": "bee", "count": 1}`,
+			strict: true,
+			want:   []string{`field "count" is not documented`},
+		},
+		{
+			name:   "all of",
+			schema: "{allOf: [{required: [a], properties: {a: {type: string}}}, {required: [b], properties: {b: {type: string}}}]}",
+			value:  `{"a": "x"}`,
+			want:   []string{`required field "b" is missing`},
+		},
+		{
+			name:   "one of",
+			schema: "{oneOf: [{type: string}, {type: integer}]}",
+			value:  `1`,
+		},
+		{
+			name:   "none of one of",
+			schema: "{oneOf: [{type: string}, {type: integer}]}",
+			value:  `true`,
+			want:   []string{"response is boolean, expected string"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{loader: newLoader(), strict: tc.strict}
+
+			got, err := v.value(context.Background(), decodeJSON(t, tc.value), decodeSchema(t, tc.schema), location, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got problems %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateValueMissingReference(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecs(t, dir, map[string]string{"api.yaml": validateSpec})
+
+	v := &validator{loader: newLoader()}
+	schema := decodeSchema(t, `{type: object, properties: {a: {$ref: "#/components/schemas/Missing"}}}`)
+	if _, err := v.value(context.Background(), decodeJSON(t, `{"a": 1}`), schema, filepath.Join(dir, "api.yaml"), ""); err == nil {
+		t.Error("missing reference is resolved")
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	op := decodeSchema(t, `
+responses:
+  "200":
+    content:
+      application/json:
+        schema: {type: object, required: [reference]}
+  4XX:
+    content:
+      application/problem+json:
+        schema: {type: object}
+  default:
+    description: error
+`)
+
+	for _, tc := range []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		decodeErr   error
+		want        []string
+	}{
+		{name: "valid", status: 200, contentType: "application/json; charset=utf-8", body: `{"reference": "abcd"}`},
+		{name: "invalid body", status: 200, contentType: "application/json", body: `{}`, want: []string{`required field "reference" is missing`}},
+		{name: "invalid json", status: 200, contentType: "application/json", body: `null`, decodeErr: errors.New("unexpected EOF"), want: []string{"invalid json body: unexpected EOF"}},
+		{name: "undocumented content type", status: 200, contentType: "text/plain", body: `null`, want: []string{`content type "text/plain" is not documented, documented are application/json`}},
+		{name: "status class", status: 404, contentType: "application/problem+json", body: `{}`},
+		{name: "default", status: 500, contentType: "text/plain", body: `null`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{loader: newLoader()}
+
+			got, err := v.response(context.Background(), op, "api.yaml", tc.status, tc.contentType, decodeJSON(t, tc.body), tc.decodeErr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got problems %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	delete(op["responses"].(node), "default")
+	v := &validator{loader: newLoader()}
+	got, err := v.response(context.Background(), op, "api.yaml", 500, "text/plain", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"status code 500 is not documented, documented are 200, 4XX"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got problems %q, want %q", got, want)
+	}
+}
@@ -39,43 +39,71 @@ func NewCheck(logger logging.Logger) beekeeper.Action {
 }
 
 // Run executes ping check
-func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, _ interface{}) (err error) {
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	_, err = c.RunResult(ctx, cluster, opts)
+	return err
+}
+
+// RunResult runs the check and returns pings of every node group as steps
+// and the mean and maximal round trip time as measurements of its result
+func (c *Check) RunResult(ctx context.Context, cluster orchestration.Cluster, _ interface{}) (r beekeeper.Result, err error) {
+	var (
+		total, max time.Duration
+		pings      int
+	)
+
 	nodeGroups := cluster.NodeGroups()
-	for _, ng := range nodeGroups {
-		nodesClients, err := ng.NodesClients(ctx)
-		if err != nil {
-			return fmt.Errorf("get nodes clients: %w", err)
-		}
+	for name, ng := range nodeGroups {
+		if err := r.Step(fmt.Sprintf("node group %s", name), func() error {
+			nodesClients, err := ng.NodesClients(ctx)
+			if err != nil {
+				return fmt.Errorf("get nodes clients: %w", err)
+			}
 
-		for n := range nodeStream(ctx, nodesClients) { // TODO: confirm use case for nodeStream(ctx, ng.NodesClientsAll(ctx))
-			for t := 0; t < 5; t++ {
-				time.Sleep(2 * time.Duration(t) * time.Second)
+			for n := range nodeStream(ctx, nodesClients) { // TODO: confirm use case for nodeStream(ctx, ng.NodesClientsAll(ctx))
+				for t := 0; t < 5; t++ {
+					time.Sleep(2 * time.Duration(t) * time.Second)
 
-				if n.Error != nil {
-					if t == 4 {
-						return fmt.Errorf("node %s: %w", n.Name, n.Error)
+					if n.Error != nil {
+						if t == 4 {
+							return fmt.Errorf("node %s: %w", n.Name, n.Error)
+						}
+						c.logger.Infof("node %s: %v", n.Name, n.Error)
+						continue
+					}
+					c.logger.Infof("Node %s: %s Peer: %s RTT: %s", n.Name, n.Address, n.PeerAddress, n.RTT)
+
+					rtt, err := time.ParseDuration(n.RTT)
+					if err != nil {
+						if t == 4 {
+							return fmt.Errorf("node %s: %w", n.Name, err)
+						}
+						c.logger.Infof("node %s: %v", n.Name, err)
+						continue
 					}
-					c.logger.Infof("node %s: %v", n.Name, n.Error)
-					continue
-				}
-				c.logger.Infof("Node %s: %s Peer: %s RTT: %s", n.Name, n.Address, n.PeerAddress, n.RTT)
 
-				rtt, err := time.ParseDuration(n.RTT)
-				if err != nil {
-					if t == 4 {
-						return fmt.Errorf("node %s: %w", n.Name, err)
+					c.metrics.RttGauge.WithLabelValues(n.Address.String(), n.PeerAddress.String()).Set(rtt.Seconds())
+					c.metrics.RttHistogram.Observe(rtt.Seconds())
+					total += rtt
+					pings++
+					if rtt > max {
+						max = rtt
 					}
-					c.logger.Infof("node %s: %v", n.Name, err)
-					continue
+					break
 				}
-
-				c.metrics.RttGauge.WithLabelValues(n.Address.String(), n.PeerAddress.String()).Set(rtt.Seconds())
-				c.metrics.RttHistogram.Observe(rtt.Seconds())
-				break
 			}
+			return nil
+		}); err != nil {
+			return r, err
 		}
 	}
-	return
+
+	if pings > 0 {
+		r.Measure("mean rtt", (total / time.Duration(pings)).Seconds(), "s")
+		r.Measure("max rtt", max.Seconds(), "s")
+	}
+
+	return r, nil
 }
 
 type nodeStreamMsg struct {
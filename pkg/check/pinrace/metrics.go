@@ -0,0 +1,55 @@
+package pinrace
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	PressureChunks      prometheus.Counter
+	PinnedChunks        prometheus.Counter
+	EvictedPinnedChunks prometheus.Counter
+	PinDuration         prometheus.Histogram
+}
+
+func newMetrics() metrics {
+	subsystem := "check_pinrace"
+	return metrics{
+		PressureChunks: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "pressure_chunks_count",
+				Help:      "Number of chunks uploaded to create reserve and cache pressure.",
+			},
+		),
+		PinnedChunks: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "pinned_chunks_count",
+				Help:      "Number of chunks pinned under pressure.",
+			},
+		),
+		EvictedPinnedChunks: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "evicted_pinned_chunks_count",
+				Help:      "Number of pinned chunks that were evicted.",
+			},
+		),
+		PinDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "pin_duration_seconds",
+				Help:      "Pin request duration.",
+			},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
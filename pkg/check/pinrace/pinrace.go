@@ -0,0 +1,278 @@
+package pinrace
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+	"golang.org/x/sync/errgroup"
+)
+
+// Options represents check options
+type Options struct {
+	GasPrice        string
+	PinnedChunks    int           // number of chunks pinned while under pressure
+	PinDelay        time.Duration // delay between chunk upload and pinning
+	PostageAmount   int64
+	PostageDepth    uint64
+	PostageLabel    string
+	PressureChunks  int // number of chunks uploaded to push the reserve and cache into eviction
+	PressureWorkers int
+	Seed            int64
+	VerifyDuration  time.Duration // how long to keep verifying pinned chunks after the pressure stopped
+	VerifyInterval  time.Duration
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		GasPrice:        "",
+		PinnedChunks:    50,
+		PinDelay:        0,
+		PostageAmount:   1,
+		PostageDepth:    20,
+		PostageLabel:    "test-label",
+		PressureChunks:  5000,
+		PressureWorkers: 10,
+		Seed:            0,
+		VerifyDuration:  30 * time.Second,
+		VerifyInterval:  200 * time.Millisecond,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run uploads chunks to a node to push its reserve and cache into eviction,
+// while concurrently uploading and pinning chunks that are first in line for
+// eviction. All pinned chunks are continuously verified to be present on the
+// node, from the moment the pin request returned until the end of the run, so
+// that any window in which a freshly pinned chunk is evicted is reported.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	node, err := cluster.RandomNode(ctx, rnd)
+	if err != nil {
+		return fmt.Errorf("random node: %w", err)
+	}
+	client := node.Client()
+	c.logger.Infof("chosen node: %s", node.Name())
+
+	overlay, err := client.Overlay(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", node.Name(), err)
+	}
+
+	// both pressure and pinned chunks are placed at proximity order 0, the
+	// furthest from the node, which is evicted first
+	pressureChunks := bee.GenerateNRandomChunksAt(rnd, overlay, o.PressureChunks, 0)
+	pinnedChunks := bee.GenerateNRandomChunksAt(rnd, overlay, o.PinnedChunks, 0)
+
+	batchID, err := client.CreatePostageBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel, true)
+	if err != nil {
+		return fmt.Errorf("node %s: create batch: %w", node.Name(), err)
+	}
+	c.logger.Infof("node %s: batch id %s", node.Name(), batchID)
+
+	pinned := new(pinnedSet)
+	pressureDone := make(chan struct{})
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	// pressure: upload chunks with a number of workers
+	g.Go(func() error {
+		defer close(pressureDone)
+		return c.pressure(gctx, client, batchID, pressureChunks, o.PressureWorkers)
+	})
+
+	// pinner: upload and pin chunks spread over the pressure window
+	g.Go(func() error {
+		for i, ch := range pinnedChunks {
+			select {
+			case <-gctx.Done():
+				return gctx.Err()
+			case <-pressureDone:
+				c.logger.Infof("pressure finished after %d pinned chunks", i)
+				return nil
+			default:
+			}
+
+			if _, err := client.UploadChunk(gctx, ch.Data(), api.UploadOptions{BatchID: batchID}); err != nil {
+				return fmt.Errorf("upload chunk %s: %w", ch.Address(), err)
+			}
+
+			if o.PinDelay > 0 {
+				select {
+				case <-gctx.Done():
+					return gctx.Err()
+				case <-time.After(o.PinDelay):
+				}
+			}
+
+			start := time.Now()
+			if err := client.PinRootHash(gctx, ch.Address()); err != nil {
+				return fmt.Errorf("pin chunk %s: %w", ch.Address(), err)
+			}
+			c.metrics.PinDuration.Observe(time.Since(start).Seconds())
+			c.metrics.PinnedChunks.Inc()
+
+			pinned.add(ch.Address(), time.Now())
+		}
+		return nil
+	})
+
+	// verifier: all pinned chunks must stay in the localstore
+	g.Go(func() error {
+		done := pressureDone
+		var deadline <-chan time.Time
+		for {
+			if err := c.verify(gctx, client, pinned); err != nil {
+				return err
+			}
+
+			select {
+			case <-gctx.Done():
+				return gctx.Err()
+			case <-done:
+				done = nil
+				deadline = time.After(o.VerifyDuration)
+			case <-deadline:
+				return nil
+			case <-time.After(o.VerifyInterval):
+			}
+		}
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// a final sanity check that the pins are still registered
+	pins, err := client.GetPins(ctx)
+	if err != nil {
+		return fmt.Errorf("get pins: %w", err)
+	}
+	registered := make(map[string]struct{}, len(pins))
+	for _, p := range pins {
+		registered[p.String()] = struct{}{}
+	}
+	for _, p := range pinned.chunks() {
+		if _, ok := registered[p.address.String()]; !ok {
+			return fmt.Errorf("chunk %s is not listed as pinned", p.address)
+		}
+	}
+
+	for _, p := range pinned.chunks() {
+		if err := client.UnpinRootHash(ctx, p.address); err != nil {
+			return fmt.Errorf("unpin chunk %s: %w", p.address, err)
+		}
+	}
+
+	c.logger.Infof("node %s: %d pinned chunks survived %d chunks of pressure", node.Name(), len(pinned.chunks()), len(pressureChunks))
+
+	return nil
+}
+
+// pressure uploads chunks concurrently with the given number of workers
+func (c *Check) pressure(ctx context.Context, client *bee.Client, batchID string, chunks []swarm.Chunk, workers int) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	chunksC := make(chan swarm.Chunk)
+	g, gctx := errgroup.WithContext(ctx)
+
+	for i := 0; i < workers; i++ {
+		g.Go(func() error {
+			for ch := range chunksC {
+				if _, err := client.UploadChunk(gctx, ch.Data(), api.UploadOptions{BatchID: batchID}); err != nil {
+					return fmt.Errorf("upload pressure chunk %s: %w", ch.Address(), err)
+				}
+				c.metrics.PressureChunks.Inc()
+			}
+			return nil
+		})
+	}
+
+	g.Go(func() error {
+		defer close(chunksC)
+		for _, ch := range chunks {
+			select {
+			case <-gctx.Done():
+				return gctx.Err()
+			case chunksC <- ch:
+			}
+		}
+		return nil
+	})
+
+	return g.Wait()
+}
+
+// verify checks that all pinned chunks are present on the node
+func (c *Check) verify(ctx context.Context, client *bee.Client, pinned *pinnedSet) error {
+	for _, p := range pinned.chunks() {
+		has, err := client.HasChunk(ctx, p.address)
+		if err != nil {
+			return fmt.Errorf("has chunk %s: %w", p.address, err)
+		}
+		if !has {
+			c.metrics.EvictedPinnedChunks.Inc()
+			return fmt.Errorf("pinned chunk %s evicted %s after pinning", p.address, time.Since(p.pinnedAt))
+		}
+	}
+	return nil
+}
+
+type pinnedChunk struct {
+	address  swarm.Address
+	pinnedAt time.Time
+}
+
+// pinnedSet holds chunks that are pinned and the time they were pinned at
+type pinnedSet struct {
+	mu     sync.Mutex
+	pinned []pinnedChunk
+}
+
+func (s *pinnedSet) add(a swarm.Address, t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pinned = append(s.pinned, pinnedChunk{address: a, pinnedAt: t})
+}
+
+func (s *pinnedSet) chunks() []pinnedChunk {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]pinnedChunk(nil), s.pinned...)
+}
@@ -0,0 +1,29 @@
+package plugin
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Value *prometheus.GaugeVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_plugin"
+	return metrics{
+		Value: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "value",
+				Help:      "Last value of a metric reported by a plugin.",
+			},
+			[]string{"plugin", "name"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
@@ -0,0 +1,223 @@
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// maxMessageSize is the maximum size of a single message line
+const maxMessageSize = 1024 * 1024
+
+// Options represents check options
+type Options struct {
+	Args    []string               // arguments of the plugin executable
+	Options map[string]interface{} // options passed to the plugin as they are
+	Path    string                 // path of the plugin executable
+	Seed    int64
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		Seed: random.Int64(),
+	}
+}
+
+// compile check whether Check implements interface
+var (
+	_ beekeeper.Action         = (*Check)(nil)
+	_ beekeeper.ResultReporter = (*Check)(nil)
+)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run runs an external check executable
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	_, err = c.RunResult(ctx, cluster, opts)
+	return err
+}
+
+// RunResult runs an external check executable, passing it the cluster
+// connection information and options, and returns the result it reports
+func (c *Check) RunResult(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (r beekeeper.Result, err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return r, fmt.Errorf("invalid options type")
+	}
+	if o.Path == "" {
+		return r, errors.New("plugin path not set")
+	}
+	name := filepath.Base(o.Path)
+
+	c.logger.Infof("seed: %d", o.Seed)
+
+	request, err := json.Marshal(Request{
+		Cluster: clusterInfo(cluster),
+		Options: o.Options,
+		Seed:    o.Seed,
+	})
+	if err != nil {
+		return r, fmt.Errorf("marshal request: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, o.Path, o.Args...)
+	cmd.Stdin = bytes.NewReader(append(request, '\n'))
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return r, fmt.Errorf("plugin %s: stdout: %w", name, err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return r, fmt.Errorf("plugin %s: stderr: %w", name, err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return r, fmt.Errorf("plugin %s: start: %w", name, err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s := bufio.NewScanner(stderr)
+		for s.Scan() {
+			c.logger.Infof("plugin %s: %s", name, s.Text())
+		}
+	}()
+
+	var result *beekeeper.Result
+	readErr := c.readMessages(stdout, name, func(res *beekeeper.Result) { result = res })
+
+	// pipes have to be read to the end before waiting for the plugin
+	if readErr != nil {
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+	wg.Wait()
+
+	if err := cmd.Wait(); err != nil {
+		return r, fmt.Errorf("plugin %s: %w", name, err)
+	}
+	if readErr != nil {
+		return r, fmt.Errorf("plugin %s: %w", name, readErr)
+	}
+
+	if result == nil {
+		return r, fmt.Errorf("plugin %s: exited without result", name)
+	}
+	r = *result
+	if r.Status == beekeeper.StatusFailed {
+		return r, fmt.Errorf("plugin %s: %s", name, r.Error)
+	}
+
+	return r, nil
+}
+
+// readMessages reads messages of the plugin, logging its log lines, setting
+// its metrics and passing its result to the result function
+func (c *Check) readMessages(r io.Reader, name string, result func(*beekeeper.Result)) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+
+	for s.Scan() {
+		if len(bytes.TrimSpace(s.Bytes())) == 0 {
+			continue
+		}
+
+		var msg Message
+		if err := json.Unmarshal(s.Bytes(), &msg); err != nil {
+			return fmt.Errorf("invalid message %q: %w", s.Text(), err)
+		}
+
+		if msg.Log != nil {
+			c.log(name, msg.Log)
+		}
+		if msg.Metric != nil {
+			c.metrics.Value.WithLabelValues(name, msg.Metric.Name).Set(msg.Metric.Value)
+		}
+		if msg.Result != nil {
+			result(msg.Result)
+		}
+	}
+
+	return s.Err()
+}
+
+// log logs the plugin log line on its level
+func (c *Check) log(name string, l *Log) {
+	switch l.Level {
+	case "debug":
+		c.logger.Debugf("plugin %s: %s", name, l.Message)
+	case "warning":
+		c.logger.Warningf("plugin %s: %s", name, l.Message)
+	case "error":
+		c.logger.Errorf("plugin %s: %s", name, l.Message)
+	default:
+		c.logger.Infof("plugin %s: %s", name, l.Message)
+	}
+}
+
+// clusterInfo returns connection information of all cluster nodes, with
+// credentials of their APIs
+func clusterInfo(cluster orchestration.Cluster) Cluster {
+	info := Cluster{Name: cluster.Name()}
+
+	groups := cluster.NodeGroups()
+	for _, gName := range cluster.NodeGroupsSorted() {
+		g := groups[gName]
+		for _, nName := range g.NodesSorted() {
+			n, err := g.Node(nName)
+			if err != nil || n.Client() == nil {
+				continue
+			}
+
+			node := Node{Name: nName, NodeGroup: gName}
+			if cfg := n.Config(); cfg != nil {
+				node.FullNode = cfg.FullNode
+			}
+			o := n.Client().Config()
+			if o.APIURL != nil {
+				node.APIURL = o.APIURL.String()
+				node.APIInsecureTLS = o.APIInsecureTLS
+			}
+			if o.DebugAPIURL != nil {
+				node.DebugAPIURL = o.DebugAPIURL.String()
+				node.DebugAPIInsecureTLS = o.DebugAPIInsecureTLS
+			}
+			node.BearerToken = o.BearerToken
+			node.CAFile, node.CertFile, node.KeyFile = o.CAFile, o.CertFile, o.KeyFile
+			if o.Restricted {
+				node.Restricted = true
+				node.RoleTokens = api.RoleTokens()
+			}
+			info.Nodes = append(info.Nodes, node)
+		}
+	}
+
+	return info
+}
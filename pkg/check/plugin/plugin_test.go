@@ -0,0 +1,163 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+var logger = logging.New(io.Discard, 0, "")
+
+// testCluster implements the parts of the cluster used by the check
+type testCluster struct {
+	orchestration.Cluster
+	group *testNodeGroup
+}
+
+func (c *testCluster) Name() string {
+	return "test"
+}
+
+func (c *testCluster) NodeGroups() map[string]orchestration.NodeGroup {
+	return map[string]orchestration.NodeGroup{"bee": c.group}
+}
+
+func (c *testCluster) NodeGroupsSorted() []string {
+	return []string{"bee"}
+}
+
+// testNodeGroup implements the parts of the node group used by the check
+type testNodeGroup struct {
+	orchestration.NodeGroup
+	nodes map[string]*testNode
+}
+
+func (g *testNodeGroup) NodesSorted() []string {
+	return []string{"bee-0", "bee-1"}
+}
+
+func (g *testNodeGroup) Node(name string) (orchestration.Node, error) {
+	n, ok := g.nodes[name]
+	if !ok {
+		return nil, fmt.Errorf("node %s not found", name)
+	}
+	return n, nil
+}
+
+// testNode implements the parts of the node used by the check
+type testNode struct {
+	orchestration.Node
+	client *bee.Client
+}
+
+func (n *testNode) Client() *bee.Client {
+	return n.client
+}
+
+func (n *testNode) Config() *orchestration.Config {
+	return &orchestration.Config{FullNode: true}
+}
+
+func newTestNode(t *testing.T, name string, o bee.ClientOptions) *testNode {
+	t.Helper()
+
+	var err error
+	if o.APIURL, err = url.Parse(fmt.Sprintf("https://%s.localhost", name)); err != nil {
+		t.Fatal(err)
+	}
+	if o.DebugAPIURL, err = url.Parse(fmt.Sprintf("https://%s-debug.localhost", name)); err != nil {
+		t.Fatal(err)
+	}
+
+	return &testNode{client: bee.NewClient(o, logger)}
+}
+
+func TestRunRequest(t *testing.T) {
+	cluster := &testCluster{group: &testNodeGroup{nodes: map[string]*testNode{
+		"bee-0": newTestNode(t, "bee-0", bee.ClientOptions{
+			BearerToken: "secret",
+			CAFile:      "/etc/beekeeper/ca.pem",
+			CertFile:    "/etc/beekeeper/client.pem",
+			KeyFile:     "/etc/beekeeper/client-key.pem",
+		}),
+		"bee-1": newTestNode(t, "bee-1", bee.ClientOptions{
+			APIInsecureTLS:      true,
+			DebugAPIInsecureTLS: true,
+			Restricted:          true,
+		}),
+	}}}
+
+	// the plugin writes the request to the file and passes
+	dir := t.TempDir()
+	requestFile := filepath.Join(dir, "request.json")
+	path := filepath.Join(dir, "plugin")
+	script := "#!/bin/sh\ncat > \"$1\"\necho '{\"result\":{\"status\":\"passed\"}}'\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCheck(logger)
+	if err := c.Run(context.Background(), cluster, Options{
+		Args:    []string{requestFile},
+		Options: map[string]interface{}{"chunks": float64(10)},
+		Path:    path,
+		Seed:    1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(requestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Request
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Request{
+		Cluster: Cluster{
+			Name: "test",
+			Nodes: []Node{
+				{
+					Name:        "bee-0",
+					NodeGroup:   "bee",
+					FullNode:    true,
+					APIURL:      "https://bee-0.localhost/",
+					DebugAPIURL: "https://bee-0-debug.localhost/",
+					BearerToken: "secret",
+					CAFile:      "/etc/beekeeper/ca.pem",
+					CertFile:    "/etc/beekeeper/client.pem",
+					KeyFile:     "/etc/beekeeper/client-key.pem",
+				},
+				{
+					Name:                "bee-1",
+					NodeGroup:           "bee",
+					FullNode:            true,
+					APIURL:              "https://bee-1.localhost/",
+					APIInsecureTLS:      true,
+					DebugAPIURL:         "https://bee-1-debug.localhost/",
+					DebugAPIInsecureTLS: true,
+					Restricted:          true,
+					RoleTokens:          api.RoleTokens(),
+				},
+			},
+		},
+		Options: map[string]interface{}{"chunks": float64(10)},
+		Seed:    1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got request\n%+v\nwant\n%+v", got, want)
+	}
+}
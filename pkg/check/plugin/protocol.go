@@ -0,0 +1,66 @@
+package plugin
+
+import "github.com/ethersphere/beekeeper/pkg/beekeeper"
+
+// The plugin protocol is line delimited JSON over standard streams. Beekeeper
+// starts the plugin executable, writes a single Request to its standard input
+// and closes it. The plugin writes Messages to its standard output, one per
+// line, and reports the outcome with a Message carrying a Result. Lines
+// written to standard error are logged as they are. A plugin that exits with
+// a non-zero status fails the check.
+
+// Request is sent to the plugin on its standard input
+type Request struct {
+	Cluster Cluster                `json:"cluster"`
+	Options map[string]interface{} `json:"options,omitempty"`
+	Seed    int64                  `json:"seed"`
+}
+
+// Cluster holds connection information of the cluster under test
+type Cluster struct {
+	Name  string `json:"name"`
+	Nodes []Node `json:"nodes"`
+}
+
+// Node holds connection information of a cluster node, with credentials
+// Beekeeper uses for its APIs
+type Node struct {
+	Name                string `json:"name"`
+	NodeGroup           string `json:"nodeGroup"`
+	FullNode            bool   `json:"fullNode"`
+	APIURL              string `json:"apiURL"`
+	APIInsecureTLS      bool   `json:"apiInsecureTLS,omitempty"`
+	DebugAPIURL         string `json:"debugAPIURL"`
+	DebugAPIInsecureTLS bool   `json:"debugAPIInsecureTLS,omitempty"`
+	// BearerToken authenticates requests that are not authenticated with a
+	// role token of a restricted node, like to an authenticating ingress
+	BearerToken string `json:"bearerToken,omitempty"`
+	// CAFile is the PEM encoded CA file verifying the node, in addition to
+	// system CAs, CertFile and KeyFile are the client certificate
+	CAFile   string `json:"caFile,omitempty"`
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+	// Restricted nodes authenticate requests with tokens of roles allowed
+	// to call the endpoint, like the maintainer role for admin endpoints
+	Restricted bool              `json:"restricted,omitempty"`
+	RoleTokens map[string]string `json:"roleTokens,omitempty"`
+}
+
+// Message is written by the plugin on its standard output
+type Message struct {
+	Log    *Log              `json:"log,omitempty"`
+	Metric *Metric           `json:"metric,omitempty"`
+	Result *beekeeper.Result `json:"result,omitempty"`
+}
+
+// Log is a log line of the plugin
+type Log struct {
+	Level   string `json:"level"` // debug, info, warning or error
+	Message string `json:"message"`
+}
+
+// Metric is a value reported by the plugin
+type Metric struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
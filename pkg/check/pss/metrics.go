@@ -6,7 +6,10 @@ import (
 )
 
 type metrics struct {
-	SendAndReceiveGauge *prometheus.GaugeVec
+	SendAndReceiveGauge   *prometheus.GaugeVec
+	TargetedSent          *prometheus.CounterVec
+	TargetedLatency       *prometheus.HistogramVec
+	TargetedDeliveryRatio *prometheus.GaugeVec
 }
 
 func newMetrics() metrics {
@@ -21,6 +24,34 @@ func newMetrics() metrics {
 			},
 			[]string{"nodeA", "nodeB"},
 		),
+		TargetedSent: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "targeted_sent_count",
+				Help:      "Number of PSS messages sent with mined targets.",
+			},
+			[]string{"sender", "recipient"},
+		),
+		TargetedLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "targeted_delivery_duration_seconds",
+				Help:      "Duration between sending a PSS message with mined targets and receiving it on the recipient's subscription.",
+				Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+			},
+			[]string{"sender", "recipient"},
+		),
+		TargetedDeliveryRatio: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "targeted_delivery_ratio",
+				Help:      "Ratio of received to sent PSS messages with mined targets.",
+			},
+			[]string{"recipient"},
+		),
 	}
 }
 
@@ -5,41 +5,50 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
-	"net/http"
 	"time"
 
 	"github.com/ethersphere/beekeeper/pkg/bee"
-	"github.com/ethersphere/beekeeper/pkg/bee/api"
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
 	"github.com/ethersphere/beekeeper/pkg/logging"
 	"github.com/ethersphere/beekeeper/pkg/orchestration"
 	"github.com/ethersphere/beekeeper/pkg/random"
-	"github.com/gorilla/websocket"
 )
 
 // Options represents check options
 type Options struct {
-	Count          int64
-	AddressPrefix  int
-	GasPrice       string
-	PostageAmount  int64
-	PostageDepth   uint64
-	PostageLabel   string
-	RequestTimeout time.Duration
-	Seed           int64
+	Count             int64
+	AddressPrefix     int
+	GasPrice          string
+	MessagesPerSender int     // targeted mode only
+	MinDeliveryRatio  float64 // targeted mode only
+	Mode              string
+	PostageAmount     int64
+	PostageDepth      uint64
+	PostageLabel      string
+	ReceiveTimeout    time.Duration // targeted mode only
+	RequestTimeout    time.Duration
+	Seed              int64
+	Senders           int // targeted mode only
+	TargetCount       int // targeted mode only
 }
 
 // NewDefaultOptions returns new default options
 func NewDefaultOptions() Options {
 	return Options{
-		Count:          1,
-		AddressPrefix:  1,
-		GasPrice:       "",
-		PostageAmount:  1,
-		PostageDepth:   16,
-		PostageLabel:   "test-label",
-		RequestTimeout: 5 * time.Minute,
-		Seed:           random.Int64(),
+		Count:             1,
+		AddressPrefix:     1,
+		GasPrice:          "",
+		MessagesPerSender: 10,
+		MinDeliveryRatio:  1,
+		Mode:              "default",
+		PostageAmount:     1,
+		PostageDepth:      16,
+		PostageLabel:      "test-label",
+		ReceiveTimeout:    time.Minute,
+		RequestTimeout:    5 * time.Minute,
+		Seed:              random.Int64(),
+		Senders:           3,
+		TargetCount:       4,
 	}
 }
 
@@ -66,6 +75,10 @@ func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts int
 		return fmt.Errorf("invalid options type")
 	}
 
+	if o.Mode == "targeted" {
+		return c.targetedCheck(ctx, cluster, o)
+	}
+
 	clients, err := cluster.NodesClients(ctx)
 	if err != nil {
 		return err
@@ -130,7 +143,8 @@ func (c *Check) testPss(nodeAName, nodeBName string, clients map[string]*bee.Cli
 	}
 	c.logger.Infof("node %s: batched id %s", nodeAName, batchID)
 
-	ch, close, err := listenWebsocket(ctx, nodeB.Config().APIURL.Host, nodeB.Config().Restricted, testTopic, c.logger)
+	// the subscription ends with the context
+	sub, err := nodeB.SubscribePSS(ctx, testTopic)
 	if err != nil {
 		cancel()
 		return err
@@ -141,25 +155,26 @@ func (c *Check) testPss(nodeAName, nodeBName string, clients map[string]*bee.Cli
 	tStart := time.Now()
 	err = nodeA.SendPSSMessage(ctx, addrB.Overlay, addrB.PSSPublicKey, testTopic, o.AddressPrefix, testData, batchID)
 	if err != nil {
-		close()
 		cancel()
 		return err
 	}
 
-	msg, ok := <-ch
+	msg, ok := <-sub.C
 	if ok {
-		if msg == string(testData) {
+		if string(msg.Data) == string(testData) {
 			c.logger.Info("pss: websocket connection received correct message")
 			c.metrics.SendAndReceiveGauge.WithLabelValues(nodeAName, nodeBName).Set(time.Since(tStart).Seconds())
 		} else {
 			err = errDataMismatch
 		}
 	} else {
+		if serr := sub.Err(); serr != nil {
+			c.logger.Infof("pss: %v", serr)
+		}
 		err = errWebsocketConnection
 	}
 
 	cancel()
-	close()
 
 	if err != nil {
 		return err
@@ -167,36 +182,3 @@ func (c *Check) testPss(nodeAName, nodeBName string, clients map[string]*bee.Cli
 
 	return nil
 }
-
-func listenWebsocket(ctx context.Context, host string, setHeader bool, topic string, logger logging.Logger) (<-chan string, func(), error) {
-	dialer := &websocket.Dialer{
-		Proxy:            http.ProxyFromEnvironment,
-		HandshakeTimeout: 45 * time.Second,
-	}
-
-	var header http.Header
-	if setHeader {
-		header = make(http.Header)
-		header.Add("Authorization", "Bearer "+api.TokenConsumer)
-	}
-
-	ws, _, err := dialer.DialContext(ctx, fmt.Sprintf("ws://%s/pss/subscribe/%s", host, topic), header)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	ch := make(chan string)
-
-	go func() {
-		_, data, err := ws.ReadMessage()
-		if err != nil {
-			logger.Infof("pss: websocket error %v", err)
-			close(ch)
-			return
-		}
-
-		ch <- string(data)
-	}()
-
-	return ch, func() { ws.Close() }, nil
-}
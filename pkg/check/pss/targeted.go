@@ -0,0 +1,210 @@
+package pss
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// maxTargetBytes is the maximum length of a trojan target accepted by bee
+const maxTargetBytes = 3
+
+// targetedCheck sends PSS messages to a recipient from the nodes most distant
+// from it, with trojan targets mined for the recipient's neighborhood, and
+// measures delivery ratio and latency of the messages received on the
+// recipient's websocket subscription
+func (c *Check) targetedCheck(ctx context.Context, cluster orchestration.Cluster, o Options) error {
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	overlays, err := cluster.FlattenOverlays(ctx)
+	if err != nil {
+		return err
+	}
+
+	fullNodeNames := cluster.FullNodeNames()
+	if len(fullNodeNames) < 2 {
+		return fmt.Errorf("pss test require at least 2 full nodes")
+	}
+
+	for i := 0; i < int(o.Count); i++ {
+		c.logger.Infof("pss: targeted test %d of %d", i+1, o.Count)
+
+		recipientName := pickAtRandom(rnd, fullNodeNames, "")
+		recipient := clients[recipientName]
+
+		addr, err := recipient.Addresses(ctx)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", recipientName, err)
+		}
+
+		topology, err := recipient.Topology(ctx)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", recipientName, err)
+		}
+
+		targets := mineTargets(rnd, addr.Overlay, topology.Depth, o.TargetCount)
+		c.logger.Infof("pss: recipient %s depth %d targets %v", recipientName, topology.Depth, targets)
+
+		senders := mostDistant(addr.Overlay, overlays, fullNodeNames, recipientName, o.Senders)
+
+		if err := c.testTargeted(ctx, clients, recipientName, addr.PSSPublicKey, senders, targets, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Check) testTargeted(ctx context.Context, clients map[string]*bee.Client, recipientName, publicKey string, senders, targets []string, o Options) error {
+	ctx, cancel := context.WithTimeout(ctx, o.RequestTimeout)
+	defer cancel()
+
+	recipient := clients[recipientName]
+	topic := fmt.Sprintf("%s-targeted-%d", testTopic, time.Now().UnixNano())
+
+	// the subscription ends with the context
+	sub, err := recipient.SubscribePSS(ctx, topic)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", recipientName, err)
+	}
+
+	sent := make(map[string]time.Time) // message id -> time it was sent
+
+	for _, sender := range senders {
+		client := clients[sender]
+
+		batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+		if err != nil {
+			return fmt.Errorf("node %s: batched id %w", sender, err)
+		}
+		c.logger.Infof("node %s: batched id %s", sender, batchID)
+
+		for j := 0; j < o.MessagesPerSender; j++ {
+			id := sender + ":" + strconv.Itoa(j)
+
+			sent[id] = time.Now()
+			if err := client.SendPSSMessageToTargets(ctx, targets, publicKey, topic, []byte(id), batchID); err != nil {
+				return fmt.Errorf("node %s: send message: %w", sender, err)
+			}
+			c.metrics.TargetedSent.WithLabelValues(sender, recipientName).Inc()
+		}
+	}
+	c.logger.Infof("pss: sent %d messages to node %s", len(sent), recipientName)
+
+	received := make(map[string]struct{})
+	timeout := time.After(o.ReceiveTimeout)
+
+receive:
+	for len(received) < len(sent) {
+		select {
+		case <-timeout:
+			break receive
+		case msg, ok := <-sub.C:
+			if !ok {
+				if err := sub.Err(); err != nil {
+					c.logger.Infof("pss: node %s: %v", recipientName, err)
+				}
+				break receive
+			}
+
+			id := string(msg.Data)
+			start, ok := sent[id]
+			if !ok {
+				return fmt.Errorf("node %s: received unknown message %q", recipientName, id)
+			}
+			if _, ok := received[id]; ok {
+				c.logger.Infof("pss: node %s received duplicate message %s", recipientName, id)
+				continue
+			}
+			received[id] = struct{}{}
+
+			sender := id[:strings.LastIndex(id, ":")]
+			c.metrics.TargetedLatency.WithLabelValues(sender, recipientName).Observe(msg.ReceivedAt.Sub(start).Seconds())
+		}
+	}
+
+	ratio := float64(len(received)) / float64(len(sent))
+	c.metrics.TargetedDeliveryRatio.WithLabelValues(recipientName).Set(ratio)
+	c.logger.Infof("pss: node %s received %d of %d messages, delivery ratio %.2f", recipientName, len(received), len(sent), ratio)
+
+	if ratio < o.MinDeliveryRatio {
+		return fmt.Errorf("pss: node %s delivery ratio %.2f below %.2f", recipientName, ratio, o.MinDeliveryRatio)
+	}
+
+	return nil
+}
+
+// mineTargets returns n distinct hex encoded trojan targets that fall into the
+// neighborhood of the overlay at the given depth
+func mineTargets(r *rand.Rand, overlay swarm.Address, depth, n int) []string {
+	size := (depth + 7) / 8
+	if size < 1 {
+		size = 1
+	}
+	if size > maxTargetBytes {
+		size = maxTargetBytes
+	}
+	bits := depth
+	if bits > size*8 {
+		bits = size * 8
+	}
+
+	seen := make(map[string]struct{})
+	var targets []string
+
+	// there are at most 2^(size*8-bits) distinct targets in the neighborhood
+	for attempts := 0; len(targets) < n && attempts < 100*n; attempts++ {
+		t := make([]byte, size)
+		r.Read(t)
+		for i := 0; i < bits; i++ {
+			mask := byte(1) << (7 - uint(i%8))
+			t[i/8] = t[i/8]&^mask | overlay.Bytes()[i/8]&mask
+		}
+
+		h := hex.EncodeToString(t)
+		if _, ok := seen[h]; ok {
+			continue
+		}
+		seen[h] = struct{}{}
+		targets = append(targets, h)
+	}
+
+	return targets
+}
+
+// mostDistant returns up to n names of nodes with the lowest proximity to
+// the overlay, skipping the given node
+func mostDistant(overlay swarm.Address, overlays map[string]swarm.Address, names []string, skip string, n int) []string {
+	var candidates []string
+	for _, name := range names {
+		if name != skip {
+			candidates = append(candidates, name)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return swarm.Proximity(overlay.Bytes(), overlays[candidates[i]].Bytes()) < swarm.Proximity(overlay.Bytes(), overlays[candidates[j]].Bytes())
+	})
+
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+
+	return candidates
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/assert"
 	"github.com/ethersphere/beekeeper/pkg/bee"
 	"github.com/ethersphere/beekeeper/pkg/bee/api"
 	"github.com/ethersphere/beekeeper/pkg/logging"
@@ -13,7 +14,8 @@ import (
 	"github.com/ethersphere/beekeeper/pkg/random"
 )
 
-// checkChunks uploads given chunks on cluster and checks pushsync ability of the cluster
+// checkChunks uploads given chunks on cluster and checks pushsync ability of the cluster.
+// Chunks that are not synced or replicated are reported together at the end.
 func checkChunks(ctx context.Context, c orchestration.Cluster, o Options, l logging.Logger) error {
 	l.Info("running pushsync (chunks mode)")
 	rnds := random.PseudoGenerators(o.Seed, o.UploadNodeCount)
@@ -29,6 +31,7 @@ func checkChunks(ctx context.Context, c orchestration.Cluster, o Options, l logg
 	}
 
 	sortedNodes := c.FullNodeNames()
+	a := assert.New()
 
 	for i := 0; i < o.UploadNodeCount; i++ {
 
@@ -42,7 +45,6 @@ func checkChunks(ctx context.Context, c orchestration.Cluster, o Options, l logg
 		}
 		l.Infof("node %s: batch id %s", nodeName, batchID)
 
-	testCases:
 		for j := 0; j < o.ChunksPerNode; j++ {
 			chunk, err := bee.NewRandomChunk(rnds[i], l)
 			if err != nil {
@@ -63,12 +65,12 @@ func checkChunks(ctx context.Context, c orchestration.Cluster, o Options, l logg
 			l.Infof("closest node %s overlay %s", closestName, closestAddress)
 
 			time.Sleep(o.RetryDelay)
-			synced, err := clients[closestName].HasChunk(ctx, ref)
+			synced, err := clients[closestName].ProbeChunk(ctx, ref)
 			if err != nil {
 				return fmt.Errorf("node %s: %w", nodeName, err)
 			}
-			if !synced {
-				return fmt.Errorf("node %s chunk %s not found in the closest node %s", nodeName, ref.String(), closestAddress)
+			if !a.True(synced, "node %s chunk %s not found in the closest node %s", nodeName, ref.String(), closestAddress) {
+				continue
 			}
 
 			l.Infof("node %s chunk %s found in the closest node %s", nodeName, ref.String(), closestAddress)
@@ -79,6 +81,7 @@ func checkChunks(ctx context.Context, c orchestration.Cluster, o Options, l logg
 			}
 
 			skipPeers := []swarm.Address{closestAddress, uploaderAddr}
+			replicated := false
 			// chunk should be replicated at least once either during forwarding or after storing
 			for range overlays {
 				name, address, err := chunk.ClosestNodeFromMap(overlays, skipPeers...)
@@ -86,19 +89,20 @@ func checkChunks(ctx context.Context, c orchestration.Cluster, o Options, l logg
 				if err != nil {
 					continue
 				}
-				synced, err = clients[name].HasChunk(ctx, ref)
+				synced, err = clients[name].ProbeChunk(ctx, ref)
 				if err != nil {
 					continue
 				}
 				if synced {
 					l.Infof("node %s chunk %s was replicated to node %s", name, ref.String(), address.String())
-					continue testCases
+					replicated = true
+					break
 				}
 			}
 
-			return fmt.Errorf("node %s chunk %s not replicated", nodeName, ref.String())
+			a.True(replicated, "node %s chunk %s not replicated", nodeName, ref.String())
 		}
 	}
 
-	return nil
+	return a.Err()
 }
@@ -84,7 +84,7 @@ func checkLightChunks(ctx context.Context, cluster orchestration.Cluster, o Opti
 
 			var synced bool
 			for i := 0; i < 3; i++ {
-				synced, _ = clients[closestName].HasChunk(ctx, ref)
+				synced, _ = clients[closestName].ProbeChunk(ctx, ref)
 				if synced {
 					break
 				}
@@ -106,7 +106,7 @@ func checkLightChunks(ctx context.Context, cluster orchestration.Cluster, o Opti
 					continue
 				}
 
-				synced, err = clients[name].HasChunk(ctx, ref)
+				synced, err = clients[name].ProbeChunk(ctx, ref)
 				if err != nil {
 					continue
 				}
@@ -0,0 +1,95 @@
+package pushsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/assert"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// checkPresignedChunks stamps chunks on nodes that own the batch and uploads
+// them with the pre-signed stamps through other nodes, which do not own the
+// batch, and checks that chunks are pushed to their closest nodes. Nodes have
+// to support pre-signed stamps, with the /envelope endpoint, added after Bee
+// 1.13.
+func checkPresignedChunks(ctx context.Context, c orchestration.Cluster, o Options, l logging.Logger) error {
+	l.Info("running pushsync (presigned-chunks mode)")
+	rnds := random.PseudoGenerators(o.Seed, o.UploadNodeCount)
+	l.Infof("seed: %d", o.Seed)
+
+	overlays, err := c.FlattenOverlays(ctx, o.ExcludeNodeGroups...)
+	if err != nil {
+		return err
+	}
+	clients, err := c.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	sortedNodes := c.FullNodeNames()
+	if len(sortedNodes) < 2 {
+		return fmt.Errorf("presigned chunks require at least 2 full nodes, got %d", len(sortedNodes))
+	}
+	a := assert.New()
+
+	for i := 0; i < o.UploadNodeCount; i++ {
+		ownerName := sortedNodes[i%len(sortedNodes)]
+		gatewayName := sortedNodes[(i+1)%len(sortedNodes)]
+		owner, gateway := clients[ownerName], clients[gatewayName]
+
+		batchID, err := owner.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+		if err != nil {
+			return fmt.Errorf("node %s: batch id %w", ownerName, err)
+		}
+		l.Infof("node %s: batch id %s", ownerName, batchID)
+
+		for j := 0; j < o.ChunksPerNode; j++ {
+			chunk, err := bee.NewRandomChunk(rnds[i], l)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", ownerName, err)
+			}
+
+			stamp, err := owner.StampChunk(ctx, batchID, chunk.Address())
+			if err != nil {
+				return fmt.Errorf("node %s: %w", ownerName, err)
+			}
+
+			ref, err := gateway.UploadChunk(ctx, chunk.Data(), api.UploadOptions{Stamp: stamp})
+			if err != nil {
+				return fmt.Errorf("node %s: upload chunk stamped by node %s: %w", gatewayName, ownerName, err)
+			}
+			if !a.True(ref.Equal(chunk.Address()), "node %s returned reference %s for chunk %s", gatewayName, ref, chunk.Address()) {
+				continue
+			}
+			l.Infof("uploaded chunk %s stamped by node %s to node %s", ref, ownerName, gatewayName)
+
+			closestName, closestAddress, err := chunk.ClosestNodeFromMap(overlays)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", gatewayName, err)
+			}
+
+			var synced bool
+			for r := 0; r <= o.Retries && !synced; r++ {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(o.RetryDelay):
+				}
+				if synced, err = clients[closestName].ProbeChunk(ctx, ref); err != nil {
+					return fmt.Errorf("node %s: %w", closestName, err)
+				}
+			}
+			if a.True(synced, "node %s chunk %s not found in the closest node %s", gatewayName, ref, closestAddress) {
+				l.Infof("node %s chunk %s found in the closest node %s", gatewayName, ref, closestAddress)
+			}
+		}
+	}
+
+	return a.Err()
+}
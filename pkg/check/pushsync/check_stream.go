@@ -0,0 +1,94 @@
+package pushsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/assert"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// checkStreamChunks uploads chunks on chunk streams, without a request for
+// every chunk, and checks that they are pushed to their closest nodes.
+func checkStreamChunks(ctx context.Context, c orchestration.Cluster, o Options, l logging.Logger) error {
+	l.Info("running pushsync (stream-chunks mode)")
+	rnds := random.PseudoGenerators(o.Seed, o.UploadNodeCount)
+	l.Infof("seed: %d", o.Seed)
+
+	overlays, err := c.FlattenOverlays(ctx, o.ExcludeNodeGroups...)
+	if err != nil {
+		return err
+	}
+	clients, err := c.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	sortedNodes := c.FullNodeNames()
+	a := assert.New()
+
+	for i := 0; i < o.UploadNodeCount; i++ {
+		nodeName := sortedNodes[i]
+		uploader := clients[nodeName]
+
+		batchID, err := uploader.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+		if err != nil {
+			return fmt.Errorf("node %s: batch id %w", nodeName, err)
+		}
+		l.Infof("node %s: batch id %s", nodeName, batchID)
+
+		stream, err := uploader.StreamChunks(ctx, api.ChunkStreamOptions{UploadOptions: api.UploadOptions{BatchID: batchID}})
+		if err != nil {
+			return fmt.Errorf("node %s: %w", nodeName, err)
+		}
+
+		chunks := make([]bee.Chunk, 0, o.ChunksPerNode)
+		start := time.Now()
+		for j := 0; j < o.ChunksPerNode; j++ {
+			chunk, err := bee.NewRandomChunk(rnds[i], l)
+			if err != nil {
+				_ = stream.Close(ctx)
+				return fmt.Errorf("node %s: %w", nodeName, err)
+			}
+			if err := stream.Send(ctx, chunk.Data()); err != nil {
+				_ = stream.Close(ctx)
+				return fmt.Errorf("node %s: send chunk %s: %w", nodeName, chunk.Address(), err)
+			}
+			chunks = append(chunks, chunk)
+		}
+		if err := stream.Close(ctx); err != nil {
+			return fmt.Errorf("node %s: %d of %d chunks stored: %w", nodeName, stream.Acknowledged(), len(chunks), err)
+		}
+		l.Infof("uploaded %d chunks to node %s on a chunk stream in %s", len(chunks), nodeName, time.Since(start))
+
+		// chunks are pushed while the stream is open, they are waited for
+		// together and only missing ones are retried
+		time.Sleep(o.RetryDelay)
+		for _, chunk := range chunks {
+			closestName, closestAddress, err := chunk.ClosestNodeFromMap(overlays)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", nodeName, err)
+			}
+
+			var synced bool
+			for r := 0; r <= o.Retries && !synced; r++ {
+				if r > 0 {
+					time.Sleep(o.RetryDelay)
+				}
+				if synced, err = clients[closestName].ProbeChunk(ctx, chunk.Address()); err != nil {
+					return fmt.Errorf("node %s: %w", closestName, err)
+				}
+			}
+			if a.True(synced, "node %s chunk %s not found in the closest node %s", nodeName, chunk.Address(), closestAddress) {
+				l.Infof("node %s chunk %s found in the closest node %s", nodeName, chunk.Address(), closestAddress)
+			}
+		}
+	}
+
+	return a.Err()
+}
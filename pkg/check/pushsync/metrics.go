@@ -8,7 +8,7 @@ import (
 type metrics struct {
 	UploadedCounter     *prometheus.CounterVec
 	UploadTimeGauge     *prometheus.GaugeVec
-	UploadTimeHistogram prometheus.Histogram
+	UploadTimeHistogram *prometheus.HistogramVec
 	SyncedCounter       *prometheus.CounterVec
 	NotSyncedCounter    *prometheus.CounterVec
 }
@@ -34,13 +34,14 @@ func newMetrics() metrics {
 			},
 			[]string{"node", "chunk"},
 		),
-		UploadTimeHistogram: prometheus.NewHistogram(
+		UploadTimeHistogram: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Namespace: m.Namespace,
 				Subsystem: subsystem,
 				Name:      "chunk_upload_seconds",
 				Help:      "Chunk upload duration Histogram.",
 			},
+			[]string{"uploader", "iteration"},
 		),
 		SyncedCounter: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -9,6 +9,7 @@ import (
 	"github.com/ethersphere/beekeeper/pkg/bee/api"
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
 	"github.com/ethersphere/beekeeper/pkg/logging"
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
 	"github.com/ethersphere/beekeeper/pkg/orchestration"
 	"github.com/ethersphere/beekeeper/pkg/random"
 )
@@ -73,6 +74,10 @@ func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts int
 		return checkChunks(ctx, cluster, o, c.logger)
 	case "light-chunks":
 		return checkLightChunks(ctx, cluster, o, c.logger)
+	case "presigned-chunks":
+		return checkPresignedChunks(ctx, cluster, o, c.logger)
+	case "stream-chunks":
+		return checkStreamChunks(ctx, cluster, o, c.logger)
 	default:
 		return c.defaultCheck(ctx, cluster, o)
 	}
@@ -121,7 +126,7 @@ func (c *Check) defaultCheck(ctx context.Context, cluster orchestration.Cluster,
 
 			c.metrics.UploadedCounter.WithLabelValues(overlays[nodeName].String()).Inc()
 			c.metrics.UploadTimeGauge.WithLabelValues(overlays[nodeName].String(), addr.String()).Set(d0.Seconds())
-			c.metrics.UploadTimeHistogram.Observe(d0.Seconds())
+			c.metrics.UploadTimeHistogram.WithLabelValues(nodeName, m.IterationLabel(j)).Observe(d0.Seconds())
 
 			closestName, closestAddress, err := chunk.ClosestNodeFromMap(overlays)
 			if err != nil {
@@ -139,7 +144,7 @@ func (c *Check) defaultCheck(ctx context.Context, cluster orchestration.Cluster,
 
 				time.Sleep(o.RetryDelay)
 				node := clients[closestName]
-				synced, err := node.HasChunk(ctx, addr)
+				synced, err := node.ProbeChunk(ctx, addr)
 				if err != nil {
 					return fmt.Errorf("node %s: %w", nodeName, err)
 				}
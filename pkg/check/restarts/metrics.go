@@ -0,0 +1,52 @@
+package restarts
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Restarts *prometheus.GaugeVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_restarts"
+	return metrics{
+		Restarts: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "node_restarts",
+				Help:      "Number of Bee container restarts.",
+			},
+			[]string{"node", "reason"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
+
+type watcherMetrics struct {
+	PhaseRestarts *prometheus.CounterVec
+}
+
+func newWatcherMetrics() watcherMetrics {
+	subsystem := "restarts_watcher"
+	return watcherMetrics{
+		PhaseRestarts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "phase_restarts_count",
+				Help:      "Number of Bee container restarts observed during a phase.",
+			},
+			[]string{"node", "phase", "reason"},
+		),
+	}
+}
+
+func (w *Watcher) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(w.metrics)
+}
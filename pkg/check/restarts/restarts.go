@@ -0,0 +1,100 @@
+package restarts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// Options represents check options
+type Options struct {
+	MaxRestarts  int32
+	FailOnOOM    bool
+	FailOnCrash  bool
+	IgnoreGroups []string
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		MaxRestarts: 0,
+		FailOnOOM:   true,
+		FailOnCrash: true,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run fails if any of the nodes restarted more than allowed, was OOM killed
+// or is crashlooping since it was started.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	restarts, err := cluster.Restarts(ctx)
+	if err != nil {
+		return fmt.Errorf("restarts: %w", err)
+	}
+
+	var failed []string
+	for group, nodes := range restarts {
+		if contains(o.IgnoreGroups, group) {
+			continue
+		}
+
+		for name, r := range nodes {
+			c.metrics.Restarts.WithLabelValues(name, r.Reason).Set(float64(r.Count))
+			if r.Count == 0 && !r.CrashLoop {
+				continue
+			}
+
+			c.logger.Infof("node %s: restarts %d, last termination reason %q, crashloop %t", name, r.Count, r.Reason, r.CrashLoop)
+
+			switch {
+			case o.FailOnOOM && r.Reason == ReasonOOMKilled,
+				o.FailOnCrash && r.CrashLoop,
+				r.Count > o.MaxRestarts:
+				failed = append(failed, name)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("nodes restarted: %v", failed)
+	}
+
+	c.logger.Info("no unexpected node restarts")
+
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package restarts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// ReasonOOMKilled is the container termination reason reported by Kubernetes
+// when the container exceeded its memory limit.
+const ReasonOOMKilled = "OOMKilled"
+
+// Event represents a node restart observed during a phase.
+type Event struct {
+	Node      string
+	Phase     string
+	Workload  string
+	Restarts  int32
+	Reason    string
+	CrashLoop bool
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("node %s restarted %d times during phase %s (workload: %s), last termination reason %q, crashloop %t", e.Node, e.Restarts, e.Phase, e.Workload, e.Reason, e.CrashLoop)
+}
+
+// OOM reports whether the node was OOM killed.
+func (e Event) OOM() bool {
+	return e.Reason == ReasonOOMKilled
+}
+
+// Watcher correlates node restarts with phases of a run. A phase is started
+// with Begin, which snapshots restart counts of all nodes, and finished with
+// End, which reports all nodes that restarted or are crashlooping since.
+// Failures of nodes watched with WatchFailures cancel phases as they happen
+// with FailFast.
+type Watcher struct {
+	cluster orchestration.Cluster
+	metrics watcherMetrics
+	logger  logging.Logger
+
+	mu       sync.Mutex
+	phase    string
+	workload string
+	baseline orchestration.NodeGroupRestarts
+	failFast *failFast
+}
+
+// failFast is the phase canceled by the first failure of a node
+type failFast struct {
+	phase  string
+	cancel context.CancelCauseFunc
+}
+
+// NewWatcher returns new restarts watcher
+func NewWatcher(cluster orchestration.Cluster, logger logging.Logger) *Watcher {
+	return &Watcher{
+		cluster: cluster,
+		metrics: newWatcherMetrics(),
+		logger:  logger,
+	}
+}
+
+// Begin starts a new phase. Workload describes the load applied to the
+// cluster during the phase and is recorded with every event.
+func (w *Watcher) Begin(ctx context.Context, phase, workload string) error {
+	baseline, err := w.cluster.FlattenRestarts(ctx)
+	if err != nil {
+		return fmt.Errorf("restarts: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.phase = phase
+	w.workload = workload
+	w.baseline = baseline
+
+	return nil
+}
+
+// WatchFailures watches failures of nodes, OOM kills and crashloops, until
+// the context is done, so that phases started with FailFast fail as soon as
+// a node fails. It returns orchestration.ErrNotSupported if the orchestrator
+// does not watch failures.
+func (w *Watcher) WatchFailures(ctx context.Context) error {
+	failures, err := w.cluster.WatchFailures(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for f := range failures {
+			w.mu.Lock()
+			ff := w.failFast
+			w.mu.Unlock()
+			if ff == nil {
+				continue
+			}
+			w.logger.Errorf("phase %s: %v", ff.phase, f)
+			ff.cancel(f)
+		}
+	}()
+
+	return nil
+}
+
+// FailFast cancels the phase with the first failure of a node watched by
+// WatchFailures, with the failure as the cause, until the returned function
+// is called
+func (w *Watcher) FailFast(phase string, cancel context.CancelCauseFunc) (stop func()) {
+	ff := &failFast{phase: phase, cancel: cancel}
+
+	w.mu.Lock()
+	w.failFast = ff
+	w.mu.Unlock()
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.failFast == ff {
+			w.failFast = nil
+		}
+	}
+}
+
+// End finishes the current phase and returns restart events observed during it.
+func (w *Watcher) End(ctx context.Context) (events []Event, err error) {
+	current, err := w.cluster.FlattenRestarts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("restarts: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for name, r := range current {
+		delta := r.Count - w.baseline[name].Count
+		if delta < 0 { // pod was recreated, restart count starts from zero
+			delta = r.Count
+		}
+		if delta == 0 && !r.CrashLoop {
+			continue
+		}
+
+		e := Event{
+			Node:      name,
+			Phase:     w.phase,
+			Workload:  w.workload,
+			Restarts:  delta,
+			Reason:    r.Reason,
+			CrashLoop: r.CrashLoop,
+		}
+		events = append(events, e)
+
+		w.metrics.PhaseRestarts.WithLabelValues(name, w.phase, r.Reason).Add(float64(delta))
+		w.logger.Warning(e.String())
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Node < events[j].Node
+	})
+
+	w.baseline = current
+
+	return events, nil
+}
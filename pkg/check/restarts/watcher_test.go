@@ -0,0 +1,63 @@
+package restarts_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/check/restarts"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// failuresCluster sends failures of nodes of the channel
+type failuresCluster struct {
+	orchestration.Cluster
+	failures chan orchestration.NodeFailure
+}
+
+func (c failuresCluster) WatchFailures(ctx context.Context) (<-chan orchestration.NodeFailure, error) {
+	return c.failures, nil
+}
+
+func TestWatcherFailFast(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cluster := failuresCluster{failures: make(chan orchestration.NodeFailure)}
+	defer close(cluster.failures)
+
+	w := restarts.NewWatcher(cluster, logging.New(io.Discard, 0, ""))
+	if err := w.WatchFailures(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// failures after the phase are ignored, the second failure is received
+	// after the first one is handled
+	stoppedCtx, cancelStopped := context.WithCancelCause(ctx)
+	defer cancelStopped(nil)
+	w.FailFast("pingpong", cancelStopped)()
+	failure := orchestration.NodeFailure{Node: "bee-1", Reason: "CrashLoopBackOff"}
+	cluster.failures <- failure
+	cluster.failures <- failure
+	if stoppedCtx.Err() != nil {
+		t.Error("phase is canceled by a failure after it")
+	}
+
+	phaseCtx, cancelPhase := context.WithCancelCause(ctx)
+	stop := w.FailFast("retrieval", cancelPhase)
+	defer stop()
+	cluster.failures <- failure
+
+	select {
+	case <-phaseCtx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("phase is not canceled")
+	}
+	var got orchestration.NodeFailure
+	if !errors.As(context.Cause(phaseCtx), &got) || got.Node != failure.Node {
+		t.Errorf("got cause %v, want %v", context.Cause(phaseCtx), failure)
+	}
+}
@@ -8,14 +8,19 @@ import (
 type metrics struct {
 	UploadedCounter       *prometheus.CounterVec
 	UploadTimeGauge       *prometheus.GaugeVec
-	UploadTimeHistogram   prometheus.Histogram
+	UploadTimeHistogram   *prometheus.HistogramVec
 	DownloadedCounter     *prometheus.CounterVec
 	DownloadTimeGauge     *prometheus.GaugeVec
-	DownloadTimeHistogram prometheus.Histogram
+	DownloadTimeHistogram *prometheus.HistogramVec
+	DownloadTimeSummary   *prometheus.SummaryVec
+	TTFBSummary           *prometheus.SummaryVec
 	RetrievedCounter      *prometheus.CounterVec
 	NotRetrievedCounter   *prometheus.CounterVec
 }
 
+// percentiles are p50, p95 and p99 objectives with their allowed errors
+var percentiles = map[float64]float64{0.5: 0.05, 0.95: 0.01, 0.99: 0.001}
+
 func newMetrics() metrics {
 	subsystem := "check_retrieval"
 	return metrics{
@@ -37,7 +42,7 @@ func newMetrics() metrics {
 			},
 			[]string{"node", "chunk"},
 		),
-		UploadTimeHistogram: prometheus.NewHistogram(
+		UploadTimeHistogram: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Namespace: m.Namespace,
 				Subsystem: subsystem,
@@ -45,6 +50,7 @@ func newMetrics() metrics {
 				Help:      "Chunk upload duration Histogram.",
 				Buckets:   prometheus.LinearBuckets(0, 0.1, 10),
 			},
+			[]string{"uploader", "iteration"},
 		),
 		DownloadedCounter: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -64,7 +70,7 @@ func newMetrics() metrics {
 			},
 			[]string{"node", "chunk"},
 		),
-		DownloadTimeHistogram: prometheus.NewHistogram(
+		DownloadTimeHistogram: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Namespace: m.Namespace,
 				Subsystem: subsystem,
@@ -72,6 +78,27 @@ func newMetrics() metrics {
 				Help:      "Chunk download duration Histogram.",
 				Buckets:   prometheus.LinearBuckets(0, 0.1, 10),
 			},
+			[]string{"uploader", "downloader", "iteration"},
+		),
+		DownloadTimeSummary: prometheus.NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  m.Namespace,
+				Subsystem:  subsystem,
+				Name:       "chunk_download_duration_summary_seconds",
+				Help:       "Chunk download duration percentiles per downloader node.",
+				Objectives: percentiles,
+			},
+			[]string{"node"},
+		),
+		TTFBSummary: prometheus.NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  m.Namespace,
+				Subsystem:  subsystem,
+				Name:       "chunk_download_ttfb_seconds",
+				Help:       "Chunk download time to first byte percentiles per downloader node.",
+				Objectives: percentiles,
+			},
+			[]string{"node"},
 		),
 		RetrievedCounter: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
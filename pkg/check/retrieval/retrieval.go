@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http/httptrace"
 	"time"
 
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
 	"github.com/ethersphere/beekeeper/pkg/logging"
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
 	"github.com/ethersphere/beekeeper/pkg/orchestration"
 	"github.com/ethersphere/beekeeper/pkg/random"
 	test "github.com/ethersphere/beekeeper/pkg/test"
@@ -97,21 +99,31 @@ func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts int
 
 			c.metrics.UploadedCounter.WithLabelValues(uploader.Overlay).Inc()
 			c.metrics.UploadTimeGauge.WithLabelValues(uploader.Overlay, chunk.AddrString()).Set(d0.Seconds())
-			c.metrics.UploadTimeHistogram.Observe(d0.Seconds())
+			c.metrics.UploadTimeHistogram.WithLabelValues(uploader.Name(), m.IterationLabel(j)).Observe(d0.Seconds())
 
-			// time download
+			// time download, time to first byte is measured separately to
+			// distinguish routing latency from transfer time
+			var ttfb time.Duration
 			t1 := time.Now()
+			trace := &httptrace.ClientTrace{
+				GotFirstResponseByte: func() {
+					ttfb = time.Since(t1)
+				},
+			}
 
-			data, err := lastBee.DownloadChunk(ctx, chunk.Addr())
+			data, err := lastBee.DownloadChunk(httptrace.WithClientTrace(ctx, trace), chunk.Addr())
 			if err != nil {
 				return fmt.Errorf("node %s: %w", lastBee.Name(), err)
 			}
 
 			d1 := time.Since(t1)
 
+			c.metrics.TTFBSummary.WithLabelValues(lastBee.Name()).Observe(ttfb.Seconds())
+			c.metrics.DownloadTimeSummary.WithLabelValues(lastBee.Name()).Observe(d1.Seconds())
+
 			c.metrics.DownloadedCounter.WithLabelValues(uploader.Name()).Inc()
 			c.metrics.DownloadTimeGauge.WithLabelValues(uploader.Name(), chunk.AddrString()).Set(d1.Seconds())
-			c.metrics.DownloadTimeHistogram.Observe(d1.Seconds())
+			c.metrics.DownloadTimeHistogram.WithLabelValues(uploader.Name(), lastBee.Name(), m.IterationLabel(j)).Observe(d1.Seconds())
 
 			if !chunk.Equals(data) {
 				c.metrics.NotRetrievedCounter.WithLabelValues(uploader.Name()).Inc()
@@ -123,7 +135,7 @@ func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts int
 			}
 
 			c.metrics.RetrievedCounter.WithLabelValues(uploader.Name()).Inc()
-			c.logger.Infof("Node %s. Chunk %d retrieved successfully. Node: %s Chunk: %s", lastBee.Name(), j, uploader.Name(), chunk.AddrString())
+			c.logger.Infof("Node %s. Chunk %d retrieved successfully in %s, time to first byte %s. Node: %s Chunk: %s", lastBee.Name(), j, d1, ttfb, uploader.Name(), chunk.AddrString())
 		}
 	}
 
@@ -0,0 +1,49 @@
+package rollingrestart
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Uploads         *prometheus.CounterVec
+	Retrievals      *prometheus.CounterVec
+	RestartDuration *prometheus.GaugeVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_rollingrestart"
+	return metrics{
+		Uploads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "uploads_count",
+				Help:      "Number of uploads during rolling restart by result.",
+			},
+			[]string{"result"},
+		),
+		Retrievals: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "retrievals_count",
+				Help:      "Number of retrievals of acknowledged uploads by result.",
+			},
+			[]string{"result"},
+		),
+		RestartDuration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "restart_duration_seconds",
+				Help:      "Time from stopping a node until it is ready again.",
+			},
+			[]string{"node"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
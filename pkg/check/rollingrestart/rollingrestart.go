@@ -0,0 +1,302 @@
+package rollingrestart
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/chaos"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	ContentSize      int64
+	GasPrice         string
+	MaxHungUploads   int // number of uploads allowed to hang until UploadTimeout
+	PostageAmount    int64
+	PostageDepth     uint64
+	PostageLabel     string
+	ReadyTimeout     time.Duration // time a restarted node has to become ready
+	RestartGroups    []string      // node groups to roll, all groups if empty
+	RetrievalRetries int
+	RetryDelay       time.Duration
+	Seed             int64
+	StopDelay        time.Duration // time a node is kept stopped
+	UploadInterval   time.Duration
+	UploadNode       string
+	UploadTimeout    time.Duration
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		ContentSize:      1024 * 100, // 100kb
+		GasPrice:         "",
+		MaxHungUploads:   0,
+		PostageAmount:    1000,
+		PostageDepth:     20,
+		PostageLabel:     "test-label",
+		ReadyTimeout:     5 * time.Minute,
+		RetrievalRetries: 5,
+		RetryDelay:       5 * time.Second,
+		Seed:             random.Int64(),
+		StopDelay:        10 * time.Second,
+		UploadInterval:   time.Second,
+		UploadTimeout:    time.Minute,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// upload is content acknowledged by the uploader
+type upload struct {
+	ref  swarm.Address
+	data []byte
+}
+
+// Run continuously uploads content to a node while all other nodes are
+// restarted one by one. Uploads must either succeed or fail with an error
+// within the upload timeout, and all acknowledged content must be retrievable
+// from the cluster once all nodes are back.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	uploaderName := o.UploadNode
+	if uploaderName == "" {
+		fullNodes := cluster.FullNodeNames()
+		if len(fullNodes) < 2 {
+			return errors.New("rolling restart check requires at least 2 full nodes")
+		}
+		sort.Strings(fullNodes)
+		uploaderName = fullNodes[0]
+	}
+	uploader, ok := clients[uploaderName]
+	if !ok {
+		return fmt.Errorf("upload node %s not found", uploaderName)
+	}
+
+	batchID, err := uploader.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: batch id %w", uploaderName, err)
+	}
+	c.logger.Infof("node %s: batch id %s", uploaderName, batchID)
+
+	var (
+		mu       sync.Mutex
+		acked    []upload
+		failed   int
+		hung     int
+		stop     = make(chan struct{})
+		uploadWG sync.WaitGroup
+	)
+
+	uploadWG.Add(1)
+	go func() {
+		defer uploadWG.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stop:
+				return
+			case <-time.After(o.UploadInterval):
+			}
+
+			data := make([]byte, o.ContentSize)
+			if _, err := rnd.Read(data); err != nil {
+				c.logger.Errorf("random data: %v", err)
+				return
+			}
+
+			uctx, cancel := context.WithTimeout(ctx, o.UploadTimeout)
+			ref, err := uploader.UploadBytes(uctx, data, api.UploadOptions{BatchID: batchID})
+			timedOut := errors.Is(uctx.Err(), context.DeadlineExceeded)
+			cancel()
+
+			mu.Lock()
+			switch {
+			case err == nil:
+				acked = append(acked, upload{ref: ref, data: data})
+				c.metrics.Uploads.WithLabelValues("acked").Inc()
+			case timedOut:
+				hung++
+				c.metrics.Uploads.WithLabelValues("hung").Inc()
+				c.logger.Warningf("node %s: upload did not finish in %s", uploaderName, o.UploadTimeout)
+			default:
+				failed++
+				c.metrics.Uploads.WithLabelValues("failed").Inc()
+				c.logger.Infof("node %s: upload failed cleanly: %v", uploaderName, err)
+			}
+			mu.Unlock()
+		}
+	}()
+
+	rollErr := c.roll(ctx, cluster, uploaderName, o)
+
+	close(stop)
+	uploadWG.Wait()
+
+	if rollErr != nil {
+		return fmt.Errorf("rolling restart: %w", rollErr)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	c.logger.Infof("uploads during rolling restart: %d acknowledged, %d failed, %d hung", len(acked), failed, hung)
+	if hung > o.MaxHungUploads {
+		return fmt.Errorf("%d uploads hung during rolling restart, allowed %d", hung, o.MaxHungUploads)
+	}
+	if len(acked) == 0 {
+		return errors.New("no upload was acknowledged during rolling restart")
+	}
+
+	// all acknowledged content must be retrievable from a node that did not
+	// upload it
+	var downloaders []string
+	for name := range clients {
+		if name != uploaderName {
+			downloaders = append(downloaders, name)
+		}
+	}
+	sort.Strings(downloaders)
+	if len(downloaders) == 0 {
+		return errors.New("rolling restart check requires at least 2 nodes")
+	}
+
+	for i, u := range acked {
+		name := downloaders[i%len(downloaders)]
+		if err := c.retrieve(ctx, clients[name], name, u, o); err != nil {
+			return err
+		}
+	}
+
+	c.logger.Infof("all %d acknowledged uploads are retrievable", len(acked))
+
+	return nil
+}
+
+// roll stops and starts nodes one by one, waiting for each node to become
+// ready before moving to the next one
+func (c *Check) roll(ctx context.Context, cluster orchestration.Cluster, skip string, o Options) error {
+	groups := cluster.NodeGroups()
+	groupNames := make([]string, 0, len(groups))
+	for name := range groups {
+		if len(o.RestartGroups) == 0 || contains(o.RestartGroups, name) {
+			groupNames = append(groupNames, name)
+		}
+	}
+	sort.Strings(groupNames)
+
+	for _, gName := range groupNames {
+		g := groups[gName]
+		for _, name := range g.NodesSorted() {
+			if name == skip {
+				continue
+			}
+
+			c.logger.Infof("restarting node %s", name)
+			start := time.Now()
+
+			if err := g.StopNode(ctx, name); err != nil {
+				return fmt.Errorf("stop node %s: %w", name, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(o.StopDelay):
+			}
+
+			if err := g.StartNode(ctx, name); err != nil {
+				return fmt.Errorf("start node %s: %w", name, err)
+			}
+
+			if err := chaos.WaitReady(ctx, g, name, o.ReadyTimeout); err != nil {
+				return err
+			}
+
+			c.metrics.RestartDuration.WithLabelValues(name).Set(time.Since(start).Seconds())
+			c.logger.Infof("node %s restarted in %s", name, time.Since(start))
+		}
+	}
+
+	return nil
+}
+
+// retrieve downloads acknowledged content and compares it to the uploaded data
+func (c *Check) retrieve(ctx context.Context, client *bee.Client, name string, u upload, o Options) (err error) {
+	var data []byte
+	for i := 0; i < o.RetrievalRetries; i++ {
+		data, err = client.DownloadBytes(ctx, u.ref)
+		if err == nil {
+			break
+		}
+		c.logger.Infof("node %s: download %s: %v", name, u.ref, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(o.RetryDelay):
+		}
+	}
+	if err != nil {
+		c.metrics.Retrievals.WithLabelValues("failed").Inc()
+		return fmt.Errorf("node %s: acknowledged upload %s not retrievable: %w", name, u.ref, err)
+	}
+
+	if !bytes.Equal(data, u.data) {
+		c.metrics.Retrievals.WithLabelValues("mismatch").Inc()
+		return fmt.Errorf("node %s: acknowledged upload %s retrieved with different content", name, u.ref)
+	}
+
+	c.metrics.Retrievals.WithLabelValues("ok").Inc()
+
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ethersphere/bee/pkg/swarm"
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
 	"github.com/ethersphere/beekeeper/pkg/logging"
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
 	"github.com/ethersphere/beekeeper/pkg/orchestration"
 )
 
@@ -97,6 +98,14 @@ func (c *LoadCheck) Run(ctx context.Context, cluster orchestration.Cluster, opts
 
 		c.logger.Infof("uploader: %s", txNames)
 
+		// durations of uploads to many nodes are summed up and can not be
+		// attributed to a single one
+		txLabel := "multiple"
+		if len(txNames) == 1 {
+			txLabel = txNames[0]
+		}
+		iteration := m.IterationLabel(i)
+
 		var (
 			upload sync.WaitGroup
 			once   sync.Once
@@ -109,60 +118,60 @@ func (c *LoadCheck) Run(ctx context.Context, cluster orchestration.Cluster, opts
 
 			go func() {
 				defer once.Do(func() { upload.Done() }) // don't wait for all uploads
-				for retries := 10; txDuration == 0 && retries > 0; retries-- {
-					select {
-					case <-ctx.Done():
-						c.logger.Info("we are done")
-						return
-					default:
-					}
+				// failed requests are retried by the client
+				select {
+				case <-ctx.Done():
+					c.logger.Info("we are done")
+					return
+				default:
+				}
 
-					c.metrics.UploadAttempts.Inc()
-					var duration time.Duration
-					c.logger.Infof("uploading to: %s", txName)
+				c.metrics.UploadAttempts.WithLabelValues(txName).Inc()
+				var duration time.Duration
+				c.logger.Infof("uploading to: %s", txName)
 
-					batchesMtx.Lock()
+				batchesMtx.Lock()
 
-					if batch, ok := batches[txName]; ok {
-						if time.Now().After(batch.expires) {
-							delete(batches, txName)
-						}
+				if batch, ok := batches[txName]; ok {
+					if time.Now().After(batch.expires) {
+						delete(batches, txName)
 					}
+				}
 
-					var batchID string
-
-					if b, ok := batches[txName]; ok {
-						batchID = b.batchID
-						batchesMtx.Unlock()
-					} else {
-						batchesMtx.Unlock()
-						batchID, err = clients[txName].CreatePostageBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, "load-test", true)
-						if err != nil {
-							c.logger.Errorf("create new batch: %v", err)
-							return
-						}
-
-						batchesMtx.Lock()
-						batches[txName] = batch{batchID: batchID, expires: time.Now().Add(o.MaxUseBatch)}
-						batchesMtx.Unlock()
-					}
+				var batchID string
 
-					address, duration, err = test.uploadWithBatch(txName, txData, batchID)
+				if b, ok := batches[txName]; ok {
+					batchID = b.batchID
+					batchesMtx.Unlock()
+				} else {
+					batchesMtx.Unlock()
+					batchID, err = clients[txName].CreatePostageBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, "load-test", true)
 					if err != nil {
-						c.metrics.UploadErrors.Inc()
-						c.logger.Infof("upload failed: %v", err)
-						c.logger.Infof("retrying in: %v", o.TxOnErrWait)
-						time.Sleep(o.TxOnErrWait)
+						c.logger.Errorf("create new batch: %v", err)
 						return
 					}
-					txDuration += duration // dirty
+
+					batchesMtx.Lock()
+					batches[txName] = batch{batchID: batchID, expires: time.Now().Add(o.MaxUseBatch)}
+					batchesMtx.Unlock()
+				}
+
+				address, duration, err = test.uploadWithBatch(txName, txData, batchID)
+				if err != nil {
+					c.metrics.UploadErrors.WithLabelValues(txName).Inc()
+					c.logger.Infof("upload failed: %v", err)
+					return
 				}
+				txDuration += duration // dirty
 			}()
 		}
 
 		upload.Wait()
 
 		if txDuration == 0 {
+			if !waitOnErr(ctx, c.logger, o.TxOnErrWait) {
+				return nil
+			}
 			continue
 		}
 
@@ -181,39 +190,27 @@ func (c *LoadCheck) Run(ctx context.Context, cluster orchestration.Cluster, opts
 			go func() {
 				defer wg.Done()
 
-				var (
-					rxDuration time.Duration
-					rxData     []byte
-				)
-
-				for retries := 10; rxDuration == 0 && retries > 0; retries-- {
-					select {
-					case <-ctx.Done():
-						c.logger.Infof("context done in retry: %v", retries)
-						return
-					default:
-					}
-
-					c.metrics.DownloadAttempts.Inc()
-
-					rxData, rxDuration, err = test.download(rxName, address)
-					if err != nil {
-						c.metrics.DownloadErrors.Inc()
-						c.logger.Infof("download failed: %v", err)
-						c.logger.Infof("retrying in: %v", o.RxOnErrWait)
-						time.Sleep(o.RxOnErrWait)
-					}
+				// failed requests are retried by the client
+				select {
+				case <-ctx.Done():
+					c.logger.Info("we are done")
+					return
+				default:
 				}
 
-				// download error, skip comprarison below
-				if rxDuration == 0 {
+				c.metrics.DownloadAttempts.WithLabelValues(rxName).Inc()
+
+				rxData, rxDuration, err := test.download(rxName, address)
+				if err != nil {
+					c.metrics.DownloadErrors.WithLabelValues(rxName).Inc()
+					c.logger.Infof("download failed: %v", err)
 					return
 				}
 
 				if !bytes.Equal(rxData, txData) {
 					c.logger.Info("uploaded data does not match downloaded data")
 
-					c.metrics.DownloadMismatch.Inc()
+					c.metrics.DownloadMismatch.WithLabelValues(txLabel, rxName).Inc()
 
 					rxLen, txLen := len(rxData), len(txData)
 					if rxLen != txLen {
@@ -236,8 +233,8 @@ func (c *LoadCheck) Run(ctx context.Context, cluster orchestration.Cluster, opts
 
 				// We want to update the metrics when no error has been
 				// encountered in order to avoid counter mismatch.
-				c.metrics.UploadDuration.Observe(txDuration.Seconds())
-				c.metrics.DownloadDuration.Observe(rxDuration.Seconds())
+				c.metrics.UploadDuration.WithLabelValues(txLabel, iteration).Observe(txDuration.Seconds())
+				c.metrics.DownloadDuration.WithLabelValues(txLabel, rxName, iteration).Observe(rxDuration.Seconds())
 			}()
 		}
 
@@ -6,72 +6,79 @@ import (
 )
 
 type metrics struct {
-	UploadErrors     prometheus.Counter
-	UploadAttempts   prometheus.Counter
-	DownloadErrors   prometheus.Counter
-	DownloadMismatch prometheus.Counter
-	DownloadAttempts prometheus.Counter
-	UploadDuration   prometheus.Histogram
-	DownloadDuration prometheus.Histogram
+	UploadErrors     *prometheus.CounterVec
+	UploadAttempts   *prometheus.CounterVec
+	DownloadErrors   *prometheus.CounterVec
+	DownloadMismatch *prometheus.CounterVec
+	DownloadAttempts *prometheus.CounterVec
+	UploadDuration   *prometheus.HistogramVec
+	DownloadDuration *prometheus.HistogramVec
 }
 
 func newMetrics(subsystem string) metrics {
 	return metrics{
-		UploadAttempts: prometheus.NewCounter(
+		UploadAttempts: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: m.Namespace,
 				Subsystem: subsystem,
 				Name:      "upload_attempts",
 				Help:      "Number of upload attempts.",
 			},
+			[]string{"uploader"},
 		),
-		DownloadAttempts: prometheus.NewCounter(
+		DownloadAttempts: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: m.Namespace,
 				Subsystem: subsystem,
 				Name:      "download_attempts",
 				Help:      "Number of download attempts.",
 			},
+			[]string{"downloader"},
 		),
-		UploadErrors: prometheus.NewCounter(
+		UploadErrors: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: m.Namespace,
 				Subsystem: subsystem,
 				Name:      "upload_errors_count",
 				Help:      "The total number of errors encountered before successful upload.",
 			},
+			[]string{"uploader"},
 		),
-		DownloadErrors: prometheus.NewCounter(
+		DownloadErrors: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: m.Namespace,
 				Subsystem: subsystem,
 				Name:      "download_errors_count",
 				Help:      "The total number of errors encountered before successful download.",
 			},
+			[]string{"downloader"},
 		),
-		DownloadMismatch: prometheus.NewCounter(
+		DownloadMismatch: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: m.Namespace,
 				Subsystem: subsystem,
 				Name:      "download_mismatch",
 				Help:      "The total number of times uploaded data is different from downloaded data.",
 			},
+			[]string{"uploader", "downloader"},
 		),
-		UploadDuration: prometheus.NewHistogram(
+		UploadDuration: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Namespace: m.Namespace,
 				Subsystem: subsystem,
 				Name:      "data_upload_duration",
 				Help:      "Data upload duration through the /bytes endpoint.",
 			},
+			[]string{"uploader", "iteration"},
 		),
-		DownloadDuration: prometheus.NewHistogram(
+		DownloadDuration: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Namespace: m.Namespace,
 				Subsystem: subsystem,
 				Name:      "data_download_duration",
 				Help:      "Data download duration through the /bytes endpoint.",
 			},
+			[]string{"uploader", "downloader", "iteration"},
 		),
 	}
 }
@@ -12,8 +12,10 @@ import (
 	"github.com/ethersphere/beekeeper/pkg/bee/api"
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
 	"github.com/ethersphere/beekeeper/pkg/logging"
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
 	"github.com/ethersphere/beekeeper/pkg/orchestration"
 	"github.com/ethersphere/beekeeper/pkg/random"
+	"github.com/sirupsen/logrus"
 )
 
 // Options represents smoke test options
@@ -103,6 +105,9 @@ func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts int
 			c.logger.Infof("starting iteration: #%d", i)
 		}
 
+		logger := logging.WithFields(c.logger, logrus.Fields{logging.FieldIteration: i})
+		test.logger = logger
+
 		perm := rnd.Perm(cluster.Size())
 		txIdx := perm[0]
 		rxIdx := perm[1]
@@ -116,8 +121,10 @@ func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts int
 		txName := nn[txIdx]
 		rxName := nn[rxIdx]
 
-		c.logger.Infof("uploader: %s", txName)
-		c.logger.Infof("downloader: %s", rxName)
+		logger.Infof("uploader: %s", txName)
+		logger.Infof("downloader: %s", rxName)
+
+		iteration := m.IterationLabel(i)
 
 		var (
 			txDuration time.Duration
@@ -129,88 +136,81 @@ func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts int
 
 		txData = make([]byte, o.ContentSize)
 		if _, err := rand.Read(txData); err != nil {
-			c.logger.Infof("unable to create random content: %v", err)
+			logger.Infof("unable to create random content: %v", err)
 			continue
 		}
 
-		for retries := 3; txDuration == 0 && retries > 0; retries-- {
-			select {
-			case <-ctx.Done():
+		// failed requests are retried by the client, content that failed to
+		// upload or download is skipped and the next iteration uploads new
+		// content after the wait
+		c.metrics.UploadAttempts.WithLabelValues(txName).Inc()
+		address, txDuration, err = test.upload(txName, txData)
+		if err != nil {
+			c.metrics.UploadErrors.WithLabelValues(txName).Inc()
+			logger.Infof("upload failed: %v", err)
+			if !waitOnErr(ctx, logger, o.TxOnErrWait) {
 				return nil
-			default:
-			}
-
-			c.metrics.UploadAttempts.Inc()
-
-			address, txDuration, err = test.upload(txName, txData)
-			if err != nil {
-				c.metrics.UploadErrors.Inc()
-				c.logger.Infof("upload failed: %v", err)
-				c.logger.Infof("retrying in: %v", o.TxOnErrWait)
-				time.Sleep(o.TxOnErrWait)
 			}
-		}
-
-		if txDuration == 0 {
 			continue
 		}
 
-		c.metrics.UploadDuration.Observe(txDuration.Seconds())
+		c.metrics.UploadDuration.WithLabelValues(txName, iteration).Observe(txDuration.Seconds())
 
 		time.Sleep(o.NodesSyncWait) // Wait for nodes to sync.
 
-		for retries := 3; rxDuration == 0 && retries > 0; retries-- {
-			select {
-			case <-ctx.Done():
+		c.metrics.DownloadAttempts.WithLabelValues(rxName).Inc()
+		rxData, rxDuration, err = test.download(rxName, address)
+		if err != nil {
+			c.metrics.DownloadErrors.WithLabelValues(rxName).Inc()
+			logger.Infof("download failed: %v", err)
+			if !waitOnErr(ctx, logger, o.RxOnErrWait) {
 				return nil
-			case <-time.After(o.RxOnErrWait):
-			}
-
-			c.metrics.DownloadAttempts.Inc()
-
-			rxData, rxDuration, err = test.download(rxName, address)
-			if err != nil {
-				c.metrics.DownloadErrors.Inc()
-				c.logger.Infof("download failed: %v", err)
-				c.logger.Infof("retrying in: %v", o.RxOnErrWait)
-				continue
-			}
-
-			if rxDuration == 0 {
-				continue
 			}
+			continue
+		}
 
-			if bytes.Equal(rxData, txData) {
-				c.metrics.DownloadDuration.Observe(rxDuration.Seconds())
-				break
-			}
+		if bytes.Equal(rxData, txData) {
+			c.metrics.DownloadDuration.WithLabelValues(txName, rxName, iteration).Observe(rxDuration.Seconds())
+			continue
+		}
 
-			c.logger.Info("uploaded data does not match downloaded data")
+		logger.Info("uploaded data does not match downloaded data")
 
-			c.metrics.DownloadMismatch.Inc()
+		c.metrics.DownloadMismatch.WithLabelValues(txName, rxName).Inc()
 
-			rxLen, txLen := len(rxData), len(txData)
-			if rxLen != txLen {
-				c.logger.Infof("length mismatch: download length %d; upload length %d", rxLen, txLen)
-				if txLen < rxLen {
-					c.logger.Info("length mismatch: rx length is bigger then tx length")
-				}
-				continue
+		rxLen, txLen := len(rxData), len(txData)
+		if rxLen != txLen {
+			logger.Infof("length mismatch: download length %d; upload length %d", rxLen, txLen)
+			if txLen < rxLen {
+				logger.Info("length mismatch: rx length is bigger then tx length")
 			}
+			continue
+		}
 
-			var diff int
-			for i := range txData {
-				if txData[i] != rxData[i] {
-					diff++
-				}
+		var diff int
+		for i := range txData {
+			if txData[i] != rxData[i] {
+				diff++
 			}
-			c.logger.Infof("data mismatch: found %d different bytes, ~%.2f%%", diff, float64(diff)/float64(txLen)*100)
 		}
+		logger.Infof("data mismatch: found %d different bytes, ~%.2f%%", diff, float64(diff)/float64(txLen)*100)
 	}
 
 	return nil
 }
 
+// waitOnErr waits before the next iteration after a failed one, it returns
+// false if the context is done
+func waitOnErr(ctx context.Context, logger logging.Logger, wait time.Duration) bool {
+	logger.Infof("next iteration in: %v", wait)
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(wait):
+		return true
+	}
+}
+
 type test struct {
 	opt     Options
 	ctx     context.Context
@@ -220,14 +220,14 @@ type test struct {
 
 func (t *test) uploadWithBatch(cName string, data []byte, batchID string) (swarm.Address, time.Duration, error) {
 	client := t.clients[cName]
-	t.logger.Infof("node %s: uploading data, batch id %s", cName, batchID)
+	t.logger.WithField(logging.FieldNode, cName).Infof("node %s: uploading data, batch id %s", cName, batchID)
 	start := time.Now()
 	addr, err := client.UploadBytes(t.ctx, data, api.UploadOptions{Pin: false, BatchID: batchID, Direct: true})
 	if err != nil {
 		return swarm.ZeroAddress, 0, fmt.Errorf("upload to the node %s: %w", cName, err)
 	}
 	txDuration := time.Since(start)
-	t.logger.Infof("node %s: upload done in %s", cName, txDuration)
+	t.logger.WithField(logging.FieldNode, cName).Infof("node %s: upload done in %s", cName, txDuration)
 
 	return addr, txDuration, nil
 }
@@ -238,28 +238,28 @@ func (t *test) upload(cName string, data []byte) (swarm.Address, time.Duration,
 	if err != nil {
 		return swarm.ZeroAddress, 0, fmt.Errorf("node %s: unable to create batch id: %w", cName, err)
 	}
-	t.logger.Infof("node %s: uploading data, batch id %s", cName, batchID)
+	t.logger.WithField(logging.FieldNode, cName).Infof("node %s: uploading data, batch id %s", cName, batchID)
 	start := time.Now()
 	addr, err := client.UploadBytes(t.ctx, data, api.UploadOptions{Pin: false, BatchID: batchID, Direct: false})
 	if err != nil {
 		return swarm.ZeroAddress, 0, fmt.Errorf("upload to the node %s: %w", cName, err)
 	}
 	txDuration := time.Since(start)
-	t.logger.Infof("node %s: upload done in %s", cName, txDuration)
+	t.logger.WithField(logging.FieldNode, cName).Infof("node %s: upload done in %s", cName, txDuration)
 
 	return addr, txDuration, nil
 }
 
 func (t *test) download(cName string, addr swarm.Address) ([]byte, time.Duration, error) {
 	client := t.clients[cName]
-	t.logger.Infof("node %s: downloading address %s", cName, addr)
+	t.logger.WithField(logging.FieldNode, cName).Infof("node %s: downloading address %s", cName, addr)
 	start := time.Now()
 	data, err := client.DownloadBytes(t.ctx, addr)
 	if err != nil {
 		return nil, 0, fmt.Errorf("download from node %s: %w", cName, err)
 	}
 	rxDuration := time.Since(start)
-	t.logger.Infof("node %s: download done in %s", cName, rxDuration)
+	t.logger.WithField(logging.FieldNode, cName).Infof("node %s: download done in %s", cName, rxDuration)
 
 	return data, rxDuration, nil
 }
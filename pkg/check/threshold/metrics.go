@@ -0,0 +1,46 @@
+package threshold
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	DownloadedChunks prometheus.Counter
+	DebtAtSettlement prometheus.Gauge
+	SettlementTime   prometheus.Gauge
+}
+
+func newMetrics() metrics {
+	subsystem := "check_threshold"
+	return metrics{
+		DownloadedChunks: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "downloaded_chunks_count",
+				Help:      "Number of chunks the debtor downloaded from the creditor.",
+			},
+		),
+		DebtAtSettlement: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "debt_at_settlement",
+				Help:      "Debt of the debtor to the creditor after crossing the payment threshold.",
+			},
+		),
+		SettlementTime: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "settlement_seconds",
+				Help:      "Time from crossing the payment threshold to the settlement.",
+			},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
@@ -0,0 +1,335 @@
+package threshold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	BasePrice         int64 // price of a chunk at the maximal proximity order
+	ChunkPO           uint8 // proximity order of downloaded chunks to the creditor
+	CreditorNode      string
+	DebtorNode        string
+	EarlyPayment      int64 // percentage of the payment threshold at which the debtor settles
+	GasPrice          string
+	MaxChunks         int   // maximal number of chunks downloaded before crossing the threshold
+	PaymentThreshold  int64 // payment threshold announced by the creditor, read from the debtor if zero
+	PostageAmount     int64
+	PostageDepth      uint64
+	PostageLabel      string
+	Seed              int64
+	SettlementTimeout time.Duration
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		BasePrice:         10000,
+		ChunkPO:           10,
+		CreditorNode:      "",
+		DebtorNode:        "",
+		EarlyPayment:      50,
+		GasPrice:          "",
+		MaxChunks:         10000,
+		PaymentThreshold:  0,
+		PostageAmount:     1000,
+		PostageDepth:      20,
+		PostageLabel:      "test-label",
+		Seed:              random.Int64(),
+		SettlementTimeout: time.Minute,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Run makes the debtor download chunks stored by the creditor until its debt
+// is just below the point where it has to settle, asserting that no
+// settlement happens, then crosses that point with one more chunk and asserts
+// that a settlement is sent within the timeout.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	overlays, err := cluster.FlattenOverlays(ctx)
+	if err != nil {
+		return err
+	}
+
+	debtorName, creditorName, err := c.pair(ctx, cluster, clients, overlays, rnd, o)
+	if err != nil {
+		return err
+	}
+	debtor, creditor := clients[debtorName], clients[creditorName]
+	creditorOverlay := overlays[creditorName]
+	c.logger.Infof("debtor %s, creditor %s", debtorName, creditorName)
+
+	batchID, err := creditor.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: batch id %w", creditorName, err)
+	}
+	c.logger.Infof("node %s: batch id %s", creditorName, batchID)
+
+	baseline, err := sent(ctx, debtor, creditorOverlay)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", debtorName, err)
+	}
+
+	debt, threshold, err := account(ctx, debtor, creditorOverlay)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", debtorName, err)
+	}
+	if o.PaymentThreshold > 0 {
+		threshold = o.PaymentThreshold
+	}
+	if threshold <= 0 {
+		return fmt.Errorf("node %s: no payment threshold with %s", debtorName, creditorName)
+	}
+	trigger := threshold * (100 - o.EarlyPayment) / 100
+
+	// the price is measured after every download, the first estimate is based
+	// on the proximity of the chunk to the creditor
+	price := (int64(swarm.MaxPO) - int64(o.ChunkPO) + 1) * o.BasePrice
+	c.logger.Infof("payment threshold %d, settlement at %d, debt %d, chunk price %d", threshold, trigger, debt, price)
+
+	if debt+price >= trigger {
+		return fmt.Errorf("node %s: debt %d to %s is too close to the settlement at %d to cross it with a single chunk", debtorName, debt, creditorName, trigger)
+	}
+
+	for i := 0; ; i++ {
+		if i >= o.MaxChunks {
+			return fmt.Errorf("node %s: debt %d to %s did not approach the settlement at %d after %d chunks", debtorName, debt, creditorName, trigger, o.MaxChunks)
+		}
+
+		crossing := debt+price >= trigger
+
+		if err := c.transfer(ctx, debtor, creditor, creditorOverlay, overlays, rnd, batchID, o); err != nil {
+			return fmt.Errorf("debtor %s, creditor %s: %w", debtorName, creditorName, err)
+		}
+
+		if crossing {
+			c.logger.Infof("node %s: crossed the settlement at %d with debt %d and chunk price %d", debtorName, trigger, debt, price)
+			break
+		}
+
+		d, _, err := account(ctx, debtor, creditorOverlay)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", debtorName, err)
+		}
+		if d > debt {
+			price = d - debt
+		}
+		debt = d
+
+		s, err := sent(ctx, debtor, creditorOverlay)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", debtorName, err)
+		}
+		if s != baseline {
+			return fmt.Errorf("node %s: settled %d with %s early, at debt %d below the settlement at %d", debtorName, s-baseline, creditorName, debt, trigger)
+		}
+	}
+
+	start := time.Now()
+	settled, err := c.waitSettlement(ctx, debtor, creditorOverlay, baseline, o.SettlementTimeout)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", debtorName, err)
+	}
+	if settled == 0 {
+		return fmt.Errorf("node %s: no settlement with %s within %s after crossing the settlement at %d", debtorName, creditorName, o.SettlementTimeout, trigger)
+	}
+	c.metrics.DebtAtSettlement.Set(float64(debt + price))
+	c.metrics.SettlementTime.Set(time.Since(start).Seconds())
+
+	c.logger.Infof("node %s: settled %d with %s in %s", debtorName, settled, creditorName, time.Since(start).Round(time.Second))
+
+	return nil
+}
+
+// pair returns the debtor and the creditor, picking random connected full
+// nodes for those that are not configured
+func (c *Check) pair(ctx context.Context, cluster orchestration.Cluster, clients map[string]*bee.Client, overlays map[string]swarm.Address, rnd *rand.Rand, o Options) (debtor, creditor string, err error) {
+	fullNodes := cluster.FullNodeNames()
+	sort.Strings(fullNodes)
+	rnd.Shuffle(len(fullNodes), func(i, j int) { fullNodes[i], fullNodes[j] = fullNodes[j], fullNodes[i] })
+
+	debtors, creditors := fullNodes, fullNodes
+	if o.DebtorNode != "" {
+		debtors = []string{o.DebtorNode}
+	}
+	if o.CreditorNode != "" {
+		creditors = []string{o.CreditorNode}
+	}
+
+	for _, d := range debtors {
+		client, ok := clients[d]
+		if !ok {
+			return "", "", fmt.Errorf("node %s not found", d)
+		}
+		peers, err := client.Peers(ctx)
+		if err != nil {
+			return "", "", fmt.Errorf("node %s: %w", d, err)
+		}
+
+		for _, cr := range creditors {
+			if cr == d {
+				continue
+			}
+			overlay, ok := overlays[cr]
+			if !ok {
+				return "", "", fmt.Errorf("node %s not found", cr)
+			}
+			for _, p := range peers {
+				if p.Equal(overlay) {
+					return d, cr, nil
+				}
+			}
+		}
+	}
+
+	return "", "", errors.New("no connected debtor and creditor found")
+}
+
+// transfer uploads a chunk that is closest to the creditor to the creditor and
+// downloads it from the debtor
+func (c *Check) transfer(ctx context.Context, debtor, creditor *bee.Client, creditorOverlay swarm.Address, overlays map[string]swarm.Address, rnd *rand.Rand, batchID string, o Options) error {
+	chunk, err := mineChunk(rnd, creditorOverlay, overlays, o.ChunkPO)
+	if err != nil {
+		return err
+	}
+
+	if _, err := creditor.UploadChunk(ctx, chunk.Data(), api.UploadOptions{BatchID: batchID}); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+
+	if _, err := debtor.DownloadChunk(ctx, chunk.Address(), ""); err != nil {
+		return fmt.Errorf("download: %w", err)
+	}
+	c.metrics.DownloadedChunks.Inc()
+
+	return nil
+}
+
+// waitSettlement waits until the debtor sends a settlement to the creditor
+// and returns the settled amount, zero if no settlement was sent in time
+func (c *Check) waitSettlement(ctx context.Context, debtor *bee.Client, creditor swarm.Address, baseline int64, timeout time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		s, err := sent(ctx, debtor, creditor)
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			return 0, err
+		}
+		if err == nil && s != baseline {
+			return s - baseline, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// mineChunk returns a random chunk at the proximity order to the creditor
+// that is closer to the creditor than to any other node
+func mineChunk(rnd *rand.Rand, creditor swarm.Address, overlays map[string]swarm.Address, po uint8) (swarm.Chunk, error) {
+next:
+	for {
+		chunk := bee.GenerateRandomChunkAt(rnd, creditor, po)
+		for _, overlay := range overlays {
+			if overlay.Equal(creditor) {
+				continue
+			}
+			dcmp, err := swarm.DistanceCmp(chunk.Address(), creditor, overlay)
+			if err != nil {
+				return nil, fmt.Errorf("mine chunk: %w", err)
+			}
+			if dcmp != 1 {
+				continue next
+			}
+		}
+		return chunk, nil
+	}
+}
+
+// account returns the debt of the node to the peer and the payment threshold
+// the peer announced to the node
+func account(ctx context.Context, client *bee.Client, peer swarm.Address) (debt, threshold int64, err error) {
+	a, err := client.Accounting(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, acc := range a.Accounting {
+		if acc.Peer != peer.String() {
+			continue
+		}
+		if acc.Balance < 0 {
+			debt = -acc.Balance
+		}
+		return debt, acc.ThresholdReceived, nil
+	}
+
+	return 0, 0, fmt.Errorf("no account with %s", peer)
+}
+
+// sent returns the total amount the node settled with the peer with cheques
+// and with pseudosettle
+func sent(ctx context.Context, client *bee.Client, peer swarm.Address) (total int64, err error) {
+	swap, err := client.Settlements(ctx)
+	if err != nil {
+		return 0, err
+	}
+	timed, err := client.TimeSettlements(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range append(swap.Settlements, timed.Settlements...) {
+		if s.Peer == peer.String() {
+			total += s.Sent
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,49 @@
+package uploadmode
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	UploadDuration    *prometheus.GaugeVec
+	TimeToRetrievable *prometheus.GaugeVec
+	Difference        *prometheus.GaugeVec
+}
+
+func newMetrics() metrics {
+	subsystem := "check_uploadmode"
+	return metrics{
+		UploadDuration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "upload_duration_seconds",
+				Help:      "Mean duration of uploads by upload mode.",
+			},
+			[]string{"mode"},
+		),
+		TimeToRetrievable: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "time_to_retrievable_seconds",
+				Help:      "Mean time from the start of an upload until the content is retrievable from remote nodes by upload mode.",
+			},
+			[]string{"mode"},
+		),
+		Difference: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "difference_seconds",
+				Help:      "Mean duration of deferred uploads minus mean duration of direct uploads.",
+			},
+			[]string{"measure"},
+		),
+	}
+}
+
+func (c *Check) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(c.metrics)
+}
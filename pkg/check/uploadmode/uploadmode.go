@@ -0,0 +1,224 @@
+package uploadmode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	ContentCount    int // number of uploads in every mode
+	ContentSize     int64
+	GasPrice        string
+	PollInterval    time.Duration
+	PostageAmount   int64
+	PostageDepth    uint64
+	PostageLabel    string
+	RemoteNodes     int // number of nodes the content has to be retrievable from
+	RetrievableWait time.Duration
+	Seed            int64
+	UploadNode      string
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		ContentCount:    5,
+		ContentSize:     1024 * 1024, // 1mb
+		GasPrice:        "",
+		PollInterval:    500 * time.Millisecond,
+		PostageAmount:   1000,
+		PostageDepth:    20,
+		PostageLabel:    "test-label",
+		RemoteNodes:     2,
+		RetrievableWait: 5 * time.Minute,
+		Seed:            random.Int64(),
+	}
+}
+
+// compile check whether Check implements interface
+var (
+	_ beekeeper.Action         = (*Check)(nil)
+	_ beekeeper.ResultReporter = (*Check)(nil)
+)
+
+// Check instance
+type Check struct {
+	metrics metrics
+	logger  logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// mode is an upload mode
+type mode struct {
+	name   string
+	direct bool
+}
+
+var modes = []mode{{name: "deferred"}, {name: "direct", direct: true}}
+
+// Run uploads corpora of the same shape in deferred and direct mode and
+// measures the time until every upload is retrievable from remote nodes. The
+// corpora hold different data, otherwise the second mode would find the
+// content already in the network.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	_, err = c.RunResult(ctx, cluster, opts)
+	return err
+}
+
+// RunResult runs the check and returns the mean durations of both modes as
+// measurements of its result
+func (c *Check) RunResult(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (r beekeeper.Result, err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return r, fmt.Errorf("invalid options type")
+	}
+	if o.ContentCount <= 0 {
+		return r, fmt.Errorf("content count must be positive, got %d", o.ContentCount)
+	}
+
+	c.logger.Infof("seed: %d", o.Seed)
+	rnd := random.PseudoGenerator(o.Seed)
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return r, err
+	}
+
+	fullNodes := cluster.FullNodeNames()
+	if len(fullNodes) < o.RemoteNodes+1 {
+		return r, fmt.Errorf("upload mode check requires at least %d full nodes", o.RemoteNodes+1)
+	}
+	sort.Strings(fullNodes)
+	rnd.Shuffle(len(fullNodes), func(i, j int) { fullNodes[i], fullNodes[j] = fullNodes[j], fullNodes[i] })
+
+	uploader := o.UploadNode
+	if uploader == "" {
+		uploader = fullNodes[0]
+	}
+	client, ok := clients[uploader]
+	if !ok {
+		return r, fmt.Errorf("node %s not found", uploader)
+	}
+	var remotes []string
+	for _, name := range fullNodes {
+		if name != uploader && len(remotes) < o.RemoteNodes {
+			remotes = append(remotes, name)
+		}
+	}
+
+	var batchID string
+	if err := r.Step("batch", func() (err error) {
+		batchID, err = client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+		if err != nil {
+			return fmt.Errorf("node %s: batch id %w", uploader, err)
+		}
+		return nil
+	}); err != nil {
+		return r, err
+	}
+	c.logger.Infof("node %s: batch id %s, remote nodes %v", uploader, batchID, remotes)
+
+	uploadTotal := make(map[string]time.Duration)
+	retrievableTotal := make(map[string]time.Duration)
+
+	for i := 0; i < o.ContentCount; i++ {
+		// alternate the order of the modes so neither benefits from running
+		// first
+		order := modes
+		if i%2 == 1 {
+			order = []mode{modes[1], modes[0]}
+		}
+
+		for _, m := range order {
+			if err := r.Step(fmt.Sprintf("%s upload %d", m.name, i+1), func() error {
+				data := make([]byte, o.ContentSize)
+				if _, err := rnd.Read(data); err != nil {
+					return fmt.Errorf("random data: %w", err)
+				}
+
+				start := time.Now()
+				ref, err := client.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID, Direct: m.direct})
+				if err != nil {
+					return fmt.Errorf("node %s: %s upload: %w", uploader, m.name, err)
+				}
+				uploaded := time.Since(start)
+
+				for _, name := range remotes {
+					if err := c.waitRetrievable(ctx, clients[name], ref, data, o); err != nil {
+						return fmt.Errorf("node %s: %s upload %s: %w", name, m.name, ref, err)
+					}
+				}
+				retrievable := time.Since(start)
+
+				uploadTotal[m.name] += uploaded
+				retrievableTotal[m.name] += retrievable
+				c.logger.Infof("%s upload %d: uploaded in %s, retrievable in %s", m.name, i+1, uploaded, retrievable)
+				return nil
+			}); err != nil {
+				return r, err
+			}
+		}
+	}
+
+	mean := func(d time.Duration) time.Duration { return d / time.Duration(o.ContentCount) }
+	for _, m := range modes {
+		c.metrics.UploadDuration.WithLabelValues(m.name).Set(mean(uploadTotal[m.name]).Seconds())
+		c.metrics.TimeToRetrievable.WithLabelValues(m.name).Set(mean(retrievableTotal[m.name]).Seconds())
+		r.Measure(m.name+" upload", mean(uploadTotal[m.name]).Seconds(), "s")
+		r.Measure(m.name+" time to retrievable", mean(retrievableTotal[m.name]).Seconds(), "s")
+		c.logger.Infof("%s: mean upload %s, mean time to retrievable %s", m.name, mean(uploadTotal[m.name]), mean(retrievableTotal[m.name]))
+	}
+
+	uploadDiff := mean(uploadTotal["deferred"]) - mean(uploadTotal["direct"])
+	retrievableDiff := mean(retrievableTotal["deferred"]) - mean(retrievableTotal["direct"])
+	c.metrics.Difference.WithLabelValues("upload").Set(uploadDiff.Seconds())
+	c.metrics.Difference.WithLabelValues("time_to_retrievable").Set(retrievableDiff.Seconds())
+	r.Measure("upload difference", uploadDiff.Seconds(), "s")
+	r.Measure("time to retrievable difference", retrievableDiff.Seconds(), "s")
+	c.logger.Infof("deferred minus direct: upload %s, time to retrievable %s", uploadDiff, retrievableDiff)
+
+	return r, nil
+}
+
+// waitRetrievable downloads the content until it is retrieved and matches the
+// uploaded data
+func (c *Check) waitRetrievable(ctx context.Context, client *bee.Client, ref swarm.Address, data []byte, o Options) error {
+	ctx, cancel := context.WithTimeout(ctx, o.RetrievableWait)
+	defer cancel()
+
+	for {
+		got, err := client.DownloadBytes(ctx, ref)
+		if err == nil {
+			if !bytes.Equal(got, data) {
+				return errors.New("downloaded content differs")
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("not retrievable after %s: %w", o.RetrievableWait, err)
+		case <-time.After(o.PollInterval):
+		}
+	}
+}
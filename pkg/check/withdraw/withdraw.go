@@ -0,0 +1,193 @@
+package withdraw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/debugapi"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+const (
+	coinBZZ    = "bzz"
+	coinNative = "nativetoken"
+)
+
+// Options represents check options
+type Options struct {
+	BzzAmount             int64 // in PLUR
+	NativeAmount          int64 // in wei
+	NodeName              string
+	DestinationNodeName   string
+	NonWhitelistedAddress string
+	Retries               int
+	RetryDelay            time.Duration
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		BzzAmount:             100000000000000,  // 0.01 BZZ
+		NativeAmount:          1000000000000000, // 0.001 xDAI
+		NonWhitelistedAddress: "0x000000000000000000000000000000000000dEaD",
+		Retries:               30,
+		RetryDelay:            5 * time.Second,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct {
+	logger logging.Logger
+}
+
+// NewCheck returns new check
+func NewCheck(logger logging.Logger) beekeeper.Action {
+	return &Check{
+		logger: logger,
+	}
+}
+
+// Run withdraws BZZ and native token from one node's wallet to the wallet of
+// another node and verifies balances of both wallets. The destination node's
+// wallet address must be whitelisted in the withdrawal-addresses-whitelist
+// configuration of the source node. Withdrawal to a non-whitelisted address
+// is expected to be rejected.
+func (c *Check) Run(ctx context.Context, cluster orchestration.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	fullNodes := cluster.FullNodeNames()
+	if len(fullNodes) < 2 && (o.NodeName == "" || o.DestinationNodeName == "") {
+		return errors.New("withdraw check requires at least 2 full nodes")
+	}
+
+	srcName, dstName := o.NodeName, o.DestinationNodeName
+	if srcName == "" {
+		srcName = fullNodes[0]
+	}
+	if dstName == "" {
+		for _, n := range fullNodes {
+			if n != srcName {
+				dstName = n
+				break
+			}
+		}
+	}
+
+	src, ok := clients[srcName]
+	if !ok {
+		return fmt.Errorf("node %s not found", srcName)
+	}
+	dst, ok := clients[dstName]
+	if !ok {
+		return fmt.Errorf("node %s not found", dstName)
+	}
+
+	dstWallet, err := dst.Wallet(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", dstName, err)
+	}
+	c.logger.Infof("withdrawing from node %s to node %s wallet %s", srcName, dstName, dstWallet.WalletAddress)
+
+	// withdrawal to an address that is not whitelisted must be rejected
+	for _, coin := range []string{coinBZZ, coinNative} {
+		_, err := src.WalletWithdraw(ctx, coin, o.NonWhitelistedAddress, big.NewInt(1))
+		if !debugapi.IsHTTPStatusErrorCode(err, http.StatusBadRequest) {
+			return fmt.Errorf("node %s: withdraw %s to non-whitelisted address: expected code %d, got %v", srcName, coin, http.StatusBadRequest, err)
+		}
+		c.logger.Infof("node %s: withdraw %s to non-whitelisted address %s rejected", srcName, coin, o.NonWhitelistedAddress)
+	}
+
+	if err := c.withdraw(ctx, coinBZZ, big.NewInt(o.BzzAmount), srcName, src, dstName, dst, dstWallet.WalletAddress, o); err != nil {
+		return err
+	}
+
+	if err := c.withdraw(ctx, coinNative, big.NewInt(o.NativeAmount), srcName, src, dstName, dst, dstWallet.WalletAddress, o); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// withdraw withdraws amount of a coin from src to dst wallet and verifies
+// balance deltas once the transaction is mined
+func (c *Check) withdraw(ctx context.Context, coin string, amount *big.Int, srcName string, src *bee.Client, dstName string, dst *bee.Client, address string, o Options) error {
+	srcBefore, err := src.Wallet(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", srcName, err)
+	}
+	dstBefore, err := dst.Wallet(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", dstName, err)
+	}
+
+	txHash, err := src.WalletWithdraw(ctx, coin, address, amount)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", srcName, err)
+	}
+	c.logger.Infof("node %s: withdrawing %s %s in transaction %s", srcName, amount, coin, txHash)
+
+	var srcAfter, dstAfter bee.WalletResponse
+	for i := 0; ; i++ {
+		if i >= o.Retries {
+			return fmt.Errorf("%s withdrawal transaction %s not confirmed", coin, txHash)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(o.RetryDelay):
+		}
+
+		if dstAfter, err = dst.Wallet(ctx); err != nil {
+			return fmt.Errorf("node %s: %w", dstName, err)
+		}
+		if balance(coin, dstAfter).Cmp(balance(coin, dstBefore)) != 0 {
+			break
+		}
+		c.logger.Infof("transaction %s not yet confirmed", txHash)
+	}
+
+	if srcAfter, err = src.Wallet(ctx); err != nil {
+		return fmt.Errorf("node %s: %w", srcName, err)
+	}
+
+	received := new(big.Int).Sub(balance(coin, dstAfter), balance(coin, dstBefore))
+	if received.Cmp(amount) != 0 {
+		return fmt.Errorf("node %s: %s balance increased by %s, expected %s", dstName, coin, received, amount)
+	}
+
+	// native token balance of the sender also pays for the gas
+	sent := new(big.Int).Sub(balance(coin, srcBefore), balance(coin, srcAfter))
+	if (coin == coinBZZ && sent.Cmp(amount) != 0) || sent.Cmp(amount) < 0 {
+		return fmt.Errorf("node %s: %s balance decreased by %s, expected %s", srcName, coin, sent, amount)
+	}
+
+	c.logger.Infof("node %s: withdrawal of %s %s to node %s confirmed", srcName, amount, coin, dstName)
+
+	return nil
+}
+
+func balance(coin string, w bee.WalletResponse) *big.Int {
+	if coin == coinBZZ {
+		return w.BZZ
+	}
+	return w.NativeToken
+}
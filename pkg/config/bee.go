@@ -46,6 +46,7 @@ type BeeConfig struct {
 	StorageIncentivesEnable    *string        `yaml:"storage-incentives-enable"`
 	ResolverOptions            *string        `yaml:"resolver-options"`
 	Restricted                 *bool          `yaml:"restricted"`
+	Template                   *string        `yaml:"template"`
 	TokenEncryptionKey         *string        `yaml:"token-encryption-key"`
 	AdminPassword              *string        `yaml:"admin-password"`
 	ChequebookEnable           *bool          `yaml:"chequebook-enable"`
@@ -60,6 +61,7 @@ type BeeConfig struct {
 	TracingServiceName         *string        `yaml:"tracing-service-name"`
 	Verbosity                  *uint64        `yaml:"verbosity"`
 	WelcomeMessage             *string        `yaml:"welcome-message"`
+	WithdrawalAddresses        *string        `yaml:"withdrawal-addresses-whitelist"`
 	WarmupTime                 *time.Duration `yaml:"warmup-time"`
 }
 
@@ -84,3 +86,24 @@ func (b *BeeConfig) Export() (o orchestration.Config) {
 
 	return remoteVal.Interface().(orchestration.Config)
 }
+
+// Override returns copy of BeeConfig with settings set in the override
+// replacing its own, unset settings of the override are kept
+func (b BeeConfig) Override(override *BeeConfig) BeeConfig {
+	if override == nil {
+		return b
+	}
+
+	m := reflect.ValueOf(&b).Elem()
+	o := reflect.ValueOf(override).Elem()
+	for i := 0; i < m.NumField(); i++ {
+		if m.Type().Field(i).Anonymous {
+			continue
+		}
+		if !o.Field(i).IsNil() {
+			m.Field(i).Set(o.Field(i))
+		}
+	}
+
+	return b
+}
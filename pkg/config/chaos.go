@@ -0,0 +1,163 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/chaos"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// Chaos represents chaos actions run while the check runs
+type Chaos struct {
+	PodKill       *PodKill       `yaml:"pod-kill"`       // kills or restarts nodes on a schedule
+	Partition     *Partition     `yaml:"partition"`      // splits nodes into groups for a duration
+	NetworkFaults *NetworkFaults `yaml:"network-faults"` // latency, loss or bandwidth faults injected by chaos-mesh
+	DiskPressure  *DiskPressure  `yaml:"disk-pressure"`  // fills data volumes of nodes
+	IOFaults      *IOFaults      `yaml:"io-faults"`      // IO latency faults injected by chaos-mesh
+	Stress        *Stress        `yaml:"stress"`         // CPU and memory stress injected by chaos-mesh
+}
+
+// PodKill represents options of the pod kill chaos action
+type PodKill struct {
+	Mode         string        `yaml:"mode"`          // kill, the default, or restart
+	NodeGroups   []string      `yaml:"node-groups"`   // node groups nodes are selected from, all by default
+	Nodes        []string      `yaml:"nodes"`         // nodes selected from, overrides node groups
+	Exclude      []string      `yaml:"exclude"`       // nodes never selected
+	Count        int           `yaml:"count"`         // nodes affected every round, 1 by default
+	Interval     time.Duration `yaml:"interval"`      // delay before every round, 1m by default
+	Rounds       int           `yaml:"rounds"`        // until the check is done if not set
+	StopDelay    time.Duration `yaml:"stop-delay"`    // time nodes are kept stopped in restart mode, 10s by default
+	ReadyTimeout time.Duration `yaml:"ready-timeout"` // 5m by default
+	Seed         int64         `yaml:"seed"`          // random by default
+}
+
+// Partition represents options of the partition chaos action
+type Partition struct {
+	Groups   [][]string    `yaml:"groups"`   // names of nodes or node groups of every group, other nodes form another one
+	Delay    time.Duration `yaml:"delay"`    // delay before the cluster is partitioned
+	Duration time.Duration `yaml:"duration"` // 1m by default
+}
+
+// NetworkFaults represents options of the network fault chaos action
+type NetworkFaults struct {
+	Faults   []NetworkFault `yaml:"faults"`
+	Delay    time.Duration  `yaml:"delay"`    // delay before faults are injected
+	Duration time.Duration  `yaml:"duration"` // until the check is done if not set
+}
+
+// NetworkFault represents fault of networks of pods of nodes of node groups
+type NetworkFault struct {
+	Name       string        `yaml:"name"`
+	Action     string        `yaml:"action"`      // delay, loss or bandwidth
+	NodeGroups []string      `yaml:"node-groups"` // all by default
+	Mode       string        `yaml:"mode"`        // all, the default, one, fixed, fixed-percent or random-max-percent
+	Value      string        `yaml:"value"`       // number or percentage of pods of the fixed and percent modes
+	Direction  string        `yaml:"direction"`   // to, the default, from or both
+	Latency    time.Duration `yaml:"latency"`
+	Jitter     time.Duration `yaml:"jitter"`
+	Loss       float64       `yaml:"loss"` // percentage of dropped packets
+	Rate       string        `yaml:"rate"` // like 1mbps
+	Limit      uint32        `yaml:"limit"`
+	Buffer     uint32        `yaml:"buffer"`
+}
+
+// DiskPressure represents options of the disk pressure chaos action
+type DiskPressure struct {
+	Nodes    []string      `yaml:"nodes"`    // names of nodes or node groups
+	Percent  int           `yaml:"percent"`  // percentage of capacity of data volumes filled
+	Delay    time.Duration `yaml:"delay"`    // delay before volumes are filled
+	Duration time.Duration `yaml:"duration"` // until the check is done if not set
+}
+
+// IOFaults represents options of the IO fault chaos action
+type IOFaults struct {
+	Faults   []IOFault     `yaml:"faults"`
+	Delay    time.Duration `yaml:"delay"`    // delay before faults are injected
+	Duration time.Duration `yaml:"duration"` // until the check is done if not set
+}
+
+// IOFault represents fault of IO of data volumes of pods of nodes of node
+// groups
+type IOFault struct {
+	Name       string        `yaml:"name"`
+	Action     string        `yaml:"action"`      // latency, the default
+	NodeGroups []string      `yaml:"node-groups"` // all by default
+	Mode       string        `yaml:"mode"`        // all, the default, one, fixed, fixed-percent or random-max-percent
+	Value      string        `yaml:"value"`       // number or percentage of pods of the fixed and percent modes
+	Latency    time.Duration `yaml:"latency"`
+	Percent    int           `yaml:"percent"`     // percentage of delayed operations, 100 by default
+	Methods    []string      `yaml:"methods"`     // like READ and WRITE, all by default
+	VolumePath string        `yaml:"volume-path"` // /home/bee/.bee by default
+}
+
+// Stress represents options of the stress chaos action
+type Stress struct {
+	Stresses      []StressSpec  `yaml:"stresses"`
+	Delay         time.Duration `yaml:"delay"`          // delay before stress is injected
+	Duration      time.Duration `yaml:"duration"`       // until the check is done if not set
+	ProbeInterval time.Duration `yaml:"probe-interval"` // 10s by default
+	MaxLatency    time.Duration `yaml:"max-latency"`    // api latency failing the check, only logged if not set
+}
+
+// StressSpec represents CPU and memory stress of pods of nodes of node
+// groups
+type StressSpec struct {
+	Name          string   `yaml:"name"`
+	NodeGroups    []string `yaml:"node-groups"`    // all by default
+	Mode          string   `yaml:"mode"`           // all, the default, one, fixed, fixed-percent or random-max-percent
+	Value         string   `yaml:"value"`          // number or percentage of pods of the fixed and percent modes
+	CPUWorkers    int      `yaml:"cpu-workers"`    // no CPU stress if not set
+	CPULoad       int      `yaml:"cpu-load"`       // percentage loaded by every worker, 100 by default
+	MemoryWorkers int      `yaml:"memory-workers"` // 1 by default
+	MemorySize    string   `yaml:"memory-size"`    // memory allocated by every worker, like 256MB or 50%
+}
+
+// ChaosActions returns chaos actions of the check, none if chaos is not configured
+func (c *Check) ChaosActions(logger logging.Logger) (actions []chaos.Action) {
+	if c.Chaos == nil {
+		return nil
+	}
+
+	if c.Chaos.PodKill != nil {
+		actions = append(actions, chaos.NewPodKill(chaos.PodKillOptions(*c.Chaos.PodKill), logger))
+	}
+	if p := c.Chaos.Partition; p != nil {
+		actions = append(actions, chaos.NewPartition(chaos.PartitionOptions{
+			Groups:   p.Groups,
+			Delay:    p.Delay,
+			Duration: p.Duration,
+		}, logger))
+	}
+	if n := c.Chaos.NetworkFaults; n != nil {
+		o := chaos.NetworkFaultOptions{Delay: n.Delay, Duration: n.Duration}
+		for _, f := range n.Faults {
+			o.Faults = append(o.Faults, orchestration.NetworkFault(f))
+		}
+		actions = append(actions, chaos.NewNetworkFault(o, logger))
+	}
+	if d := c.Chaos.DiskPressure; d != nil {
+		actions = append(actions, chaos.NewDiskPressure(chaos.DiskPressureOptions(*d), logger))
+	}
+	if i := c.Chaos.IOFaults; i != nil {
+		o := chaos.IOFaultOptions{Delay: i.Delay, Duration: i.Duration}
+		for _, f := range i.Faults {
+			o.Faults = append(o.Faults, orchestration.IOFault(f))
+		}
+		actions = append(actions, chaos.NewIOFault(o, logger))
+	}
+	if st := c.Chaos.Stress; st != nil {
+		o := chaos.StressOptions{
+			Delay:         st.Delay,
+			Duration:      st.Duration,
+			ProbeInterval: st.ProbeInterval,
+			MaxLatency:    st.MaxLatency,
+		}
+		for _, s := range st.Stresses {
+			o.Stresses = append(o.Stresses, orchestration.Stress(s))
+		}
+		actions = append(actions, chaos.NewStress(o, logger))
+	}
+
+	return
+}
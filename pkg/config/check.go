@@ -1,34 +1,56 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/ethersphere/beekeeper/pkg/check/stake"
 
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/check/accounting"
+	"github.com/ethersphere/beekeeper/pkg/check/apicompat"
+	"github.com/ethersphere/beekeeper/pkg/check/apifuzz"
 	"github.com/ethersphere/beekeeper/pkg/check/authenticated"
+	"github.com/ethersphere/beekeeper/pkg/check/autotune"
 	"github.com/ethersphere/beekeeper/pkg/check/balances"
+	"github.com/ethersphere/beekeeper/pkg/check/cache"
 	"github.com/ethersphere/beekeeper/pkg/check/cashout"
+	"github.com/ethersphere/beekeeper/pkg/check/chequebook"
 	"github.com/ethersphere/beekeeper/pkg/check/chunkrepair"
+	"github.com/ethersphere/beekeeper/pkg/check/cid"
 	"github.com/ethersphere/beekeeper/pkg/check/contentavailability"
+	"github.com/ethersphere/beekeeper/pkg/check/crashrecovery"
+	"github.com/ethersphere/beekeeper/pkg/check/dedup"
+	"github.com/ethersphere/beekeeper/pkg/check/durability"
 	"github.com/ethersphere/beekeeper/pkg/check/fileretrieval"
 	"github.com/ethersphere/beekeeper/pkg/check/fullconnectivity"
 	"github.com/ethersphere/beekeeper/pkg/check/gc"
 	"github.com/ethersphere/beekeeper/pkg/check/kademlia"
+	"github.com/ethersphere/beekeeper/pkg/check/lightnode"
 	"github.com/ethersphere/beekeeper/pkg/check/manifest"
+	"github.com/ethersphere/beekeeper/pkg/check/migration"
+	"github.com/ethersphere/beekeeper/pkg/check/openapi"
 	"github.com/ethersphere/beekeeper/pkg/check/peercount"
 	"github.com/ethersphere/beekeeper/pkg/check/pingpong"
+	"github.com/ethersphere/beekeeper/pkg/check/pinrace"
+	"github.com/ethersphere/beekeeper/pkg/check/plugin"
 	"github.com/ethersphere/beekeeper/pkg/check/postage"
 	"github.com/ethersphere/beekeeper/pkg/check/pss"
 	"github.com/ethersphere/beekeeper/pkg/check/pullsync"
 	"github.com/ethersphere/beekeeper/pkg/check/pushsync"
+	"github.com/ethersphere/beekeeper/pkg/check/restarts"
 	"github.com/ethersphere/beekeeper/pkg/check/retrieval"
+	"github.com/ethersphere/beekeeper/pkg/check/rollingrestart"
 	"github.com/ethersphere/beekeeper/pkg/check/settlements"
 	"github.com/ethersphere/beekeeper/pkg/check/smoke"
 	"github.com/ethersphere/beekeeper/pkg/check/soc"
+	"github.com/ethersphere/beekeeper/pkg/check/threshold"
+	"github.com/ethersphere/beekeeper/pkg/check/uploadmode"
+	"github.com/ethersphere/beekeeper/pkg/check/withdraw"
 	"github.com/ethersphere/beekeeper/pkg/logging"
 	"github.com/ethersphere/beekeeper/pkg/random"
 	"gopkg.in/yaml.v3"
@@ -36,9 +58,154 @@ import (
 
 // Check represents check configuration
 type Check struct {
-	Options yaml.Node      `yaml:"options"`
-	Timeout *time.Duration `yaml:"timeout"`
-	Type    string         `yaml:"type"`
+	Chaos        *Chaos         `yaml:"chaos"`         // chaos actions disrupting nodes while the check runs
+	DependsOn    []string       `yaml:"depends-on"`    // checks that have to pass before the check runs
+	LogVerbosity *string        `yaml:"log-verbosity"` // log verbosity of the check, overrides the global one
+	Options      yaml.Node      `yaml:"options"`
+	Retries      *int           `yaml:"retries"`       // number of times a failed check is run again
+	RetryBackoff *time.Duration `yaml:"retry-backoff"` // delay before the first retry, doubled for every following one
+	Severity     *string        `yaml:"severity"`      // severity of the check failure, used to route notifications
+	Timeout      *time.Duration `yaml:"timeout"`       // timeout of every attempt
+	Type         string         `yaml:"type"`
+}
+
+// DefaultSeverity is the severity of checks without a configured one
+const DefaultSeverity = "critical"
+
+// GetSeverity returns severity of the check failure
+func (c *Check) GetSeverity() string {
+	if c.Severity == nil || *c.Severity == "" {
+		return DefaultSeverity
+	}
+	return *c.Severity
+}
+
+// GetRetries returns number of check retries
+func (c *Check) GetRetries() int {
+	if c.Retries == nil || *c.Retries < 0 {
+		return 0
+	}
+	return *c.Retries
+}
+
+// GetRetryBackoff returns delay before the first check retry
+func (c *Check) GetRetryBackoff() time.Duration {
+	if c.RetryBackoff == nil {
+		return 0
+	}
+	return *c.RetryBackoff
+}
+
+// CheckOrder returns the named checks together with all of their dependencies
+// ordered so that every check comes after the checks it depends on. Otherwise
+// the order of the names is kept.
+func (c *Config) CheckOrder(names []string) (order []string, err error) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int)
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("check dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+
+		check, ok := c.Checks[name]
+		if !ok {
+			if len(path) > 0 {
+				return fmt.Errorf("check '%s' depends on check '%s' that doesn't exist", path[len(path)-1], name)
+			}
+			return fmt.Errorf("check '%s' doesn't exist", name)
+		}
+
+		state[name] = visiting
+		for _, dep := range check.DependsOn {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, name)
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// CheckOption describes an option of a check type
+type CheckOption struct {
+	Name    string // name of the option in the configuration
+	Type    string
+	Default string
+}
+
+// DescribeCheck returns options of the check type with their default values
+func DescribeCheck(checkType string) (options []CheckOption, err error) {
+	if _, ok := Checks[checkType]; !ok {
+		return nil, fmt.Errorf("check %s not implemented", checkType)
+	}
+
+	registered, ok := checkOptions[checkType]
+	if !ok {
+		return nil, nil
+	}
+
+	local := registered.local
+	ov := reflect.ValueOf(registered.defaults)
+	for i := 0; i < local.NumField(); i++ {
+		f := local.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		o := CheckOption{Name: name, Type: f.Type.Elem().String()}
+
+		switch {
+		case f.Name == "Seed":
+			o.Default = "global seed"
+		case ov.Kind() == reflect.Struct && ov.FieldByName(f.Name).IsValid():
+			o.Default = fmt.Sprint(ov.FieldByName(f.Name).Interface())
+		}
+
+		options = append(options, o)
+	}
+
+	return options, nil
+}
+
+// RestoreCheckOptions returns options of the check type with the values of
+// the JSON encoded options, e.g. recorded in a run manifest, applied over the
+// defaults
+func RestoreCheckOptions(checkType string, data []byte) (interface{}, error) {
+	check, ok := Checks[checkType]
+	if !ok {
+		return nil, fmt.Errorf("check %s not implemented", checkType)
+	}
+
+	opts, err := check.NewOptions(CheckGlobalConfig{Seed: -1}, Check{Options: yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, Type: checkType})
+	if err != nil {
+		return nil, fmt.Errorf("creating check %s options: %w", checkType, err)
+	}
+	if opts == nil || len(data) == 0 {
+		return opts, nil
+	}
+
+	v := reflect.New(reflect.TypeOf(opts))
+	v.Elem().Set(reflect.ValueOf(opts))
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, fmt.Errorf("decoding check %s options: %w", checkType, err)
+	}
+
+	return v.Elem().Interface(), nil
 }
 
 // CheckType is used for linking beekeeper actions with check and it's proper options
@@ -57,18 +224,7 @@ var Checks = map[string]CheckType{
 	"balances": {
 		NewAction: balances.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				DryRun             *bool          `yaml:"dry-run"`
-				FileName           *string        `yaml:"file-name"`
-				FileSize           *int64         `yaml:"file-size"`
-				GasPrice           *string        `yaml:"gas-price"`
-				PostageAmount      *int64         `yaml:"postage-amount"`
-				PostageDepth       *uint64        `yaml:"postage-depth"`
-				PostageLabel       *string        `yaml:"postage-label"`
-				Seed               *int64         `yaml:"seed"`
-				UploadNodeCount    *int           `yaml:"upload-node-count"`
-				WaitBeforeDownload *time.Duration `yaml:"wait-before-download"`
-			})
+			checkOpts := new(balancesCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -84,9 +240,7 @@ var Checks = map[string]CheckType{
 	"cashout": {
 		NewAction: cashout.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				NodeGroup *string `yaml:"node-group"`
-			})
+			checkOpts := new(cashoutCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -102,14 +256,7 @@ var Checks = map[string]CheckType{
 	"chunk-repair": {
 		NewAction: chunkrepair.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				GasPrice               *string `yaml:"gas-price"`
-				NodeGroup              *string `yaml:"node-group"`
-				NumberOfChunksToRepair *int    `yaml:"number-of-chunks-to-repair"`
-				PostageAmount          *int64  `yaml:"postage-amount"`
-				PostageLabel           *string `yaml:"postage-label"`
-				Seed                   *int64  `yaml:"seed"`
-			})
+			checkOpts := new(chunkRepairCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -125,17 +272,7 @@ var Checks = map[string]CheckType{
 	"file-retrieval": {
 		NewAction: fileretrieval.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				FileName        *string `yaml:"file-name"`
-				FileSize        *int64  `yaml:"file-size"`
-				FilesPerNode    *int    `yaml:"files-per-node"`
-				Full            *bool   `yaml:"full"`
-				GasPrice        *string `yaml:"gas-price"`
-				PostageAmount   *int64  `yaml:"postage-amount"`
-				PostageLabel    *string `yaml:"postage-label"`
-				Seed            *int64  `yaml:"seed"`
-				UploadNodeCount *int    `yaml:"upload-node-count"`
-			})
+			checkOpts := new(fileRetrievalCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -151,11 +288,7 @@ var Checks = map[string]CheckType{
 	"full-connectivity": {
 		NewAction: fullconnectivity.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				LightNodeNames *[]string `yaml:"group-1"`
-				FullNodeNames  *[]string `yaml:"group-2"`
-				BootNodeNames  *[]string `yaml:"boot-nodes"`
-			})
+			checkOpts := new(fullConnectivityCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -171,13 +304,7 @@ var Checks = map[string]CheckType{
 	"gc": {
 		NewAction: gc.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				CacheSize    *int    `yaml:"cache-size"`
-				GasPrice     *string `yaml:"gas-price"`
-				PostageLabel *string `yaml:"postage-label"`
-				ReserveSize  *int    `yaml:"reserve-size"`
-				Seed         *int64  `yaml:"seed"`
-			})
+			checkOpts := new(gcCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -193,9 +320,7 @@ var Checks = map[string]CheckType{
 	"kademlia": {
 		NewAction: kademlia.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				Dynamic *bool `yaml:"dynamic"`
-			})
+			checkOpts := new(kademliaCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -211,15 +336,7 @@ var Checks = map[string]CheckType{
 	"manifest": {
 		NewAction: manifest.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				FilesInCollection *int    `yaml:"files-in-collection"`
-				GasPrice          *string `yaml:"gas-price"`
-				MaxPathnameLength *int32  `yaml:"max-pathname-length"`
-				PostageAmount     *int64  `yaml:"postage-amount"`
-				PostageDepth      *uint64 `yaml:"postage-depth"`
-				PostageLabel      *string `yaml:"postage-label"`
-				Seed              *int64  `yaml:"seed"`
-			})
+			checkOpts := new(manifestCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -248,16 +365,7 @@ var Checks = map[string]CheckType{
 	"pss": {
 		NewAction: pss.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				Count          *int64         `yaml:"count"`
-				AddressPrefix  *int           `yaml:"address-prefix"`
-				GasPrice       *string        `yaml:"gas-price"`
-				PostageAmount  *int64         `yaml:"postage-amount"`
-				PostageDepth   *uint64        `yaml:"postage-depth"`
-				PostageLabel   *string        `yaml:"postage-label"`
-				RequestTimeout *time.Duration `yaml:"request-timeout"`
-				Seed           *int64         `yaml:"seed"`
-			})
+			checkOpts := new(pssCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -273,15 +381,7 @@ var Checks = map[string]CheckType{
 	"pullsync": {
 		NewAction: pullsync.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				ChunksPerNode              *int    `yaml:"chunks-per-node"`
-				GasPrice                   *string `yaml:"gas-price"`
-				PostageAmount              *int64  `yaml:"postage-amount"`
-				PostageLabel               *string `yaml:"postage-label"`
-				ReplicationFactorThreshold *int    `yaml:"replication-factor-threshold"`
-				Seed                       *int64  `yaml:"seed"`
-				UploadNodeCount            *int    `yaml:"upload-node-count"`
-			})
+			checkOpts := new(pullsyncCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -297,19 +397,7 @@ var Checks = map[string]CheckType{
 	"pushsync": {
 		NewAction: pushsync.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				ChunksPerNode     *int           `yaml:"chunks-per-node"`
-				GasPrice          *string        `yaml:"gas-price"`
-				Mode              *string        `yaml:"mode"`
-				PostageAmount     *int64         `yaml:"postage-amount"`
-				PostageDepth      *uint64        `yaml:"postage-depth"`
-				PostageLabel      *string        `yaml:"postage-label"`
-				Retries           *int           `yaml:"retries"`
-				RetryDelay        *time.Duration `yaml:"retry-delay"`
-				Seed              *int64         `yaml:"seed"`
-				UploadNodeCount   *int           `yaml:"upload-node-count"`
-				ExcludeNodeGroups *[]string      `yaml:"exclude-node-group"`
-			})
+			checkOpts := new(pushsyncCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -325,15 +413,7 @@ var Checks = map[string]CheckType{
 	"retrieval": {
 		NewAction: retrieval.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				ChunksPerNode   *int    `yaml:"chunks-per-node"`
-				GasPrice        *string `yaml:"gas-price"`
-				PostageAmount   *int64  `yaml:"postage-amount"`
-				PostageDepth    *uint64 `yaml:"postage-depth"`
-				PostageLabel    *string `yaml:"postage-label"`
-				Seed            *int64  `yaml:"seed"`
-				UploadNodeCount *int    `yaml:"upload-node-count"`
-			})
+			checkOpts := new(retrievalCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -349,20 +429,7 @@ var Checks = map[string]CheckType{
 	"settlements": {
 		NewAction: settlements.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				DryRun             *bool          `yaml:"dry-run"`
-				ExpectSettlements  *bool          `yaml:"expect-settlements"`
-				FileName           *string        `yaml:"file-name"`
-				FileSize           *int64         `yaml:"file-size"`
-				GasPrice           *string        `yaml:"gas-price"`
-				PostageAmount      *int64         `yaml:"postage-amount"`
-				PostageDepth       *uint64        `yaml:"postage-depth"`
-				PostageLabel       *string        `yaml:"postage-label"`
-				Seed               *int64         `yaml:"seed"`
-				Threshold          *int64         `yaml:"threshold"`
-				UploadNodeCount    *int           `yaml:"upload-node-count"`
-				WaitBeforeDownload *time.Duration `yaml:"wait-before-download"`
-			})
+			checkOpts := new(settlementsCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -378,16 +445,7 @@ var Checks = map[string]CheckType{
 	"smoke": {
 		NewAction: smoke.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				ContentSize   *int64         `yaml:"content-size"`
-				RndSeed       *int64         `yaml:"rnd-seed"`
-				PostageAmount *int64         `yaml:"postage-amount"`
-				PostageDepth  *uint64        `yaml:"postage-depth"`
-				TxOnErrWait   *time.Duration `yaml:"tx-on-err-wait"`
-				RxOnErrWait   *time.Duration `yaml:"rx-on-err-wait"`
-				NodesSyncWait *time.Duration `yaml:"nodes-sync-wait"`
-				Duration      *time.Duration `yaml:"duration"`
-			})
+			checkOpts := new(smokeCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -403,21 +461,7 @@ var Checks = map[string]CheckType{
 	"load": {
 		NewAction: smoke.NewLoadCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				ContentSize     *int64         `yaml:"content-size"`
-				RndSeed         *int64         `yaml:"rnd-seed"`
-				PostageAmount   *int64         `yaml:"postage-amount"`
-				PostageDepth    *uint64        `yaml:"postage-depth"`
-				GasPrice        *string        `yaml:"gas-price"`
-				TxOnErrWait     *time.Duration `yaml:"tx-on-err-wait"`
-				RxOnErrWait     *time.Duration `yaml:"rx-on-err-wait"`
-				NodesSyncWait   *time.Duration `yaml:"nodes-sync-wait"`
-				Duration        *time.Duration `yaml:"duration"`
-				UploaderCount   *int           `yaml:"uploader-count"`
-				UploadGroups    *[]string      `yaml:"upload-groups"`
-				DownloaderCount *int           `yaml:"downloader-count"`
-				DownloadGroups  *[]string      `yaml:"download-groups"`
-			})
+			checkOpts := new(loadCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -433,13 +477,7 @@ var Checks = map[string]CheckType{
 	"soc": {
 		NewAction: soc.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				GasPrice       *string        `yaml:"gas-price"`
-				PostageAmount  *int64         `yaml:"postage-amount"`
-				PostageDepth   *uint64        `yaml:"postage-depth"`
-				PostageLabel   *string        `yaml:"postage-label"`
-				RequestTimeout *time.Duration `yaml:"request-timeout"`
-			})
+			checkOpts := new(socCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -455,14 +493,7 @@ var Checks = map[string]CheckType{
 	"content-availability": {
 		NewAction: contentavailability.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				ContentSize   *int64  `yaml:"content-size"`
-				GasPrice      *string `yaml:"gas-price"`
-				PostageAmount *int64  `yaml:"postage-amount"`
-				PostageDepth  *uint64 `yaml:"postage-depth"`
-				PostageLabel  *string `yaml:"postage-label"`
-				Seed          *int64  `yaml:"seed"`
-			})
+			checkOpts := new(contentAvailabilityCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -476,14 +507,7 @@ var Checks = map[string]CheckType{
 	"postage": {
 		NewAction: postage.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				GasPrice           *string `yaml:"gas-price"`
-				PostageAmount      *int64  `yaml:"postage-amount"`
-				PostageTopupAmount *int64  `yaml:"postage-topup-amount"`
-				PostageDepth       *uint64 `yaml:"postage-depth"`
-				PostageNewDepth    *uint64 `yaml:"postage-new-depth"`
-				PostageLabel       *string `yaml:"postage-label"`
-			})
+			checkOpts := new(postageCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -497,14 +521,7 @@ var Checks = map[string]CheckType{
 	"stake": {
 		NewAction: stake.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				Amount             *big.Int `yaml:"amount"`
-				InsufficientAmount *big.Int `yaml:"insufficient-amount"`
-				ContractAddr       *string  `yaml:"contract-addr"`
-				CallerPrivateKey   *string  `yaml:"private-key"`
-				GethURL            *string  `yaml:"geth-url"`
-				GethChainID        *big.Int `yaml:"geth-chain-id"`
-			})
+			checkOpts := new(stakeCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -518,12 +535,7 @@ var Checks = map[string]CheckType{
 	"authenticate": {
 		NewAction: authenticated.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
-			checkOpts := new(struct {
-				DryRun              *bool   `yaml:"dry-run"`
-				Role                *string `yaml:"role"`
-				AdminPassword       *string `yaml:"admin-password"`
-				RestrictedGroupName *string `yaml:"restricted-group-name"`
-			})
+			checkOpts := new(authenticateCheckOptions)
 			if err := check.Options.Decode(checkOpts); err != nil {
 				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
 			}
@@ -534,6 +546,326 @@ var Checks = map[string]CheckType{
 			return opts, nil
 		},
 	},
+	"autotune": {
+		NewAction: autotune.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(autotuneCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := autotune.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"restarts": {
+		NewAction: restarts.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(restartsCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := restarts.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"chequebook": {
+		NewAction: chequebook.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(chequebookCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := chequebook.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"withdraw": {
+		NewAction: withdraw.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(withdrawCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := withdraw.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"pinrace": {
+		NewAction: pinrace.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(pinraceCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := pinrace.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"lightnode": {
+		NewAction: lightnode.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(lightnodeCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := lightnode.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"rollingrestart": {
+		NewAction: rollingrestart.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(rollingrestartCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := rollingrestart.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"durability": {
+		NewAction: durability.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(durabilityCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := durability.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"apicompat": {
+		NewAction: apicompat.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(apicompatCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := apicompat.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"openapi": {
+		NewAction: openapi.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(openapiCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := openapi.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"apifuzz": {
+		NewAction: apifuzz.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(apifuzzCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := apifuzz.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"dedup": {
+		NewAction: dedup.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(dedupCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := dedup.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"accounting": {
+		NewAction: accounting.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(accountingCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := accounting.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"threshold": {
+		NewAction: threshold.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(thresholdCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := threshold.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"crashrecovery": {
+		NewAction: crashrecovery.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(crashrecoveryCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := crashrecovery.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"migration": {
+		NewAction: migration.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(migrationCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := migration.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"cache": {
+		NewAction: cache.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(cacheCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := cache.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"cid": {
+		NewAction: cid.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(cidCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := cid.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"uploadmode": {
+		NewAction: uploadmode.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(uploadmodeCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := uploadmode.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
+	"plugin": {
+		NewAction: plugin.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(pluginCheckOptions)
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := plugin.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
 }
 
 // applyCheckConfig merges global and local options into default options
@@ -579,3 +911,494 @@ func applyCheckConfig(global CheckGlobalConfig, local, opts interface{}) (err er
 
 	return
 }
+
+// options of check types read from the configuration, unset ones keep
+// their defaults
+type (
+	balancesCheckOptions struct {
+		DryRun             *bool          `yaml:"dry-run"`
+		FileName           *string        `yaml:"file-name"`
+		FileSize           *int64         `yaml:"file-size"`
+		GasPrice           *string        `yaml:"gas-price"`
+		PostageAmount      *int64         `yaml:"postage-amount"`
+		PostageDepth       *uint64        `yaml:"postage-depth"`
+		PostageLabel       *string        `yaml:"postage-label"`
+		Seed               *int64         `yaml:"seed"`
+		UploadNodeCount    *int           `yaml:"upload-node-count"`
+		WaitBeforeDownload *time.Duration `yaml:"wait-before-download"`
+	}
+	cashoutCheckOptions struct {
+		NodeGroup *string `yaml:"node-group"`
+	}
+	chunkRepairCheckOptions struct {
+		GasPrice               *string `yaml:"gas-price"`
+		NodeGroup              *string `yaml:"node-group"`
+		NumberOfChunksToRepair *int    `yaml:"number-of-chunks-to-repair"`
+		PostageAmount          *int64  `yaml:"postage-amount"`
+		PostageLabel           *string `yaml:"postage-label"`
+		Seed                   *int64  `yaml:"seed"`
+	}
+	fileRetrievalCheckOptions struct {
+		FileName        *string `yaml:"file-name"`
+		FileSize        *int64  `yaml:"file-size"`
+		FilesPerNode    *int    `yaml:"files-per-node"`
+		Full            *bool   `yaml:"full"`
+		GasPrice        *string `yaml:"gas-price"`
+		PostageAmount   *int64  `yaml:"postage-amount"`
+		PostageLabel    *string `yaml:"postage-label"`
+		Seed            *int64  `yaml:"seed"`
+		UploadNodeCount *int    `yaml:"upload-node-count"`
+	}
+	fullConnectivityCheckOptions struct {
+		LightNodeNames *[]string `yaml:"group-1"`
+		FullNodeNames  *[]string `yaml:"group-2"`
+		BootNodeNames  *[]string `yaml:"boot-nodes"`
+	}
+	gcCheckOptions struct {
+		CacheSize    *int    `yaml:"cache-size"`
+		GasPrice     *string `yaml:"gas-price"`
+		MaxDBGrowth  *int64  `yaml:"max-db-growth"`
+		PostageLabel *string `yaml:"postage-label"`
+		ReserveSize  *int    `yaml:"reserve-size"`
+		Seed         *int64  `yaml:"seed"`
+	}
+	kademliaCheckOptions struct {
+		Dynamic *bool `yaml:"dynamic"`
+	}
+	manifestCheckOptions struct {
+		FilesInCollection *int    `yaml:"files-in-collection"`
+		GasPrice          *string `yaml:"gas-price"`
+		MaxPathnameLength *int32  `yaml:"max-pathname-length"`
+		PostageAmount     *int64  `yaml:"postage-amount"`
+		PostageDepth      *uint64 `yaml:"postage-depth"`
+		PostageLabel      *string `yaml:"postage-label"`
+		Seed              *int64  `yaml:"seed"`
+	}
+	pssCheckOptions struct {
+		Count             *int64         `yaml:"count"`
+		AddressPrefix     *int           `yaml:"address-prefix"`
+		GasPrice          *string        `yaml:"gas-price"`
+		MessagesPerSender *int           `yaml:"messages-per-sender"`
+		MinDeliveryRatio  *float64       `yaml:"min-delivery-ratio"`
+		Mode              *string        `yaml:"mode"`
+		PostageAmount     *int64         `yaml:"postage-amount"`
+		PostageDepth      *uint64        `yaml:"postage-depth"`
+		PostageLabel      *string        `yaml:"postage-label"`
+		ReceiveTimeout    *time.Duration `yaml:"receive-timeout"`
+		RequestTimeout    *time.Duration `yaml:"request-timeout"`
+		Seed              *int64         `yaml:"seed"`
+		Senders           *int           `yaml:"senders"`
+		TargetCount       *int           `yaml:"target-count"`
+	}
+	pullsyncCheckOptions struct {
+		ChunksPerNode              *int    `yaml:"chunks-per-node"`
+		GasPrice                   *string `yaml:"gas-price"`
+		PostageAmount              *int64  `yaml:"postage-amount"`
+		PostageLabel               *string `yaml:"postage-label"`
+		ReplicationFactorThreshold *int    `yaml:"replication-factor-threshold"`
+		Seed                       *int64  `yaml:"seed"`
+		UploadNodeCount            *int    `yaml:"upload-node-count"`
+	}
+	pushsyncCheckOptions struct {
+		ChunksPerNode     *int           `yaml:"chunks-per-node"`
+		GasPrice          *string        `yaml:"gas-price"`
+		Mode              *string        `yaml:"mode"`
+		PostageAmount     *int64         `yaml:"postage-amount"`
+		PostageDepth      *uint64        `yaml:"postage-depth"`
+		PostageLabel      *string        `yaml:"postage-label"`
+		Retries           *int           `yaml:"retries"`
+		RetryDelay        *time.Duration `yaml:"retry-delay"`
+		Seed              *int64         `yaml:"seed"`
+		UploadNodeCount   *int           `yaml:"upload-node-count"`
+		ExcludeNodeGroups *[]string      `yaml:"exclude-node-group"`
+	}
+	retrievalCheckOptions struct {
+		ChunksPerNode   *int    `yaml:"chunks-per-node"`
+		GasPrice        *string `yaml:"gas-price"`
+		PostageAmount   *int64  `yaml:"postage-amount"`
+		PostageDepth    *uint64 `yaml:"postage-depth"`
+		PostageLabel    *string `yaml:"postage-label"`
+		Seed            *int64  `yaml:"seed"`
+		UploadNodeCount *int    `yaml:"upload-node-count"`
+	}
+	settlementsCheckOptions struct {
+		DryRun             *bool          `yaml:"dry-run"`
+		ExpectSettlements  *bool          `yaml:"expect-settlements"`
+		FileName           *string        `yaml:"file-name"`
+		FileSize           *int64         `yaml:"file-size"`
+		GasPrice           *string        `yaml:"gas-price"`
+		PostageAmount      *int64         `yaml:"postage-amount"`
+		PostageDepth       *uint64        `yaml:"postage-depth"`
+		PostageLabel       *string        `yaml:"postage-label"`
+		Seed               *int64         `yaml:"seed"`
+		Threshold          *int64         `yaml:"threshold"`
+		UploadNodeCount    *int           `yaml:"upload-node-count"`
+		WaitBeforeDownload *time.Duration `yaml:"wait-before-download"`
+	}
+	smokeCheckOptions struct {
+		ContentSize   *int64         `yaml:"content-size"`
+		RndSeed       *int64         `yaml:"rnd-seed"`
+		PostageAmount *int64         `yaml:"postage-amount"`
+		PostageDepth  *uint64        `yaml:"postage-depth"`
+		TxOnErrWait   *time.Duration `yaml:"tx-on-err-wait"`
+		RxOnErrWait   *time.Duration `yaml:"rx-on-err-wait"`
+		NodesSyncWait *time.Duration `yaml:"nodes-sync-wait"`
+		Duration      *time.Duration `yaml:"duration"`
+	}
+	loadCheckOptions struct {
+		ContentSize     *int64         `yaml:"content-size"`
+		RndSeed         *int64         `yaml:"rnd-seed"`
+		PostageAmount   *int64         `yaml:"postage-amount"`
+		PostageDepth    *uint64        `yaml:"postage-depth"`
+		GasPrice        *string        `yaml:"gas-price"`
+		TxOnErrWait     *time.Duration `yaml:"tx-on-err-wait"`
+		RxOnErrWait     *time.Duration `yaml:"rx-on-err-wait"`
+		NodesSyncWait   *time.Duration `yaml:"nodes-sync-wait"`
+		Duration        *time.Duration `yaml:"duration"`
+		UploaderCount   *int           `yaml:"uploader-count"`
+		UploadGroups    *[]string      `yaml:"upload-groups"`
+		DownloaderCount *int           `yaml:"downloader-count"`
+		DownloadGroups  *[]string      `yaml:"download-groups"`
+	}
+	socCheckOptions struct {
+		GasPrice       *string        `yaml:"gas-price"`
+		PostageAmount  *int64         `yaml:"postage-amount"`
+		PostageDepth   *uint64        `yaml:"postage-depth"`
+		PostageLabel   *string        `yaml:"postage-label"`
+		RequestTimeout *time.Duration `yaml:"request-timeout"`
+	}
+	contentAvailabilityCheckOptions struct {
+		ContentSize   *int64  `yaml:"content-size"`
+		GasPrice      *string `yaml:"gas-price"`
+		PostageAmount *int64  `yaml:"postage-amount"`
+		PostageDepth  *uint64 `yaml:"postage-depth"`
+		PostageLabel  *string `yaml:"postage-label"`
+		Seed          *int64  `yaml:"seed"`
+	}
+	postageCheckOptions struct {
+		GasPrice           *string `yaml:"gas-price"`
+		PostageAmount      *int64  `yaml:"postage-amount"`
+		PostageTopupAmount *int64  `yaml:"postage-topup-amount"`
+		PostageDepth       *uint64 `yaml:"postage-depth"`
+		PostageNewDepth    *uint64 `yaml:"postage-new-depth"`
+		PostageLabel       *string `yaml:"postage-label"`
+	}
+	stakeCheckOptions struct {
+		Amount             *big.Int `yaml:"amount"`
+		InsufficientAmount *big.Int `yaml:"insufficient-amount"`
+		ContractAddr       *string  `yaml:"contract-addr"`
+		CallerPrivateKey   *string  `yaml:"private-key"`
+		GethURL            *string  `yaml:"geth-url"`
+		GethChainID        *big.Int `yaml:"geth-chain-id"`
+	}
+	authenticateCheckOptions struct {
+		DryRun              *bool   `yaml:"dry-run"`
+		Role                *string `yaml:"role"`
+		AdminPassword       *string `yaml:"admin-password"`
+		RestrictedGroupName *string `yaml:"restricted-group-name"`
+	}
+	autotuneCheckOptions struct {
+		ContentSize        *int64         `yaml:"content-size"`
+		Duration           *time.Duration `yaml:"duration"`
+		GasPrice           *string        `yaml:"gas-price"`
+		PostageAmount      *int64         `yaml:"postage-amount"`
+		PostageDepth       *uint64        `yaml:"postage-depth"`
+		PostageLabel       *string        `yaml:"postage-label"`
+		Seed               *int64         `yaml:"seed"`
+		UploadGroups       *[]string      `yaml:"upload-groups"`
+		InitialConcurrency *int           `yaml:"initial-concurrency"`
+		MinConcurrency     *int           `yaml:"min-concurrency"`
+		MaxConcurrency     *int           `yaml:"max-concurrency"`
+		IncreaseStep       *float64       `yaml:"increase-step"`
+		BackoffFactor      *float64       `yaml:"backoff-factor"`
+		MaxErrorRate       *float64       `yaml:"max-error-rate"`
+		LatencyTarget      *time.Duration `yaml:"latency-target"`
+		WindowSize         *int           `yaml:"window-size"`
+		ConvergeWindows    *int           `yaml:"converge-windows"`
+	}
+	restartsCheckOptions struct {
+		MaxRestarts  *int32    `yaml:"max-restarts"`
+		FailOnOOM    *bool     `yaml:"fail-on-oom"`
+		FailOnCrash  *bool     `yaml:"fail-on-crash"`
+		IgnoreGroups *[]string `yaml:"ignore-groups"`
+	}
+	chequebookCheckOptions struct {
+		FileSize      *int64         `yaml:"file-size"`
+		GasPrice      *string        `yaml:"gas-price"`
+		MaxUploads    *int           `yaml:"max-uploads"`
+		PostageAmount *int64         `yaml:"postage-amount"`
+		PostageDepth  *uint64        `yaml:"postage-depth"`
+		PostageLabel  *string        `yaml:"postage-label"`
+		Retries       *int           `yaml:"retries"`
+		RetryDelay    *time.Duration `yaml:"retry-delay"`
+		Seed          *int64         `yaml:"seed"`
+		UploadNode    *string        `yaml:"upload-node"`
+	}
+	withdrawCheckOptions struct {
+		BzzAmount             *int64         `yaml:"bzz-amount"`
+		NativeAmount          *int64         `yaml:"native-amount"`
+		NodeName              *string        `yaml:"node-name"`
+		DestinationNodeName   *string        `yaml:"destination-node-name"`
+		NonWhitelistedAddress *string        `yaml:"non-whitelisted-address"`
+		Retries               *int           `yaml:"retries"`
+		RetryDelay            *time.Duration `yaml:"retry-delay"`
+	}
+	pinraceCheckOptions struct {
+		GasPrice        *string        `yaml:"gas-price"`
+		PinnedChunks    *int           `yaml:"pinned-chunks"`
+		PinDelay        *time.Duration `yaml:"pin-delay"`
+		PostageAmount   *int64         `yaml:"postage-amount"`
+		PostageDepth    *uint64        `yaml:"postage-depth"`
+		PostageLabel    *string        `yaml:"postage-label"`
+		PressureChunks  *int           `yaml:"pressure-chunks"`
+		PressureWorkers *int           `yaml:"pressure-workers"`
+		Seed            *int64         `yaml:"seed"`
+		VerifyDuration  *time.Duration `yaml:"verify-duration"`
+		VerifyInterval  *time.Duration `yaml:"verify-interval"`
+	}
+	lightnodeCheckOptions struct {
+		ChunksPerNode *int           `yaml:"chunks-per-node"`
+		FileSize      *int64         `yaml:"file-size"`
+		GasPrice      *string        `yaml:"gas-price"`
+		PostageAmount *int64         `yaml:"postage-amount"`
+		PostageDepth  *uint64        `yaml:"postage-depth"`
+		PostageLabel  *string        `yaml:"postage-label"`
+		Retries       *int           `yaml:"retries"`
+		RetryDelay    *time.Duration `yaml:"retry-delay"`
+		Seed          *int64         `yaml:"seed"`
+		SyncWait      *time.Duration `yaml:"sync-wait"`
+	}
+	rollingrestartCheckOptions struct {
+		ContentSize      *int64         `yaml:"content-size"`
+		GasPrice         *string        `yaml:"gas-price"`
+		MaxHungUploads   *int           `yaml:"max-hung-uploads"`
+		PostageAmount    *int64         `yaml:"postage-amount"`
+		PostageDepth     *uint64        `yaml:"postage-depth"`
+		PostageLabel     *string        `yaml:"postage-label"`
+		ReadyTimeout     *time.Duration `yaml:"ready-timeout"`
+		RestartGroups    *[]string      `yaml:"restart-groups"`
+		RetrievalRetries *int           `yaml:"retrieval-retries"`
+		RetryDelay       *time.Duration `yaml:"retry-delay"`
+		Seed             *int64         `yaml:"seed"`
+		StopDelay        *time.Duration `yaml:"stop-delay"`
+		UploadInterval   *time.Duration `yaml:"upload-interval"`
+		UploadNode       *string        `yaml:"upload-node"`
+		UploadTimeout    *time.Duration `yaml:"upload-timeout"`
+	}
+	durabilityCheckOptions struct {
+		AgeBuckets      *[]time.Duration `yaml:"age-buckets"`
+		ContentSize     *int64           `yaml:"content-size"`
+		CorpusSize      *int             `yaml:"corpus-size"`
+		GasPrice        *string          `yaml:"gas-price"`
+		MinSuccessRatio *float64         `yaml:"min-success-ratio"`
+		PostageAmount   *int64           `yaml:"postage-amount"`
+		PostageDepth    *uint64          `yaml:"postage-depth"`
+		PostageLabel    *string          `yaml:"postage-label"`
+		Retries         *int             `yaml:"retries"`
+		RetryDelay      *time.Duration   `yaml:"retry-delay"`
+		SamplesPerAge   *int             `yaml:"samples-per-age"`
+		Seed            *int64           `yaml:"seed"`
+		StorePath       *string          `yaml:"store-path"`
+	}
+	apicompatCheckOptions struct {
+		ContentSize   *int64    `yaml:"content-size"`
+		GasPrice      *string   `yaml:"gas-price"`
+		NodeGroups    *[]string `yaml:"node-groups"`
+		PostageAmount *int64    `yaml:"postage-amount"`
+		PostageDepth  *uint64   `yaml:"postage-depth"`
+		PostageLabel  *string   `yaml:"postage-label"`
+		Seed          *int64    `yaml:"seed"`
+	}
+	openapiCheckOptions struct {
+		APIEndpoints      *[]string `yaml:"api-endpoints"`
+		APISpec           *string   `yaml:"api-spec"`
+		DebugAPIEndpoints *[]string `yaml:"debug-api-endpoints"`
+		DebugAPISpec      *string   `yaml:"debug-api-spec"`
+		NodeGroups        *[]string `yaml:"node-groups"`
+		StrictFields      *bool     `yaml:"strict-fields"`
+	}
+	apifuzzCheckOptions struct {
+		GasPrice           *string        `yaml:"gas-price"`
+		Iterations         *int           `yaml:"iterations"`
+		MaxGoroutineGrowth *int           `yaml:"max-goroutine-growth"`
+		NodeName           *string        `yaml:"node-name"`
+		PostageAmount      *int64         `yaml:"postage-amount"`
+		PostageDepth       *uint64        `yaml:"postage-depth"`
+		PostageLabel       *string        `yaml:"postage-label"`
+		RandomCases        *int           `yaml:"random-cases"`
+		RequestTimeout     *time.Duration `yaml:"request-timeout"`
+		Seed               *int64         `yaml:"seed"`
+		SettleTimeout      *time.Duration `yaml:"settle-timeout"`
+	}
+	dedupCheckOptions struct {
+		ContentSize       *int64         `yaml:"content-size"`
+		GasPrice          *string        `yaml:"gas-price"`
+		MaxStorageGrowth  *float64       `yaml:"max-storage-growth"`
+		PostageAmount     *int64         `yaml:"postage-amount"`
+		PostageDepth      *uint64        `yaml:"postage-depth"`
+		PostageLabel      *string        `yaml:"postage-label"`
+		ReserveSizeMetric *string        `yaml:"reserve-size-metric"`
+		Seed              *int64         `yaml:"seed"`
+		SyncWait          *time.Duration `yaml:"sync-wait"`
+		Uploaders         *int           `yaml:"uploaders"`
+	}
+	accountingCheckOptions struct {
+		DisconnectTolerance *int64         `yaml:"disconnect-tolerance"`
+		Downloaders         *int           `yaml:"downloaders"`
+		ExpectRefreshment   *bool          `yaml:"expect-refreshment"`
+		FileSize            *int64         `yaml:"file-size"`
+		GasPrice            *string        `yaml:"gas-price"`
+		LightRefreshRate    *int64         `yaml:"light-refresh-rate"`
+		MirrorRetries       *int           `yaml:"mirror-retries"`
+		MirrorRetryDelay    *time.Duration `yaml:"mirror-retry-delay"`
+		PostageAmount       *int64         `yaml:"postage-amount"`
+		PostageDepth        *uint64        `yaml:"postage-depth"`
+		PostageLabel        *string        `yaml:"postage-label"`
+		RefreshRate         *int64         `yaml:"refresh-rate"`
+		RefreshSlack        *time.Duration `yaml:"refresh-slack"`
+		Rounds              *int           `yaml:"rounds"`
+		Seed                *int64         `yaml:"seed"`
+		Uploaders           *int           `yaml:"uploaders"`
+	}
+	thresholdCheckOptions struct {
+		BasePrice         *int64         `yaml:"base-price"`
+		ChunkPO           *uint8         `yaml:"chunk-po"`
+		CreditorNode      *string        `yaml:"creditor-node"`
+		DebtorNode        *string        `yaml:"debtor-node"`
+		EarlyPayment      *int64         `yaml:"early-payment"`
+		GasPrice          *string        `yaml:"gas-price"`
+		MaxChunks         *int           `yaml:"max-chunks"`
+		PaymentThreshold  *int64         `yaml:"payment-threshold"`
+		PostageAmount     *int64         `yaml:"postage-amount"`
+		PostageDepth      *uint64        `yaml:"postage-depth"`
+		PostageLabel      *string        `yaml:"postage-label"`
+		Seed              *int64         `yaml:"seed"`
+		SettlementTimeout *time.Duration `yaml:"settlement-timeout"`
+	}
+	crashrecoveryCheckOptions struct {
+		ContentCount      *int           `yaml:"content-count"`
+		ContentSize       *int64         `yaml:"content-size"`
+		GasPrice          *string        `yaml:"gas-price"`
+		KillDelay         *time.Duration `yaml:"kill-delay"`
+		MinReserveRatio   *float64       `yaml:"min-reserve-ratio"`
+		NodeName          *string        `yaml:"node-name"`
+		PostageAmount     *int64         `yaml:"postage-amount"`
+		PostageDepth      *uint64        `yaml:"postage-depth"`
+		PostageLabel      *string        `yaml:"postage-label"`
+		ReadyTimeout      *time.Duration `yaml:"ready-timeout"`
+		ReserveSizeMetric *string        `yaml:"reserve-size-metric"`
+		RetrievalRetries  *int           `yaml:"retrieval-retries"`
+		RetryDelay        *time.Duration `yaml:"retry-delay"`
+		Seed              *int64         `yaml:"seed"`
+		UploadInterval    *time.Duration `yaml:"upload-interval"`
+	}
+	migrationCheckOptions struct {
+		ContentCount  *int           `yaml:"content-count"`
+		ContentSize   *int64         `yaml:"content-size"`
+		Downgrade     *bool          `yaml:"downgrade"`
+		FromImage     *string        `yaml:"from-image"`
+		GasPrice      *string        `yaml:"gas-price"`
+		NodeGroups    *[]string      `yaml:"node-groups"`
+		PostageAmount *int64         `yaml:"postage-amount"`
+		PostageDepth  *uint64        `yaml:"postage-depth"`
+		PostageLabel  *string        `yaml:"postage-label"`
+		ReadyTimeout  *time.Duration `yaml:"ready-timeout"`
+		Seed          *int64         `yaml:"seed"`
+		ToImage       *string        `yaml:"to-image"`
+	}
+	cacheCheckOptions struct {
+		ContentSize      *int64         `yaml:"content-size"`
+		GasPrice         *string        `yaml:"gas-price"`
+		MaxCachedRatio   *float64       `yaml:"max-cached-ratio"`
+		PostageAmount    *int64         `yaml:"postage-amount"`
+		PostageDepth     *uint64        `yaml:"postage-depth"`
+		PostageLabel     *string        `yaml:"postage-label"`
+		RetrievalRetries *int           `yaml:"retrieval-retries"`
+		RetryDelay       *time.Duration `yaml:"retry-delay"`
+		Rounds           *int           `yaml:"rounds"`
+		Seed             *int64         `yaml:"seed"`
+		SyncWait         *time.Duration `yaml:"sync-wait"`
+		UploadNode       *string        `yaml:"upload-node"`
+	}
+	cidCheckOptions struct {
+		FileCount        *int           `yaml:"file-count"`
+		FileName         *string        `yaml:"file-name"`
+		FileSize         *int64         `yaml:"file-size"`
+		GasPrice         *string        `yaml:"gas-price"`
+		PostageAmount    *int64         `yaml:"postage-amount"`
+		PostageDepth     *uint64        `yaml:"postage-depth"`
+		PostageLabel     *string        `yaml:"postage-label"`
+		RetrievalRetries *int           `yaml:"retrieval-retries"`
+		RetryDelay       *time.Duration `yaml:"retry-delay"`
+		Seed             *int64         `yaml:"seed"`
+		UploadNode       *string        `yaml:"upload-node"`
+	}
+	uploadmodeCheckOptions struct {
+		ContentCount    *int           `yaml:"content-count"`
+		ContentSize     *int64         `yaml:"content-size"`
+		GasPrice        *string        `yaml:"gas-price"`
+		PollInterval    *time.Duration `yaml:"poll-interval"`
+		PostageAmount   *int64         `yaml:"postage-amount"`
+		PostageDepth    *uint64        `yaml:"postage-depth"`
+		PostageLabel    *string        `yaml:"postage-label"`
+		RemoteNodes     *int           `yaml:"remote-nodes"`
+		RetrievableWait *time.Duration `yaml:"retrievable-wait"`
+		Seed            *int64         `yaml:"seed"`
+		UploadNode      *string        `yaml:"upload-node"`
+	}
+	pluginCheckOptions struct {
+		Args    *[]string               `yaml:"args"`
+		Options *map[string]interface{} `yaml:"options"`
+		Path    *string                 `yaml:"path"`
+		Seed    *int64                  `yaml:"seed"`
+	}
+)
+
+// checkOptions registers types of options of check types read from the
+// configuration together with their defaults, check types without options
+// are not registered
+var checkOptions = map[string]struct {
+	local    reflect.Type
+	defaults interface{}
+}{
+	"balances":             {reflect.TypeOf(balancesCheckOptions{}), balances.NewDefaultOptions()},
+	"cashout":              {reflect.TypeOf(cashoutCheckOptions{}), cashout.NewDefaultOptions()},
+	"chunk-repair":         {reflect.TypeOf(chunkRepairCheckOptions{}), chunkrepair.NewDefaultOptions()},
+	"file-retrieval":       {reflect.TypeOf(fileRetrievalCheckOptions{}), fileretrieval.NewDefaultOptions()},
+	"full-connectivity":    {reflect.TypeOf(fullConnectivityCheckOptions{}), fullconnectivity.NewDefaultOptions()},
+	"gc":                   {reflect.TypeOf(gcCheckOptions{}), gc.NewDefaultOptions()},
+	"kademlia":             {reflect.TypeOf(kademliaCheckOptions{}), kademlia.NewDefaultOptions()},
+	"manifest":             {reflect.TypeOf(manifestCheckOptions{}), manifest.NewDefaultOptions()},
+	"pss":                  {reflect.TypeOf(pssCheckOptions{}), pss.NewDefaultOptions()},
+	"pullsync":             {reflect.TypeOf(pullsyncCheckOptions{}), pullsync.NewDefaultOptions()},
+	"pushsync":             {reflect.TypeOf(pushsyncCheckOptions{}), pushsync.NewDefaultOptions()},
+	"retrieval":            {reflect.TypeOf(retrievalCheckOptions{}), retrieval.NewDefaultOptions()},
+	"settlements":          {reflect.TypeOf(settlementsCheckOptions{}), settlements.NewDefaultOptions()},
+	"smoke":                {reflect.TypeOf(smokeCheckOptions{}), smoke.NewDefaultOptions()},
+	"load":                 {reflect.TypeOf(loadCheckOptions{}), smoke.NewDefaultOptions()},
+	"soc":                  {reflect.TypeOf(socCheckOptions{}), soc.NewDefaultOptions()},
+	"content-availability": {reflect.TypeOf(contentAvailabilityCheckOptions{}), contentavailability.NewDefaultOptions()},
+	"postage":              {reflect.TypeOf(postageCheckOptions{}), postage.NewDefaultOptions()},
+	"stake":                {reflect.TypeOf(stakeCheckOptions{}), stake.NewDefaultOptions()},
+	"authenticate":         {reflect.TypeOf(authenticateCheckOptions{}), authenticated.NewDefaultOptions()},
+	"autotune":             {reflect.TypeOf(autotuneCheckOptions{}), autotune.NewDefaultOptions()},
+	"restarts":             {reflect.TypeOf(restartsCheckOptions{}), restarts.NewDefaultOptions()},
+	"chequebook":           {reflect.TypeOf(chequebookCheckOptions{}), chequebook.NewDefaultOptions()},
+	"withdraw":             {reflect.TypeOf(withdrawCheckOptions{}), withdraw.NewDefaultOptions()},
+	"pinrace":              {reflect.TypeOf(pinraceCheckOptions{}), pinrace.NewDefaultOptions()},
+	"lightnode":            {reflect.TypeOf(lightnodeCheckOptions{}), lightnode.NewDefaultOptions()},
+	"rollingrestart":       {reflect.TypeOf(rollingrestartCheckOptions{}), rollingrestart.NewDefaultOptions()},
+	"durability":           {reflect.TypeOf(durabilityCheckOptions{}), durability.NewDefaultOptions()},
+	"apicompat":            {reflect.TypeOf(apicompatCheckOptions{}), apicompat.NewDefaultOptions()},
+	"openapi":              {reflect.TypeOf(openapiCheckOptions{}), openapi.NewDefaultOptions()},
+	"apifuzz":              {reflect.TypeOf(apifuzzCheckOptions{}), apifuzz.NewDefaultOptions()},
+	"dedup":                {reflect.TypeOf(dedupCheckOptions{}), dedup.NewDefaultOptions()},
+	"accounting":           {reflect.TypeOf(accountingCheckOptions{}), accounting.NewDefaultOptions()},
+	"threshold":            {reflect.TypeOf(thresholdCheckOptions{}), threshold.NewDefaultOptions()},
+	"crashrecovery":        {reflect.TypeOf(crashrecoveryCheckOptions{}), crashrecovery.NewDefaultOptions()},
+	"migration":            {reflect.TypeOf(migrationCheckOptions{}), migration.NewDefaultOptions()},
+	"cache":                {reflect.TypeOf(cacheCheckOptions{}), cache.NewDefaultOptions()},
+	"cid":                  {reflect.TypeOf(cidCheckOptions{}), cid.NewDefaultOptions()},
+	"uploadmode":           {reflect.TypeOf(uploadmodeCheckOptions{}), uploadmode.NewDefaultOptions()},
+	"plugin":               {reflect.TypeOf(pluginCheckOptions{}), plugin.NewDefaultOptions()},
+}
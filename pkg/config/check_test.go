@@ -0,0 +1,211 @@
+package config_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/check/pinrace"
+	"github.com/ethersphere/beekeeper/pkg/check/withdraw"
+	"github.com/ethersphere/beekeeper/pkg/config"
+)
+
+func TestCheckOrder(t *testing.T) {
+	cfg := &config.Config{Checks: map[string]config.Check{
+		"upload":   {},
+		"fund":     {},
+		"download": {DependsOn: []string{"upload"}},
+		"settle":   {DependsOn: []string{"fund", "upload"}},
+		"report":   {DependsOn: []string{"settle", "download"}},
+		"a":        {DependsOn: []string{"b"}},
+		"b":        {DependsOn: []string{"c"}},
+		"c":        {DependsOn: []string{"a"}},
+		"self":     {DependsOn: []string{"self"}},
+		"broken":   {DependsOn: []string{"upload", "missing"}},
+	}}
+
+	for _, tc := range []struct {
+		name    string
+		names   []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "no dependencies",
+			names: []string{"fund", "upload"},
+			want:  []string{"fund", "upload"},
+		},
+		{
+			name:  "dependencies first",
+			names: []string{"download"},
+			want:  []string{"upload", "download"},
+		},
+		{
+			// dependencies are ordered as they are listed, checks already
+			// ordered are not repeated
+			name:  "stable order",
+			names: []string{"report", "upload", "fund"},
+			want:  []string{"fund", "upload", "settle", "download", "report"},
+		},
+		{
+			name:  "named dependency",
+			names: []string{"upload", "download", "upload"},
+			want:  []string{"upload", "download"},
+		},
+		{
+			name:    "cycle",
+			names:   []string{"fund", "a"},
+			wantErr: "check dependency cycle: a -> b -> c -> a",
+		},
+		{
+			name:    "self dependency",
+			names:   []string{"self"},
+			wantErr: "check dependency cycle: self -> self",
+		},
+		{
+			name:    "missing dependency",
+			names:   []string{"broken"},
+			wantErr: "check 'broken' depends on check 'missing' that doesn't exist",
+		},
+		{
+			name:    "missing check",
+			names:   []string{"upload", "missing"},
+			wantErr: "check 'missing' doesn't exist",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := cfg.CheckOrder(tc.names)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got order %v with error %v, want error %q", got, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got order %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRestoreCheckOptions(t *testing.T) {
+	recorded := pinrace.NewDefaultOptions()
+	recorded.Seed = 42
+	recorded.PinDelay = 250 * time.Millisecond
+	recorded.PostageLabel = "replay"
+	data, err := json.Marshal(recorded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withdrawDefaults := withdraw.NewDefaultOptions()
+	withdrawPartial := withdraw.NewDefaultOptions()
+	withdrawPartial.NodeName = "bee-1"
+
+	for _, tc := range []struct {
+		name      string
+		checkType string
+		data      string
+		want      interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "recorded options",
+			checkType: "pinrace",
+			data:      string(data),
+			want:      recorded,
+		},
+		{
+			// fields left out of the recording, like secrets, have defaults
+			name:      "partial options",
+			checkType: "withdraw",
+			data:      `{"NodeName": "bee-1"}`,
+			want:      withdrawPartial,
+		},
+		{
+			name:      "no options",
+			checkType: "withdraw",
+			want:      withdrawDefaults,
+		},
+		{
+			name:      "unknown check type",
+			checkType: "unknown",
+			data:      `{}`,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid options",
+			checkType: "withdraw",
+			data:      `{"Retries": "many"}`,
+			wantErr:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := config.RestoreCheckOptions(tc.checkType, []byte(tc.data))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("got options %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got options %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDescribeCheck(t *testing.T) {
+	options, err := config.DescribeCheck("balances")
+	if err != nil {
+		t.Fatal(err)
+	}
+	described := make(map[string]config.CheckOption)
+	for _, o := range options {
+		described[o.Name] = o
+	}
+	for _, want := range []config.CheckOption{
+		{Name: "file-name", Type: "string", Default: "balances"},
+		{Name: "postage-depth", Type: "uint64", Default: "16"},
+		{Name: "wait-before-download", Type: "time.Duration", Default: "5s"},
+		{Name: "seed", Type: "int64", Default: "global seed"},
+	} {
+		if got := described[want.Name]; got != want {
+			t.Errorf("got option %+v, want %+v", got, want)
+		}
+	}
+
+	// check types without options are described without them
+	if options, err := config.DescribeCheck("pingpong"); err != nil || len(options) != 0 {
+		t.Errorf("got options %+v with error %v, want none", options, err)
+	}
+
+	if _, err := config.DescribeCheck("unknown"); err == nil {
+		t.Error("unknown check type is described")
+	}
+
+	// options of check types are registered for descriptions separately
+	// from check types, only the listed check types are not configurable
+	withoutOptions := map[string]bool{"peer-count": true, "pingpong": true}
+	for checkType := range config.Checks {
+		options, err := config.DescribeCheck(checkType)
+		if err != nil {
+			t.Errorf("check %s: %v", checkType, err)
+			continue
+		}
+		if len(options) == 0 && !withoutOptions[checkType] {
+			t.Errorf("check %s: options are not registered", checkType)
+		}
+		if len(options) > 0 && withoutOptions[checkType] {
+			t.Errorf("check %s: options are registered for check type without options", checkType)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/ethersphere/beekeeper/pkg/orchestration"
 )
@@ -12,6 +13,8 @@ type Cluster struct {
 	*Inherit `yaml:",inline"`
 	// Cluster configuration
 	Name                *string                      `yaml:"name"`
+	Orchestrator        *string                      `yaml:"orchestrator"`   // k8s, helm, docker or static, defaults to k8s
+	DockerHostIP        *string                      `yaml:"docker-host-ip"` // IP APIs of docker nodes are published on
 	Namespace           *string                      `yaml:"namespace"`
 	DisableNamespace    *bool                        `yaml:"disable-namespace"`
 	APIDomain           *string                      `yaml:"api-domain"`
@@ -22,25 +25,191 @@ type Cluster struct {
 	DebugAPIScheme      *string                      `yaml:"debug-api-scheme"`
 	Funding             *Funding                     `yaml:"funding"`
 	NodeGroups          *map[string]ClusterNodeGroup `yaml:"node-groups"`
+	Topology            *string                      `yaml:"topology"` // bootnode or mesh, defaults to bootnode
 	AdminPassword       *string                      `yaml:"admin-password"`
+	RateLimit           *float64                     `yaml:"rate-limit"`              // requests per second to all nodes
+	RateLimitBurst      *int                         `yaml:"rate-limit-burst"`        // requests above the rate to all nodes
+	NodeRateLimit       *float64                     `yaml:"node-rate-limit"`         // requests per second to every node
+	NodeRateLimitBurst  *int                         `yaml:"node-rate-limit-burst"`   // requests above the rate to every node
+	ReadRetries         *int                         `yaml:"read-retries"`            // retries of failed reads, negative for none
+	WriteRetries        *int                         `yaml:"write-retries"`           // retries of writes, like uploads, whose connection failed, none by default
+	RetryMinBackoff     *time.Duration               `yaml:"retry-min-backoff"`       // delay before the first retry
+	RetryMaxBackoff     *time.Duration               `yaml:"retry-max-backoff"`       // maximum delay between retries
+	RetryBudget         *float64                     `yaml:"retry-budget"`            // ratio of retries to requests to every node
+	MaxIdleConnsPerHost *int                         `yaml:"max-idle-conns-per-host"` // idle connections to every node kept for reuse
+	MaxConnsPerHost     *int                         `yaml:"max-conns-per-host"`      // connections to every node
+	IdleConnTimeout     *time.Duration               `yaml:"idle-conn-timeout"`       // time idle connections are kept for reuse
+	DisableKeepAlives   *bool                        `yaml:"disable-keep-alives"`     // opens a connection for every request
+	DisableHTTP2        *bool                        `yaml:"disable-http2"`           // does not negotiate HTTP/2 with nodes
+	AccessMode          *string                      `yaml:"access-mode"`             // ingress, the default, or port-forward to reach APIs of kubernetes nodes
 }
 
 // ClusterNodeGroup represents node group in the cluster
 type ClusterNodeGroup struct {
-	Mode      string        `yaml:"mode"`
-	BeeConfig string        `yaml:"bee-config"`
-	Config    string        `yaml:"config"`
-	Count     int           `yaml:"count"`
-	Nodes     []ClusterNode `yaml:"nodes"`
+	Mode              string            `yaml:"mode"`
+	BeeConfig         string            `yaml:"bee-config"`
+	Config            string            `yaml:"config"`
+	Count             int               `yaml:"count"`
+	Nodes             []ClusterNode     `yaml:"nodes"`
+	Neighborhoods     map[string]int    `yaml:"neighborhoods"`      // number of nodes per binary overlay prefix, keys are mined
+	NeighborhoodSeed  int64             `yaml:"neighborhood-seed"`  // seed for deterministic key mining
+	NeighborhoodDepth int               `yaml:"neighborhood-depth"` // depth of neighborhoods that all get neighborhood-size nodes
+	NeighborhoodSize  int               `yaml:"neighborhood-size"`  // minimum number of nodes in every neighborhood at neighborhood-depth
+	Selector          map[string]string `yaml:"selector"`           // labels of pods of nodes discovered in discover mode
+	Image             string            `yaml:"image"`              // image of the node group, overrides the one of the node group config
+	Namespace         string            `yaml:"namespace"`          // namespace of the node group, the cluster namespace by default
+	APIDomain         string            `yaml:"api-domain"`         // domain of the node group API ingresses, the cluster domain by default
+	DebugAPIDomain    string            `yaml:"debug-api-domain"`   // domain of the node group debug API ingresses, the cluster domain by default
+	Kubeconfig        string            `yaml:"kubeconfig"`         // kubeconfig of the kubernetes cluster of the node group, the global one by default
+	KubeContext       string            `yaml:"kube-context"`       // context of the kubeconfig of the node group, the global one by default
+	Resources         *Resources        `yaml:"resources"`          // resources of the node group nodes, override the ones of the node group config
+	Persistence       *Persistence      `yaml:"persistence"`        // storage of data of the node group nodes, overrides the one of the node group config
+	Scheduling        *Scheduling       `yaml:"scheduling"`         // scheduling of pods of the node group nodes, overrides the one of the node group config
+	SecurityContext   *SecurityContext  `yaml:"security-context"`   // security settings of pods of the node group nodes, replace the ones of the node group config
+	Netem             *Netem            `yaml:"netem"`              // traffic shaping of pods of the node group nodes, replaces the one of the node group config
+}
+
+// Persistence represents storage of data of nodes, persistent volumes or
+// emptyDir volumes if persistence is disabled
+type Persistence struct {
+	Enabled           *bool  `yaml:"enabled"`
+	StorageClass      string `yaml:"storage-class"`
+	Size              string `yaml:"size"`
+	RetentionPolicy   string `yaml:"retention-policy"` // Retain or Delete volumes of deleted nodes
+	EmptyDirMedium    string `yaml:"empty-dir-medium"`
+	EmptyDirSizeLimit string `yaml:"empty-dir-size-limit"`
+}
+
+// Scheduling represents constraints of scheduling pods of nodes on
+// kubernetes nodes, set ones replace the ones they override
+type Scheduling struct {
+	NodeSelector              map[string]string          `yaml:"node-selector"`
+	NodeAffinity              []NodeSelectorRequirement  `yaml:"node-affinity"`     // requirements of labels of kubernetes nodes
+	PodAffinity               []PodAffinityTerm          `yaml:"pod-affinity"`      // pods to schedule pods in the same topology domain with
+	PodAntiAffinity           []PodAffinityTerm          `yaml:"pod-anti-affinity"` // pods not to schedule pods in the same topology domain with
+	Tolerations               []Toleration               `yaml:"tolerations"`
+	TopologySpreadConstraints []TopologySpreadConstraint `yaml:"topology-spread-constraints"`
+}
+
+// NodeSelectorRequirement represents requirement of labels of kubernetes nodes
+type NodeSelectorRequirement struct {
+	Key      string   `yaml:"key"`
+	Operator string   `yaml:"operator"` // In, NotIn, Exists, DoesNotExist, Gt or Lt
+	Values   []string `yaml:"values"`
+}
+
+// PodAffinityTerm represents pods in the topology domain of the topology key
+type PodAffinityTerm struct {
+	LabelSelector map[string]string `yaml:"label-selector"` // pods of the node group by default
+	TopologyKey   string            `yaml:"topology-key"`   // like kubernetes.io/hostname or topology.kubernetes.io/zone
+	Weight        int32             `yaml:"weight"`         // the term is preferred with the weight, required if not set
+}
+
+// Toleration represents toleration of taints of kubernetes nodes
+type Toleration struct {
+	Key               string `yaml:"key"`
+	Operator          string `yaml:"operator"` // Exists or Equal
+	Value             string `yaml:"value"`
+	Effect            string `yaml:"effect"`             // all effects if not set
+	TolerationSeconds int64  `yaml:"toleration-seconds"` // only for NoExecute taints
+}
+
+// TopologySpreadConstraint represents spreading of pods among topology domains
+type TopologySpreadConstraint struct {
+	MaxSkew           int32             `yaml:"max-skew"`           // 1 by default
+	TopologyKey       string            `yaml:"topology-key"`       // like kubernetes.io/hostname or topology.kubernetes.io/zone
+	WhenUnsatisfiable string            `yaml:"when-unsatisfiable"` // DoNotSchedule, the default, or ScheduleAnyway
+	LabelSelector     map[string]string `yaml:"label-selector"`     // pods of the node group by default
+}
+
+// export sets the scheduling constraints to the node group options,
+// replacing the ones that are set
+func (s *Scheduling) export(o *orchestration.NodeGroupOptions) {
+	if len(s.NodeSelector) > 0 {
+		o.NodeSelector = s.NodeSelector
+	}
+	if len(s.NodeAffinity) > 0 {
+		o.Affinity.Node = nil
+		for _, r := range s.NodeAffinity {
+			o.Affinity.Node = append(o.Affinity.Node, orchestration.NodeSelectorRequirement(r))
+		}
+	}
+	if len(s.PodAffinity) > 0 {
+		o.Affinity.Pod = exportPodAffinityTerms(s.PodAffinity)
+	}
+	if len(s.PodAntiAffinity) > 0 {
+		o.Affinity.PodAnti = exportPodAffinityTerms(s.PodAntiAffinity)
+	}
+	if len(s.Tolerations) > 0 {
+		o.Tolerations = nil
+		for _, t := range s.Tolerations {
+			o.Tolerations = append(o.Tolerations, orchestration.Toleration(t))
+		}
+	}
+	if len(s.TopologySpreadConstraints) > 0 {
+		o.TopologySpreadConstraints = nil
+		for _, c := range s.TopologySpreadConstraints {
+			o.TopologySpreadConstraints = append(o.TopologySpreadConstraints, orchestration.TopologySpreadConstraint(c))
+		}
+	}
+}
+
+// exportPodAffinityTerms exports pod affinity terms to orchestration ones
+func exportPodAffinityTerms(terms []PodAffinityTerm) (l []orchestration.PodAffinityTerm) {
+	for _, t := range terms {
+		l = append(l, orchestration.PodAffinityTerm(t))
+	}
+	return
+}
+
+// SecurityContext represents security settings of pods and containers of
+// nodes
+type SecurityContext struct {
+	Restricted              bool   `yaml:"restricted"`                // complies with the restricted pod security standard
+	RunAsNonRoot            *bool  `yaml:"run-as-non-root"`           // containers are required to run as non-root
+	RunAsUser               *int64 `yaml:"run-as-user"`               // 999 by default
+	RunAsGroup              *int64 `yaml:"run-as-group"`              // the image one by default
+	FSGroup                 *int64 `yaml:"fs-group"`                  // 999 by default
+	ClusterAssignedIDs      bool   `yaml:"cluster-assigned-ids"`      // users and groups are assigned by the cluster, like by OpenShift
+	ReadOnlyRootFilesystem  *bool  `yaml:"read-only-root-filesystem"` // nodes write to their data volumes only
+	SeccompProfile          string `yaml:"seccomp-profile"`           // RuntimeDefault, Localhost or Unconfined
+	SeccompLocalhostProfile string `yaml:"seccomp-localhost-profile"` // profile of the Localhost seccomp profile
+}
+
+// Netem represents traffic shaping of network interfaces of pods of nodes by
+// the tc netem queueing discipline
+type Netem struct {
+	Image     string        `yaml:"image"`     // image with tc, nicolaka/netshoot:v0.11 by default
+	Interface string        `yaml:"interface"` // eth0 by default
+	Delay     time.Duration `yaml:"delay"`     // like 50ms
+	Jitter    time.Duration `yaml:"jitter"`    // random variation of the delay
+	Loss      float64       `yaml:"loss"`      // percentage of dropped packets
+	Rate      string        `yaml:"rate"`      // bandwidth cap, like 10mbit
+}
+
+// Resources represents requests and limits of resources of containers of
+// nodes, quantities are in the kubernetes format, like 500m or 1Gi
+type Resources struct {
+	LimitCPU                string `yaml:"limit-cpu"`
+	LimitMemory             string `yaml:"limit-memory"`
+	LimitEphemeralStorage   string `yaml:"limit-ephemeral-storage"`
+	RequestCPU              string `yaml:"request-cpu"`
+	RequestMemory           string `yaml:"request-memory"`
+	RequestEphemeralStorage string `yaml:"request-ephemeral-storage"`
 }
 
 // ClusterNode represents node in the cluster
 type ClusterNode struct {
-	Name      string `yaml:"name"`
-	Bootnodes string `yaml:"bootnodes"`
-	Clef      Clef   `yaml:"clef"`
-	LibP2PKey string `yaml:"libp2p-key"`
-	SwarmKey  string `yaml:"swarm-key"`
+	Name        string     `yaml:"name"`
+	Bootnodes   string     `yaml:"bootnodes"`
+	Clef        Clef       `yaml:"clef"`
+	LibP2PKey   string     `yaml:"libp2p-key"`
+	SwarmKey    string     `yaml:"swarm-key"`
+	APIURL      string     `yaml:"api-url"`       // API of an existing node, with the static orchestrator
+	DebugAPIURL string     `yaml:"debug-api-url"` // debug API of an existing node, with the static orchestrator
+	Image       string     `yaml:"image"`         // image of the node, overrides the one of the node group
+	Overrides   *BeeConfig `yaml:"overrides"`     // Bee configuration of the node, overrides settings of the node group bee config
+	Peers       []string   `yaml:"peers"`         // nodes the node connects to with the mesh topology, all other nodes if empty
 }
 
 type Clef struct {
@@ -86,6 +255,72 @@ func (c *Cluster) GetNamespace() string {
 	return *c.Namespace
 }
 
+// GetTopology returns topology of the cluster nodes
+func (c *Cluster) GetTopology() string {
+	if c.Topology == nil {
+		return "bootnode"
+	}
+	return *c.Topology
+}
+
+// GetNodeGroupNamespace returns namespace of the node group, the cluster
+// namespace if the node group does not set one
+func (c *Cluster) GetNodeGroupNamespace(ng ClusterNodeGroup) string {
+	if ng.Namespace != "" {
+		return ng.Namespace
+	}
+	return c.GetNamespace()
+}
+
+// Export exports ClusterNodeGroup to orchestration.NodeGroupOptions of the
+// node group profile, with the settings of the cluster node group overriding
+// the ones of the profile
+func (ng ClusterNodeGroup) Export(profile NodeGroup) (o orchestration.NodeGroupOptions) {
+	o = profile.Export()
+	if ng.Image != "" {
+		o.Image = ng.Image
+	}
+	o.Namespace = ng.Namespace
+	o.APIDomain = ng.APIDomain
+	o.DebugAPIDomain = ng.DebugAPIDomain
+	if r := ng.Resources; r != nil {
+		overrideString(&o.ResourcesLimitCPU, r.LimitCPU)
+		overrideString(&o.ResourcesLimitMemory, r.LimitMemory)
+		overrideString(&o.ResourcesLimitEphemeralStorage, r.LimitEphemeralStorage)
+		overrideString(&o.ResourcesRequestCPU, r.RequestCPU)
+		overrideString(&o.ResourcesRequestMemory, r.RequestMemory)
+		overrideString(&o.ResourcesRequestEphemeralStorage, r.RequestEphemeralStorage)
+	}
+	if p := ng.Persistence; p != nil {
+		if p.Enabled != nil {
+			o.PersistenceEnabled = *p.Enabled
+		}
+		overrideString(&o.PersistenceStorageClass, p.StorageClass)
+		overrideString(&o.PersistenceStorageRequest, p.Size)
+		overrideString(&o.PersistenceRetentionPolicy, p.RetentionPolicy)
+		overrideString(&o.EmptyDirMedium, p.EmptyDirMedium)
+		overrideString(&o.EmptyDirSizeLimit, p.EmptyDirSizeLimit)
+	}
+	if ng.Scheduling != nil {
+		ng.Scheduling.export(&o)
+	}
+	if ng.SecurityContext != nil {
+		o.SecurityContext = orchestration.SecurityContext(*ng.SecurityContext)
+	}
+	if ng.Netem != nil {
+		o.Netem = orchestration.Netem(*ng.Netem)
+	}
+
+	return
+}
+
+// overrideString sets the string to the override, unless it is empty
+func overrideString(s *string, override string) {
+	if override != "" {
+		*s = override
+	}
+}
+
 // GetNodeGroups returns cluster node groups
 func (c *Cluster) GetNodeGroups() map[string]ClusterNodeGroup {
 	if c.NodeGroups == nil {
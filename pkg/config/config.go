@@ -11,11 +11,12 @@ import (
 
 // Config represents Beekeeper's configuration read from files
 type Config struct {
-	Clusters    map[string]Cluster    `yaml:"clusters"`
-	NodeGroups  map[string]NodeGroup  `yaml:"node-groups"`
-	BeeConfigs  map[string]BeeConfig  `yaml:"bee-configs"`
-	Checks      map[string]Check      `yaml:"checks"`
-	Simulations map[string]Simulation `yaml:"simulations"`
+	Clusters      map[string]Cluster      `yaml:"clusters"`
+	NodeGroups    map[string]NodeGroup    `yaml:"node-groups"`
+	BeeConfigs    map[string]BeeConfig    `yaml:"bee-configs"`
+	Checks        map[string]Check        `yaml:"checks"`
+	Simulations   map[string]Simulation   `yaml:"simulations"`
+	Notifications map[string]Notification `yaml:"notifications"`
 }
 
 type YamlFile struct {
@@ -113,11 +114,12 @@ func (c *Config) merge() (err error) {
 // Read reads given YAML files and unmarshals them into Config
 func Read(log logging.Logger, yamlFiles []YamlFile) (*Config, error) {
 	c := Config{
-		Clusters:    make(map[string]Cluster),
-		NodeGroups:  make(map[string]NodeGroup),
-		BeeConfigs:  make(map[string]BeeConfig),
-		Checks:      make(map[string]Check),
-		Simulations: make(map[string]Simulation),
+		Clusters:      make(map[string]Cluster),
+		NodeGroups:    make(map[string]NodeGroup),
+		BeeConfigs:    make(map[string]BeeConfig),
+		Checks:        make(map[string]Check),
+		Simulations:   make(map[string]Simulation),
+		Notifications: make(map[string]Notification),
 	}
 
 	for _, file := range yamlFiles {
@@ -171,6 +173,15 @@ func Read(log logging.Logger, yamlFiles []YamlFile) (*Config, error) {
 				log.Warningf("simulation '%s' in file '%s' already exits in configuration", k, file.Name)
 			}
 		}
+		// join Notifications
+		for k, v := range tmp.Notifications {
+			_, ok := c.Notifications[k]
+			if !ok {
+				c.Notifications[k] = v
+			} else {
+				log.Warningf("notification '%s' in file '%s' already exits in configuration", k, file.Name)
+			}
+		}
 	}
 
 	// merge for inheritance
@@ -11,28 +11,49 @@ type NodeGroup struct {
 	// parent to inherit settings from
 	*Inherit `yaml:",inline"`
 	// node group configuration
-	Annotations               *map[string]string `yaml:"annotations"`
-	ClefImage                 *string            `yaml:"clef-image"`
-	ClefImagePullPolicy       *string            `yaml:"clef-image-pull-policy"`
-	Image                     *string            `yaml:"image"`
-	ImagePullPolicy           *string            `yaml:"image-pull-policy"`
-	ImagePullSecrets          *[]string          `yaml:"image-pull-secrets"`
-	IngressAnnotations        *map[string]string `yaml:"ingress-annotations"`
-	IngressClass              *string            `yaml:"ingress-class"`
-	IngressDebugAnnotations   *map[string]string `yaml:"ingress-debug-annotations"`
-	IngressDebugClass         *string            `yaml:"ingress-debug-class"`
-	Labels                    *map[string]string `yaml:"labels"`
-	NodeSelector              *map[string]string `yaml:"node-selector"`
-	PersistenceEnabled        *bool              `yaml:"persistence-enabled"`
-	PersistenceStorageClass   *string            `yaml:"persistence-storage-class"`
-	PersistenceStorageRequest *string            `yaml:"persistence-storage-request"`
-	PodManagementPolicy       *string            `yaml:"pod-management-policy"`
-	ResourcesLimitCPU         *string            `yaml:"resources-limit-cpu"`
-	ResourcesLimitMemory      *string            `yaml:"resources-limit-memory"`
-	ResourcesRequestCPU       *string            `yaml:"resources-request-cpu"`
-	ResourcesRequestMemory    *string            `yaml:"resources-request-memory"`
-	RestartPolicy             *string            `yaml:"restart-policy"`
-	UpdateStrategy            *string            `yaml:"update-strategy"`
+	Annotations                      *map[string]string `yaml:"annotations"`
+	APIBearerToken                   *string            `yaml:"api-bearer-token"`
+	APICAFile                        *string            `yaml:"api-ca-file"`
+	APICertFile                      *string            `yaml:"api-cert-file"`
+	APIInsecureTLS                   *bool              `yaml:"api-insecure-tls"`
+	APIKeyFile                       *string            `yaml:"api-key-file"`
+	ClefImage                        *string            `yaml:"clef-image"`
+	ClefImagePullPolicy              *string            `yaml:"clef-image-pull-policy"`
+	DeploymentMode                   *string            `yaml:"deployment-mode"`
+	Gateway                          *string            `yaml:"gateway"`
+	HelmChart                        *string            `yaml:"helm-chart"`
+	HelmChartRepo                    *string            `yaml:"helm-chart-repo"`
+	HelmChartVersion                 *string            `yaml:"helm-chart-version"`
+	HelmValuesFiles                  *[]string          `yaml:"helm-values-files"`
+	Image                            *string            `yaml:"image"`
+	ImagePullPolicy                  *string            `yaml:"image-pull-policy"`
+	ImagePullSecrets                 *[]string          `yaml:"image-pull-secrets"`
+	IngressAnnotations               *map[string]string `yaml:"ingress-annotations"`
+	IngressClass                     *string            `yaml:"ingress-class"`
+	IngressTLSSecret                 *string            `yaml:"ingress-tls-secret"`
+	IngressDebugAnnotations          *map[string]string `yaml:"ingress-debug-annotations"`
+	IngressDebugClass                *string            `yaml:"ingress-debug-class"`
+	IngressDebugTLSSecret            *string            `yaml:"ingress-debug-tls-secret"`
+	Labels                           *map[string]string `yaml:"labels"`
+	NodeSelector                     *map[string]string `yaml:"node-selector"`
+	EmptyDirMedium                   *string            `yaml:"empty-dir-medium"`
+	EmptyDirSizeLimit                *string            `yaml:"empty-dir-size-limit"`
+	Netem                            *Netem             `yaml:"netem"`
+	PersistenceEnabled               *bool              `yaml:"persistence-enabled"`
+	PersistenceStorageClass          *string            `yaml:"persistence-storage-class"`
+	PersistenceStorageRequest        *string            `yaml:"persistence-storage-request"`
+	PersistenceRetentionPolicy       *string            `yaml:"persistence-retention-policy"`
+	PodManagementPolicy              *string            `yaml:"pod-management-policy"`
+	ResourcesLimitCPU                *string            `yaml:"resources-limit-cpu"`
+	ResourcesLimitEphemeralStorage   *string            `yaml:"resources-limit-ephemeral-storage"`
+	ResourcesLimitMemory             *string            `yaml:"resources-limit-memory"`
+	ResourcesRequestCPU              *string            `yaml:"resources-request-cpu"`
+	ResourcesRequestEphemeralStorage *string            `yaml:"resources-request-ephemeral-storage"`
+	ResourcesRequestMemory           *string            `yaml:"resources-request-memory"`
+	RestartPolicy                    *string            `yaml:"restart-policy"`
+	Scheduling                       *Scheduling        `yaml:"scheduling"`
+	SecurityContext                  *SecurityContext   `yaml:"security-context"`
+	UpdateStrategy                   *string            `yaml:"update-strategy"`
 }
 
 // Export exports NodeGroup to orchestration.NodeGroupOptions
@@ -54,5 +75,16 @@ func (n *NodeGroup) Export() (o orchestration.NodeGroupOptions) {
 		}
 	}
 
-	return remoteVal.Interface().(orchestration.NodeGroupOptions)
+	o = remoteVal.Interface().(orchestration.NodeGroupOptions)
+	if n.Scheduling != nil {
+		n.Scheduling.export(&o)
+	}
+	if n.SecurityContext != nil {
+		o.SecurityContext = orchestration.SecurityContext(*n.SecurityContext)
+	}
+	if n.Netem != nil {
+		o.Netem = orchestration.Netem(*n.Netem)
+	}
+
+	return o
 }
@@ -0,0 +1,17 @@
+package config
+
+import "os"
+
+// Notification represents notification target configuration
+type Notification struct {
+	Type       string   `yaml:"type"`       // slack, discord or webhook
+	URL        string   `yaml:"url"`        // environment variables in it are expanded
+	Severities []string `yaml:"severities"` // severities of failed checks to notify about, all if empty
+	OnSuccess  bool     `yaml:"on-success"` // notify about runs without failed checks too
+}
+
+// GetURL returns the notification URL with environment variables expanded,
+// so secret webhook URLs do not have to be kept in config files
+func (n *Notification) GetURL() string {
+	return os.ExpandEnv(n.URL)
+}
@@ -0,0 +1,189 @@
+package cost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/swap"
+)
+
+const (
+	// NativeTokenDecimals is the number of decimals of the native token
+	NativeTokenDecimals = 18
+	// BZZDecimals is the number of decimals of the BZZ token
+	BZZDecimals = 16
+)
+
+// Cost represents tokens a node spent and on-chain transactions it sent
+// during a phase. Gas is the decrease of the native token balance and
+// Postage the decrease of the BZZ balance of the node wallet, as postage
+// batches are bought and topped up with the wallet BZZ.
+type Cost struct {
+	Node         string
+	Gas          *big.Int // in wei
+	Postage      *big.Int // in PLUR
+	Transactions uint64
+}
+
+// GasTokens returns gas in native token units
+func (c Cost) GasTokens() float64 {
+	return tokens(c.Gas, NativeTokenDecimals)
+}
+
+// PostageBZZ returns postage in BZZ
+func (c Cost) PostageBZZ() float64 {
+	return tokens(c.Postage, BZZDecimals)
+}
+
+// Total returns sum of the costs
+func Total(costs []Cost) Cost {
+	t := Cost{Gas: new(big.Int), Postage: new(big.Int)}
+	for _, c := range costs {
+		t.Gas.Add(t.Gas, c.Gas)
+		t.Postage.Add(t.Postage, c.Postage)
+		t.Transactions += c.Transactions
+	}
+	return t
+}
+
+// snapshot holds wallet balances and the nonce of a node
+type snapshot struct {
+	native *big.Int
+	bzz    *big.Int
+	nonce  uint64
+}
+
+// Tracker accounts costs of phases of a run, like checks. A phase is started
+// with Begin, which snapshots wallets of all nodes, and finished with End,
+// which reports what every node spent since. Transactions are counted only
+// if the swap client is set, from nonces of the node wallets.
+type Tracker struct {
+	cluster orchestration.Cluster
+	swap    swap.Client
+	metrics metrics
+	logger  logging.Logger
+
+	mu       sync.Mutex
+	phase    string
+	baseline map[string]snapshot
+}
+
+// NewTracker returns new cost tracker
+func NewTracker(cluster orchestration.Cluster, swapClient swap.Client, logger logging.Logger) *Tracker {
+	return &Tracker{
+		cluster: cluster,
+		swap:    swapClient,
+		metrics: newMetrics(),
+		logger:  logger,
+	}
+}
+
+// Begin starts a new phase
+func (t *Tracker) Begin(ctx context.Context, phase string) error {
+	baseline, err := t.snapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("cost: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.phase = phase
+	t.baseline = baseline
+
+	return nil
+}
+
+// End finishes the current phase and returns costs of nodes that spent
+// anything during it. Balances that grew, like wallets funded during the
+// phase, count as nothing spent.
+func (t *Tracker) End(ctx context.Context) (costs []Cost, err error) {
+	current, err := t.snapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cost: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for name, s := range current {
+		b, ok := t.baseline[name]
+		if !ok {
+			continue
+		}
+
+		c := Cost{
+			Node:    name,
+			Gas:     spent(b.native, s.native),
+			Postage: spent(b.bzz, s.bzz),
+		}
+		if s.nonce > b.nonce {
+			c.Transactions = s.nonce - b.nonce
+		}
+		if c.Gas.Sign() == 0 && c.Postage.Sign() == 0 && c.Transactions == 0 {
+			continue
+		}
+		costs = append(costs, c)
+
+		t.metrics.Gas.WithLabelValues(name, t.phase).Add(c.GasTokens())
+		t.metrics.Postage.WithLabelValues(name, t.phase).Add(c.PostageBZZ())
+		t.metrics.Transactions.WithLabelValues(name, t.phase).Add(float64(c.Transactions))
+		t.logger.Debugf("cost: node %s spent %g gas, %g BZZ and sent %d transactions during phase %s", name, c.GasTokens(), c.PostageBZZ(), c.Transactions, t.phase)
+	}
+
+	sort.Slice(costs, func(i, j int) bool {
+		return costs[i].Node < costs[j].Node
+	})
+
+	t.baseline = current
+
+	return costs, nil
+}
+
+// snapshots returns wallet balances and nonces of all nodes
+func (t *Tracker) snapshots(ctx context.Context) (map[string]snapshot, error) {
+	clients, err := t.cluster.NodesClients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("node clients: %w", err)
+	}
+
+	snapshots := make(map[string]snapshot, len(clients))
+	for name, client := range clients {
+		w, err := client.Wallet(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("node %s: %w", name, err)
+		}
+
+		s := snapshot{native: w.NativeToken, bzz: w.BZZ}
+		s.nonce, err = t.swap.TransactionCount(ctx, w.WalletAddress)
+		if err != nil && !errors.Is(err, swap.ErrNotSet) {
+			return nil, fmt.Errorf("node %s: transaction count: %w", name, err)
+		}
+		snapshots[name] = s
+	}
+
+	return snapshots, nil
+}
+
+// spent returns the decrease of the balance, zero if it did not decrease
+func spent(before, after *big.Int) *big.Int {
+	if before == nil || after == nil || after.Cmp(before) >= 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Sub(before, after)
+}
+
+// tokens returns the amount in token units
+func tokens(amount *big.Int, decimals int) float64 {
+	if amount == nil {
+		return 0
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))).Float64()
+	return f
+}
@@ -0,0 +1,49 @@
+package cost
+
+import (
+	m "github.com/ethersphere/beekeeper/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Gas          *prometheus.CounterVec
+	Postage      *prometheus.CounterVec
+	Transactions *prometheus.CounterVec
+}
+
+func newMetrics() metrics {
+	subsystem := "cost"
+	return metrics{
+		Gas: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "gas_native_tokens",
+				Help:      "Native tokens spent by a node during a phase.",
+			},
+			[]string{"node", "phase"},
+		),
+		Postage: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "postage_bzz",
+				Help:      "BZZ spent by a node during a phase.",
+			},
+			[]string{"node", "phase"},
+		),
+		Transactions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "transactions_count",
+				Help:      "On-chain transactions sent by a node during a phase.",
+			},
+			[]string{"node", "phase"},
+		),
+	}
+}
+
+func (t *Tracker) Report() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(t.metrics)
+}
@@ -0,0 +1,389 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContainerOptions represents options for creating container
+type ContainerOptions struct {
+	Name          string
+	Image         string
+	Pull          string // missing, always or never, defaults to missing
+	Command       []string
+	WorkingDir    string
+	User          string
+	Network       string
+	Aliases       []string // names of the container in the network
+	Labels        map[string]string
+	Ports         []Port            // ports published on the host
+	Volumes       map[string]string // named volumes by their mount path
+	RestartPolicy string            // no, always, unless-stopped or on-failure
+	CPUs          string            // number of CPUs, like 1.5
+	Memory        string            // memory limit, like 2g
+}
+
+// Port represents port of the container published on the host
+type Port struct {
+	ContainerPort int
+	HostIP        string
+	HostPort      int
+}
+
+// String returns the port in the format of the publish flag, ports without
+// the host port are published on a random port
+func (p Port) String() string {
+	hostPort := ""
+	if p.HostPort > 0 {
+		hostPort = strconv.Itoa(p.HostPort)
+	}
+	if p.HostIP == "" {
+		if hostPort == "" {
+			return strconv.Itoa(p.ContainerPort)
+		}
+		return hostPort + ":" + strconv.Itoa(p.ContainerPort)
+	}
+	return p.HostIP + ":" + hostPort + ":" + strconv.Itoa(p.ContainerPort)
+}
+
+// Container represents state of the container
+type Container struct {
+	Name         string
+	Image        string
+	Labels       map[string]string
+	Ports        []Port
+	Running      bool
+	Restarting   bool
+	OOMKilled    bool
+	ExitCode     int
+	RestartCount int
+}
+
+// File represents file copied to the container
+type File struct {
+	Name string // path relative to the destination directory
+	Data []byte
+	Mode int64
+	UID  int
+	GID  int
+}
+
+// CreateContainer creates container, it is not started
+func (c *Client) CreateContainer(ctx context.Context, o ContainerOptions) (err error) {
+	if _, err := c.run(ctx, nil, createArgs(o)...); err != nil {
+		return fmt.Errorf("create container %s: %w", o.Name, err)
+	}
+
+	return
+}
+
+// createArgs returns arguments of the docker command creating the container
+func createArgs(o ContainerOptions) (args []string) {
+	args = []string{"container", "create", "--name", o.Name}
+
+	if o.Network != "" {
+		args = append(args, "--network", o.Network)
+		for _, a := range o.Aliases {
+			args = append(args, "--network-alias", a)
+		}
+	}
+	for _, k := range sortedKeys(o.Labels) {
+		args = append(args, "--label", k+"="+o.Labels[k])
+	}
+	for _, p := range o.Ports {
+		args = append(args, "--publish", p.String())
+	}
+	for _, mountPath := range sortedKeys(o.Volumes) {
+		args = append(args, "--volume", o.Volumes[mountPath]+":"+mountPath)
+	}
+	if o.Pull != "" {
+		args = append(args, "--pull", o.Pull)
+	}
+	if o.WorkingDir != "" {
+		args = append(args, "--workdir", o.WorkingDir)
+	}
+	if o.User != "" {
+		args = append(args, "--user", o.User)
+	}
+	if o.RestartPolicy != "" {
+		args = append(args, "--restart", o.RestartPolicy)
+	}
+	if o.CPUs != "" {
+		args = append(args, "--cpus", o.CPUs)
+	}
+	if o.Memory != "" {
+		args = append(args, "--memory", o.Memory)
+	}
+
+	args = append(args, o.Image)
+	args = append(args, o.Command...)
+
+	return
+}
+
+// CopyToContainer copies files to the directory of the container, parent
+// directories of files are created with the owner of the file
+func (c *Client) CopyToContainer(ctx context.Context, name, dir string, files []File) (err error) {
+	archive, err := tarFiles(files)
+	if err != nil {
+		return fmt.Errorf("archive files for container %s: %w", name, err)
+	}
+
+	// archive mode keeps owners of files, so the container user can read them
+	if _, err := c.run(ctx, archive, "container", "cp", "--archive", "-", name+":"+dir); err != nil {
+		return fmt.Errorf("copy files to container %s: %w", name, err)
+	}
+
+	return
+}
+
+// tarFiles returns the tar archive of the files
+func tarFiles(files []File) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	dirs := make(map[string]bool)
+	for _, f := range files {
+		for dir := path.Dir(f.Name); dir != "." && !dirs[dir]; dir = path.Dir(dir) {
+			dirs[dir] = true
+			if err := tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     dir + "/",
+				Mode:     0o700,
+				Uid:      f.UID,
+				Gid:      f.GID,
+			}); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     f.Name,
+			Mode:     f.Mode,
+			Size:     int64(len(f.Data)),
+			Uid:      f.UID,
+			Gid:      f.GID,
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(f.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+// StartContainer starts container
+func (c *Client) StartContainer(ctx context.Context, name string) (err error) {
+	if _, err := c.run(ctx, nil, "container", "start", name); err != nil {
+		return fmt.Errorf("start container %s: %w", name, err)
+	}
+
+	return
+}
+
+// StopContainer stops container, it is killed if it does not stop before the
+// timeout
+func (c *Client) StopContainer(ctx context.Context, name string, timeout time.Duration) (err error) {
+	if _, err := c.run(ctx, nil, "container", "stop", "--time", strconv.Itoa(int(timeout.Seconds())), name); err != nil {
+		return fmt.Errorf("stop container %s: %w", name, err)
+	}
+
+	return
+}
+
+// KillContainer kills container without a grace period
+func (c *Client) KillContainer(ctx context.Context, name string) (err error) {
+	if _, err := c.run(ctx, nil, "container", "kill", name); err != nil {
+		return fmt.Errorf("kill container %s: %w", name, err)
+	}
+
+	return
+}
+
+// RemoveContainer removes container, running containers are killed first
+func (c *Client) RemoveContainer(ctx context.Context, name string) (err error) {
+	if _, err := c.run(ctx, nil, "container", "rm", "--force", name); err != nil {
+		return fmt.Errorf("remove container %s: %w", name, err)
+	}
+
+	return
+}
+
+// ContainerLogs returns standard output and error of the container since the
+// time, limited to the last tail lines, zero values return all logs
+func (c *Client) ContainerLogs(ctx context.Context, name string, since time.Time, tail int64) (logs []byte, err error) {
+	args := []string{"container", "logs"}
+	if !since.IsZero() {
+		args = append(args, "--since", since.Format(time.RFC3339Nano))
+	}
+	if tail > 0 {
+		args = append(args, "--tail", strconv.FormatInt(tail, 10))
+	}
+	args = append(args, name)
+
+	// the CLI writes the container output to the streams the container wrote
+	// to, so both are read into the same buffer, keeping the order of lines
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.binary, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(out.String())
+		if isNotFound(msg) {
+			return nil, fmt.Errorf("logs of container %s: %s: %w", name, msg, ErrNotFound)
+		}
+		return nil, fmt.Errorf("logs of container %s: %s: %w", name, msg, err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// ExecContainer runs the command in the running container and returns its
+// standard output
+func (c *Client) ExecContainer(ctx context.Context, name string, command ...string) (stdout []byte, err error) {
+	stdout, err = c.run(ctx, nil, append([]string{"container", "exec", name}, command...)...)
+	if err != nil {
+		return nil, fmt.Errorf("exec in container %s: %w", name, err)
+	}
+
+	return stdout, nil
+}
+
+// InspectContainer returns state of the container
+func (c *Client) InspectContainer(ctx context.Context, name string) (ct Container, err error) {
+	out, err := c.run(ctx, nil, "container", "inspect", name)
+	if err != nil {
+		return Container{}, fmt.Errorf("inspect container %s: %w", name, err)
+	}
+
+	return parseInspect(out)
+}
+
+// inspectResponse is the part of the docker inspect output of the container
+// the client uses
+type inspectResponse struct {
+	Name  string `json:"Name"`
+	State struct {
+		Running    bool `json:"Running"`
+		Restarting bool `json:"Restarting"`
+		OOMKilled  bool `json:"OOMKilled"`
+		ExitCode   int  `json:"ExitCode"`
+	} `json:"State"`
+	RestartCount int `json:"RestartCount"`
+	Config       struct {
+		Image  string            `json:"Image"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	HostConfig struct {
+		PortBindings map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string `json:"HostPort"`
+		} `json:"PortBindings"`
+	} `json:"HostConfig"`
+}
+
+// parseInspect parses the docker inspect output of a single container
+func parseInspect(data []byte) (ct Container, err error) {
+	var r []inspectResponse
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Container{}, fmt.Errorf("unmarshal inspect output: %w", err)
+	}
+	if len(r) != 1 {
+		return Container{}, fmt.Errorf("inspect output of %d containers", len(r))
+	}
+
+	ct = Container{
+		Name:         strings.TrimPrefix(r[0].Name, "/"),
+		Image:        r[0].Config.Image,
+		Labels:       r[0].Config.Labels,
+		Running:      r[0].State.Running,
+		Restarting:   r[0].State.Restarting,
+		OOMKilled:    r[0].State.OOMKilled,
+		ExitCode:     r[0].State.ExitCode,
+		RestartCount: r[0].RestartCount,
+	}
+
+	// port bindings of the container configuration are kept while it is
+	// stopped, unlike published ports of its network settings
+	for port, bindings := range r[0].HostConfig.PortBindings {
+		containerPort, err := strconv.Atoi(strings.TrimSuffix(port, "/tcp"))
+		if err != nil {
+			continue
+		}
+		for _, b := range bindings {
+			hostPort, _ := strconv.Atoi(b.HostPort)
+			ct.Ports = append(ct.Ports, Port{
+				ContainerPort: containerPort,
+				HostIP:        b.HostIP,
+				HostPort:      hostPort,
+			})
+		}
+	}
+	sort.Slice(ct.Ports, func(i, j int) bool {
+		return ct.Ports[i].ContainerPort < ct.Ports[j].ContainerPort
+	})
+
+	return
+}
+
+// HostPort returns the host port the container port is published on, 0 if
+// it is not published
+func (ct Container) HostPort(containerPort int) int {
+	for _, p := range ct.Ports {
+		if p.ContainerPort == containerPort {
+			return p.HostPort
+		}
+	}
+	return 0
+}
+
+// ListContainers returns names of containers with all the labels, running
+// containers only, unless all is set
+func (c *Client) ListContainers(ctx context.Context, labels map[string]string, all bool) (names []string, err error) {
+	args := []string{"container", "ls", "--format", "{{.Names}}"}
+	if all {
+		args = append(args, "--all")
+	}
+	for _, k := range sortedKeys(labels) {
+		args = append(args, "--filter", "label="+k+"="+labels[k])
+	}
+
+	out, err := c.run(ctx, nil, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+
+	for _, n := range strings.Split(string(out), "\n") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	return
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
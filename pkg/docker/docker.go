@@ -0,0 +1,72 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// ErrNotFound represents error when a container, network or volume does not
+// exist
+var ErrNotFound = errors.New("not found")
+
+// Client manages containers, networks and volumes with the docker CLI, so
+// it works with every docker host the CLI works with, like the ones set by
+// DOCKER_HOST or docker contexts
+type Client struct {
+	binary string
+}
+
+// ClientOptions holds optional parameters for the Client.
+type ClientOptions struct {
+	Binary string // docker CLI, defaults to docker found in PATH
+}
+
+// NewClient returns docker client
+func NewClient(o *ClientOptions) *Client {
+	if o == nil {
+		o = &ClientOptions{}
+	}
+	if o.Binary == "" {
+		o.Binary = "docker"
+	}
+
+	return &Client{
+		binary: o.Binary,
+	}
+}
+
+// run runs the docker command and returns its standard output, errors of
+// missing objects wrap ErrNotFound
+func (c *Client) run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, c.binary, args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		if isNotFound(msg) {
+			return nil, fmt.Errorf("docker %s: %s: %w", args[0], msg, ErrNotFound)
+		}
+		return nil, fmt.Errorf("docker %s: %s", args[0], msg)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// isNotFound reports whether the error message of the docker CLI is about a
+// missing object, messages differ between objects and docker versions
+func isNotFound(msg string) bool {
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "no such") || strings.Contains(msg, "not found")
+}
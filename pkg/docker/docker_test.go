@@ -0,0 +1,176 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// fakeDocker returns the client of a docker CLI script writing the output
+// and the error and exiting with the code
+func fakeDocker(t *testing.T, stdout, stderr string, code int) *Client {
+	t.Helper()
+
+	bin := filepath.Join(t.TempDir(), "docker")
+	script := "#!/bin/sh\nprintf '%s' '" + stdout + "'\nprintf '%s' '" + stderr + "' >&2\nexit " + strconv.Itoa(code) + "\n"
+	if err := os.WriteFile(bin, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewClient(&ClientOptions{Binary: bin})
+}
+
+func TestClientRun(t *testing.T) {
+	ctx := context.Background()
+
+	out, err := fakeDocker(t, "bee-0", "", 0).run(ctx, nil, "container", "ls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "bee-0" {
+		t.Errorf("got output %q, want %q", out, "bee-0")
+	}
+
+	_, err = fakeDocker(t, "", "Error: No such container: bee-0", 1).run(ctx, nil, "container", "inspect", "bee-0")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrNotFound)
+	}
+
+	_, err = fakeDocker(t, "", "Error response from daemon: conflict", 1).run(ctx, nil, "container", "create")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v, want conflict error", err)
+	}
+}
+
+func TestCreateArgs(t *testing.T) {
+	got := createArgs(ContainerOptions{
+		Name:       "local-bee-0",
+		Image:      "ethersphere/bee:latest",
+		Command:    []string{"bee", "start", "--config=.bee.yaml"},
+		WorkingDir: "/home/bee",
+		Network:    "local",
+		Aliases:    []string{"bee-0"},
+		Labels: map[string]string{
+			"beekeeper.ethswarm.org/node":       "bee-0",
+			"beekeeper.ethswarm.org/node-group": "bee",
+		},
+		Ports: []Port{
+			{ContainerPort: 1633, HostIP: "127.0.0.1", HostPort: 41633},
+			{ContainerPort: 1634},
+		},
+		Volumes:       map[string]string{"/home/bee/.bee": "local-bee-0-data"},
+		RestartPolicy: "unless-stopped",
+		Memory:        "2g",
+	})
+
+	want := []string{
+		"container", "create", "--name", "local-bee-0",
+		"--network", "local", "--network-alias", "bee-0",
+		"--label", "beekeeper.ethswarm.org/node=bee-0",
+		"--label", "beekeeper.ethswarm.org/node-group=bee",
+		"--publish", "127.0.0.1:41633:1633",
+		"--publish", "1634",
+		"--volume", "local-bee-0-data:/home/bee/.bee",
+		"--workdir", "/home/bee",
+		"--restart", "unless-stopped",
+		"--memory", "2g",
+		"ethersphere/bee:latest", "bee", "start", "--config=.bee.yaml",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got args\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestParseInspect(t *testing.T) {
+	ct, err := parseInspect([]byte(`[{
+		"Name": "/local-bee-0",
+		"RestartCount": 2,
+		"State": {"Running": false, "Restarting": true, "OOMKilled": true, "ExitCode": 137},
+		"Config": {"Image": "ethersphere/bee:latest", "Labels": {"beekeeper.ethswarm.org/node": "bee-0"}},
+		"HostConfig": {"PortBindings": {
+			"1635/tcp": [{"HostIp": "127.0.0.1", "HostPort": "41635"}],
+			"1633/tcp": [{"HostIp": "127.0.0.1", "HostPort": "41633"}]
+		}}
+	}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Container{
+		Name:   "local-bee-0",
+		Image:  "ethersphere/bee:latest",
+		Labels: map[string]string{"beekeeper.ethswarm.org/node": "bee-0"},
+		Ports: []Port{
+			{ContainerPort: 1633, HostIP: "127.0.0.1", HostPort: 41633},
+			{ContainerPort: 1635, HostIP: "127.0.0.1", HostPort: 41635},
+		},
+		Restarting:   true,
+		OOMKilled:    true,
+		ExitCode:     137,
+		RestartCount: 2,
+	}
+	if !reflect.DeepEqual(ct, want) {
+		t.Errorf("got container %+v, want %+v", ct, want)
+	}
+	if p := ct.HostPort(1635); p != 41635 {
+		t.Errorf("got host port %d, want %d", p, 41635)
+	}
+	if p := ct.HostPort(1634); p != 0 {
+		t.Errorf("got host port %d of unpublished port, want 0", p)
+	}
+}
+
+func TestTarFiles(t *testing.T) {
+	buf, err := tarFiles([]File{
+		{Name: ".bee.yaml", Data: []byte("api-addr: :1633"), Mode: 0o644, UID: 999, GID: 999},
+		{Name: "keys/libp2p_v2.key", Data: []byte("{}"), Mode: 0o600, UID: 999, GID: 999},
+		{Name: "keys/swarm.key", Data: []byte("{}"), Mode: 0o600, UID: 999, GID: 999},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	tr := tar.NewReader(buf)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.Uid != 999 || h.Gid != 999 {
+			t.Errorf("%s: got owner %d:%d, want 999:999", h.Name, h.Uid, h.Gid)
+		}
+		names = append(names, h.Name)
+	}
+
+	want := []string{".bee.yaml", "keys/", "keys/libp2p_v2.key", "keys/swarm.key"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("got entries %q, want %q", names, want)
+	}
+}
+
+func TestExecContainer(t *testing.T) {
+	ctx := context.Background()
+
+	out, err := fakeDocker(t, "4\t/home/bee/.bee", "", 0).ExecContainer(ctx, "bee-0", "du", "-k", "/home/bee/.bee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "4\t/home/bee/.bee" {
+		t.Errorf("got output %q, want %q", out, "4\t/home/bee/.bee")
+	}
+
+	_, err = fakeDocker(t, "", "Error: No such container: bee-0", 1).ExecContainer(ctx, "bee-0", "du")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrNotFound)
+	}
+}
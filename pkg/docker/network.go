@@ -0,0 +1,37 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// CreateNetwork creates bridge network, if it does not exist
+func (c *Client) CreateNetwork(ctx context.Context, name string, labels map[string]string) (err error) {
+	if _, err := c.run(ctx, nil, "network", "inspect", name); err == nil {
+		return nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("inspect network %s: %w", name, err)
+	}
+
+	args := []string{"network", "create", "--driver", "bridge"}
+	for _, k := range sortedKeys(labels) {
+		args = append(args, "--label", k+"="+labels[k])
+	}
+	args = append(args, name)
+
+	if _, err := c.run(ctx, nil, args...); err != nil {
+		return fmt.Errorf("create network %s: %w", name, err)
+	}
+
+	return
+}
+
+// RemoveNetwork removes network, if it exists
+func (c *Client) RemoveNetwork(ctx context.Context, name string) (err error) {
+	if _, err := c.run(ctx, nil, "network", "rm", name); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("remove network %s: %w", name, err)
+	}
+
+	return
+}
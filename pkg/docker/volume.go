@@ -0,0 +1,32 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// CreateVolume creates named volume, existing volumes are kept with their
+// data
+func (c *Client) CreateVolume(ctx context.Context, name string, labels map[string]string) (err error) {
+	args := []string{"volume", "create"}
+	for _, k := range sortedKeys(labels) {
+		args = append(args, "--label", k+"="+labels[k])
+	}
+	args = append(args, name)
+
+	if _, err := c.run(ctx, nil, args...); err != nil {
+		return fmt.Errorf("create volume %s: %w", name, err)
+	}
+
+	return
+}
+
+// RemoveVolume removes named volume with its data, if it exists
+func (c *Client) RemoveVolume(ctx context.Context, name string) (err error) {
+	if _, err := c.run(ctx, nil, "volume", "rm", name); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("remove volume %s: %w", name, err)
+	}
+
+	return
+}
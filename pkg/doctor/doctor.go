@@ -0,0 +1,194 @@
+// Package doctor runs fast, read-only probes against a Bee cluster and
+// reports problems ordered by severity, together with remediation hints.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// Severity of a problem
+type Severity int
+
+const (
+	SeverityCritical Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityCritical:
+		return "CRITICAL"
+	case SeverityWarning:
+		return "WARNING"
+	case SeverityInfo:
+		return "INFO"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Problem represents a problem found by a probe
+type Problem struct {
+	Severity Severity
+	Probe    string
+	Node     string
+	Message  string
+	Hint     string
+}
+
+// Options represents doctor options
+type Options struct {
+	MaxChainLag      uint64 // maximum number of blocks the node may be behind the chain tip
+	MaxClockSkew     time.Duration
+	MaxDepthSpread   int // maximum difference of kademlia depths between full nodes
+	MinBZZBalance    *big.Int
+	MinNativeBalance *big.Int
+	MinBatchTTL      time.Duration
+	ProbeTimeout     time.Duration // timeout of a single request to a node
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		MaxChainLag:      10,
+		MaxClockSkew:     5 * time.Second,
+		MaxDepthSpread:   1,
+		MinBZZBalance:    big.NewInt(0),
+		MinNativeBalance: big.NewInt(1000000000000000), // 0.001 xDAI
+		MinBatchTTL:      time.Hour,
+		ProbeTimeout:     10 * time.Second,
+	}
+}
+
+// probe checks the cluster and returns found problems. Probes get only nodes
+// that passed the readiness gate.
+type probe struct {
+	name string
+	run  func(d *Doctor, ctx context.Context, nodes map[string]*bee.Client) []Problem
+}
+
+// Doctor runs probes against a cluster
+type Doctor struct {
+	cluster orchestration.Cluster
+	opts    Options
+	logger  logging.Logger
+}
+
+// New returns new Doctor
+func New(cluster orchestration.Cluster, o Options, logger logging.Logger) *Doctor {
+	return &Doctor{
+		cluster: cluster,
+		opts:    o,
+		logger:  logger,
+	}
+}
+
+// Run runs all probes and returns found problems ordered by priority. Nodes
+// that are not ready are reported and excluded from the remaining probes.
+func (d *Doctor) Run(ctx context.Context) (problems []Problem, err error) {
+	clients, err := d.cluster.NodesClients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("nodes clients: %w", err)
+	}
+
+	d.logger.Infof("doctor: probing readiness of %d nodes", len(clients))
+	ready, problems := d.readiness(ctx, clients)
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, p := range probes {
+		p := p
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.logger.Infof("doctor: running %s probe", p.name)
+			found := p.run(d, ctx, ready)
+
+			mu.Lock()
+			problems = append(problems, found...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sortProblems(problems)
+
+	return problems, nil
+}
+
+// perNode calls f concurrently for every node with a request timeout
+func (d *Doctor) perNode(ctx context.Context, nodes map[string]*bee.Client, f func(ctx context.Context, name string, client *bee.Client) []Problem) (problems []Problem) {
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, client := range nodes {
+		name, client := name, client
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, d.opts.ProbeTimeout)
+			defer cancel()
+
+			found := f(ctx, name, client)
+
+			mu.Lock()
+			problems = append(problems, found...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return problems
+}
+
+func sortProblems(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i], problems[j]
+		if a.Severity != b.Severity {
+			return a.Severity < b.Severity
+		}
+		if a.Probe != b.Probe {
+			return a.Probe < b.Probe
+		}
+		return a.Node < b.Node
+	})
+}
+
+// Print writes problems as a table, most severe first
+func Print(w io.Writer, problems []Problem) error {
+	if len(problems) == 0 {
+		_, err := fmt.Fprintln(w, "no problems found")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "#\tSEVERITY\tPROBE\tNODE\tPROBLEM\tHINT"); err != nil {
+		return err
+	}
+	for i, p := range problems {
+		node := p.Node
+		if node == "" {
+			node = "-"
+		}
+		if _, err := fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", i+1, p.Severity, p.Probe, node, p.Message, p.Hint); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,293 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+)
+
+const (
+	probeReadiness = "readiness"
+	probeTopology  = "topology"
+	probeBatches   = "batches"
+	probeWallet    = "wallet"
+	probeChain     = "chain"
+	probeClock     = "clock"
+)
+
+// probes run after the readiness gate
+var probes = []probe{
+	{name: probeTopology, run: (*Doctor).topology},
+	{name: probeBatches, run: (*Doctor).batches},
+	{name: probeWallet, run: (*Doctor).wallet},
+	{name: probeChain, run: (*Doctor).chain},
+	{name: probeClock, run: (*Doctor).clock},
+}
+
+// readiness returns nodes that are ready and problems for the ones that are not
+func (d *Doctor) readiness(ctx context.Context, nodes map[string]*bee.Client) (ready map[string]*bee.Client, problems []Problem) {
+	var mu sync.Mutex
+	ready = make(map[string]*bee.Client)
+
+	problems = d.perNode(ctx, nodes, func(ctx context.Context, name string, client *bee.Client) []Problem {
+		ok, err := client.Readiness(ctx)
+		if err != nil {
+			return []Problem{{
+				Severity: SeverityCritical,
+				Probe:    probeReadiness,
+				Node:     name,
+				Message:  fmt.Sprintf("debug API unreachable: %v", err),
+				Hint:     fmt.Sprintf("check that pod %s-0 is running and inspect its logs with kubectl logs %s-0 -c bee", name, name),
+			}}
+		}
+		if !ok {
+			return []Problem{{
+				Severity: SeverityCritical,
+				Probe:    probeReadiness,
+				Node:     name,
+				Message:  "node is not ready",
+				Hint:     "node may still be syncing postage snapshot or waiting for funding; inspect its logs",
+			}}
+		}
+
+		mu.Lock()
+		ready[name] = client
+		mu.Unlock()
+		return nil
+	})
+
+	return ready, problems
+}
+
+// topology checks that nodes are connected and that full nodes agree on depth
+func (d *Doctor) topology(ctx context.Context, nodes map[string]*bee.Client) (problems []Problem) {
+	full := make(map[string]bool)
+	for _, name := range d.cluster.FullNodeNames() {
+		full[name] = true
+	}
+
+	var (
+		mu     sync.Mutex
+		depths = make(map[string]int)
+	)
+
+	problems = d.perNode(ctx, nodes, func(ctx context.Context, name string, client *bee.Client) []Problem {
+		t, err := client.Topology(ctx)
+		if err != nil {
+			return []Problem{probeError(probeTopology, name, err)}
+		}
+
+		if t.Connected == 0 {
+			return []Problem{{
+				Severity: SeverityCritical,
+				Probe:    probeTopology,
+				Node:     name,
+				Message:  "node has no connected peers",
+				Hint:     "verify bootnodes configuration and that p2p port is reachable",
+			}}
+		}
+
+		if full[name] {
+			mu.Lock()
+			depths[name] = t.Depth
+			mu.Unlock()
+		}
+
+		return nil
+	})
+
+	if len(depths) < 2 {
+		return problems
+	}
+
+	names := make([]string, 0, len(depths))
+	for name := range depths {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return depths[names[i]] < depths[names[j]] })
+
+	minName, maxName := names[0], names[len(names)-1]
+	if spread := depths[maxName] - depths[minName]; spread > d.opts.MaxDepthSpread {
+		problems = append(problems, Problem{
+			Severity: SeverityWarning,
+			Probe:    probeTopology,
+			Message:  fmt.Sprintf("kademlia depths of full nodes differ by %d: %s has %d, %s has %d", spread, minName, depths[minName], maxName, depths[maxName]),
+			Hint:     "nodes with lower depth are poorly connected; check their peer counts and connectivity",
+		})
+	}
+
+	return problems
+}
+
+// batches checks that batches owned by nodes are usable, not about to expire
+// and visible to all other nodes
+func (d *Doctor) batches(ctx context.Context, nodes map[string]*bee.Client) (problems []Problem) {
+	var (
+		mu    sync.Mutex
+		owned = make(map[string]string)              // batch id -> owner node
+		known = make(map[string]map[string]struct{}) // node -> batch ids
+	)
+
+	problems = d.perNode(ctx, nodes, func(ctx context.Context, name string, client *bee.Client) (problems []Problem) {
+		stamps, err := client.PostageBatches(ctx)
+		if err != nil {
+			return []Problem{probeError(probeBatches, name, err)}
+		}
+
+		batches, err := client.Batches(ctx)
+		if err != nil {
+			return []Problem{probeError(probeBatches, name, err)}
+		}
+
+		ids := make(map[string]struct{}, len(batches))
+		for _, b := range batches {
+			ids[b.BatchID] = struct{}{}
+		}
+
+		mu.Lock()
+		known[name] = ids
+		for _, s := range stamps {
+			if s.Exists && s.Usable {
+				owned[s.BatchID] = name
+			}
+		}
+		mu.Unlock()
+
+		for _, s := range stamps {
+			switch {
+			case s.Exists && !s.Usable:
+				problems = append(problems, Problem{
+					Severity: SeverityInfo,
+					Probe:    probeBatches,
+					Node:     name,
+					Message:  fmt.Sprintf("batch %s is not usable yet", s.BatchID),
+					Hint:     "batches become usable after a number of confirmations; check chain sync if this persists",
+				})
+			case s.Exists && time.Duration(s.BatchTTL)*time.Second < d.opts.MinBatchTTL:
+				problems = append(problems, Problem{
+					Severity: SeverityWarning,
+					Probe:    probeBatches,
+					Node:     name,
+					Message:  fmt.Sprintf("batch %s expires in %s", s.BatchID, time.Duration(s.BatchTTL)*time.Second),
+					Hint:     "top up the batch or create a new one",
+				})
+			}
+		}
+
+		return problems
+	})
+
+	for id, owner := range owned {
+		for name, ids := range known {
+			if _, ok := ids[id]; !ok {
+				problems = append(problems, Problem{
+					Severity: SeverityWarning,
+					Probe:    probeBatches,
+					Node:     name,
+					Message:  fmt.Sprintf("batch %s of node %s is not visible", id, owner),
+					Hint:     "node's batch store is behind the chain; check chain probe and swap endpoint",
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// wallet checks native token and BZZ balances of the nodes' wallets
+func (d *Doctor) wallet(ctx context.Context, nodes map[string]*bee.Client) []Problem {
+	return d.perNode(ctx, nodes, func(ctx context.Context, name string, client *bee.Client) (problems []Problem) {
+		w, err := client.Wallet(ctx)
+		if err != nil {
+			return []Problem{probeError(probeWallet, name, err)}
+		}
+
+		if w.NativeToken == nil || w.NativeToken.Cmp(d.opts.MinNativeBalance) < 0 {
+			problems = append(problems, Problem{
+				Severity: SeverityCritical,
+				Probe:    probeWallet,
+				Node:     name,
+				Message:  fmt.Sprintf("native token balance %s is below %s", w.NativeToken, d.opts.MinNativeBalance),
+				Hint:     fmt.Sprintf("node cannot pay for transactions; fund it with beekeeper fund --addresses=%s --eth-deposit", w.WalletAddress),
+			})
+		}
+
+		if w.BZZ == nil || w.BZZ.Cmp(d.opts.MinBZZBalance) < 0 {
+			problems = append(problems, Problem{
+				Severity: SeverityWarning,
+				Probe:    probeWallet,
+				Node:     name,
+				Message:  fmt.Sprintf("BZZ balance %s is below %s", w.BZZ, d.opts.MinBZZBalance),
+				Hint:     fmt.Sprintf("node cannot buy batches or stake; fund it with beekeeper fund --addresses=%s --bzz-deposit", w.WalletAddress),
+			})
+		}
+
+		return problems
+	})
+}
+
+// chain checks that nodes follow the chain tip
+func (d *Doctor) chain(ctx context.Context, nodes map[string]*bee.Client) []Problem {
+	return d.perNode(ctx, nodes, func(ctx context.Context, name string, client *bee.Client) []Problem {
+		s, err := client.ChainState(ctx)
+		if err != nil {
+			return []Problem{probeError(probeChain, name, err)}
+		}
+
+		if s.ChainTip > s.Block && s.ChainTip-s.Block > d.opts.MaxChainLag {
+			return []Problem{{
+				Severity: SeverityWarning,
+				Probe:    probeChain,
+				Node:     name,
+				Message:  fmt.Sprintf("node is %d blocks behind the chain tip %d", s.ChainTip-s.Block, s.ChainTip),
+				Hint:     "check that swap endpoint is healthy and not rate limited",
+			}}
+		}
+
+		return nil
+	})
+}
+
+// clock checks nodes' clocks against the local clock
+func (d *Doctor) clock(ctx context.Context, nodes map[string]*bee.Client) []Problem {
+	return d.perNode(ctx, nodes, func(ctx context.Context, name string, client *bee.Client) []Problem {
+		start := time.Now()
+		t, err := client.Time(ctx)
+		if err != nil {
+			return []Problem{probeError(probeClock, name, err)}
+		}
+		// compare with the middle of the request, Date header has a
+		// resolution of one second
+		local := start.Add(time.Since(start) / 2)
+
+		skew := t.Sub(local)
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > d.opts.MaxClockSkew+time.Second {
+			return []Problem{{
+				Severity: SeverityWarning,
+				Probe:    probeClock,
+				Node:     name,
+				Message:  fmt.Sprintf("clock skew %s", skew.Round(time.Second)),
+				Hint:     "enable time synchronization (NTP) on the Kubernetes node or on this machine",
+			}}
+		}
+
+		return nil
+	})
+}
+
+func probeError(probe, node string, err error) Problem {
+	return Problem{
+		Severity: SeverityWarning,
+		Probe:    probe,
+		Node:     node,
+		Message:  fmt.Sprintf("probe failed: %v", err),
+		Hint:     "node passed readiness but does not answer this endpoint; check bee version and debug API restrictions",
+	}
+}
@@ -0,0 +1,148 @@
+// Package github reports check results to GitHub commits with the Checks
+// API.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the URL of the public GitHub API
+const DefaultAPIURL = "https://api.github.com"
+
+// check run statuses and conclusions
+const (
+	StatusInProgress = "in_progress"
+	StatusCompleted  = "completed"
+
+	ConclusionSuccess  = "success"
+	ConclusionFailure  = "failure"
+	ConclusionSkipped  = "skipped"
+	ConclusionTimedOut = "timed_out"
+)
+
+// maxOutputText is the maximum length of the check run output text
+const maxOutputText = 65535
+
+// Client creates and updates check runs of a repository
+type Client struct {
+	apiURL     string
+	repo       string // owner/name
+	token      string
+	httpClient *http.Client
+}
+
+// Options represents client options
+type Options struct {
+	APIURL     string // defaults to the public GitHub API
+	Repo       string // as owner/name
+	Token      string // needs checks write permission
+	HTTPClient *http.Client
+}
+
+// NewClient returns a new client
+func NewClient(o Options) (*Client, error) {
+	if strings.Count(o.Repo, "/") != 1 {
+		return nil, fmt.Errorf("repository %q not in owner/name form", o.Repo)
+	}
+	if o.Token == "" {
+		return nil, fmt.Errorf("token not set")
+	}
+	if o.APIURL == "" {
+		o.APIURL = DefaultAPIURL
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &Client{
+		apiURL:     strings.TrimSuffix(o.APIURL, "/"),
+		repo:       o.Repo,
+		token:      o.Token,
+		httpClient: o.HTTPClient,
+	}, nil
+}
+
+// CheckRun represents a check run of a commit
+type CheckRun struct {
+	Name        string     `json:"name,omitempty"`
+	HeadSHA     string     `json:"head_sha,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Conclusion  string     `json:"conclusion,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	DetailsURL  string     `json:"details_url,omitempty"`
+	Output      *Output    `json:"output,omitempty"`
+}
+
+// Output is shown on the check run page
+type Output struct {
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+	Text    string `json:"text,omitempty"`
+}
+
+// CreateCheckRun creates the check run and returns its id
+func (c *Client) CreateCheckRun(ctx context.Context, run CheckRun) (id int64, err error) {
+	var resp struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.request(ctx, http.MethodPost, "/repos/"+c.repo+"/check-runs", run, &resp); err != nil {
+		return 0, fmt.Errorf("create check run %s: %w", run.Name, err)
+	}
+	return resp.ID, nil
+}
+
+// UpdateCheckRun updates the check run
+func (c *Client) UpdateCheckRun(ctx context.Context, id int64, run CheckRun) error {
+	if err := c.request(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/check-runs/%d", c.repo, id), run, nil); err != nil {
+		return fmt.Errorf("update check run %d: %w", id, err)
+	}
+	return nil
+}
+
+// request sends the JSON body to the API path and decodes the response
+func (c *Client) request(ctx context.Context, method, path string, body, v interface{}) error {
+	if run, ok := body.(CheckRun); ok && run.Output != nil && len(run.Output.Text) > maxOutputText {
+		output := *run.Output
+		output.Text = output.Text[:maxOutputText-3] + "..."
+		run.Output = &output
+		body = run
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,78 @@
+package helm
+
+import (
+	"fmt"
+	"os"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// Client manages releases of Helm charts with the Helm SDK, with the
+// repositories and registry credentials of the helm CLI, so charts are
+// installed like operators install them
+type Client struct {
+	settings          *cli.EnvSettings
+	kubeconfigPath    string
+	kubeContext       string
+	impersonate       string
+	impersonateGroups []string
+	// config returns configuration of actions in the namespace
+	config func(namespace string) (*action.Configuration, error)
+}
+
+// ClientOptions holds optional parameters for the Client.
+type ClientOptions struct {
+	KubeconfigPath    string   // kubeconfig of the cluster, defaults to the one of the helm CLI
+	KubeContext       string   // context of the kubeconfig, the current one by default
+	Impersonate       string   // user releases are managed as
+	ImpersonateGroups []string // groups of the impersonated user
+}
+
+// NewClient returns helm client
+func NewClient(o *ClientOptions) *Client {
+	if o == nil {
+		o = &ClientOptions{}
+	}
+
+	c := &Client{
+		settings:          cli.New(),
+		kubeconfigPath:    o.KubeconfigPath,
+		kubeContext:       o.KubeContext,
+		impersonate:       o.Impersonate,
+		impersonateGroups: o.ImpersonateGroups,
+	}
+	c.config = c.configuration
+
+	return c
+}
+
+// configuration returns configuration of actions in the namespace, releases
+// are stored by the storage driver of the helm CLI
+func (c *Client) configuration(namespace string) (*action.Configuration, error) {
+	kubeconfig := c.kubeconfigPath
+	if kubeconfig == "" {
+		kubeconfig = c.settings.KubeConfig
+	}
+	kubeContext := c.kubeContext
+	if kubeContext == "" {
+		kubeContext = c.settings.KubeContext
+	}
+	groups := c.impersonateGroups
+
+	getter := &genericclioptions.ConfigFlags{
+		KubeConfig:       &kubeconfig,
+		Context:          &kubeContext,
+		Namespace:        &namespace,
+		Impersonate:      &c.impersonate,
+		ImpersonateGroup: &groups,
+	}
+
+	cfg := new(action.Configuration)
+	if err := cfg.Init(getter, namespace, os.Getenv("HELM_DRIVER"), func(format string, v ...interface{}) {}); err != nil {
+		return nil, fmt.Errorf("helm configuration of namespace %s: %w", namespace, err)
+	}
+
+	return cfg, nil
+}
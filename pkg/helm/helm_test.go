@@ -0,0 +1,145 @@
+package helm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// newTestClient returns the client storing releases in memory, without a
+// kubernetes cluster, and the configuration of its actions
+func newTestClient(t *testing.T) (*Client, *action.Configuration) {
+	t.Helper()
+
+	mem := driver.NewMemory()
+	cfg := &action.Configuration{
+		Releases:     storage.Init(mem),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(format string, v ...interface{}) {},
+	}
+
+	c := NewClient(nil)
+	c.config = func(namespace string) (*action.Configuration, error) {
+		mem.SetNamespace(namespace)
+		return cfg, nil
+	}
+
+	return c, cfg
+}
+
+// writeChart writes the local chart rendering the image of its values
+func writeChart(t *testing.T) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "bee")
+	for name, data := range map[string]string{
+		"Chart.yaml":               "apiVersion: v2\nname: bee\nversion: 0.1.0\n",
+		"values.yaml":              "image:\n  repository: ethersphere/bee\n  tag: latest\n",
+		"templates/configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}\ndata:\n  image: {{ .Values.image.repository }}:{{ .Values.image.tag }}\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestUpgrade(t *testing.T) {
+	ctx := context.Background()
+	c, cfg := newTestClient(t)
+	chart := Chart{Name: writeChart(t)}
+
+	valuesFile := filepath.Join(t.TempDir(), "values.yaml")
+	if err := os.WriteFile(valuesFile, []byte("image:\n  tag: 1.13.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(t *testing.T) *release.Release {
+		t.Helper()
+		rel, err := action.NewGet(cfg).Run("bee-0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rel
+	}
+
+	// the missing release is installed, values of files override the values
+	if err := c.Upgrade(ctx, "bee-0", "local", chart, UpgradeOptions{
+		Values:      []byte("image:\n  repository: ethersphere/bee-test\n  tag: 1.12.0\n"),
+		ValuesFiles: []string{valuesFile},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	rel := get(t)
+	if rel.Version != 1 || rel.Namespace != "local" || rel.Info.Status != release.StatusDeployed {
+		t.Errorf("got revision %d in namespace %s with status %s, want deployed revision 1 in namespace local", rel.Version, rel.Namespace, rel.Info.Status)
+	}
+	if want := "image: ethersphere/bee-test:1.13.0"; !strings.Contains(rel.Manifest, want) {
+		t.Errorf("got manifest\n%s\nwant %q", rel.Manifest, want)
+	}
+
+	// the existing release is upgraded with values of the last revision
+	if err := c.Upgrade(ctx, "bee-0", "local", chart, UpgradeOptions{
+		Values:      []byte("image:\n  tag: 1.14.0\n"),
+		ReuseValues: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	rel = get(t)
+	if rel.Version != 2 {
+		t.Errorf("got revision %d, want 2", rel.Version)
+	}
+	if want := "image: ethersphere/bee-test:1.14.0"; !strings.Contains(rel.Manifest, want) {
+		t.Errorf("got manifest\n%s\nwant %q", rel.Manifest, want)
+	}
+	want := map[string]interface{}{"image": map[string]interface{}{"repository": "ethersphere/bee-test", "tag": "1.14.0"}}
+	if !reflect.DeepEqual(rel.Config, want) {
+		t.Errorf("got values %v, want %v", rel.Config, want)
+	}
+}
+
+func TestUninstall(t *testing.T) {
+	ctx := context.Background()
+	c, cfg := newTestClient(t)
+
+	if err := c.Upgrade(ctx, "bee-0", "local", Chart{Name: writeChart(t)}, UpgradeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Uninstall(ctx, "bee-0", "local"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := action.NewGet(cfg).Run("bee-0"); !errors.Is(err, driver.ErrReleaseNotFound) {
+		t.Errorf("got error %v of the uninstalled release, want %v", err, driver.ErrReleaseNotFound)
+	}
+
+	if err := c.Uninstall(ctx, "bee-0", "local"); err != nil {
+		t.Errorf("got error %v of missing release, want none", err)
+	}
+}
+
+func TestUpgradeMissingChart(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	err := c.Upgrade(context.Background(), "bee-0", "local", Chart{Name: filepath.Join(t.TempDir(), "missing")}, UpgradeOptions{})
+	if err == nil || !strings.Contains(err.Error(), "locate chart") {
+		t.Errorf("got error %v, want error locating the chart", err)
+	}
+}
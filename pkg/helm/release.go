@@ -0,0 +1,162 @@
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"sigs.k8s.io/yaml"
+)
+
+// Chart represents the chart releases are installed from
+type Chart struct {
+	Name    string // chart reference, like ethersphere/bee or oci://registry/bee
+	Repo    string // URL of the chart repository, if the name is not prefixed with a repository
+	Version string // chart version, the latest one by default
+}
+
+// UpgradeOptions represents options of installing and upgrading releases
+type UpgradeOptions struct {
+	Values      []byte        // YAML values of the release
+	ValuesFiles []string      // files with values overriding the ones above
+	ReuseValues bool          // values of the last revision are merged with the values
+	Timeout     time.Duration // time to wait for helm operations, defaults to the helm default
+}
+
+// Upgrade installs the release of the chart in the namespace or upgrades it,
+// if it exists
+func (c *Client) Upgrade(ctx context.Context, release, namespace string, chart Chart, o UpgradeOptions) (err error) {
+	cfg, err := c.config(namespace)
+	if err != nil {
+		return err
+	}
+
+	vals, err := values(o)
+	if err != nil {
+		return fmt.Errorf("values of release %s: %w", release, err)
+	}
+
+	// releases without history are installed, like by helm upgrade --install
+	history := action.NewHistory(cfg)
+	history.Max = 1
+	if _, err := history.Run(release); errors.Is(err, driver.ErrReleaseNotFound) {
+		install := action.NewInstall(cfg)
+		install.ReleaseName = release
+		install.Namespace = namespace
+		install.Timeout = timeout(o.Timeout)
+		install.ChartPathOptions = chartPathOptions(chart)
+
+		ch, err := c.loadChart(install.ChartPathOptions, chart.Name)
+		if err != nil {
+			return err
+		}
+		if _, err := install.RunWithContext(ctx, ch, vals); err != nil {
+			return fmt.Errorf("install release %s: %w", release, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("history of release %s: %w", release, err)
+	}
+
+	upgrade := action.NewUpgrade(cfg)
+	upgrade.Namespace = namespace
+	upgrade.ReuseValues = o.ReuseValues
+	upgrade.Timeout = timeout(o.Timeout)
+	upgrade.ChartPathOptions = chartPathOptions(chart)
+
+	ch, err := c.loadChart(upgrade.ChartPathOptions, chart.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := upgrade.RunWithContext(ctx, release, ch, vals); err != nil {
+		return fmt.Errorf("upgrade release %s: %w", release, err)
+	}
+
+	return nil
+}
+
+// Uninstall uninstalls the release from the namespace, missing releases are
+// ignored
+func (c *Client) Uninstall(ctx context.Context, release, namespace string) (err error) {
+	cfg, err := c.config(namespace)
+	if err != nil {
+		return err
+	}
+
+	uninstall := action.NewUninstall(cfg)
+	uninstall.Timeout = timeout(0)
+	if _, err := uninstall.Run(release); err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		return fmt.Errorf("uninstall release %s: %w", release, err)
+	}
+
+	return nil
+}
+
+// loadChart downloads the chart, unless it is a local one, and loads it
+func (c *Client) loadChart(o action.ChartPathOptions, name string) (*chart.Chart, error) {
+	path, err := o.LocateChart(name, c.settings)
+	if err != nil {
+		return nil, fmt.Errorf("locate chart %s: %w", name, err)
+	}
+
+	ch, err := loader.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("load chart %s: %w", name, err)
+	}
+	if ch.Metadata.Dependencies != nil {
+		if err := action.CheckDependencies(ch, ch.Metadata.Dependencies); err != nil {
+			return nil, fmt.Errorf("chart %s: %w", name, err)
+		}
+	}
+
+	return ch, nil
+}
+
+// chartPathOptions returns options of locating the chart
+func chartPathOptions(chart Chart) action.ChartPathOptions {
+	return action.ChartPathOptions{
+		RepoURL: chart.Repo,
+		Version: chart.Version,
+	}
+}
+
+// values returns values of the release, values of files override the YAML
+// values and the ones of later files override earlier ones
+func values(o UpgradeOptions) (vals map[string]interface{}, err error) {
+	vals = make(map[string]interface{})
+	if len(o.Values) > 0 {
+		if err := yaml.Unmarshal(o.Values, &vals); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range o.ValuesFiles {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		fileVals := make(map[string]interface{})
+		if err := yaml.Unmarshal(data, &fileVals); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", f, err)
+		}
+		vals = chartutil.CoalesceTables(fileVals, vals)
+	}
+
+	return vals, nil
+}
+
+// timeout returns the timeout of helm operations, the helm default if it is
+// not set
+func timeout(t time.Duration) time.Duration {
+	if t <= 0 {
+		return 5 * time.Minute
+	}
+	return t
+}
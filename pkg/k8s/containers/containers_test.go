@@ -695,9 +695,18 @@ func TestToK8S(t *testing.T) {
 					Privileged:             true,
 					ProcMount:              "ProcMount",
 					ReadOnlyRootFilesystem: true,
-					RunAsGroup:             1,
-					RunAsNonRoot:           true,
-					RunAsUser:              2,
+					RunAsGroup: func() *int64 {
+						var pointerInt64 int64 = 1
+						return &pointerInt64
+					}(),
+					RunAsNonRoot: func() *bool {
+						var pointerBool bool = true
+						return &pointerBool
+					}(),
+					RunAsUser: func() *int64 {
+						var pointerInt64 int64 = 2
+						return &pointerInt64
+					}(),
 					SELinuxOptions: containers.SELinuxOptions{
 						User:  "user",
 						Role:  "role",
@@ -968,11 +977,8 @@ func newExpectedDefaultContainer() v1.Container {
 		SecurityContext: &v1.SecurityContext{
 			Privileged:               new(bool),
 			SELinuxOptions:           &v1.SELinuxOptions{},
-			RunAsUser:                new(int64),
-			RunAsNonRoot:             new(bool),
 			ReadOnlyRootFilesystem:   new(bool),
 			AllowPrivilegeEscalation: new(bool),
-			RunAsGroup:               new(int64),
 			ProcMount: func() *v1.ProcMountType {
 				procMountType := v1.ProcMountType("")
 				return &procMountType
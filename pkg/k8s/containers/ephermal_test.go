@@ -32,11 +32,8 @@ func Test_EphemeralContainers_ToK8S(t *testing.T) {
 						SecurityContext: &v1.SecurityContext{
 							Privileged:               new(bool),
 							SELinuxOptions:           &v1.SELinuxOptions{},
-							RunAsUser:                new(int64),
-							RunAsNonRoot:             new(bool),
 							ReadOnlyRootFilesystem:   new(bool),
 							AllowPrivilegeEscalation: new(bool),
-							RunAsGroup:               new(int64),
 							ProcMount: func() *v1.ProcMountType {
 								procMountType := v1.ProcMountType("")
 								return &procMountType
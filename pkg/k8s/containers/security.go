@@ -9,9 +9,10 @@ type SecurityContext struct {
 	Privileged               bool
 	ProcMount                string
 	ReadOnlyRootFilesystem   bool
-	RunAsGroup               int64
-	RunAsNonRoot             bool
-	RunAsUser                int64
+	RunAsGroup               *int64 // left to the pod security context if nil
+	RunAsNonRoot             *bool  // left to the pod security context if nil
+	RunAsUser                *int64 // left to the pod security context if nil
+	SeccompProfile           SeccompProfile
 	SELinuxOptions           SELinuxOptions
 	WindowsOptions           WindowsOptions
 }
@@ -27,9 +28,10 @@ func (sc *SecurityContext) toK8S() *v1.SecurityContext {
 			return &p
 		}(),
 		ReadOnlyRootFilesystem: &sc.ReadOnlyRootFilesystem,
-		RunAsGroup:             &sc.RunAsGroup,
-		RunAsNonRoot:           &sc.RunAsNonRoot,
-		RunAsUser:              &sc.RunAsUser,
+		RunAsGroup:             sc.RunAsGroup,
+		RunAsNonRoot:           sc.RunAsNonRoot,
+		RunAsUser:              sc.RunAsUser,
+		SeccompProfile:         sc.SeccompProfile.toK8S(),
 		SELinuxOptions:         sc.SELinuxOptions.toK8S(),
 		WindowsOptions:         sc.WindowsOptions.toK8S(),
 	}
@@ -57,6 +59,24 @@ func (cap *Capabilities) toK8S() *v1.Capabilities {
 	return &caps
 }
 
+// SeccompProfile represents Kubernetes SeccompProfile
+type SeccompProfile struct {
+	Type             string
+	LocalhostProfile string
+}
+
+// toK8S converts SeccompProfile to Kuberntes client object
+func (sp *SeccompProfile) toK8S() *v1.SeccompProfile {
+	if sp.Type == "" {
+		return nil
+	}
+	p := v1.SeccompProfile{Type: v1.SeccompProfileType(sp.Type)}
+	if sp.LocalhostProfile != "" {
+		p.LocalhostProfile = &sp.LocalhostProfile
+	}
+	return &p
+}
+
 // SELinuxOptions represents Kubernetes SELinuxOptions
 type SELinuxOptions struct {
 	User  string
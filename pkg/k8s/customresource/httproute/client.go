@@ -0,0 +1,79 @@
+package httproute
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Client manages communication with the Gateway API HTTPRoute.
+type Client struct {
+	clientset Interface
+}
+
+// NewClient constructs a new Client.
+func NewClient(clientset Interface) *Client {
+	return &Client{
+		clientset: clientset,
+	}
+}
+
+// Options holds optional parameters for the Client.
+type Options struct {
+	Annotations map[string]string
+	Labels      map[string]string
+	Spec        HTTPRouteSpec
+}
+
+// Set updates HTTPRoute or creates it if it does not exist
+func (c *Client) Set(ctx context.Context, name, namespace string, o Options) (route *HTTPRoute, err error) {
+	spec := &HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HTTPRoute",
+			APIVersion: SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: o.Annotations,
+			Labels:      o.Labels,
+		},
+		Spec: o.Spec,
+	}
+
+	getObj, err := c.clientset.HTTPRoutes(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			route, err = c.clientset.HTTPRoutes(namespace).Create(ctx, spec)
+			if err != nil {
+				return nil, fmt.Errorf("creating http route %s in namespace %s: %w", name, namespace, err)
+			}
+			return
+		} else {
+			return nil, fmt.Errorf("getting http route %s in namespace %s: %w", name, namespace, err)
+		}
+	}
+
+	spec.ResourceVersion = getObj.GetResourceVersion()
+
+	route, err = c.clientset.HTTPRoutes(namespace).Update(ctx, spec, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("updating http route %s in namespace %s: %w", name, namespace, err)
+	}
+	return
+}
+
+// Delete deletes HTTPRoute
+func (c *Client) Delete(ctx context.Context, name, namespace string) (err error) {
+	err = c.clientset.HTTPRoutes(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("deleting http route %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
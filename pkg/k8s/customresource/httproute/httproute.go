@@ -0,0 +1,109 @@
+package httproute
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// HTTPRouteInterface has methods to work with HTTPRoute resources.
+type HTTPRouteInterface interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*HTTPRouteList, error)
+	Get(ctx context.Context, name string, options metav1.GetOptions) (*HTTPRoute, error)
+	Create(ctx context.Context, hr *HTTPRoute) (*HTTPRoute, error)
+	Update(ctx context.Context, hr *HTTPRoute, opts metav1.UpdateOptions) (*HTTPRoute, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// httpRouteClient implements HTTPRouteInterface.
+type httpRouteClient struct {
+	restClient rest.Interface
+	ns         string
+}
+
+const HTTPRouteResource string = "httproutes"
+
+// List takes label and field selectors, and returns the list of HTTPRoutes that match those selectors.
+func (c *httpRouteClient) List(ctx context.Context, opts metav1.ListOptions) (*HTTPRouteList, error) {
+	result := HTTPRouteList{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(HTTPRouteResource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Get takes name of the HTTPRoute, and returns the corresponding HTTPRoute object, and an error if there is any.
+func (c *httpRouteClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*HTTPRoute, error) {
+	result := HTTPRoute{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(HTTPRouteResource).
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Create takes the representation of a HTTPRoute and creates it.  Returns the server's representation of the HTTPRoute, and an error, if there is any.
+func (c *httpRouteClient) Create(ctx context.Context, httpRoute *HTTPRoute) (*HTTPRoute, error) {
+	result := HTTPRoute{}
+	err := c.restClient.
+		Post().
+		Namespace(c.ns).
+		Resource(HTTPRouteResource).
+		Body(httpRoute).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Update takes the representation of a HTTPRoute and updates it. Returns the server's representation of the HTTPRoute, and an error, if there is any.
+func (c *httpRouteClient) Update(ctx context.Context, hr *HTTPRoute, opts metav1.UpdateOptions) (*HTTPRoute, error) {
+	result := HTTPRoute{}
+	err := c.restClient.
+		Put().
+		Namespace(c.ns).
+		Resource(HTTPRouteResource).
+		Name(hr.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(hr).
+		Do(ctx).
+		Into(&result)
+	return &result, err
+}
+
+// Watch returns a watch.Interface that watches the requested HTTPRoute.
+func (c *httpRouteClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(HTTPRouteResource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+// Delete takes name of the HTTPRoute and deletes it. Returns an error if one occurs.
+func (c *httpRouteClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.restClient.
+		Delete().
+		Namespace(c.ns).
+		Resource(HTTPRouteResource).
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
@@ -0,0 +1,93 @@
+package httproute
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	_ runtime.Object = (*HTTPRoute)(nil)
+	_ runtime.Object = (*HTTPRouteList)(nil)
+)
+
+type HTTPRouteSpec struct {
+	ParentRefs []ParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string          `json:"hostnames,omitempty"`
+	Rules      []Rule            `json:"rules,omitempty"`
+}
+
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HTTPRouteSpec `json:"spec"`
+}
+
+type HTTPRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HTTPRoute `json:"items"`
+}
+
+// ParentReference references the Gateway the HTTPRoute is attached to
+type ParentReference struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+type Rule struct {
+	Matches     []Match      `json:"matches,omitempty"`
+	BackendRefs []BackendRef `json:"backendRefs,omitempty"`
+}
+
+type Match struct {
+	Path *PathMatch `json:"path,omitempty"`
+}
+
+type PathMatch struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// BackendRef references the service requests are routed to
+type BackendRef struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *HTTPRouteList) DeepCopyObject() runtime.Object {
+	out := HTTPRouteList{}
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+
+	if in.Items != nil {
+		out.Items = make([]HTTPRoute, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+
+	return &out
+}
+
+// DeepCopyObject implements runtime.Object
+func (hr *HTTPRoute) DeepCopyObject() runtime.Object {
+	out := HTTPRoute{}
+	hr.DeepCopyInto(&out)
+	return &out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the
+// same type that is provided as a pointer.
+func (in *HTTPRoute) DeepCopyInto(out *HTTPRoute) {
+	out.TypeMeta = in.TypeMeta
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = in.Spec
+	out.Spec.ParentRefs = append([]ParentReference(nil), in.Spec.ParentRefs...)
+	out.Spec.Hostnames = append([]string(nil), in.Spec.Hostnames...)
+	out.Spec.Rules = append([]Rule(nil), in.Spec.Rules...)
+}
@@ -0,0 +1,109 @@
+package iochaos
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// IOChaosInterface has methods to work with IOChaos resources.
+type IOChaosInterface interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*IOChaosList, error)
+	Get(ctx context.Context, name string, options metav1.GetOptions) (*IOChaos, error)
+	Create(ctx context.Context, ic *IOChaos) (*IOChaos, error)
+	Update(ctx context.Context, ic *IOChaos, opts metav1.UpdateOptions) (*IOChaos, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// ioChaosClient implements IOChaosInterface.
+type ioChaosClient struct {
+	restClient rest.Interface
+	ns         string
+}
+
+const IOChaosResource string = "iochaos"
+
+// List takes label and field selectors, and returns the list of IOChaos that match those selectors.
+func (c *ioChaosClient) List(ctx context.Context, opts metav1.ListOptions) (*IOChaosList, error) {
+	result := IOChaosList{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(IOChaosResource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Get takes name of the IOChaos, and returns the corresponding IOChaos object, and an error if there is any.
+func (c *ioChaosClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*IOChaos, error) {
+	result := IOChaos{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(IOChaosResource).
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Create takes the representation of a IOChaos and creates it.  Returns the server's representation of the IOChaos, and an error, if there is any.
+func (c *ioChaosClient) Create(ctx context.Context, ic *IOChaos) (*IOChaos, error) {
+	result := IOChaos{}
+	err := c.restClient.
+		Post().
+		Namespace(c.ns).
+		Resource(IOChaosResource).
+		Body(ic).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Update takes the representation of a IOChaos and updates it. Returns the server's representation of the IOChaos, and an error, if there is any.
+func (c *ioChaosClient) Update(ctx context.Context, ic *IOChaos, opts metav1.UpdateOptions) (*IOChaos, error) {
+	result := IOChaos{}
+	err := c.restClient.
+		Put().
+		Namespace(c.ns).
+		Resource(IOChaosResource).
+		Name(ic.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(ic).
+		Do(ctx).
+		Into(&result)
+	return &result, err
+}
+
+// Watch returns a watch.Interface that watches the requested IOChaos.
+func (c *ioChaosClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(IOChaosResource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+// Delete takes name of the IOChaos and deletes it. Returns an error if one occurs.
+func (c *ioChaosClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.restClient.
+		Delete().
+		Namespace(c.ns).
+		Resource(IOChaosResource).
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
@@ -0,0 +1,92 @@
+package iochaos
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	_ runtime.Object = (*IOChaos)(nil)
+	_ runtime.Object = (*IOChaosList)(nil)
+)
+
+// IOChaosSpec is the chaos-mesh IO fault injected into the volume of the
+// selected pods, until the IOChaos is deleted if the duration is not set
+type IOChaosSpec struct {
+	Action         string      `json:"action"` // latency
+	Mode           string      `json:"mode"`   // all, one, fixed, fixed-percent or random-max-percent
+	Value          string      `json:"value,omitempty"`
+	Selector       PodSelector `json:"selector"`
+	VolumePath     string      `json:"volumePath"`
+	Path           string      `json:"path,omitempty"`
+	Delay          string      `json:"delay,omitempty"`
+	Percent        int         `json:"percent,omitempty"`
+	Methods        []string    `json:"methods,omitempty"`
+	ContainerNames []string    `json:"containerNames,omitempty"`
+	Duration       *string     `json:"duration,omitempty"`
+}
+
+type IOChaos struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IOChaosSpec `json:"spec"`
+}
+
+type IOChaosList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []IOChaos `json:"items"`
+}
+
+// PodSelector selects pods in the namespaces by their labels
+type PodSelector struct {
+	Namespaces     []string          `json:"namespaces,omitempty"`
+	LabelSelectors map[string]string `json:"labelSelectors,omitempty"`
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *IOChaosList) DeepCopyObject() runtime.Object {
+	out := IOChaosList{}
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+
+	if in.Items != nil {
+		out.Items = make([]IOChaos, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+
+	return &out
+}
+
+// DeepCopyObject implements runtime.Object
+func (ic *IOChaos) DeepCopyObject() runtime.Object {
+	out := IOChaos{}
+	ic.DeepCopyInto(&out)
+	return &out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the
+// same type that is provided as a pointer.
+func (in *IOChaos) DeepCopyInto(out *IOChaos) {
+	out.TypeMeta = in.TypeMeta
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = in.Spec
+	out.Spec.Selector.Namespaces = append([]string(nil), in.Spec.Selector.Namespaces...)
+	if in.Spec.Selector.LabelSelectors != nil {
+		out.Spec.Selector.LabelSelectors = make(map[string]string, len(in.Spec.Selector.LabelSelectors))
+		for k, v := range in.Spec.Selector.LabelSelectors {
+			out.Spec.Selector.LabelSelectors[k] = v
+		}
+	}
+	out.Spec.Methods = append([]string(nil), in.Spec.Methods...)
+	out.Spec.ContainerNames = append([]string(nil), in.Spec.ContainerNames...)
+	if in.Spec.Duration != nil {
+		duration := *in.Spec.Duration
+		out.Spec.Duration = &duration
+	}
+}
@@ -0,0 +1,102 @@
+package networkchaos
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Client manages communication with the chaos-mesh NetworkChaos.
+type Client struct {
+	clientset Interface
+}
+
+// NewClient constructs a new Client.
+func NewClient(clientset Interface) *Client {
+	return &Client{
+		clientset: clientset,
+	}
+}
+
+// Options holds optional parameters for the Client.
+type Options struct {
+	Annotations map[string]string
+	Labels      map[string]string
+	Spec        NetworkChaosSpec
+}
+
+// Set updates NetworkChaos or creates it if it does not exist
+func (c *Client) Set(ctx context.Context, name, namespace string, o Options) (nc *NetworkChaos, err error) {
+	spec := &NetworkChaos{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkChaos",
+			APIVersion: SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: o.Annotations,
+			Labels:      o.Labels,
+		},
+		Spec: o.Spec,
+	}
+
+	getObj, err := c.clientset.NetworkChaos(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			nc, err = c.clientset.NetworkChaos(namespace).Create(ctx, spec)
+			if err != nil {
+				return nil, fmt.Errorf("creating network chaos %s in namespace %s: %w", name, namespace, err)
+			}
+			return
+		} else {
+			return nil, fmt.Errorf("getting network chaos %s in namespace %s: %w", name, namespace, err)
+		}
+	}
+
+	spec.ResourceVersion = getObj.GetResourceVersion()
+
+	nc, err = c.clientset.NetworkChaos(namespace).Update(ctx, spec, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("updating network chaos %s in namespace %s: %w", name, namespace, err)
+	}
+	return
+}
+
+// Delete deletes NetworkChaos
+func (c *Client) Delete(ctx context.Context, name, namespace string) (err error) {
+	err = c.clientset.NetworkChaos(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("deleting network chaos %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
+
+// DeleteSelected deletes NetworkChaos in the namespace with the labels of the
+// selector
+func (c *Client) DeleteSelected(ctx context.Context, namespace string, selector map[string]string) (err error) {
+	l, err := c.clientset.NetworkChaos(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("listing network chaos in namespace %s: %w", namespace, err)
+	}
+
+	for _, nc := range l.Items {
+		if err := c.Delete(ctx, nc.Name, namespace); err != nil {
+			return err
+		}
+	}
+
+	return
+}
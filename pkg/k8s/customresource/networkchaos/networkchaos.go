@@ -0,0 +1,109 @@
+package networkchaos
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// NetworkChaosInterface has methods to work with NetworkChaos resources.
+type NetworkChaosInterface interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*NetworkChaosList, error)
+	Get(ctx context.Context, name string, options metav1.GetOptions) (*NetworkChaos, error)
+	Create(ctx context.Context, nc *NetworkChaos) (*NetworkChaos, error)
+	Update(ctx context.Context, nc *NetworkChaos, opts metav1.UpdateOptions) (*NetworkChaos, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// networkChaosClient implements NetworkChaosInterface.
+type networkChaosClient struct {
+	restClient rest.Interface
+	ns         string
+}
+
+const NetworkChaosResource string = "networkchaos"
+
+// List takes label and field selectors, and returns the list of NetworkChaos that match those selectors.
+func (c *networkChaosClient) List(ctx context.Context, opts metav1.ListOptions) (*NetworkChaosList, error) {
+	result := NetworkChaosList{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(NetworkChaosResource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Get takes name of the NetworkChaos, and returns the corresponding NetworkChaos object, and an error if there is any.
+func (c *networkChaosClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*NetworkChaos, error) {
+	result := NetworkChaos{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(NetworkChaosResource).
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Create takes the representation of a NetworkChaos and creates it.  Returns the server's representation of the NetworkChaos, and an error, if there is any.
+func (c *networkChaosClient) Create(ctx context.Context, nc *NetworkChaos) (*NetworkChaos, error) {
+	result := NetworkChaos{}
+	err := c.restClient.
+		Post().
+		Namespace(c.ns).
+		Resource(NetworkChaosResource).
+		Body(nc).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Update takes the representation of a NetworkChaos and updates it. Returns the server's representation of the NetworkChaos, and an error, if there is any.
+func (c *networkChaosClient) Update(ctx context.Context, nc *NetworkChaos, opts metav1.UpdateOptions) (*NetworkChaos, error) {
+	result := NetworkChaos{}
+	err := c.restClient.
+		Put().
+		Namespace(c.ns).
+		Resource(NetworkChaosResource).
+		Name(nc.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(nc).
+		Do(ctx).
+		Into(&result)
+	return &result, err
+}
+
+// Watch returns a watch.Interface that watches the requested NetworkChaos.
+func (c *networkChaosClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(NetworkChaosResource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+// Delete takes name of the NetworkChaos and deletes it. Returns an error if one occurs.
+func (c *networkChaosClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.restClient.
+		Delete().
+		Namespace(c.ns).
+		Resource(NetworkChaosResource).
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
@@ -0,0 +1,121 @@
+package networkchaos
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	_ runtime.Object = (*NetworkChaos)(nil)
+	_ runtime.Object = (*NetworkChaosList)(nil)
+)
+
+// NetworkChaosSpec is the chaos-mesh network fault injected into the selected
+// pods, until the NetworkChaos is deleted if the duration is not set
+type NetworkChaosSpec struct {
+	Action    string         `json:"action"` // delay, loss or bandwidth
+	Mode      string         `json:"mode"`   // all, one, fixed, fixed-percent or random-max-percent
+	Value     string         `json:"value,omitempty"`
+	Selector  PodSelector    `json:"selector"`
+	Direction string         `json:"direction,omitempty"` // to, from or both
+	Delay     *DelaySpec     `json:"delay,omitempty"`
+	Loss      *LossSpec      `json:"loss,omitempty"`
+	Bandwidth *BandwidthSpec `json:"bandwidth,omitempty"`
+	Duration  *string        `json:"duration,omitempty"`
+}
+
+type NetworkChaos struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NetworkChaosSpec `json:"spec"`
+}
+
+type NetworkChaosList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NetworkChaos `json:"items"`
+}
+
+// PodSelector selects pods in the namespaces by their labels
+type PodSelector struct {
+	Namespaces     []string          `json:"namespaces,omitempty"`
+	LabelSelectors map[string]string `json:"labelSelectors,omitempty"`
+}
+
+// DelaySpec delays packets by the latency with the jitter
+type DelaySpec struct {
+	Latency     string `json:"latency"`
+	Jitter      string `json:"jitter,omitempty"`
+	Correlation string `json:"correlation,omitempty"`
+}
+
+// LossSpec drops the percentage of packets
+type LossSpec struct {
+	Loss        string `json:"loss"`
+	Correlation string `json:"correlation,omitempty"`
+}
+
+// BandwidthSpec limits bandwidth to the rate, like 1mbps, with the limit of
+// queued bytes and the buffer of bytes sent instantaneously
+type BandwidthSpec struct {
+	Rate   string `json:"rate"`
+	Limit  uint32 `json:"limit"`
+	Buffer uint32 `json:"buffer"`
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *NetworkChaosList) DeepCopyObject() runtime.Object {
+	out := NetworkChaosList{}
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+
+	if in.Items != nil {
+		out.Items = make([]NetworkChaos, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+
+	return &out
+}
+
+// DeepCopyObject implements runtime.Object
+func (nc *NetworkChaos) DeepCopyObject() runtime.Object {
+	out := NetworkChaos{}
+	nc.DeepCopyInto(&out)
+	return &out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the
+// same type that is provided as a pointer.
+func (in *NetworkChaos) DeepCopyInto(out *NetworkChaos) {
+	out.TypeMeta = in.TypeMeta
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = in.Spec
+	out.Spec.Selector.Namespaces = append([]string(nil), in.Spec.Selector.Namespaces...)
+	if in.Spec.Selector.LabelSelectors != nil {
+		out.Spec.Selector.LabelSelectors = make(map[string]string, len(in.Spec.Selector.LabelSelectors))
+		for k, v := range in.Spec.Selector.LabelSelectors {
+			out.Spec.Selector.LabelSelectors[k] = v
+		}
+	}
+	if in.Spec.Delay != nil {
+		delay := *in.Spec.Delay
+		out.Spec.Delay = &delay
+	}
+	if in.Spec.Loss != nil {
+		loss := *in.Spec.Loss
+		out.Spec.Loss = &loss
+	}
+	if in.Spec.Bandwidth != nil {
+		bandwidth := *in.Spec.Bandwidth
+		out.Spec.Bandwidth = &bandwidth
+	}
+	if in.Spec.Duration != nil {
+		duration := *in.Spec.Duration
+		out.Spec.Duration = &duration
+	}
+}
@@ -0,0 +1,109 @@
+package stresschaos
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// StressChaosInterface has methods to work with StressChaos resources.
+type StressChaosInterface interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*StressChaosList, error)
+	Get(ctx context.Context, name string, options metav1.GetOptions) (*StressChaos, error)
+	Create(ctx context.Context, sc *StressChaos) (*StressChaos, error)
+	Update(ctx context.Context, sc *StressChaos, opts metav1.UpdateOptions) (*StressChaos, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// stressChaosClient implements StressChaosInterface.
+type stressChaosClient struct {
+	restClient rest.Interface
+	ns         string
+}
+
+const StressChaosResource string = "stresschaos"
+
+// List takes label and field selectors, and returns the list of StressChaos that match those selectors.
+func (c *stressChaosClient) List(ctx context.Context, opts metav1.ListOptions) (*StressChaosList, error) {
+	result := StressChaosList{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(StressChaosResource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Get takes name of the StressChaos, and returns the corresponding StressChaos object, and an error if there is any.
+func (c *stressChaosClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*StressChaos, error) {
+	result := StressChaos{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(StressChaosResource).
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Create takes the representation of a StressChaos and creates it.  Returns the server's representation of the StressChaos, and an error, if there is any.
+func (c *stressChaosClient) Create(ctx context.Context, sc *StressChaos) (*StressChaos, error) {
+	result := StressChaos{}
+	err := c.restClient.
+		Post().
+		Namespace(c.ns).
+		Resource(StressChaosResource).
+		Body(sc).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+// Update takes the representation of a StressChaos and updates it. Returns the server's representation of the StressChaos, and an error, if there is any.
+func (c *stressChaosClient) Update(ctx context.Context, sc *StressChaos, opts metav1.UpdateOptions) (*StressChaos, error) {
+	result := StressChaos{}
+	err := c.restClient.
+		Put().
+		Namespace(c.ns).
+		Resource(StressChaosResource).
+		Name(sc.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(sc).
+		Do(ctx).
+		Into(&result)
+	return &result, err
+}
+
+// Watch returns a watch.Interface that watches the requested StressChaos.
+func (c *stressChaosClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource(StressChaosResource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+// Delete takes name of the StressChaos and deletes it. Returns an error if one occurs.
+func (c *stressChaosClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.restClient.
+		Delete().
+		Namespace(c.ns).
+		Resource(StressChaosResource).
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
@@ -0,0 +1,114 @@
+package stresschaos
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	_ runtime.Object = (*StressChaos)(nil)
+	_ runtime.Object = (*StressChaosList)(nil)
+)
+
+// StressChaosSpec is the chaos-mesh CPU and memory stress injected into
+// containers of the selected pods by stress-ng, until the StressChaos is
+// deleted if the duration is not set
+type StressChaosSpec struct {
+	Mode           string      `json:"mode"` // all, one, fixed, fixed-percent or random-max-percent
+	Value          string      `json:"value,omitempty"`
+	Selector       PodSelector `json:"selector"`
+	Stressors      Stressors   `json:"stressors"`
+	ContainerNames []string    `json:"containerNames,omitempty"`
+	Duration       *string     `json:"duration,omitempty"`
+}
+
+// Stressors are stress-ng workers stressing CPU or memory, or both
+type Stressors struct {
+	CPU    *CPUStressor    `json:"cpu,omitempty"`
+	Memory *MemoryStressor `json:"memory,omitempty"`
+}
+
+// CPUStressor are workers loading CPU to the percentage each
+type CPUStressor struct {
+	Workers int `json:"workers"`
+	Load    int `json:"load,omitempty"`
+}
+
+// MemoryStressor are workers allocating the size of memory each, in bytes,
+// with units like MB, or in percentage of total memory, like 50%
+type MemoryStressor struct {
+	Workers int    `json:"workers"`
+	Size    string `json:"size,omitempty"`
+}
+
+type StressChaos struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StressChaosSpec `json:"spec"`
+}
+
+type StressChaosList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []StressChaos `json:"items"`
+}
+
+// PodSelector selects pods in the namespaces by their labels
+type PodSelector struct {
+	Namespaces     []string          `json:"namespaces,omitempty"`
+	LabelSelectors map[string]string `json:"labelSelectors,omitempty"`
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *StressChaosList) DeepCopyObject() runtime.Object {
+	out := StressChaosList{}
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+
+	if in.Items != nil {
+		out.Items = make([]StressChaos, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+
+	return &out
+}
+
+// DeepCopyObject implements runtime.Object
+func (sc *StressChaos) DeepCopyObject() runtime.Object {
+	out := StressChaos{}
+	sc.DeepCopyInto(&out)
+	return &out
+}
+
+// DeepCopyInto copies all properties of this object into another object of the
+// same type that is provided as a pointer.
+func (in *StressChaos) DeepCopyInto(out *StressChaos) {
+	out.TypeMeta = in.TypeMeta
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = in.Spec
+	out.Spec.Selector.Namespaces = append([]string(nil), in.Spec.Selector.Namespaces...)
+	if in.Spec.Selector.LabelSelectors != nil {
+		out.Spec.Selector.LabelSelectors = make(map[string]string, len(in.Spec.Selector.LabelSelectors))
+		for k, v := range in.Spec.Selector.LabelSelectors {
+			out.Spec.Selector.LabelSelectors[k] = v
+		}
+	}
+	if in.Spec.Stressors.CPU != nil {
+		cpu := *in.Spec.Stressors.CPU
+		out.Spec.Stressors.CPU = &cpu
+	}
+	if in.Spec.Stressors.Memory != nil {
+		memory := *in.Spec.Stressors.Memory
+		out.Spec.Stressors.Memory = &memory
+	}
+	out.Spec.ContainerNames = append([]string(nil), in.Spec.ContainerNames...)
+	if in.Spec.Duration != nil {
+		duration := *in.Spec.Duration
+		out.Spec.Duration = &duration
+	}
+}
@@ -0,0 +1,91 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// Client runs commands in containers of Kubernetes Pods.
+type Client struct {
+	clientset kubernetes.Interface
+	config    *rest.Config
+}
+
+// NewClient constructs a new Client.
+func NewClient(clientset kubernetes.Interface, config *rest.Config) *Client {
+	return &Client{
+		clientset: clientset,
+		config:    config,
+	}
+}
+
+// Options holds optional parameters for the Client.
+type Options struct {
+	Container string    // the default container of the Pod if empty
+	Stdin     io.Reader // no standard input if nil
+}
+
+// Run runs the command in the container of the Pod, through the Kubernetes
+// API server, and returns its standard output. Errors of failed commands
+// include their standard error.
+func (c *Client) Run(ctx context.Context, name, namespace string, command []string, o Options) (stdout []byte, err error) {
+	if c.config == nil {
+		return nil, fmt.Errorf("exec in pod %s in namespace %s: kubernetes client config is not set", name, namespace)
+	}
+	if len(command) == 0 {
+		return nil, fmt.Errorf("exec in pod %s in namespace %s: command is not set", name, namespace)
+	}
+
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: o.Container,
+			Command:   command,
+			Stdin:     o.Stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.config, "POST", req.URL())
+	if err != nil {
+		return nil, fmt.Errorf("exec in pod %s in namespace %s: %w", name, namespace, err)
+	}
+
+	var outBuf, errBuf bytes.Buffer
+	result := make(chan error, 1)
+	go func() {
+		result <- executor.Stream(remotecommand.StreamOptions{
+			Stdin:  o.Stdin,
+			Stdout: &outBuf,
+			Stderr: &errBuf,
+		})
+	}()
+
+	// streams are not canceled with the context, the command keeps running
+	// until it exits
+	select {
+	case err := <-result:
+		if err != nil {
+			if msg := strings.TrimSpace(errBuf.String()); msg != "" {
+				return nil, fmt.Errorf("exec %s in pod %s in namespace %s: %s: %w", command[0], name, namespace, msg, err)
+			}
+			return nil, fmt.Errorf("exec %s in pod %s in namespace %s: %w", command[0], name, namespace, err)
+		}
+	case <-ctx.Done():
+		return nil, fmt.Errorf("exec %s in pod %s in namespace %s: %w", command[0], name, namespace, ctx.Err())
+	}
+
+	return outBuf.Bytes(), nil
+}
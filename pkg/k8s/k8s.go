@@ -7,11 +7,18 @@ import (
 	"net/http"
 
 	"github.com/ethersphere/beekeeper/pkg/k8s/configmap"
+	"github.com/ethersphere/beekeeper/pkg/k8s/customresource/httproute"
 	"github.com/ethersphere/beekeeper/pkg/k8s/customresource/ingressroute"
+	"github.com/ethersphere/beekeeper/pkg/k8s/customresource/iochaos"
+	"github.com/ethersphere/beekeeper/pkg/k8s/customresource/networkchaos"
+	"github.com/ethersphere/beekeeper/pkg/k8s/customresource/stresschaos"
+	"github.com/ethersphere/beekeeper/pkg/k8s/exec"
 	"github.com/ethersphere/beekeeper/pkg/k8s/ingress"
 	"github.com/ethersphere/beekeeper/pkg/k8s/namespace"
+	"github.com/ethersphere/beekeeper/pkg/k8s/networkpolicy"
 	"github.com/ethersphere/beekeeper/pkg/k8s/persistentvolumeclaim"
 	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
+	"github.com/ethersphere/beekeeper/pkg/k8s/portforward"
 	"github.com/ethersphere/beekeeper/pkg/k8s/secret"
 	"github.com/ethersphere/beekeeper/pkg/k8s/service"
 	"github.com/ethersphere/beekeeper/pkg/k8s/serviceaccount"
@@ -19,6 +26,7 @@ import (
 	"github.com/ethersphere/beekeeper/pkg/logging"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/flowcontrol"
 )
 
@@ -28,25 +36,37 @@ var ErrKubeconfigNotSet = errors.New("kubeconfig is not set")
 // Client manages communication with the Kubernetes
 type Client struct {
 	clientset kubernetes.Interface // Kubernetes client must handle authentication implicitly.
+	namespace string               // namespace of the kubeconfig context, or its override
 	logger    logging.Logger
 
 	// Services that K8S provides
 	ConfigMap      *configmap.Client
+	Exec           *exec.Client
 	Ingress        *ingress.Client
 	Namespace      *namespace.Client
+	NetworkPolicy  *networkpolicy.Client
 	Pods           *pod.Client
+	PortForward    *portforward.Client
 	PVC            *persistentvolumeclaim.Client
 	Secret         *secret.Client
 	ServiceAccount *serviceaccount.Client
 	Service        *service.Client
 	StatefulSet    *statefulset.Client
 	IngressRoute   *ingressroute.Client
+	HTTPRoute      *httproute.Client
+	NetworkChaos   *networkchaos.Client
+	IOChaos        *iochaos.Client
+	StressChaos    *stresschaos.Client
 }
 
 // ClientOptions holds optional parameters for the Client.
 type ClientOptions struct {
-	InCluster      bool
-	KubeconfigPath string
+	InCluster         bool
+	KubeconfigPath    string
+	Context           string   // kubeconfig context, the current one by default
+	Namespace         string   // overrides the namespace of the kubeconfig context
+	Impersonate       string   // user requests are made as
+	ImpersonateGroups []string // groups of the impersonated user
 }
 
 // ClientSetup holds functions for configuration of the Client.
@@ -55,9 +75,12 @@ type ClientSetup struct {
 	NewForConfig         func(c *rest.Config) (*kubernetes.Clientset, error)
 	InClusterConfig      func() (*rest.Config, error)
 	BuildConfigFromFlags func(masterUrl string, kubeconfigPath string) (*rest.Config, error)
-	FlagString           func(name string, value string, usage string) *string
-	FlagParse            func()
-	OsUserHomeDir        func() (string, error)
+	// BuildConfigFromContext returns config and namespace of the kubeconfig
+	// context, used when the context is set
+	BuildConfigFromContext func(kubeconfigPath string, context string) (*rest.Config, string, error)
+	FlagString             func(name string, value string, usage string) *string
+	FlagParse              func()
+	OsUserHomeDir          func() (string, error)
 }
 
 // customTransport is an example custom transport that wraps the default transport
@@ -79,6 +102,7 @@ func NewClient(s *ClientSetup, o *ClientOptions, logger logging.Logger) (c *Clie
 	}
 
 	var config *rest.Config
+	var namespace string
 
 	if o.InCluster {
 		// set in-cluster client
@@ -104,16 +128,39 @@ func NewClient(s *ClientSetup, o *ClientOptions, logger logging.Logger) (c *Clie
 		kubeconfig := s.FlagString("kubeconfig", configPath, "kubeconfig file")
 		flag.Parse()
 
-		config, err = s.BuildConfigFromFlags("", *kubeconfig)
-		if err != nil {
-			return nil, fmt.Errorf("creating Kubernetes client config: %w", err)
+		if o.Context != "" {
+			config, namespace, err = s.BuildConfigFromContext(*kubeconfig, o.Context)
+			if err != nil {
+				return nil, fmt.Errorf("creating Kubernetes client config of context %s: %w", o.Context, err)
+			}
+		} else {
+			config, err = s.BuildConfigFromFlags("", *kubeconfig)
+			if err != nil {
+				return nil, fmt.Errorf("creating Kubernetes client config: %w", err)
+			}
+		}
+	}
+
+	if o.Namespace != "" {
+		namespace = o.Namespace
+	}
+
+	if o.Impersonate != "" {
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: o.Impersonate,
+			Groups:   o.ImpersonateGroups,
 		}
 	}
 
 	config.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(50, 100)
 
-	// Wrap the default transport with our custom transport.
+	// Wrap the default transport with our custom transport, keeping wrappers
+	// set by the kubeconfig.
+	wrapTransport := config.WrapTransport
 	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrapTransport != nil {
+			rt = wrapTransport(rt)
+		}
 		return NewCustomTransport(rt, config)
 	}
 
@@ -127,31 +174,91 @@ func NewClient(s *ClientSetup, o *ClientOptions, logger logging.Logger) (c *Clie
 		return nil, fmt.Errorf("creating custom resource Kubernetes api clientset: %w", err)
 	}
 
-	return newClient(clientset, apiClientset, logger), nil
+	gatewayClientset, err := httproute.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating Gateway API Kubernetes clientset: %w", err)
+	}
+
+	chaosClientset, err := networkchaos.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating chaos-mesh Kubernetes clientset: %w", err)
+	}
+
+	ioChaosClientset, err := iochaos.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating chaos-mesh Kubernetes clientset: %w", err)
+	}
+
+	stressChaosClientset, err := stresschaos.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating chaos-mesh Kubernetes clientset: %w", err)
+	}
+
+	c = newClient(clientset, apiClientset, gatewayClientset, chaosClientset, ioChaosClientset, stressChaosClientset, logger)
+	c.Exec = exec.NewClient(clientset, config)
+	c.PortForward = portforward.NewClient(clientset, config)
+	c.namespace = namespace
+
+	return c, nil
 }
 
 // newClient constructs a new *Client with the provided http Client, which
 // should handle authentication implicitly, and sets all other services.
-func newClient(clientset *kubernetes.Clientset, apiClientset *ingressroute.CustomResourceClient, logger logging.Logger) (c *Client) {
+func newClient(clientset *kubernetes.Clientset, apiClientset *ingressroute.CustomResourceClient, gatewayClientset *httproute.CustomResourceClient, chaosClientset *networkchaos.CustomResourceClient, ioChaosClientset *iochaos.CustomResourceClient, stressChaosClientset *stresschaos.CustomResourceClient, logger logging.Logger) (c *Client) {
 	c = &Client{
 		clientset: clientset,
 		logger:    logger,
 	}
 
 	c.ConfigMap = configmap.NewClient(clientset)
+	c.Exec = exec.NewClient(clientset, nil)
 	c.Ingress = ingress.NewClient(clientset)
 	c.Namespace = namespace.NewClient(clientset)
+	c.NetworkPolicy = networkpolicy.NewClient(clientset)
 	c.Pods = pod.NewClient(clientset)
+	c.PortForward = portforward.NewClient(clientset, nil)
 	c.PVC = persistentvolumeclaim.NewClient(clientset)
 	c.Secret = secret.NewClient(clientset)
 	c.ServiceAccount = serviceaccount.NewClient(clientset)
 	c.Service = service.NewClient(clientset)
 	c.StatefulSet = statefulset.NewClient(clientset)
 	c.IngressRoute = ingressroute.NewClient(apiClientset)
+	c.HTTPRoute = httproute.NewClient(gatewayClientset)
+	c.NetworkChaos = networkchaos.NewClient(chaosClientset)
+	c.IOChaos = iochaos.NewClient(ioChaosClientset)
+	c.StressChaos = stresschaos.NewClient(stressChaosClientset)
 
 	return c
 }
 
+// DefaultNamespace returns namespace of the kubeconfig context, or the one
+// of the client options overriding it, empty if neither is set.
+func (c *Client) DefaultNamespace() string {
+	return c.namespace
+}
+
+// BuildConfigFromContext returns config and namespace of the context of the
+// kubeconfig. Credentials of users of the context are obtained like by
+// kubectl, including exec credential plugins, like the ones of EKS or GKE.
+func BuildConfigFromContext(kubeconfigPath string, context string) (*rest.Config, string, error) {
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath},
+		&clientcmd.ConfigOverrides{CurrentContext: context},
+	)
+
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return config, namespace, nil
+}
+
 func NewCustomTransport(base http.RoundTripper, config *rest.Config) http.RoundTripper {
 	return &customTransport{
 		base:        base,
@@ -16,10 +16,11 @@ import (
 
 func TestNewClient(t *testing.T) {
 	testTable := []struct {
-		name     string
-		options  *k8s.ClientOptions
-		k8sFuncs *k8s.ClientSetup
-		errorMsg error
+		name      string
+		options   *k8s.ClientOptions
+		k8sFuncs  *k8s.ClientSetup
+		errorMsg  error
+		namespace string
 	}{
 		{
 			name:     "in_cluster_config_error",
@@ -96,6 +97,42 @@ func TestNewClient(t *testing.T) {
 				FlagParse:            mock.FlagParse,
 			},
 		},
+		{
+			name:    "not_in_cluster_context",
+			options: &k8s.ClientOptions{InCluster: false, KubeconfigPath: "~/.kube/test_example", Context: "test"},
+			k8sFuncs: &k8s.ClientSetup{
+				NewForConfig:           mock.NewClient(false).NewForConfig,
+				InClusterConfig:        mock.NewClient(false).InClusterConfig,
+				BuildConfigFromContext: mock.NewClient(false).BuildConfigFromContext,
+				FlagString:             mock.FlagString,
+				FlagParse:              mock.FlagParse,
+			},
+			namespace: "context-namespace",
+		},
+		{
+			name:    "not_in_cluster_context_namespace_override",
+			options: &k8s.ClientOptions{InCluster: false, KubeconfigPath: "~/.kube/test_example", Context: "test", Namespace: "bee", Impersonate: "beekeeper", ImpersonateGroups: []string{"testers"}},
+			k8sFuncs: &k8s.ClientSetup{
+				NewForConfig:           mock.NewClient(false).NewForConfig,
+				InClusterConfig:        mock.NewClient(false).InClusterConfig,
+				BuildConfigFromContext: mock.NewClient(false).BuildConfigFromContext,
+				FlagString:             mock.FlagString,
+				FlagParse:              mock.FlagParse,
+			},
+			namespace: "bee",
+		},
+		{
+			name:    "not_in_cluster_context_bad",
+			options: &k8s.ClientOptions{InCluster: false, KubeconfigPath: "~/.kube/test_example", Context: "test"},
+			k8sFuncs: &k8s.ClientSetup{
+				NewForConfig:           mock.NewClient(false).NewForConfig,
+				InClusterConfig:        mock.NewClient(false).InClusterConfig,
+				BuildConfigFromContext: mock.NewClient(true).BuildConfigFromContext,
+				FlagString:             mock.FlagString,
+				FlagParse:              mock.FlagParse,
+			},
+			errorMsg: fmt.Errorf("creating Kubernetes client config of context test: mock error"),
+		},
 		{
 			name:    "not_in_cluster_fail_home_dir",
 			options: &k8s.ClientOptions{InCluster: false, KubeconfigPath: "~/.kube/config"},
@@ -121,7 +158,10 @@ func TestNewClient(t *testing.T) {
 					t.Errorf("error not expected, got: %s", err.Error())
 				}
 				if response == nil {
-					t.Errorf("response expected, got nil")
+					t.Fatalf("response expected, got nil")
+				}
+				if response.DefaultNamespace() != test.namespace {
+					t.Errorf("namespace expected: %s, got: %s", test.namespace, response.DefaultNamespace())
 				}
 
 				// Get the value of the struct using reflection.
@@ -134,6 +174,9 @@ func TestNewClient(t *testing.T) {
 					fieldVal := val.Field(i)
 
 					// Check if the field is nil.
+					if fieldVal.Kind() == reflect.String {
+						continue
+					}
 					if fieldVal.IsNil() {
 						t.Errorf("nil not expected for '%s' property", val.Type().Field(i).Name)
 					}
@@ -58,6 +58,35 @@ func (c *Client) Update(ctx context.Context, name string, o Options) (*v1.Namesp
 	return c.clientset.CoreV1().Namespaces().Update(ctx, spec, metav1.UpdateOptions{})
 }
 
+// Label adds labels and annotations to the namespace, keeping the existing
+// ones
+func (c *Client) Label(ctx context.Context, name string, o Options) (*v1.Namespace, error) {
+	n, err := c.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Labels == nil {
+		n.Labels = make(map[string]string)
+	}
+	for k, v := range o.Labels {
+		n.Labels[k] = v
+	}
+	if n.Annotations == nil {
+		n.Annotations = make(map[string]string)
+	}
+	for k, v := range o.Annotations {
+		n.Annotations[k] = v
+	}
+
+	n, err = c.clientset.CoreV1().Namespaces().Update(ctx, n, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("labeling namespace %s: %w", name, err)
+	}
+
+	return n, nil
+}
+
 // Delete deletes namespace
 func (c *Client) Delete(ctx context.Context, name string) (err error) {
 	n, err := c.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
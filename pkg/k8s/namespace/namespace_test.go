@@ -138,6 +138,81 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestLabel(t *testing.T) {
+	testTable := []struct {
+		name      string
+		nsName    string
+		clientset kubernetes.Interface
+		options   namespace.Options
+		expected  *v1.Namespace
+		errorMsg  error
+	}{
+		{
+			name:   "label_namespace",
+			nsName: "test",
+			clientset: fake.NewSimpleClientset(&v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"created-by": fmt.Sprintf("beekeeper:%s", beekeeper.Version),
+					},
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "beekeeper",
+					},
+				},
+			}),
+			options: namespace.Options{
+				Annotations: map[string]string{"annotation_1": "annotation_value_1"},
+				Labels:      map[string]string{"label_1": "label_value_1"},
+			},
+			expected: &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"created-by":   fmt.Sprintf("beekeeper:%s", beekeeper.Version),
+						"annotation_1": "annotation_value_1",
+					},
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "beekeeper",
+						"label_1":                      "label_value_1",
+					},
+				},
+			},
+		},
+		{
+			name:      "no_namespaces",
+			nsName:    "test",
+			clientset: fake.NewSimpleClientset(),
+			errorMsg:  fmt.Errorf("namespaces \"test\" not found"),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			client := namespace.NewClient(test.clientset)
+			response, err := client.Label(context.Background(), test.nsName, test.options)
+			if test.errorMsg == nil {
+				if err != nil {
+					t.Errorf("error not expected, got: %s", err.Error())
+				}
+				if !reflect.DeepEqual(response, test.expected) {
+					t.Errorf("response expected: %q, got: %q", test.expected, response)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("error not happened, expected: %s", test.errorMsg.Error())
+				}
+				if err.Error() != test.errorMsg.Error() {
+					t.Errorf("error expected: %s, got: %s", test.errorMsg.Error(), err.Error())
+				}
+				if response != nil {
+					t.Errorf("response not expected")
+				}
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	testTable := []struct {
 		name      string
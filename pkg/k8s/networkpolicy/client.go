@@ -0,0 +1,90 @@
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Client manages communication with the Kubernetes NetworkPolicy.
+type Client struct {
+	clientset kubernetes.Interface
+}
+
+// NewClient constructs a new Client.
+func NewClient(clientset kubernetes.Interface) *Client {
+	return &Client{
+		clientset: clientset,
+	}
+}
+
+// Options holds optional parameters for the Client.
+type Options struct {
+	Annotations map[string]string
+	Labels      map[string]string
+	Spec        Spec
+}
+
+// Set updates NetworkPolicy or creates it if it does not exist
+func (c *Client) Set(ctx context.Context, name, namespace string, o Options) (np *v1.NetworkPolicy, err error) {
+	spec := &v1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: o.Annotations,
+			Labels:      o.Labels,
+		},
+		Spec: o.Spec.toK8S(),
+	}
+
+	np, err = c.clientset.NetworkingV1().NetworkPolicies(namespace).Update(ctx, spec, metav1.UpdateOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			np, err = c.clientset.NetworkingV1().NetworkPolicies(namespace).Create(ctx, spec, metav1.CreateOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("creating network policy %s in namespace %s: %w", name, namespace, err)
+			}
+		} else {
+			return nil, fmt.Errorf("updating network policy %s in namespace %s: %w", name, namespace, err)
+		}
+	}
+
+	return
+}
+
+// Delete deletes NetworkPolicy
+func (c *Client) Delete(ctx context.Context, name, namespace string) (err error) {
+	err = c.clientset.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("deleting network policy %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
+
+// DeleteSelected deletes NetworkPolicies in the namespace with the labels of
+// the selector
+func (c *Client) DeleteSelected(ctx context.Context, namespace string, selector map[string]string) (err error) {
+	l, err := c.clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("listing network policies in namespace %s: %w", namespace, err)
+	}
+
+	for _, np := range l.Items {
+		if err := c.Delete(ctx, np.Name, namespace); err != nil {
+			return err
+		}
+	}
+
+	return
+}
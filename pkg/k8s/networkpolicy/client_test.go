@@ -0,0 +1,107 @@
+package networkpolicy_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s/networkpolicy"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSet(t *testing.T) {
+	testTable := []struct {
+		name      string
+		clientset kubernetes.Interface
+	}{
+		{
+			name:      "create_network_policy",
+			clientset: fake.NewSimpleClientset(),
+		},
+		{
+			name: "update_network_policy",
+			clientset: fake.NewSimpleClientset(&v1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test_policy",
+					Namespace: "test",
+				},
+			}),
+		},
+	}
+
+	namespaceSelector := networkpolicy.Selector{}
+	options := networkpolicy.Options{
+		Labels: map[string]string{"label_1": "label_value_1"},
+		Spec: networkpolicy.Spec{
+			PodSelector: networkpolicy.Selector{MatchLabels: map[string]string{"app": "bee"}},
+			Ingress: networkpolicy.IngressRules{{
+				From: networkpolicy.Peers{
+					{
+						PodSelector: networkpolicy.Selector{MatchExpressions: []networkpolicy.SelectorRequirement{{Key: "pod", Operator: "In", Values: []string{"bee-0", "bee-1"}}}},
+					},
+					{
+						PodSelector:       networkpolicy.Selector{MatchExpressions: []networkpolicy.SelectorRequirement{{Key: "app", Operator: "DoesNotExist"}}},
+						NamespaceSelector: &namespaceSelector,
+					},
+				},
+			}},
+		},
+	}
+
+	expectedPodSelector := metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "pod", Operator: "In", Values: []string{"bee-0", "bee-1"}}}}
+	expectedOtherSelector := metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "DoesNotExist"}}}
+	expectedSpec := v1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "bee"}},
+		Ingress: []v1.NetworkPolicyIngressRule{{
+			From: []v1.NetworkPolicyPeer{
+				{PodSelector: &expectedPodSelector},
+				{PodSelector: &expectedOtherSelector, NamespaceSelector: &metav1.LabelSelector{}},
+			},
+		}},
+		PolicyTypes: []v1.PolicyType{v1.PolicyTypeIngress},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			client := networkpolicy.NewClient(test.clientset)
+			response, err := client.Set(context.Background(), "test_policy", "test", options)
+			if err != nil {
+				t.Fatalf("error not expected, got: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(response.Labels, options.Labels) {
+				t.Errorf("response labels expected: %v, got: %v", options.Labels, response.Labels)
+			}
+			if !reflect.DeepEqual(response.Spec, expectedSpec) {
+				t.Errorf("response spec expected: %+v, got: %+v", expectedSpec, response.Spec)
+			}
+		})
+	}
+}
+
+func TestDeleteSelected(t *testing.T) {
+	policy := func(name string, labels map[string]string) *v1.NetworkPolicy {
+		return &v1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Labels: labels}}
+	}
+	clientset := fake.NewSimpleClientset(
+		policy("partition-1", map[string]string{"partition": "true"}),
+		policy("partition-2", map[string]string{"partition": "true"}),
+		policy("other", map[string]string{"app": "other"}),
+	)
+
+	client := networkpolicy.NewClient(clientset)
+	if err := client.DeleteSelected(context.Background(), "test", map[string]string{"partition": "true"}); err != nil {
+		t.Fatalf("error not expected, got: %s", err.Error())
+	}
+
+	l, err := clientset.NetworkingV1().NetworkPolicies("test").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Items) != 1 || l.Items[0].Name != "other" {
+		t.Errorf("expected only policy other to remain, got: %v", l.Items)
+	}
+}
@@ -0,0 +1,110 @@
+package networkpolicy
+
+import (
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Spec represents Kubernetes NetworkPolicySpec restricting ingress of the
+// selected pods to the ingress rules, all ingress is denied without rules
+type Spec struct {
+	PodSelector Selector
+	Ingress     IngressRules
+}
+
+// toK8S converts Spec to Kuberntes client object
+func (s *Spec) toK8S() v1.NetworkPolicySpec {
+	return v1.NetworkPolicySpec{
+		PodSelector: s.PodSelector.toK8S(),
+		Ingress:     s.Ingress.toK8S(),
+		PolicyTypes: []v1.PolicyType{v1.PolicyTypeIngress},
+	}
+}
+
+// IngressRules represents Kubernetes NetworkPolicyIngressRules
+type IngressRules []IngressRule
+
+// toK8S converts IngressRules to Kuberntes client object
+func (irs IngressRules) toK8S() (l []v1.NetworkPolicyIngressRule) {
+	if len(irs) > 0 {
+		l = make([]v1.NetworkPolicyIngressRule, 0, len(irs))
+		for _, ir := range irs {
+			l = append(l, ir.toK8S())
+		}
+	}
+	return
+}
+
+// IngressRule represents Kubernetes NetworkPolicyIngressRule allowing
+// ingress from the peers
+type IngressRule struct {
+	From Peers
+}
+
+// toK8S converts IngressRule to Kuberntes client object
+func (ir *IngressRule) toK8S() v1.NetworkPolicyIngressRule {
+	return v1.NetworkPolicyIngressRule{
+		From: ir.From.toK8S(),
+	}
+}
+
+// Peers represents Kubernetes NetworkPolicyPeers
+type Peers []Peer
+
+// toK8S converts Peers to Kuberntes client object
+func (ps Peers) toK8S() (l []v1.NetworkPolicyPeer) {
+	if len(ps) > 0 {
+		l = make([]v1.NetworkPolicyPeer, 0, len(ps))
+		for _, p := range ps {
+			l = append(l, p.toK8S())
+		}
+	}
+	return
+}
+
+// Peer represents Kubernetes NetworkPolicyPeer, pods selected by the pod
+// selector in namespaces selected by the namespace selector, or in the
+// namespace of the policy if it is not set
+type Peer struct {
+	PodSelector       Selector
+	NamespaceSelector *Selector
+}
+
+// toK8S converts Peer to Kuberntes client object
+func (p *Peer) toK8S() v1.NetworkPolicyPeer {
+	podSelector := p.PodSelector.toK8S()
+	peer := v1.NetworkPolicyPeer{PodSelector: &podSelector}
+	if p.NamespaceSelector != nil {
+		namespaceSelector := p.NamespaceSelector.toK8S()
+		peer.NamespaceSelector = &namespaceSelector
+	}
+	return peer
+}
+
+// Selector represents Kubernetes LabelSelector, an empty one selects all
+// objects
+type Selector struct {
+	MatchLabels      map[string]string
+	MatchExpressions []SelectorRequirement
+}
+
+// toK8S converts Selector to Kuberntes client object
+func (s *Selector) toK8S() metav1.LabelSelector {
+	ls := metav1.LabelSelector{MatchLabels: s.MatchLabels}
+	for _, r := range s.MatchExpressions {
+		ls.MatchExpressions = append(ls.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      r.Key,
+			Operator: metav1.LabelSelectorOperator(r.Operator),
+			Values:   r.Values,
+		})
+	}
+	return ls
+}
+
+// SelectorRequirement represents Kubernetes LabelSelectorRequirement, the key
+// In or NotIn the values, or Exists or DoesNotExist
+type SelectorRequirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
@@ -3,10 +3,15 @@ package pod
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -68,3 +73,102 @@ func (c *Client) Delete(ctx context.Context, name, namespace string) (err error)
 
 	return
 }
+
+// Kill deletes Pod without a grace period, terminating its containers with SIGKILL
+func (c *Client) Kill(ctx context.Context, name, namespace string) (err error) {
+	var gracePeriod int64
+	err = c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("killing pod %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
+
+// List returns Pods in the namespace with all labels of the selector
+func (c *Client) List(ctx context.Context, namespace string, selector map[string]string) (pods []v1.Pod, err error) {
+	list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods in namespace %s: %w", namespace, err)
+	}
+
+	return list.Items, nil
+}
+
+// Watch returns watcher of changes of Pods in the namespace with all labels of
+// the selector, starting with additions of the existing ones
+func (c *Client) Watch(ctx context.Context, namespace string, selector map[string]string) (w watch.Interface, err error) {
+	w, err = c.clientset.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("watching pods in namespace %s: %w", namespace, err)
+	}
+
+	return w, nil
+}
+
+// Events returns events of the Pod, like container restarts and OOM kills,
+// ordered by the time they were last seen
+func (c *Client) Events(ctx context.Context, name, namespace string) (events []v1.Event, err error) {
+	list, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": "Pod",
+			"involvedObject.name": name,
+		}.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing events of pod %s in namespace %s: %w", name, namespace, err)
+	}
+
+	events = list.Items
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastTimestamp.Before(&events[j].LastTimestamp)
+	})
+
+	return events, nil
+}
+
+// ContainerStatuses returns statuses of the Pod's containers, or nil if the Pod does not exist
+func (c *Client) ContainerStatuses(ctx context.Context, name, namespace string) (statuses []v1.ContainerStatus, err error) {
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting pod %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return pod.Status.ContainerStatuses, nil
+}
+
+// LogsOptions holds optional parameters for fetching Pod logs.
+type LogsOptions struct {
+	Container string
+	SinceTime time.Time // zero for logs since the container start
+	TailLines int64     // zero for all lines
+}
+
+// Logs returns logs of the Pod's container
+func (c *Client) Logs(ctx context.Context, name, namespace string, o LogsOptions) (logs []byte, err error) {
+	opts := &v1.PodLogOptions{Container: o.Container}
+	if !o.SinceTime.IsZero() {
+		since := metav1.NewTime(o.SinceTime)
+		opts.SinceTime = &since
+	}
+	if o.TailLines > 0 {
+		opts.TailLines = &o.TailLines
+	}
+
+	logs, err = c.clientset.CoreV1().Pods(namespace).GetLogs(name, opts).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting logs of pod %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return logs, nil
+}
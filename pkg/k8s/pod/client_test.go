@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	mock "github.com/ethersphere/beekeeper/mocks/k8s"
 	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
@@ -155,3 +156,287 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestKill(t *testing.T) {
+	testTable := []struct {
+		name      string
+		podName   string
+		clientset kubernetes.Interface
+		errorMsg  error
+	}{
+		{
+			name:    "kill_pod",
+			podName: "test_pod",
+			clientset: fake.NewSimpleClientset(&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test_pod",
+					Namespace: "test",
+				},
+			}),
+		},
+		{
+			name:    "kill_not_found",
+			podName: "test_pod_not_found",
+			clientset: fake.NewSimpleClientset(&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test_pod",
+					Namespace: "test",
+				},
+			}),
+		},
+		{
+			name:      "kill_error",
+			podName:   "delete_bad",
+			clientset: mock.NewClientset(),
+			errorMsg:  fmt.Errorf("killing pod delete_bad in namespace test: mock error: cannot delete pod"),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			client := pod.NewClient(test.clientset)
+			err := client.Kill(context.Background(), test.podName, "test")
+			if test.errorMsg == nil {
+				if err != nil {
+					t.Errorf("error not expected, got: %s", err.Error())
+				}
+				if _, err := test.clientset.CoreV1().Pods("test").Get(context.Background(), test.podName, metav1.GetOptions{}); err == nil {
+					t.Errorf("pod %s not deleted", test.podName)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("error not happened, expected: %s", test.errorMsg.Error())
+				}
+				if err.Error() != test.errorMsg.Error() {
+					t.Errorf("error expected: %s, got: %s", test.errorMsg.Error(), err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestLogs(t *testing.T) {
+	client := pod.NewClient(fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test_pod",
+			Namespace: "test",
+		},
+	}))
+
+	logs, err := client.Logs(context.Background(), "test_pod", "test", pod.LogsOptions{
+		Container: "bee",
+		SinceTime: time.Now().Add(-time.Hour),
+		TailLines: 100,
+	})
+	if err != nil {
+		t.Fatalf("error not expected, got: %s", err.Error())
+	}
+
+	// fake clientset returns fixed logs for every pod
+	if string(logs) != "fake logs" {
+		t.Errorf("logs expected: %q, got: %q", "fake logs", string(logs))
+	}
+}
+
+func TestList(t *testing.T) {
+	bee := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bee-0",
+			Namespace: "test",
+			Labels:    map[string]string{"app.kubernetes.io/name": "bee"},
+		},
+	}
+	other := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "geth-0",
+			Namespace: "test",
+			Labels:    map[string]string{"app.kubernetes.io/name": "geth"},
+		},
+	}
+
+	testTable := []struct {
+		name      string
+		selector  map[string]string
+		clientset kubernetes.Interface
+		expected  []string
+		errorMsg  error
+	}{
+		{
+			name:      "list_selected",
+			selector:  map[string]string{"app.kubernetes.io/name": "bee"},
+			clientset: fake.NewSimpleClientset(&bee, &other),
+			expected:  []string{"bee-0"},
+		},
+		{
+			name:      "list_none",
+			selector:  map[string]string{"app.kubernetes.io/name": "clef"},
+			clientset: fake.NewSimpleClientset(&bee, &other),
+		},
+		{
+			name:      "list_error",
+			selector:  map[string]string{"list": "bad"},
+			clientset: mock.NewClientset(),
+			errorMsg:  fmt.Errorf("listing pods in namespace test: mock error: cannot list pods"),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			client := pod.NewClient(test.clientset)
+			response, err := client.List(context.Background(), "test", test.selector)
+			if test.errorMsg == nil {
+				if err != nil {
+					t.Errorf("error not expected, got: %s", err.Error())
+				}
+				var names []string
+				for _, p := range response {
+					names = append(names, p.Name)
+				}
+				if !reflect.DeepEqual(names, test.expected) {
+					t.Errorf("response expected: %v, got: %v", test.expected, names)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("error not happened, expected: %s", test.errorMsg.Error())
+				}
+				if err.Error() != test.errorMsg.Error() {
+					t.Errorf("error expected: %s, got: %s", test.errorMsg.Error(), err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestContainerStatuses(t *testing.T) {
+	statuses := []v1.ContainerStatus{
+		{
+			Name:         "bee",
+			RestartCount: 2,
+			LastTerminationState: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled"},
+			},
+		},
+	}
+
+	testTable := []struct {
+		name      string
+		podName   string
+		clientset kubernetes.Interface
+		expected  []v1.ContainerStatus
+		errorMsg  error
+	}{
+		{
+			name:    "get_statuses",
+			podName: "test_pod",
+			clientset: fake.NewSimpleClientset(&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test_pod",
+					Namespace: "test",
+				},
+				Status: v1.PodStatus{
+					ContainerStatuses: statuses,
+				},
+			}),
+			expected: statuses,
+		},
+		{
+			name:      "get_not_found",
+			podName:   "test_pod_not_found",
+			clientset: fake.NewSimpleClientset(),
+		},
+		{
+			name:      "get_error",
+			podName:   "get_bad",
+			clientset: mock.NewClientset(),
+			errorMsg:  fmt.Errorf("getting pod get_bad in namespace test: mock error: cannot get pod"),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			client := pod.NewClient(test.clientset)
+			response, err := client.ContainerStatuses(context.Background(), test.podName, "test")
+			if test.errorMsg == nil {
+				if err != nil {
+					t.Errorf("error not expected, got: %s", err.Error())
+				}
+				if !reflect.DeepEqual(response, test.expected) {
+					t.Errorf("response expected: %v, got: %v", test.expected, response)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("error not happened, expected: %s", test.errorMsg.Error())
+				}
+				if err.Error() != test.errorMsg.Error() {
+					t.Errorf("error expected: %s, got: %s", test.errorMsg.Error(), err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	client := pod.NewClient(fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bee-0",
+			Namespace: "test",
+			Labels:    map[string]string{"app.kubernetes.io/name": "bee"},
+		},
+	}))
+
+	w, err := client.Watch(context.Background(), "test", map[string]string{"app.kubernetes.io/name": "bee"})
+	if err != nil {
+		t.Fatalf("error not expected, got: %s", err.Error())
+	}
+	defer w.Stop()
+
+	if _, err := client.Set(context.Background(), "bee-1", "test", pod.Options{
+		Labels: map[string]string{"app.kubernetes.io/name": "bee"},
+	}); err != nil {
+		t.Fatalf("error not expected, got: %s", err.Error())
+	}
+
+	select {
+	case e := <-w.ResultChan():
+		p, ok := e.Object.(*v1.Pod)
+		if !ok {
+			t.Fatalf("pod expected, got: %T", e.Object)
+		}
+		if p.Name != "bee-1" {
+			t.Errorf("pod expected: bee-1, got: %s", p.Name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("pod change not watched")
+	}
+}
+
+func TestEvents(t *testing.T) {
+	now := time.Now()
+	client := pod.NewClient(fake.NewSimpleClientset(
+		&v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "bee-0.2", Namespace: "test"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "bee-0"},
+			Reason:         "BackOff",
+			LastTimestamp:  metav1.NewTime(now),
+		},
+		&v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "bee-0.1", Namespace: "test"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "bee-0"},
+			Reason:         "Killing",
+			LastTimestamp:  metav1.NewTime(now.Add(-time.Minute)),
+		},
+	))
+
+	events, err := client.Events(context.Background(), "bee-0", "test")
+	if err != nil {
+		t.Fatalf("error not expected, got: %s", err.Error())
+	}
+
+	var reasons []string
+	for _, e := range events {
+		reasons = append(reasons, e.Reason)
+	}
+	if expected := []string{"Killing", "BackOff"}; !reflect.DeepEqual(reasons, expected) {
+		t.Errorf("events expected: %v, got: %v", expected, reasons)
+	}
+}
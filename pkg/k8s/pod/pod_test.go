@@ -347,6 +347,27 @@ func TestToK8S(t *testing.T) {
 				return newPodSpec
 			}(),
 		},
+		{
+			name: "tolerations_without_seconds",
+			pts: pod.PodTemplateSpec{
+				Spec: pod.PodSpec{
+					Tolerations: pod.Tolerations{{
+						Key:      "key",
+						Operator: "operator",
+						Effect:   "effect",
+					}},
+				},
+			},
+			expected: func() v1.PodTemplateSpec {
+				newPodSpec := newDefaultPodTemplateSpec()
+				newPodSpec.Spec.Tolerations = []v1.Toleration{{
+					Key:      "key",
+					Operator: "operator",
+					Effect:   "effect",
+				}}
+				return newPodSpec
+			}(),
+		},
 		{
 			name: "topology_spread_constraints",
 			pts: pod.PodTemplateSpec{
@@ -601,6 +622,62 @@ func TestToK8S(t *testing.T) {
 				return newPodSpec
 			}(),
 		},
+		{
+			name: "security_non_root_seccomp",
+			pts: pod.PodTemplateSpec{
+				Spec: pod.PodSpec{
+					PodSecurityContext: pod.PodSecurityContext{
+						FSGroup: func() *int64 {
+							var fsGroup int64 = 999
+							return &fsGroup
+						}(),
+						RunAsNonRoot: func() *bool {
+							var nonRoot bool = true
+							return &nonRoot
+						}(),
+						SeccompProfile: pod.SeccompProfile{
+							Type: "RuntimeDefault",
+						},
+					},
+				},
+			},
+			expected: func() v1.PodTemplateSpec {
+				newPodSpec := newDefaultPodTemplateSpec()
+				newPodSpec.Spec.SecurityContext.FSGroup = func() *int64 {
+					var fsGroup int64 = 999
+					return &fsGroup
+				}()
+				newPodSpec.Spec.SecurityContext.RunAsNonRoot = func() *bool {
+					var nonRoot bool = true
+					return &nonRoot
+				}()
+				newPodSpec.Spec.SecurityContext.SeccompProfile = &v1.SeccompProfile{
+					Type: v1.SeccompProfileTypeRuntimeDefault,
+				}
+				return newPodSpec
+			}(),
+		},
+		{
+			name: "security_root",
+			pts: pod.PodTemplateSpec{
+				Spec: pod.PodSpec{
+					PodSecurityContext: pod.PodSecurityContext{
+						FSGroup:      new(int64),
+						RunAsGroup:   new(int64),
+						RunAsNonRoot: new(bool),
+						RunAsUser:    new(int64),
+					},
+				},
+			},
+			expected: func() v1.PodTemplateSpec {
+				newPodSpec := newDefaultPodTemplateSpec()
+				newPodSpec.Spec.SecurityContext.FSGroup = new(int64)
+				newPodSpec.Spec.SecurityContext.RunAsGroup = new(int64)
+				newPodSpec.Spec.SecurityContext.RunAsNonRoot = new(bool)
+				newPodSpec.Spec.SecurityContext.RunAsUser = new(int64)
+				return newPodSpec
+			}(),
+		},
 	}
 
 	for _, test := range testTable {
@@ -623,10 +700,6 @@ func newDefaultPodTemplateSpec() v1.PodTemplateSpec {
 			ShareProcessNamespace:         new(bool),
 			SecurityContext: &v1.PodSecurityContext{
 				SELinuxOptions: &v1.SELinuxOptions{},
-				RunAsUser:      new(int64),
-				RunAsGroup:     new(int64),
-				RunAsNonRoot:   new(bool),
-				FSGroup:        new(int64),
 			},
 			Affinity:           &v1.Affinity{},
 			Priority:           new(int32),
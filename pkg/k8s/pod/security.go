@@ -6,11 +6,12 @@ import (
 
 // PodSecurityContext represents Kubernetes PodSecurityContext
 type PodSecurityContext struct {
-	FSGroup             int64
+	FSGroup             *int64 // left to the cluster if nil
 	FSGroupChangePolicy string
-	RunAsGroup          int64
-	RunAsNonRoot        bool
-	RunAsUser           int64
+	RunAsGroup          *int64 // left to the image if nil
+	RunAsNonRoot        *bool  // left to the cluster if nil
+	RunAsUser           *int64 // left to the image if nil
+	SeccompProfile      SeccompProfile
 	SELinuxOptions      SELinuxOptions
 	SupplementalGroups  []int64
 	Sysctls             Sysctls
@@ -20,7 +21,7 @@ type PodSecurityContext struct {
 // toK8S converts PodSecurityContext to Kuberntes client object
 func (psc *PodSecurityContext) toK8S() *v1.PodSecurityContext {
 	return &v1.PodSecurityContext{
-		FSGroup: &psc.FSGroup,
+		FSGroup: psc.FSGroup,
 		FSGroupChangePolicy: func() *v1.PodFSGroupChangePolicy {
 			if len(psc.FSGroupChangePolicy) == 0 {
 				return nil
@@ -28,9 +29,10 @@ func (psc *PodSecurityContext) toK8S() *v1.PodSecurityContext {
 			f := v1.PodFSGroupChangePolicy(psc.FSGroupChangePolicy)
 			return &f
 		}(),
-		RunAsGroup:         &psc.RunAsGroup,
-		RunAsNonRoot:       &psc.RunAsNonRoot,
-		RunAsUser:          &psc.RunAsUser,
+		RunAsGroup:         psc.RunAsGroup,
+		RunAsNonRoot:       psc.RunAsNonRoot,
+		RunAsUser:          psc.RunAsUser,
+		SeccompProfile:     psc.SeccompProfile.toK8S(),
 		SELinuxOptions:     psc.SELinuxOptions.toK8S(),
 		SupplementalGroups: psc.SupplementalGroups,
 		Sysctls:            psc.Sysctls.toK8S(),
@@ -38,6 +40,24 @@ func (psc *PodSecurityContext) toK8S() *v1.PodSecurityContext {
 	}
 }
 
+// SeccompProfile represents Kubernetes SeccompProfile
+type SeccompProfile struct {
+	Type             string
+	LocalhostProfile string
+}
+
+// toK8S converts SeccompProfile to Kuberntes client object
+func (sp *SeccompProfile) toK8S() *v1.SeccompProfile {
+	if sp.Type == "" {
+		return nil
+	}
+	p := v1.SeccompProfile{Type: v1.SeccompProfileType(sp.Type)}
+	if sp.LocalhostProfile != "" {
+		p.LocalhostProfile = &sp.LocalhostProfile
+	}
+	return &p
+}
+
 // SELinuxOptions represents Kubernetes SELinuxOptions
 type SELinuxOptions struct {
 	User  string
@@ -22,16 +22,20 @@ type Toleration struct {
 	Operator          string
 	Value             string
 	Effect            string
-	TolerationSeconds int64
+	TolerationSeconds int64 // only for NoExecute taints, tolerated forever if 0
 }
 
 // toK8S converts Toleration to Kuberntes client object
 func (t *Toleration) toK8S() v1.Toleration {
-	return v1.Toleration{
-		Key:               t.Key,
-		Operator:          v1.TolerationOperator(t.Operator),
-		Value:             t.Value,
-		Effect:            v1.TaintEffect(t.Effect),
-		TolerationSeconds: &t.TolerationSeconds,
+	toleration := v1.Toleration{
+		Key:      t.Key,
+		Operator: v1.TolerationOperator(t.Operator),
+		Value:    t.Value,
+		Effect:   v1.TaintEffect(t.Effect),
 	}
+	if t.TolerationSeconds > 0 {
+		seconds := t.TolerationSeconds
+		toleration.TolerationSeconds = &seconds
+	}
+	return toleration
 }
@@ -0,0 +1,351 @@
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// Client manages port-forwards to Kubernetes Pods.
+type Client struct {
+	clientset kubernetes.Interface
+	config    *rest.Config
+}
+
+// NewClient constructs a new Client.
+func NewClient(clientset kubernetes.Interface, config *rest.Config) *Client {
+	return &Client{
+		clientset: clientset,
+		config:    config,
+	}
+}
+
+// Dial returns connection to the port of the Pod, forwarded through the
+// Kubernetes API server, so the Pod is reached without exposing it. Every
+// connection is forwarded separately, so connections dialed after the Pod is
+// restarted reach the new one.
+func (c *Client) Dial(ctx context.Context, name, namespace string, port int) (net.Conn, error) {
+	if c.config == nil {
+		return nil, fmt.Errorf("port-forwarding pod %s in namespace %s: kubernetes client config is not set", name, namespace)
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(c.config)
+	if err != nil {
+		return nil, fmt.Errorf("port-forwarding pod %s in namespace %s: %w", name, namespace, err)
+	}
+
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	type dialResult struct {
+		conn httpstream.Connection
+		err  error
+	}
+	result := make(chan dialResult, 1)
+	go func() {
+		conn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+		result <- dialResult{conn: conn, err: err}
+	}()
+
+	var streamConn httpstream.Connection
+	select {
+	case r := <-result:
+		if r.err != nil {
+			return nil, fmt.Errorf("port-forwarding pod %s in namespace %s: %w", name, namespace, r.err)
+		}
+		streamConn = r.conn
+	case <-ctx.Done():
+		go func() {
+			if r := <-result; r.conn != nil {
+				r.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+
+	headers := http.Header{}
+	headers.Set(v1.StreamType, v1.StreamTypeError)
+	headers.Set(v1.PortHeader, strconv.Itoa(port))
+	headers.Set(v1.PortForwardRequestIDHeader, "0")
+	errorStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		streamConn.Close()
+		return nil, fmt.Errorf("creating error stream of pod %s port %d in namespace %s: %w", name, port, namespace, err)
+	}
+	// nothing is written to the error stream
+	errorStream.Close()
+
+	headers.Set(v1.StreamType, v1.StreamTypeData)
+	dataStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		streamConn.Close()
+		return nil, fmt.Errorf("creating data stream of pod %s port %d in namespace %s: %w", name, port, namespace, err)
+	}
+
+	conn := newConn(dataStream, streamConn, addr(fmt.Sprintf("%s.%s:%d", name, namespace, port)))
+	go func() {
+		// the forward of the connection fails, like if the pod does not
+		// listen on the port, with a message on the error stream
+		if message, err := io.ReadAll(errorStream); err == nil && len(message) > 0 {
+			conn.Close()
+		}
+	}()
+
+	return conn, nil
+}
+
+// conn is a connection forwarded to a port of a Pod. Streams have no
+// deadlines, so the stream is read by a goroutine and reads wait for its data
+// until the read deadline, while writes are abandoned at the write deadline by
+// closing the connection.
+type conn struct {
+	stream     httpstream.Stream
+	streamConn httpstream.Connection
+	remote     addr
+
+	readOnce sync.Once
+	reads    chan readResult
+	readMu   sync.Mutex
+	pending  []byte // data read from the stream and not returned yet
+	readErr  error  // error of the stream returned after the pending data
+
+	writeMu sync.Mutex
+
+	readDeadline  *deadline
+	writeDeadline *deadline
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// readResult is the result of a read of the stream
+type readResult struct {
+	data []byte
+	err  error
+}
+
+func newConn(stream httpstream.Stream, streamConn httpstream.Connection, remote addr) *conn {
+	return &conn{
+		stream:        stream,
+		streamConn:    streamConn,
+		remote:        remote,
+		reads:         make(chan readResult),
+		readDeadline:  newDeadline(),
+		writeDeadline: newDeadline(),
+		closed:        make(chan struct{}),
+	}
+}
+
+// pump reads the stream until it fails or the connection is closed
+func (c *conn) pump() {
+	for {
+		buf := make([]byte, 32*1024)
+		n, err := c.stream.Read(buf)
+		if n > 0 {
+			select {
+			case c.reads <- readResult{data: buf[:n]}:
+			case <-c.closed:
+				return
+			}
+		}
+		if err != nil {
+			select {
+			case c.reads <- readResult{err: err}:
+			case <-c.closed:
+			}
+			return
+		}
+	}
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	c.readOnce.Do(func() { go c.pump() })
+
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+
+	// the expired deadline is reported before the data already read
+	select {
+	case <-c.readDeadline.done():
+		return 0, os.ErrDeadlineExceeded
+	default:
+	}
+
+	for len(c.pending) == 0 {
+		if c.readErr != nil {
+			return 0, c.readErr
+		}
+
+		select {
+		case r := <-c.reads:
+			c.pending, c.readErr = r.data, r.err
+		case <-c.readDeadline.done():
+			return 0, os.ErrDeadlineExceeded
+		case <-c.closed:
+			return 0, net.ErrClosed
+		}
+	}
+
+	n := copy(b, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+func (c *conn) Write(b []byte) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	expired := c.writeDeadline.done()
+	select {
+	case <-expired:
+		return 0, os.ErrDeadlineExceeded
+	case <-c.closed:
+		return 0, net.ErrClosed
+	default:
+	}
+
+	type writeResult struct {
+		n   int
+		err error
+	}
+	result := make(chan writeResult, 1)
+	go func() {
+		n, err := c.stream.Write(b)
+		result <- writeResult{n: n, err: err}
+	}()
+
+	select {
+	case r := <-result:
+		return r.n, r.err
+	case <-expired:
+		// a partly written stream can not be continued
+		c.Close()
+		r := <-result
+		return r.n, os.ErrDeadlineExceeded
+	}
+}
+
+// Close closes the stream and the connection to the API server it is
+// multiplexed on
+func (c *conn) Close() (err error) {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.stream.Reset()
+		err = c.streamConn.Close()
+	})
+	return
+}
+
+func (c *conn) LocalAddr() net.Addr {
+	return addr("port-forward")
+}
+
+func (c *conn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+func (c *conn) SetDeadline(t time.Time) error {
+	c.readDeadline.set(t)
+	c.writeDeadline.set(t)
+	return nil
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	c.readDeadline.set(t)
+	return nil
+}
+
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	c.writeDeadline.set(t)
+	return nil
+}
+
+// deadline is a deadline of reads or writes that can be changed while they
+// wait for it
+type deadline struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	expired chan struct{} // closed when the deadline expires
+}
+
+func newDeadline() *deadline {
+	return &deadline{expired: make(chan struct{})}
+}
+
+// set sets the deadline, the zero time for none
+func (d *deadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil && !d.timer.Stop() {
+		// wait for the timer to expire the deadline
+		<-d.expired
+	}
+	d.timer = nil
+
+	expired := isClosed(d.expired)
+	if t.IsZero() {
+		if expired {
+			d.expired = make(chan struct{})
+		}
+		return
+	}
+
+	if dur := time.Until(t); dur > 0 {
+		if expired {
+			d.expired = make(chan struct{})
+		}
+		ch := d.expired
+		d.timer = time.AfterFunc(dur, func() { close(ch) })
+		return
+	}
+
+	if !expired {
+		close(d.expired)
+	}
+}
+
+// done returns the channel closed when the deadline expires
+func (d *deadline) done() chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.expired
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// addr is address of a forwarded connection
+type addr string
+
+func (a addr) Network() string {
+	return "port-forward"
+}
+
+func (a addr) String() string {
+	return string(a)
+}
@@ -0,0 +1,118 @@
+package portforward
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// testStream is the stream of one end of a pipe
+type testStream struct {
+	net.Conn
+}
+
+func (s testStream) Reset() error         { return s.Conn.Close() }
+func (s testStream) Headers() http.Header { return nil }
+func (s testStream) Identifier() uint32   { return 0 }
+
+// testConnection is the connection streams are multiplexed on
+type testConnection struct {
+	httpstream.Connection
+}
+
+func (testConnection) Close() error { return nil }
+
+// newTestConn returns the forwarded connection and the other end of its
+// stream
+func newTestConn(t *testing.T) (*conn, net.Conn) {
+	local, remote := net.Pipe()
+	c := newConn(testStream{local}, testConnection{}, addr("bee-0.test:1633"))
+	t.Cleanup(func() {
+		c.Close()
+		remote.Close()
+	})
+	return c, remote
+}
+
+func TestConnReadDeadline(t *testing.T) {
+	c, remote := newTestConn(t)
+
+	if err := c.SetReadDeadline(time.Now().Add(20 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 5)
+	if _, err := c.Read(buf); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+	var netErr net.Error
+	if _, err := c.Read(buf); !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("got error %v after the deadline, want timeout", err)
+	}
+
+	// the connection is read after the deadline is extended
+	go func() {
+		_, _ = remote.Write([]byte("hello"))
+	}()
+	if err := c.SetReadDeadline(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadFull(c, buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "hello" {
+		t.Errorf("got %q, want %q", buf, "hello")
+	}
+
+	// a deadline in the past expires waiting reads
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Read(buf)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if err := c.SetDeadline(time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+}
+
+func TestConnWriteDeadline(t *testing.T) {
+	c, remote := newTestConn(t)
+
+	if err := c.SetWriteDeadline(time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Write([]byte("hello")); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+
+	// writes pass before the deadline
+	if err := c.SetWriteDeadline(time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_, _ = io.ReadFull(remote, make([]byte, 5))
+	}()
+	if _, err := c.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+
+	// the write blocked on the remote end is abandoned with the connection
+	if err := c.SetWriteDeadline(time.Now().Add(20 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Write([]byte("hello")); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+	if _, err := c.Read(make([]byte, 1)); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("got error %v reading after the abandoned write, want %v", err, net.ErrClosed)
+	}
+}
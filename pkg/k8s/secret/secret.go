@@ -62,6 +62,29 @@ func (c *Client) Set(ctx context.Context, name, namespace string, o Options) (sc
 	return
 }
 
+// Get returns data of the Secret, or nil if it does not exist
+func (c *Client) Get(ctx context.Context, name, namespace string) (data map[string][]byte, err error) {
+	sc, err := c.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting secret %s in namespace %s: %w", name, namespace, err)
+	}
+
+	data = make(map[string][]byte, len(sc.Data)+len(sc.StringData))
+	for k, v := range sc.Data {
+		data[k] = v
+	}
+	// string data is merged into data by the API server, but not by fake
+	// clientsets
+	for k, v := range sc.StringData {
+		data[k] = []byte(v)
+	}
+
+	return
+}
+
 // Delete deletes Secret
 func (c *Client) Delete(ctx context.Context, name, namespace string) (err error) {
 	err = c.clientset.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
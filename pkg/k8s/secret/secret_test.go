@@ -168,3 +168,60 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestGet(t *testing.T) {
+	testTable := []struct {
+		name       string
+		secretName string
+		clientset  kubernetes.Interface
+		expected   map[string][]byte
+		errorMsg   error
+	}{
+		{
+			name:       "get_secret",
+			secretName: "test_secret",
+			clientset: fake.NewSimpleClientset(&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test_secret",
+					Namespace: "test",
+				},
+				Data:       map[string][]byte{"bee-0": {1, 2, 3}},
+				StringData: map[string]string{"bee-1": "key"},
+			}),
+			expected: map[string][]byte{"bee-0": {1, 2, 3}, "bee-1": []byte("key")},
+		},
+		{
+			name:       "get_not_found",
+			secretName: "test_secret_not_found",
+			clientset:  fake.NewSimpleClientset(),
+		},
+		{
+			name:       "get_error",
+			secretName: "get_bad",
+			clientset:  mock.NewClientset(),
+			errorMsg:   fmt.Errorf("getting secret get_bad in namespace test: mock error: cannot get secret"),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			client := secret.NewClient(test.clientset)
+			data, err := client.Get(context.Background(), test.secretName, "test")
+			if test.errorMsg == nil {
+				if err != nil {
+					t.Errorf("error not expected, got: %s", err.Error())
+				}
+				if !reflect.DeepEqual(data, test.expected) {
+					t.Errorf("data expected: %v, got: %v", test.expected, data)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("error not happened, expected: %s", test.errorMsg.Error())
+				}
+				if err.Error() != test.errorMsg.Error() {
+					t.Errorf("error expected: %s, got: %s", test.errorMsg.Error(), err.Error())
+				}
+			}
+		})
+	}
+}
@@ -57,6 +57,22 @@ func (c *Client) ReadyReplicas(ctx context.Context, name, namespace string) (rea
 	return
 }
 
+// Replicas returns desired number of Pods of the StatefulSet, or 0 if the StatefulSet does not exist
+func (c *Client) Replicas(ctx context.Context, name, namespace string) (replicas int32, err error) {
+	s, err := c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("getting replicas from statefulset %s in namespace %s: %w", name, namespace, err)
+	}
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+
+	return
+}
+
 // ReadyReplicasWatch returns number of Pods created by the StatefulSet controller that have a Ready Condition by watching events
 func (c *Client) ReadyReplicasWatch(ctx context.Context, name, namespace string) (ready int32, err error) {
 	watcher, err := c.clientset.AppsV1().StatefulSets(namespace).Watch(ctx, metav1.ListOptions{
@@ -147,6 +163,32 @@ func (c *Client) Set(ctx context.Context, name, namespace string, o Options) (st
 	return
 }
 
+// SetImage sets image of the StatefulSet's container
+func (c *Client) SetImage(ctx context.Context, name, namespace, container, image string) (statefulSet *appsv1.StatefulSet, err error) {
+	statefulSet, err = c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting statefulset %s in namespace %s: %w", name, namespace, err)
+	}
+
+	found := false
+	for i, ct := range statefulSet.Spec.Template.Spec.Containers {
+		if ct.Name == container {
+			statefulSet.Spec.Template.Spec.Containers[i].Image = image
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("container %s not found in statefulset %s in namespace %s", container, name, namespace)
+	}
+
+	statefulSet, err = c.clientset.AppsV1().StatefulSets(namespace).Update(ctx, statefulSet, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("updating statefulset %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
+
 // StoppedStatefulSets returns names of stopped StatefulSets
 func (c *Client) StoppedStatefulSets(ctx context.Context, namespace string) (stopped []string, err error) {
 	statefulSets, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
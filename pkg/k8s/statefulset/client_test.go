@@ -10,6 +10,7 @@ import (
 	"github.com/ethersphere/beekeeper/pkg/k8s/statefulset"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
@@ -247,6 +248,70 @@ func TestReadyReplicas(t *testing.T) {
 	}
 }
 
+func TestReplicas(t *testing.T) {
+	replicas := int32(3)
+
+	testTable := []struct {
+		name            string
+		statefulsetName string
+		clientset       kubernetes.Interface
+		expected        int32
+		errorMsg        error
+	}{
+		{
+			name:            "statefulset_not_found",
+			statefulsetName: "test_statefulset",
+			clientset:       fake.NewSimpleClientset(),
+			expected:        0,
+		},
+		{
+			name:            "replicas_found",
+			statefulsetName: "test_statefulset",
+			clientset: fake.NewSimpleClientset(&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test_statefulset",
+					Namespace: "test",
+				},
+				Spec: appsv1.StatefulSetSpec{Replicas: &replicas},
+			}),
+			expected: 3,
+		},
+		{
+			name:            "replicas_error",
+			statefulsetName: "statefulset_bad",
+			clientset:       mock.NewClientset(),
+			errorMsg:        fmt.Errorf("getting replicas from statefulset statefulset_bad in namespace test: mock error: bad request"),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			client := statefulset.NewClient(test.clientset)
+			replicas, err := client.Replicas(context.Background(), test.statefulsetName, "test")
+			if test.errorMsg == nil {
+				if err != nil {
+					t.Errorf("error not expected, got: %s", err.Error())
+				}
+
+				if replicas != test.expected {
+					t.Errorf("response expected: %v, got: %v", test.expected, replicas)
+				}
+
+			} else {
+				if err == nil {
+					t.Fatalf("error not happened, expected: %s", test.errorMsg.Error())
+				}
+				if err.Error() != test.errorMsg.Error() {
+					t.Errorf("error expected: %s, got: %s", test.errorMsg.Error(), err.Error())
+				}
+				if replicas != 0 {
+					t.Errorf("response not expected")
+				}
+			}
+		})
+	}
+}
+
 func TestReadyReplicasWatch(t *testing.T) {
 	testTable := []struct {
 		name            string
@@ -465,6 +530,86 @@ func TestScale(t *testing.T) {
 	}
 }
 
+func TestSetImage(t *testing.T) {
+	existing := func() *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test_statefulset",
+				Namespace: "test",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "bee", Image: "ethersphere/bee:1.12.0"},
+							{Name: "clef", Image: "ethersphere/clef:0.13.2"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testTable := []struct {
+		name            string
+		statefulSetName string
+		container       string
+		clientset       kubernetes.Interface
+		errorMsg        error
+	}{
+		{
+			name:            "set_image",
+			statefulSetName: "test_statefulset",
+			container:       "bee",
+			clientset:       fake.NewSimpleClientset(existing()),
+		},
+		{
+			name:            "container_not_found",
+			statefulSetName: "test_statefulset",
+			container:       "unknown",
+			clientset:       fake.NewSimpleClientset(existing()),
+			errorMsg:        fmt.Errorf("container unknown not found in statefulset test_statefulset in namespace test"),
+		},
+		{
+			name:            "get_error",
+			statefulSetName: "statefulset_bad",
+			container:       "bee",
+			clientset:       mock.NewClientset(),
+			errorMsg:        fmt.Errorf("getting statefulset statefulset_bad in namespace test: mock error: bad request"),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			client := statefulset.NewClient(test.clientset)
+			response, err := client.SetImage(context.Background(), test.statefulSetName, "test", test.container, "ethersphere/bee:1.13.0")
+			if test.errorMsg == nil {
+				if err != nil {
+					t.Fatalf("error not expected, got: %s", err.Error())
+				}
+
+				containers := response.Spec.Template.Spec.Containers
+				if containers[0].Image != "ethersphere/bee:1.13.0" {
+					t.Errorf("image expected: %s, got: %s", "ethersphere/bee:1.13.0", containers[0].Image)
+				}
+				if containers[1].Image != "ethersphere/clef:0.13.2" {
+					t.Errorf("image of other containers changed to: %s", containers[1].Image)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("error not happened, expected: %s", test.errorMsg.Error())
+				}
+				if err.Error() != test.errorMsg.Error() {
+					t.Errorf("error expected: %s, got: %s", test.errorMsg.Error(), err.Error())
+				}
+				if response != nil {
+					t.Errorf("response not expected")
+				}
+			}
+		})
+	}
+}
+
 func TestStoppedStatefulSets(t *testing.T) {
 	testTable := []struct {
 		name             string
@@ -0,0 +1,288 @@
+// Package elasticsearch provides a client indexing log documents in
+// Elasticsearch with the bulk API.
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultIndex      = "beekeeper"
+	defaultBatchSize  = 500
+	defaultBatchWait  = time.Second
+	defaultQueueSize  = 10000
+	defaultMaxRetries = 3
+	defaultBackoff    = time.Second
+)
+
+// ClientOptions holds optional parameters for the Client
+type ClientOptions struct {
+	HTTPClient *http.Client
+	// Index is the prefix of daily indices documents are indexed in, like
+	// beekeeper-2023.10.16
+	Index string
+	// Username and Password authenticate requests with basic authentication
+	Username string
+	Password string
+	// APIKey authenticates requests with the base64 encoded API key
+	APIKey string
+
+	BatchSize  int           // number of documents indexed in one request
+	BatchWait  time.Duration // maximum time documents wait for a batch
+	QueueSize  int           // number of documents waiting to be indexed, more are dropped
+	MaxRetries int           // number of retries of a failed request, negative for none
+	// ErrorLog receives errors of requests, as the client can not log them
+	// to the logger it indexes logs of, defaults to standard error
+	ErrorLog io.Writer
+}
+
+// document is a log document with the time it is indexed by
+type document struct {
+	time time.Time
+	doc  map[string]interface{}
+}
+
+// Client indexes documents in batches with the bulk API in the background.
+// Failed requests are retried with exponential backoff and dropped after
+// all retries failed.
+type Client struct {
+	url  string
+	opts ClientOptions
+	docs chan document
+	quit chan struct{}
+	done chan struct{}
+
+	closeOnce sync.Once
+}
+
+// NewClient returns the client indexing documents in Elasticsearch at the
+// URL and starts indexing
+func NewClient(url string, o ClientOptions) (*Client, error) {
+	if url == "" {
+		return nil, fmt.Errorf("elasticsearch url not set")
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if o.Index == "" {
+		o.Index = defaultIndex
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultBatchSize
+	}
+	if o.BatchWait <= 0 {
+		o.BatchWait = defaultBatchWait
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = defaultQueueSize
+	}
+	if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	} else if o.MaxRetries == 0 {
+		o.MaxRetries = defaultMaxRetries
+	}
+	if o.ErrorLog == nil {
+		o.ErrorLog = os.Stderr
+	}
+
+	c := &Client{
+		url:  strings.TrimSuffix(url, "/"),
+		opts: o,
+		docs: make(chan document, o.QueueSize),
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go c.run()
+
+	return c, nil
+}
+
+// Index queues the document, it is indexed in the daily index of its time.
+// The document is dropped if the queue is full or the client is closed.
+func (c *Client) Index(t time.Time, doc map[string]interface{}) {
+	select {
+	case <-c.quit:
+		return
+	default:
+	}
+
+	select {
+	case c.docs <- document{time: t, doc: doc}:
+	default:
+		c.errorf("queue full, log document dropped")
+	}
+}
+
+// Close indexes queued documents and stops the client
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+	})
+	<-c.done
+	return nil
+}
+
+// run batches queued documents and indexes them
+func (c *Client) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.opts.BatchWait)
+	defer ticker.Stop()
+
+	var pending []document
+	flush := func(final bool) {
+		if len(pending) > 0 {
+			c.send(pending, final)
+			pending = nil
+		}
+	}
+
+	for {
+		select {
+		case d := <-c.docs:
+			pending = append(pending, d)
+			if len(pending) >= c.opts.BatchSize {
+				flush(false)
+			}
+		case <-ticker.C:
+			flush(false)
+		case <-c.quit:
+			for {
+				select {
+				case d := <-c.docs:
+					pending = append(pending, d)
+					continue
+				default:
+				}
+				break
+			}
+			flush(true)
+			return
+		}
+	}
+}
+
+// send indexes documents, retrying failed requests. Final documents are not
+// retried, the client is closing.
+func (c *Client) send(docs []document, final bool) {
+	body, err := c.bulkBody(docs)
+	if err != nil {
+		c.errorf("%v, %d log documents dropped", err, len(docs))
+		return
+	}
+
+	retries := c.opts.MaxRetries
+	if final {
+		retries = 0
+	}
+
+	backoff := defaultBackoff
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		if retry, err = c.bulk(body); err == nil || !retry || attempt >= retries {
+			break
+		}
+
+		select {
+		case <-c.quit:
+			retries = 0
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	if err != nil {
+		c.errorf("%v, %d log documents dropped", err, len(docs))
+	}
+}
+
+// bulkBody returns the newline delimited bulk request indexing documents
+func (c *Client) bulkBody(docs []document) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, d := range docs {
+		action := map[string]map[string]string{
+			"index": {"_index": c.opts.Index + "-" + d.time.UTC().Format("2006.01.02")},
+		}
+		if err := enc.Encode(action); err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(d.doc); err != nil {
+			return nil, fmt.Errorf("encode log document: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// bulk sends the bulk request once, it returns whether a failed request
+// should be retried. Documents rejected by Elasticsearch are reported and not
+// retried.
+func (c *Client) bulk(body []byte) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, c.url+"/_bulk", bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if c.opts.Username != "" || c.opts.Password != "" {
+		req.SetBasicAuth(c.opts.Username, c.opts.Password)
+	}
+	if c.opts.APIKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+c.opts.APIKey)
+	}
+
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err = fmt.Errorf("error indexing log documents (%s): %s", resp.Status, strings.TrimSpace(string(b)))
+		return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError, err
+	}
+
+	var r bulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return false, fmt.Errorf("decode bulk response: %w", err)
+	}
+	if r.Errors {
+		var failed int
+		var reason string
+		for _, item := range r.Items {
+			for _, result := range item {
+				if result.Error != nil {
+					failed++
+					reason = result.Error.Reason
+				}
+			}
+		}
+		c.errorf("%d log documents rejected: %s", failed, reason)
+	}
+
+	return false, nil
+}
+
+// bulkResponse is the response of the bulk API
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error,omitempty"`
+	} `json:"items"`
+}
+
+func (c *Client) errorf(format string, args ...interface{}) {
+	fmt.Fprintf(c.opts.ErrorLog, "elasticsearch: "+format+"\n", args...)
+}
@@ -0,0 +1,117 @@
+package elasticsearch_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/logging/elasticsearch"
+)
+
+func TestClient(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		lines []map[string]interface{}
+		auth  string
+	)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		auth = r.Header.Get("Authorization")
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var v map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &v); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			lines = append(lines, v)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"errors":false,"items":[]}`))
+	}))
+	defer s.Close()
+
+	c, err := elasticsearch.NewClient(s.URL, elasticsearch.ClientOptions{
+		Index:    "logs",
+		APIKey:   "key",
+		ErrorLog: io.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Index(time.Date(2023, 10, 16, 12, 0, 0, 0, time.UTC), map[string]interface{}{"message": "line", "check": "smoke"})
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if auth != "ApiKey key" {
+		t.Errorf("got authorization %q", auth)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("got %d bulk lines, want 2", len(lines))
+	}
+	action, ok := lines[0]["index"].(map[string]interface{})
+	if !ok || action["_index"] != "logs-2023.10.16" {
+		t.Errorf("got action %v, want index logs-2023.10.16", lines[0])
+	}
+	if lines[1]["message"] != "line" || lines[1]["check"] != "smoke" {
+		t.Errorf("got document %v", lines[1])
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"errors":false,"items":[]}`))
+	}))
+	defer s.Close()
+
+	c, err := elasticsearch.NewClient(s.URL, elasticsearch.ClientOptions{
+		BatchWait: 10 * time.Millisecond,
+		ErrorLog:  io.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Index(time.Now(), map[string]interface{}{"message": "line"})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := requests
+		mu.Unlock()
+		if n >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d requests, want a retry", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// Names of fields logged with lines of checks
+const (
+	FieldCheck     = "check"
+	FieldNode      = "node"
+	FieldIteration = "iteration"
+)
+
+// entryLogger logs lines with fields of its entry
+type entryLogger struct {
+	*logrus.Entry
+}
+
+// WithFields returns the logger that logs every line with the fields
+func WithFields(l Logger, fields logrus.Fields) Logger {
+	return &entryLogger{
+		Entry: l.WithFields(fields),
+	}
+}
+
+// WithLevel returns the logger that logs lines up to the level, with fields
+// of the logger, to the same output and hooks, like Loki, as the logger. It
+// scopes the level to a part of the run, like a check, without changing the
+// level of the logger.
+func WithLevel(l Logger, level logrus.Level) Logger {
+	e := l.NewEntry()
+	scoped := &logrus.Logger{
+		Out:          e.Logger.Out,
+		Hooks:        e.Logger.Hooks,
+		Formatter:    e.Logger.Formatter,
+		ReportCaller: e.Logger.ReportCaller,
+		Level:        level,
+		ExitFunc:     e.Logger.ExitFunc,
+	}
+	return &entryLogger{
+		Entry: logrus.NewEntry(scoped).WithFields(e.Data),
+	}
+}
+
+func (l *entryLogger) NewEntry() *logrus.Entry {
+	return l.Entry.Dup()
+}
+
+func (l *entryLogger) GetLevel() string {
+	return l.Entry.Logger.Level.String()
+}
@@ -7,8 +7,14 @@
 package logging
 
 import (
+	"crypto/tls"
+	"errors"
 	"io"
+	"time"
 
+	"github.com/ethersphere/beekeeper/pkg/logging/elasticsearch"
+	"github.com/ethersphere/beekeeper/pkg/logging/loki"
+	"github.com/ethersphere/beekeeper/pkg/logging/rotate"
 	"github.com/sirupsen/logrus"
 )
 
@@ -33,28 +39,159 @@ type Logger interface {
 type logger struct {
 	*logrus.Logger
 	metrics metrics
+	sinks   []io.Closer
 }
 
-func New(w io.Writer, level logrus.Level, lokiEndpoint string) Logger {
+// Option sets an optional parameter of the logger
+type Option func(*options)
+
+type options struct {
+	formatter            logrus.Formatter
+	loki                 loki.ClientOptions
+	lokiLabels           map[string]string
+	lokiFieldLabels      []string
+	elasticsearchURL     string
+	elasticsearchOptions elasticsearch.ClientOptions
+	filePath             string
+	fileOptions          rotate.Options
+}
+
+// WithJSONFormatter formats log lines as JSON objects with fields of the
+// line, like check, node and iteration, as object fields, so that they can
+// be ingested without parsing
+func WithJSONFormatter() Option {
+	return func(o *options) {
+		o.formatter = &logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		}
+	}
+}
+
+// WithLokiBuffer buffers log lines that could not be pushed to Loki, after
+// all retries failed, in the directory until Loki accepts them again. Size
+// of the directory is limited by maxBytes, 0 for no limit.
+func WithLokiBuffer(dir string, maxBytes int64) Option {
+	return func(o *options) {
+		o.loki.BufferDir = dir
+		o.loki.BufferMaxBytes = maxBytes
+	}
+}
+
+// WithLokiAuth authenticates requests to Loki with basic authentication, if
+// the username or password are set, or with the bearer token, and sends the
+// tenant ID to multi-tenant Loki
+func WithLokiAuth(username, password, bearerToken, tenantID string) Option {
+	return func(o *options) {
+		o.loki.Username = username
+		o.loki.Password = password
+		o.loki.BearerToken = bearerToken
+		o.loki.TenantID = tenantID
+	}
+}
+
+// WithLokiTLS sets TLS configuration of requests to Loki, like custom CAs and
+// client certificates
+func WithLokiTLS(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.loki.TLSConfig = cfg
+	}
+}
+
+// WithLokiLabels labels log lines pushed to Loki with the static labels,
+// like cluster or environment, and with values of the fields of log lines,
+// like check or node, as labels of the same names
+func WithLokiLabels(labels map[string]string, fields []string) Option {
+	return func(o *options) {
+		o.lokiLabels = labels
+		o.lokiFieldLabels = fields
+	}
+}
+
+// WithElasticsearch indexes log lines as documents with their fields in
+// Elasticsearch at the URL
+func WithElasticsearch(url string, eo elasticsearch.ClientOptions) Option {
+	return func(o *options) {
+		o.elasticsearchURL = url
+		o.elasticsearchOptions = eo
+	}
+}
+
+// WithFile writes log lines to the file, rotated by its size and age
+func WithFile(path string, fo rotate.Options) Option {
+	return func(o *options) {
+		o.filePath = path
+		o.fileOptions = fo
+	}
+}
+
+func New(w io.Writer, level logrus.Level, lokiEndpoint string, opts ...Option) Logger {
+	o := options{
+		formatter: &logrus.TextFormatter{
+			FullTimestamp: true,
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	l := logrus.New()
 	l.SetOutput(w)
 	l.SetLevel(level)
-	l.Formatter = &logrus.TextFormatter{
-		FullTimestamp: true,
-	}
+	l.Formatter = o.formatter
 
 	metrics := newMetrics()
 	l.AddHook(metrics)
 
+	var sinks []io.Closer
 	if lokiEndpoint != "" {
-		loki := newLoki(lokiEndpoint)
-		l.AddHook(loki)
+		if hook, err := newLoki(lokiEndpoint, o.loki, o.lokiLabels, o.lokiFieldLabels); err != nil {
+			l.Errorf("loki disabled: %v", err)
+		} else {
+			l.AddHook(hook)
+			sinks = append(sinks, hook)
+		}
+	}
+	if o.elasticsearchURL != "" {
+		if hook, err := newElasticsearchHook(o.elasticsearchURL, o.elasticsearchOptions); err != nil {
+			l.Errorf("elasticsearch disabled: %v", err)
+		} else {
+			l.AddHook(hook)
+			sinks = append(sinks, hook)
+		}
+	}
+	if o.filePath != "" {
+		formatter := o.formatter
+		if f, ok := formatter.(*logrus.TextFormatter); ok {
+			// terminal colors are escape codes in files
+			nf := *f
+			nf.DisableColors = true
+			formatter = &nf
+		}
+		if hook, err := newFileHook(o.filePath, o.fileOptions, formatter); err != nil {
+			l.Errorf("log file disabled: %v", err)
+		} else {
+			l.AddHook(hook)
+			sinks = append(sinks, hook)
+		}
 	}
 
 	return &logger{
 		Logger:  l,
 		metrics: metrics,
+		sinks:   sinks,
+	}
+}
+
+// Close sends log lines waiting to be sent to sinks, like Loki, and closes
+// them
+func (l *logger) Close() error {
+	var errs []error
+	for _, s := range l.sinks {
+		if err := s.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errors.Join(errs...)
 }
 
 func (l *logger) NewEntry() *logrus.Entry {
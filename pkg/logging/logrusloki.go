@@ -1,11 +1,7 @@
 package logging
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 
 	"github.com/ethersphere/beekeeper/pkg/logging/loki"
@@ -13,22 +9,37 @@ import (
 )
 
 type LokiHook struct {
-	hostname     string
-	lokiEndpoint string
+	labels      map[string]string
+	fieldLabels []string
+	client      *loki.Client
 }
 
-func newLoki(lokiEndpoint string) LokiHook {
+// newLoki returns the hook pushing log lines labeled with the hostname, the
+// static labels and values of the fields to Loki
+func newLoki(lokiEndpoint string, o loki.ClientOptions, labels map[string]string, fieldLabels []string) (*LokiHook, error) {
 	hostname, err := os.Hostname()
 	if err != nil {
 		hostname = "unknown"
 	}
-	return LokiHook{
-		hostname:     hostname,
-		lokiEndpoint: lokiEndpoint,
+
+	client, err := loki.NewClient(lokiEndpoint, o)
+	if err != nil {
+		return nil, err
+	}
+
+	l := map[string]string{"hostname": hostname}
+	for k, v := range labels {
+		l[k] = v
 	}
+
+	return &LokiHook{
+		labels:      l,
+		fieldLabels: fieldLabels,
+		client:      client,
+	}, nil
 }
 
-func (l LokiHook) Levels() []logrus.Level {
+func (l *LokiHook) Levels() []logrus.Level {
 	return []logrus.Level{
 		logrus.ErrorLevel,
 		logrus.WarnLevel,
@@ -38,56 +49,34 @@ func (l LokiHook) Levels() []logrus.Level {
 	}
 }
 
-func (l LokiHook) Fire(entry *logrus.Entry) error {
-	stream := loki.NewStream()
-
+// Fire queues the log line, it is pushed to Loki in the background
+func (l *LokiHook) Fire(entry *logrus.Entry) error {
 	msg, err := entry.Logger.Formatter.Format(entry)
 	if err != nil {
 		return fmt.Errorf("loki format failed: %s", err.Error())
 	}
-	stream.AddEntry(entry.Time, string(msg))
-	stream.AddLabel("hostname", l.hostname)
-
-	batch := loki.NewBatch()
-	batch.AddStream(stream)
 
-	err = l.executeHTTPRequest(batch)
-	if err != nil {
-		return fmt.Errorf("loki request failed: %s", err.Error())
+	labels, copied := l.labels, false
+	for _, f := range l.fieldLabels {
+		v, ok := entry.Data[f]
+		if !ok {
+			continue
+		}
+		if !copied { // static labels are shared by all lines
+			labels = make(map[string]string, len(l.labels)+len(l.fieldLabels))
+			for k, v := range l.labels {
+				labels[k] = v
+			}
+			copied = true
+		}
+		labels[f] = fmt.Sprint(v)
 	}
+
+	l.client.Push(labels, entry.Time, string(msg))
 	return nil
 }
 
-func (l LokiHook) executeHTTPRequest(batch *loki.Batch) error {
-	data, err := json.Marshal(batch)
-	if err != nil {
-		return err
-	}
-
-	req, err := http.NewRequest("POST", l.lokiEndpoint, bytes.NewReader(data))
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client := http.DefaultClient
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		data, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("error reading response (%s): %s", resp.Status, err.Error())
-		}
-
-		return fmt.Errorf("error posting loki batch (%s): %s", resp.Status, string(data))
-	}
-
-	return err
+// Close pushes queued log lines
+func (l *LokiHook) Close() error {
+	return l.client.Close()
 }
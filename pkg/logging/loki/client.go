@@ -0,0 +1,473 @@
+package loki
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchSize  = 500
+	defaultBatchWait  = time.Second
+	defaultQueueSize  = 10000
+	defaultMaxRetries = 5
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+
+	bufferFileExt = ".json"
+	tenantHeader  = "X-Scope-OrgID"
+)
+
+// ClientOptions holds optional parameters for the Client
+type ClientOptions struct {
+	HTTPClient *http.Client
+	// TLSConfig of requests, like custom CAs or client certificates, used if
+	// HTTPClient is not set
+	TLSConfig *tls.Config
+	// Username and Password authenticate requests with basic authentication,
+	// like to Grafana Cloud, where the username is the Loki user ID
+	Username string
+	Password string
+	// BearerToken authenticates requests with the bearer token
+	BearerToken string
+	// TenantID is sent in the X-Scope-OrgID header to multi-tenant Loki
+	TenantID string
+
+	BatchSize  int           // number of entries sent in one request
+	BatchWait  time.Duration // maximum time entries wait for a batch
+	QueueSize  int           // number of entries waiting to be sent, more are dropped
+	MaxRetries int           // number of retries of a failed request, negative for none
+	MinBackoff time.Duration // delay before the first retry, doubled on every retry
+	MaxBackoff time.Duration // maximum delay between retries
+	// BufferDir is the directory batches that failed all retries are written
+	// to, they are sent again once Loki accepts requests. Batches are dropped
+	// if it is not set.
+	BufferDir string
+	// BufferMaxBytes limits the size of the buffer directory, 0 for no limit
+	BufferMaxBytes int64
+	// ErrorLog receives errors of requests, as the client can not log them
+	// to the logger it sends logs of, defaults to standard error
+	ErrorLog io.Writer
+}
+
+// entry is a log line of a stream
+type entry struct {
+	labels map[string]string
+	time   time.Time
+	line   string
+}
+
+// Client pushes log lines to Loki in batches in the background. Failed
+// requests are retried with exponential backoff and batches that failed all
+// retries are written to the buffer directory, if it is set. Buffered batches
+// are sent before new ones, without retries, once Loki accepts requests
+// again, so new batches do not wait for retries during long outages. Loki has
+// to accept out of order writes for them, which it does by default.
+type Client struct {
+	endpoint string
+	opts     ClientOptions
+	entries  chan entry
+	quit     chan struct{}
+	done     chan struct{}
+
+	bufferMu   sync.Mutex
+	bufferSize int64
+	closeOnce  sync.Once
+}
+
+// NewClient returns the client pushing to the Loki push API endpoint and
+// starts sending
+func NewClient(endpoint string, o ClientOptions) (*Client, error) {
+	if o.HTTPClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = o.TLSConfig
+		o.HTTPClient = &http.Client{Transport: transport, Timeout: 30 * time.Second}
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultBatchSize
+	}
+	if o.BatchWait <= 0 {
+		o.BatchWait = defaultBatchWait
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = defaultQueueSize
+	}
+	if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	} else if o.MaxRetries == 0 {
+		o.MaxRetries = defaultMaxRetries
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = defaultMinBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaultMaxBackoff
+	}
+	if o.ErrorLog == nil {
+		o.ErrorLog = os.Stderr
+	}
+
+	c := &Client{
+		endpoint: endpoint,
+		opts:     o,
+		entries:  make(chan entry, o.QueueSize),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	if o.BufferDir != "" {
+		if err := os.MkdirAll(o.BufferDir, 0o755); err != nil {
+			return nil, fmt.Errorf("loki buffer: %w", err)
+		}
+		files, err := c.bufferFiles()
+		if err != nil {
+			return nil, fmt.Errorf("loki buffer: %w", err)
+		}
+		for _, f := range files {
+			if fi, err := os.Stat(f); err == nil {
+				c.bufferSize += fi.Size()
+			}
+		}
+	}
+
+	go c.run()
+
+	return c, nil
+}
+
+// Push queues the log line of the stream with the labels, the line is
+// dropped if the queue is full or the client is closed
+func (c *Client) Push(labels map[string]string, t time.Time, line string) {
+	select {
+	case <-c.quit:
+		return
+	default:
+	}
+
+	select {
+	case c.entries <- entry{labels: labels, time: t, line: line}:
+	default:
+		c.errorf("queue full, log line dropped")
+	}
+}
+
+// Close sends queued lines and stops the client. Lines that can not be sent
+// are buffered, if the buffer directory is set.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+	})
+	<-c.done
+	return nil
+}
+
+// run batches queued entries and sends them
+func (c *Client) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.opts.BatchWait)
+	defer ticker.Stop()
+
+	var pending []entry
+	flush := func(final bool) {
+		if len(pending) > 0 {
+			c.send(newBatch(pending), final)
+			pending = nil
+		}
+	}
+
+	for {
+		select {
+		case e := <-c.entries:
+			pending = append(pending, e)
+			if len(pending) >= c.opts.BatchSize {
+				flush(false)
+			}
+		case <-ticker.C:
+			flush(false)
+			if len(pending) == 0 {
+				// buffered batches are sent when there is nothing new
+				_ = c.drainBuffer()
+			}
+		case <-c.quit:
+			for {
+				select {
+				case e := <-c.entries:
+					pending = append(pending, e)
+					continue
+				default:
+				}
+				break
+			}
+			flush(true)
+			return
+		}
+	}
+}
+
+// send sends the batch after the buffered ones. The batch is buffered right
+// away if buffered batches can not be sent, otherwise after all retries
+// failed. Final batches are not retried, the client is closing.
+func (c *Client) send(b *Batch, final bool) {
+	if err := c.drainBuffer(); err != nil {
+		c.buffer(b, err)
+		return
+	}
+
+	retries := c.opts.MaxRetries
+	if final {
+		retries = 0
+	}
+
+	var err error
+	backoff := c.opts.MinBackoff
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		if retry, err = c.post(b); err == nil || !retry || attempt >= retries {
+			break
+		}
+
+		select {
+		case <-c.quit:
+			retries = 0
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > c.opts.MaxBackoff {
+			backoff = c.opts.MaxBackoff
+		}
+	}
+	if err != nil {
+		c.buffer(b, err)
+	}
+}
+
+// post sends the batch once, it returns whether a failed request should be
+// retried
+func (c *Client) post(b *Batch) (retry bool, err error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	return c.postData(data)
+}
+
+func (c *Client) postData(data []byte) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.opts.Username != "" || c.opts.Password != "" {
+		req.SetBasicAuth(c.opts.Username, c.opts.Password)
+	}
+	if c.opts.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.opts.BearerToken)
+	}
+	if c.opts.TenantID != "" {
+		req.Header.Set(tenantHeader, c.opts.TenantID)
+	}
+
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("error posting loki batch (%s): %s", resp.Status, strings.TrimSpace(string(body)))
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError, err
+}
+
+// buffer writes the batch that could not be sent to the buffer directory, or
+// drops it
+func (c *Client) buffer(b *Batch, sendErr error) {
+	if c.opts.BufferDir == "" {
+		c.errorf("%v, %d log lines dropped", sendErr, b.size())
+		return
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		c.errorf("buffer: %v, %d log lines dropped", err, b.size())
+		return
+	}
+
+	c.bufferMu.Lock()
+	defer c.bufferMu.Unlock()
+
+	if c.opts.BufferMaxBytes > 0 && c.bufferSize+int64(len(data)) > c.opts.BufferMaxBytes {
+		c.errorf("%v, buffer full, %d log lines dropped", sendErr, b.size())
+		return
+	}
+
+	name := filepath.Join(c.opts.BufferDir, strconv.FormatInt(time.Now().UnixNano(), 10)+bufferFileExt)
+	if err := os.WriteFile(name, data, 0o644); err != nil {
+		c.errorf("buffer: %v, %d log lines dropped", err, b.size())
+		return
+	}
+	c.bufferSize += int64(len(data))
+}
+
+// drainBuffer sends buffered batches in the order they were buffered and
+// removes the sent ones, it stops at the first one that fails
+func (c *Client) drainBuffer() error {
+	if c.opts.BufferDir == "" {
+		return nil
+	}
+
+	c.bufferMu.Lock()
+	defer c.bufferMu.Unlock()
+
+	if c.bufferSize == 0 {
+		return nil
+	}
+
+	files, err := c.bufferFiles()
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		retry, err := c.postData(data)
+		if err != nil && retry {
+			return err
+		}
+		if err != nil {
+			c.errorf("buffered batch %s rejected: %v", filepath.Base(f), err)
+		}
+		if err := os.Remove(f); err != nil {
+			return err
+		}
+		if c.bufferSize -= int64(len(data)); c.bufferSize < 0 {
+			c.bufferSize = 0
+		}
+	}
+
+	return nil
+}
+
+// bufferFiles returns buffered batch files, oldest first
+func (c *Client) bufferFiles() ([]string, error) {
+	entries, err := os.ReadDir(c.opts.BufferDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != bufferFileExt {
+			continue
+		}
+		files = append(files, filepath.Join(c.opts.BufferDir, e.Name()))
+	}
+	// names are timestamps of the same length until the year 2286
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func (c *Client) errorf(format string, args ...interface{}) {
+	fmt.Fprintf(c.opts.ErrorLog, "loki: "+format+"\n", args...)
+}
+
+// newBatch groups entries into streams by their labels
+func newBatch(entries []entry) *Batch {
+	b := NewBatch()
+	streams := make(map[string]int)
+	for _, e := range entries {
+		key := labelsKey(e.labels)
+		i, ok := streams[key]
+		if !ok {
+			s := NewStream()
+			for k, v := range e.labels {
+				s.AddLabel(k, v)
+			}
+			b.AddStream(s)
+			i = len(b.Streams) - 1
+			streams[key] = i
+		}
+		b.Streams[i].AddEntry(e.time, e.line)
+	}
+	return b
+}
+
+// labelsKey returns the key identifying the stream with the labels
+func labelsKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(strconv.Quote(k))
+		sb.WriteByte('=')
+		sb.WriteString(strconv.Quote(labels[k]))
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
+
+// size returns the number of entries of the batch
+func (b *Batch) size() (n int) {
+	for _, s := range b.Streams {
+		n += len(s.Entries)
+	}
+	return n
+}
+
+// NewTLSConfig returns the TLS configuration verifying the server with CAs
+// of the PEM encoded CA file, in addition to system CAs, and authenticating
+// with the client certificate, if the files are set
+func NewTLSConfig(caFile, certFile, keyFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	cfg := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in CA file %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
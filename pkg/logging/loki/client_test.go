@@ -0,0 +1,235 @@
+package loki_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/logging/loki"
+)
+
+// server is a Loki push API that fails while it is down
+type server struct {
+	*httptest.Server
+	down     atomic.Bool
+	requests atomic.Int32
+
+	mu    sync.Mutex
+	lines []string
+}
+
+func newServer(t *testing.T) *server {
+	t.Helper()
+
+	s := new(server)
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		if s.down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var b loki.Batch
+		if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		for _, stream := range b.Streams {
+			for _, e := range stream.Entries {
+				s.lines = append(s.lines, e[1])
+			}
+		}
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *server) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.lines...)
+}
+
+func TestClientRetry(t *testing.T) {
+	s := newServer(t)
+	s.down.Store(true)
+
+	c, err := loki.NewClient(s.URL, loki.ClientOptions{
+		BatchWait:  10 * time.Millisecond,
+		MaxRetries: 3,
+		MinBackoff: 10 * time.Millisecond,
+		ErrorLog:   io.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Push(map[string]string{"hostname": "test"}, time.Now(), "line")
+
+	// loki comes back during retries
+	for s.requests.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	s.down.Store(false)
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := s.received(); len(got) != 1 || got[0] != "line" {
+		t.Fatalf("got lines %v, want [line]", got)
+	}
+}
+
+func TestClientBuffer(t *testing.T) {
+	s := newServer(t)
+	s.down.Store(true)
+	dir := t.TempDir()
+
+	o := loki.ClientOptions{
+		BatchWait:  10 * time.Millisecond,
+		MaxRetries: -1,
+		BufferDir:  dir,
+		ErrorLog:   io.Discard,
+	}
+	c, err := loki.NewClient(s.URL, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := map[string]string{"hostname": "test"}
+	c.Push(labels, time.Now(), "first")
+	for s.requests.Load() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Push(labels, time.Now(), "second")
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("got %d buffered batches, want 2", len(files))
+	}
+	if got := s.received(); len(got) != 0 {
+		t.Fatalf("got lines %v while loki is down", got)
+	}
+
+	// buffered batches are sent first once loki is back
+	s.down.Store(false)
+	c, err = loki.NewClient(s.URL, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Push(labels, time.Now(), "third")
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := s.received()
+	want := []string{"first", "second", "third"}
+	if len(got) != len(want) {
+		t.Fatalf("got lines %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got lines %v, want %v", got, want)
+		}
+	}
+	if files, err := os.ReadDir(dir); err != nil || len(files) != 0 {
+		t.Fatalf("got %d buffered batches after sending, error %v", len(files), err)
+	}
+}
+
+func TestClientBufferMaxBytes(t *testing.T) {
+	s := newServer(t)
+	s.down.Store(true)
+	dir := t.TempDir()
+
+	c, err := loki.NewClient(s.URL, loki.ClientOptions{
+		BatchWait:      10 * time.Millisecond,
+		MaxRetries:     -1,
+		BufferDir:      dir,
+		BufferMaxBytes: 1,
+		ErrorLog:       io.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Push(map[string]string{"hostname": "test"}, time.Now(), "dropped")
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if files, err := os.ReadDir(dir); err != nil || len(files) != 0 {
+		t.Fatalf("got %d buffered batches over the limit, error %v", len(files), err)
+	}
+}
+
+func TestClientAuthentication(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		headers http.Header
+	)
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = r.Header.Clone()
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer s.Close()
+
+	// server is verified with its certificate as the CA
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tlsConfig, err := loki.NewTLSConfig(caFile, "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := loki.NewClient(s.URL, loki.ClientOptions{
+		TLSConfig:  tlsConfig,
+		Username:   "123456",
+		Password:   "secret",
+		TenantID:   "bee",
+		MaxRetries: -1,
+		ErrorLog:   io.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Push(map[string]string{"hostname": "test"}, time.Now(), "line")
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if headers == nil {
+		t.Fatal("no request")
+	}
+	if got, want := headers.Get("Authorization"), "Basic "+base64.StdEncoding.EncodeToString([]byte("123456:secret")); got != want {
+		t.Errorf("got authorization %q, want %q", got, want)
+	}
+	if got := headers.Get("X-Scope-OrgID"); got != "bee" {
+		t.Errorf("got tenant %q, want bee", got)
+	}
+}
@@ -0,0 +1,168 @@
+// Package rotate provides a log file that is rotated by size and age.
+package rotate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the format of the time suffix of rotated files, it
+// sorts in the order of rotations
+const backupTimeFormat = "20060102T150405.000000000"
+
+// Options holds optional parameters of the File
+type Options struct {
+	MaxBytes   int64         // size of the file after which it is rotated, 0 for no limit
+	MaxAge     time.Duration // age of the file after which it is rotated, 0 for no limit
+	MaxBackups int           // number of rotated files kept, 0 to keep all
+}
+
+// File is a log file that is rotated once it grows over the maximum size or
+// gets older than the maximum age. Rotated files are renamed with the time
+// of the rotation as suffix, like beekeeper.log.20231016T150405.000000000.
+type File struct {
+	path string
+	opts Options
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// Open opens the file for appending, creating it and its directory if they
+// do not exist
+func Open(path string, o Options) (*File, error) {
+	f := &File{
+		path: path,
+		opts: o,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write writes to the file, rotating it first if the write would grow it
+// over the maximum size or it is older than the maximum age
+func (f *File) Write(p []byte) (n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if f.size > 0 && ((f.opts.MaxBytes > 0 && f.size+int64(len(p)) > f.opts.MaxBytes) ||
+		(f.opts.MaxAge > 0 && time.Since(f.opened) > f.opts.MaxAge)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the file
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// open opens the file, the age of an existing file counts from its
+// modification time
+func (f *File) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = fi.Size()
+	f.opened = time.Now()
+	if f.size > 0 {
+		f.opened = fi.ModTime()
+	}
+	return nil
+}
+
+// rotate renames the file, opens a new one and removes the oldest backups
+func (f *File) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+	f.file = nil
+
+	if err := os.Rename(f.path, f.path+"."+time.Now().UTC().Format(backupTimeFormat)); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	return f.removeBackups()
+}
+
+// removeBackups removes rotated files over the maximum number of backups
+func (f *File) removeBackups() error {
+	if f.opts.MaxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := Backups(f.path)
+	if err != nil {
+		return err
+	}
+	for len(backups) > f.opts.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return fmt.Errorf("remove log file backup: %w", err)
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// Backups returns rotated files of the log file, oldest first
+func Backups(path string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("list log file backups: %w", err)
+	}
+
+	prefix := filepath.Base(path) + "."
+	var backups []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, strings.TrimPrefix(e.Name(), prefix)); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(filepath.Dir(path), e.Name()))
+	}
+	sort.Strings(backups)
+
+	return backups, nil
+}
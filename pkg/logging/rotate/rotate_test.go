@@ -0,0 +1,78 @@
+package rotate_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/logging/rotate"
+)
+
+func TestFileMaxBytes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "beekeeper.log")
+
+	f, err := rotate.Open(path, rotate.Options{MaxBytes: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := rotate.Backups(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("got %d backups, want 2", len(backups))
+	}
+
+	// the oldest backup with the first line is removed
+	var contents []string
+	for _, p := range append(backups, path) {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents = append(contents, string(b))
+	}
+	if got, want := strings.Join(contents, ""), "second\nthird\nfourth\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestFileMaxAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beekeeper.log")
+
+	f, err := rotate.Open(path, rotate.Options{MaxAge: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write([]byte("old\n")); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := f.Write([]byte("new\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := rotate.Backups(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("got %d backups, want 1", len(backups))
+	}
+	if b, err := os.ReadFile(path); err != nil || string(b) != "new\n" {
+		t.Fatalf("got %q, error %v, want new line", b, err)
+	}
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"fmt"
+
+	"github.com/ethersphere/beekeeper/pkg/logging/elasticsearch"
+	"github.com/ethersphere/beekeeper/pkg/logging/rotate"
+	"github.com/sirupsen/logrus"
+)
+
+// elasticsearchHook indexes log lines in Elasticsearch
+type elasticsearchHook struct {
+	client *elasticsearch.Client
+}
+
+func newElasticsearchHook(url string, o elasticsearch.ClientOptions) (*elasticsearchHook, error) {
+	client, err := elasticsearch.NewClient(url, o)
+	if err != nil {
+		return nil, err
+	}
+	return &elasticsearchHook{client: client}, nil
+}
+
+func (h *elasticsearchHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire queues the log line as a document with its fields, it is indexed in
+// the background
+func (h *elasticsearchHook) Fire(entry *logrus.Entry) error {
+	doc := make(map[string]interface{}, len(entry.Data)+3)
+	for k, v := range entry.Data {
+		if err, ok := v.(error); ok { // errors do not marshal to JSON
+			v = err.Error()
+		}
+		doc[k] = v
+	}
+	doc["@timestamp"] = entry.Time
+	doc["level"] = entry.Level.String()
+	doc["message"] = entry.Message
+
+	h.client.Index(entry.Time, doc)
+	return nil
+}
+
+func (h *elasticsearchHook) Close() error {
+	return h.client.Close()
+}
+
+// fileHook writes formatted log lines to a rotated file
+type fileHook struct {
+	file      *rotate.File
+	formatter logrus.Formatter
+}
+
+func newFileHook(path string, o rotate.Options, formatter logrus.Formatter) (*fileHook, error) {
+	file, err := rotate.Open(path, o)
+	if err != nil {
+		return nil, err
+	}
+	return &fileHook{file: file, formatter: formatter}, nil
+}
+
+func (h *fileHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *fileHook) Fire(entry *logrus.Entry) error {
+	msg, err := h.formatter.Format(entry)
+	if err != nil {
+		return fmt.Errorf("log file format failed: %w", err)
+	}
+	_, err = h.file.Write(msg)
+	return err
+}
+
+func (h *fileHook) Close() error {
+	return h.file.Close()
+}
@@ -1,7 +1,9 @@
 package metrics
 
 import (
+	"errors"
 	"reflect"
+	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/push"
@@ -33,3 +35,32 @@ func RegisterCollectors(p *push.Pusher, c ...prometheus.Collector) {
 		p.Collector(cc)
 	}
 }
+
+// RegisterAll registers collectors with the registerer. Collectors that are
+// already registered are left out, other collectors that can not be
+// registered are returned in the error.
+func RegisterAll(r prometheus.Registerer, c ...prometheus.Collector) error {
+	var errs []error
+	for _, cc := range c {
+		if err := r.Register(cc); err != nil {
+			var are prometheus.AlreadyRegisteredError
+			if errors.As(err, &are) {
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MaxIterationLabels bounds the cardinality of iteration labels, iterations
+// from it on share a single label value
+const MaxIterationLabels = 10
+
+// IterationLabel returns the label value of the iteration
+func IterationLabel(i int) string {
+	if i >= MaxIterationLabels {
+		return strconv.Itoa(MaxIterationLabels) + "+"
+	}
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,321 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// otlpMetricsPath is the path of the OTLP/HTTP metrics endpoint
+const otlpMetricsPath = "/v1/metrics"
+
+// aggregation temporality of cumulative Prometheus metrics
+const otlpCumulative = 2
+
+// posInf is the upper bound of the last Prometheus histogram bucket
+var posInf = math.Inf(1)
+
+// OTLPOptions represents OTLP exporter options
+type OTLPOptions struct {
+	Endpoint           string            // base URL of the OTLP/HTTP receiver
+	Headers            map[string]string // like authentication headers of vendors
+	ResourceAttributes map[string]string // service.name defaults to beekeeper
+	HTTPClient         *http.Client
+}
+
+// OTLPExporter exports gathered metrics to an OpenTelemetry collector or a
+// vendor over OTLP/HTTP with JSON encoding
+type OTLPExporter struct {
+	url      string
+	headers  map[string]string
+	resource []otlpAttribute
+	gatherer prometheus.Gatherer
+	client   *http.Client
+	start    time.Time
+}
+
+// NewOTLPExporter returns a new OTLP exporter of metrics gathered by the
+// gatherer
+func NewOTLPExporter(g prometheus.Gatherer, o OTLPOptions) *OTLPExporter {
+	attrs := map[string]string{"service.name": Namespace}
+	for k, v := range o.ResourceAttributes {
+		attrs[k] = v
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	url := strings.TrimSuffix(o.Endpoint, "/")
+	if !strings.HasSuffix(url, otlpMetricsPath) {
+		url += otlpMetricsPath
+	}
+
+	return &OTLPExporter{
+		url:      url,
+		headers:  o.Headers,
+		resource: otlpAttributes(attrs),
+		gatherer: g,
+		client:   o.HTTPClient,
+		start:    time.Now(),
+	}
+}
+
+// Export gathers metrics and sends them to the receiver
+func (e *OTLPExporter) Export(ctx context.Context) error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("gather: %w", err)
+	}
+
+	body, err := json.Marshal(e.request(families, time.Now()))
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("export: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
+
+// The types below follow the JSON encoding of the OTLP protobuf messages,
+// where 64 bit integers are encoded as strings.
+
+type otlpRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpMetric struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Gauge       *otlpGauge     `json:"gauge,omitempty"`
+	Sum         *otlpSum       `json:"sum,omitempty"`
+	Histogram   *otlpHistogram `json:"histogram,omitempty"`
+	Summary     *otlpSummary   `json:"summary,omitempty"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+	IsMonotonic            bool                  `json:"isMonotonic"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	AsDouble          float64         `json:"asDouble"`
+}
+
+type otlpHistogram struct {
+	DataPoints             []otlpHistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                      `json:"aggregationTemporality"`
+}
+
+type otlpHistogramDataPoint struct {
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	Count             string          `json:"count"`
+	Sum               float64         `json:"sum"`
+	BucketCounts      []string        `json:"bucketCounts"`
+	ExplicitBounds    []float64       `json:"explicitBounds"`
+}
+
+type otlpSummary struct {
+	DataPoints []otlpSummaryDataPoint `json:"dataPoints"`
+}
+
+type otlpSummaryDataPoint struct {
+	Attributes        []otlpAttribute     `json:"attributes,omitempty"`
+	StartTimeUnixNano string              `json:"startTimeUnixNano"`
+	TimeUnixNano      string              `json:"timeUnixNano"`
+	Count             string              `json:"count"`
+	Sum               float64             `json:"sum"`
+	QuantileValues    []otlpQuantileValue `json:"quantileValues"`
+}
+
+type otlpQuantileValue struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+// request converts gathered metric families to the OTLP export request
+func (e *OTLPExporter) request(families []*dto.MetricFamily, now time.Time) otlpRequest {
+	start, ts := nanos(e.start), nanos(now)
+
+	metrics := make([]otlpMetric, 0, len(families))
+	for _, f := range families {
+		m := otlpMetric{Name: f.GetName(), Description: f.GetHelp()}
+
+		switch f.GetType() {
+		case dto.MetricType_COUNTER:
+			m.Sum = &otlpSum{AggregationTemporality: otlpCumulative, IsMonotonic: true}
+			for _, pm := range f.GetMetric() {
+				m.Sum.DataPoints = append(m.Sum.DataPoints, otlpNumberDataPoint{
+					Attributes:        labelAttributes(pm.GetLabel()),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      ts,
+					AsDouble:          pm.GetCounter().GetValue(),
+				})
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			m.Gauge = &otlpGauge{}
+			for _, pm := range f.GetMetric() {
+				v := pm.GetGauge().GetValue()
+				if f.GetType() == dto.MetricType_UNTYPED {
+					v = pm.GetUntyped().GetValue()
+				}
+				m.Gauge.DataPoints = append(m.Gauge.DataPoints, otlpNumberDataPoint{
+					Attributes:        labelAttributes(pm.GetLabel()),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      ts,
+					AsDouble:          v,
+				})
+			}
+		case dto.MetricType_HISTOGRAM:
+			m.Histogram = &otlpHistogram{AggregationTemporality: otlpCumulative}
+			for _, pm := range f.GetMetric() {
+				h := pm.GetHistogram()
+				dp := otlpHistogramDataPoint{
+					Attributes:        labelAttributes(pm.GetLabel()),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      ts,
+					Count:             strconv.FormatUint(h.GetSampleCount(), 10),
+					Sum:               h.GetSampleSum(),
+				}
+				// Prometheus buckets are cumulative, OTLP buckets are not
+				// and have an implicit last bucket up to infinity
+				var prev uint64
+				for _, b := range h.GetBucket() {
+					if b.GetUpperBound() == posInf {
+						continue
+					}
+					dp.ExplicitBounds = append(dp.ExplicitBounds, b.GetUpperBound())
+					dp.BucketCounts = append(dp.BucketCounts, strconv.FormatUint(b.GetCumulativeCount()-prev, 10))
+					prev = b.GetCumulativeCount()
+				}
+				dp.BucketCounts = append(dp.BucketCounts, strconv.FormatUint(h.GetSampleCount()-prev, 10))
+				m.Histogram.DataPoints = append(m.Histogram.DataPoints, dp)
+			}
+		case dto.MetricType_SUMMARY:
+			m.Summary = &otlpSummary{}
+			for _, pm := range f.GetMetric() {
+				s := pm.GetSummary()
+				dp := otlpSummaryDataPoint{
+					Attributes:        labelAttributes(pm.GetLabel()),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      ts,
+					Count:             strconv.FormatUint(s.GetSampleCount(), 10),
+					Sum:               s.GetSampleSum(),
+				}
+				for _, q := range s.GetQuantile() {
+					dp.QuantileValues = append(dp.QuantileValues, otlpQuantileValue{Quantile: q.GetQuantile(), Value: q.GetValue()})
+				}
+				m.Summary.DataPoints = append(m.Summary.DataPoints, dp)
+			}
+		default:
+			continue
+		}
+
+		metrics = append(metrics, m)
+	}
+
+	return otlpRequest{
+		ResourceMetrics: []otlpResourceMetrics{{
+			Resource: otlpResource{Attributes: e.resource},
+			ScopeMetrics: []otlpScopeMetrics{{
+				Scope:   otlpScope{Name: Namespace},
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
+
+// labelAttributes returns metric labels as OTLP attributes
+func labelAttributes(labels []*dto.LabelPair) []otlpAttribute {
+	attrs := make([]otlpAttribute, 0, len(labels))
+	for _, l := range labels {
+		attrs = append(attrs, otlpAttribute{Key: l.GetName(), Value: otlpValue{StringValue: l.GetValue()}})
+	}
+	return attrs
+}
+
+// otlpAttributes returns OTLP attributes sorted by their keys
+func otlpAttributes(m map[string]string) []otlpAttribute {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]otlpAttribute, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, otlpAttribute{Key: k, Value: otlpValue{StringValue: m[k]}})
+	}
+	return attrs
+}
+
+// nanos returns the time as a string of nanoseconds since the Unix epoch
+func nanos(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,175 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	otlpTestStart = time.Unix(1700000000, 0)
+	otlpTestNow   = time.Unix(1700000060, 500)
+)
+
+func TestOTLPRequest(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		collector func() prometheus.Collector
+		want      string
+	}{
+		{
+			name: "counter",
+			collector: func() prometheus.Collector {
+				c := prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: Namespace, Name: "uploads_total", Help: "Uploads."}, []string{"node"})
+				c.WithLabelValues("bee-0").Add(3)
+				c.WithLabelValues("bee-1").Inc()
+				return c
+			},
+			want: `{"name":"beekeeper_uploads_total","description":"Uploads.","sum":{"dataPoints":[
+				{"attributes":[{"key":"node","value":{"stringValue":"bee-0"}}],"startTimeUnixNano":"1700000000000000000","timeUnixNano":"1700000060000000500","asDouble":3},
+				{"attributes":[{"key":"node","value":{"stringValue":"bee-1"}}],"startTimeUnixNano":"1700000000000000000","timeUnixNano":"1700000060000000500","asDouble":1}
+			],"aggregationTemporality":2,"isMonotonic":true}}`,
+		},
+		{
+			name: "gauge",
+			collector: func() prometheus.Collector {
+				g := prometheus.NewGauge(prometheus.GaugeOpts{Namespace: Namespace, Name: "peers", Help: "Peers."})
+				g.Set(-2.5)
+				return g
+			},
+			want: `{"name":"beekeeper_peers","description":"Peers.","gauge":{"dataPoints":[
+				{"startTimeUnixNano":"1700000000000000000","timeUnixNano":"1700000060000000500","asDouble":-2.5}
+			]}}`,
+		},
+		{
+			name: "untyped",
+			collector: func() prometheus.Collector {
+				return prometheus.NewUntypedFunc(prometheus.UntypedOpts{Namespace: Namespace, Name: "depth", Help: "Depth."}, func() float64 { return 8 })
+			},
+			want: `{"name":"beekeeper_depth","description":"Depth.","gauge":{"dataPoints":[
+				{"startTimeUnixNano":"1700000000000000000","timeUnixNano":"1700000060000000500","asDouble":8}
+			]}}`,
+		},
+		{
+			name: "histogram",
+			collector: func() prometheus.Collector {
+				h := prometheus.NewHistogramVec(prometheus.HistogramOpts{Namespace: Namespace, Name: "upload_duration_seconds", Help: "Upload duration.", Buckets: []float64{0.5, 1, 5}}, []string{"node"})
+				for _, v := range []float64{0.1, 0.2, 0.7, 3, 10, 20} {
+					h.WithLabelValues("bee-0").Observe(v)
+				}
+				return h
+			},
+			want: `{"name":"beekeeper_upload_duration_seconds","description":"Upload duration.","histogram":{"dataPoints":[
+				{"attributes":[{"key":"node","value":{"stringValue":"bee-0"}}],"startTimeUnixNano":"1700000000000000000","timeUnixNano":"1700000060000000500",
+				"count":"6","sum":34,"bucketCounts":["2","1","1","2"],"explicitBounds":[0.5,1,5]}
+			],"aggregationTemporality":2}}`,
+		},
+		{
+			name: "summary",
+			collector: func() prometheus.Collector {
+				s := prometheus.NewSummary(prometheus.SummaryOpts{Namespace: Namespace, Name: "latency_seconds", Help: "Latency.", Objectives: map[float64]float64{0.5: 0}})
+				for _, v := range []float64{1, 2, 3} {
+					s.Observe(v)
+				}
+				return s
+			},
+			want: `{"name":"beekeeper_latency_seconds","description":"Latency.","summary":{"dataPoints":[
+				{"startTimeUnixNano":"1700000000000000000","timeUnixNano":"1700000060000000500","count":"3","sum":6,"quantileValues":[{"quantile":0.5,"value":2}]}
+			]}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			registry.MustRegister(tc.collector())
+
+			e := NewOTLPExporter(registry, OTLPOptions{Endpoint: "http://collector:4318"})
+			e.start = otlpTestStart
+
+			families, err := registry.Gather()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(e.request(families, otlpTestNow))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := `{"resourceMetrics":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"beekeeper"}}]},
+				"scopeMetrics":[{"scope":{"name":"beekeeper"},"metrics":[` + tc.want + `]}]}]}`
+			if g, w := string(got), compactJSON(t, want); g != w {
+				t.Errorf("got request\n%s\nwant\n%s", g, w)
+			}
+		})
+	}
+}
+
+// compactJSON returns the JSON without insignificant whitespace
+func compactJSON(t *testing.T, s string) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestOTLPExport(t *testing.T) {
+	var (
+		gotPath, gotContentType, gotAuth string
+		gotBody                          []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotContentType, gotAuth = r.URL.Path, r.Header.Get("Content-Type"), r.Header.Get("Authorization")
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	g := prometheus.NewGauge(prometheus.GaugeOpts{Namespace: Namespace, Name: "peers"})
+	g.Set(1)
+	registry.MustRegister(g)
+
+	e := NewOTLPExporter(registry, OTLPOptions{
+		Endpoint:           server.URL + "/",
+		Headers:            map[string]string{"Authorization": "Bearer token"},
+		ResourceAttributes: map[string]string{"service.name": "ci", "deployment.environment": "staging"},
+	})
+	if err := e.Export(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if gotPath != otlpMetricsPath {
+		t.Errorf("got path %s, want %s", gotPath, otlpMetricsPath)
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("got content type %s, want application/json", gotContentType)
+	}
+	if gotAuth != "Bearer token" {
+		t.Errorf("got authorization %q, want %q", gotAuth, "Bearer token")
+	}
+	wantResource := `"resource":{"attributes":[{"key":"deployment.environment","value":{"stringValue":"staging"}},{"key":"service.name","value":{"stringValue":"ci"}}]}`
+	if !strings.Contains(string(gotBody), wantResource) {
+		t.Errorf("got body %s, want resource %s", gotBody, wantResource)
+	}
+}
+
+func TestOTLPExportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid metrics", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	e := NewOTLPExporter(prometheus.NewRegistry(), OTLPOptions{Endpoint: server.URL + otlpMetricsPath})
+	err := e.Export(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "invalid metrics") {
+		t.Errorf("got error %v, want the response of the receiver", err)
+	}
+}
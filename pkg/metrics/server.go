@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server exposes gathered metrics on /metrics for scraping and liveness on
+// /healthz
+type Server struct {
+	server *http.Server
+}
+
+// NewServer returns a new metrics server listening on the address
+func NewServer(addr string, g prometheus.Gatherer) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(g, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok\n"))
+	})
+
+	return &Server{
+		server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Start starts listening on the address, serving in the background until
+// the server is closed
+func (s *Server) Start() (net.Addr, error) {
+	l, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		_ = s.server.Serve(l)
+	}()
+
+	return l.Addr(), nil
+}
+
+// Close shuts down the server, waiting for scrapes in progress
+func (s *Server) Close(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
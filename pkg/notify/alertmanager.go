@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"time"
+)
+
+// alertmanagerAlertsPath is the path of the Alertmanager API alerts
+// endpoint relative to its base URL
+const alertmanagerAlertsPath = "/api/v2/alerts"
+
+// alert names sent to Alertmanager
+const (
+	alertCheckFailed = "BeekeeperCheckFailed"
+	alertRegression  = "BeekeeperRegression"
+)
+
+// alert is an alert of the Alertmanager API
+type alert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// alertmanager fires an alert for every failed check and every regression,
+// so they are routed by the existing Alertmanager configuration. Alerts are
+// resolved by Alertmanager when they are not sent again within its resolve
+// timeout.
+type alertmanager struct {
+	poster
+}
+
+func (n *alertmanager) Notify(ctx context.Context, s Summary) error {
+	alerts := make([]alert, 0, len(s.Failures)+len(s.Regressions))
+
+	for _, f := range s.Failures {
+		a := n.alert(s, alertCheckFailed, f.Check, f.Severity)
+		a.Annotations["summary"] = "Beekeeper check " + f.Check + " failed on cluster " + s.Cluster
+		a.Annotations["description"] = f.Error
+		if f.FailedStep != "" {
+			a.Annotations["failed_step"] = f.FailedStep
+		}
+		alerts = append(alerts, a)
+	}
+
+	for _, r := range s.Regressions {
+		a := n.alert(s, alertRegression, r.Check, r.Severity)
+		a.Labels["measurement"] = r.Measurement
+		a.Annotations["summary"] = "Beekeeper check " + r.Check + " measurement " + r.Measurement + " regressed on cluster " + s.Cluster
+		a.Annotations["description"] = r.Message
+		alerts = append(alerts, a)
+	}
+
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	return n.post(ctx, alerts)
+}
+
+// alert returns the alert about the check of the run
+func (n *alertmanager) alert(s Summary, name, check, severity string) alert {
+	a := alert{
+		Labels: map[string]string{
+			"alertname": name,
+			"cluster":   s.Cluster,
+			"check":     check,
+			"severity":  severity,
+		},
+		Annotations:  make(map[string]string),
+		StartsAt:     s.End,
+		GeneratorURL: s.ReportURL,
+	}
+	if len(s.BeeVersions) > 0 {
+		a.Labels["bee_version"] = s.BeeVersions[0]
+		if len(s.BeeVersions) > 1 {
+			a.Labels["bee_version"] = "mixed"
+		}
+	}
+	return a
+}
@@ -0,0 +1,188 @@
+// Package notify posts summaries of check runs to chats and webhooks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+)
+
+// discordMaxContent is the maximum length of a Discord message
+const discordMaxContent = 2000
+
+// Notifier sends summaries of runs
+type Notifier interface {
+	Notify(ctx context.Context, s Summary) error
+}
+
+// Summary summarizes a check run
+type Summary struct {
+	Cluster     string           `json:"cluster"`
+	BeeVersions []string         `json:"beeVersions"`
+	Status      beekeeper.Status `json:"status"`
+	Start       time.Time        `json:"start"`
+	End         time.Time        `json:"end"`
+	Passed      int              `json:"passed"`
+	Failed      int              `json:"failed"`
+	Skipped     int              `json:"skipped"`
+	Failures    []Failure        `json:"failures"`
+	Regressions []Regression     `json:"regressions,omitempty"`
+	ReportURL   string           `json:"reportURL,omitempty"`
+}
+
+// Failure describes a failed check
+type Failure struct {
+	Check      string               `json:"check"`
+	Severity   string               `json:"severity"`
+	Error      string               `json:"error"`
+	FailedStep string               `json:"failedStep,omitempty"`
+	Artifacts  []beekeeper.Artifact `json:"artifacts,omitempty"`
+}
+
+// Regression describes a measurement that regressed against the baseline run
+type Regression struct {
+	Check       string `json:"check"`
+	Severity    string `json:"severity"`
+	Measurement string `json:"measurement"`
+	Message     string `json:"message"`
+}
+
+// Text returns the summary as a plain text message
+func (s Summary) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Beekeeper run on %s %s: %d passed, %d failed, %d skipped in %s", s.Cluster, s.Status, s.Passed, s.Failed, s.Skipped, s.End.Sub(s.Start).Round(time.Second))
+	if len(s.BeeVersions) > 0 {
+		fmt.Fprintf(&b, "\nBee version: %s", strings.Join(s.BeeVersions, ", "))
+	}
+	for _, f := range s.Failures {
+		fmt.Fprintf(&b, "\n• %s [%s] failed", f.Check, f.Severity)
+		if f.FailedStep != "" {
+			fmt.Fprintf(&b, " in step %s", f.FailedStep)
+		}
+		fmt.Fprintf(&b, ": %s", f.Error)
+		for _, a := range f.Artifacts {
+			fmt.Fprintf(&b, "\n    %s: %s", a.Name, a.Path)
+		}
+	}
+	for _, r := range s.Regressions {
+		fmt.Fprintf(&b, "\n• %s [%s] regressed: %s", r.Check, r.Severity, r.Message)
+	}
+	if s.ReportURL != "" {
+		fmt.Fprintf(&b, "\nReport: %s", s.ReportURL)
+	}
+
+	return b.String()
+}
+
+// New returns the notifier of the type, one of slack, discord, webhook or
+// alertmanager, that posts to the URL
+func New(notifierType, url string, client *http.Client) (Notifier, error) {
+	if url == "" {
+		return nil, fmt.Errorf("%s notification url not set", notifierType)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	p := poster{url: url, client: client}
+	switch notifierType {
+	case "slack":
+		return &slack{poster: p}, nil
+	case "discord":
+		return &discord{poster: p}, nil
+	case "webhook":
+		return &webhook{poster: p}, nil
+	case "alertmanager":
+		p.url = strings.TrimSuffix(url, "/") + alertmanagerAlertsPath
+		return &alertmanager{poster: p}, nil
+	default:
+		return nil, fmt.Errorf("unknown notification type %s", notifierType)
+	}
+}
+
+// slack posts summaries to a Slack incoming webhook
+type slack struct {
+	poster
+}
+
+func (n *slack) Notify(ctx context.Context, s Summary) error {
+	return n.post(ctx, struct {
+		Text string `json:"text"`
+	}{Text: s.Text()})
+}
+
+// discord posts summaries to a Discord webhook
+type discord struct {
+	poster
+}
+
+func (n *discord) Notify(ctx context.Context, s Summary) error {
+	text := s.Text()
+	if len(text) > discordMaxContent {
+		// the text is cut on a rune boundary to stay valid UTF-8
+		i := discordMaxContent - 3
+		for i > 0 && !utf8.RuneStart(text[i]) {
+			i--
+		}
+		text = text[:i] + "..."
+	}
+	return n.post(ctx, struct {
+		Content string `json:"content"`
+	}{Content: text})
+}
+
+// webhook posts summaries as JSON to a generic webhook
+type webhook struct {
+	poster
+}
+
+func (n *webhook) Notify(ctx context.Context, s Summary) error {
+	return n.post(ctx, s)
+}
+
+// poster posts JSON to the URL
+type poster struct {
+	url    string
+	client *http.Client
+}
+
+func (p poster) post(ctx context.Context, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		// the url carries the secret of the webhook
+		return fmt.Errorf("post notification: %w", redact(err, p.url))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("post notification: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
+
+// redact removes the url from the error message
+func redact(err error, url string) error {
+	return fmt.Errorf("%s", strings.ReplaceAll(err.Error(), url, "<redacted>"))
+}
@@ -0,0 +1,167 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/notify"
+)
+
+func TestNotify(t *testing.T) {
+	summary := notify.Summary{
+		Cluster: "default",
+		Status:  beekeeper.StatusFailed,
+		Failed:  1,
+		Failures: []notify.Failure{
+			{Check: "pushsync", Severity: "critical", Error: "chunk not replicated", FailedStep: "replicate"},
+		},
+	}
+
+	for _, tc := range []struct {
+		notifierType string
+		field        string
+	}{
+		{notifierType: "slack", field: "text"},
+		{notifierType: "discord", field: "content"},
+		{notifierType: "webhook", field: "cluster"},
+	} {
+		t.Run(tc.notifierType, func(t *testing.T) {
+			var got map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Error(err)
+				}
+			}))
+			defer srv.Close()
+
+			n, err := notify.New(tc.notifierType, srv.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := n.Notify(context.Background(), summary); err != nil {
+				t.Fatal(err)
+			}
+
+			v, ok := got[tc.field].(string)
+			if !ok {
+				t.Fatalf("field %s not set in %v", tc.field, got)
+			}
+			if tc.notifierType != "webhook" && !strings.Contains(v, "pushsync [critical] failed in step replicate: chunk not replicated") {
+				t.Errorf("unexpected message %q", v)
+			}
+		})
+	}
+}
+
+func TestDiscordTruncate(t *testing.T) {
+	// the bullet of the second failure is cut in the middle at the maximum
+	// length of the message
+	summary := notify.Summary{
+		Cluster:  "default",
+		Status:   beekeeper.StatusFailed,
+		Failed:   2,
+		Failures: []notify.Failure{{Check: "pushsync", Severity: "critical"}},
+	}
+	first := len(summary.Text())
+	summary.Failures[0].Error = strings.Repeat("x", 1995-first)
+	summary.Failures = append(summary.Failures, notify.Failure{Check: "retrieval", Severity: "critical", Error: strings.Repeat("x", 100)})
+	if !strings.HasPrefix(summary.Text()[1996:], "•") {
+		t.Fatalf("bullet not at the cut point of %q", summary.Text())
+	}
+
+	var got struct {
+		Content string `json:"content"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	n, err := notify.New("discord", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Notify(context.Background(), summary); err != nil {
+		t.Fatal(err)
+	}
+
+	if !utf8.ValidString(got.Content) {
+		t.Errorf("message %q is not valid UTF-8", got.Content)
+	}
+	if want := summary.Text()[:1996] + "..."; got.Content != want {
+		t.Errorf("got message of %d bytes ending with %q, want %d bytes ending with %q", len(got.Content), got.Content[len(got.Content)-10:], len(want), want[len(want)-10:])
+	}
+}
+
+func TestNotifyError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid token", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	n, err := notify.New("slack", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = n.Notify(context.Background(), notify.Summary{})
+	if err == nil || !strings.Contains(err.Error(), "invalid token") {
+		t.Errorf("expected error with response message, got %v", err)
+	}
+}
+
+func TestAlertmanager(t *testing.T) {
+	var (
+		path   string
+		alerts []struct {
+			Labels      map[string]string `json:"labels"`
+			Annotations map[string]string `json:"annotations"`
+		}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&alerts); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	n, err := notify.New("alertmanager", srv.URL+"/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Notify(context.Background(), notify.Summary{
+		Cluster:     "default",
+		BeeVersions: []string{"1.13.0"},
+		Failures: []notify.Failure{
+			{Check: "pushsync", Severity: "critical", Error: "chunk not replicated"},
+		},
+		Regressions: []notify.Regression{
+			{Check: "smoke", Severity: "warning", Measurement: "download", Message: "50% worse"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "/api/v2/alerts" {
+		t.Errorf("got path %s, want /api/v2/alerts", path)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("got %d alerts, want 2", len(alerts))
+	}
+	want := map[string]string{"alertname": "BeekeeperCheckFailed", "cluster": "default", "check": "pushsync", "severity": "critical", "bee_version": "1.13.0"}
+	if !reflect.DeepEqual(alerts[0].Labels, want) {
+		t.Errorf("got labels %v, want %v", alerts[0].Labels, want)
+	}
+	if alerts[1].Labels["alertname"] != "BeekeeperRegression" || alerts[1].Labels["measurement"] != "download" {
+		t.Errorf("unexpected regression alert labels %v", alerts[1].Labels)
+	}
+}
@@ -3,9 +3,12 @@ package orchestration
 import (
 	"context"
 	"math/rand"
+	"time"
 
 	"github.com/ethersphere/bee/pkg/swarm"
 	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/docker"
+	"github.com/ethersphere/beekeeper/pkg/helm"
 	"github.com/ethersphere/beekeeper/pkg/k8s"
 	"github.com/ethersphere/beekeeper/pkg/swap"
 )
@@ -32,6 +35,18 @@ type Cluster interface {
 	FlattenOverlays(ctx context.Context, exclude ...string) (map[string]swarm.Address, error)
 	Peers(ctx context.Context, exclude ...string) (peers ClusterPeers, err error)
 	RandomNode(ctx context.Context, r *rand.Rand) (node Node, err error)
+	Restarts(ctx context.Context) (restarts ClusterRestarts, err error)
+	FlattenRestarts(ctx context.Context) (restarts NodeGroupRestarts, err error)
+	WatchFailures(ctx context.Context) (failures <-chan NodeFailure, err error)
+	Partition(ctx context.Context, groups [][]string) (err error)
+	Heal(ctx context.Context) (err error)
+	InjectNetworkFault(ctx context.Context, f NetworkFault) (err error)
+	RemoveNetworkFaults(ctx context.Context) (err error)
+	InjectIOFault(ctx context.Context, f IOFault) (err error)
+	RemoveIOFaults(ctx context.Context) (err error)
+	InjectStress(ctx context.Context, s Stress) (err error)
+	RemoveStress(ctx context.Context) (err error)
+	ScaleNodeGroup(ctx context.Context, name string, replicas int) (err error)
 	Settlements(ctx context.Context) (settlements ClusterSettlements, err error)
 	FlattenSettlements(ctx context.Context) (settlements NodeGroupSettlements, err error)
 	Size() (size int)
@@ -49,11 +64,31 @@ type ClusterOptions struct {
 	DebugAPIInsecureTLS bool
 	DebugAPIScheme      string
 	K8SClient           *k8s.Client
+	HelmClient          *helm.Client
+	DockerClient        *docker.Client
+	DockerHostIP        string
+	Orchestrator        string
 	SwapClient          swap.Client
+	Funding             FundingOptions // funding of nodes added by scaling node groups
 	Labels              map[string]string
 	Namespace           string
 	DisableNamespace    bool
 	AdminPassword       string
+	RateLimit           float64
+	RateLimitBurst      int
+	NodeRateLimit       float64
+	NodeRateLimitBurst  int
+	ReadRetries         int
+	WriteRetries        int
+	RetryMinBackoff     time.Duration
+	RetryMaxBackoff     time.Duration
+	RetryBudget         float64
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+	DisableKeepAlives   bool
+	DisableHTTP2        bool
+	AccessMode          string // how APIs of kubernetes nodes are reached, ingress by default or port-forward
 }
 
 // ClusterAddresses represents addresses of all nodes in the cluster
@@ -71,6 +106,9 @@ type ClusterOverlays map[string]NodeGroupOverlays
 // ClusterPeers represents peers of all nodes in the cluster
 type ClusterPeers map[string]NodeGroupPeers
 
+// ClusterRestarts represents restart state of all nodes in the cluster
+type ClusterRestarts map[string]NodeGroupRestarts
+
 // ClusterSettlements represents settlements of all nodes in the cluster
 type ClusterSettlements map[string]NodeGroupSettlements
 
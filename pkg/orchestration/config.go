@@ -0,0 +1,132 @@
+package orchestration
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigTemplate is the template of the Bee configuration file, executed
+// with the Config of the node
+const ConfigTemplate = `api-addr: {{.APIAddr}}
+allow-private-cidrs: {{ .AllowPrivateCIDRs }}
+block-time: {{ .BlockTime }}
+bootnode: {{.Bootnodes}}
+bootnode-mode: {{.BootnodeMode}}
+cache-capacity: {{.CacheCapacity}}
+clef-signer-enable: {{.ClefSignerEnable}}
+clef-signer-endpoint: {{.ClefSignerEndpoint}}
+cors-allowed-origins: {{.CORSAllowedOrigins}}
+data-dir: {{.DataDir}}
+db-open-files-limit: {{.DbOpenFilesLimit}}
+db-block-cache-capacity: {{.DbBlockCacheCapacity}}
+db-write-buffer-size: {{.DbWriteBufferSize}}
+db-disable-seeks-compaction: {{.DbDisableSeeksCompaction}}
+debug-api-addr: {{.DebugAPIAddr}}
+debug-api-enable: {{.DebugAPIEnable}}
+full-node: {{.FullNode}}
+mainnet: {{.Mainnet}}
+nat-addr: {{.NATAddr}}
+network-id: {{.NetworkID}}
+p2p-addr: {{.P2PAddr}}
+p2p-ws-enable: {{.P2PWSEnable}}
+password: {{.Password}}
+payment-early-percent: {{.PaymentEarly}}
+payment-threshold: {{.PaymentThreshold}}
+payment-tolerance-percent: {{.PaymentTolerance}}
+postage-stamp-address: {{ .PostageStampAddress }}
+postage-stamp-start-block: {{ .PostageContractStartBlock }}
+price-oracle-address: {{ .PriceOracleAddress }}
+redistribution-address: {{ .RedistributionAddress }}
+staking-address: {{ .StakingAddress }}
+storage-incentives-enable: {{ .StorageIncentivesEnable }}
+resolver-options: {{.ResolverOptions}}
+restricted: {{.Restricted}}
+token-encryption-key: {{.TokenEncryptionKey}}
+admin-password: {{.AdminPassword}}
+chequebook-enable: {{.ChequebookEnable}}
+swap-enable: {{.SwapEnable}}
+swap-endpoint: {{.SwapEndpoint}}
+swap-deployment-gas-price: {{.SwapDeploymentGasPrice}}
+swap-factory-address: {{.SwapFactoryAddress}}
+swap-legacy-factory-addresses: {{.SwapLegacyFactoryAddresses}}
+swap-initial-deposit: {{.SwapInitialDeposit}}
+tracing-enable: {{.TracingEnabled}}
+tracing-endpoint: {{.TracingEndpoint}}
+tracing-service-name: {{.TracingServiceName}}
+verbosity: {{.Verbosity}}
+welcome-message: {{.WelcomeMessage}}
+withdrawal-addresses-whitelist: {{.WithdrawalAddresses}}
+warmup-time: {{.WarmupTime}}
+`
+
+// ConfigTemplateData is the input of the config template of a node
+type ConfigTemplateData struct {
+	Name          string   // name of the node
+	Namespace     string   // namespace of the cluster
+	Index         int      // index of the node in its node group, the number its name ends with
+	Bootnodes     []string // bootnode addresses
+	ChainEndpoint string   // blockchain endpoint
+	Config        Config   // Bee configuration of the node
+}
+
+// ApplyConfigTemplate executes the config template of the node and adds its
+// options to the generated Bee configuration. Options of the template replace
+// generated options with the same name, so options not modeled by Config can
+// be set without changing the orchestrators.
+func ApplyConfigTemplate(config []byte, o CreateOptions) ([]byte, error) {
+	if o.Config.Template == "" {
+		return config, nil
+	}
+
+	t, err := template.New(o.Name).Option("missingkey=error").Parse(o.Config.Template)
+	if err != nil {
+		return nil, fmt.Errorf("parse config template: %w", err)
+	}
+
+	var extra bytes.Buffer
+	if err := t.Execute(&extra, ConfigTemplateData{
+		Name:          o.Name,
+		Namespace:     o.Namespace,
+		Index:         nodeIndex(o.Name),
+		Bootnodes:     strings.Fields(o.Config.Bootnodes),
+		ChainEndpoint: o.Config.SwapEndpoint,
+		Config:        o.Config,
+	}); err != nil {
+		return nil, fmt.Errorf("execute config template: %w", err)
+	}
+
+	options := make(map[string]interface{})
+	if err := yaml.Unmarshal(extra.Bytes(), &options); err != nil {
+		return nil, fmt.Errorf("config template of node %s is not a YAML map: %w", o.Name, err)
+	}
+
+	var b bytes.Buffer
+	for _, line := range strings.SplitAfter(string(config), "\n") {
+		name, _, _ := strings.Cut(line, ":")
+		if _, ok := options[strings.TrimSpace(name)]; ok {
+			continue
+		}
+		b.WriteString(line)
+	}
+	if b.Len() > 0 && !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+		b.WriteString("\n")
+	}
+	b.Write(extra.Bytes())
+
+	return b.Bytes(), nil
+}
+
+// nodeIndex returns the number the node name ends with, like 2 of bee-2, or
+// 0 if it does not end with one
+func nodeIndex(name string) int {
+	i, err := strconv.Atoi(name[strings.LastIndex(name, "-")+1:])
+	if err != nil {
+		return 0
+	}
+	return i
+}
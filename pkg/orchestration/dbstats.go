@@ -0,0 +1,90 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// defaultDataDir is the data directory of nodes without one configured, the
+// one of the Bee image
+const defaultDataDir = "/home/bee/.bee"
+
+// NodeDBStats represents on-disk statistics of the node's data directory,
+// like sizes of its localstore databases
+type NodeDBStats struct {
+	Size  int64            // bytes of the data directory
+	Files int64            // files in the data directory
+	Dirs  map[string]int64 // bytes of directories two levels deep, like localstore/sharky, by path relative to the data directory
+}
+
+// DBStats returns on-disk statistics of the data directory of the node of the
+// node group, collected by du and find run in the node's container
+func DBStats(ctx context.Context, g NodeGroup, name string) (stats NodeDBStats, err error) {
+	n, err := g.Node(name)
+	if err != nil {
+		return NodeDBStats{}, err
+	}
+
+	dataDir := nodeDataDir(n)
+	out, err := g.NodeExec(ctx, name, DBStatsCommand(dataDir)...)
+	if err != nil {
+		return NodeDBStats{}, fmt.Errorf("db stats of node %s: %w", name, err)
+	}
+
+	if stats, err = ParseDBStats(dataDir, out); err != nil {
+		return NodeDBStats{}, fmt.Errorf("db stats of node %s: %w", name, err)
+	}
+
+	return stats, nil
+}
+
+// nodeDataDir returns data directory of the node
+func nodeDataDir(n Node) string {
+	if n.Config() != nil && n.Config().DataDir != "" {
+		return n.Config().DataDir
+	}
+	return defaultDataDir
+}
+
+// DBStatsCommand returns the command printing disk usage of the data
+// directory and its directories two levels deep, in kilobytes, and the number
+// of its files, parsed by ParseDBStats
+func DBStatsCommand(dataDir string) []string {
+	return []string{"sh", "-c", `du -k -d 2 "$1" && echo "files $(find "$1" -type f | wc -l)"`, "sh", dataDir}
+}
+
+// ParseDBStats parses output of the DBStatsCommand of the data directory
+func ParseDBStats(dataDir string, out []byte) (stats NodeDBStats, err error) {
+	stats.Dirs = make(map[string]int64)
+	dataDir = path.Clean(dataDir)
+
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		if fields[0] == "files" {
+			if stats.Files, err = strconv.ParseInt(fields[1], 10, 64); err != nil {
+				return NodeDBStats{}, fmt.Errorf("parsing files count %q: %w", line, err)
+			}
+			continue
+		}
+
+		kb, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return NodeDBStats{}, fmt.Errorf("parsing disk usage %q: %w", line, err)
+		}
+		dir := path.Clean(strings.Join(fields[1:], " "))
+		if dir == dataDir {
+			stats.Size = kb * 1024
+			continue
+		}
+		stats.Dirs[strings.TrimPrefix(dir, dataDir+"/")] = kb * 1024
+	}
+
+	return stats, nil
+}
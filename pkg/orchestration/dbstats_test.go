@@ -0,0 +1,98 @@
+package orchestration_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+func TestParseDBStats(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		dataDir string
+		out     string
+		want    orchestration.NodeDBStats
+		wantErr bool
+	}{
+		{
+			name:    "data directory",
+			dataDir: "/home/bee/.bee",
+			out: "8\t/home/bee/.bee/keys\n" +
+				"1024\t/home/bee/.bee/localstore/sharky\n" +
+				"16\t/home/bee/.bee/localstore/indexstore\n" +
+				"1040\t/home/bee/.bee/localstore\n" +
+				"1052\t/home/bee/.bee\n" +
+				"files 42\n",
+			want: orchestration.NodeDBStats{
+				Size:  1052 * 1024,
+				Files: 42,
+				Dirs: map[string]int64{
+					"keys":                  8 * 1024,
+					"localstore":            1040 * 1024,
+					"localstore/sharky":     1024 * 1024,
+					"localstore/indexstore": 16 * 1024,
+				},
+			},
+		},
+		{
+			name:    "trailing slash of data directory",
+			dataDir: "/data/",
+			out:     "4\t/data/statestore\n12\t/data\nfiles 3\n",
+			want: orchestration.NodeDBStats{
+				Size:  12 * 1024,
+				Files: 3,
+				Dirs:  map[string]int64{"statestore": 4 * 1024},
+			},
+		},
+		{
+			name:    "directory with spaces",
+			dataDir: "/data",
+			out:     "4 /data/lost found\n12 /data\nfiles 1\n",
+			want: orchestration.NodeDBStats{
+				Size:  12 * 1024,
+				Files: 1,
+				Dirs:  map[string]int64{"lost found": 4 * 1024},
+			},
+		},
+		{
+			name:    "empty lines",
+			dataDir: "/data",
+			out:     "\n0\t/data\n\nfiles 0\n\n",
+			want:    orchestration.NodeDBStats{Dirs: map[string]int64{}},
+		},
+		{
+			name:    "empty output",
+			dataDir: "/data",
+			want:    orchestration.NodeDBStats{Dirs: map[string]int64{}},
+		},
+		{
+			name:    "invalid disk usage",
+			dataDir: "/data",
+			out:     "du: cannot access '/data': No such file or directory\nfiles 0\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid files count",
+			dataDir: "/data",
+			out:     "12\t/data\nfiles many\n",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := orchestration.ParseDBStats(tc.dataDir, []byte(tc.out))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("got stats %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got stats %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
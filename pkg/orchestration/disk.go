@@ -0,0 +1,86 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// diskFillFile is the file filling the volume of the data directory of nodes,
+// in the data directory
+const diskFillFile = ".beekeeper-fill"
+
+// FillDisk fills the volume of the data directory of the node of the node
+// group to the percentage of its capacity with a file in the data directory,
+// replacing the file of a previous fill, and returns the size of the file in
+// bytes, 0 if the volume is already filled to the percentage
+func FillDisk(ctx context.Context, g NodeGroup, name string, percent int) (size int64, err error) {
+	if percent <= 0 || percent > 100 {
+		return 0, fmt.Errorf("fill disk of node %s: percentage %d is not in range 1-100", name, percent)
+	}
+
+	n, err := g.Node(name)
+	if err != nil {
+		return 0, err
+	}
+
+	out, err := g.NodeExec(ctx, name, FillDiskCommand(nodeDataDir(n), percent)...)
+	if err != nil {
+		return 0, fmt.Errorf("fill disk of node %s: %w", name, err)
+	}
+
+	kb, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("fill disk of node %s: parsing filled size %q: %w", name, out, err)
+	}
+
+	return kb * 1024, nil
+}
+
+// FreeDisk deletes the file filling the volume of the data directory of the
+// node of the node group
+func FreeDisk(ctx context.Context, g NodeGroup, name string) (err error) {
+	n, err := g.Node(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := g.NodeExec(ctx, name, "rm", "-f", path.Join(nodeDataDir(n), diskFillFile)); err != nil {
+		return fmt.Errorf("free disk of node %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// FillDiskCommand returns the command filling the volume of the data
+// directory to the percentage of its capacity, measured by df, and printing
+// the filled size in kilobytes, parsed by FillDisk
+func FillDiskCommand(dataDir string, percent int) []string {
+	return []string{"sh", "-c", `f="$1/` + diskFillFile + `"; rm -f "$f"; set -- $(df -Pk "$1" | tail -n 1) "$2"
+fill=$(( $2 * $7 / 100 - $3 ))
+if [ "$fill" -le 0 ]; then echo 0; exit 0; fi
+fallocate -l "${fill}k" "$f" 2>/dev/null || dd if=/dev/zero of="$f" bs=1024 count="$fill" 2>/dev/null
+echo "$fill"`, "sh", dataDir, strconv.Itoa(percent)}
+}
+
+const (
+	// IOFaultLatency delays IO operations of pods of nodes
+	IOFaultLatency = "latency"
+)
+
+// IOFault represents fault of IO of data volumes of pods of nodes of node
+// groups, injected declaratively by chaos-mesh until it is removed
+type IOFault struct {
+	Name       string        // name of the fault, unique among injected ones
+	Action     string        // latency, the default
+	NodeGroups []string      // node groups of nodes the fault is injected into, all by default
+	Mode       string        // pods selected, all, the default, one, fixed, fixed-percent or random-max-percent
+	Value      string        // number or percentage of pods of the fixed and percent modes
+	Latency    time.Duration // delay of IO operations
+	Percent    int           // percentage of delayed operations, 100 by default
+	Methods    []string      // file system operations delayed, like READ and WRITE, all by default
+	VolumePath string        // mount path of the data volume, /home/bee/.bee by default
+}
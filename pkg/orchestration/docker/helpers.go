@@ -0,0 +1,116 @@
+package docker
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Labels of docker objects, containers of a node group are listed by them
+const (
+	labelCluster   = "org.ethswarm.beekeeper.cluster"
+	labelNodeGroup = "org.ethswarm.beekeeper.node-group"
+	labelNode      = "org.ethswarm.beekeeper.node"
+)
+
+const defaultDataDir = "/home/bee/.bee"
+
+// containerName returns name of the node's container, prefixed with the
+// namespace, as container names are global on the docker host
+func containerName(namespace, name string) string {
+	return fmt.Sprintf("%s-%s", namespace, name)
+}
+
+// volumeName returns name of the volume with the node's data directory
+func volumeName(namespace, name string) string {
+	return fmt.Sprintf("%s-%s-data", namespace, name)
+}
+
+// freePort returns a port of the host IP that is free now, so published
+// ports of the node are known before the container starts and kept when it
+// restarts
+func freePort(hostIP string) (int, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort(hostIP, "0"))
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// pullPolicy returns the docker pull policy of the kubernetes image pull
+// policy
+func pullPolicy(policy string) string {
+	switch policy {
+	case "Always":
+		return "always"
+	case "Never":
+		return "never"
+	}
+	return "missing"
+}
+
+// restartPolicy returns the docker restart policy of the kubernetes restart
+// policy, stopped nodes are not restarted
+func restartPolicy(policy string) string {
+	switch policy {
+	case "OnFailure":
+		return "on-failure"
+	case "Never":
+		return "no"
+	}
+	return "unless-stopped"
+}
+
+// cpus returns the docker CPU limit of the kubernetes CPU quantity, like
+// 1.5 of 1500m
+func cpus(quantity string) string {
+	if m, ok := strings.CutSuffix(quantity, "m"); ok {
+		v, err := strconv.ParseFloat(m, 64)
+		if err != nil {
+			return ""
+		}
+		return strconv.FormatFloat(v/1000, 'f', -1, 64)
+	}
+	return quantity
+}
+
+// memory returns the docker memory limit of the kubernetes memory quantity,
+// like 2g of 2Gi
+func memory(quantity string) string {
+	for suffix, unit := range map[string]string{"Ki": "k", "Mi": "m", "Gi": "g", "K": "k", "M": "m", "G": "g"} {
+		if v, ok := strings.CutSuffix(quantity, suffix); ok {
+			return v + unit
+		}
+	}
+	return quantity
+}
+
+func mergeMaps(a, b map[string]string) map[string]string {
+	m := map[string]string{}
+	for k, v := range a {
+		m[k] = v
+	}
+	for k, v := range b {
+		m[k] = v
+	}
+
+	return m
+}
+
+func parsePort(port string) (int, error) {
+	i := strings.LastIndex(port, ":")
+	return strconv.Atoi(port[i+1:])
+}
+
+func contains(list []string, find string) bool {
+	for _, v := range list {
+		if v == find {
+			return true
+		}
+	}
+
+	return false
+}
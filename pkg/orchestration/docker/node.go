@@ -0,0 +1,389 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/docker"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+const (
+	// beeUID is the user of the Bee image, files copied to containers are
+	// owned by it
+	beeUID      = 999
+	stopTimeout = 30 * time.Second
+)
+
+// compile check whether client implements interface
+var _ orchestration.Node = (*Node)(nil)
+
+// Node represents Bee node running in a docker container
+type Node struct {
+	name         string
+	clefKey      string
+	clefPassword string
+	client       *bee.Client
+	config       *orchestration.Config
+	docker       *docker.Client
+	image        string
+	libP2PKey    string
+	swarmKey     string
+	logger       logging.Logger
+
+	// set by the node group, containers are recreated with them
+	apiPort   int // host port of the API
+	debugPort int // host port of the debug API
+	hostIP    string
+	create    orchestration.CreateOptions
+}
+
+// NewNode returns Bee node
+func NewNode(name string, opts orchestration.NodeOptions, logger logging.Logger) (n *Node) {
+	n = &Node{
+		name:   name,
+		logger: logger,
+	}
+
+	if opts.Client != nil {
+		n.client = opts.Client
+	}
+	if opts.Config != nil {
+		n.config = opts.Config
+	}
+	if len(opts.ClefKey) > 0 {
+		n.clefKey = opts.ClefKey
+	}
+	if len(opts.ClefPassword) > 0 {
+		n.clefPassword = opts.ClefPassword
+	}
+	if len(opts.LibP2PKey) > 0 {
+		n.libP2PKey = opts.LibP2PKey
+	}
+	if len(opts.SwarmKey) > 0 {
+		n.swarmKey = opts.SwarmKey.ToString()
+	}
+	if opts.Docker != nil {
+		n.docker = opts.Docker
+	}
+	if len(opts.Image) > 0 {
+		n.image = opts.Image
+	}
+
+	return
+}
+
+// Name returns node's name
+func (n Node) Name() string {
+	return n.name
+}
+
+// Client returns node's client
+func (n Node) Client() *bee.Client {
+	return n.client
+}
+
+// Config returns node's config
+func (n Node) Config() *orchestration.Config {
+	return n.config
+}
+
+// Image returns image of the node's container
+func (n Node) Image() string {
+	return n.image
+}
+
+// ClefKey returns node's clefKey
+func (n Node) ClefKey() string {
+	return n.clefKey
+}
+
+// ClefPassword returns node's clefPassword
+func (n Node) ClefPassword() string {
+	return n.clefPassword
+}
+
+// LibP2PKey returns node's libP2PKey
+func (n Node) LibP2PKey() string {
+	return n.libP2PKey
+}
+
+// SwarmKey returns node's swarmKey
+func (n Node) SwarmKey() string {
+	return n.swarmKey
+}
+
+// SetSwarmKey sets node's Swarm key
+func (n Node) SetSwarmKey(key string) orchestration.Node {
+	n.swarmKey = key
+	return n
+}
+
+// SetClefKey sets node's Clef key
+func (n Node) SetClefKey(key string) orchestration.Node {
+	n.clefKey = key
+	return n
+}
+
+// SetClefPassword sets node's Clef password
+func (n Node) SetClefPassword(password string) orchestration.Node {
+	n.clefPassword = password
+	return n
+}
+
+// Create creates the node's container in the network of the namespace, with
+// the data directory in a named volume, and copies the configuration and
+// keys to it. The container is not started.
+func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err error) {
+	if o.Config.ClefSignerEnable {
+		return errors.New("clef signer is not supported by the docker orchestrator")
+	}
+	if o.Netem.Enabled() {
+		return errors.New("netem is not supported by the docker orchestrator")
+	}
+
+	if err := n.docker.CreateNetwork(ctx, o.Namespace, map[string]string{labelCluster: o.Namespace}); err != nil {
+		return err
+	}
+
+	volume := volumeName(o.Namespace, o.Name)
+	if err := n.docker.CreateVolume(ctx, volume, o.Labels); err != nil {
+		return err
+	}
+
+	if err := n.createContainer(ctx, o); err != nil {
+		return err
+	}
+
+	var keys []docker.File
+	if len(o.LibP2PKey) > 0 {
+		keys = append(keys, keyFile("keys/libp2p_v2.key", o.LibP2PKey))
+	}
+	if len(o.SwarmKey) > 0 {
+		keys = append(keys, keyFile("keys/swarm.key", o.SwarmKey))
+	}
+	if len(keys) > 0 {
+		if err := n.docker.CopyToContainer(ctx, containerName(o.Namespace, o.Name), dataDir(o.Config), keys); err != nil {
+			return err
+		}
+	}
+
+	n.logger.Infof("node %s is created in namespace %s", o.Name, o.Namespace)
+	return
+}
+
+// createContainer creates the container with the configuration file
+func (n Node) createContainer(ctx context.Context, o orchestration.CreateOptions) (err error) {
+	var config bytes.Buffer
+	if err := template.Must(template.New("").Parse(orchestration.ConfigTemplate)).Execute(&config, o.Config); err != nil {
+		return err
+	}
+	beeConfig, err := orchestration.ApplyConfigTemplate(config.Bytes(), o)
+	if err != nil {
+		return err
+	}
+
+	portAPI, err := parsePort(o.Config.APIAddr)
+	if err != nil {
+		return fmt.Errorf("parsing API port from config: %s", err)
+	}
+	portDebug, err := parsePort(o.Config.DebugAPIAddr)
+	if err != nil {
+		return fmt.Errorf("parsing Debug port from config: %s", err)
+	}
+
+	name := containerName(o.Namespace, o.Name)
+	if err := n.docker.CreateContainer(ctx, docker.ContainerOptions{
+		Name:       name,
+		Image:      o.Image,
+		Pull:       pullPolicy(o.ImagePullPolicy),
+		Command:    []string{"bee", "start", "--config=.bee.yaml"},
+		WorkingDir: "/home/bee",
+		Network:    o.Namespace,
+		// the headless service name of kubernetes clusters is an alias, so
+		// bootnodes of existing cluster configurations are resolved
+		Aliases: []string{o.Name, fmt.Sprintf("%s-headless.%s.svc.cluster.local", o.Name, o.Namespace)},
+		Labels:  o.Labels,
+		Ports: []docker.Port{
+			{ContainerPort: portAPI, HostIP: n.hostIP, HostPort: n.apiPort},
+			{ContainerPort: portDebug, HostIP: n.hostIP, HostPort: n.debugPort},
+		},
+		Volumes:       map[string]string{dataDir(o.Config): volumeName(o.Namespace, o.Name)},
+		RestartPolicy: restartPolicy(o.RestartPolicy),
+		CPUs:          cpus(o.ResourcesLimitCPU),
+		Memory:        memory(o.ResourcesLimitMemory),
+	}); err != nil {
+		return err
+	}
+
+	if err := n.docker.CopyToContainer(ctx, name, "/home/bee", []docker.File{{
+		Name: ".bee.yaml",
+		Data: beeConfig,
+		Mode: 0o644,
+		UID:  beeUID,
+		GID:  beeUID,
+	}}); err != nil {
+		return err
+	}
+
+	return
+}
+
+// Delete removes the node's container, its volume is removed by the node
+// group, unless persistence is enabled
+func (n Node) Delete(ctx context.Context, namespace string) (err error) {
+	name := containerName(namespace, n.name)
+	if err := n.docker.RemoveContainer(ctx, name); err != nil && !errors.Is(err, docker.ErrNotFound) {
+		return err
+	}
+	n.logger.Infof("container %s is removed", name)
+
+	n.logger.Infof("node %s is deleted in namespace %s", n.name, namespace)
+	return
+}
+
+// Kill kills the node's container without a grace period and starts it
+// again, like the statefulset recreates the pod of kubernetes clusters
+func (n Node) Kill(ctx context.Context, namespace string) (err error) {
+	name := containerName(namespace, n.name)
+	if err := n.docker.KillContainer(ctx, name); err != nil {
+		return err
+	}
+
+	if err := n.docker.StartContainer(ctx, name); err != nil {
+		return err
+	}
+
+	n.logger.Infof("node %s is killed in namespace %s", n.name, namespace)
+	return
+}
+
+// Logs returns logs of the node's container since the time, limited to the
+// last tail lines
+// Exec runs the command in the node's container and returns its standard output
+func (n Node) Exec(ctx context.Context, namespace string, command ...string) (stdout []byte, err error) {
+	return n.docker.ExecContainer(ctx, containerName(namespace, n.name), command...)
+}
+
+func (n Node) Logs(ctx context.Context, namespace string, since time.Time, tail int64) (logs []byte, err error) {
+	return n.docker.ContainerLogs(ctx, containerName(namespace, n.name), since, tail)
+}
+
+// Ready returns whether the node's container is running and the node reports
+// it is ready, like the readiness probe of kubernetes clusters
+func (n Node) Ready(ctx context.Context, namespace string) (ready bool, err error) {
+	ct, err := n.docker.InspectContainer(ctx, containerName(namespace, n.name))
+	if errors.Is(err, docker.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !ct.Running || n.client == nil {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// the node does not respond while it starts
+	ready, err = n.client.Readiness(ctx)
+	if err != nil {
+		return false, nil
+	}
+
+	return ready, nil
+}
+
+// Restarts returns restart state of the node's container
+func (n Node) Restarts(ctx context.Context, namespace string) (restarts orchestration.NodeRestarts, err error) {
+	ct, err := n.docker.InspectContainer(ctx, containerName(namespace, n.name))
+	if err != nil {
+		return orchestration.NodeRestarts{}, err
+	}
+
+	restarts.Count = int32(ct.RestartCount)
+	if ct.OOMKilled {
+		restarts.Reason = "OOMKilled"
+	} else if ct.ExitCode != 0 {
+		restarts.Reason = "Error"
+	}
+	restarts.CrashLoop = ct.Restarting
+
+	return
+}
+
+// SetImage recreates the node's container with the image, the data volume is
+// kept, so the node keeps its keys and data
+func (n Node) SetImage(ctx context.Context, namespace, image string) (err error) {
+	if n.create.Name == "" {
+		return fmt.Errorf("node %s is not added to a node group", n.name)
+	}
+
+	name := containerName(namespace, n.name)
+	if err := n.docker.StopContainer(ctx, name, stopTimeout); err != nil && !errors.Is(err, docker.ErrNotFound) {
+		return err
+	}
+	if err := n.docker.RemoveContainer(ctx, name); err != nil && !errors.Is(err, docker.ErrNotFound) {
+		return err
+	}
+
+	o := n.create
+	o.Image = image
+	if err := n.createContainer(ctx, o); err != nil {
+		return err
+	}
+
+	if err := n.docker.StartContainer(ctx, name); err != nil {
+		return err
+	}
+
+	n.logger.Infof("node %s image is set to %s in namespace %s", n.name, image, namespace)
+	return
+}
+
+// Start starts the node's container
+func (n Node) Start(ctx context.Context, namespace string) (err error) {
+	if err := n.docker.StartContainer(ctx, containerName(namespace, n.name)); err != nil {
+		return err
+	}
+
+	n.logger.Infof("node %s is started in namespace %s", n.name, namespace)
+	return
+}
+
+// Stop stops the node's container, it is killed if it does not stop in time
+func (n Node) Stop(ctx context.Context, namespace string) (err error) {
+	if err := n.docker.StopContainer(ctx, containerName(namespace, n.name), stopTimeout); err != nil {
+		return err
+	}
+
+	n.logger.Infof("node %s is stopped in namespace %s", n.name, namespace)
+	return
+}
+
+// dataDir returns the data directory of the configuration, the volume of the
+// node is mounted on it
+func dataDir(c orchestration.Config) string {
+	if c.DataDir == "" {
+		return defaultDataDir
+	}
+	return c.DataDir
+}
+
+// keyFile returns the key file readable by the Bee user only
+func keyFile(name, key string) docker.File {
+	return docker.File{
+		Name: name,
+		Data: []byte(key),
+		Mode: 0o600,
+		UID:  beeUID,
+		GID:  beeUID,
+	}
+}
@@ -0,0 +1,855 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/docker"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"github.com/ethersphere/beekeeper/pkg/orchestration/utils"
+	"golang.org/x/sync/errgroup"
+)
+
+const nodeRetryTimeout = 5 * time.Second
+
+// compile check whether client implements interface
+var _ orchestration.NodeGroup = (*NodeGroup)(nil)
+
+// NodeGroup represents group of Bee nodes running in docker containers
+type NodeGroup struct {
+	name  string
+	nodes map[string]orchestration.Node
+	opts  orchestration.NodeGroupOptions
+
+	// set when added to the cluster
+	cluster *Cluster
+	docker  *docker.Client
+
+	logger logging.Logger
+
+	lock sync.RWMutex
+}
+
+// NewNodeGroup returns new node group
+func NewNodeGroup(name string, o orchestration.NodeGroupOptions, logger logging.Logger) *NodeGroup {
+	return &NodeGroup{
+		name:   name,
+		nodes:  make(map[string]orchestration.Node),
+		opts:   o,
+		logger: logger,
+	}
+}
+
+// AddNode adds new node to the node group. Nodes that have a container are
+// reached on its published ports, ports of new nodes are picked now, so
+// their clients are set before their containers are created.
+func (g *NodeGroup) AddNode(ctx context.Context, name string, o orchestration.NodeOptions) (err error) {
+	var config *orchestration.Config
+	if o.Config != nil {
+		config = o.Config
+	} else {
+		config = g.opts.BeeConfig
+	}
+
+	apiPort, debugPort, err := g.hostPorts(ctx, name, config)
+	if err != nil {
+		return fmt.Errorf("node %s ports: %w", name, err)
+	}
+
+	aURL, err := url.Parse("http://" + net.JoinHostPort(g.cluster.hostIP, strconv.Itoa(apiPort)))
+	if err != nil {
+		return fmt.Errorf("API URL %s: %w", name, err)
+	}
+	dURL, err := url.Parse("http://" + net.JoinHostPort(g.cluster.hostIP, strconv.Itoa(debugPort)))
+	if err != nil {
+		return fmt.Errorf("debug API URL %s: %w", name, err)
+	}
+
+	client := bee.NewClient(bee.ClientOptions{
+		APIURL:             aURL,
+		DebugAPIURL:        dURL,
+		Retry:              g.cluster.retry,
+		Restricted:         config.Restricted,
+		RateLimiter:        g.cluster.rateLimiter,
+		NodeRateLimit:      g.cluster.nodeRateLimit,
+		NodeRateLimitBurst: g.cluster.nodeRateLimitBurst,
+		Transport:          g.cluster.transport,
+	}, g.logger)
+
+	// nodes may pin their own image, like for version-matrix testing
+	image := g.opts.Image
+	if o.Image != "" {
+		image = o.Image
+	}
+
+	n := NewNode(name, orchestration.NodeOptions{
+		ClefKey:      o.ClefKey,
+		ClefPassword: o.ClefPassword,
+		Client:       client,
+		Config:       config,
+		Docker:       g.docker,
+		Image:        image,
+		LibP2PKey:    o.LibP2PKey,
+		SwarmKey:     o.SwarmKey,
+	}, g.logger)
+	n.apiPort = apiPort
+	n.debugPort = debugPort
+	n.hostIP = g.cluster.hostIP
+	n.create = g.createOptions(name, image, *config)
+
+	g.addNode(*n)
+
+	return
+}
+
+// hostPorts returns host ports of the node's API and debug API, the ones of
+// its container, if it exists
+func (g *NodeGroup) hostPorts(ctx context.Context, name string, config *orchestration.Config) (apiPort, debugPort int, err error) {
+	ct, err := g.docker.InspectContainer(ctx, containerName(g.cluster.namespace, name))
+	if errors.Is(err, docker.ErrNotFound) {
+		// a port could be taken before the container starts, it fails to
+		// start then and is created again by the next setup
+		if apiPort, err = freePort(g.cluster.hostIP); err != nil {
+			return 0, 0, err
+		}
+		if debugPort, err = freePort(g.cluster.hostIP); err != nil {
+			return 0, 0, err
+		}
+		return apiPort, debugPort, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	portAPI, err := parsePort(config.APIAddr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing API port from config: %s", err)
+	}
+	portDebug, err := parsePort(config.DebugAPIAddr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing Debug port from config: %s", err)
+	}
+
+	apiPort, debugPort = ct.HostPort(portAPI), ct.HostPort(portDebug)
+	if apiPort == 0 || debugPort == 0 {
+		return 0, 0, fmt.Errorf("API ports of container %s are not published", ct.Name)
+	}
+
+	return apiPort, debugPort, nil
+}
+
+// createOptions returns options of the node's container
+func (g *NodeGroup) createOptions(name, image string, config orchestration.Config) orchestration.CreateOptions {
+	return orchestration.CreateOptions{
+		Config:    config,
+		Name:      name,
+		Namespace: g.cluster.namespace,
+		Labels: mergeMaps(g.opts.Labels, map[string]string{
+			labelCluster:   g.cluster.namespace,
+			labelNodeGroup: g.name,
+			labelNode:      name,
+		}),
+		Image:                image,
+		ImagePullPolicy:      g.opts.ImagePullPolicy,
+		Netem:                g.opts.Netem,
+		PersistenceEnabled:   g.opts.PersistenceEnabled,
+		RestartPolicy:        g.opts.RestartPolicy,
+		ResourcesLimitCPU:    g.opts.ResourcesLimitCPU,
+		ResourcesLimitMemory: g.opts.ResourcesLimitMemory,
+	}
+}
+
+// Addresses returns NodeGroupAddresses
+func (g *NodeGroup) Addresses(ctx context.Context) (addrs orchestration.NodeGroupAddresses, err error) {
+	return nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bee.Addresses, error) {
+		return c.Addresses(ctx)
+	})
+}
+
+// Accounting returns NodeGroupAccounting
+func (g *NodeGroup) Accounting(ctx context.Context) (accounting orchestration.NodeGroupAccounting, err error) {
+	overlays, err := g.Overlays(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("overlays: %w", err)
+	}
+
+	accounts, err := nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bee.Accounting, error) {
+		return c.Accounting(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accounting = make(orchestration.NodeGroupAccounting)
+	for name, a := range accounts {
+		tmp := make(map[string]bee.Account)
+		for _, acc := range a.Accounting {
+			tmp[acc.Peer] = acc
+		}
+		accounting[overlays[name].String()] = tmp
+	}
+
+	return
+}
+
+// Balances returns NodeGroupBalances
+func (g *NodeGroup) Balances(ctx context.Context) (balances orchestration.NodeGroupBalances, err error) {
+	overlays, err := g.Overlays(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("overlays: %w", err)
+	}
+
+	bals, err := nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bee.Balances, error) {
+		return c.Balances(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	balances = make(orchestration.NodeGroupBalances)
+	for name, b := range bals {
+		tmp := make(map[string]int64)
+		for _, bal := range b.Balances {
+			tmp[bal.Peer] = bal.Balance
+		}
+		balances[overlays[name].String()] = tmp
+	}
+
+	return
+}
+
+// CreateNode creates the node's container
+func (g *NodeGroup) CreateNode(ctx context.Context, name string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	o := g.createOptions(name, n.Image(), *n.Config())
+	o.ClefKey = n.ClefKey()
+	o.ClefPassword = n.ClefPassword()
+	o.LibP2PKey = n.LibP2PKey()
+	o.SwarmKey = n.SwarmKey()
+
+	return n.Create(ctx, o)
+}
+
+// DeleteNode removes the node's container and removes it from the node
+// group, the node's data volume is removed as well, unless persistence is
+// enabled
+func (g *NodeGroup) DeleteNode(ctx context.Context, name string) (err error) {
+	n := NewNode(name, orchestration.NodeOptions{Docker: g.docker}, g.logger)
+	if err := n.Delete(ctx, g.cluster.namespace); err != nil {
+		return err
+	}
+
+	if !g.opts.PersistenceEnabled || g.opts.PersistenceRetentionPolicy == "Delete" {
+		if err := g.DeleteNodeVolume(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	g.deleteNode(name)
+
+	return
+}
+
+// DeleteNodeVolume removes the volume with the node's data
+func (g *NodeGroup) DeleteNodeVolume(ctx context.Context, name string) (err error) {
+	volume := volumeName(g.cluster.namespace, name)
+	if err := g.docker.RemoveVolume(ctx, volume); err != nil {
+		return err
+	}
+	g.logger.Infof("volume %s is removed", volume)
+
+	return
+}
+
+// Fund adds funds to the node
+func (g *NodeGroup) Fund(ctx context.Context, name string, o orchestration.NodeOptions, f orchestration.FundingOptions) (err error) {
+	if f.Eth <= 0 && f.Bzz <= 0 && f.GBzz <= 0 {
+		return
+	}
+
+	var a bee.Addresses
+	a.Ethereum, _ = o.SwarmKey.GetEthAddress()
+	if a.Ethereum == "" {
+		c, err := g.NodeClient(name)
+		if err != nil {
+			return err
+		}
+		if err := retry(5, func() (err error) {
+			a, err = c.Addresses(ctx)
+			return err
+		}); err != nil {
+			return fmt.Errorf("get %s address: %w", name, err)
+		}
+	}
+	g.logger.Infof("fund eth address: %s", a.Ethereum)
+
+	for _, t := range []struct {
+		token  string
+		amount float64
+		send   func(ctx context.Context, address string, amount float64) (string, error)
+	}{
+		{"ETH", f.Eth, g.cluster.swap.SendETH},
+		{"BZZ", f.Bzz, g.cluster.swap.SendBZZ},
+		{"gBZZ", f.GBzz, g.cluster.swap.SendGBZZ},
+	} {
+		if t.amount <= 0 {
+			continue
+		}
+
+		var tx string
+		if err := retry(5, func() (err error) {
+			tx, err = t.send(ctx, a.Ethereum, t.amount)
+			return err
+		}); err != nil {
+			return fmt.Errorf("send %s: %w", t.token, err)
+		}
+		g.logger.Infof("%s funded with %.2f %s, transaction: %s", name, t.amount, t.token, tx)
+	}
+
+	return
+}
+
+// GroupReplicationFactor returns the total number of nodes in the node group that contain given chunk
+func (g *NodeGroup) GroupReplicationFactor(ctx context.Context, a swarm.Address) (grf int, err error) {
+	found, err := nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bool, error) {
+		return c.HasChunk(ctx, a)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, ok := range found {
+		if ok {
+			grf++
+		}
+	}
+
+	return
+}
+
+// KillNode kills node's container, simulating an unclean shutdown
+func (g *NodeGroup) KillNode(ctx context.Context, name string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	return n.Kill(ctx, g.cluster.namespace)
+}
+
+// NodeExec runs the command in the container of the node and returns its
+// standard output
+func (g *NodeGroup) NodeExec(ctx context.Context, name string, command ...string) (stdout []byte, err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.Exec(ctx, g.cluster.namespace, command...)
+}
+
+// NodeLogs returns logs of the node since the time, limited to the last tail
+// lines
+func (g *NodeGroup) NodeLogs(ctx context.Context, name string, since time.Time, tail int64) (logs []byte, err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.Logs(ctx, g.cluster.namespace, since, tail)
+}
+
+// Name returns name of the node group
+func (g *NodeGroup) Name() string {
+	return g.name
+}
+
+// Nodes returns map of nodes in the node group
+func (g *NodeGroup) Nodes() map[string]orchestration.Node {
+	return g.getNodes()
+}
+
+// NodesClients returns map of node's clients in the node group excluding stopped nodes
+func (g *NodeGroup) NodesClients(ctx context.Context) (map[string]*bee.Client, error) {
+	stopped, err := g.StoppedNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("stopped nodes: %w", err)
+	}
+
+	clients := g.getClients()
+	for _, n := range stopped {
+		delete(clients, n)
+	}
+
+	return clients, nil
+}
+
+// NodesClientsAll returns map of node's clients in the node group
+func (g *NodeGroup) NodesClientsAll(ctx context.Context) map[string]*bee.Client {
+	return g.getClients()
+}
+
+// NodesSorted returns list of nodes sorted by names from the node group.
+func (g *NodeGroup) NodesSorted() []string {
+	nodes := g.getNodes()
+
+	l := make([]string, 0, len(nodes))
+	for k := range nodes {
+		l = append(l, k)
+	}
+	sort.Strings(l)
+
+	return l
+}
+
+// Node returns node
+func (g *NodeGroup) Node(name string) (orchestration.Node, error) {
+	return g.getNode(name)
+}
+
+// NodeClient returns node's client
+func (g *NodeGroup) NodeClient(name string) (*bee.Client, error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+	return n.Client(), nil
+}
+
+// Overlays returns NodeGroupOverlays
+func (g *NodeGroup) Overlays(ctx context.Context) (overlays orchestration.NodeGroupOverlays, err error) {
+	return nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (swarm.Address, error) {
+		return c.Overlay(ctx)
+	})
+}
+
+// Peers returns NodeGroupPeers
+func (g *NodeGroup) Peers(ctx context.Context) (peers orchestration.NodeGroupPeers, err error) {
+	return nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) ([]swarm.Address, error) {
+		return c.Peers(ctx)
+	})
+}
+
+// NodeReady returns node's readiness
+func (g *NodeGroup) NodeReady(ctx context.Context, name string) (ok bool, err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return false, err
+	}
+
+	return n.Ready(ctx, g.cluster.namespace)
+}
+
+// PregenerateSwarmKey for a node if needed, the overlay Ethereum address of
+// the key is attested, as nodes without swap do not deploy a chequebook
+func (g *NodeGroup) PregenerateSwarmKey(ctx context.Context, name string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	if n.Config().SwapEnable && n.Config().ChequebookEnable {
+		return
+	}
+
+	swarmKey := n.SwarmKey()
+	if swarmKey == "" {
+		swarmKey, err = utils.CreateSwarmKey(n.Config().Password)
+		if err != nil {
+			return fmt.Errorf("create Swarm key for node %s: %w", name, err)
+		}
+
+		if err := g.setNode(name, n.SetSwarmKey(swarmKey)); err != nil {
+			return fmt.Errorf("setting node %s: %w", name, err)
+		}
+	}
+
+	var key utils.EncryptedKey
+	if err := json.Unmarshal([]byte(swarmKey), &key); err != nil {
+		return err
+	}
+
+	txHash, err := g.cluster.swap.AttestOverlayEthAddress(ctx, key.Address)
+	if err != nil {
+		return fmt.Errorf("attest overlay Ethereum address for node %s: %w", name, err)
+	}
+	g.logger.Infof("overlay Ethereum address %s for node %s attested successfully: transaction: %s", key.Address, name, txHash)
+
+	return
+}
+
+// Restarts returns NodeGroupRestarts
+func (g *NodeGroup) Restarts(ctx context.Context) (restarts orchestration.NodeGroupRestarts, err error) {
+	restarts = make(orchestration.NodeGroupRestarts)
+
+	for name, n := range g.getNodes() {
+		r, err := n.Restarts(ctx, g.cluster.namespace)
+		if err != nil {
+			return nil, fmt.Errorf("node %s: %w", name, err)
+		}
+
+		restarts[name] = r
+	}
+
+	return
+}
+
+// RunningNodes returns list of nodes with running containers
+func (g *NodeGroup) RunningNodes(ctx context.Context) (running []string, err error) {
+	names, err := g.containerNodes(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range g.NodesSorted() {
+		if contains(names, v) {
+			running = append(running, v)
+		}
+	}
+
+	return
+}
+
+// SetNodeImage sets image of the node and waits until it is ready again
+func (g *NodeGroup) SetNodeImage(ctx context.Context, name, image string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	if err := n.SetImage(ctx, g.cluster.namespace, image); err != nil {
+		return err
+	}
+
+	// the container is recreated with the image from now on
+	if dn, ok := n.(Node); ok {
+		dn.image = image
+		dn.create.Image = image
+		if err := g.setNode(name, dn); err != nil {
+			return err
+		}
+	}
+
+	return g.waitReady(ctx, name, true)
+}
+
+// SetupNode creates new node in the node group, starts its container and funds it
+func (g *NodeGroup) SetupNode(ctx context.Context, name string, o orchestration.NodeOptions, f orchestration.FundingOptions) (err error) {
+	g.logger.Infof("starting setup node: %s", name)
+
+	if err := g.AddNode(ctx, name, o); err != nil {
+		return fmt.Errorf("add node %s: %w", name, err)
+	}
+
+	if err := g.PregenerateSwarmKey(ctx, name); err != nil {
+		return fmt.Errorf("pregenerate Swarm key for node %s: %w", name, err)
+	}
+
+	if err := g.CreateNode(ctx, name); err != nil {
+		return fmt.Errorf("create node %s in docker: %w", name, err)
+	}
+
+	if err := g.StartNode(ctx, name); err != nil {
+		return fmt.Errorf("start node %s in docker: %w", name, err)
+	}
+
+	if err := g.Fund(ctx, name, o, f); err != nil {
+		return fmt.Errorf("fund node %s: %w", name, err)
+	}
+
+	return
+}
+
+// scale sets up nodes the node group lacks or deletes nodes above the number
+// of replicas
+func (g *NodeGroup) scale(ctx context.Context, replicas int, f orchestration.FundingOptions) (err error) {
+	if replicas < 0 {
+		return fmt.Errorf("node group %s: negative number of replicas %d", g.name, replicas)
+	}
+
+	add, remove := utils.ScaleNames(g.name, g.NodesSorted(), replicas)
+	if len(add) > 0 && g.opts.BeeConfig == nil {
+		return fmt.Errorf("node group %s: nodes are added to node groups with bee config only", g.name)
+	}
+
+	errGroup := new(errgroup.Group)
+	for _, name := range add {
+		name := name
+		errGroup.Go(func() error {
+			return g.SetupNode(ctx, name, orchestration.NodeOptions{}, f)
+		})
+	}
+	for _, name := range remove {
+		name := name
+		errGroup.Go(func() error {
+			return g.DeleteNode(ctx, name)
+		})
+	}
+	if err := errGroup.Wait(); err != nil {
+		return fmt.Errorf("scale node group %s: %w", g.name, err)
+	}
+
+	g.logger.Infof("node group %s is scaled to %d nodes, %d added and %d deleted", g.name, replicas, len(add), len(remove))
+	return
+}
+
+// Settlements returns NodeGroupSettlements
+func (g *NodeGroup) Settlements(ctx context.Context) (settlements orchestration.NodeGroupSettlements, err error) {
+	overlays, err := g.Overlays(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("checking settlements: %w", err)
+	}
+
+	sets, err := nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bee.Settlements, error) {
+		return c.Settlements(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	settlements = make(orchestration.NodeGroupSettlements)
+	for name, s := range sets {
+		tmp := make(map[string]orchestration.SentReceived)
+		for _, set := range s.Settlements {
+			tmp[set.Peer] = orchestration.SentReceived{
+				Received: set.Received,
+				Sent:     set.Sent,
+			}
+		}
+		settlements[overlays[name].String()] = tmp
+	}
+
+	return
+}
+
+// Size returns size of the node group
+func (g *NodeGroup) Size() int {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return len(g.nodes)
+}
+
+// StartNode starts the node's container and waits until the node is ready
+func (g *NodeGroup) StartNode(ctx context.Context, name string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	if err := n.Start(ctx, g.cluster.namespace); err != nil {
+		return err
+	}
+
+	return g.waitReady(ctx, name, true)
+}
+
+// StopNode stops the node's container
+func (g *NodeGroup) StopNode(ctx context.Context, name string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	if err := n.Stop(ctx, g.cluster.namespace); err != nil {
+		return err
+	}
+
+	return g.waitReady(ctx, name, false)
+}
+
+// StoppedNodes returns list of nodes with containers that are not running
+func (g *NodeGroup) StoppedNodes(ctx context.Context) (stopped []string, err error) {
+	all, err := g.containerNodes(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	running, err := g.containerNodes(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range g.NodesSorted() {
+		if contains(all, v) && !contains(running, v) {
+			stopped = append(stopped, v)
+		}
+	}
+
+	return
+}
+
+// Topologies returns NodeGroupTopologies
+func (g *NodeGroup) Topologies(ctx context.Context) (topologies orchestration.NodeGroupTopologies, err error) {
+	return nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bee.Topology, error) {
+		return c.Topology(ctx)
+	})
+}
+
+// containerNodes returns names of nodes of the node group with containers,
+// running containers only, unless all is set
+func (g *NodeGroup) containerNodes(ctx context.Context, all bool) (names []string, err error) {
+	containers, err := g.docker.ListContainers(ctx, map[string]string{
+		labelCluster:   g.cluster.namespace,
+		labelNodeGroup: g.name,
+	}, all)
+	if err != nil {
+		return nil, fmt.Errorf("containers of node group %s: %w", g.name, err)
+	}
+
+	prefix := containerName(g.cluster.namespace, "")
+	for _, c := range containers {
+		names = append(names, c[len(prefix):])
+	}
+
+	return
+}
+
+// waitReady waits until the node is ready, or not ready if ready is not set
+func (g *NodeGroup) waitReady(ctx context.Context, name string, ready bool) (err error) {
+	state := "ready"
+	if !ready {
+		state = "stopped"
+	}
+
+	g.logger.Infof("wait for %s to become %s", name, state)
+	for {
+		ok, err := g.NodeReady(ctx, name)
+		if err != nil {
+			return fmt.Errorf("node %s readiness: %w", name, err)
+		}
+
+		if ok == ready {
+			g.logger.Infof("%s is %s", name, state)
+			return nil
+		}
+
+		g.logger.Infof("%s is not %s yet", name, state)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(nodeRetryTimeout):
+		}
+	}
+}
+
+// nodesDo calls the function with clients of all running nodes of the node
+// group concurrently and returns results by node names, or errors of all
+// nodes that failed
+func nodesDo[T any](ctx context.Context, g *NodeGroup, f func(ctx context.Context, c *bee.Client) (T, error)) (map[string]T, error) {
+	clients, err := g.NodesClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		name  string
+		value T
+		err   error
+	}
+
+	results := make(chan result, len(clients))
+	for name, c := range clients {
+		go func(name string, c *bee.Client) {
+			v, err := f(ctx, c)
+			results <- result{name: name, value: v, err: err}
+		}(name, c)
+	}
+
+	values := make(map[string]T, len(clients))
+	for range clients {
+		r := <-results
+		if r.err != nil {
+			err = errors.Join(err, fmt.Errorf("%s: %w", r.name, r.err))
+			continue
+		}
+		values[r.name] = r.value
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// retry calls the function until it succeeds, up to the number of attempts
+func retry(attempts int, f func() error) (err error) {
+	for i := 0; i < attempts; i++ {
+		if err = f(); err == nil {
+			return nil
+		}
+		if i < attempts-1 {
+			time.Sleep(nodeRetryTimeout)
+		}
+	}
+	return err
+}
+
+func (g *NodeGroup) addNode(n Node) {
+	g.lock.Lock()
+	g.nodes[n.Name()] = n
+	g.lock.Unlock()
+}
+
+func (g *NodeGroup) deleteNode(name string) {
+	g.lock.Lock()
+	delete(g.nodes, name)
+	g.lock.Unlock()
+}
+
+func (g *NodeGroup) getClients() map[string]*bee.Client {
+	c := make(map[string]*bee.Client)
+	g.lock.RLock()
+	for k, v := range g.nodes {
+		c[k] = v.Client()
+	}
+	g.lock.RUnlock()
+	return c
+}
+
+func (g *NodeGroup) getNode(name string) (n orchestration.Node, err error) {
+	g.lock.RLock()
+	n, ok := g.nodes[name]
+	g.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("node %s not found", name)
+	}
+	return
+}
+
+func (g *NodeGroup) getNodes() map[string]orchestration.Node {
+	nodes := make(map[string]orchestration.Node)
+	g.lock.RLock()
+	for k, v := range g.nodes {
+		nodes[k] = v
+	}
+	g.lock.RUnlock()
+	return nodes
+}
+
+func (g *NodeGroup) setNode(name string, n orchestration.Node) (err error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	_, ok := g.nodes[name]
+	if !ok {
+		return fmt.Errorf("node %s not found", name)
+	}
+
+	g.nodes[name] = n
+
+	return
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethersphere/bee/pkg/swarm"
 	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/helm"
 	"github.com/ethersphere/beekeeper/pkg/k8s"
 	"github.com/ethersphere/beekeeper/pkg/logging"
 	"github.com/ethersphere/beekeeper/pkg/orchestration"
@@ -29,11 +30,19 @@ type Cluster struct {
 	debugAPIInsecureTLS bool
 	debugAPIScheme      string
 	k8s                 *k8s.Client
+	helm                *helm.Client // installs nodes from the Helm chart, if set
 	swap                swap.Client
+	funding             orchestration.FundingOptions // funding of nodes added by scaling
 	labels              map[string]string
 	namespace           string
 	disableNamespace    bool                  // do not use namespace for node hostnames
 	nodeGroups          map[string]*NodeGroup // set when groups are added to the cluster
+	rateLimiter         *bee.RateLimiter      // shared by clients of all nodes
+	nodeRateLimit       float64
+	nodeRateLimitBurst  int
+	retry               bee.RetryOptions
+	transport           bee.TransportOptions
+	accessMode          string
 	logger              logging.Logger
 }
 
@@ -49,12 +58,32 @@ func NewCluster(name string, o orchestration.ClusterOptions, logger logging.Logg
 		debugAPIInsecureTLS: o.DebugAPIInsecureTLS,
 		debugAPIScheme:      o.DebugAPIScheme,
 		k8s:                 o.K8SClient,
+		helm:                o.HelmClient,
 		swap:                o.SwapClient,
+		funding:             o.Funding,
 		labels:              o.Labels,
 		namespace:           o.Namespace,
 		disableNamespace:    o.DisableNamespace,
 		nodeGroups:          make(map[string]*NodeGroup),
-		logger:              logger,
+		rateLimiter:         bee.NewRateLimiter(o.RateLimit, o.RateLimitBurst),
+		nodeRateLimit:       o.NodeRateLimit,
+		nodeRateLimitBurst:  o.NodeRateLimitBurst,
+		retry: bee.RetryOptions{
+			ReadRetries:  o.ReadRetries,
+			WriteRetries: o.WriteRetries,
+			MinBackoff:   o.RetryMinBackoff,
+			MaxBackoff:   o.RetryMaxBackoff,
+			Budget:       o.RetryBudget,
+		},
+		transport: bee.TransportOptions{
+			MaxIdleConnsPerHost: o.MaxIdleConnsPerHost,
+			MaxConnsPerHost:     o.MaxConnsPerHost,
+			IdleConnTimeout:     o.IdleConnTimeout,
+			DisableKeepAlives:   o.DisableKeepAlives,
+			DisableHTTP2:        o.DisableHTTP2,
+		},
+		accessMode: o.AccessMode,
+		logger:     logger,
 	}
 }
 
@@ -63,6 +92,13 @@ func (c *Cluster) AddNodeGroup(name string, o orchestration.NodeGroupOptions) {
 	g := NewNodeGroup(name, o, c.logger)
 	g.cluster = c
 	g.k8s = c.k8s
+	if o.K8SClient != nil {
+		g.k8s = o.K8SClient
+	}
+	g.helm = c.helm
+	if o.HelmClient != nil {
+		g.helm = o.HelmClient
+	}
 	g.opts.Annotations = mergeMaps(g.cluster.annotations, o.Annotations)
 	g.opts.Labels = mergeMaps(g.cluster.labels, o.Labels)
 
@@ -370,6 +406,55 @@ func (c *Cluster) RandomNode(ctx context.Context, r *rand.Rand) (node orchestrat
 	return nodes[r.Intn(len(nodes))], nil
 }
 
+// Restarts returns ClusterRestarts
+func (c *Cluster) Restarts(ctx context.Context) (restarts orchestration.ClusterRestarts, err error) {
+	restarts = make(orchestration.ClusterRestarts)
+
+	for k, v := range c.nodeGroups {
+		r, err := v.Restarts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+
+		restarts[k] = r
+	}
+
+	return
+}
+
+// FlattenRestarts returns aggregated NodeGroupRestarts
+func (c *Cluster) FlattenRestarts(ctx context.Context) (restarts orchestration.NodeGroupRestarts, err error) {
+	r, err := c.Restarts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	restarts = make(orchestration.NodeGroupRestarts)
+
+	for _, v := range r {
+		for n, res := range v {
+			if _, found := restarts[n]; found {
+				return nil, fmt.Errorf("key %s already present", n)
+			}
+			restarts[n] = res
+		}
+	}
+
+	return
+}
+
+// ScaleNodeGroup adds nodes to the node group or deletes nodes from it, so it
+// has the number of replicas. Added nodes are set up and funded like nodes
+// of the cluster configuration.
+func (c *Cluster) ScaleNodeGroup(ctx context.Context, name string, replicas int) (err error) {
+	g, ok := c.nodeGroups[name]
+	if !ok {
+		return fmt.Errorf("node group %s not found", name)
+	}
+
+	return g.scale(ctx, replicas, c.funding)
+}
+
 // Settlements returns
 func (c *Cluster) Settlements(ctx context.Context) (settlements orchestration.ClusterSettlements, err error) {
 	settlements = make(orchestration.ClusterSettlements)
@@ -453,11 +538,11 @@ func (c *Cluster) FlattenTopologies(ctx context.Context) (topologies map[string]
 }
 
 // apiURL generates URL for node's API
-func (c *Cluster) apiURL(name string) (u *url.URL, err error) {
+func (c *Cluster) apiURL(name, namespace, domain string) (u *url.URL, err error) {
 	if c.disableNamespace {
-		u, err = url.Parse(fmt.Sprintf("%s://%s.%s", c.apiScheme, name, c.apiDomain))
+		u, err = url.Parse(fmt.Sprintf("%s://%s.%s", c.apiScheme, name, domain))
 	} else {
-		u, err = url.Parse(fmt.Sprintf("%s://%s.%s.%s", c.apiScheme, name, c.namespace, c.apiDomain))
+		u, err = url.Parse(fmt.Sprintf("%s://%s.%s.%s", c.apiScheme, name, namespace, domain))
 	}
 	if err != nil {
 		return nil, fmt.Errorf("bad API url for node %s: %w", name, err)
@@ -466,19 +551,19 @@ func (c *Cluster) apiURL(name string) (u *url.URL, err error) {
 }
 
 // ingressHost generates host for node's API ingress
-func (c *Cluster) ingressHost(name string) string {
+func (c *Cluster) ingressHost(name, namespace, domain string) string {
 	if c.disableNamespace {
-		return fmt.Sprintf("%s.%s", name, c.apiDomain)
+		return fmt.Sprintf("%s.%s", name, domain)
 	}
-	return fmt.Sprintf("%s.%s.%s", name, c.namespace, c.apiDomain)
+	return fmt.Sprintf("%s.%s.%s", name, namespace, domain)
 }
 
 // debugAPIURL generates URL for node's DebugAPI
-func (c *Cluster) debugAPIURL(name string) (u *url.URL, err error) {
+func (c *Cluster) debugAPIURL(name, namespace, domain string) (u *url.URL, err error) {
 	if c.disableNamespace {
-		u, err = url.Parse(fmt.Sprintf("%s://%s-debug.%s", c.debugAPIScheme, name, c.debugAPIDomain))
+		u, err = url.Parse(fmt.Sprintf("%s://%s-debug.%s", c.debugAPIScheme, name, domain))
 	} else {
-		u, err = url.Parse(fmt.Sprintf("%s://%s-debug.%s.%s", c.debugAPIScheme, name, c.namespace, c.debugAPIDomain))
+		u, err = url.Parse(fmt.Sprintf("%s://%s-debug.%s.%s", c.debugAPIScheme, name, namespace, domain))
 	}
 	if err != nil {
 		return nil, fmt.Errorf("bad debug API url for node %s: %w", name, err)
@@ -487,9 +572,9 @@ func (c *Cluster) debugAPIURL(name string) (u *url.URL, err error) {
 }
 
 // ingressHost generates host for node's DebugAPI ingress
-func (c *Cluster) ingressDebugHost(name string) string {
+func (c *Cluster) ingressDebugHost(name, namespace, domain string) string {
 	if c.disableNamespace {
-		return fmt.Sprintf("%s-debug.%s", name, c.debugAPIDomain)
+		return fmt.Sprintf("%s-debug.%s", name, domain)
 	}
-	return fmt.Sprintf("%s-debug.%s.%s", name, c.namespace, c.debugAPIDomain)
+	return fmt.Sprintf("%s-debug.%s.%s", name, namespace, domain)
 }
@@ -0,0 +1,164 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// failuresWatchRetry is the delay before watches of pods closed by the API
+// server, or failed to start, are started again
+const failuresWatchRetry = 5 * time.Second
+
+// WatchFailures watches pods of nodes of all node groups until the context is
+// done and sends failures of their Bee containers, OOM kills and crashloops,
+// with events of their pods. Restarts before the watch started are not
+// failures, crashloops are.
+func (c *Cluster) WatchFailures(ctx context.Context) (<-chan orchestration.NodeFailure, error) {
+	failures := make(chan orchestration.NodeFailure)
+
+	var wg sync.WaitGroup
+	for _, g := range c.nodeGroups {
+		wg.Add(1)
+		go func(g *NodeGroup) {
+			defer wg.Done()
+			g.watchFailures(ctx, failures)
+		}(g)
+	}
+
+	go func() {
+		wg.Wait()
+		close(failures)
+	}()
+
+	return failures, nil
+}
+
+// watchFailures sends failures of Bee containers of the node group nodes
+// until the context is done
+func (g *NodeGroup) watchFailures(ctx context.Context, failures chan<- orchestration.NodeFailure) {
+	baseline := make(map[string]int32)  // restart counts of pods when they were first seen
+	reported := make(map[string]string) // the last failure reported for pods
+
+	for {
+		w, err := g.k8s.Pods.Watch(ctx, g.namespace(), nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			g.logger.Warningf("node group %s: %v", g.name, err)
+		} else {
+			for e := range w.ResultChan() {
+				p, ok := e.Object.(*v1.Pod)
+				if !ok {
+					continue
+				}
+				node, ok := g.podNodes()[p.Name]
+				if !ok {
+					continue
+				}
+
+				if e.Type == watch.Deleted {
+					delete(baseline, p.Name)
+					delete(reported, p.Name)
+					continue
+				}
+
+				reason, restarts, ok := beeFailure(p, baseline)
+				if !ok {
+					continue
+				}
+				key := fmt.Sprintf("%s/%d", reason, restarts)
+				if reported[p.Name] == key {
+					continue
+				}
+				reported[p.Name] = key
+
+				f := orchestration.NodeFailure{
+					Node:   node,
+					Reason: reason,
+					Events: g.podEvents(ctx, p.Name),
+				}
+				g.logger.Warningf("node group %s: %v", g.name, f)
+
+				select {
+				case failures <- f:
+				case <-ctx.Done():
+					w.Stop()
+					return
+				}
+			}
+			w.Stop()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(failuresWatchRetry):
+		}
+	}
+}
+
+// beeFailure returns reason and restart count of the failure of the pod's Bee
+// container, if it is crashlooping or was OOM killed since the pod was first
+// seen, when its restart count is recorded in the baseline
+func beeFailure(p *v1.Pod, baseline map[string]int32) (reason string, restarts int32, ok bool) {
+	for _, s := range p.Status.ContainerStatuses {
+		if s.Name != "bee" {
+			continue
+		}
+
+		first, seen := baseline[p.Name]
+		if !seen || s.RestartCount < first { // pod was recreated, restart count starts from zero
+			first = s.RestartCount
+			baseline[p.Name] = first
+		}
+
+		if s.State.Waiting != nil && s.State.Waiting.Reason == "CrashLoopBackOff" {
+			reason = s.State.Waiting.Reason
+			if s.LastTerminationState.Terminated != nil {
+				reason = fmt.Sprintf("%s after %s", reason, s.LastTerminationState.Terminated.Reason)
+			}
+			return reason, s.RestartCount, true
+		}
+		if s.State.Terminated != nil && s.State.Terminated.Reason == "OOMKilled" {
+			return s.State.Terminated.Reason, s.RestartCount, true
+		}
+		if s.RestartCount > first && s.LastTerminationState.Terminated != nil && s.LastTerminationState.Terminated.Reason == "OOMKilled" {
+			return s.LastTerminationState.Terminated.Reason, s.RestartCount, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// podEvents returns events of the pod formatted as type, reason and message,
+// none if they can not be listed
+func (g *NodeGroup) podEvents(ctx context.Context, pod string) (events []string) {
+	l, err := g.k8s.Pods.Events(ctx, pod, g.namespace())
+	if err != nil {
+		g.logger.Warningf("node group %s: %v", g.name, err)
+		return nil
+	}
+
+	for _, e := range l {
+		events = append(events, fmt.Sprintf("%s %s: %s", e.Type, e.Reason, e.Message))
+	}
+	return
+}
+
+// podNodes returns names of the node group nodes by names of their pods
+func (g *NodeGroup) podNodes() map[string]string {
+	pods := make(map[string]string)
+	for name, n := range g.getNodes() {
+		if n, ok := n.(*Node); ok {
+			pods[n.podName()] = name
+		}
+	}
+	return pods
+}
@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// beePod returns the pod with the Bee container in the state
+func beePod(restarts int32, state, last v1.ContainerState) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "bee-0-0"},
+		Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+			{Name: "init", RestartCount: 10, State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			{Name: "bee", RestartCount: restarts, State: state, LastTerminationState: last},
+		}},
+	}
+}
+
+var (
+	running       = v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	crashLoop     = v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+	oomKilled     = v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled"}}
+	errorExit     = v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error"}}
+	notTerminated = v1.ContainerState{}
+)
+
+func TestBeeFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		baseline     map[string]int32
+		pod          *v1.Pod
+		wantReason   string
+		wantRestarts int32
+		wantBaseline int32
+	}{
+		{
+			name:         "running",
+			pod:          beePod(0, running, notTerminated),
+			wantBaseline: 0,
+		},
+		{
+			name:         "restarts before the pod was seen",
+			pod:          beePod(3, running, oomKilled),
+			wantBaseline: 3,
+		},
+		{
+			name:         "oom killed since the pod was seen",
+			baseline:     map[string]int32{"bee-0-0": 1},
+			pod:          beePod(2, running, oomKilled),
+			wantReason:   "OOMKilled",
+			wantRestarts: 2,
+			wantBaseline: 1,
+		},
+		{
+			name:         "restarted with error since the pod was seen",
+			baseline:     map[string]int32{"bee-0-0": 1},
+			pod:          beePod(2, running, errorExit),
+			wantBaseline: 1,
+		},
+		{
+			name:         "oom killed",
+			pod:          beePod(0, oomKilled, notTerminated),
+			wantReason:   "OOMKilled",
+			wantBaseline: 0,
+		},
+		{
+			name:         "crashloop before the pod was seen",
+			pod:          beePod(5, crashLoop, errorExit),
+			wantReason:   "CrashLoopBackOff after Error",
+			wantRestarts: 5,
+			wantBaseline: 5,
+		},
+		{
+			name:         "crashloop without last termination",
+			pod:          beePod(1, crashLoop, notTerminated),
+			wantReason:   "CrashLoopBackOff",
+			wantRestarts: 1,
+			wantBaseline: 1,
+		},
+		{
+			name:         "recreated pod",
+			baseline:     map[string]int32{"bee-0-0": 4},
+			pod:          beePod(1, running, oomKilled),
+			wantBaseline: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			baseline := tc.baseline
+			if baseline == nil {
+				baseline = make(map[string]int32)
+			}
+
+			reason, restarts, ok := beeFailure(tc.pod, baseline)
+			if ok != (tc.wantReason != "") || reason != tc.wantReason || restarts != tc.wantRestarts {
+				t.Errorf("got failure %q with %d restarts, %t, want %q with %d restarts", reason, restarts, ok, tc.wantReason, tc.wantRestarts)
+			}
+			if got := baseline["bee-0-0"]; got != tc.wantBaseline {
+				t.Errorf("got baseline %d, want %d", got, tc.wantBaseline)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/ethersphere/beekeeper/pkg/helm"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+	"gopkg.in/yaml.v3"
+)
+
+// installRelease installs the node from the Helm chart, as the release named
+// after the node. The chart creates the statefulset named after the node,
+// so the node is started, stopped and observed like nodes created from
+// manifests.
+func (n Node) installRelease(ctx context.Context, o orchestration.CreateOptions) (err error) {
+	if n.chart.Name == "" {
+		return fmt.Errorf("helm chart of node %s is not set", o.Name)
+	}
+	if o.Config.ClefSignerEnable {
+		return errors.New("clef signer is not supported by the helm orchestrator")
+	}
+	if o.Gateway != "" {
+		return errors.New("gateway is not supported by the helm orchestrator")
+	}
+	if o.Netem.Enabled() {
+		return errors.New("netem is not supported by the helm orchestrator")
+	}
+
+	values, err := releaseValues(o)
+	if err != nil {
+		return fmt.Errorf("values of release %s: %w", o.Name, err)
+	}
+
+	if err := n.helm.Upgrade(ctx, o.Name, o.Namespace, n.chart, helm.UpgradeOptions{
+		Values:      values,
+		ValuesFiles: n.valuesFiles,
+	}); err != nil {
+		return fmt.Errorf("install release %s in namespace %s: %w", o.Name, o.Namespace, err)
+	}
+	n.logger.Infof("release %s of chart %s is installed in namespace %s", o.Name, n.chart.Name, o.Namespace)
+
+	n.logger.Infof("node %s started in namespace %s", o.Name, o.Namespace)
+	return
+}
+
+// uninstallRelease uninstalls the node's release, persistent volume claims
+// are kept, like the ones of statefulsets
+func (n Node) uninstallRelease(ctx context.Context, namespace string) (err error) {
+	if err := n.helm.Uninstall(ctx, n.name, namespace); err != nil {
+		return fmt.Errorf("uninstall release %s in namespace %s: %w", n.name, namespace, err)
+	}
+	n.logger.Infof("release %s is uninstalled in namespace %s", n.name, namespace)
+
+	n.logger.Infof("node %s is deleted in namespace %s", n.name, namespace)
+	return
+}
+
+// setReleaseImage upgrades the node's release with the image and recreates
+// its pod, so the image is applied regardless of the update strategy
+func (n Node) setReleaseImage(ctx context.Context, namespace, image string) (err error) {
+	values, err := yaml.Marshal(map[string]interface{}{"image": imageValues(image, "")})
+	if err != nil {
+		return err
+	}
+
+	if err := n.helm.Upgrade(ctx, n.name, namespace, n.chart, helm.UpgradeOptions{
+		Values:      values,
+		ReuseValues: true,
+	}); err != nil {
+		return fmt.Errorf("upgrade release %s in namespace %s: %w", n.name, namespace, err)
+	}
+
+	if err := n.k8s.Pods.Delete(ctx, fmt.Sprintf("%s-0", n.name), namespace); err != nil {
+		return fmt.Errorf("delete pod %s-0 in namespace %s: %w", n.name, namespace, err)
+	}
+
+	n.logger.Infof("node %s image is set to %s in namespace %s", n.name, image, namespace)
+	return
+}
+
+// releaseValues returns values of the bee chart with the node's configuration,
+// keys and ingresses
+func releaseValues(o orchestration.CreateOptions) ([]byte, error) {
+	var config bytes.Buffer
+	if err := template.Must(template.New("").Parse(orchestration.ConfigTemplate)).Execute(&config, o.Config); err != nil {
+		return nil, err
+	}
+	data, err := orchestration.ApplyConfigTemplate(config.Bytes(), o)
+	if err != nil {
+		return nil, err
+	}
+	beeConfig := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &beeConfig); err != nil {
+		return nil, fmt.Errorf("bee configuration: %w", err)
+	}
+
+	// the statefulset has a single replica, so its pod is always suffixed with -0
+	pod := fmt.Sprintf("%s-0", o.Name)
+
+	values := map[string]interface{}{
+		"fullnameOverride":    o.Name,
+		"replicaCount":        1,
+		"image":               imageValues(o.Image, o.ImagePullPolicy),
+		"beeConfig":           beeConfig,
+		"podManagementPolicy": o.PodManagementPolicy,
+		"updateStrategy":      map[string]interface{}{"type": o.UpdateStrategy},
+		"nodeSelector":        o.NodeSelector,
+		"persistence": map[string]interface{}{
+			"enabled":      o.PersistenceEnabled,
+			"storageClass": o.PersistenceStorageClass,
+			"size":         o.PersistenceStorageRequest,
+		},
+		"resources": map[string]interface{}{
+			"limits":   resourceValues(o.ResourcesLimitCPU, o.ResourcesLimitMemory, o.ResourcesLimitEphemeralStorage),
+			"requests": resourceValues(o.ResourcesRequestCPU, o.ResourcesRequestMemory, o.ResourcesRequestEphemeralStorage),
+		},
+		"ingress":      ingressValues(o.IngressClass, o.IngressHost, o.IngressTLSSecret, mergeMaps(o.Annotations, o.IngressAnnotations)),
+		"ingressDebug": ingressValues(o.IngressDebugClass, o.IngressDebugHost, o.IngressDebugTLSSecret, mergeMaps(o.Annotations, o.IngressDebugAnnotations)),
+	}
+
+	schedulingValues(values, o)
+	securityValues(values, o.SecurityContext)
+
+	var secrets []map[string]string
+	for _, s := range o.ImagePullSecrets {
+		secrets = append(secrets, map[string]string{"name": s})
+	}
+	if len(secrets) > 0 {
+		values["imagePullSecrets"] = secrets
+	}
+	if len(o.LibP2PKey) > 0 {
+		values["libp2pSettings"] = map[string]interface{}{
+			"enabled":    true,
+			"libp2pKeys": map[string]string{pod: o.LibP2PKey},
+		}
+	}
+	if len(o.SwarmKey) > 0 {
+		values["swarmSettings"] = map[string]interface{}{
+			"enabled":   true,
+			"swarmKeys": map[string]string{pod: o.SwarmKey},
+		}
+	}
+
+	return yaml.Marshal(values)
+}
+
+// imageValues returns image values of the image reference, the tag is
+// latest if the reference has none
+func imageValues(image, pullPolicy string) map[string]string {
+	repository, tag := image, "latest"
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		repository, tag = image[:i], image[i+1:]
+	}
+
+	v := map[string]string{
+		"repository": repository,
+		"tag":        tag,
+	}
+	if pullPolicy != "" {
+		v["pullPolicy"] = pullPolicy
+	}
+
+	return v
+}
+
+// ingressValues returns values of the ingress of the host, with TLS
+// terminated with the certificate of the secret if it is set
+func ingressValues(class, host, tlsSecret string, annotations map[string]string) map[string]interface{} {
+	v := map[string]interface{}{
+		"enabled":     true,
+		"className":   class,
+		"annotations": annotations,
+		"hosts": []map[string]interface{}{{
+			"host": host,
+			"paths": []map[string]string{{
+				"path":     "/",
+				"pathType": "ImplementationSpecific",
+			}},
+		}},
+	}
+	if tlsSecret != "" {
+		v["tls"] = []map[string]interface{}{{
+			"secretName": tlsSecret,
+			"hosts":      []string{host},
+		}}
+	}
+
+	return v
+}
+
+// resourceValues returns values of resources, unset quantities are omitted
+func resourceValues(cpu, memory, ephemeralStorage string) map[string]string {
+	v := make(map[string]string)
+	if cpu != "" {
+		v["cpu"] = cpu
+	}
+	if memory != "" {
+		v["memory"] = memory
+	}
+	if ephemeralStorage != "" {
+		v["ephemeral-storage"] = ephemeralStorage
+	}
+
+	return v
+}
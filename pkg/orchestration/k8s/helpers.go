@@ -8,59 +8,7 @@ import (
 	pvc "github.com/ethersphere/beekeeper/pkg/k8s/persistentvolumeclaim"
 	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
 	"github.com/ethersphere/beekeeper/pkg/k8s/service"
-)
-
-const (
-	configTemplate = `api-addr: {{.APIAddr}}
-allow-private-cidrs: {{ .AllowPrivateCIDRs }}
-block-time: {{ .BlockTime }}
-bootnode: {{.Bootnodes}}
-bootnode-mode: {{.BootnodeMode}}
-cache-capacity: {{.CacheCapacity}}
-clef-signer-enable: {{.ClefSignerEnable}}
-clef-signer-endpoint: {{.ClefSignerEndpoint}}
-cors-allowed-origins: {{.CORSAllowedOrigins}}
-data-dir: {{.DataDir}}
-db-open-files-limit: {{.DbOpenFilesLimit}}
-db-block-cache-capacity: {{.DbBlockCacheCapacity}}
-db-write-buffer-size: {{.DbWriteBufferSize}}
-db-disable-seeks-compaction: {{.DbDisableSeeksCompaction}}
-debug-api-addr: {{.DebugAPIAddr}}
-debug-api-enable: {{.DebugAPIEnable}}
-full-node: {{.FullNode}}
-mainnet: {{.Mainnet}}
-nat-addr: {{.NATAddr}}
-network-id: {{.NetworkID}}
-p2p-addr: {{.P2PAddr}}
-p2p-ws-enable: {{.P2PWSEnable}}
-password: {{.Password}}
-payment-early-percent: {{.PaymentEarly}}
-payment-threshold: {{.PaymentThreshold}}
-payment-tolerance-percent: {{.PaymentTolerance}}
-postage-stamp-address: {{ .PostageStampAddress }}
-postage-stamp-start-block: {{ .PostageContractStartBlock }}
-price-oracle-address: {{ .PriceOracleAddress }}
-redistribution-address: {{ .RedistributionAddress }}
-staking-address: {{ .StakingAddress }}
-storage-incentives-enable: {{ .StorageIncentivesEnable }}
-resolver-options: {{.ResolverOptions}}
-restricted: {{.Restricted}}
-token-encryption-key: {{.TokenEncryptionKey}}
-admin-password: {{.AdminPassword}}
-chequebook-enable: {{.ChequebookEnable}}
-swap-enable: {{.SwapEnable}}
-swap-endpoint: {{.SwapEndpoint}}
-swap-deployment-gas-price: {{.SwapDeploymentGasPrice}}
-swap-factory-address: {{.SwapFactoryAddress}}
-swap-legacy-factory-addresses: {{.SwapLegacyFactoryAddresses}}
-swap-initial-deposit: {{.SwapInitialDeposit}}
-tracing-enable: {{.TracingEnabled}}
-tracing-endpoint: {{.TracingEndpoint}}
-tracing-service-name: {{.TracingServiceName}}
-verbosity: {{.Verbosity}}
-welcome-message: {{.WelcomeMessage}}
-warmup-time: {{.WarmupTime}}
-`
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
 )
 
 type setInitContainersOptions struct {
@@ -71,6 +19,8 @@ type setInitContainersOptions struct {
 	ClefPassword        string
 	LibP2PEnabled       bool
 	SwarmEnabled        bool
+	Netem               orchestration.Netem
+	SecurityContext     orchestration.SecurityContext
 }
 
 func setInitContainers(o setInitContainersOptions) (inits containers.Containers) {
@@ -80,6 +30,7 @@ func setInitContainers(o setInitContainersOptions) (inits containers.Containers)
 			Image:           o.ClefImage,
 			ImagePullPolicy: o.ClefImagePullPolicy,
 			Command:         []string{"sh", "-c", "/entrypoint.sh init; echo 'clef initialization done';"},
+			SecurityContext: setSidecarSecurityContext(o.SecurityContext),
 			VolumeMounts: setClefVolumeMounts(setClefVolumeMountsOptions{
 				ClefEnabled:       o.ClefEnabled,
 				ClefSecretEnabled: o.ClefSecretEnabled,
@@ -93,6 +44,7 @@ func setInitContainers(o setInitContainersOptions) (inits containers.Containers)
 			Command: []string{"sh", "-c", `mkdir -p /home/bee/.bee/keys;
 chown -R 999:999 /home/bee/.bee/keys;
 echo 'bee initialization done';`},
+			SecurityContext: setSidecarSecurityContext(o.SecurityContext),
 			VolumeMounts: containers.VolumeMounts{
 				{
 					Name:      "data",
@@ -101,29 +53,33 @@ echo 'bee initialization done';`},
 			},
 		})
 	}
+	inits = append(inits, setNetemInitContainers(o.Netem)...)
 
 	return
 }
 
 type setContainersOptions struct {
-	Name                   string
-	Image                  string
-	ImagePullPolicy        string
-	PortAPI                int32
-	PortDebug              int32
-	PortP2P                int32
-	PersistenceEnabled     bool
-	ResourcesLimitCPU      string
-	ResourcesLimitMemory   string
-	ResourcesRequestCPU    string
-	ResourcesRequestMemory string
-	ClefEnabled            bool
-	ClefSecretEnabled      bool
-	ClefImage              string
-	ClefImagePullPolicy    string
-	ClefPassword           string
-	LibP2PEnabled          bool
-	SwarmEnabled           bool
+	Name                             string
+	Image                            string
+	ImagePullPolicy                  string
+	PortAPI                          int32
+	PortDebug                        int32
+	PortP2P                          int32
+	PersistenceEnabled               bool
+	ResourcesLimitCPU                string
+	ResourcesLimitEphemeralStorage   string
+	ResourcesLimitMemory             string
+	ResourcesRequestCPU              string
+	ResourcesRequestEphemeralStorage string
+	ResourcesRequestMemory           string
+	ClefEnabled                      bool
+	ClefSecretEnabled                bool
+	ClefImage                        string
+	ClefImagePullPolicy              string
+	ClefPassword                     string
+	LibP2PEnabled                    bool
+	SwarmEnabled                     bool
+	SecurityContext                  orchestration.SecurityContext
 }
 
 func setContainers(o setContainersOptions) (c containers.Containers) {
@@ -168,18 +124,17 @@ func setContainers(o setContainersOptions) (c containers.Containers) {
 		}},
 		Resources: containers.Resources{
 			Limit: containers.Limit{
-				CPU:    o.ResourcesLimitCPU,
-				Memory: o.ResourcesLimitMemory,
+				CPU:              o.ResourcesLimitCPU,
+				Memory:           o.ResourcesLimitMemory,
+				EphemeralStorage: o.ResourcesLimitEphemeralStorage,
 			},
 			Request: containers.Request{
-				CPU:    o.ResourcesRequestCPU,
-				Memory: o.ResourcesRequestMemory,
+				CPU:              o.ResourcesRequestCPU,
+				Memory:           o.ResourcesRequestMemory,
+				EphemeralStorage: o.ResourcesRequestEphemeralStorage,
 			},
 		},
-		SecurityContext: containers.SecurityContext{
-			AllowPrivilegeEscalation: false,
-			RunAsUser:                999,
-		},
+		SecurityContext: setSecurityContext(o.SecurityContext),
 		VolumeMounts: setBeeVolumeMounts(setBeeVolumeMountsOptions{
 			LibP2PEnabled: o.LibP2PEnabled,
 			SwarmEnabled:  o.SwarmEnabled,
@@ -192,6 +147,7 @@ func setContainers(o setContainersOptions) (c containers.Containers) {
 			Image:           o.ClefImage,
 			ImagePullPolicy: o.ClefImagePullPolicy,
 			Command:         []string{"sh", "-c", "/entrypoint.sh run;"},
+			SecurityContext: setSidecarSecurityContext(o.SecurityContext),
 			Ports: containers.Ports{
 				{
 					Name:          "api",
@@ -281,6 +237,8 @@ type setVolumesOptions struct {
 	KeysSecret         string
 	ClefSecret         string
 	PersistenceEnabled bool
+	EmptyDirMedium     string
+	EmptyDirSizeLimit  string
 	ClefEnabled        bool
 	ClefSecretEnabled  bool
 	LibP2PEnabled      bool
@@ -297,7 +255,9 @@ func setVolumes(o setVolumesOptions) (volumes pod.Volumes) {
 	if !o.PersistenceEnabled {
 		volumes = append(volumes, pod.Volume{
 			EmptyDir: &pod.EmptyDirVolume{
-				Name: "data",
+				Name:      "data",
+				Medium:    o.EmptyDirMedium,
+				SizeLimit: o.EmptyDirSizeLimit,
 			},
 		})
 	}
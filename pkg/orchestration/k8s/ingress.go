@@ -0,0 +1,69 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s/customresource/httproute"
+	"github.com/ethersphere/beekeeper/pkg/k8s/ingress"
+)
+
+// setIngressTLS returns TLS of the ingress of the host, terminated with the
+// certificate of the secret, or nil if the secret is not set
+func setIngressTLS(host, secret string) ingress.TLSs {
+	if secret == "" {
+		return nil
+	}
+	return ingress.TLSs{{
+		Hosts:      []string{host},
+		SecretName: secret,
+	}}
+}
+
+type setHTTPRouteOptions struct {
+	Annotations map[string]string
+	Labels      map[string]string
+	Gateway     string // name or namespace/name of the gateway, in the namespace of the route by default
+	Host        string
+	Service     string
+	Port        int32
+}
+
+// setHTTPRoute sets the Gateway API HTTPRoute routing all requests to the
+// host to the port of the service, attached to the gateway
+func (n Node) setHTTPRoute(ctx context.Context, name, namespace string, o setHTTPRouteOptions) (err error) {
+	parent := httproute.ParentReference{Name: o.Gateway}
+	if i := strings.Index(o.Gateway, "/"); i >= 0 {
+		parent = httproute.ParentReference{
+			Namespace: o.Gateway[:i],
+			Name:      o.Gateway[i+1:],
+		}
+	}
+
+	if _, err := n.k8s.HTTPRoute.Set(ctx, name, namespace, httproute.Options{
+		Annotations: o.Annotations,
+		Labels:      o.Labels,
+		Spec: httproute.HTTPRouteSpec{
+			ParentRefs: []httproute.ParentReference{parent},
+			Hostnames:  []string{o.Host},
+			Rules: []httproute.Rule{{
+				Matches: []httproute.Match{{
+					Path: &httproute.PathMatch{
+						Type:  "PathPrefix",
+						Value: "/",
+					},
+				}},
+				BackendRefs: []httproute.BackendRef{{
+					Name: o.Service,
+					Port: o.Port,
+				}},
+			}},
+		},
+	}); err != nil {
+		return fmt.Errorf("set http route in namespace %s: %w", namespace, err)
+	}
+	n.logger.Infof("http route %s is set in namespace %s", name, namespace)
+
+	return
+}
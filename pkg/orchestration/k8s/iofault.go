@@ -0,0 +1,105 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s/customresource/iochaos"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// InjectIOFault injects the fault into data volumes of pods of nodes of its
+// node groups by chaos-mesh IOChaos resources, one in the namespace of every
+// node group, named after the fault and the node group. It requires
+// chaos-mesh installed in the kubernetes cluster.
+func (c *Cluster) InjectIOFault(ctx context.Context, f orchestration.IOFault) (err error) {
+	spec, err := ioChaosSpec(f)
+	if err != nil {
+		return fmt.Errorf("io fault %s: %w", f.Name, err)
+	}
+
+	names := f.NodeGroups
+	if len(names) == 0 {
+		for name := range c.nodeGroups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	for _, name := range names {
+		g, ok := c.nodeGroups[name]
+		if !ok {
+			return fmt.Errorf("io fault %s: node group %s not found", f.Name, name)
+		}
+
+		spec.Selector = iochaos.PodSelector{
+			Namespaces:     []string{g.namespace()},
+			LabelSelectors: map[string]string{nodeGroupLabel: g.name},
+		}
+		if _, err := g.k8s.IOChaos.Set(ctx, fmt.Sprintf("%s-%s", f.Name, g.name), g.namespace(), iochaos.Options{
+			Labels: map[string]string{chaosLabel: "true"},
+			Spec:   spec,
+		}); err != nil {
+			return fmt.Errorf("io fault %s: %w", f.Name, err)
+		}
+		c.logger.Infof("io fault %s is injected into node group %s", f.Name, g.name)
+	}
+
+	return
+}
+
+// RemoveIOFaults deletes chaos-mesh resources of all injected IO faults, in
+// namespaces of node groups of all kubernetes clusters
+func (c *Cluster) RemoveIOFaults(ctx context.Context) (err error) {
+	for _, ns := range c.namespaces() {
+		if err := ns.k8s.IOChaos.DeleteSelected(ctx, ns.name, map[string]string{chaosLabel: "true"}); err != nil {
+			return fmt.Errorf("remove io faults: %w", err)
+		}
+	}
+
+	return
+}
+
+// ioChaosSpec returns spec of IOChaos resources of the fault, without the pod
+// selector, faults affect the bee container only
+func ioChaosSpec(f orchestration.IOFault) (spec iochaos.IOChaosSpec, err error) {
+	if f.Name == "" {
+		return iochaos.IOChaosSpec{}, errors.New("name is not set")
+	}
+
+	spec = iochaos.IOChaosSpec{
+		Action:         f.Action,
+		Mode:           f.Mode,
+		Value:          f.Value,
+		VolumePath:     f.VolumePath,
+		Percent:        f.Percent,
+		Methods:        f.Methods,
+		ContainerNames: []string{"bee"},
+	}
+	if spec.Action == "" {
+		spec.Action = orchestration.IOFaultLatency
+	}
+	if spec.Mode == "" {
+		spec.Mode = "all"
+	}
+	if spec.VolumePath == "" {
+		spec.VolumePath = "/home/bee/.bee"
+	}
+	if spec.Percent == 0 {
+		spec.Percent = 100
+	}
+
+	switch spec.Action {
+	case orchestration.IOFaultLatency:
+		if f.Latency <= 0 {
+			return iochaos.IOChaosSpec{}, errors.New("latency is not set")
+		}
+		spec.Delay = f.Latency.String()
+	default:
+		return iochaos.IOChaosSpec{}, fmt.Errorf("action %s is not supported", spec.Action)
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,44 @@
+package k8s
+
+import (
+	"errors"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s/containers"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// netemImage is the image of the netem init container without one configured
+const netemImage = "nicolaka/netshoot:v0.11"
+
+// checkNetem returns error if traffic shaping is set for pods whose containers
+// are required to run as non-root, as tc requires root with NET_ADMIN
+func checkNetem(o orchestration.CreateOptions) error {
+	if o.Netem.Enabled() && nonRoot(o.SecurityContext) {
+		return errors.New("netem requires containers running as root with the NET_ADMIN capability, it is not allowed with non-root or restricted security contexts")
+	}
+	return nil
+}
+
+// setNetemInitContainers returns the init container setting the netem
+// queueing discipline on the network interface of the pod, none if traffic
+// shaping is not set. The discipline is set in the network namespace of the
+// pod, so it applies to all its containers until the pod is deleted.
+func setNetemInitContainers(n orchestration.Netem) (inits containers.Containers) {
+	if !n.Enabled() {
+		return nil
+	}
+
+	image := n.Image
+	if image == "" {
+		image = netemImage
+	}
+
+	return containers.Containers{{
+		Name:    "init-netem",
+		Image:   image,
+		Command: n.Command(),
+		SecurityContext: containers.SecurityContext{
+			Capabilities: containers.Capabilities{Add: []string{"NET_ADMIN"}},
+		},
+	}}
+}
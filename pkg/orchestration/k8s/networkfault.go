@@ -0,0 +1,120 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s/customresource/networkchaos"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// chaosLabel labels chaos-mesh resources injecting faults, so they are
+// deleted when faults are removed
+const chaosLabel = "beekeeper.ethersphere.io/chaos"
+
+// InjectNetworkFault injects the fault into pods of nodes of its node groups
+// by chaos-mesh NetworkChaos resources, one in the namespace of every node
+// group, named after the fault and the node group. It requires chaos-mesh
+// installed in the kubernetes cluster.
+func (c *Cluster) InjectNetworkFault(ctx context.Context, f orchestration.NetworkFault) (err error) {
+	spec, err := networkChaosSpec(f)
+	if err != nil {
+		return fmt.Errorf("network fault %s: %w", f.Name, err)
+	}
+
+	names := f.NodeGroups
+	if len(names) == 0 {
+		for name := range c.nodeGroups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	for _, name := range names {
+		g, ok := c.nodeGroups[name]
+		if !ok {
+			return fmt.Errorf("network fault %s: node group %s not found", f.Name, name)
+		}
+
+		spec.Selector = networkchaos.PodSelector{
+			Namespaces:     []string{g.namespace()},
+			LabelSelectors: map[string]string{nodeGroupLabel: g.name},
+		}
+		if _, err := g.k8s.NetworkChaos.Set(ctx, fmt.Sprintf("%s-%s", f.Name, g.name), g.namespace(), networkchaos.Options{
+			Labels: map[string]string{chaosLabel: "true"},
+			Spec:   spec,
+		}); err != nil {
+			return fmt.Errorf("network fault %s: %w", f.Name, err)
+		}
+		c.logger.Infof("network fault %s is injected into node group %s", f.Name, g.name)
+	}
+
+	return
+}
+
+// RemoveNetworkFaults deletes chaos-mesh resources of all injected faults, in
+// namespaces of node groups of all kubernetes clusters
+func (c *Cluster) RemoveNetworkFaults(ctx context.Context) (err error) {
+	for _, ns := range c.namespaces() {
+		if err := ns.k8s.NetworkChaos.DeleteSelected(ctx, ns.name, map[string]string{chaosLabel: "true"}); err != nil {
+			return fmt.Errorf("remove network faults: %w", err)
+		}
+	}
+
+	return
+}
+
+// networkChaosSpec returns spec of NetworkChaos resources of the fault,
+// without the pod selector
+func networkChaosSpec(f orchestration.NetworkFault) (spec networkchaos.NetworkChaosSpec, err error) {
+	if f.Name == "" {
+		return networkchaos.NetworkChaosSpec{}, errors.New("name is not set")
+	}
+
+	spec = networkchaos.NetworkChaosSpec{
+		Action:    f.Action,
+		Mode:      f.Mode,
+		Value:     f.Value,
+		Direction: f.Direction,
+	}
+	if spec.Mode == "" {
+		spec.Mode = "all"
+	}
+	if spec.Direction == "" {
+		spec.Direction = "to"
+	}
+
+	switch f.Action {
+	case orchestration.NetworkFaultDelay:
+		if f.Latency <= 0 {
+			return networkchaos.NetworkChaosSpec{}, errors.New("latency of the delay is not set")
+		}
+		spec.Delay = &networkchaos.DelaySpec{Latency: f.Latency.String()}
+		if f.Jitter > 0 {
+			spec.Delay.Jitter = f.Jitter.String()
+		}
+	case orchestration.NetworkFaultLoss:
+		if f.Loss <= 0 {
+			return networkchaos.NetworkChaosSpec{}, errors.New("loss percentage is not set")
+		}
+		spec.Loss = &networkchaos.LossSpec{Loss: strconv.FormatFloat(f.Loss, 'f', -1, 64)}
+	case orchestration.NetworkFaultBandwidth:
+		if f.Rate == "" {
+			return networkchaos.NetworkChaosSpec{}, errors.New("rate of the bandwidth is not set")
+		}
+		spec.Bandwidth = &networkchaos.BandwidthSpec{Rate: f.Rate, Limit: f.Limit, Buffer: f.Buffer}
+		if spec.Bandwidth.Limit == 0 {
+			spec.Bandwidth.Limit = 20971520
+		}
+		if spec.Bandwidth.Buffer == 0 {
+			spec.Bandwidth.Buffer = 10000
+		}
+	default:
+		return networkchaos.NetworkChaosSpec{}, fmt.Errorf("action %s is not supported", f.Action)
+	}
+
+	return spec, nil
+}
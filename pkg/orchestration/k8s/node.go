@@ -5,11 +5,14 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	"time"
 
 	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/helm"
 	"github.com/ethersphere/beekeeper/pkg/k8s"
 	"github.com/ethersphere/beekeeper/pkg/k8s/configmap"
 	"github.com/ethersphere/beekeeper/pkg/k8s/customresource/ingressroute"
+	"github.com/ethersphere/beekeeper/pkg/k8s/exec"
 	"github.com/ethersphere/beekeeper/pkg/k8s/ingress"
 	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
 	"github.com/ethersphere/beekeeper/pkg/k8s/secret"
@@ -30,10 +33,17 @@ type Node struct {
 	clefPassword string
 	client       *bee.Client
 	config       *orchestration.Config
+	image        string
 	k8s          *k8s.Client
 	libP2PKey    string
 	swarmKey     string
+	statefulSet  string // statefulset of the node group the node is a replica of, with the statefulset deployment mode
 	logger       logging.Logger
+
+	// set with the helm orchestrator, the node is installed from the chart
+	helm        *helm.Client
+	chart       helm.Chart
+	valuesFiles []string
 }
 
 // NewNode returns Bee node
@@ -61,9 +71,15 @@ func NewNode(name string, opts orchestration.NodeOptions, logger logging.Logger)
 	if len(opts.SwarmKey) > 0 {
 		n.swarmKey = opts.SwarmKey.ToString()
 	}
+	if len(opts.Image) > 0 {
+		n.image = opts.Image
+	}
 	if opts.K8S != nil {
 		n.k8s = opts.K8S
 	}
+	if opts.Helm != nil {
+		n.helm = opts.Helm
+	}
 
 	return
 }
@@ -78,6 +94,11 @@ func (n Node) Client() *bee.Client {
 	return n.client
 }
 
+// Image returns image of the node's Bee container
+func (n Node) Image() string {
+	return n.image
+}
+
 // Config returns node's config
 func (n Node) Config() *orchestration.Config {
 	return n.config
@@ -121,11 +142,40 @@ func (n Node) SetClefPassword(password string) orchestration.Node {
 	return n
 }
 
+// podName returns name of the node's pod, the only replica of the node's
+// statefulset or the replica of the node group statefulset named after the node
+func (n Node) podName() string {
+	if n.statefulSet != "" {
+		return n.name
+	}
+	return fmt.Sprintf("%s-0", n.name)
+}
+
 // Create
 func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err error) {
+	if n.helm != nil {
+		return n.installRelease(ctx, o)
+	}
+	if err := checkNetem(o); err != nil {
+		return err
+	}
+
+	// replicas of the node group statefulset have only services of their own
+	if o.StatefulSet != "" {
+		if _, _, _, err := n.setServices(ctx, o); err != nil {
+			return err
+		}
+		n.logger.Infof("node %s of statefulset %s is set in namespace %s", o.Name, o.StatefulSet, o.Namespace)
+		return
+	}
+
 	// bee configuration
 	var config bytes.Buffer
-	if err := template.Must(template.New("").Parse(configTemplate)).Execute(&config, o.Config); err != nil {
+	if err := template.Must(template.New("").Parse(orchestration.ConfigTemplate)).Execute(&config, o.Config); err != nil {
+		return err
+	}
+	beeConfig, err := orchestration.ApplyConfigTemplate(config.Bytes(), o)
+	if err != nil {
 		return err
 	}
 
@@ -134,7 +184,7 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 		Annotations: o.Annotations,
 		Labels:      o.Labels,
 		Data: map[string]string{
-			".bee.yaml": config.String(),
+			".bee.yaml": string(beeConfig),
 		},
 	}); err != nil {
 		return fmt.Errorf("set configmap in namespace %s: %w", o.Namespace, err)
@@ -189,10 +239,149 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 	}
 	n.logger.Infof("serviceaccount %s is set in namespace %s", svcAccount, o.Namespace)
 
+	// services of the node
+	portAPI, portDebug, portP2P, err := n.setServices(ctx, o)
+	if err != nil {
+		return err
+	}
+
+	// headless service
+	headlessSvc := fmt.Sprintf("%s-headless", o.Name)
+	if _, err := n.k8s.Service.Set(ctx, headlessSvc, o.Namespace, service.Options{
+		Annotations: o.Annotations,
+		Labels:      o.Labels,
+		ServiceSpec: service.Spec{
+			Ports: service.Ports{
+				{
+					AppProtocol: "TCP",
+					Name:        "api",
+					Protocol:    "TCP",
+					Port:        portAPI,
+					TargetPort:  "api",
+				},
+				{
+					AppProtocol: "TCP",
+					Name:        "debug",
+					Protocol:    "TCP",
+					Port:        portDebug,
+					TargetPort:  "debug",
+				},
+				{
+					AppProtocol: "TCP",
+					Name:        "p2p",
+					Protocol:    "TCP",
+					Port:        portP2P,
+					TargetPort:  "p2p",
+				},
+			},
+			Selector: o.Selector,
+			Type:     "ClusterIP",
+		},
+	}); err != nil {
+		return fmt.Errorf("set service in namespace %s: %w", o.Namespace, err)
+	}
+	n.logger.Infof("service %s is set in namespace %s", headlessSvc, o.Namespace)
+
+	// statefulset
+	sSet := o.Name
+	clefEnabled := o.Config.ClefSignerEnable
+	libP2PEnabled := len(o.LibP2PKey) > 0
+	swarmEnabled := len(o.SwarmKey) > 0
+
+	if _, err := n.k8s.StatefulSet.Set(ctx, sSet, o.Namespace, statefulset.Options{
+		Annotations: o.Annotations,
+		Labels:      o.Labels,
+		Spec: statefulset.StatefulSetSpec{
+			PodManagementPolicy: o.PodManagementPolicy,
+			Replicas:            0,
+			Selector:            o.Selector,
+			ServiceName:         headlessSvc,
+			Template: pod.PodTemplateSpec{
+				Name:        sSet,
+				Namespace:   o.Namespace,
+				Annotations: o.Annotations,
+				Labels:      o.Labels,
+				Spec: pod.PodSpec{
+					Affinity: setAffinity(o.Affinity),
+					InitContainers: setInitContainers(setInitContainersOptions{
+						ClefEnabled:         clefEnabled,
+						ClefSecretEnabled:   clefSecretEnabled,
+						ClefImage:           o.ClefImage,
+						ClefImagePullPolicy: o.ClefImagePullPolicy,
+						ClefPassword:        o.ClefPassword,
+						LibP2PEnabled:       libP2PEnabled,
+						SwarmEnabled:        swarmEnabled,
+						Netem:               o.Netem,
+						SecurityContext:     o.SecurityContext,
+					}),
+					Containers: setContainers(setContainersOptions{
+						Name:                             sSet,
+						Image:                            o.Image,
+						ImagePullPolicy:                  o.ImagePullPolicy,
+						PortAPI:                          portAPI,
+						PortDebug:                        portDebug,
+						PortP2P:                          portP2P,
+						PersistenceEnabled:               o.PersistenceEnabled,
+						ResourcesLimitCPU:                o.ResourcesLimitCPU,
+						ResourcesLimitEphemeralStorage:   o.ResourcesLimitEphemeralStorage,
+						ResourcesLimitMemory:             o.ResourcesLimitMemory,
+						ResourcesRequestCPU:              o.ResourcesRequestCPU,
+						ResourcesRequestEphemeralStorage: o.ResourcesRequestEphemeralStorage,
+						ResourcesRequestMemory:           o.ResourcesRequestMemory,
+						ClefEnabled:                      clefEnabled,
+						ClefSecretEnabled:                clefSecretEnabled,
+						ClefImage:                        o.ClefImage,
+						ClefImagePullPolicy:              o.ClefImagePullPolicy,
+						ClefPassword:                     o.ClefPassword,
+						LibP2PEnabled:                    libP2PEnabled,
+						SwarmEnabled:                     swarmEnabled,
+						SecurityContext:                  o.SecurityContext,
+					}),
+					NodeSelector:              o.NodeSelector,
+					PodSecurityContext:        setPodSecurityContext(o.SecurityContext),
+					RestartPolicy:             o.RestartPolicy,
+					ServiceAccountName:        svcAccount,
+					Tolerations:               setTolerations(o.Tolerations),
+					TopologySpreadConstraints: setTopologySpreadConstraints(o.TopologySpreadConstraints),
+					Volumes: setVolumes(setVolumesOptions{
+						ConfigCM:           configCM,
+						KeysSecret:         keysSecret,
+						PersistenceEnabled: o.PersistenceEnabled,
+						EmptyDirMedium:     o.EmptyDirMedium,
+						EmptyDirSizeLimit:  o.EmptyDirSizeLimit,
+						ClefEnabled:        clefEnabled,
+						ClefSecretEnabled:  clefSecretEnabled,
+						ClefSecret:         clefSecret,
+						LibP2PEnabled:      libP2PEnabled,
+						SwarmEnabled:       swarmEnabled,
+					}),
+				},
+			},
+			UpdateStrategy: statefulset.UpdateStrategy{
+				Type: o.UpdateStrategy,
+			},
+			VolumeClaimTemplates: setPersistentVolumeClaims(setPersistentVolumeClaimsOptions{
+				Enabled:        o.PersistenceEnabled,
+				StorageClass:   o.PersistenceStorageClass,
+				StorageRequest: o.PersistenceStorageRequest,
+			}),
+		},
+	}); err != nil {
+		return fmt.Errorf("set statefulset in namespace %s: %w", o.Namespace, err)
+	}
+	n.logger.Infof("statefulset %s is set in namespace %s", sSet, o.Namespace)
+
+	n.logger.Infof("node %s started in namespace %s", o.Name, o.Namespace)
+	return
+}
+
+// setServices sets API, debug API and p2p services of the node and ingresses
+// of both APIs, and returns their ports
+func (n Node) setServices(ctx context.Context, o orchestration.CreateOptions) (portAPI, portDebug, portP2P int32, err error) {
 	// api service
-	portAPI, err := parsePort(o.Config.APIAddr)
+	portAPI, err = parsePort(o.Config.APIAddr)
 	if err != nil {
-		return fmt.Errorf("parsing API port from config: %s", err)
+		return 0, 0, 0, fmt.Errorf("parsing API port from config: %s", err)
 	}
 
 	apiSvc := fmt.Sprintf("%s-api", o.Name)
@@ -213,11 +402,24 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 			Type:     "ClusterIP",
 		},
 	}); err != nil {
-		return fmt.Errorf("set service in namespace %s: %w", o.Namespace, err)
+		return 0, 0, 0, fmt.Errorf("set service in namespace %s: %w", o.Namespace, err)
 	}
 	n.logger.Infof("service %s is set in namespace %s", apiSvc, o.Namespace)
 
-	if o.IngressClass == "traefik" {
+	if o.Gateway != "" {
+		// api service's http route
+		apiIn := fmt.Sprintf("%s-api", o.Name)
+		if err := n.setHTTPRoute(ctx, apiIn, o.Namespace, setHTTPRouteOptions{
+			Annotations: mergeMaps(o.Annotations, o.IngressAnnotations),
+			Labels:      o.Labels,
+			Gateway:     o.Gateway,
+			Host:        o.IngressHost,
+			Service:     apiSvc,
+			Port:        portAPI,
+		}); err != nil {
+			return 0, 0, 0, err
+		}
+	} else if o.IngressClass == "traefik" {
 		// api service's ingressroute
 		apiIn := fmt.Sprintf("%s-api", o.Name)
 		if _, err := n.k8s.IngressRoute.Set(ctx, apiIn, o.Namespace, ingressroute.Options{
@@ -240,7 +442,7 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 				},
 			},
 		}); err != nil {
-			return fmt.Errorf("set ingressroute in namespace %s: %w", o.Namespace, err)
+			return 0, 0, 0, fmt.Errorf("set ingressroute in namespace %s: %w", o.Namespace, err)
 		}
 		n.logger.Infof("ingressroute %s is set in namespace %s", apiIn, o.Namespace)
 	} else {
@@ -251,6 +453,7 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 			Labels:      o.Labels,
 			Spec: ingress.Spec{
 				Class: o.IngressClass,
+				TLS:   setIngressTLS(o.IngressHost, o.IngressTLSSecret),
 				Rules: ingress.Rules{{
 					Host: o.IngressHost,
 					Paths: ingress.Paths{{
@@ -264,15 +467,15 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 				}},
 			},
 		}); err != nil {
-			return fmt.Errorf("set ingress in namespace %s: %w", o.Namespace, err)
+			return 0, 0, 0, fmt.Errorf("set ingress in namespace %s: %w", o.Namespace, err)
 		}
 		n.logger.Infof("ingress %s is set in namespace %s", apiIn, o.Namespace)
 	}
 
 	// debug API
-	portDebug, err := parsePort(o.Config.DebugAPIAddr)
+	portDebug, err = parsePort(o.Config.DebugAPIAddr)
 	if err != nil {
-		return fmt.Errorf("parsing Debug port from config: %s", err)
+		return 0, 0, 0, fmt.Errorf("parsing Debug port from config: %s", err)
 	}
 
 	// debug service
@@ -292,11 +495,24 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 			Type:     "ClusterIP",
 		},
 	}); err != nil {
-		return fmt.Errorf("set service in namespace %s: %w", o.Namespace, err)
+		return 0, 0, 0, fmt.Errorf("set service in namespace %s: %w", o.Namespace, err)
 	}
 	n.logger.Infof("service %s is set in namespace %s", debugSvc, o.Namespace)
 
-	if o.IngressDebugClass == "traefik" {
+	if o.Gateway != "" {
+		// debug service's http route
+		debugIn := fmt.Sprintf("%s-debug", o.Name)
+		if err := n.setHTTPRoute(ctx, debugIn, o.Namespace, setHTTPRouteOptions{
+			Annotations: mergeMaps(o.Annotations, o.IngressDebugAnnotations),
+			Labels:      o.Labels,
+			Gateway:     o.Gateway,
+			Host:        o.IngressDebugHost,
+			Service:     debugSvc,
+			Port:        portDebug,
+		}); err != nil {
+			return 0, 0, 0, err
+		}
+	} else if o.IngressDebugClass == "traefik" {
 		// debug service's ingressroute
 		debugIn := fmt.Sprintf("%s-debug", o.Name)
 		if _, err := n.k8s.IngressRoute.Set(ctx, debugIn, o.Namespace, ingressroute.Options{
@@ -319,7 +535,7 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 				},
 			},
 		}); err != nil {
-			return fmt.Errorf("set ingressroute in namespace %s: %w", o.Namespace, err)
+			return 0, 0, 0, fmt.Errorf("set ingressroute in namespace %s: %w", o.Namespace, err)
 		}
 		n.logger.Infof("ingressroute %s is set in namespace %s", debugIn, o.Namespace)
 	} else {
@@ -330,6 +546,7 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 			Labels:      o.Labels,
 			Spec: ingress.Spec{
 				Class: o.IngressDebugClass,
+				TLS:   setIngressTLS(o.IngressDebugHost, o.IngressDebugTLSSecret),
 				Rules: ingress.Rules{{
 					Host: o.IngressDebugHost,
 					Paths: ingress.Paths{{
@@ -343,22 +560,22 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 				}},
 			},
 		}); err != nil {
-			return fmt.Errorf("set ingress in namespace %s: %w", o.Namespace, err)
+			return 0, 0, 0, fmt.Errorf("set ingress in namespace %s: %w", o.Namespace, err)
 		}
 		n.logger.Infof("ingress %s is set in namespace %s", debugIn, o.Namespace)
 	}
 
 	// p2p service
-	portP2P, err := parsePort(o.Config.P2PAddr)
+	portP2P, err = parsePort(o.Config.P2PAddr)
 	if err != nil {
-		return fmt.Errorf("parsing P2P port from config: %s", err)
+		return 0, 0, 0, fmt.Errorf("parsing P2P port from config: %s", err)
 	}
 
 	var nodePortP2P int32
 	if len(o.Config.NATAddr) > 0 {
 		nodePortP2P, err = parsePort(o.Config.NATAddr)
 		if err != nil {
-			return fmt.Errorf("parsing NAT address from config: %s", err)
+			return 0, 0, 0, fmt.Errorf("parsing NAT address from config: %s", err)
 		}
 	}
 
@@ -380,133 +597,18 @@ func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err er
 			Type:     "NodePort",
 		},
 	}); err != nil {
-		return fmt.Errorf("set service in namespace %s: %w", o.Namespace, err)
+		return 0, 0, 0, fmt.Errorf("set service in namespace %s: %w", o.Namespace, err)
 	}
 	n.logger.Infof("service %s is set in namespace %s", p2pSvc, o.Namespace)
 
-	// headless service
-	headlessSvc := fmt.Sprintf("%s-headless", o.Name)
-	if _, err := n.k8s.Service.Set(ctx, headlessSvc, o.Namespace, service.Options{
-		Annotations: o.Annotations,
-		Labels:      o.Labels,
-		ServiceSpec: service.Spec{
-			Ports: service.Ports{
-				{
-					AppProtocol: "TCP",
-					Name:        "api",
-					Protocol:    "TCP",
-					Port:        portAPI,
-					TargetPort:  "api",
-				},
-				{
-					AppProtocol: "TCP",
-					Name:        "debug",
-					Protocol:    "TCP",
-					Port:        portDebug,
-					TargetPort:  "debug",
-				},
-				{
-					AppProtocol: "TCP",
-					Name:        "p2p",
-					Protocol:    "TCP",
-					Port:        portP2P,
-					TargetPort:  "p2p",
-				},
-			},
-			Selector: o.Selector,
-			Type:     "ClusterIP",
-		},
-	}); err != nil {
-		return fmt.Errorf("set service in namespace %s: %w", o.Namespace, err)
-	}
-	n.logger.Infof("service %s is set in namespace %s", headlessSvc, o.Namespace)
-
-	// statefulset
-	sSet := o.Name
-	clefEnabled := o.Config.ClefSignerEnable
-	libP2PEnabled := len(o.LibP2PKey) > 0
-	swarmEnabled := len(o.SwarmKey) > 0
-
-	if _, err := n.k8s.StatefulSet.Set(ctx, sSet, o.Namespace, statefulset.Options{
-		Annotations: o.Annotations,
-		Labels:      o.Labels,
-		Spec: statefulset.StatefulSetSpec{
-			PodManagementPolicy: o.PodManagementPolicy,
-			Replicas:            0,
-			Selector:            o.Selector,
-			ServiceName:         headlessSvc,
-			Template: pod.PodTemplateSpec{
-				Name:        sSet,
-				Namespace:   o.Namespace,
-				Annotations: o.Annotations,
-				Labels:      o.Labels,
-				Spec: pod.PodSpec{
-					InitContainers: setInitContainers(setInitContainersOptions{
-						ClefEnabled:         clefEnabled,
-						ClefSecretEnabled:   clefSecretEnabled,
-						ClefImage:           o.ClefImage,
-						ClefImagePullPolicy: o.ClefImagePullPolicy,
-						ClefPassword:        o.ClefPassword,
-						LibP2PEnabled:       libP2PEnabled,
-						SwarmEnabled:        swarmEnabled,
-					}),
-					Containers: setContainers(setContainersOptions{
-						Name:                   sSet,
-						Image:                  o.Image,
-						ImagePullPolicy:        o.ImagePullPolicy,
-						PortAPI:                portAPI,
-						PortDebug:              portDebug,
-						PortP2P:                portP2P,
-						PersistenceEnabled:     o.PersistenceEnabled,
-						ResourcesLimitCPU:      o.ResourcesLimitCPU,
-						ResourcesLimitMemory:   o.ResourcesLimitMemory,
-						ResourcesRequestCPU:    o.ResourcesRequestCPU,
-						ResourcesRequestMemory: o.ResourcesRequestMemory,
-						ClefEnabled:            clefEnabled,
-						ClefSecretEnabled:      clefSecretEnabled,
-						ClefImage:              o.ClefImage,
-						ClefImagePullPolicy:    o.ClefImagePullPolicy,
-						ClefPassword:           o.ClefPassword,
-						LibP2PEnabled:          libP2PEnabled,
-						SwarmEnabled:           swarmEnabled,
-					}),
-					NodeSelector: o.NodeSelector,
-					PodSecurityContext: pod.PodSecurityContext{
-						FSGroup: 999,
-					},
-					RestartPolicy:      o.RestartPolicy,
-					ServiceAccountName: svcAccount,
-					Volumes: setVolumes(setVolumesOptions{
-						ConfigCM:           configCM,
-						KeysSecret:         keysSecret,
-						PersistenceEnabled: o.PersistenceEnabled,
-						ClefEnabled:        clefEnabled,
-						ClefSecretEnabled:  clefSecretEnabled,
-						ClefSecret:         clefSecret,
-						LibP2PEnabled:      libP2PEnabled,
-						SwarmEnabled:       swarmEnabled,
-					}),
-				},
-			},
-			UpdateStrategy: statefulset.UpdateStrategy{
-				Type: o.UpdateStrategy,
-			},
-			VolumeClaimTemplates: setPersistentVolumeClaims(setPersistentVolumeClaimsOptions{
-				Enabled:        o.PersistenceEnabled,
-				StorageClass:   o.PersistenceStorageClass,
-				StorageRequest: o.PersistenceStorageRequest,
-			}),
-		},
-	}); err != nil {
-		return fmt.Errorf("set statefulset in namespace %s: %w", o.Namespace, err)
-	}
-	n.logger.Infof("statefulset %s is set in namespace %s", sSet, o.Namespace)
-
-	n.logger.Infof("node %s started in namespace %s", o.Name, o.Namespace)
 	return
 }
 
 func (n Node) Delete(ctx context.Context, namespace string) (err error) {
+	if n.helm != nil {
+		return n.uninstallRelease(ctx, namespace)
+	}
+
 	// statefulset
 	if err := n.k8s.StatefulSet.Delete(ctx, n.name, namespace); err != nil {
 		return fmt.Errorf("deleting statefulset in namespace %s: %w", namespace, err)
@@ -540,6 +642,12 @@ func (n Node) Delete(ctx context.Context, namespace string) (err error) {
 	}
 	n.logger.Infof("ingress route %s is deleted in namespace %s", debugIn, namespace)
 
+	// debug service's http route
+	if err := n.k8s.HTTPRoute.Delete(ctx, debugIn, namespace); err != nil {
+		return fmt.Errorf("deleting http route in namespace %s: %w", namespace, err)
+	}
+	n.logger.Infof("http route %s is deleted in namespace %s", debugIn, namespace)
+
 	// debug service
 	debugSvc := fmt.Sprintf("%s-debug", n.name)
 	if err := n.k8s.Service.Delete(ctx, debugSvc, namespace); err != nil {
@@ -560,6 +668,12 @@ func (n Node) Delete(ctx context.Context, namespace string) (err error) {
 	}
 	n.logger.Infof("ingress route %s is deleted in namespace %s", apiIn, namespace)
 
+	// api service's http route
+	if err := n.k8s.HTTPRoute.Delete(ctx, apiIn, namespace); err != nil {
+		return fmt.Errorf("deleting http route in namespace %s: %w", namespace, err)
+	}
+	n.logger.Infof("http route %s is deleted in namespace %s", apiIn, namespace)
+
 	// api service
 	apiSvc := fmt.Sprintf("%s-api", n.name)
 	if err := n.k8s.Service.Delete(ctx, apiSvc, namespace); err != nil {
@@ -599,14 +713,106 @@ func (n Node) Delete(ctx context.Context, namespace string) (err error) {
 	return
 }
 
+// Kill kills the node's pod without a grace period, the statefulset recreates it
+func (n Node) Kill(ctx context.Context, namespace string) (err error) {
+	if err := n.k8s.Pods.Kill(ctx, n.podName(), namespace); err != nil {
+		return fmt.Errorf("kill pod %s in namespace %s: %w", n.podName(), namespace, err)
+	}
+
+	n.logger.Infof("node %s is killed in namespace %s", n.name, namespace)
+	return
+}
+
+// Exec runs the command in the node's Bee container and returns its standard
+// output
+func (n Node) Exec(ctx context.Context, namespace string, command ...string) (stdout []byte, err error) {
+	stdout, err = n.k8s.Exec.Run(ctx, n.podName(), namespace, command, exec.Options{Container: "bee"})
+	if err != nil {
+		return nil, fmt.Errorf("exec in pod %s in namespace %s: %w", n.podName(), namespace, err)
+	}
+
+	return stdout, nil
+}
+
+// Logs returns logs of the node's Bee container since the time, limited to
+// the last tail lines
+func (n Node) Logs(ctx context.Context, namespace string, since time.Time, tail int64) (logs []byte, err error) {
+	logs, err = n.k8s.Pods.Logs(ctx, n.podName(), namespace, pod.LogsOptions{
+		Container: "bee",
+		SinceTime: since,
+		TailLines: tail,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("logs of pod %s in namespace %s: %w", n.podName(), namespace, err)
+	}
+
+	return logs, nil
+}
+
+// Ready returns whether the node's Bee container is ready, it does not wait
+// for the container, other replicas of the node group statefulset do not make
+// the node ready
 func (n Node) Ready(ctx context.Context, namespace string) (ready bool, err error) {
-	// r, err := n.k8s.StatefulSet.ReadyReplicas(ctx, n.name, namespace)
-	r, err := n.k8s.StatefulSet.ReadyReplicasWatch(ctx, n.name, namespace)
+	statuses, err := n.k8s.Pods.ContainerStatuses(ctx, n.podName(), namespace)
+	if err != nil {
+		return false, fmt.Errorf("pod %s in namespace %s container statuses: %w", n.podName(), namespace, err)
+	}
+	for _, s := range statuses {
+		if s.Name == "bee" {
+			return s.Ready, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Restarts returns restart state of the node's Bee container
+func (n Node) Restarts(ctx context.Context, namespace string) (restarts orchestration.NodeRestarts, err error) {
+	statuses, err := n.k8s.Pods.ContainerStatuses(ctx, n.podName(), namespace)
 	if err != nil {
-		return false, fmt.Errorf("statefulset %s in namespace %s ready replicas: %w", n.name, namespace, err)
+		return orchestration.NodeRestarts{}, fmt.Errorf("pod %s in namespace %s container statuses: %w", n.podName(), namespace, err)
 	}
 
-	return r == 1, nil
+	for _, s := range statuses {
+		if s.Name != "bee" {
+			continue
+		}
+
+		restarts.Count = s.RestartCount
+		if s.LastTerminationState.Terminated != nil {
+			restarts.Reason = s.LastTerminationState.Terminated.Reason
+		}
+		if s.State.Waiting != nil && s.State.Waiting.Reason == "CrashLoopBackOff" {
+			restarts.CrashLoop = true
+		}
+	}
+
+	return
+}
+
+// SetImage sets image of the node's Bee container and recreates its pod, so
+// the image is applied regardless of the statefulset update strategy. The
+// image of the node group statefulset is set for all of its replicas, other
+// replicas get it when they are recreated, by the update strategy.
+func (n Node) SetImage(ctx context.Context, namespace, image string) (err error) {
+	if n.helm != nil {
+		return n.setReleaseImage(ctx, namespace, image)
+	}
+
+	sSet := n.name
+	if n.statefulSet != "" {
+		sSet = n.statefulSet
+	}
+	if _, err := n.k8s.StatefulSet.SetImage(ctx, sSet, namespace, "bee", image); err != nil {
+		return fmt.Errorf("set image of statefulset %s in namespace %s: %w", sSet, namespace, err)
+	}
+
+	if err := n.k8s.Pods.Delete(ctx, n.podName(), namespace); err != nil {
+		return fmt.Errorf("delete pod %s in namespace %s: %w", n.podName(), namespace, err)
+	}
+
+	n.logger.Infof("node %s image is set to %s in namespace %s", n.name, image, namespace)
+	return
 }
 
 func (n Node) Start(ctx context.Context, namespace string) (err error) {
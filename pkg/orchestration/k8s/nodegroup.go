@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"sort"
 	"sync"
 	"time"
@@ -11,10 +12,12 @@ import (
 	"github.com/ethersphere/bee/pkg/swarm"
 	"github.com/ethersphere/beekeeper/pkg/bee"
 
+	"github.com/ethersphere/beekeeper/pkg/helm"
 	"github.com/ethersphere/beekeeper/pkg/k8s"
 	"github.com/ethersphere/beekeeper/pkg/logging"
 	"github.com/ethersphere/beekeeper/pkg/orchestration"
 	"github.com/ethersphere/beekeeper/pkg/orchestration/utils"
+	"golang.org/x/sync/errgroup"
 )
 
 const nodeRetryTimeout = 5 * time.Second
@@ -31,10 +34,12 @@ type NodeGroup struct {
 	// set when added to the cluster
 	cluster *Cluster
 	k8s     *k8s.Client
+	helm    *helm.Client
 
 	logger logging.Logger
 
-	lock sync.RWMutex
+	lock            sync.RWMutex
+	statefulSetLock sync.Mutex // serializes updates of the node group statefulset
 }
 
 // NewNodeGroup returns new node group
@@ -47,16 +52,36 @@ func NewNodeGroup(name string, o orchestration.NodeGroupOptions, logger logging.
 	}
 }
 
-// AddNode adss new node to the node group
-func (g *NodeGroup) AddNode(ctx context.Context, name string, o orchestration.NodeOptions) (err error) {
-	aURL, err := g.cluster.apiURL(name)
-	if err != nil {
-		return fmt.Errorf("API URL %s: %w", name, err)
+// namespace returns namespace of the node group nodes
+func (g *NodeGroup) namespace() string {
+	if g.opts.Namespace != "" {
+		return g.opts.Namespace
 	}
+	return g.cluster.namespace
+}
 
-	dURL, err := g.cluster.debugAPIURL(name)
-	if err != nil {
-		return fmt.Errorf("debug API URL %s: %w", name, err)
+// apiDomain returns domain of the node group API ingresses
+func (g *NodeGroup) apiDomain() string {
+	if g.opts.APIDomain != "" {
+		return g.opts.APIDomain
+	}
+	return g.cluster.apiDomain
+}
+
+// debugAPIDomain returns domain of the node group debug API ingresses
+func (g *NodeGroup) debugAPIDomain() string {
+	if g.opts.DebugAPIDomain != "" {
+		return g.opts.DebugAPIDomain
+	}
+	return g.cluster.debugAPIDomain
+}
+
+// AddNode adss new node to the node group
+func (g *NodeGroup) AddNode(ctx context.Context, name string, o orchestration.NodeOptions) (err error) {
+	if g.statefulSetMode() {
+		if err := g.checkReplica(name, o); err != nil {
+			return err
+		}
 	}
 
 	// TODO: make more granular, check every sub-option
@@ -67,24 +92,71 @@ func (g *NodeGroup) AddNode(ctx context.Context, name string, o orchestration.No
 		config = g.opts.BeeConfig
 	}
 
+	transport := g.cluster.transport
+	var aURL, dURL *url.URL
+	if g.cluster.accessMode == accessModePortForward {
+		if aURL, dURL, err = g.portForwardURLs(name, config); err != nil {
+			return fmt.Errorf("port-forward URLs %s: %w", name, err)
+		}
+		transport.DialContext = g.portForwardDialer()
+	} else {
+		if aURL, err = g.cluster.apiURL(name, g.namespace(), g.apiDomain()); err != nil {
+			return fmt.Errorf("API URL %s: %w", name, err)
+		}
+		if dURL, err = g.cluster.debugAPIURL(name, g.namespace(), g.debugAPIDomain()); err != nil {
+			return fmt.Errorf("debug API URL %s: %w", name, err)
+		}
+	}
+
+	tlsConfig, err := bee.NewTLSConfig(g.opts.APICAFile, g.opts.APICertFile, g.opts.APIKeyFile)
+	if err != nil {
+		return fmt.Errorf("node group %s TLS: %w", g.name, err)
+	}
+
 	client := bee.NewClient(bee.ClientOptions{
 		APIURL:              aURL,
-		APIInsecureTLS:      g.cluster.apiInsecureTLS,
+		APIInsecureTLS:      g.cluster.apiInsecureTLS || g.opts.APIInsecureTLS,
 		DebugAPIURL:         dURL,
-		DebugAPIInsecureTLS: g.cluster.debugAPIInsecureTLS,
-		Retry:               5,
+		DebugAPIInsecureTLS: g.cluster.debugAPIInsecureTLS || g.opts.APIInsecureTLS,
+		Retry:               g.cluster.retry,
 		Restricted:          config.Restricted,
+		RateLimiter:         g.cluster.rateLimiter,
+		NodeRateLimit:       g.cluster.nodeRateLimit,
+		NodeRateLimitBurst:  g.cluster.nodeRateLimitBurst,
+		TLSConfig:           tlsConfig,
+		CAFile:              g.opts.APICAFile,
+		CertFile:            g.opts.APICertFile,
+		KeyFile:             g.opts.APIKeyFile,
+		BearerToken:         g.opts.APIBearerToken,
+		Transport:           transport,
 	}, g.logger)
 
+	// nodes may pin their own image, like for version-matrix testing
+	image := g.opts.Image
+	if o.Image != "" {
+		image = o.Image
+	}
+
 	n := NewNode(name, orchestration.NodeOptions{
 		ClefKey:      o.ClefKey,
 		ClefPassword: o.ClefPassword,
 		Client:       client,
 		Config:       config,
+		Helm:         g.helm,
+		Image:        image,
 		K8S:          g.k8s,
 		LibP2PKey:    o.LibP2PKey,
 		SwarmKey:     o.SwarmKey,
 	}, g.logger)
+	n.chart = helm.Chart{
+		Name:    g.opts.HelmChart,
+		Repo:    g.opts.HelmChartRepo,
+		Version: g.opts.HelmChartVersion,
+	}
+	n.valuesFiles = g.opts.HelmValuesFiles
+	if g.statefulSetMode() {
+		n.statefulSet = g.name
+	}
 
 	g.addNode(n)
 
@@ -317,57 +389,116 @@ func (g *NodeGroup) CreateNode(ctx context.Context, name string) (err error) {
 		return err
 	}
 
-	if err := n.Create(ctx, orchestration.CreateOptions{
+	o := orchestration.CreateOptions{
 		// Bee configuration
 		Config: *n.Config(),
 		// Kubernetes configuration
-		Name:                      name,
-		Namespace:                 g.cluster.namespace,
-		Annotations:               g.opts.Annotations,
-		ClefImage:                 g.opts.ClefImage,
-		ClefImagePullPolicy:       g.opts.ClefImagePullPolicy,
-		ClefKey:                   n.ClefKey(),
-		ClefPassword:              n.ClefPassword(),
-		Image:                     g.opts.Image,
-		ImagePullPolicy:           g.opts.ImagePullPolicy,
-		ImagePullSecrets:          g.opts.ImagePullSecrets,
-		IngressAnnotations:        g.opts.IngressAnnotations,
-		IngressClass:              g.opts.IngressClass,
-		IngressHost:               g.cluster.ingressHost(name),
-		IngressDebugAnnotations:   g.opts.IngressDebugAnnotations,
-		IngressDebugClass:         g.opts.IngressDebugClass,
-		IngressDebugHost:          g.cluster.ingressDebugHost(name),
-		Labels:                    labels,
-		LibP2PKey:                 n.LibP2PKey(),
-		NodeSelector:              g.opts.NodeSelector,
-		PersistenceEnabled:        g.opts.PersistenceEnabled,
-		PersistenceStorageClass:   g.opts.PersistenceStorageClass,
-		PersistenceStorageRequest: g.opts.PersistenceStorageRequest,
-		PodManagementPolicy:       g.opts.PodManagementPolicy,
-		RestartPolicy:             g.opts.RestartPolicy,
-		ResourcesLimitCPU:         g.opts.ResourcesLimitCPU,
-		ResourcesLimitMemory:      g.opts.ResourcesLimitMemory,
-		ResourcesRequestCPU:       g.opts.ResourcesRequestCPU,
-		ResourcesRequestMemory:    g.opts.ResourcesRequestMemory,
-		Selector:                  labels,
-		SwarmKey:                  n.SwarmKey(),
-		UpdateStrategy:            g.opts.UpdateStrategy,
-	}); err != nil {
+		Name:                             name,
+		Namespace:                        g.namespace(),
+		Affinity:                         g.affinity(),
+		Annotations:                      g.opts.Annotations,
+		ClefImage:                        g.opts.ClefImage,
+		ClefImagePullPolicy:              g.opts.ClefImagePullPolicy,
+		ClefKey:                          n.ClefKey(),
+		ClefPassword:                     n.ClefPassword(),
+		Gateway:                          g.opts.Gateway,
+		Image:                            n.Image(),
+		ImagePullPolicy:                  g.opts.ImagePullPolicy,
+		ImagePullSecrets:                 g.opts.ImagePullSecrets,
+		IngressAnnotations:               g.opts.IngressAnnotations,
+		IngressClass:                     g.opts.IngressClass,
+		IngressHost:                      g.cluster.ingressHost(name, g.namespace(), g.apiDomain()),
+		IngressTLSSecret:                 g.opts.IngressTLSSecret,
+		IngressDebugAnnotations:          g.opts.IngressDebugAnnotations,
+		IngressDebugClass:                g.opts.IngressDebugClass,
+		IngressDebugHost:                 g.cluster.ingressDebugHost(name, g.namespace(), g.debugAPIDomain()),
+		IngressDebugTLSSecret:            g.opts.IngressDebugTLSSecret,
+		Labels:                           g.podLabels(labels),
+		LibP2PKey:                        n.LibP2PKey(),
+		NodeSelector:                     g.opts.NodeSelector,
+		EmptyDirMedium:                   g.opts.EmptyDirMedium,
+		EmptyDirSizeLimit:                g.opts.EmptyDirSizeLimit,
+		Netem:                            g.opts.Netem,
+		PersistenceEnabled:               g.opts.PersistenceEnabled,
+		PersistenceStorageClass:          g.opts.PersistenceStorageClass,
+		PersistenceStorageRequest:        g.opts.PersistenceStorageRequest,
+		PodManagementPolicy:              g.opts.PodManagementPolicy,
+		RestartPolicy:                    g.opts.RestartPolicy,
+		ResourcesLimitCPU:                g.opts.ResourcesLimitCPU,
+		ResourcesLimitEphemeralStorage:   g.opts.ResourcesLimitEphemeralStorage,
+		ResourcesLimitMemory:             g.opts.ResourcesLimitMemory,
+		ResourcesRequestCPU:              g.opts.ResourcesRequestCPU,
+		ResourcesRequestEphemeralStorage: g.opts.ResourcesRequestEphemeralStorage,
+		ResourcesRequestMemory:           g.opts.ResourcesRequestMemory,
+		SecurityContext:                  g.opts.SecurityContext,
+		Selector:                         labels,
+		SwarmKey:                         n.SwarmKey(),
+		Tolerations:                      g.opts.Tolerations,
+		TopologySpreadConstraints:        g.topologySpreadConstraints(),
+		UpdateStrategy:                   g.opts.UpdateStrategy,
+	}
+	if g.statefulSetMode() {
+		// services of the node select the pod of its replica
+		o.Selector = map[string]string{"statefulset.kubernetes.io/pod-name": name}
+		o.StatefulSet = g.name
+	}
+
+	if err := n.Create(ctx, o); err != nil {
 		return err
 	}
 
+	if g.statefulSetMode() {
+		return g.setStatefulSet(ctx, o)
+	}
+
 	return
 }
 
 // DeleteNode deletes node from the k8s cluster and removes it from the node group
 func (g *NodeGroup) DeleteNode(ctx context.Context, name string) (err error) {
-	n := NewNode(name, orchestration.NodeOptions{K8S: g.k8s}, g.logger)
-	if err := n.Delete(ctx, g.cluster.namespace); err != nil {
+	// replicas above the deleted one are stopped with it
+	if g.statefulSetMode() {
+		if err := g.stopReplica(ctx, name, true); err != nil {
+			return err
+		}
+	}
+
+	n := NewNode(name, orchestration.NodeOptions{Helm: g.helm, K8S: g.k8s}, g.logger)
+	if err := n.Delete(ctx, g.namespace()); err != nil {
 		return err
 	}
 
 	g.deleteNode(name)
 
+	if g.opts.PersistenceEnabled && g.opts.PersistenceRetentionPolicy == "Delete" {
+		if err := g.DeleteNodeVolumeClaim(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	// the statefulset has no replicas left without the first one
+	if g.statefulSetMode() {
+		if ordinal, _ := g.replicaOrdinal(name); ordinal == 0 {
+			if err := g.deleteStatefulSet(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return
+}
+
+// DeleteNodeVolumeClaim deletes the persistent volume claim of the node's data
+func (g *NodeGroup) DeleteNodeVolumeClaim(ctx context.Context, name string) (err error) {
+	pvcName := fmt.Sprintf("data-%s-0", name)
+	if g.statefulSetMode() {
+		pvcName = fmt.Sprintf("data-%s", name)
+	}
+
+	if err := g.k8s.PVC.Delete(ctx, pvcName, g.namespace()); err != nil {
+		return fmt.Errorf("deleting pvc %s: %w", pvcName, err)
+	}
+
 	return
 }
 
@@ -389,7 +520,11 @@ func (g *NodeGroup) Fund(ctx context.Context, name string, o orchestration.NodeO
 					if retries == 0 {
 						return fmt.Errorf("get %s address: %w", name, err)
 					}
-					time.Sleep(nodeRetryTimeout)
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case <-time.After(nodeRetryTimeout):
+					}
 					continue
 				}
 				break
@@ -407,7 +542,11 @@ func (g *NodeGroup) Fund(ctx context.Context, name string, o orchestration.NodeO
 				if retries == 0 {
 					return fmt.Errorf("send eth: %w", err)
 				}
-				time.Sleep(nodeRetryTimeout)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(nodeRetryTimeout):
+				}
 				continue
 			}
 			g.logger.Infof("%s funded with %.2f ETH, transaction: %s", name, f.Eth, tx)
@@ -424,7 +563,11 @@ func (g *NodeGroup) Fund(ctx context.Context, name string, o orchestration.NodeO
 				if retries == 0 {
 					return fmt.Errorf("send eth: %w", err)
 				}
-				time.Sleep(nodeRetryTimeout)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(nodeRetryTimeout):
+				}
 				continue
 			}
 			g.logger.Infof("%s funded with %.2f BZZ, transaction: %s", name, f.Bzz, tx)
@@ -441,7 +584,11 @@ func (g *NodeGroup) Fund(ctx context.Context, name string, o orchestration.NodeO
 				if retries == 0 {
 					return fmt.Errorf("send eth: %w", err)
 				}
-				time.Sleep(nodeRetryTimeout)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(nodeRetryTimeout):
+				}
 				continue
 			}
 			g.logger.Infof("%s funded with %.2f gBZZ, transaction: %s", name, f.GBzz, tx)
@@ -518,6 +665,38 @@ func (g *NodeGroup) HasChunkStream(ctx context.Context, a swarm.Address) (<-chan
 	return hasChunkStream, nil
 }
 
+// KillNode kills node's pod, simulating an unclean shutdown
+func (g *NodeGroup) KillNode(ctx context.Context, name string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	return n.Kill(ctx, g.namespace())
+}
+
+// NodeExec runs the command in the Bee container of the node and returns its
+// standard output
+func (g *NodeGroup) NodeExec(ctx context.Context, name string, command ...string) (stdout []byte, err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.Exec(ctx, g.namespace(), command...)
+}
+
+// NodeLogs returns logs of the node since the time, limited to the last tail
+// lines
+func (g *NodeGroup) NodeLogs(ctx context.Context, name string, since time.Time, tail int64) (logs []byte, err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.Logs(ctx, g.namespace(), since, tail)
+}
+
 // Name returns name of the node group
 func (g *NodeGroup) Name() string {
 	return g.name
@@ -713,7 +892,7 @@ func (g *NodeGroup) NodeReady(ctx context.Context, name string) (ok bool, err er
 		return false, err
 	}
 
-	return n.Ready(ctx, g.cluster.namespace)
+	return n.Ready(ctx, g.namespace())
 }
 
 // PregenerateSwarmKey for a node if needed
@@ -780,12 +959,28 @@ func (g *NodeGroup) PregenerateSwarmKey(ctx context.Context, name string) (err e
 	return
 }
 
+// Restarts returns NodeGroupRestarts
+func (g *NodeGroup) Restarts(ctx context.Context) (restarts orchestration.NodeGroupRestarts, err error) {
+	restarts = make(orchestration.NodeGroupRestarts)
+
+	for name, n := range g.getNodes() {
+		r, err := n.Restarts(ctx, g.namespace())
+		if err != nil {
+			return nil, fmt.Errorf("node %s: %w", name, err)
+		}
+
+		restarts[name] = r
+	}
+
+	return
+}
+
 // RunningNodes returns list of running nodes
 // TODO: filter by labels
 func (g *NodeGroup) RunningNodes(ctx context.Context) (running []string, err error) {
-	running, err = g.k8s.StatefulSet.RunningStatefulSets(ctx, g.cluster.namespace)
+	running, err = g.k8s.StatefulSet.RunningStatefulSets(ctx, g.namespace())
 	if err != nil {
-		return nil, fmt.Errorf("running statefulsets in namespace %s: %w", g.cluster.namespace, err)
+		return nil, fmt.Errorf("running statefulsets in namespace %s: %w", g.namespace(), err)
 	}
 
 	for _, v := range running {
@@ -797,6 +992,39 @@ func (g *NodeGroup) RunningNodes(ctx context.Context) (running []string, err err
 	return
 }
 
+// SetNodeImage sets image of the node and waits until it is ready again
+func (g *NodeGroup) SetNodeImage(ctx context.Context, name, image string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	if err := n.SetImage(ctx, g.namespace(), image); err != nil {
+		return err
+	}
+	g.setImage(name, image)
+
+	g.logger.Infof("wait for %s to become ready", name)
+	for {
+		ok, err := g.NodeReady(ctx, name)
+		if err != nil {
+			return fmt.Errorf("node %s readiness: %w", name, err)
+		}
+
+		if ok {
+			g.logger.Infof("%s is ready", name)
+			return nil
+		}
+
+		g.logger.Infof("%s is not ready yet", name)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(nodeRetryTimeout):
+		}
+	}
+}
+
 // SetupNode creates new node in the node group, starts it in the k8s cluster and funds it
 func (g *NodeGroup) SetupNode(ctx context.Context, name string, o orchestration.NodeOptions, f orchestration.FundingOptions) (err error) {
 	g.logger.Infof("starting setup node: %s", name)
@@ -824,6 +1052,39 @@ func (g *NodeGroup) SetupNode(ctx context.Context, name string, o orchestration.
 	return
 }
 
+// scale sets up nodes the node group lacks or deletes nodes above the number
+// of replicas
+func (g *NodeGroup) scale(ctx context.Context, replicas int, f orchestration.FundingOptions) (err error) {
+	if replicas < 0 {
+		return fmt.Errorf("node group %s: negative number of replicas %d", g.name, replicas)
+	}
+
+	add, remove := utils.ScaleNames(g.name, g.NodesSorted(), replicas)
+	if len(add) > 0 && g.opts.BeeConfig == nil {
+		return fmt.Errorf("node group %s: nodes are added to node groups with bee config only", g.name)
+	}
+
+	errGroup := new(errgroup.Group)
+	for _, name := range add {
+		name := name
+		errGroup.Go(func() error {
+			return g.SetupNode(ctx, name, orchestration.NodeOptions{}, f)
+		})
+	}
+	for _, name := range remove {
+		name := name
+		errGroup.Go(func() error {
+			return g.DeleteNode(ctx, name)
+		})
+	}
+	if err := errGroup.Wait(); err != nil {
+		return fmt.Errorf("scale node group %s: %w", g.name, err)
+	}
+
+	g.logger.Infof("node group %s is scaled to %d nodes, %d added and %d deleted", g.name, replicas, len(add), len(remove))
+	return
+}
+
 // Settlements returns NodeGroupSettlements
 func (g *NodeGroup) Settlements(ctx context.Context) (settlements orchestration.NodeGroupSettlements, err error) {
 	stream, err := g.SettlementsStream(ctx)
@@ -907,14 +1168,20 @@ func (g *NodeGroup) Size() int {
 	return len(g.nodes)
 }
 
-// StartNode start node by scaling its statefulset to 1
+// StartNode start node by scaling its statefulset to 1, or the node group
+// statefulset up to its replica
 func (g *NodeGroup) StartNode(ctx context.Context, name string) (err error) {
 	n, err := g.getNode(name)
 	if err != nil {
 		return err
 	}
 
-	if err := n.Start(ctx, g.cluster.namespace); err != nil {
+	if g.statefulSetMode() {
+		err = g.startReplica(ctx, name)
+	} else {
+		err = n.Start(ctx, g.namespace())
+	}
+	if err != nil {
 		return err
 	}
 
@@ -935,14 +1202,20 @@ func (g *NodeGroup) StartNode(ctx context.Context, name string) (err error) {
 	}
 }
 
-// StopNode stops node by scaling down its statefulset to 0
+// StopNode stops node by scaling down its statefulset to 0, or the node group
+// statefulset below its replica
 func (g *NodeGroup) StopNode(ctx context.Context, name string) (err error) {
 	n, err := g.getNode(name)
 	if err != nil {
 		return err
 	}
 
-	if err := n.Stop(ctx, g.cluster.namespace); err != nil {
+	if g.statefulSetMode() {
+		err = g.stopReplica(ctx, name, false)
+	} else {
+		err = n.Stop(ctx, g.namespace())
+	}
+	if err != nil {
 		return err
 	}
 
@@ -966,9 +1239,9 @@ func (g *NodeGroup) StopNode(ctx context.Context, name string) (err error) {
 // StoppedNodes returns list of stopped nodes
 // TODO: filter by labels
 func (g *NodeGroup) StoppedNodes(ctx context.Context) (stopped []string, err error) {
-	allStopped, err := g.k8s.StatefulSet.StoppedStatefulSets(ctx, g.cluster.namespace)
+	allStopped, err := g.k8s.StatefulSet.StoppedStatefulSets(ctx, g.namespace())
 	if err != nil {
-		return nil, fmt.Errorf("stopped statefulsets in namespace %s: %w", g.cluster.namespace, err)
+		return nil, fmt.Errorf("stopped statefulsets in namespace %s: %w", g.namespace(), err)
 	}
 
 	for _, v := range allStopped {
@@ -1051,6 +1324,21 @@ func (g *NodeGroup) addNode(n *Node) {
 	g.lock.Unlock()
 }
 
+// setImage records the image the node runs with
+func (g *NodeGroup) setImage(name, image string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	// nodes with keys set are stored by value
+	switch n := g.nodes[name].(type) {
+	case *Node:
+		n.image = image
+	case Node:
+		n.image = image
+		g.nodes[name] = n
+	}
+}
+
 func (g *NodeGroup) deleteNode(name string) {
 	g.lock.Lock()
 	delete(g.nodes, name)
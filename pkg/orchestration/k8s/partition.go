@@ -0,0 +1,147 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s"
+	"github.com/ethersphere/beekeeper/pkg/k8s/networkpolicy"
+)
+
+const (
+	// partitionLabel labels network policies partitioning the cluster, so
+	// they are deleted when it is healed
+	partitionLabel = "beekeeper.ethersphere.io/partition"
+	// podNameLabel is the label of pods of statefulsets with the pod name
+	podNameLabel = "statefulset.kubernetes.io/pod-name"
+)
+
+// Partition splits nodes of the cluster into the groups of node names, nodes
+// not in any group form another one, by network policies allowing ingress of
+// pods of nodes only from pods of nodes of their group and from pods that are
+// not nodes, like ingress controllers. Policies of a previous partition are
+// replaced. It requires a network plugin enforcing network policies.
+func (c *Cluster) Partition(ctx context.Context, groups [][]string) (err error) {
+	if err := c.Heal(ctx); err != nil {
+		return err
+	}
+
+	podGroups := make(map[string]int) // groups of pods of nodes by pod names
+	var pods [][]string               // names of pods of nodes of groups
+	var rest []string                 // names of pods of nodes not in any group
+	for i, names := range groups {
+		pods = append(pods, nil)
+		for _, name := range names {
+			n, err := c.node(name)
+			if err != nil {
+				return err
+			}
+			podGroups[n.podName()] = i
+			pods[i] = append(pods[i], n.podName())
+		}
+	}
+	for _, g := range c.nodeGroups {
+		for _, n := range g.getNodes() {
+			if n, ok := n.(*Node); ok {
+				if _, ok := podGroups[n.podName()]; !ok {
+					podGroups[n.podName()] = len(groups)
+					rest = append(rest, n.podName())
+				}
+			}
+		}
+	}
+	if len(rest) > 0 {
+		pods = append(pods, rest)
+	}
+	for _, l := range pods {
+		sort.Strings(l)
+	}
+
+	allNamespaces := networkpolicy.Selector{}
+	for _, g := range c.nodeGroups {
+		for _, n := range g.getNodes() {
+			n, ok := n.(*Node)
+			if !ok {
+				continue
+			}
+			pod := n.podName()
+
+			name := fmt.Sprintf("partition-%s", pod)
+			if _, err := g.k8s.NetworkPolicy.Set(ctx, name, g.namespace(), networkpolicy.Options{
+				Labels: map[string]string{partitionLabel: "true"},
+				Spec: networkpolicy.Spec{
+					PodSelector: networkpolicy.Selector{MatchLabels: map[string]string{podNameLabel: pod}},
+					Ingress: networkpolicy.IngressRules{{
+						From: networkpolicy.Peers{
+							{
+								PodSelector: networkpolicy.Selector{MatchExpressions: []networkpolicy.SelectorRequirement{{
+									Key:      podNameLabel,
+									Operator: "In",
+									Values:   pods[podGroups[pod]],
+								}}},
+								NamespaceSelector: &allNamespaces,
+							},
+							{
+								PodSelector: networkpolicy.Selector{MatchExpressions: []networkpolicy.SelectorRequirement{{
+									Key:      nodeGroupLabel,
+									Operator: "DoesNotExist",
+								}}},
+								NamespaceSelector: &allNamespaces,
+							},
+						},
+					}},
+				},
+			}); err != nil {
+				return fmt.Errorf("partition node %s: %w", n.Name(), err)
+			}
+		}
+	}
+
+	c.logger.Infof("cluster %s is partitioned into %d groups", c.name, len(pods))
+	return
+}
+
+// Heal deletes network policies partitioning the cluster, in namespaces of
+// node groups of all kubernetes clusters
+func (c *Cluster) Heal(ctx context.Context) (err error) {
+	for _, ns := range c.namespaces() {
+		if err := ns.k8s.NetworkPolicy.DeleteSelected(ctx, ns.name, map[string]string{partitionLabel: "true"}); err != nil {
+			return fmt.Errorf("heal partition: %w", err)
+		}
+	}
+
+	return
+}
+
+// clusterNamespace represents namespace of node groups in the kubernetes
+// cluster of the client
+type clusterNamespace struct {
+	k8s  *k8s.Client
+	name string
+}
+
+// namespaces returns namespaces of node groups of the cluster
+func (c *Cluster) namespaces() (l []clusterNamespace) {
+	seen := make(map[clusterNamespace]bool)
+	for _, g := range c.nodeGroups {
+		ns := clusterNamespace{k8s: g.k8s, name: g.namespace()}
+		if !seen[ns] {
+			seen[ns] = true
+			l = append(l, ns)
+		}
+	}
+	return
+}
+
+// node returns the node of the cluster
+func (c *Cluster) node(name string) (*Node, error) {
+	for _, g := range c.nodeGroups {
+		if n, ok := g.getNodes()[name]; ok {
+			if n, ok := n.(*Node); ok {
+				return n, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("node %s not found", name)
+}
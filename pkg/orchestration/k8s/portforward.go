@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// accessModePortForward is the access mode in which APIs of nodes are reached
+// through port-forwards of the kubernetes API, instead of through ingresses
+const accessModePortForward = "port-forward"
+
+// portForwardURLs returns URLs of the API and the debug API of the node in the
+// port-forward access mode, with hosts of the node pod in the node group
+// namespace, that are resolved by the port-forward dialer only
+func (g *NodeGroup) portForwardURLs(name string, config *orchestration.Config) (aURL, dURL *url.URL, err error) {
+	pod := fmt.Sprintf("%s-0", name)
+	if g.statefulSetMode() {
+		pod = name
+	}
+
+	apiPort, err := parsePort(config.APIAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing API port: %w", err)
+	}
+	debugAPIPort, err := parsePort(config.DebugAPIAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing debug API port: %w", err)
+	}
+
+	if aURL, err = url.Parse(fmt.Sprintf("http://%s.%s:%d", pod, g.namespace(), apiPort)); err != nil {
+		return nil, nil, fmt.Errorf("bad API url for node %s: %w", name, err)
+	}
+	if dURL, err = url.Parse(fmt.Sprintf("http://%s.%s:%d", pod, g.namespace(), debugAPIPort)); err != nil {
+		return nil, nil, fmt.Errorf("bad debug API url for node %s: %w", name, err)
+	}
+	return
+}
+
+// portForwardDialer returns the dialer of connections to pods of the node
+// group, with addresses of port-forward URLs, through port-forwards of the
+// kubernetes API of the node group
+func (g *NodeGroup) portForwardDialer() func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, p, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("port-forward address %s: %w", addr, err)
+		}
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("port-forward address %s: %w", addr, err)
+		}
+		pod, namespace, ok := strings.Cut(host, ".")
+		if !ok {
+			return nil, fmt.Errorf("port-forward address %s: no namespace", addr)
+		}
+		return g.k8s.PortForward.Dial(ctx, pod, namespace, port)
+	}
+}
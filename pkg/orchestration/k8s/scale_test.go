@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+func TestNodeGroupScale(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		replicas int
+		wantErr  string
+	}{
+		{name: "unchanged", replicas: 2},
+		{name: "negative", replicas: -1, wantErr: "negative number of replicas -1"},
+		// nodes are set up from the bee config of the node group
+		{name: "grow without bee config", replicas: 3, wantErr: "nodes are added to node groups with bee config only"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewNodeGroup("bee", orchestration.NodeGroupOptions{}, logging.New(io.Discard, 0, ""))
+			g.nodes["bee-0"] = nil
+			g.nodes["bee-1"] = nil
+
+			err := g.scale(context.Background(), tc.replicas, orchestration.FundingOptions{})
+			if tc.wantErr == "" && err != nil || tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Errorf("got error %v, want %q", err, tc.wantErr)
+			}
+			if got := g.NodesSorted(); len(got) != 2 {
+				t.Errorf("got nodes %v, want the nodes unchanged", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package k8s
+
+import (
+	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// nodeGroupLabel labels pods of nodes with the name of their node group, pods
+// of the node group are selected by it by scheduling constraints without a
+// label selector
+const nodeGroupLabel = "beekeeper.ethersphere.io/node-group"
+
+// podLabels returns labels of pods of the node group nodes
+func (g *NodeGroup) podLabels(labels map[string]string) map[string]string {
+	return mergeMaps(labels, map[string]string{nodeGroupLabel: g.name})
+}
+
+// affinity returns affinity of pods of the node group nodes, pod affinity
+// terms without a label selector select pods of the node group
+func (g *NodeGroup) affinity() orchestration.Affinity {
+	return orchestration.Affinity{
+		Node:    g.opts.Affinity.Node,
+		Pod:     g.podAffinityTerms(g.opts.Affinity.Pod),
+		PodAnti: g.podAffinityTerms(g.opts.Affinity.PodAnti),
+	}
+}
+
+// podAffinityTerms returns the terms with label selectors of pods of the node
+// group set, if they have none
+func (g *NodeGroup) podAffinityTerms(terms []orchestration.PodAffinityTerm) (l []orchestration.PodAffinityTerm) {
+	for _, t := range terms {
+		if len(t.LabelSelector) == 0 {
+			t.LabelSelector = map[string]string{nodeGroupLabel: g.name}
+		}
+		l = append(l, t)
+	}
+	return
+}
+
+// topologySpreadConstraints returns topology spread constraints of pods of the
+// node group nodes, constraints without a label selector spread pods of the
+// node group
+func (g *NodeGroup) topologySpreadConstraints() (l []orchestration.TopologySpreadConstraint) {
+	for _, c := range g.opts.TopologySpreadConstraints {
+		if len(c.LabelSelector) == 0 {
+			c.LabelSelector = map[string]string{nodeGroupLabel: g.name}
+		}
+		l = append(l, c)
+	}
+	return
+}
+
+// setAffinity converts affinity of pods of nodes to the pod affinity
+func setAffinity(a orchestration.Affinity) (affinity pod.Affinity) {
+	if len(a.Node) > 0 {
+		var requirements pod.NodeSelectorRequirements
+		for _, r := range a.Node {
+			requirements = append(requirements, pod.NodeSelectorRequirement{
+				Key:      r.Key,
+				Operator: r.Operator,
+				Values:   r.Values,
+			})
+		}
+		affinity.NodeAffinity = &pod.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: pod.NodeSelector{
+				NodeSelectorTerms: pod.NodeSelectorTerms{{MatchExpressions: requirements}},
+			},
+		}
+	}
+
+	if len(a.Pod) > 0 {
+		required, preferred := setPodAffinityTerms(a.Pod)
+		affinity.PodAffinity = &pod.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+
+	if len(a.PodAnti) > 0 {
+		required, preferred := setPodAffinityTerms(a.PodAnti)
+		affinity.PodAntiAffinity = &pod.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+
+	return
+}
+
+// setPodAffinityTerms converts pod affinity terms to required terms and to
+// preferred ones, the ones with a weight
+func setPodAffinityTerms(terms []orchestration.PodAffinityTerm) (required pod.PodAffinityTerms, preferred pod.WeightedPodAffinityTerms) {
+	for _, t := range terms {
+		term := pod.PodAffinityTerm{
+			LabelSelector: t.LabelSelector,
+			TopologyKey:   t.TopologyKey,
+		}
+		if t.Weight > 0 {
+			preferred = append(preferred, pod.WeightedPodAffinityTerm{
+				PodAffinityTerm: term,
+				Weight:          t.Weight,
+			})
+			continue
+		}
+		required = append(required, term)
+	}
+	return
+}
+
+// setTolerations converts tolerations of pods of nodes to pod tolerations
+func setTolerations(ts []orchestration.Toleration) (tolerations pod.Tolerations) {
+	for _, t := range ts {
+		tolerations = append(tolerations, pod.Toleration{
+			Key:               t.Key,
+			Operator:          t.Operator,
+			Value:             t.Value,
+			Effect:            t.Effect,
+			TolerationSeconds: t.TolerationSeconds,
+		})
+	}
+	return
+}
+
+// setTopologySpreadConstraints converts topology spread constraints of pods
+// of nodes to pod topology spread constraints
+func setTopologySpreadConstraints(cs []orchestration.TopologySpreadConstraint) (constraints pod.TopologySpreadConstraints) {
+	for _, c := range cs {
+		maxSkew := c.MaxSkew
+		if maxSkew == 0 {
+			maxSkew = 1
+		}
+		whenUnsatisfiable := c.WhenUnsatisfiable
+		if whenUnsatisfiable == "" {
+			whenUnsatisfiable = "DoNotSchedule"
+		}
+		constraints = append(constraints, pod.TopologySpreadConstraint{
+			MaxSkew:           maxSkew,
+			TopologyKey:       c.TopologyKey,
+			WhenUnsatisfiable: whenUnsatisfiable,
+			LabelSelector:     c.LabelSelector,
+		})
+	}
+	return
+}
+
+// schedulingValues sets affinity, tolerations and topology spread constraints
+// values of the bee chart, in the format of the kubernetes pod spec
+func schedulingValues(values map[string]interface{}, o orchestration.CreateOptions) {
+	affinity := make(map[string]interface{})
+	if len(o.Affinity.Node) > 0 {
+		var expressions []map[string]interface{}
+		for _, r := range o.Affinity.Node {
+			expressions = append(expressions, map[string]interface{}{
+				"key":      r.Key,
+				"operator": r.Operator,
+				"values":   r.Values,
+			})
+		}
+		affinity["nodeAffinity"] = map[string]interface{}{
+			"requiredDuringSchedulingIgnoredDuringExecution": map[string]interface{}{
+				"nodeSelectorTerms": []map[string]interface{}{{"matchExpressions": expressions}},
+			},
+		}
+	}
+	if len(o.Affinity.Pod) > 0 {
+		affinity["podAffinity"] = podAffinityValues(o.Affinity.Pod)
+	}
+	if len(o.Affinity.PodAnti) > 0 {
+		affinity["podAntiAffinity"] = podAffinityValues(o.Affinity.PodAnti)
+	}
+	if len(affinity) > 0 {
+		values["affinity"] = affinity
+	}
+
+	if len(o.Tolerations) > 0 {
+		var tolerations []map[string]interface{}
+		for _, t := range o.Tolerations {
+			toleration := map[string]interface{}{
+				"key":      t.Key,
+				"operator": t.Operator,
+				"value":    t.Value,
+				"effect":   t.Effect,
+			}
+			if t.TolerationSeconds > 0 {
+				toleration["tolerationSeconds"] = t.TolerationSeconds
+			}
+			tolerations = append(tolerations, toleration)
+		}
+		values["tolerations"] = tolerations
+	}
+
+	if len(o.TopologySpreadConstraints) > 0 {
+		var constraints []map[string]interface{}
+		for _, c := range setTopologySpreadConstraints(o.TopologySpreadConstraints) {
+			constraints = append(constraints, map[string]interface{}{
+				"maxSkew":           c.MaxSkew,
+				"topologyKey":       c.TopologyKey,
+				"whenUnsatisfiable": c.WhenUnsatisfiable,
+				"labelSelector":     map[string]interface{}{"matchLabels": c.LabelSelector},
+			})
+		}
+		values["topologySpreadConstraints"] = constraints
+	}
+}
+
+// podAffinityValues returns values of pod affinity or anti-affinity of the
+// terms
+func podAffinityValues(terms []orchestration.PodAffinityTerm) map[string]interface{} {
+	var required, preferred []map[string]interface{}
+	for _, t := range terms {
+		term := map[string]interface{}{
+			"labelSelector": map[string]interface{}{"matchLabels": t.LabelSelector},
+			"topologyKey":   t.TopologyKey,
+		}
+		if t.Weight > 0 {
+			preferred = append(preferred, map[string]interface{}{
+				"podAffinityTerm": term,
+				"weight":          t.Weight,
+			})
+			continue
+		}
+		required = append(required, term)
+	}
+
+	v := make(map[string]interface{})
+	if len(required) > 0 {
+		v["requiredDuringSchedulingIgnoredDuringExecution"] = required
+	}
+	if len(preferred) > 0 {
+		v["preferredDuringSchedulingIgnoredDuringExecution"] = preferred
+	}
+	return v
+}
@@ -0,0 +1,122 @@
+package k8s
+
+import (
+	"github.com/ethersphere/beekeeper/pkg/k8s/containers"
+	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// beeUser is the user and the group of the bee image
+const beeUser int64 = 999
+
+// setPodSecurityContext returns security context of pods of nodes, volumes are
+// owned by the bee group unless IDs are assigned by the cluster
+func setPodSecurityContext(sc orchestration.SecurityContext) (psc pod.PodSecurityContext) {
+	psc.RunAsNonRoot = runAsNonRoot(sc)
+	psc.SeccompProfile = pod.SeccompProfile{
+		Type:             seccompProfile(sc),
+		LocalhostProfile: sc.SeccompLocalhostProfile,
+	}
+	if !sc.ClusterAssignedIDs {
+		fsGroup := beeUser
+		if sc.FSGroup != nil {
+			fsGroup = *sc.FSGroup
+		}
+		psc.FSGroup = &fsGroup
+		psc.RunAsGroup = sc.RunAsGroup
+	}
+	return
+}
+
+// setSecurityContext returns security context of the bee container, it runs
+// as the bee user unless IDs are assigned by the cluster
+func setSecurityContext(sc orchestration.SecurityContext) (csc containers.SecurityContext) {
+	csc.AllowPrivilegeEscalation = false
+	csc.ReadOnlyRootFilesystem = sc.ReadOnlyRootFilesystem != nil && *sc.ReadOnlyRootFilesystem
+	csc.RunAsNonRoot = runAsNonRoot(sc)
+	if sc.Restricted {
+		csc.Capabilities = containers.Capabilities{Drop: []string{"ALL"}}
+	}
+	if !sc.ClusterAssignedIDs {
+		runAsUser := beeUser
+		if sc.RunAsUser != nil {
+			runAsUser = *sc.RunAsUser
+		}
+		csc.RunAsUser = &runAsUser
+		csc.RunAsGroup = sc.RunAsGroup
+	}
+	return
+}
+
+// setSidecarSecurityContext returns security context of init and clef
+// containers, they run as the bee container only if containers are required
+// to run as non-root, as users of their images otherwise
+func setSidecarSecurityContext(sc orchestration.SecurityContext) containers.SecurityContext {
+	if !nonRoot(sc) {
+		return containers.SecurityContext{}
+	}
+	return setSecurityContext(sc)
+}
+
+// nonRoot returns true if containers are required to run as non-root
+func nonRoot(sc orchestration.SecurityContext) bool {
+	return sc.Restricted || (sc.RunAsNonRoot != nil && *sc.RunAsNonRoot)
+}
+
+// runAsNonRoot returns runAsNonRoot of security contexts, nil if it is left
+// to the cluster, explicit false is kept unless the context is restricted
+func runAsNonRoot(sc orchestration.SecurityContext) *bool {
+	if sc.Restricted {
+		v := true
+		return &v
+	}
+	return sc.RunAsNonRoot
+}
+
+// seccompProfile returns type of the seccomp profile, RuntimeDefault for
+// restricted security contexts without one
+func seccompProfile(sc orchestration.SecurityContext) string {
+	if sc.SeccompProfile == "" && sc.Restricted {
+		return "RuntimeDefault"
+	}
+	return sc.SeccompProfile
+}
+
+// securityValues sets pod and container security context values of the bee
+// chart, in the format of the kubernetes pod spec
+func securityValues(values map[string]interface{}, sc orchestration.SecurityContext) {
+	podSecurityContext := make(map[string]interface{})
+	securityContext := map[string]interface{}{
+		"allowPrivilegeEscalation": false,
+	}
+
+	if v := runAsNonRoot(sc); v != nil {
+		podSecurityContext["runAsNonRoot"] = *v
+		securityContext["runAsNonRoot"] = *v
+	}
+	if profile := seccompProfile(sc); profile != "" {
+		seccomp := map[string]interface{}{"type": profile}
+		if sc.SeccompLocalhostProfile != "" {
+			seccomp["localhostProfile"] = sc.SeccompLocalhostProfile
+		}
+		podSecurityContext["seccompProfile"] = seccomp
+	}
+	if sc.Restricted {
+		securityContext["capabilities"] = map[string]interface{}{"drop": []string{"ALL"}}
+	}
+	if sc.ReadOnlyRootFilesystem != nil {
+		securityContext["readOnlyRootFilesystem"] = *sc.ReadOnlyRootFilesystem
+	}
+	if !sc.ClusterAssignedIDs {
+		psc, csc := setPodSecurityContext(sc), setSecurityContext(sc)
+		podSecurityContext["fsGroup"] = *psc.FSGroup
+		securityContext["runAsUser"] = *csc.RunAsUser
+		if sc.RunAsGroup != nil {
+			podSecurityContext["runAsGroup"] = *sc.RunAsGroup
+			securityContext["runAsGroup"] = *sc.RunAsGroup
+		}
+	}
+
+	values["podSecurityContext"] = podSecurityContext
+	values["securityContext"] = securityContext
+}
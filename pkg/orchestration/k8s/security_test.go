@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+func TestSecurityValues(t *testing.T) {
+	var root int64
+	readOnly := false
+
+	for _, tc := range []struct {
+		name                   string
+		sc                     orchestration.SecurityContext
+		wantPodSecurityContext map[string]interface{}
+		wantSecurityContext    map[string]interface{}
+	}{
+		{
+			name:                   "default",
+			wantPodSecurityContext: map[string]interface{}{"fsGroup": beeUser},
+			wantSecurityContext:    map[string]interface{}{"allowPrivilegeEscalation": false, "runAsUser": beeUser},
+		},
+		{
+			// explicit zeros are set instead of the defaults
+			name: "root",
+			sc: orchestration.SecurityContext{
+				RunAsNonRoot:           new(bool),
+				RunAsUser:              &root,
+				RunAsGroup:             &root,
+				FSGroup:                &root,
+				ReadOnlyRootFilesystem: &readOnly,
+			},
+			wantPodSecurityContext: map[string]interface{}{"fsGroup": root, "runAsGroup": root, "runAsNonRoot": false},
+			wantSecurityContext: map[string]interface{}{
+				"allowPrivilegeEscalation": false,
+				"readOnlyRootFilesystem":   false,
+				"runAsGroup":               root,
+				"runAsNonRoot":             false,
+				"runAsUser":                root,
+			},
+		},
+		{
+			name:                   "cluster assigned ids",
+			sc:                     orchestration.SecurityContext{ClusterAssignedIDs: true, RunAsUser: &root},
+			wantPodSecurityContext: map[string]interface{}{},
+			wantSecurityContext:    map[string]interface{}{"allowPrivilegeEscalation": false},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			values := make(map[string]interface{})
+			securityValues(values, tc.sc)
+
+			if got := values["podSecurityContext"]; !reflect.DeepEqual(got, tc.wantPodSecurityContext) {
+				t.Errorf("got pod security context %v, want %v", got, tc.wantPodSecurityContext)
+			}
+			if got := values["securityContext"]; !reflect.DeepEqual(got, tc.wantSecurityContext) {
+				t.Errorf("got security context %v, want %v", got, tc.wantSecurityContext)
+			}
+
+			psc, csc := setPodSecurityContext(tc.sc), setSecurityContext(tc.sc)
+			if tc.sc.ClusterAssignedIDs {
+				if psc.FSGroup != nil || csc.RunAsUser != nil {
+					t.Errorf("got fs group %v and user %v, want them assigned by the cluster", psc.FSGroup, csc.RunAsUser)
+				}
+				return
+			}
+			if *psc.FSGroup != tc.wantPodSecurityContext["fsGroup"] || *csc.RunAsUser != tc.wantSecurityContext["runAsUser"] {
+				t.Errorf("got fs group %d and user %d of the pod spec, want the ones of the values", *psc.FSGroup, *csc.RunAsUser)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s/configmap"
+	"github.com/ethersphere/beekeeper/pkg/k8s/containers"
+	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
+	"github.com/ethersphere/beekeeper/pkg/k8s/secret"
+	"github.com/ethersphere/beekeeper/pkg/k8s/service"
+	"github.com/ethersphere/beekeeper/pkg/k8s/serviceaccount"
+	"github.com/ethersphere/beekeeper/pkg/k8s/statefulset"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// deploymentModeStatefulSet deploys nodes of the node group as replicas of one
+// statefulset named after the node group, instead of a statefulset of every
+// node, so nodes get stable network identities of the statefulset and volumes
+// of its volume claim templates
+const deploymentModeStatefulSet = "statefulset"
+
+// statefulSetMode returns true if nodes of the node group are replicas of the
+// node group statefulset
+func (g *NodeGroup) statefulSetMode() bool {
+	return g.opts.DeploymentMode == deploymentModeStatefulSet
+}
+
+// replicaOrdinal returns ordinal of the statefulset replica of the node, nodes
+// are named after their pods, like bee-0
+func (g *NodeGroup) replicaOrdinal(name string) (int32, error) {
+	i, err := strconv.ParseInt(strings.TrimPrefix(name, g.name+"-"), 10, 32)
+	if err != nil || i < 0 || fmt.Sprintf("%s-%d", g.name, i) != name {
+		return 0, fmt.Errorf("node %s is not named after a replica of statefulset %s, like %s-0", name, g.name, g.name)
+	}
+
+	return int32(i), nil
+}
+
+// checkReplica checks whether the node can be a replica of the node group
+// statefulset, replicas share the bee config and the image of the node group
+func (g *NodeGroup) checkReplica(name string, o orchestration.NodeOptions) error {
+	if g.helm != nil {
+		return fmt.Errorf("statefulset deployment mode is not supported by the helm orchestrator")
+	}
+	if _, err := g.replicaOrdinal(name); err != nil {
+		return err
+	}
+	if g.opts.BeeConfig == nil || o.Config != nil {
+		return fmt.Errorf("node %s: replicas of statefulset %s share the bee config of the node group", name, g.name)
+	}
+	if o.Image != "" && o.Image != g.opts.Image {
+		return fmt.Errorf("node %s: replicas of statefulset %s share the image of the node group", name, g.name)
+	}
+	if g.opts.BeeConfig.ClefSignerEnable || o.ClefKey != "" {
+		return fmt.Errorf("node %s: Clef is not supported by the statefulset deployment mode", name)
+	}
+
+	return nil
+}
+
+// setStatefulSet sets the node group statefulset and its resources shared by
+// replicas, keeping its number of replicas. Keys of all nodes of the node group
+// are in one secret, replicas copy their keys by their hostnames when they
+// start.
+func (g *NodeGroup) setStatefulSet(ctx context.Context, o orchestration.CreateOptions) (err error) {
+	g.statefulSetLock.Lock()
+	defer g.statefulSetLock.Unlock()
+
+	if err := checkNetem(o); err != nil {
+		return err
+	}
+
+	namespace := g.namespace()
+	labels := mergeMaps(g.opts.Labels, map[string]string{
+		"app.kubernetes.io/instance": g.name,
+	})
+
+	// bee configuration, templates are executed for the node group
+	o.Name = g.name
+	var config bytes.Buffer
+	if err := template.Must(template.New("").Parse(orchestration.ConfigTemplate)).Execute(&config, o.Config); err != nil {
+		return err
+	}
+	beeConfig, err := orchestration.ApplyConfigTemplate(config.Bytes(), o)
+	if err != nil {
+		return err
+	}
+
+	configCM := g.name
+	if _, err = g.k8s.ConfigMap.Set(ctx, configCM, namespace, configmap.Options{
+		Annotations: g.opts.Annotations,
+		Labels:      labels,
+		Data: map[string]string{
+			".bee.yaml": string(beeConfig),
+		},
+	}); err != nil {
+		return fmt.Errorf("set configmap in namespace %s: %w", namespace, err)
+	}
+
+	// secret with keys of all nodes
+	keysSecret := fmt.Sprintf("%s-keys", g.name)
+	keysSecretData := map[string]string{}
+	for name, n := range g.getNodes() {
+		if len(n.LibP2PKey()) > 0 {
+			keysSecretData[name+"-libp2p"] = n.LibP2PKey()
+		}
+		if len(n.SwarmKey()) > 0 {
+			keysSecretData[name+"-swarm"] = n.SwarmKey()
+		}
+	}
+
+	if _, err := g.k8s.Secret.Set(ctx, keysSecret, namespace, secret.Options{
+		Annotations: g.opts.Annotations,
+		Labels:      labels,
+		StringData:  keysSecretData,
+	}); err != nil {
+		return fmt.Errorf("set secret in namespace %s: %w", namespace, err)
+	}
+
+	// service account
+	svcAccount := g.name
+	if _, err := g.k8s.ServiceAccount.Set(ctx, svcAccount, namespace, serviceaccount.Options{
+		Annotations:      g.opts.Annotations,
+		Labels:           labels,
+		ImagePullSecrets: g.opts.ImagePullSecrets,
+	}); err != nil {
+		return fmt.Errorf("set serviceaccount in namespace %s: %w", namespace, err)
+	}
+
+	portAPI, err := parsePort(o.Config.APIAddr)
+	if err != nil {
+		return fmt.Errorf("parsing API port from config: %s", err)
+	}
+	portDebug, err := parsePort(o.Config.DebugAPIAddr)
+	if err != nil {
+		return fmt.Errorf("parsing Debug port from config: %s", err)
+	}
+	portP2P, err := parsePort(o.Config.P2PAddr)
+	if err != nil {
+		return fmt.Errorf("parsing P2P port from config: %s", err)
+	}
+
+	// headless service, replicas are resolved as <pod>.<service>
+	headlessSvc := fmt.Sprintf("%s-headless", g.name)
+	if _, err := g.k8s.Service.Set(ctx, headlessSvc, namespace, service.Options{
+		Annotations: g.opts.Annotations,
+		Labels:      labels,
+		ServiceSpec: service.Spec{
+			Ports: service.Ports{
+				{
+					AppProtocol: "TCP",
+					Name:        "api",
+					Protocol:    "TCP",
+					Port:        portAPI,
+					TargetPort:  "api",
+				},
+				{
+					AppProtocol: "TCP",
+					Name:        "debug",
+					Protocol:    "TCP",
+					Port:        portDebug,
+					TargetPort:  "debug",
+				},
+				{
+					AppProtocol: "TCP",
+					Name:        "p2p",
+					Protocol:    "TCP",
+					Port:        portP2P,
+					TargetPort:  "p2p",
+				},
+			},
+			Selector: labels,
+			Type:     "ClusterIP",
+		},
+	}); err != nil {
+		return fmt.Errorf("set service in namespace %s: %w", namespace, err)
+	}
+
+	// statefulset, replicas are started and stopped by scaling it
+	replicas, err := g.k8s.StatefulSet.Replicas(ctx, g.name, namespace)
+	if err != nil {
+		return err
+	}
+
+	volumes := setVolumes(setVolumesOptions{
+		ConfigCM:           configCM,
+		PersistenceEnabled: g.opts.PersistenceEnabled,
+		EmptyDirMedium:     g.opts.EmptyDirMedium,
+		EmptyDirSizeLimit:  g.opts.EmptyDirSizeLimit,
+	})
+	volumes = append(volumes, pod.Volume{
+		Secret: &pod.SecretVolume{
+			Name:       "keys",
+			SecretName: keysSecret,
+		},
+	})
+
+	if _, err := g.k8s.StatefulSet.Set(ctx, g.name, namespace, statefulset.Options{
+		Annotations: g.opts.Annotations,
+		Labels:      labels,
+		Spec: statefulset.StatefulSetSpec{
+			PodManagementPolicy: g.opts.PodManagementPolicy,
+			Replicas:            replicas,
+			Selector:            labels,
+			ServiceName:         headlessSvc,
+			Template: pod.PodTemplateSpec{
+				Name:        g.name,
+				Namespace:   namespace,
+				Annotations: g.opts.Annotations,
+				Labels:      g.podLabels(labels),
+				Spec: pod.PodSpec{
+					Affinity: setAffinity(g.affinity()),
+					InitContainers: append(containers.Containers{{
+						Name:  "init-bee",
+						Image: "ethersphere/busybox:1.33",
+						Command: []string{"sh", "-c", `mkdir -p /home/bee/.bee/keys;
+if [ -f /keys/$(hostname)-libp2p ]; then cp /keys/$(hostname)-libp2p /home/bee/.bee/keys/libp2p_v2.key; fi;
+if [ -f /keys/$(hostname)-swarm ]; then cp /keys/$(hostname)-swarm /home/bee/.bee/keys/swarm.key; fi;
+chown -R 999:999 /home/bee/.bee/keys;
+echo 'bee initialization done';`},
+						SecurityContext: setSidecarSecurityContext(g.opts.SecurityContext),
+						VolumeMounts: containers.VolumeMounts{
+							{
+								Name:      "data",
+								MountPath: "home/bee/.bee",
+							},
+							{
+								Name:      "keys",
+								MountPath: "/keys",
+								ReadOnly:  true,
+							},
+						},
+					}}, setNetemInitContainers(g.opts.Netem)...),
+					Containers: setContainers(setContainersOptions{
+						Name:                             g.name,
+						Image:                            g.opts.Image,
+						ImagePullPolicy:                  g.opts.ImagePullPolicy,
+						PortAPI:                          portAPI,
+						PortDebug:                        portDebug,
+						PortP2P:                          portP2P,
+						PersistenceEnabled:               g.opts.PersistenceEnabled,
+						ResourcesLimitCPU:                g.opts.ResourcesLimitCPU,
+						ResourcesLimitEphemeralStorage:   g.opts.ResourcesLimitEphemeralStorage,
+						ResourcesLimitMemory:             g.opts.ResourcesLimitMemory,
+						ResourcesRequestCPU:              g.opts.ResourcesRequestCPU,
+						ResourcesRequestEphemeralStorage: g.opts.ResourcesRequestEphemeralStorage,
+						ResourcesRequestMemory:           g.opts.ResourcesRequestMemory,
+						SecurityContext:                  g.opts.SecurityContext,
+					}),
+					NodeSelector:              g.opts.NodeSelector,
+					PodSecurityContext:        setPodSecurityContext(g.opts.SecurityContext),
+					RestartPolicy:             g.opts.RestartPolicy,
+					ServiceAccountName:        svcAccount,
+					Tolerations:               setTolerations(g.opts.Tolerations),
+					TopologySpreadConstraints: setTopologySpreadConstraints(g.topologySpreadConstraints()),
+					Volumes:                   volumes,
+				},
+			},
+			UpdateStrategy: statefulset.UpdateStrategy{
+				Type: g.opts.UpdateStrategy,
+			},
+			VolumeClaimTemplates: setPersistentVolumeClaims(setPersistentVolumeClaimsOptions{
+				Enabled:        g.opts.PersistenceEnabled,
+				StorageClass:   g.opts.PersistenceStorageClass,
+				StorageRequest: g.opts.PersistenceStorageRequest,
+			}),
+		},
+	}); err != nil {
+		return fmt.Errorf("set statefulset in namespace %s: %w", namespace, err)
+	}
+	g.logger.Infof("statefulset %s is set in namespace %s", g.name, namespace)
+
+	return
+}
+
+// startReplica scales the node group statefulset up to the replica of the
+// node, replicas of lower ordinals are started with it
+func (g *NodeGroup) startReplica(ctx context.Context, name string) (err error) {
+	ordinal, err := g.replicaOrdinal(name)
+	if err != nil {
+		return err
+	}
+
+	g.statefulSetLock.Lock()
+	defer g.statefulSetLock.Unlock()
+
+	replicas, err := g.k8s.StatefulSet.Replicas(ctx, g.name, g.namespace())
+	if err != nil {
+		return err
+	}
+	if replicas > ordinal {
+		return nil
+	}
+
+	if _, err := g.k8s.StatefulSet.Scale(ctx, g.name, g.namespace(), ordinal+1); err != nil {
+		return fmt.Errorf("scale statefulset %s in namespace %s: %w", g.name, g.namespace(), err)
+	}
+
+	g.logger.Infof("statefulset %s is scaled to %d replicas in namespace %s", g.name, ordinal+1, g.namespace())
+	return
+}
+
+// stopReplica scales the node group statefulset down to the replicas below
+// the one of the node. Only the replica of the highest ordinal is stopped,
+// unless replicas above it are stopped with it, like when nodes are deleted.
+func (g *NodeGroup) stopReplica(ctx context.Context, name string, stopAbove bool) (err error) {
+	ordinal, err := g.replicaOrdinal(name)
+	if err != nil {
+		return err
+	}
+
+	g.statefulSetLock.Lock()
+	defer g.statefulSetLock.Unlock()
+
+	replicas, err := g.k8s.StatefulSet.Replicas(ctx, g.name, g.namespace())
+	if err != nil {
+		return err
+	}
+	if replicas <= ordinal {
+		return nil
+	}
+	if replicas > ordinal+1 && !stopAbove {
+		return fmt.Errorf("node %s: replicas of statefulset %s above it are running", name, g.name)
+	}
+
+	if _, err := g.k8s.StatefulSet.Scale(ctx, g.name, g.namespace(), ordinal); err != nil {
+		return fmt.Errorf("scale statefulset %s in namespace %s: %w", g.name, g.namespace(), err)
+	}
+
+	g.logger.Infof("statefulset %s is scaled to %d replicas in namespace %s", g.name, ordinal, g.namespace())
+	return
+}
+
+// deleteStatefulSet deletes the node group statefulset and its resources
+// shared by replicas
+func (g *NodeGroup) deleteStatefulSet(ctx context.Context) (err error) {
+	g.statefulSetLock.Lock()
+	defer g.statefulSetLock.Unlock()
+
+	namespace := g.namespace()
+
+	if err := g.k8s.StatefulSet.Delete(ctx, g.name, namespace); err != nil {
+		return fmt.Errorf("deleting statefulset in namespace %s: %w", namespace, err)
+	}
+
+	headlessSvc := fmt.Sprintf("%s-headless", g.name)
+	if err := g.k8s.Service.Delete(ctx, headlessSvc, namespace); err != nil {
+		return fmt.Errorf("deleting service in namespace %s: %w", namespace, err)
+	}
+
+	if err := g.k8s.ServiceAccount.Delete(ctx, g.name, namespace); err != nil {
+		return fmt.Errorf("deleting serviceaccount in namespace %s: %w", namespace, err)
+	}
+
+	keysSecret := fmt.Sprintf("%s-keys", g.name)
+	if err := g.k8s.Secret.Delete(ctx, keysSecret, namespace); err != nil {
+		return fmt.Errorf("deleting secret %s in namespace %s: %w", keysSecret, namespace, err)
+	}
+
+	if err := g.k8s.ConfigMap.Delete(ctx, g.name, namespace); err != nil {
+		return fmt.Errorf("deleting configmap %s in namespace %s: %w", g.name, namespace, err)
+	}
+
+	g.logger.Infof("statefulset %s is deleted in namespace %s", g.name, namespace)
+	return
+}
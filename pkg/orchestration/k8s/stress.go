@@ -0,0 +1,112 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s/customresource/stresschaos"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// InjectStress injects the stress into pods of nodes of its node groups by
+// chaos-mesh StressChaos resources, one in the namespace of every node group,
+// named after the stress and the node group. It requires chaos-mesh installed
+// in the kubernetes cluster.
+func (c *Cluster) InjectStress(ctx context.Context, s orchestration.Stress) (err error) {
+	spec, err := stressChaosSpec(s)
+	if err != nil {
+		return fmt.Errorf("stress %s: %w", s.Name, err)
+	}
+
+	names := s.NodeGroups
+	if len(names) == 0 {
+		for name := range c.nodeGroups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	for _, name := range names {
+		g, ok := c.nodeGroups[name]
+		if !ok {
+			return fmt.Errorf("stress %s: node group %s not found", s.Name, name)
+		}
+
+		spec.Selector = stresschaos.PodSelector{
+			Namespaces:     []string{g.namespace()},
+			LabelSelectors: map[string]string{nodeGroupLabel: g.name},
+		}
+		if _, err := g.k8s.StressChaos.Set(ctx, fmt.Sprintf("%s-%s", s.Name, g.name), g.namespace(), stresschaos.Options{
+			Labels: map[string]string{chaosLabel: "true"},
+			Spec:   spec,
+		}); err != nil {
+			return fmt.Errorf("stress %s: %w", s.Name, err)
+		}
+		c.logger.Infof("stress %s is injected into node group %s", s.Name, g.name)
+	}
+
+	return
+}
+
+// RemoveStress deletes chaos-mesh resources of all injected stress, in
+// namespaces of node groups of all kubernetes clusters
+func (c *Cluster) RemoveStress(ctx context.Context) (err error) {
+	for _, ns := range c.namespaces() {
+		if err := ns.k8s.StressChaos.DeleteSelected(ctx, ns.name, map[string]string{chaosLabel: "true"}); err != nil {
+			return fmt.Errorf("remove stress: %w", err)
+		}
+	}
+
+	return
+}
+
+// stressChaosSpec returns spec of StressChaos resources of the stress,
+// without the pod selector, stress-ng workers run in the bee container only
+func stressChaosSpec(s orchestration.Stress) (spec stresschaos.StressChaosSpec, err error) {
+	if s.Name == "" {
+		return stresschaos.StressChaosSpec{}, errors.New("name is not set")
+	}
+
+	spec = stresschaos.StressChaosSpec{
+		Mode:           s.Mode,
+		Value:          s.Value,
+		ContainerNames: []string{"bee"},
+	}
+	if spec.Mode == "" {
+		spec.Mode = "all"
+	}
+
+	if s.CPUWorkers > 0 {
+		load := s.CPULoad
+		if load == 0 {
+			load = 100
+		}
+		if load < 0 || load > 100 {
+			return stresschaos.StressChaosSpec{}, fmt.Errorf("cpu load %d is not in range 1-100", load)
+		}
+		spec.Stressors.CPU = &stresschaos.CPUStressor{
+			Workers: s.CPUWorkers,
+			Load:    load,
+		}
+	}
+	if s.MemoryWorkers > 0 || s.MemorySize != "" {
+		if s.MemorySize == "" {
+			return stresschaos.StressChaosSpec{}, errors.New("memory size is not set")
+		}
+		workers := s.MemoryWorkers
+		if workers == 0 {
+			workers = 1
+		}
+		spec.Stressors.Memory = &stresschaos.MemoryStressor{
+			Workers: workers,
+			Size:    s.MemorySize,
+		}
+	}
+	if spec.Stressors.CPU == nil && spec.Stressors.Memory == nil {
+		return stresschaos.StressChaosSpec{}, errors.New("cpu workers or memory size are not set")
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,53 @@
+package orchestration
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Netem represents traffic shaping of the network interface of pods of nodes
+// by the tc netem queueing discipline, to model WAN conditions like latency,
+// jitter, packet loss and bandwidth caps
+type Netem struct {
+	Image     string        // image with tc of the container configuring the discipline, nicolaka/netshoot:v0.11 by default
+	Interface string        // network interface of pods, eth0 by default
+	Delay     time.Duration // delay of outgoing packets
+	Jitter    time.Duration // random variation of the delay
+	Loss      float64       // percentage of outgoing packets dropped
+	Rate      string        // bandwidth cap of outgoing traffic, like 10mbit
+}
+
+// Enabled returns whether any traffic shaping is set
+func (n Netem) Enabled() bool {
+	return n.Delay > 0 || n.Jitter > 0 || n.Loss > 0 || n.Rate != ""
+}
+
+// Command returns the tc command setting the netem queueing discipline as the
+// root one of the network interface, replacing the existing one
+func (n Netem) Command() []string {
+	iface := n.Interface
+	if iface == "" {
+		iface = "eth0"
+	}
+
+	cmd := []string{"tc", "qdisc", "replace", "dev", iface, "root", "netem"}
+	if n.Delay > 0 || n.Jitter > 0 {
+		cmd = append(cmd, "delay", netemTime(n.Delay))
+		if n.Jitter > 0 {
+			cmd = append(cmd, netemTime(n.Jitter))
+		}
+	}
+	if n.Loss > 0 {
+		cmd = append(cmd, "loss", strconv.FormatFloat(n.Loss, 'f', -1, 64)+"%")
+	}
+	if n.Rate != "" {
+		cmd = append(cmd, "rate", n.Rate)
+	}
+	return cmd
+}
+
+// netemTime formats the duration in microseconds, a unit tc accepts
+func netemTime(d time.Duration) string {
+	return fmt.Sprintf("%dus", d.Microseconds())
+}
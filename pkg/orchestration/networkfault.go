@@ -0,0 +1,29 @@
+package orchestration
+
+import "time"
+
+const (
+	// NetworkFaultDelay delays packets of pods of nodes
+	NetworkFaultDelay = "delay"
+	// NetworkFaultLoss drops packets of pods of nodes
+	NetworkFaultLoss = "loss"
+	// NetworkFaultBandwidth limits bandwidth of pods of nodes
+	NetworkFaultBandwidth = "bandwidth"
+)
+
+// NetworkFault represents fault of networks of pods of nodes of node groups,
+// injected declaratively by chaos-mesh until it is removed
+type NetworkFault struct {
+	Name       string        // name of the fault, unique among injected ones
+	Action     string        // delay, loss or bandwidth
+	NodeGroups []string      // node groups of nodes the fault is injected into, all by default
+	Mode       string        // pods selected, all, the default, one, fixed, fixed-percent or random-max-percent
+	Value      string        // number or percentage of pods of the fixed and percent modes
+	Direction  string        // to, the default, from or both
+	Latency    time.Duration // delay of packets
+	Jitter     time.Duration // random variation of the delay
+	Loss       float64       // percentage of dropped packets
+	Rate       string        // bandwidth, like 1mbps
+	Limit      uint32        // bytes queued waiting for the bandwidth, 20971520 by default
+	Buffer     uint32        // bytes sent instantaneously, 10000 by default
+}
@@ -9,12 +9,17 @@ import (
 	"time"
 
 	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/docker"
+	"github.com/ethersphere/beekeeper/pkg/helm"
 	"github.com/ethersphere/beekeeper/pkg/k8s"
 )
 
 // ErrNotSet represents error when orchestration client is not set
 var ErrNotSet = errors.New("orchestration client not set")
 
+// ErrNotSupported represents error when operation is not supported by the orchestrator
+var ErrNotSupported = errors.New("not supported by the orchestrator")
+
 type Node interface {
 	Name() string
 	Client() *bee.Client
@@ -23,8 +28,14 @@ type Node interface {
 	Config() *Config
 	Create(ctx context.Context, o CreateOptions) (err error)
 	Delete(ctx context.Context, namespace string) (err error)
+	Exec(ctx context.Context, namespace string, command ...string) (stdout []byte, err error)
+	Image() string
+	Kill(ctx context.Context, namespace string) (err error)
 	LibP2PKey() string
+	Logs(ctx context.Context, namespace string, since time.Time, tail int64) (logs []byte, err error)
 	Ready(ctx context.Context, namespace string) (ready bool, err error)
+	Restarts(ctx context.Context, namespace string) (restarts NodeRestarts, err error)
+	SetImage(ctx context.Context, namespace, image string) (err error)
 	Start(ctx context.Context, namespace string) (err error)
 	Stop(ctx context.Context, namespace string) (err error)
 	SwarmKey() string
@@ -62,12 +73,39 @@ func (ek EncryptedKey) GetEthAddress() (string, error) {
 	return skj.Address, nil
 }
 
+// NodeRestarts represents restart state of the node's Bee container
+type NodeRestarts struct {
+	Count     int32  // number of container restarts
+	Reason    string // termination reason of the last restart, e.g. OOMKilled
+	CrashLoop bool   // container is waiting in CrashLoopBackOff
+}
+
+// NodeFailure represents failure of the node's Bee container, like an OOM kill
+// or a crashloop, with events of the node's pod explaining it
+type NodeFailure struct {
+	Node   string
+	Reason string   // OOMKilled, CrashLoopBackOff or another termination reason
+	Events []string // events of the node's pod, oldest first
+}
+
+func (f NodeFailure) Error() string {
+	if len(f.Events) == 0 {
+		return fmt.Sprintf("node %s failed: %s", f.Node, f.Reason)
+	}
+	return fmt.Sprintf("node %s failed: %s, events: %s", f.Node, f.Reason, strings.Join(f.Events, "; "))
+}
+
 // NodeOptions holds optional parameters for the Node.
 type NodeOptions struct {
+	APIURL       string // URL of the API of a node not managed by the orchestrator
 	ClefKey      string
 	ClefPassword string
 	Client       *bee.Client
 	Config       *Config
+	DebugAPIURL  string // URL of the debug API of a node not managed by the orchestrator
+	Docker       *docker.Client
+	Helm         *helm.Client
+	Image        string // image pinned to the node, overrides the node group image
 	K8S          *k8s.Client
 	LibP2PKey    string
 	SwarmKey     EncryptedKey
@@ -78,37 +116,50 @@ type CreateOptions struct {
 	// Bee configuration
 	Config Config
 	// Kubernetes configuration
-	Name                      string
-	Namespace                 string
-	Annotations               map[string]string
-	ClefImage                 string
-	ClefImagePullPolicy       string
-	ClefKey                   string
-	ClefPassword              string
-	Labels                    map[string]string
-	Image                     string
-	ImagePullPolicy           string
-	ImagePullSecrets          []string
-	IngressAnnotations        map[string]string
-	IngressClass              string
-	IngressHost               string
-	IngressDebugAnnotations   map[string]string
-	IngressDebugClass         string
-	IngressDebugHost          string
-	LibP2PKey                 string
-	NodeSelector              map[string]string
-	PersistenceEnabled        bool
-	PersistenceStorageClass   string
-	PersistenceStorageRequest string
-	PodManagementPolicy       string
-	RestartPolicy             string
-	ResourcesLimitCPU         string
-	ResourcesLimitMemory      string
-	ResourcesRequestCPU       string
-	ResourcesRequestMemory    string
-	Selector                  map[string]string
-	SwarmKey                  string
-	UpdateStrategy            string
+	Name                             string
+	Namespace                        string
+	Affinity                         Affinity
+	Annotations                      map[string]string
+	ClefImage                        string
+	ClefImagePullPolicy              string
+	ClefKey                          string
+	ClefPassword                     string
+	Gateway                          string // Gateway API gateway APIs are routed by, ingresses are set if it is empty
+	Labels                           map[string]string
+	Image                            string
+	ImagePullPolicy                  string
+	ImagePullSecrets                 []string
+	IngressAnnotations               map[string]string
+	IngressClass                     string
+	IngressHost                      string
+	IngressTLSSecret                 string
+	IngressDebugAnnotations          map[string]string
+	IngressDebugClass                string
+	IngressDebugHost                 string
+	IngressDebugTLSSecret            string
+	LibP2PKey                        string
+	NodeSelector                     map[string]string
+	EmptyDirMedium                   string
+	EmptyDirSizeLimit                string
+	Netem                            Netem
+	PersistenceEnabled               bool
+	PersistenceStorageClass          string
+	PersistenceStorageRequest        string
+	PodManagementPolicy              string
+	RestartPolicy                    string
+	ResourcesLimitCPU                string
+	ResourcesLimitEphemeralStorage   string
+	ResourcesLimitMemory             string
+	ResourcesRequestCPU              string
+	ResourcesRequestEphemeralStorage string
+	ResourcesRequestMemory           string
+	SecurityContext                  SecurityContext
+	Selector                         map[string]string
+	StatefulSet                      string // statefulset of the node group the node is a replica of, only services of the node are created
+	SwarmKey                         string
+	Tolerations                      []Toleration
+	TopologySpreadConstraints        []TopologySpreadConstraint
+	UpdateStrategy                   string
 }
 
 // Config represents Bee configuration
@@ -144,6 +195,7 @@ type Config struct {
 	PriceOracleAddress         string        // price Oracle address
 	ResolverOptions            string        // ENS compatible API endpoint for a TLD and with contract address, can be repeated, format [tld:][contract-addr@]url
 	Restricted                 bool          // start node in restricted mode
+	Template                   string        // Go template of Bee options not modeled by Config, executed with the node's ConfigTemplateData
 	TokenEncryptionKey         string        // username for API authentication
 	AdminPassword              string        // password hash for API authentication
 	ChequebookEnable           bool          // enable chequebook
@@ -161,5 +213,6 @@ type Config struct {
 	TracingServiceName         string        // service name identifier for tracing
 	Verbosity                  uint64        // log verbosity level 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=trace
 	WelcomeMessage             string        // send a welcome message string during handshakes
+	WithdrawalAddresses        string        // space separated list of addresses the node is allowed to withdraw funds to
 	WarmupTime                 time.Duration // warmup time pull/pushsync protocols
 }
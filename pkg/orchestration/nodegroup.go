@@ -2,9 +2,12 @@ package orchestration
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethersphere/bee/pkg/swarm"
 	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/helm"
+	"github.com/ethersphere/beekeeper/pkg/k8s"
 )
 
 type NodeGroup interface {
@@ -16,17 +19,22 @@ type NodeGroup interface {
 	DeleteNode(ctx context.Context, name string) (err error)
 	Fund(ctx context.Context, name string, o NodeOptions, f FundingOptions) (err error)
 	GroupReplicationFactor(ctx context.Context, a swarm.Address) (grf int, err error)
+	KillNode(ctx context.Context, name string) (err error)
 	Name() string
 	Nodes() map[string]Node
 	NodesClients(ctx context.Context) (map[string]*bee.Client, error)
 	NodesClientsAll(ctx context.Context) map[string]*bee.Client
 	NodesSorted() (l []string)
 	Node(name string) (Node, error)
+	NodeExec(ctx context.Context, name string, command ...string) (stdout []byte, err error)
 	NodeClient(name string) (*bee.Client, error)
+	NodeLogs(ctx context.Context, name string, since time.Time, tail int64) (logs []byte, err error)
 	Overlays(ctx context.Context) (overlays NodeGroupOverlays, err error)
 	Peers(ctx context.Context) (peers NodeGroupPeers, err error)
 	NodeReady(ctx context.Context, name string) (ok bool, err error)
+	Restarts(ctx context.Context) (restarts NodeGroupRestarts, err error)
 	RunningNodes(ctx context.Context) (running []string, err error)
+	SetNodeImage(ctx context.Context, name, image string) (err error)
 	SetupNode(ctx context.Context, name string, o NodeOptions, f FundingOptions) (err error)
 	Settlements(ctx context.Context) (settlements NodeGroupSettlements, err error)
 	Size() int
@@ -38,29 +46,57 @@ type NodeGroup interface {
 
 // NodeGroupOptions represents node group options
 type NodeGroupOptions struct {
-	Annotations               map[string]string
-	ClefImage                 string
-	ClefImagePullPolicy       string
-	BeeConfig                 *Config
-	Image                     string
-	ImagePullPolicy           string
-	ImagePullSecrets          []string
-	IngressAnnotations        map[string]string
-	IngressClass              string
-	IngressDebugAnnotations   map[string]string
-	IngressDebugClass         string
-	Labels                    map[string]string
-	NodeSelector              map[string]string
-	PersistenceEnabled        bool
-	PersistenceStorageClass   string
-	PersistenceStorageRequest string
-	PodManagementPolicy       string
-	RestartPolicy             string
-	ResourcesLimitCPU         string
-	ResourcesLimitMemory      string
-	ResourcesRequestCPU       string
-	ResourcesRequestMemory    string
-	UpdateStrategy            string
+	Affinity                         Affinity // affinity of pods of nodes, the kubernetes scheduler places them freely by default
+	Annotations                      map[string]string
+	APIDomain                        string // domain of the node group API ingresses, the cluster domain by default
+	APIBearerToken                   string // authenticates requests to nodes, like to ingresses in front of them
+	APICAFile                        string // PEM encoded CAs verifying nodes, in addition to system CAs
+	APICertFile                      string // client certificate authenticating requests to nodes
+	APIInsecureTLS                   bool   // skips verification of nodes of both APIs, in addition to cluster settings
+	APIKeyFile                       string // key of the client certificate
+	ClefImage                        string
+	ClefImagePullPolicy              string
+	BeeConfig                        *Config
+	DebugAPIDomain                   string       // domain of the node group debug API ingresses, the cluster domain by default
+	DeploymentMode                   string       // "statefulset" deploys nodes as replicas of one statefulset of the node group, a statefulset of every node by default
+	Gateway                          string       // Gateway API gateway, name or namespace/name, both APIs are routed by HTTPRoutes attached to it instead of ingresses
+	HelmClient                       *helm.Client // client of the kubernetes cluster of the node group, the cluster one by default
+	HelmChart                        string       // chart nodes are installed from with the helm orchestrator, like ethersphere/bee
+	HelmChartRepo                    string       // URL of the chart repository
+	HelmChartVersion                 string       // chart version, the latest one by default
+	HelmValuesFiles                  []string     // files with values overriding the ones set by Beekeeper
+	Image                            string
+	ImagePullPolicy                  string
+	ImagePullSecrets                 []string
+	IngressAnnotations               map[string]string
+	IngressClass                     string
+	IngressTLSSecret                 string // TLS secret of API ingresses, TLS is not terminated by ingresses by default
+	IngressDebugAnnotations          map[string]string
+	IngressDebugClass                string
+	IngressDebugTLSSecret            string      // TLS secret of debug API ingresses
+	K8SClient                        *k8s.Client // client of the kubernetes cluster of the node group, the cluster one by default
+	Labels                           map[string]string
+	Namespace                        string // namespace of the node group nodes, the cluster namespace by default
+	NodeSelector                     map[string]string
+	EmptyDirMedium                   string // medium of the data volume of nodes without persistence, like Memory
+	EmptyDirSizeLimit                string // size limit of the data volume of nodes without persistence
+	Netem                            Netem  // traffic shaping of network interfaces of pods of nodes
+	PersistenceEnabled               bool
+	PersistenceStorageClass          string
+	PersistenceStorageRequest        string
+	PersistenceRetentionPolicy       string // "Delete" deletes volumes of deleted nodes, they are retained by default
+	PodManagementPolicy              string
+	RestartPolicy                    string
+	ResourcesLimitCPU                string
+	ResourcesLimitEphemeralStorage   string
+	ResourcesLimitMemory             string
+	ResourcesRequestCPU              string
+	ResourcesRequestEphemeralStorage string
+	ResourcesRequestMemory           string
+	SecurityContext                  SecurityContext // security settings of pods and containers of nodes
+	Tolerations                      []Toleration
+	TopologySpreadConstraints        []TopologySpreadConstraint
+	UpdateStrategy                   string
 }
 
 // NodeGroupAddresses represents addresses of all nodes in the node group
@@ -84,6 +120,9 @@ type NodeGroupOverlays map[string]swarm.Address
 // NodeGroupPeers represents peers of all nodes in the node group
 type NodeGroupPeers map[string][]swarm.Address
 
+// NodeGroupRestarts represents restart state of all nodes in the node group
+type NodeGroupRestarts map[string]NodeRestarts
+
 // NodeGroupSettlements represents settlements of all nodes in the node group
 type NodeGroupSettlements map[string]map[string]SentReceived
 
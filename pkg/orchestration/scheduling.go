@@ -0,0 +1,46 @@
+package orchestration
+
+// Affinity represents constraints of scheduling pods of nodes on kubernetes
+// nodes, relative to labels of kubernetes nodes and to other pods
+type Affinity struct {
+	Node    []NodeSelectorRequirement // labels kubernetes nodes of pods are required to match
+	Pod     []PodAffinityTerm         // pods that pods are scheduled in the same topology domain with
+	PodAnti []PodAffinityTerm         // pods that pods are not scheduled in the same topology domain with
+}
+
+// NodeSelectorRequirement represents requirement of labels of kubernetes
+// nodes, like the key in values, with the In, NotIn, Exists, DoesNotExist,
+// Gt or Lt operator
+type NodeSelectorRequirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// PodAffinityTerm represents pods, selected by labels, in the topology domain
+// of the topology key, like kubernetes.io/hostname for kubernetes nodes or
+// topology.kubernetes.io/zone for zones
+type PodAffinityTerm struct {
+	LabelSelector map[string]string // pods of the node group by default
+	TopologyKey   string
+	Weight        int32 // the term is preferred with the weight, 1-100, required if it is 0
+}
+
+// Toleration represents toleration of taints of kubernetes nodes by pods of
+// nodes
+type Toleration struct {
+	Key               string
+	Operator          string // Exists or Equal
+	Value             string
+	Effect            string // NoSchedule, PreferNoSchedule or NoExecute, all effects if empty
+	TolerationSeconds int64  // time pods are bound to nodes with NoExecute taints, forever if 0
+}
+
+// TopologySpreadConstraint represents spreading of pods, selected by labels,
+// among topology domains of the topology key
+type TopologySpreadConstraint struct {
+	MaxSkew           int32             // maximum difference of numbers of pods in any two domains, 1 by default
+	TopologyKey       string            // like kubernetes.io/hostname or topology.kubernetes.io/zone
+	WhenUnsatisfiable string            // DoNotSchedule, the default, or ScheduleAnyway
+	LabelSelector     map[string]string // pods of the node group by default
+}
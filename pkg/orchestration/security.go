@@ -0,0 +1,15 @@
+package orchestration
+
+// SecurityContext represents security settings of pods and containers of
+// nodes, like the ones required by the restricted pod security standard
+type SecurityContext struct {
+	Restricted              bool   // runs containers as non-root with all capabilities dropped and the RuntimeDefault seccomp profile
+	RunAsNonRoot            *bool  // all containers of pods are required to run as non-root, if true
+	RunAsUser               *int64 // user of containers, 999 if nil
+	RunAsGroup              *int64 // primary group of containers, the one of the image if nil
+	FSGroup                 *int64 // group owning volumes of pods, 999 if nil
+	ClusterAssignedIDs      bool   // users and groups are not set, so they are assigned by the cluster, like by OpenShift
+	ReadOnlyRootFilesystem  *bool  // nodes write to their data volumes only, if true
+	SeccompProfile          string // RuntimeDefault, Localhost or Unconfined, not set by default
+	SeccompLocalhostProfile string // profile of the Localhost seccomp profile, relative to the kubelet seccomp directory
+}
@@ -0,0 +1,419 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// compile check whether client implements interface
+var _ orchestration.Cluster = (*Cluster)(nil)
+
+// Cluster represents cluster of existing Bee nodes, like nodes of a public
+// network, listed by their API URLs. Nodes are not created, deleted, started
+// or stopped by Beekeeper.
+type Cluster struct {
+	name                string
+	apiInsecureTLS      bool
+	debugAPIInsecureTLS bool
+	namespace           string
+	nodeGroups          map[string]*NodeGroup // set when groups are added to the cluster
+	rateLimiter         *bee.RateLimiter      // shared by clients of all nodes
+	nodeRateLimit       float64
+	nodeRateLimitBurst  int
+	retry               bee.RetryOptions
+	transport           bee.TransportOptions
+	logger              logging.Logger
+}
+
+// NewCluster returns new cluster
+func NewCluster(name string, o orchestration.ClusterOptions, logger logging.Logger) *Cluster {
+	return &Cluster{
+		name:                name,
+		apiInsecureTLS:      o.APIInsecureTLS,
+		debugAPIInsecureTLS: o.DebugAPIInsecureTLS,
+		namespace:           o.Namespace,
+		nodeGroups:          make(map[string]*NodeGroup),
+		rateLimiter:         bee.NewRateLimiter(o.RateLimit, o.RateLimitBurst),
+		nodeRateLimit:       o.NodeRateLimit,
+		nodeRateLimitBurst:  o.NodeRateLimitBurst,
+		retry: bee.RetryOptions{
+			ReadRetries:  o.ReadRetries,
+			WriteRetries: o.WriteRetries,
+			MinBackoff:   o.RetryMinBackoff,
+			MaxBackoff:   o.RetryMaxBackoff,
+			Budget:       o.RetryBudget,
+		},
+		transport: bee.TransportOptions{
+			MaxIdleConnsPerHost: o.MaxIdleConnsPerHost,
+			MaxConnsPerHost:     o.MaxConnsPerHost,
+			IdleConnTimeout:     o.IdleConnTimeout,
+			DisableKeepAlives:   o.DisableKeepAlives,
+			DisableHTTP2:        o.DisableHTTP2,
+		},
+		logger: logger,
+	}
+}
+
+// AddNodeGroup adds new node group to the cluster
+func (c *Cluster) AddNodeGroup(name string, o orchestration.NodeGroupOptions) {
+	g := NewNodeGroup(name, o, c.logger)
+	g.cluster = c
+
+	c.nodeGroups[name] = g
+}
+
+// Addresses returns ClusterAddresses
+func (c *Cluster) Addresses(ctx context.Context) (addrs map[string]orchestration.NodeGroupAddresses, err error) {
+	return groupsDo[orchestration.ClusterAddresses](c, nil, func(g *NodeGroup) (orchestration.NodeGroupAddresses, error) {
+		return g.Addresses(ctx)
+	})
+}
+
+// Accounting returns ClusterAccounting
+func (c *Cluster) Accounting(ctx context.Context) (accounting orchestration.ClusterAccounting, err error) {
+	return groupsDo[orchestration.ClusterAccounting](c, nil, func(g *NodeGroup) (orchestration.NodeGroupAccounting, error) {
+		return g.Accounting(ctx)
+	})
+}
+
+// FlattenAccounting returns aggregated NodeGroupAccounting
+func (c *Cluster) FlattenAccounting(ctx context.Context) (accounting orchestration.NodeGroupAccounting, err error) {
+	a, err := c.Accounting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return flatten(a)
+}
+
+// Balances returns ClusterBalances
+func (c *Cluster) Balances(ctx context.Context) (balances orchestration.ClusterBalances, err error) {
+	return groupsDo[orchestration.ClusterBalances](c, nil, func(g *NodeGroup) (orchestration.NodeGroupBalances, error) {
+		return g.Balances(ctx)
+	})
+}
+
+// FlattenBalances returns aggregated NodeGroupBalances
+func (c *Cluster) FlattenBalances(ctx context.Context) (balances orchestration.NodeGroupBalances, err error) {
+	b, err := c.Balances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return flatten(b)
+}
+
+// GlobalReplicationFactor returns the total number of nodes in the cluster that contain given chunk
+func (c *Cluster) GlobalReplicationFactor(ctx context.Context, a swarm.Address) (grf int, err error) {
+	factors, err := groupsDo[map[string]int](c, nil, func(g *NodeGroup) (int, error) {
+		return g.GroupReplicationFactor(ctx, a)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, f := range factors {
+		grf += f
+	}
+
+	return
+}
+
+// Name returns name of the cluster
+func (c *Cluster) Name() string {
+	return c.name
+}
+
+// NodeGroups returns map of node groups in the cluster
+func (c *Cluster) NodeGroups() (l map[string]orchestration.NodeGroup) {
+	nodeGroups := make(map[string]orchestration.NodeGroup)
+	for k, v := range c.nodeGroups {
+		nodeGroups[k] = v
+	}
+	return nodeGroups
+}
+
+// NodeGroupsSorted returns sorted list of node group names in the cluster
+func (c *Cluster) NodeGroupsSorted() (l []string) {
+	l = make([]string, 0, len(c.nodeGroups))
+	for k := range c.nodeGroups {
+		l = append(l, k)
+	}
+	sort.Strings(l)
+
+	return
+}
+
+// NodeGroup returns node group
+func (c *Cluster) NodeGroup(name string) (ng orchestration.NodeGroup, err error) {
+	g, ok := c.nodeGroups[name]
+	if !ok {
+		return nil, fmt.Errorf("node group %s not found", name)
+	}
+	return g, nil
+}
+
+// Nodes returns map of nodes in the cluster
+func (c *Cluster) Nodes() map[string]orchestration.Node {
+	n := make(map[string]orchestration.Node)
+	for _, ng := range c.nodeGroups {
+		for k, v := range ng.Nodes() {
+			n[k] = v
+		}
+	}
+	return n
+}
+
+// NodeNames returns a list of node names in the cluster across all node groups
+func (c *Cluster) NodeNames() (names []string) {
+	for name := range c.Nodes() {
+		names = append(names, name)
+	}
+	return
+}
+
+// LightNodeNames returns a list of light node names
+func (c *Cluster) LightNodeNames() (names []string) {
+	for name, node := range c.Nodes() {
+		if !node.Config().FullNode {
+			names = append(names, name)
+		}
+	}
+	return
+}
+
+// FullNodeNames returns a list of full node names
+func (c *Cluster) FullNodeNames() (names []string) {
+	for name, node := range c.Nodes() {
+		cfg := node.Config()
+		if cfg.FullNode && !cfg.BootnodeMode {
+			names = append(names, name)
+		}
+	}
+	return
+}
+
+// NodesClients returns map of node's clients in the cluster excluding stopped nodes
+func (c *Cluster) NodesClients(ctx context.Context) (map[string]*bee.Client, error) {
+	clients := make(map[string]*bee.Client)
+	for _, ng := range c.nodeGroups {
+		ngc, err := ng.NodesClients(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("nodes clients: %w", err)
+		}
+		for n, client := range ngc {
+			clients[n] = client
+		}
+	}
+	return clients, nil
+}
+
+// NodesClientsAll returns map of node's clients in the cluster
+func (c *Cluster) NodesClientsAll(ctx context.Context) (map[string]*bee.Client, error) {
+	clients := make(map[string]*bee.Client)
+	for _, ng := range c.nodeGroups {
+		for n, client := range ng.NodesClientsAll(ctx) {
+			clients[n] = client
+		}
+	}
+	return clients, nil
+}
+
+// Overlays returns ClusterOverlays excluding the provided node group names
+func (c *Cluster) Overlays(ctx context.Context, exclude ...string) (overlays orchestration.ClusterOverlays, err error) {
+	return groupsDo[orchestration.ClusterOverlays](c, exclude, func(g *NodeGroup) (orchestration.NodeGroupOverlays, error) {
+		return g.Overlays(ctx)
+	})
+}
+
+// FlattenOverlays returns aggregated ClusterOverlays excluding the provided node group names
+func (c *Cluster) FlattenOverlays(ctx context.Context, exclude ...string) (map[string]swarm.Address, error) {
+	o, err := c.Overlays(ctx, exclude...)
+	if err != nil {
+		return nil, err
+	}
+
+	return flatten(o)
+}
+
+// Peers returns peers of all nodes in the cluster
+func (c *Cluster) Peers(ctx context.Context, exclude ...string) (peers orchestration.ClusterPeers, err error) {
+	return groupsDo[orchestration.ClusterPeers](c, exclude, func(g *NodeGroup) (orchestration.NodeGroupPeers, error) {
+		return g.Peers(ctx)
+	})
+}
+
+// RandomNode returns random node from a cluster
+func (c *Cluster) RandomNode(ctx context.Context, r *rand.Rand) (node orchestration.Node, err error) {
+	var nodes []orchestration.Node
+	for _, name := range c.NodeGroupsSorted() {
+		ng := c.nodeGroups[name]
+		for _, n := range ng.NodesSorted() {
+			v, err := ng.Node(n)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, v)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes in cluster %s", c.name)
+	}
+
+	return nodes[r.Intn(len(nodes))], nil
+}
+
+// Restarts returns ClusterRestarts
+func (c *Cluster) Restarts(ctx context.Context) (restarts orchestration.ClusterRestarts, err error) {
+	return groupsDo[orchestration.ClusterRestarts](c, nil, func(g *NodeGroup) (orchestration.NodeGroupRestarts, error) {
+		return g.Restarts(ctx)
+	})
+}
+
+// FlattenRestarts returns aggregated NodeGroupRestarts
+func (c *Cluster) FlattenRestarts(ctx context.Context) (restarts orchestration.NodeGroupRestarts, err error) {
+	r, err := c.Restarts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return flatten(r)
+}
+
+// WatchFailures is not supported, nodes are not managed by Beekeeper
+func (c *Cluster) WatchFailures(ctx context.Context) (failures <-chan orchestration.NodeFailure, err error) {
+	return nil, fmt.Errorf("watch failures: %w", orchestration.ErrNotSupported)
+}
+
+// Partition is not supported, nodes are not managed by Beekeeper
+func (c *Cluster) Partition(ctx context.Context, groups [][]string) (err error) {
+	return fmt.Errorf("partition: %w", orchestration.ErrNotSupported)
+}
+
+// Heal is not supported, clusters are not partitioned
+func (c *Cluster) Heal(ctx context.Context) (err error) {
+	return fmt.Errorf("heal: %w", orchestration.ErrNotSupported)
+}
+
+// InjectNetworkFault is not supported, faults are injected by chaos-mesh in
+// kubernetes clusters
+func (c *Cluster) InjectNetworkFault(ctx context.Context, f orchestration.NetworkFault) (err error) {
+	return fmt.Errorf("inject network fault %s: %w", f.Name, orchestration.ErrNotSupported)
+}
+
+// RemoveNetworkFaults is not supported, faults are not injected
+func (c *Cluster) RemoveNetworkFaults(ctx context.Context) (err error) {
+	return fmt.Errorf("remove network faults: %w", orchestration.ErrNotSupported)
+}
+
+// InjectIOFault is not supported, faults are injected by chaos-mesh in
+// kubernetes clusters
+func (c *Cluster) InjectIOFault(ctx context.Context, f orchestration.IOFault) (err error) {
+	return fmt.Errorf("inject io fault %s: %w", f.Name, orchestration.ErrNotSupported)
+}
+
+// RemoveIOFaults is not supported, faults are not injected
+func (c *Cluster) RemoveIOFaults(ctx context.Context) (err error) {
+	return fmt.Errorf("remove io faults: %w", orchestration.ErrNotSupported)
+}
+
+// InjectStress is not supported, stress is injected by chaos-mesh in
+// kubernetes clusters
+func (c *Cluster) InjectStress(ctx context.Context, s orchestration.Stress) (err error) {
+	return fmt.Errorf("inject stress %s: %w", s.Name, orchestration.ErrNotSupported)
+}
+
+// RemoveStress is not supported, stress is not injected
+func (c *Cluster) RemoveStress(ctx context.Context) (err error) {
+	return fmt.Errorf("remove stress: %w", orchestration.ErrNotSupported)
+}
+
+// ScaleNodeGroup is not supported, nodes are not created or deleted by
+// Beekeeper
+func (c *Cluster) ScaleNodeGroup(ctx context.Context, name string, replicas int) (err error) {
+	return fmt.Errorf("scale node group %s: %w", name, orchestration.ErrNotSupported)
+}
+
+// Settlements returns ClusterSettlements
+func (c *Cluster) Settlements(ctx context.Context) (settlements orchestration.ClusterSettlements, err error) {
+	return groupsDo[orchestration.ClusterSettlements](c, nil, func(g *NodeGroup) (orchestration.NodeGroupSettlements, error) {
+		return g.Settlements(ctx)
+	})
+}
+
+// FlattenSettlements returns aggregated NodeGroupSettlements
+func (c *Cluster) FlattenSettlements(ctx context.Context) (settlements orchestration.NodeGroupSettlements, err error) {
+	s, err := c.Settlements(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return flatten(s)
+}
+
+// Size returns size of the cluster
+func (c *Cluster) Size() (size int) {
+	for _, ng := range c.nodeGroups {
+		size += ng.Size()
+	}
+	return
+}
+
+// Topologies returns ClusterTopologies
+func (c *Cluster) Topologies(ctx context.Context) (topologies orchestration.ClusterTopologies, err error) {
+	return groupsDo[orchestration.ClusterTopologies](c, nil, func(g *NodeGroup) (orchestration.NodeGroupTopologies, error) {
+		return g.Topologies(ctx)
+	})
+}
+
+// FlattenTopologies returns an aggregate of Topologies
+func (c *Cluster) FlattenTopologies(ctx context.Context) (topologies map[string]bee.Topology, err error) {
+	t, err := c.Topologies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return flatten(t)
+}
+
+// groupsDo calls the function with node groups of the cluster, except the
+// excluded ones, and returns results by node group names
+func groupsDo[M ~map[string]T, T any](c *Cluster, exclude []string, f func(g *NodeGroup) (T, error)) (M, error) {
+	results := make(M)
+	for name, g := range c.nodeGroups {
+		if contains(exclude, name) {
+			continue
+		}
+
+		v, err := f(g)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		results[name] = v
+	}
+
+	return results, nil
+}
+
+// flatten aggregates results of node groups, keys must be unique across
+// node groups
+func flatten[M ~map[string]G, G ~map[string]T, T any](m M) (map[string]T, error) {
+	res := make(map[string]T)
+	for _, g := range m {
+		for k, v := range g {
+			if _, found := res[k]; found {
+				return nil, fmt.Errorf("key %s already present", k)
+			}
+			res[k] = v
+		}
+	}
+
+	return res, nil
+}
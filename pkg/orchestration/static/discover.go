@@ -0,0 +1,90 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultAPIPort       = 1633
+	defaultDebugAPIPort  = 1635
+	defaultClusterDomain = "cluster.local"
+)
+
+// DiscoverOptions represents options of discovering nodes
+type DiscoverOptions struct {
+	Selector      map[string]string // labels of pods of nodes
+	ClusterDomain string            // DNS domain of the Kubernetes cluster, defaults to cluster.local
+}
+
+// DiscoveredNode represents node of a pod
+type DiscoveredNode struct {
+	Name        string
+	APIURL      string
+	DebugAPIURL string
+}
+
+// Discover returns nodes of pods in the namespace selected by labels, sorted
+// by names, so nodes deployed by other tools, like Helm or Argo CD, are used
+// without listing them. Nodes are reached on DNS names of pods of headless
+// services, like pods of statefulsets, or on pod IPs, on the container ports
+// named api and debug, so Beekeeper must run in the Kubernetes cluster.
+func Discover(ctx context.Context, k *k8s.Client, namespace string, o DiscoverOptions) (nodes []DiscoveredNode, err error) {
+	if len(o.Selector) == 0 {
+		return nil, fmt.Errorf("selector of pods in namespace %s is not set", namespace)
+	}
+	if o.ClusterDomain == "" {
+		o.ClusterDomain = defaultClusterDomain
+	}
+
+	pods, err := k.Pods.List(ctx, namespace, o.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range pods {
+		host := podHost(p, namespace, o.ClusterDomain)
+		if host == "" || p.DeletionTimestamp != nil {
+			continue
+		}
+
+		nodes = append(nodes, DiscoveredNode{
+			Name:        p.Name,
+			APIURL:      "http://" + net.JoinHostPort(host, strconv.Itoa(int(containerPort(p, "api", defaultAPIPort)))),
+			DebugAPIURL: "http://" + net.JoinHostPort(host, strconv.Itoa(int(containerPort(p, "debug", defaultDebugAPIPort)))),
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	return nodes, nil
+}
+
+// podHost returns DNS name of the pod of a headless service, or the pod IP,
+// which is empty until the pod is scheduled
+func podHost(p v1.Pod, namespace, clusterDomain string) string {
+	if p.Spec.Hostname != "" && p.Spec.Subdomain != "" {
+		return fmt.Sprintf("%s.%s.%s.svc.%s", p.Spec.Hostname, p.Spec.Subdomain, namespace, clusterDomain)
+	}
+
+	return p.Status.PodIP
+}
+
+// containerPort returns the container port of the pod with the name, or the
+// default port
+func containerPort(p v1.Pod, name string, port int32) int32 {
+	for _, c := range p.Spec.Containers {
+		for _, cp := range c.Ports {
+			if cp.Name == name {
+				return cp.ContainerPort
+			}
+		}
+	}
+
+	return port
+}
@@ -0,0 +1,11 @@
+package static
+
+func contains(list []string, find string) bool {
+	for _, v := range list {
+		if v == find {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,183 @@
+package static
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+const readyTimeout = 5 * time.Second
+
+// compile check whether client implements interface
+var _ orchestration.Node = (*Node)(nil)
+
+// Node represents Bee node that is not managed by Beekeeper, it is reached on
+// its API URLs only
+type Node struct {
+	name         string
+	clefKey      string
+	clefPassword string
+	client       *bee.Client
+	config       *orchestration.Config
+	image        string
+	libP2PKey    string
+	swarmKey     string
+	logger       logging.Logger
+}
+
+// NewNode returns Bee node
+func NewNode(name string, opts orchestration.NodeOptions, logger logging.Logger) (n *Node) {
+	n = &Node{
+		name:   name,
+		logger: logger,
+	}
+
+	if opts.Client != nil {
+		n.client = opts.Client
+	}
+	if opts.Config != nil {
+		n.config = opts.Config
+	}
+	if len(opts.ClefKey) > 0 {
+		n.clefKey = opts.ClefKey
+	}
+	if len(opts.ClefPassword) > 0 {
+		n.clefPassword = opts.ClefPassword
+	}
+	if len(opts.LibP2PKey) > 0 {
+		n.libP2PKey = opts.LibP2PKey
+	}
+	if len(opts.SwarmKey) > 0 {
+		n.swarmKey = opts.SwarmKey.ToString()
+	}
+	if len(opts.Image) > 0 {
+		n.image = opts.Image
+	}
+
+	return
+}
+
+// Name returns node's name
+func (n Node) Name() string {
+	return n.name
+}
+
+// Client returns node's client
+func (n Node) Client() *bee.Client {
+	return n.client
+}
+
+// Config returns node's config
+func (n Node) Config() *orchestration.Config {
+	return n.config
+}
+
+// Image returns image the node is configured with, the orchestrator does not
+// know the image the node runs
+func (n Node) Image() string {
+	return n.image
+}
+
+// ClefKey returns node's clefKey
+func (n Node) ClefKey() string {
+	return n.clefKey
+}
+
+// ClefPassword returns node's clefPassword
+func (n Node) ClefPassword() string {
+	return n.clefPassword
+}
+
+// LibP2PKey returns node's libP2PKey
+func (n Node) LibP2PKey() string {
+	return n.libP2PKey
+}
+
+// SwarmKey returns node's swarmKey
+func (n Node) SwarmKey() string {
+	return n.swarmKey
+}
+
+// SetSwarmKey sets node's Swarm key
+func (n Node) SetSwarmKey(key string) orchestration.Node {
+	n.swarmKey = key
+	return n
+}
+
+// SetClefKey sets node's Clef key
+func (n Node) SetClefKey(key string) orchestration.Node {
+	n.clefKey = key
+	return n
+}
+
+// SetClefPassword sets node's Clef password
+func (n Node) SetClefPassword(password string) orchestration.Node {
+	n.clefPassword = password
+	return n
+}
+
+// Create is not supported, the node runs already
+func (n Node) Create(ctx context.Context, o orchestration.CreateOptions) (err error) {
+	return orchestration.ErrNotSupported
+}
+
+// Delete is not supported, the node is not removed by Beekeeper
+func (n Node) Delete(ctx context.Context, namespace string) (err error) {
+	return orchestration.ErrNotSupported
+}
+
+// Kill is not supported
+func (n Node) Kill(ctx context.Context, namespace string) (err error) {
+	return orchestration.ErrNotSupported
+}
+
+// Exec is not supported, nodes may not even run in containers
+func (n Node) Exec(ctx context.Context, namespace string, command ...string) (stdout []byte, err error) {
+	return nil, orchestration.ErrNotSupported
+}
+
+// Logs are not supported, they are kept by the operator of the node
+func (n Node) Logs(ctx context.Context, namespace string, since time.Time, tail int64) (logs []byte, err error) {
+	return nil, orchestration.ErrNotSupported
+}
+
+// Ready returns whether the node reports it is ready, nodes that do not
+// respond are not ready
+func (n Node) Ready(ctx context.Context, namespace string) (ready bool, err error) {
+	if n.client == nil {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+
+	ready, err = n.client.Readiness(ctx)
+	if err != nil {
+		return false, nil
+	}
+
+	return ready, nil
+}
+
+// Restarts are not supported, as the process of the node is not observed
+func (n Node) Restarts(ctx context.Context, namespace string) (restarts orchestration.NodeRestarts, err error) {
+	return orchestration.NodeRestarts{}, orchestration.ErrNotSupported
+}
+
+// SetImage is not supported
+func (n Node) SetImage(ctx context.Context, namespace, image string) (err error) {
+	return orchestration.ErrNotSupported
+}
+
+// Start is not supported
+func (n Node) Start(ctx context.Context, namespace string) (err error) {
+	return orchestration.ErrNotSupported
+}
+
+// Stop is not supported
+func (n Node) Stop(ctx context.Context, namespace string) (err error) {
+	return orchestration.ErrNotSupported
+}
@@ -0,0 +1,506 @@
+package static
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/logging"
+	"github.com/ethersphere/beekeeper/pkg/orchestration"
+)
+
+// compile check whether client implements interface
+var _ orchestration.NodeGroup = (*NodeGroup)(nil)
+
+// NodeGroup represents group of Bee nodes reached on API URLs listed in the
+// cluster configuration
+type NodeGroup struct {
+	name  string
+	nodes map[string]orchestration.Node
+	opts  orchestration.NodeGroupOptions
+
+	// set when added to the cluster
+	cluster *Cluster
+
+	logger logging.Logger
+
+	lock sync.RWMutex
+}
+
+// NewNodeGroup returns new node group
+func NewNodeGroup(name string, o orchestration.NodeGroupOptions, logger logging.Logger) *NodeGroup {
+	return &NodeGroup{
+		name:   name,
+		nodes:  make(map[string]orchestration.Node),
+		opts:   o,
+		logger: logger,
+	}
+}
+
+// AddNode adds new node to the node group, the node is reached on the API
+// URLs of the node options
+func (g *NodeGroup) AddNode(ctx context.Context, name string, o orchestration.NodeOptions) (err error) {
+	if o.APIURL == "" || o.DebugAPIURL == "" {
+		return fmt.Errorf("node %s: API URL and debug API URL are required", name)
+	}
+
+	aURL, err := url.Parse(o.APIURL)
+	if err != nil {
+		return fmt.Errorf("API URL %s: %w", name, err)
+	}
+	dURL, err := url.Parse(o.DebugAPIURL)
+	if err != nil {
+		return fmt.Errorf("debug API URL %s: %w", name, err)
+	}
+
+	var config *orchestration.Config
+	if o.Config != nil {
+		config = o.Config
+	} else {
+		config = g.opts.BeeConfig
+	}
+
+	tlsConfig, err := bee.NewTLSConfig(g.opts.APICAFile, g.opts.APICertFile, g.opts.APIKeyFile)
+	if err != nil {
+		return fmt.Errorf("node group %s TLS: %w", g.name, err)
+	}
+
+	client := bee.NewClient(bee.ClientOptions{
+		APIURL:              aURL,
+		APIInsecureTLS:      g.cluster.apiInsecureTLS || g.opts.APIInsecureTLS,
+		DebugAPIURL:         dURL,
+		DebugAPIInsecureTLS: g.cluster.debugAPIInsecureTLS || g.opts.APIInsecureTLS,
+		Retry:               g.cluster.retry,
+		Restricted:          config.Restricted,
+		RateLimiter:         g.cluster.rateLimiter,
+		NodeRateLimit:       g.cluster.nodeRateLimit,
+		NodeRateLimitBurst:  g.cluster.nodeRateLimitBurst,
+		TLSConfig:           tlsConfig,
+		CAFile:              g.opts.APICAFile,
+		CertFile:            g.opts.APICertFile,
+		KeyFile:             g.opts.APIKeyFile,
+		BearerToken:         g.opts.APIBearerToken,
+		Transport:           g.cluster.transport,
+	}, g.logger)
+
+	image := g.opts.Image
+	if o.Image != "" {
+		image = o.Image
+	}
+
+	n := NewNode(name, orchestration.NodeOptions{
+		ClefKey:      o.ClefKey,
+		ClefPassword: o.ClefPassword,
+		Client:       client,
+		Config:       config,
+		Image:        image,
+		LibP2PKey:    o.LibP2PKey,
+		SwarmKey:     o.SwarmKey,
+	}, g.logger)
+
+	g.addNode(*n)
+
+	return
+}
+
+// Addresses returns NodeGroupAddresses
+func (g *NodeGroup) Addresses(ctx context.Context) (addrs orchestration.NodeGroupAddresses, err error) {
+	return nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bee.Addresses, error) {
+		return c.Addresses(ctx)
+	})
+}
+
+// Accounting returns NodeGroupAccounting
+func (g *NodeGroup) Accounting(ctx context.Context) (accounting orchestration.NodeGroupAccounting, err error) {
+	overlays, err := g.Overlays(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("overlays: %w", err)
+	}
+
+	accounts, err := nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bee.Accounting, error) {
+		return c.Accounting(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accounting = make(orchestration.NodeGroupAccounting)
+	for name, a := range accounts {
+		tmp := make(map[string]bee.Account)
+		for _, acc := range a.Accounting {
+			tmp[acc.Peer] = acc
+		}
+		accounting[overlays[name].String()] = tmp
+	}
+
+	return
+}
+
+// Balances returns NodeGroupBalances
+func (g *NodeGroup) Balances(ctx context.Context) (balances orchestration.NodeGroupBalances, err error) {
+	overlays, err := g.Overlays(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("overlays: %w", err)
+	}
+
+	bals, err := nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bee.Balances, error) {
+		return c.Balances(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	balances = make(orchestration.NodeGroupBalances)
+	for name, b := range bals {
+		tmp := make(map[string]int64)
+		for _, bal := range b.Balances {
+			tmp[bal.Peer] = bal.Balance
+		}
+		balances[overlays[name].String()] = tmp
+	}
+
+	return
+}
+
+// CreateNode is not supported, nodes run already
+func (g *NodeGroup) CreateNode(ctx context.Context, name string) (err error) {
+	return fmt.Errorf("create node %s: %w", name, orchestration.ErrNotSupported)
+}
+
+// DeleteNode is not supported, nodes are not removed by Beekeeper
+func (g *NodeGroup) DeleteNode(ctx context.Context, name string) (err error) {
+	return fmt.Errorf("delete node %s: %w", name, orchestration.ErrNotSupported)
+}
+
+// Fund is not supported, nodes are funded by their operators
+func (g *NodeGroup) Fund(ctx context.Context, name string, o orchestration.NodeOptions, f orchestration.FundingOptions) (err error) {
+	if f.Eth <= 0 && f.Bzz <= 0 && f.GBzz <= 0 {
+		return
+	}
+
+	return fmt.Errorf("fund node %s: %w", name, orchestration.ErrNotSupported)
+}
+
+// GroupReplicationFactor returns the total number of nodes in the node group that contain given chunk
+func (g *NodeGroup) GroupReplicationFactor(ctx context.Context, a swarm.Address) (grf int, err error) {
+	found, err := nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bool, error) {
+		return c.HasChunk(ctx, a)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, ok := range found {
+		if ok {
+			grf++
+		}
+	}
+
+	return
+}
+
+// KillNode is not supported
+func (g *NodeGroup) KillNode(ctx context.Context, name string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	if err := n.Kill(ctx, g.cluster.namespace); err != nil {
+		return fmt.Errorf("kill node %s: %w", name, err)
+	}
+
+	return
+}
+
+// NodeExec is not supported
+func (g *NodeGroup) NodeExec(ctx context.Context, name string, command ...string) (stdout []byte, err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if stdout, err = n.Exec(ctx, g.cluster.namespace, command...); err != nil {
+		return nil, fmt.Errorf("exec in node %s: %w", name, err)
+	}
+
+	return
+}
+
+// NodeLogs are not supported
+func (g *NodeGroup) NodeLogs(ctx context.Context, name string, since time.Time, tail int64) (logs []byte, err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if logs, err = n.Logs(ctx, g.cluster.namespace, since, tail); err != nil {
+		return nil, fmt.Errorf("logs of node %s: %w", name, err)
+	}
+
+	return
+}
+
+// Name returns name of the node group
+func (g *NodeGroup) Name() string {
+	return g.name
+}
+
+// Nodes returns map of nodes in the node group
+func (g *NodeGroup) Nodes() map[string]orchestration.Node {
+	return g.getNodes()
+}
+
+// NodesClients returns map of node's clients in the node group, nodes are
+// not stopped by Beekeeper, so these are clients of all nodes
+func (g *NodeGroup) NodesClients(ctx context.Context) (map[string]*bee.Client, error) {
+	return g.getClients(), nil
+}
+
+// NodesClientsAll returns map of node's clients in the node group
+func (g *NodeGroup) NodesClientsAll(ctx context.Context) map[string]*bee.Client {
+	return g.getClients()
+}
+
+// NodesSorted returns list of nodes sorted by names from the node group.
+func (g *NodeGroup) NodesSorted() []string {
+	nodes := g.getNodes()
+
+	l := make([]string, 0, len(nodes))
+	for k := range nodes {
+		l = append(l, k)
+	}
+	sort.Strings(l)
+
+	return l
+}
+
+// Node returns node
+func (g *NodeGroup) Node(name string) (orchestration.Node, error) {
+	return g.getNode(name)
+}
+
+// NodeClient returns node's client
+func (g *NodeGroup) NodeClient(name string) (*bee.Client, error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return nil, err
+	}
+	return n.Client(), nil
+}
+
+// Overlays returns NodeGroupOverlays
+func (g *NodeGroup) Overlays(ctx context.Context) (overlays orchestration.NodeGroupOverlays, err error) {
+	return nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (swarm.Address, error) {
+		return c.Overlay(ctx)
+	})
+}
+
+// Peers returns NodeGroupPeers
+func (g *NodeGroup) Peers(ctx context.Context) (peers orchestration.NodeGroupPeers, err error) {
+	return nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) ([]swarm.Address, error) {
+		return c.Peers(ctx)
+	})
+}
+
+// NodeReady returns node's readiness
+func (g *NodeGroup) NodeReady(ctx context.Context, name string) (ok bool, err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return false, err
+	}
+
+	return n.Ready(ctx, g.cluster.namespace)
+}
+
+// Restarts are not supported, as processes of nodes are not observed
+func (g *NodeGroup) Restarts(ctx context.Context) (restarts orchestration.NodeGroupRestarts, err error) {
+	return nil, fmt.Errorf("restarts: %w", orchestration.ErrNotSupported)
+}
+
+// RunningNodes returns list of all nodes, as nodes are not stopped by Beekeeper
+func (g *NodeGroup) RunningNodes(ctx context.Context) (running []string, err error) {
+	return g.NodesSorted(), nil
+}
+
+// SetNodeImage is not supported, nodes are upgraded by their operators
+func (g *NodeGroup) SetNodeImage(ctx context.Context, name, image string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	if err := n.SetImage(ctx, g.cluster.namespace, image); err != nil {
+		return fmt.Errorf("set image of node %s: %w", name, err)
+	}
+
+	return
+}
+
+// SetupNode adds the node to the node group only, as it runs already and it
+// is funded by its operator
+func (g *NodeGroup) SetupNode(ctx context.Context, name string, o orchestration.NodeOptions, f orchestration.FundingOptions) (err error) {
+	if err := g.AddNode(ctx, name, o); err != nil {
+		return fmt.Errorf("add node %s: %w", name, err)
+	}
+	g.logger.Infof("node %s is not managed by beekeeper, it is added without setup", name)
+
+	return
+}
+
+// Settlements returns NodeGroupSettlements
+func (g *NodeGroup) Settlements(ctx context.Context) (settlements orchestration.NodeGroupSettlements, err error) {
+	overlays, err := g.Overlays(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("checking settlements: %w", err)
+	}
+
+	sets, err := nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bee.Settlements, error) {
+		return c.Settlements(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	settlements = make(orchestration.NodeGroupSettlements)
+	for name, s := range sets {
+		tmp := make(map[string]orchestration.SentReceived)
+		for _, set := range s.Settlements {
+			tmp[set.Peer] = orchestration.SentReceived{
+				Received: set.Received,
+				Sent:     set.Sent,
+			}
+		}
+		settlements[overlays[name].String()] = tmp
+	}
+
+	return
+}
+
+// Size returns size of the node group
+func (g *NodeGroup) Size() int {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return len(g.nodes)
+}
+
+// StartNode is not supported
+func (g *NodeGroup) StartNode(ctx context.Context, name string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	if err := n.Start(ctx, g.cluster.namespace); err != nil {
+		return fmt.Errorf("start node %s: %w", name, err)
+	}
+
+	return
+}
+
+// StopNode is not supported
+func (g *NodeGroup) StopNode(ctx context.Context, name string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+
+	if err := n.Stop(ctx, g.cluster.namespace); err != nil {
+		return fmt.Errorf("stop node %s: %w", name, err)
+	}
+
+	return
+}
+
+// StoppedNodes returns no nodes, as nodes are not stopped by Beekeeper
+func (g *NodeGroup) StoppedNodes(ctx context.Context) (stopped []string, err error) {
+	return nil, nil
+}
+
+// Topologies returns NodeGroupTopologies
+func (g *NodeGroup) Topologies(ctx context.Context) (topologies orchestration.NodeGroupTopologies, err error) {
+	return nodesDo(ctx, g, func(ctx context.Context, c *bee.Client) (bee.Topology, error) {
+		return c.Topology(ctx)
+	})
+}
+
+// nodesDo calls the function with clients of all nodes of the node group
+// concurrently and returns results by node names, or errors of all nodes
+// that failed
+func nodesDo[T any](ctx context.Context, g *NodeGroup, f func(ctx context.Context, c *bee.Client) (T, error)) (map[string]T, error) {
+	clients, err := g.NodesClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		name  string
+		value T
+		err   error
+	}
+
+	results := make(chan result, len(clients))
+	for name, c := range clients {
+		go func(name string, c *bee.Client) {
+			v, err := f(ctx, c)
+			results <- result{name: name, value: v, err: err}
+		}(name, c)
+	}
+
+	values := make(map[string]T, len(clients))
+	for range clients {
+		r := <-results
+		if r.err != nil {
+			err = errors.Join(err, fmt.Errorf("%s: %w", r.name, r.err))
+			continue
+		}
+		values[r.name] = r.value
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (g *NodeGroup) addNode(n Node) {
+	g.lock.Lock()
+	g.nodes[n.Name()] = n
+	g.lock.Unlock()
+}
+
+func (g *NodeGroup) getClients() map[string]*bee.Client {
+	c := make(map[string]*bee.Client)
+	g.lock.RLock()
+	for k, v := range g.nodes {
+		c[k] = v.Client()
+	}
+	g.lock.RUnlock()
+	return c
+}
+
+func (g *NodeGroup) getNode(name string) (n orchestration.Node, err error) {
+	g.lock.RLock()
+	n, ok := g.nodes[name]
+	g.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("node %s not found", name)
+	}
+	return
+}
+
+func (g *NodeGroup) getNodes() map[string]orchestration.Node {
+	nodes := make(map[string]orchestration.Node)
+	g.lock.RLock()
+	for k, v := range g.nodes {
+		nodes[k] = v
+	}
+	g.lock.RUnlock()
+	return nodes
+}
@@ -0,0 +1,100 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/bee/debugapi"
+)
+
+// ClusterStatus is a snapshot of status of all nodes in the cluster
+type ClusterStatus struct {
+	Nodes  map[string]debugapi.Status // status of nodes that responded
+	Errors map[string]error           // errors of nodes that did not respond
+
+	ReserveSize      uint64         // sum of reserve sizes
+	MinStorageRadius uint8          // smallest storage radius of full nodes
+	MaxStorageRadius uint8          // largest storage radius of full nodes
+	MinPeers         uint64         // smallest number of connected peers
+	MaxPeers         uint64         // largest number of connected peers
+	BeeModes         map[string]int // number of nodes in each bee mode
+}
+
+// NodeNames returns sorted names of nodes that responded
+func (s ClusterStatus) NodeNames() []string {
+	names := make([]string, 0, len(s.Nodes))
+	for n := range s.Nodes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetClusterStatus returns status of all running nodes in the cluster,
+// queried concurrently. Nodes that do not respond are reported in errors,
+// the snapshot aggregates the rest.
+func GetClusterStatus(ctx context.Context, c Cluster) (ClusterStatus, error) {
+	clients, err := c.NodesClients(ctx)
+	if err != nil {
+		return ClusterStatus{}, fmt.Errorf("nodes clients: %w", err)
+	}
+
+	s := ClusterStatus{
+		Nodes:    make(map[string]debugapi.Status),
+		Errors:   make(map[string]error),
+		BeeModes: make(map[string]int),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, client := range clients {
+		wg.Add(1)
+		go func(name string, client *bee.Client) {
+			defer wg.Done()
+			status, err := client.Status(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				s.Errors[name] = err
+				return
+			}
+			s.Nodes[name] = status
+		}(name, client)
+	}
+	wg.Wait()
+
+	first, firstFull := true, true
+	for _, name := range s.NodeNames() {
+		n := s.Nodes[name]
+		s.ReserveSize += n.ReserveSize
+		s.BeeModes[n.BeeMode]++
+
+		if first || n.ConnectedPeers < s.MinPeers {
+			s.MinPeers = n.ConnectedPeers
+		}
+		if first || n.ConnectedPeers > s.MaxPeers {
+			s.MaxPeers = n.ConnectedPeers
+		}
+		first = false
+
+		// light and ultra light nodes have no reserve
+		if n.BeeMode != "full" {
+			continue
+		}
+		if firstFull || n.StorageRadius < s.MinStorageRadius {
+			s.MinStorageRadius = n.StorageRadius
+		}
+		if firstFull || n.StorageRadius > s.MaxStorageRadius {
+			s.MaxStorageRadius = n.StorageRadius
+		}
+		firstFull = false
+	}
+
+	return s, nil
+}
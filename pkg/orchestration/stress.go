@@ -0,0 +1,14 @@
+package orchestration
+
+// Stress represents CPU and memory stress of pods of nodes of node groups,
+// injected declaratively by chaos-mesh stress-ng workers until it is removed
+type Stress struct {
+	Name          string   // name of the stress, unique among injected ones
+	NodeGroups    []string // node groups of nodes the stress is injected into, all by default
+	Mode          string   // pods selected, all, the default, one, fixed, fixed-percent or random-max-percent
+	Value         string   // number or percentage of pods of the fixed and percent modes
+	CPUWorkers    int      // workers loading CPU, no CPU stress if 0
+	CPULoad       int      // percentage of CPU loaded by every worker, 100 by default
+	MemoryWorkers int      // workers allocating memory, 1 by default if the memory size is set
+	MemorySize    string   // memory allocated by every worker, like 256MB or 50%
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/bee/pkg/crypto"
+	"github.com/google/uuid"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/crypto/scrypt"
+)
+
+// CreateLibP2PKey generates libp2p key encrypted with the password, in the
+// format of the libp2p_v2.key file of Bee nodes, and returns it with the peer
+// ID of the node, so its underlay address is known before the node starts
+func CreateLibP2PKey(password string) (libP2PKey, peerID string, err error) {
+	k, err := crypto.GenerateSecp256r1Key()
+	if err != nil {
+		return "", "", err
+	}
+
+	data, err := crypto.EncodeSecp256r1PrivateKey(k)
+	if err != nil {
+		return "", "", err
+	}
+	kc, err := encryptData(data, []byte(password))
+	if err != nil {
+		return "", "", err
+	}
+	encrypted, err := json.Marshal(EncryptedKey{
+		Address: hex.EncodeToString(elliptic.Marshal(elliptic.P256(), k.PublicKey.X, k.PublicKey.Y)),
+		Crypto:  *kc,
+		Version: keyVersion,
+		Id:      uuid.NewString(),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	id, err := ecdsaPeerID(k)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(encrypted), id, nil
+}
+
+// LibP2PPeerID returns peer ID of the libp2p key encrypted with the password,
+// both secp256r1 keys of libp2p_v2.key files and legacy secp256k1 keys are
+// supported
+func LibP2PPeerID(libP2PKey, password string) (peerID string, err error) {
+	var k EncryptedKey
+	if err := json.Unmarshal([]byte(libP2PKey), &k); err != nil {
+		return "", fmt.Errorf("unmarshal libp2p key: %w", err)
+	}
+
+	data, err := decryptData(k.Crypto, []byte(password))
+	if err != nil {
+		return "", err
+	}
+
+	if r1, err := x509.ParseECPrivateKey(data); err == nil {
+		return ecdsaPeerID(r1)
+	}
+
+	k1, err := libp2pcrypto.UnmarshalSecp256k1PrivateKey(data)
+	if err != nil {
+		return "", fmt.Errorf("decode libp2p key: %w", err)
+	}
+	id, err := peer.IDFromPrivateKey(k1)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// ecdsaPeerID returns peer ID of the secp256r1 key
+func ecdsaPeerID(k *ecdsa.PrivateKey) (string, error) {
+	_, pub, err := libp2pcrypto.ECDSAKeyPairFromKey(k)
+	if err != nil {
+		return "", err
+	}
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// decryptData decrypts data of the encrypted key, encrypted by encryptData
+func decryptData(kc keyCripto, password []byte) ([]byte, error) {
+	if kc.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported cipher %s", kc.Cipher)
+	}
+	if kc.KDF != keyHeaderKDF {
+		return nil, fmt.Errorf("unsupported KDF %s", kc.KDF)
+	}
+
+	cipherText, err := hex.DecodeString(kc.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("hex decode cipher text: %w", err)
+	}
+	iv, err := hex.DecodeString(kc.CipherParams.IV)
+	if err != nil {
+		return nil, fmt.Errorf("hex decode IV: %w", err)
+	}
+	salt, err := hex.DecodeString(kc.KDFParams.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("hex decode salt: %w", err)
+	}
+	mac, err := hex.DecodeString(kc.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("hex decode MAC: %w", err)
+	}
+
+	derivedKey, err := scrypt.Key(password, salt, kc.KDFParams.N, kc.KDFParams.R, kc.KDFParams.P, kc.KDFParams.DKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	calculatedMAC, err := crypto.LegacyKeccak256(append(derivedKey[16:32], cipherText...))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(calculatedMAC, mac) {
+		return nil, errors.New("invalid password")
+	}
+
+	return aesCTRXOR(derivedKey[:16], cipherText, iv)
+}
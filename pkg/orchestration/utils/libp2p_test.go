@@ -0,0 +1,121 @@
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethersphere/bee/pkg/crypto"
+	"github.com/ethersphere/bee/pkg/keystore/file"
+	"github.com/ethersphere/beekeeper/pkg/orchestration/utils"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const password = "beekeeper"
+
+func TestCreateLibP2PKey(t *testing.T) {
+	key, peerID, err := utils.CreateLibP2PKey(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the key is read by the keystore of Bee nodes from the libp2p_v2.key
+	// file of their data directory
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "libp2p_v2.key"), []byte(key), 0600); err != nil {
+		t.Fatal(err)
+	}
+	ks := file.New(dir)
+
+	k, created, err := ks.Key("libp2p_v2", password, crypto.EDGSecp256_R1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created {
+		t.Fatal("key is created instead of read")
+	}
+	_, pub, err := libp2pcrypto.ECDSAKeyPairFromKey(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.String() != peerID {
+		t.Errorf("got peer ID %s of the node, want %s", id, peerID)
+	}
+
+	if _, _, err := ks.Key("libp2p_v2", "wrong", crypto.EDGSecp256_R1); err == nil {
+		t.Error("key is decrypted with a wrong password")
+	}
+
+	got, err := utils.LibP2PPeerID(key, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != peerID {
+		t.Errorf("got peer ID %s of the key, want %s", got, peerID)
+	}
+}
+
+func TestLibP2PPeerID(t *testing.T) {
+	// keys are written by the keystore of Bee nodes, secp256k1 keys by
+	// versions before libp2p_v2.key files
+	dir := t.TempDir()
+	ks := file.New(dir)
+
+	r1, err := ks.SetKey("libp2p_v2", password, crypto.EDGSecp256_R1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, r1Pub, err := libp2pcrypto.ECDSAKeyPairFromKey(r1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k1, err := ks.SetKey("libp2p", password, crypto.EDGSecp256_K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k1Data, err := crypto.EncodeSecp256k1PrivateKey(k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k1Priv, err := libp2pcrypto.UnmarshalSecp256k1PrivateKey(k1Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		pub  libp2pcrypto.PubKey
+	}{
+		{name: "libp2p_v2", pub: r1Pub},
+		{name: "libp2p", pub: k1Priv.GetPublic()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := os.ReadFile(filepath.Join(dir, tc.name+".key"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := peer.IDFromPublicKey(tc.pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := utils.LibP2PPeerID(string(key), password)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want.String() {
+				t.Errorf("got peer ID %s, want %s", got, want)
+			}
+
+			if _, err := utils.LibP2PPeerID(string(key), "wrong"); err == nil {
+				t.Error("peer ID is returned with a wrong password")
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ethersphere/bee/pkg/crypto"
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// maxMiningAttempts limits number of keys generated while searching for an
+// overlay in a single neighborhood
+const maxMiningAttempts = 1 << 24
+
+// Neighborhoods maps neighborhoods, given as binary overlay prefixes (e.g.
+// "0110"), to the number of nodes whose overlays should fall into them
+type Neighborhoods map[string]int
+
+// Validate checks that all neighborhoods are valid binary prefixes that can
+// be mined and that they fit into the cluster of the given size
+func (n Neighborhoods) Validate(size int) error {
+	total := 0
+	for prefix, count := range n {
+		if len(prefix) == 0 {
+			return fmt.Errorf("empty neighborhood prefix")
+		}
+		if len(prefix) > 24 {
+			return fmt.Errorf("neighborhood %s: prefix longer than 24 bits", prefix)
+		}
+		for _, b := range prefix {
+			if b != '0' && b != '1' {
+				return fmt.Errorf("neighborhood %s: prefix must contain only 0 and 1", prefix)
+			}
+		}
+		if count < 0 {
+			return fmt.Errorf("neighborhood %s: negative node count", prefix)
+		}
+		total += count
+	}
+
+	if total > size {
+		return fmt.Errorf("neighborhoods require %d nodes, cluster size is %d", total, size)
+	}
+
+	return nil
+}
+
+// DepthNeighborhoods returns all neighborhoods at the depth, each with the
+// number of nodes, so every neighborhood of a network at that depth has at
+// least that many nodes
+func DepthNeighborhoods(depth, nodes int) (Neighborhoods, error) {
+	if depth < 1 || depth > 16 {
+		return nil, fmt.Errorf("neighborhood depth %d is not between 1 and 16", depth)
+	}
+
+	n := make(Neighborhoods, 1<<depth)
+	for i := 0; i < 1<<depth; i++ {
+		n[fmt.Sprintf("%0*b", depth, i)] = nodes
+	}
+
+	return n, nil
+}
+
+// MineSwarmKeys generates size Swarm keys encrypted with the password whose
+// overlays are distributed across neighborhoods. Nodes not assigned to any
+// neighborhood get keys with unconstrained overlays. Keys are generated from
+// the seed, so the same arguments always result in the same overlays.
+//
+// Overlays are calculated with a zero nonce, as used by bee nodes that start
+// with an empty state store.
+func MineSwarmKeys(seed int64, size int, networkID uint64, password string, neighborhoods Neighborhoods) (keys []string, overlays []swarm.Address, err error) {
+	if err := neighborhoods.Validate(size); err != nil {
+		return nil, nil, err
+	}
+
+	rnd := random.PseudoGenerator(seed)
+	nonce := make([]byte, 32)
+
+	// iterate in a stable order to keep the result deterministic
+	prefixes := make([]string, 0, len(neighborhoods))
+	for prefix := range neighborhoods {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	add := func(k *ecdsa.PrivateKey, overlay swarm.Address) error {
+		encrypted, err := encryptKey(k, password)
+		if err != nil {
+			return fmt.Errorf("encrypt key: %w", err)
+		}
+		keys = append(keys, string(encrypted))
+		overlays = append(overlays, overlay)
+		return nil
+	}
+
+	for _, prefix := range prefixes {
+		for i := 0; i < neighborhoods[prefix]; i++ {
+			k, overlay, err := mineKey(rnd, networkID, nonce, prefix)
+			if err != nil {
+				return nil, nil, fmt.Errorf("neighborhood %s: %w", prefix, err)
+			}
+			if err := add(k, overlay); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	for len(keys) < size {
+		k, overlay, err := mineKey(rnd, networkID, nonce, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := add(k, overlay); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return keys, overlays, nil
+}
+
+// mineKey generates keys from r until the overlay address starts with prefix
+func mineKey(r io.Reader, networkID uint64, nonce []byte, prefix string) (*ecdsa.PrivateKey, swarm.Address, error) {
+	data := make([]byte, 32)
+	for i := 0; i < maxMiningAttempts; i++ {
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, swarm.ZeroAddress, fmt.Errorf("read random data: %w", err)
+		}
+
+		k, err := crypto.DecodeSecp256k1PrivateKey(data)
+		if err != nil {
+			continue // out of curve range, try next one
+		}
+
+		overlay, err := crypto.NewOverlayAddress(k.PublicKey, networkID, nonce)
+		if err != nil {
+			return nil, swarm.ZeroAddress, fmt.Errorf("overlay address: %w", err)
+		}
+
+		if HasPrefix(overlay, prefix) {
+			return k, overlay, nil
+		}
+	}
+
+	return nil, swarm.ZeroAddress, fmt.Errorf("no overlay found after %d attempts", maxMiningAttempts)
+}
+
+// HasPrefix reports whether the overlay address starts with the binary prefix
+func HasPrefix(overlay swarm.Address, prefix string) bool {
+	b := overlay.Bytes()
+	if len(prefix) > len(b)*8 {
+		return false
+	}
+
+	for i, c := range prefix {
+		bit := b[i/8]>>(7-uint(i%8))&1 == 1
+		if bit != (c == '1') {
+			return false
+		}
+	}
+
+	return true
+}
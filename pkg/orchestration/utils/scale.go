@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ScaleNames returns names of nodes to add to the node group and names of
+// nodes to remove from it, so it has the number of replicas. Added nodes are
+// named after the node group with the lowest free indexes, like nodes of
+// cluster configurations, and the last nodes by name are removed, with longer
+// names last, so bee-10 is removed before bee-9.
+func ScaleNames(group string, names []string, replicas int) (add, remove []string) {
+	if replicas < len(names) {
+		sorted := append([]string(nil), names...)
+		sort.Slice(sorted, func(i, j int) bool {
+			if len(sorted[i]) != len(sorted[j]) {
+				return len(sorted[i]) < len(sorted[j])
+			}
+			return sorted[i] < sorted[j]
+		})
+		for i := len(sorted) - 1; i >= replicas; i-- {
+			remove = append(remove, sorted[i])
+		}
+		return nil, remove
+	}
+
+	existing := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		existing[n] = struct{}{}
+	}
+	for i := 0; len(names)+len(add) < replicas; i++ {
+		name := fmt.Sprintf("%s-%d", group, i)
+		if _, ok := existing[name]; !ok {
+			add = append(add, name)
+		}
+	}
+
+	return add, nil
+}
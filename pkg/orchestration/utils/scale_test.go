@@ -0,0 +1,83 @@
+package utils_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/orchestration/utils"
+)
+
+func TestScaleNames(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		names      []string
+		replicas   int
+		wantAdd    []string
+		wantRemove []string
+	}{
+		{
+			name:     "unchanged",
+			names:    []string{"bee-0", "bee-1"},
+			replicas: 2,
+		},
+		{
+			name:     "grow empty",
+			replicas: 3,
+			wantAdd:  []string{"bee-0", "bee-1", "bee-2"},
+		},
+		{
+			name:     "grow",
+			names:    []string{"bee-0", "bee-1"},
+			replicas: 4,
+			wantAdd:  []string{"bee-2", "bee-3"},
+		},
+		{
+			// the lowest free indexes are used first
+			name:     "grow into gaps",
+			names:    []string{"bee-0", "bee-2", "bee-5"},
+			replicas: 6,
+			wantAdd:  []string{"bee-1", "bee-3", "bee-4"},
+		},
+		{
+			// nodes of the configuration named otherwise are kept
+			name:     "grow with other names",
+			names:    []string{"bootnode", "bee-1"},
+			replicas: 4,
+			wantAdd:  []string{"bee-0", "bee-2"},
+		},
+		{
+			name:       "shrink",
+			names:      []string{"bee-0", "bee-1", "bee-2", "bee-3"},
+			replicas:   2,
+			wantRemove: []string{"bee-3", "bee-2"},
+		},
+		{
+			// longer names are last, so bee-10 is removed before bee-9
+			name:       "shrink by index",
+			names:      []string{"bee-10", "bee-9", "bee-1", "bee-11", "bee-0"},
+			replicas:   2,
+			wantRemove: []string{"bee-11", "bee-10", "bee-9"},
+		},
+		{
+			name:       "shrink to zero",
+			names:      []string{"bee-1", "bee-0"},
+			replicas:   0,
+			wantRemove: []string{"bee-1", "bee-0"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			names := append([]string(nil), tc.names...)
+
+			add, remove := utils.ScaleNames("bee", names, tc.replicas)
+			if !reflect.DeepEqual(add, tc.wantAdd) {
+				t.Errorf("got added nodes %v, want %v", add, tc.wantAdd)
+			}
+			if !reflect.DeepEqual(remove, tc.wantRemove) {
+				t.Errorf("got removed nodes %v, want %v", remove, tc.wantRemove)
+			}
+			if !reflect.DeepEqual(names, tc.names) {
+				t.Errorf("names are modified to %v", names)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+)
+
+// growingUnits are units of measurements that regress when they grow, like
+// durations and costs, measurements in other units, like replication counts
+// or throughput, regress when they shrink
+var growingUnits = map[string]bool{
+	"ns":     true,
+	"us":     true,
+	"µs":     true,
+	"ms":     true,
+	"s":      true,
+	"m":      true,
+	"h":      true,
+	"native": true,
+	"BZZ":    true,
+	"tx":     true,
+}
+
+// Thresholds are the relative changes of measurements against the baseline
+// that are still tolerated, 0.2 tolerates 20% worse measurements
+type Thresholds struct {
+	Default      float64
+	Measurements map[string]float64 // by check and measurement name as check/measurement
+}
+
+// threshold returns the threshold of the check measurement
+func (t Thresholds) threshold(check, measurement string) float64 {
+	if v, ok := t.Measurements[check+"/"+measurement]; ok {
+		return v
+	}
+	return t.Default
+}
+
+// Regression is a measurement that is worse than in the baseline by more
+// than its threshold
+type Regression struct {
+	Check       string
+	Measurement string
+	Unit        string
+	Baseline    float64
+	Current     float64
+	Change      float64 // relative, positive is worse
+	Threshold   float64
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("check %s measurement %s: %g %s, baseline %g %s, %.1f%% worse, threshold %.1f%%",
+		r.Check, r.Measurement, r.Current, r.Unit, r.Baseline, r.Unit, r.Change*100, r.Threshold*100)
+}
+
+// ReadJSON reads the JSON results document written by WriteJSON
+func ReadJSON(r io.Reader) (doc JSONResults, err error) {
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return JSONResults{}, fmt.Errorf("decode json results: %w", err)
+	}
+	if doc.Version > JSONVersion {
+		return JSONResults{}, fmt.Errorf("json results version %d is newer than supported version %d", doc.Version, JSONVersion)
+	}
+	return doc, nil
+}
+
+// Compare compares means of measurements of the results with the same
+// measurements of the baseline and returns regressions that exceed the
+// thresholds. Measurements missing in the baseline are not compared.
+func Compare(baseline JSONResults, results []beekeeper.Result, t Thresholds) (regressions []Regression) {
+	base := make(map[string]map[string][]beekeeper.Measurement)
+	for _, c := range baseline.Checks {
+		base[c.Name] = groupMeasurements(c.Measurements)
+	}
+
+	for _, r := range results {
+		current := groupMeasurements(r.Measurements)
+		names := make([]string, 0, len(current))
+		for name := range current {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			b, ok := base[r.Name][name]
+			if !ok {
+				continue
+			}
+			baseMean, curMean := mean(b), mean(current[name])
+			if baseMean == 0 {
+				continue
+			}
+
+			unit := current[name][0].Unit
+			change := (curMean - baseMean) / math.Abs(baseMean)
+			if !growingUnits[unit] {
+				change = -change
+			}
+
+			threshold := t.threshold(r.Name, name)
+			if change > threshold {
+				regressions = append(regressions, Regression{
+					Check:       r.Name,
+					Measurement: name,
+					Unit:        unit,
+					Baseline:    baseMean,
+					Current:     curMean,
+					Change:      change,
+					Threshold:   threshold,
+				})
+			}
+		}
+	}
+
+	return regressions
+}
+
+// groupMeasurements groups measurements by their name
+func groupMeasurements(measurements []beekeeper.Measurement) map[string][]beekeeper.Measurement {
+	g := make(map[string][]beekeeper.Measurement)
+	for _, m := range measurements {
+		g[m.Name] = append(g[m.Name], m)
+	}
+	return g
+}
+
+// mean returns the mean value of the measurements
+func mean(measurements []beekeeper.Measurement) float64 {
+	var sum float64
+	for _, m := range measurements {
+		sum += m.Value
+	}
+	return sum / float64(len(measurements))
+}
@@ -0,0 +1,48 @@
+package report_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/report"
+)
+
+func TestCompare(t *testing.T) {
+	baseline := report.JSONResults{
+		Checks: []report.JSONCheck{
+			{
+				Name: "smoke",
+				Measurements: []beekeeper.Measurement{
+					{Name: "upload", Value: 1, Unit: "s"},
+					{Name: "upload", Value: 3, Unit: "s"},
+					{Name: "download", Value: 2, Unit: "s"},
+					{Name: "replicas", Value: 4},
+				},
+			},
+		},
+	}
+	results := []beekeeper.Result{
+		{
+			Name: "smoke",
+			Measurements: []beekeeper.Measurement{
+				{Name: "upload", Value: 3, Unit: "s"},       // 50% slower
+				{Name: "download", Value: 2.2, Unit: "s"},   // 10% slower
+				{Name: "replicas", Value: 2},                // 50% fewer
+				{Name: "retrieval", Value: 100, Unit: "ms"}, // not in baseline
+			},
+		},
+	}
+
+	got := report.Compare(baseline, results, report.Thresholds{
+		Default:      0.2,
+		Measurements: map[string]float64{"smoke/replicas": 0.6},
+	})
+
+	want := []report.Regression{
+		{Check: "smoke", Measurement: "upload", Unit: "s", Baseline: 2, Current: 3, Change: 0.5, Threshold: 0.2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got regressions %+v, want %+v", got, want)
+	}
+}
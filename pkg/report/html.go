@@ -0,0 +1,222 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+)
+
+// chart dimensions in pixels
+const (
+	chartBarHeight = 18
+	chartBarGap    = 4
+	chartLabels    = 200
+	chartWidth     = 760
+)
+
+// htmlReport is the data of the HTML report template
+type htmlReport struct {
+	Run    Run
+	Passed int
+	Failed int
+	Chart  chart
+	Checks []htmlCheck
+}
+
+// htmlCheck is the data of a single check in the HTML report
+type htmlCheck struct {
+	beekeeper.Result
+	Chart      chart
+	Statistics []Statistics
+}
+
+// chart is a horizontal bar chart of durations rendered as SVG
+type chart struct {
+	Height int
+	Bars   []bar
+}
+
+// bar is a single bar of a chart
+type bar struct {
+	Label  string
+	Value  string
+	Y      int
+	Width  int
+	Status beekeeper.Status
+}
+
+// Statistics summarizes measurements of a check with the same name
+type Statistics struct {
+	Name  string
+	Unit  string
+	Count int
+	Min   float64
+	P50   float64
+	P90   float64
+	P99   float64
+	Max   float64
+}
+
+// WriteHTML writes the run as a self-contained HTML page with steps,
+// durations, measurement percentiles and failures of every check.
+func WriteHTML(w io.Writer, run Run) error {
+	data := htmlReport{Run: run}
+
+	var checkBars []bar
+	for _, r := range run.Results {
+		switch r.Status {
+		case beekeeper.StatusPassed:
+			data.Passed++
+		case beekeeper.StatusFailed:
+			data.Failed++
+		}
+		checkBars = append(checkBars, bar{Label: r.Name, Status: r.Status, Value: r.Duration.Round(time.Millisecond).String(), Width: int(r.Duration)})
+
+		var stepBars []bar
+		for _, s := range r.Steps {
+			stepBars = append(stepBars, bar{Label: s.Name, Status: s.Status, Value: s.Duration.Round(time.Millisecond).String(), Width: int(s.Duration)})
+		}
+		data.Checks = append(data.Checks, htmlCheck{
+			Result:     r,
+			Chart:      newChart(stepBars),
+			Statistics: MeasurementStatistics(r.Measurements),
+		})
+	}
+	data.Chart = newChart(checkBars)
+
+	if err := htmlTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("execute html template: %w", err)
+	}
+
+	return nil
+}
+
+// newChart returns a chart of the bars with widths relative to the largest
+// one
+func newChart(bars []bar) chart {
+	var max int
+	for _, b := range bars {
+		if b.Width > max {
+			max = b.Width
+		}
+	}
+
+	for i := range bars {
+		bars[i].Y = i * (chartBarHeight + chartBarGap)
+		if max > 0 {
+			bars[i].Width = int(math.Round(float64(bars[i].Width) / float64(max) * (chartWidth - chartLabels - 80)))
+		}
+		if bars[i].Width < 1 {
+			bars[i].Width = 1
+		}
+	}
+
+	return chart{
+		Height: len(bars) * (chartBarHeight + chartBarGap),
+		Bars:   bars,
+	}
+}
+
+// MeasurementStatistics returns percentiles of measurements grouped by their
+// name, in order of the first measurement with the name
+func MeasurementStatistics(measurements []beekeeper.Measurement) (stats []Statistics) {
+	values := make(map[string][]float64)
+	units := make(map[string]string)
+	var names []string
+	for _, m := range measurements {
+		if _, ok := values[m.Name]; !ok {
+			names = append(names, m.Name)
+			units[m.Name] = m.Unit
+		}
+		values[m.Name] = append(values[m.Name], m.Value)
+	}
+
+	for _, name := range names {
+		v := values[name]
+		sort.Float64s(v)
+		stats = append(stats, Statistics{
+			Name:  name,
+			Unit:  units[name],
+			Count: len(v),
+			Min:   v[0],
+			P50:   percentile(v, 50),
+			P90:   percentile(v, 90),
+			P99:   percentile(v, 99),
+			Max:   v[len(v)-1],
+		})
+	}
+
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of the sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"add":        func(a, b int) int { return a + b },
+	"labelX":     func() int { return chartLabels - 8 },
+	"barX":       func() int { return chartLabels },
+	"chartWidth": func() int { return chartWidth },
+	"barHeight":  func() int { return chartBarHeight },
+	"duration":   func(d time.Duration) string { return d.Round(time.Millisecond).String() },
+	"time":       func(t time.Time) string { return t.UTC().Format(time.RFC3339) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Beekeeper run on {{.Run.Cluster}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+.passed { color: #2e7d32; } .failed { color: #c62828; } .skipped { color: #888; }
+rect.passed { fill: #66bb6a; } rect.failed { fill: #ef5350; } rect.skipped { fill: #bdbdbd; }
+pre { background: #f5f5f5; padding: 8px; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Beekeeper run on {{.Run.Cluster}}</h1>
+<p>{{time .Run.Start}} &ndash; {{time .Run.End}}, {{len .Run.Results}} checks, <span class="passed">{{.Passed}} passed</span>, <span class="failed">{{.Failed}} failed</span></p>
+{{define "chart"}}{{if .Bars}}<svg width="{{chartWidth}}" height="{{.Height}}" xmlns="http://www.w3.org/2000/svg" font-size="12">
+{{range .Bars}}<text x="{{labelX}}" y="{{add .Y 13}}" text-anchor="end">{{.Label}}</text>
+<rect class="{{.Status}}" x="{{barX}}" y="{{.Y}}" width="{{.Width}}" height="{{barHeight}}"></rect>
+<text x="{{add (add barX .Width) 4}}" y="{{add .Y 13}}">{{.Value}}</text>
+{{end}}</svg>{{end}}{{end}}
+{{template "chart" .Chart}}
+<table>
+<tr><th>Check</th><th>Status</th><th>Start</th><th>Duration</th></tr>
+{{range .Checks}}<tr><td><a href="#{{.Name}}">{{.Name}}</a></td><td class="{{.Status}}">{{.Status}}</td><td>{{if not .Start.IsZero}}{{time .Start}}{{end}}</td><td>{{duration .Duration}}</td></tr>
+{{end}}</table>
+{{range .Checks}}
+<h2 id="{{.Name}}">{{.Name}} <span class="{{.Status}}">{{.Status}}</span></h2>
+{{if .Error}}<pre>{{.Error}}</pre>{{end}}
+{{if .Steps}}<h3>Steps</h3>
+{{template "chart" .Chart}}
+<table>
+<tr><th>Step</th><th>Status</th><th>Duration</th><th>Error</th></tr>
+{{range .Steps}}<tr><td>{{.Name}}</td><td class="{{.Status}}">{{.Status}}</td><td>{{duration .Duration}}</td><td>{{.Error}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Statistics}}<h3>Measurements</h3>
+<table>
+<tr><th>Name</th><th>Unit</th><th>Count</th><th>Min</th><th>p50</th><th>p90</th><th>p99</th><th>Max</th></tr>
+{{range .Statistics}}<tr><td>{{.Name}}</td><td>{{.Unit}}</td><td>{{.Count}}</td><td>{{.Min}}</td><td>{{.P50}}</td><td>{{.P90}}</td><td>{{.P99}}</td><td>{{.Max}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Artifacts}}<h3>Artifacts</h3>
+<ul>
+{{range .Artifacts}}<li>{{.Name}}: {{.Path}}</li>
+{{end}}</ul>{{end}}
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+)
+
+// InfluxDB measurements the run is written to
+const (
+	influxCheck       = "beekeeper_check"
+	influxStep        = "beekeeper_step"
+	influxMeasurement = "beekeeper_measurement"
+)
+
+var (
+	influxTagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`)
+	influxFieldStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
+
+// WriteInflux writes the run in InfluxDB line protocol with nanosecond
+// precision. Every check is a point of the beekeeper_check measurement, with
+// its steps and measured values as points of the beekeeper_step and
+// beekeeper_measurement measurements, all tagged with the cluster and the
+// check.
+func WriteInflux(w io.Writer, run Run) error {
+	bw := bufio.NewWriter(w)
+
+	for _, r := range run.Results {
+		ts := r.Start
+		if ts.IsZero() {
+			ts = run.End
+		}
+		tags := map[string]string{
+			"cluster": run.Cluster,
+			"check":   r.Name,
+			"type":    run.Types[r.Name],
+		}
+		if len(run.BeeVersions) > 0 {
+			tags["bee_version"] = strings.Join(run.BeeVersions, ",")
+		}
+
+		writeInfluxLine(bw, influxCheck, withTags(tags, "status", string(r.Status)), []influxField{
+			{"duration_seconds", r.Duration.Seconds()},
+			{"passed", boolInt(r.Status == beekeeper.StatusPassed)},
+			{"failed_steps", len(r.Failed())},
+			{"steps", len(r.Steps)},
+			{"error", r.Error},
+		}, ts)
+
+		stepTime := ts
+		for _, s := range r.Steps {
+			writeInfluxLine(bw, influxStep, withTags(tags, "step", s.Name, "status", string(s.Status)), []influxField{
+				{"duration_seconds", s.Duration.Seconds()},
+				{"error", s.Error},
+			}, stepTime)
+			// points with the same series and time overwrite each other
+			stepTime = stepTime.Add(time.Nanosecond)
+		}
+
+		measurementTime := ts
+		for _, m := range r.Measurements {
+			writeInfluxLine(bw, influxMeasurement, withTags(tags, "name", m.Name, "unit", m.Unit), []influxField{
+				{"value", m.Value},
+			}, measurementTime)
+			measurementTime = measurementTime.Add(time.Nanosecond)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// influxField is a field of a line protocol point
+type influxField struct {
+	key   string
+	value interface{}
+}
+
+// writeInfluxLine writes a single line protocol point, leaving out empty tags
+// and empty string fields
+func writeInfluxLine(w *bufio.Writer, measurement string, tags map[string]string, fields []influxField, t time.Time) {
+	w.WriteString(strings.NewReplacer(",", `\,`, " ", `\ `).Replace(measurement))
+
+	keys := make([]string, 0, len(tags))
+	for k, v := range tags {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, ",%s=%s", influxTagEscaper.Replace(k), influxTagEscaper.Replace(tags[k]))
+	}
+
+	sep := " "
+	for _, f := range fields {
+		var v string
+		switch fv := f.value.(type) {
+		case float64:
+			v = strconv.FormatFloat(fv, 'g', -1, 64)
+		case int:
+			v = strconv.Itoa(fv) + "i"
+		case string:
+			if fv == "" {
+				continue
+			}
+			v = `"` + influxFieldStringEscaper.Replace(fv) + `"`
+		}
+		fmt.Fprintf(w, "%s%s=%s", sep, influxTagEscaper.Replace(f.key), v)
+		sep = ","
+	}
+
+	fmt.Fprintf(w, " %d\n", t.UnixNano())
+}
+
+// withTags returns a copy of the tags with added key value pairs
+func withTags(tags map[string]string, kv ...string) map[string]string {
+	t := make(map[string]string, len(tags)+len(kv)/2)
+	for k, v := range tags {
+		t[k] = v
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		t[kv[i]] = kv[i+1]
+	}
+	return t
+}
+
+// boolInt returns 1 for true and 0 for false
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/report"
+)
+
+func TestWriteInflux(t *testing.T) {
+	start := time.Unix(1672628645, 0)
+	run := report.Run{
+		Cluster: "default",
+		End:     start.Add(time.Minute),
+		Types:   map[string]string{"smoke": "smoke"},
+		Results: []beekeeper.Result{
+			{
+				Name:     "smoke",
+				Status:   beekeeper.StatusFailed,
+				Error:    `download "a", b`,
+				Start:    start,
+				Duration: 2 * time.Second,
+				Steps: []beekeeper.Step{
+					{Name: "upload file", Status: beekeeper.StatusPassed, Duration: time.Second},
+				},
+				Measurements: []beekeeper.Measurement{
+					{Name: "download", Value: 1.5, Unit: "s"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteInflux(&buf, run); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `beekeeper_check,check=smoke,cluster=default,status=failed,type=smoke duration_seconds=2,passed=0i,failed_steps=0i,steps=1i,error="download \"a\", b" 1672628645000000000
+beekeeper_step,check=smoke,cluster=default,status=passed,step=upload\ file,type=smoke duration_seconds=1 1672628645000000000
+beekeeper_measurement,check=smoke,cluster=default,name=download,type=smoke,unit=s value=1.5 1672628645000000000
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+)
+
+// JSONVersion is the version of the JSON results document, it changes only
+// when fields are removed or change their meaning
+const JSONVersion = 1
+
+// JSONResults is the JSON results document of a run
+type JSONResults struct {
+	Version     int              `json:"version"`
+	Cluster     string           `json:"cluster"`
+	BeeVersions []string         `json:"beeVersions"`
+	Status      beekeeper.Status `json:"status"`
+	Start       time.Time        `json:"start"`
+	End         time.Time        `json:"end"`
+	Duration    float64          `json:"durationSeconds"`
+	Checks      []JSONCheck      `json:"checks"`
+}
+
+// JSONCheck is the result of a single check in the JSON results document
+type JSONCheck struct {
+	Name         string                  `json:"name"`
+	Type         string                  `json:"type"`
+	Options      interface{}             `json:"options,omitempty"`
+	Status       beekeeper.Status        `json:"status"`
+	Error        string                  `json:"error,omitempty"`
+	Start        *time.Time              `json:"start,omitempty"`
+	Duration     float64                 `json:"durationSeconds"`
+	Steps        []JSONStep              `json:"steps"`
+	Measurements []beekeeper.Measurement `json:"measurements"`
+	Artifacts    []beekeeper.Artifact    `json:"artifacts"`
+	Nodes        []string                `json:"nodes"` // nodes involved in the failure
+}
+
+// JSONStep is the result of a check step in the JSON results document
+type JSONStep struct {
+	Name     string           `json:"name"`
+	Status   beekeeper.Status `json:"status"`
+	Error    string           `json:"error,omitempty"`
+	Duration float64          `json:"durationSeconds"`
+}
+
+// WriteJSON writes the run as the JSON results document. Lists are never
+// null, so consumers do not have to tell missing and empty lists apart.
+func WriteJSON(w io.Writer, run Run) error {
+	doc := JSONResults{
+		Version:     JSONVersion,
+		Cluster:     run.Cluster,
+		BeeVersions: append(make([]string, 0, len(run.BeeVersions)), run.BeeVersions...),
+		Status:      run.Status(),
+		Start:       run.Start,
+		End:         run.End,
+		Duration:    run.End.Sub(run.Start).Seconds(),
+		Checks:      make([]JSONCheck, 0, len(run.Results)),
+	}
+
+	for _, r := range run.Results {
+		check := JSONCheck{
+			Name:         r.Name,
+			Type:         run.Types[r.Name],
+			Options:      run.Options[r.Name],
+			Status:       r.Status,
+			Error:        r.Error,
+			Duration:     r.Duration.Seconds(),
+			Steps:        make([]JSONStep, 0, len(r.Steps)),
+			Measurements: make([]beekeeper.Measurement, 0, len(r.Measurements)),
+			Artifacts:    make([]beekeeper.Artifact, 0, len(r.Artifacts)),
+			Nodes:        make([]string, 0, len(run.Nodes[r.Name])),
+		}
+		if !r.Start.IsZero() {
+			start := r.Start
+			check.Start = &start
+		}
+		for _, s := range r.Steps {
+			check.Steps = append(check.Steps, JSONStep{Name: s.Name, Status: s.Status, Error: s.Error, Duration: s.Duration.Seconds()})
+		}
+		check.Measurements = append(check.Measurements, r.Measurements...)
+		check.Artifacts = append(check.Artifacts, r.Artifacts...)
+		check.Nodes = append(check.Nodes, run.Nodes[r.Name]...)
+
+		doc.Checks = append(doc.Checks, check)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode json results: %w", err)
+	}
+
+	return nil
+}
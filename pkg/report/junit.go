@@ -0,0 +1,145 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite reports a single check
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+// junitProperty reports a measurement or an artifact of a check
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitTestCase reports a step of a check, or the whole check if it has no
+// steps
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+// junitMessage is a failure or a skip reason
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the run as JUnit XML. Every check is a test suite with
+// its steps as test cases, a check without steps is a single test case.
+func WriteJUnit(w io.Writer, run Run) error {
+	suites := junitTestSuites{
+		Name: run.Cluster,
+		Time: seconds(run.End.Sub(run.Start)),
+	}
+
+	for _, r := range run.Results {
+		suite := junitSuite(r)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return fmt.Errorf("encode junit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitSuite returns the test suite of the check result
+func junitSuite(r beekeeper.Result) junitTestSuite {
+	suite := junitTestSuite{
+		Name: r.Name,
+		Time: seconds(r.Duration),
+	}
+	if !r.Start.IsZero() {
+		suite.Timestamp = r.Start.UTC().Format(time.RFC3339)
+	}
+	for _, m := range r.Measurements {
+		suite.Properties = append(suite.Properties, junitProperty{Name: m.Name, Value: fmt.Sprintf("%g %s", m.Value, m.Unit)})
+	}
+	for _, a := range r.Artifacts {
+		suite.Properties = append(suite.Properties, junitProperty{Name: a.Name, Value: a.Path})
+	}
+
+	if len(r.Steps) == 0 {
+		suite.Cases = append(suite.Cases, junitCase(r.Name, r.Name, r.Status, r.Error, r.Duration))
+	}
+	for _, s := range r.Steps {
+		suite.Cases = append(suite.Cases, junitCase(r.Name, s.Name, s.Status, s.Error, s.Duration))
+	}
+
+	// a check can fail outside of its steps
+	if r.Status == beekeeper.StatusFailed && len(r.Steps) > 0 && len(r.Failed()) == 0 {
+		suite.Cases = append(suite.Cases, junitCase(r.Name, r.Name, r.Status, r.Error, r.Duration))
+	}
+
+	for _, c := range suite.Cases {
+		suite.Tests++
+		if c.Failure != nil {
+			suite.Failures++
+		}
+		if c.Skipped != nil {
+			suite.Skipped++
+		}
+	}
+
+	return suite
+}
+
+// junitCase returns the test case of a check or of its step
+func junitCase(className, name string, status beekeeper.Status, msg string, d time.Duration) junitTestCase {
+	c := junitTestCase{
+		Name:      name,
+		ClassName: className,
+		Time:      seconds(d),
+	}
+	switch status {
+	case beekeeper.StatusFailed:
+		c.Failure = &junitMessage{Message: msg, Text: msg}
+	case beekeeper.StatusSkipped:
+		c.Skipped = &junitMessage{Message: msg}
+	}
+	return c
+}
+
+// seconds formats the duration in seconds as JUnit expects it
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,64 @@
+package report_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/report"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	run := report.Run{
+		Cluster: "default",
+		Start:   start,
+		End:     start.Add(3 * time.Second),
+		Results: []beekeeper.Result{
+			{
+				Name:     "pingpong",
+				Status:   beekeeper.StatusPassed,
+				Start:    start,
+				Duration: time.Second,
+			},
+			{
+				Name:     "pushsync",
+				Status:   beekeeper.StatusFailed,
+				Error:    "chunk not replicated",
+				Start:    start,
+				Duration: 2 * time.Second,
+				Steps: []beekeeper.Step{
+					{Name: "upload", Status: beekeeper.StatusPassed, Duration: time.Second},
+					{Name: "replicate", Status: beekeeper.StatusFailed, Error: "chunk not replicated", Duration: time.Second},
+				},
+			},
+			{
+				Name:   "retrieval",
+				Status: beekeeper.StatusSkipped,
+				Error:  "skipped due to dependency failure: pushsync",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteJUnit(&buf, run); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+
+	for _, want := range []string{
+		`<testsuites name="default" tests="4" failures="1" skipped="1" time="3.000">`,
+		`<testsuite name="pingpong" tests="1" failures="0" skipped="0" time="1.000" timestamp="2023-01-02T03:04:05Z">`,
+		`<testcase name="pingpong" classname="pingpong" time="1.000"></testcase>`,
+		`<testsuite name="pushsync" tests="2" failures="1" skipped="0" time="2.000" timestamp="2023-01-02T03:04:05Z">`,
+		`<testcase name="upload" classname="pushsync" time="1.000"></testcase>`,
+		`<failure message="chunk not replicated">chunk not replicated</failure>`,
+		`<skipped message="skipped due to dependency failure: pushsync"></skipped>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report does not contain %s:\n%s", want, got)
+		}
+	}
+}
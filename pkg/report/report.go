@@ -0,0 +1,32 @@
+// Package report writes reports of check runs in formats consumed by CI
+// systems and people.
+package report
+
+import (
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+)
+
+// Run represents a check run
+type Run struct {
+	Cluster     string
+	BeeVersions []string // distinct versions of the cluster nodes
+	Start       time.Time
+	End         time.Time
+	Results     []beekeeper.Result
+	Types       map[string]string      // check types by check name
+	Options     map[string]interface{} // check options by check name
+	Nodes       map[string][]string    // nodes involved in failed checks by check name
+	Regressions []Regression           // regressions against the baseline run
+}
+
+// Status returns failed if any of the checks failed, passed otherwise
+func (r Run) Status() beekeeper.Status {
+	for _, res := range r.Results {
+		if res.Status == beekeeper.StatusFailed {
+			return beekeeper.StatusFailed
+		}
+	}
+	return beekeeper.StatusPassed
+}
@@ -0,0 +1,141 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	// drivers of results databases, postgres and sqlite
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// sqlSchema creates the results tables, statements are valid in both
+// Postgres and SQLite
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS beekeeper_runs (
+		id TEXT PRIMARY KEY,
+		cluster TEXT NOT NULL,
+		bee_version TEXT NOT NULL,
+		status TEXT NOT NULL,
+		start_time TIMESTAMP NOT NULL,
+		end_time TIMESTAMP NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS beekeeper_checks (
+		run_id TEXT NOT NULL REFERENCES beekeeper_runs (id),
+		name TEXT NOT NULL,
+		type TEXT NOT NULL,
+		status TEXT NOT NULL,
+		error TEXT NOT NULL,
+		start_time TIMESTAMP,
+		duration_seconds DOUBLE PRECISION NOT NULL,
+		PRIMARY KEY (run_id, name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS beekeeper_measurements (
+		run_id TEXT NOT NULL REFERENCES beekeeper_runs (id),
+		check_name TEXT NOT NULL,
+		name TEXT NOT NULL,
+		value DOUBLE PRECISION NOT NULL,
+		unit TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS beekeeper_measurements_name ON beekeeper_measurements (check_name, name)`,
+}
+
+// SQLStore persists results of runs to a SQL database
+type SQLStore struct {
+	db     *sql.DB
+	dollar bool // placeholders are $1, $2, ... instead of ?
+}
+
+// NewSQLStore opens the results database with the postgres or sqlite
+// database/sql driver and creates the results tables if they do not exist
+func NewSQLStore(ctx context.Context, driver, dsn string) (*SQLStore, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open results database: %w", err)
+	}
+
+	s := &SQLStore{
+		db:     db,
+		dollar: driver == "postgres" || driver == "pgx",
+	}
+
+	for _, stmt := range sqlSchema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("create results tables: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// Save stores the run with outcomes and measurements of all its checks in a
+// single transaction and returns the id of the run
+func (s *SQLStore) Save(ctx context.Context, run Run) (id string, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	id = uuid.NewString()
+	if _, err := tx.ExecContext(ctx, s.query(`INSERT INTO beekeeper_runs (id, cluster, bee_version, status, start_time, end_time) VALUES (?, ?, ?, ?, ?, ?)`),
+		id, run.Cluster, strings.Join(run.BeeVersions, ","), string(run.Status()), run.Start.UTC(), run.End.UTC()); err != nil {
+		return "", fmt.Errorf("insert run: %w", err)
+	}
+
+	for _, r := range run.Results {
+		var start interface{}
+		if !r.Start.IsZero() {
+			start = r.Start.UTC()
+		}
+		if _, err := tx.ExecContext(ctx, s.query(`INSERT INTO beekeeper_checks (run_id, name, type, status, error, start_time, duration_seconds) VALUES (?, ?, ?, ?, ?, ?, ?)`),
+			id, r.Name, run.Types[r.Name], string(r.Status), r.Error, start, r.Duration.Seconds()); err != nil {
+			return "", fmt.Errorf("insert check %s: %w", r.Name, err)
+		}
+
+		for _, m := range r.Measurements {
+			if _, err := tx.ExecContext(ctx, s.query(`INSERT INTO beekeeper_measurements (run_id, check_name, name, value, unit) VALUES (?, ?, ?, ?, ?)`),
+				id, r.Name, m.Name, m.Value, m.Unit); err != nil {
+				return "", fmt.Errorf("insert check %s measurement %s: %w", r.Name, m.Name, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return id, nil
+}
+
+// Close closes the results database
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+// query rewrites ? placeholders of the query for the database driver
+func (s *SQLStore) query(q string) string {
+	if !s.dollar {
+		return q
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range q {
+		if r == '?' {
+			n++
+			fmt.Fprintf(&b, "$%d", n)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package report_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/report"
+)
+
+func TestSQLStore(t *testing.T) {
+	ctx := context.Background()
+	dsn := filepath.Join(t.TempDir(), "results.db")
+
+	s, err := report.NewSQLStore(ctx, "sqlite", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Unix(1672628645, 0)
+	id, err := s.Save(ctx, report.Run{
+		Cluster:     "default",
+		BeeVersions: []string{"1.13.0"},
+		Start:       start,
+		End:         start.Add(time.Minute),
+		Types:       map[string]string{"smoke": "smoke", "pingpong": "ping"},
+		Results: []beekeeper.Result{
+			{
+				Name:     "smoke",
+				Status:   beekeeper.StatusFailed,
+				Error:    "download failed",
+				Start:    start,
+				Duration: 2 * time.Second,
+				Measurements: []beekeeper.Measurement{
+					{Name: "upload", Value: 0.5, Unit: "s"},
+					{Name: "download", Value: 1.5, Unit: "s"},
+				},
+			},
+			{Name: "pingpong", Status: beekeeper.StatusPassed, Duration: time.Second},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the store reopens the existing tables
+	s, err = report.NewSQLStore(ctx, "sqlite", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var cluster, version, status string
+	if err := db.QueryRow(`SELECT cluster, bee_version, status FROM beekeeper_runs WHERE id = ?`, id).Scan(&cluster, &version, &status); err != nil {
+		t.Fatal(err)
+	}
+	if cluster != "default" || version != "1.13.0" || status != string(beekeeper.StatusFailed) {
+		t.Errorf("got run %s %s %s, want default 1.13.0 failed", cluster, version, status)
+	}
+
+	var checkType, checkStatus, checkError string
+	var duration float64
+	if err := db.QueryRow(`SELECT type, status, error, duration_seconds FROM beekeeper_checks WHERE run_id = ? AND name = 'smoke'`, id).Scan(&checkType, &checkStatus, &checkError, &duration); err != nil {
+		t.Fatal(err)
+	}
+	if checkType != "smoke" || checkStatus != string(beekeeper.StatusFailed) || checkError != "download failed" || duration != 2 {
+		t.Errorf("got check %s %s %q %v, want smoke failed \"download failed\" 2", checkType, checkStatus, checkError, duration)
+	}
+
+	var checks, measurements int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM beekeeper_checks WHERE run_id = ?`, id).Scan(&checks); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM beekeeper_measurements WHERE run_id = ? AND check_name = 'smoke'`, id).Scan(&measurements); err != nil {
+		t.Fatal(err)
+	}
+	if checks != 2 || measurements != 2 {
+		t.Errorf("got %d checks and %d measurements, want 2 and 2", checks, measurements)
+	}
+}
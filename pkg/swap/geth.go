@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/ethersphere/beekeeper/pkg/logging"
@@ -222,6 +223,39 @@ func (g *GethClient) AttestOverlayEthAddress(ctx context.Context, ethAddr string
 	return resp.Result, nil
 }
 
+// TransactionCount returns number of transactions sent from the address,
+// which is its nonce
+func (g *GethClient) TransactionCount(ctx context.Context, address string) (count uint64, err error) {
+	req := struct {
+		ID      string
+		JsonRPC string
+		Method  string
+		Params  []string
+	}{
+		ID:      "0",
+		JsonRPC: "1.0",
+		Method:  "eth_getTransactionCount",
+		Params:  []string{address, "latest"},
+	}
+
+	resp := new(struct {
+		ID      string `json:"id"`
+		JsonRPC string `json:"jsonrpc"`
+		Result  string `json:"result"`
+	})
+
+	if err := requestJSON(ctx, g.httpClient, http.MethodPost, "/", req, &resp); err != nil {
+		return 0, err
+	}
+
+	count, err = strconv.ParseUint(strings.TrimPrefix(resp.Result, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse transaction count %q: %w", resp.Result, err)
+	}
+
+	return count, nil
+}
+
 // ethAccounts returns list of accounts
 func (g *GethClient) ethAccounts(ctx context.Context) (a []string, err error) {
 	req := ethRequest{
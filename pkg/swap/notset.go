@@ -31,3 +31,8 @@ func (n *NotSet) SendGBZZ(ctx context.Context, to string, amount float64) (tx st
 func (n *NotSet) AttestOverlayEthAddress(ctx context.Context, ethAddr string) (tx string, err error) {
 	return "", ErrNotSet
 }
+
+// TransactionCount returns number of transactions sent from the address
+func (n *NotSet) TransactionCount(ctx context.Context, address string) (count uint64, err error) {
+	return 0, ErrNotSet
+}
@@ -20,4 +20,5 @@ type Client interface {
 	SendBZZ(ctx context.Context, to string, amount float64) (tx string, err error)
 	SendGBZZ(ctx context.Context, to string, amount float64) (tx string, err error)
 	AttestOverlayEthAddress(ctx context.Context, ethAddr string) (tx string, err error)
+	TransactionCount(ctx context.Context, address string) (count uint64, err error)
 }